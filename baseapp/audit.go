@@ -0,0 +1,143 @@
+package baseapp
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ModuleWiring is what a module expects of the wiring of the app: the stores
+// it reads and writes, its msgs, whose routes must have a handler, the routes
+// of its queriers, and the registration of its types in the codec.
+type ModuleWiring struct {
+	Name          string
+	StoreKeys     []sdk.StoreKey
+	Msgs          []sdk.Msg
+	QuerierRoutes []string
+	RegisterCodec func(cdc *codec.Codec) // may be nil
+}
+
+// WiringIssue is a mistake in the wiring of a module.
+type WiringIssue struct {
+	Module  string
+	Problem string
+}
+
+// WiringReport is the result of AuditModuleWiring, in the order the modules
+// and their wiring are declared.
+type WiringReport []WiringIssue
+
+// OK reports whether the wiring has no issue.
+func (r WiringReport) OK() bool {
+	return len(r) == 0
+}
+
+func (r WiringReport) String() string {
+	var sb strings.Builder
+	for _, issue := range r {
+		fmt.Fprintf(&sb, "%s: %s\n", issue.Module, issue.Problem)
+	}
+	return sb.String()
+}
+
+// AuditModuleWiring checks the wiring of the app against the expectations of
+// its modules: every store key is mounted, every msg is registered in the
+// codec of its module and routed to a handler, every querier route is
+// registered, and no two modules share a store key, a store name or the
+// prefix of a registered type. It reports every issue found, so the app can
+// refuse to start instead of failing at the first tx reaching a bad wiring.
+//
+// It must be called once the stores are loaded.
+func (app *BaseApp) AuditModuleWiring(modules ...ModuleWiring) WiringReport {
+	var report WiringReport
+	addIssue := func(module, format string, args ...interface{}) {
+		report = append(report, WiringIssue{Module: module, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	storeNames := make(map[string]string)
+	typePrefixes := make(map[string]string)
+	for _, module := range modules {
+		for _, key := range module.StoreKeys {
+			if owner, ok := storeNames[key.Name()]; ok {
+				addIssue(module.Name, "store %s is also used by module %s", key.Name(), owner)
+			} else {
+				storeNames[key.Name()] = module.Name
+			}
+			if app.cms.GetCommitStore(key) == nil {
+				addIssue(module.Name, "store %s is not mounted", key.Name())
+			}
+		}
+
+		registered := make(map[string]bool)
+		if module.RegisterCodec != nil {
+			types, err := registeredTypes(module.RegisterCodec)
+			if err != nil {
+				addIssue(module.Name, "cannot list the types of the codec: %v", err)
+			}
+			for _, t := range types {
+				registered[t.goType] = true
+				if owner, ok := typePrefixes[t.prefix]; ok {
+					addIssue(module.Name, "type %s has the codec prefix %s of a type of module %s", t.name, t.prefix, owner)
+				} else {
+					typePrefixes[t.prefix] = module.Name
+				}
+			}
+		}
+
+		for _, msg := range module.Msgs {
+			msgType := reflect.Indirect(reflect.ValueOf(msg)).Type().Name()
+			if module.RegisterCodec != nil && !registered[msgType] {
+				addIssue(module.Name, "msg %s is not registered in the codec", msgType)
+			}
+			if app.router.Route(msg.Route()) == nil {
+				addIssue(module.Name, "msg %s has no handler for its route %s", msgType, msg.Route())
+			}
+		}
+
+		for _, route := range module.QuerierRoutes {
+			if app.queryRouter.Route(route) == nil {
+				addIssue(module.Name, "querier route %s is not registered", route)
+			}
+		}
+	}
+	return report
+}
+
+// registeredType is a concrete type registered in a codec.
+type registeredType struct {
+	goType string
+	name   string
+	prefix string
+}
+
+// registeredTypes returns the concrete types registerCodec registers in a
+// new codec, as listed by the codec.
+func registeredTypes(registerCodec func(cdc *codec.Codec)) ([]registeredType, error) {
+	cdc := codec.New()
+	registerCodec(cdc)
+	var table bytes.Buffer
+	if err := cdc.PrintTypes(&table); err != nil {
+		return nil, err
+	}
+
+	var types []registeredType
+	// the first two lines are the header of the table, the columns of the
+	// rows are the go type, the name and the prefix
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	for _, line := range lines[2:] {
+		columns := strings.Split(line, "|")
+		if len(columns) < 4 {
+			return nil, fmt.Errorf("unexpected row %q", line)
+		}
+		types = append(types, registeredType{
+			goType: strings.TrimSpace(columns[1]),
+			name:   strings.TrimSpace(columns[2]),
+			prefix: strings.TrimSpace(columns[3]),
+		})
+	}
+	return types, nil
+}
@@ -0,0 +1,51 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAuditModuleWiring(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+		bapp.QueryRouter().AddRoute("counter", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			return nil, nil
+		})
+	}
+	app := setupBaseApp(t, routerOpt)
+
+	counter := ModuleWiring{
+		Name:          "counter",
+		StoreKeys:     []sdk.StoreKey{capKey1},
+		Msgs:          []sdk.Msg{msgCounter{}},
+		QuerierRoutes: []string{"counter"},
+		RegisterCodec: registerTestCodec,
+	}
+	require.True(t, app.AuditModuleWiring(counter).OK())
+
+	broken := ModuleWiring{
+		Name:          "broken",
+		StoreKeys:     []sdk.StoreKey{capKey2, sdk.NewKVStoreKey("key1"), sdk.NewKVStoreKey("key3")},
+		Msgs:          []sdk.Msg{msgCounter2{}},
+		QuerierRoutes: []string{"counter2"},
+		RegisterCodec: func(cdc *codec.Codec) {
+			cdc.RegisterConcrete(&msgNoRoute{}, "cosmos-sdk/baseapp/msgCounter", nil)
+		},
+	}
+	report := app.AuditModuleWiring(counter, broken)
+	require.Equal(t, WiringReport{
+		{"broken", "store key1 is also used by module counter"},
+		{"broken", "store key1 is not mounted"},
+		{"broken", "store key3 is not mounted"},
+		{"broken", "type cosmos-sdk/baseapp/msgCounter has the codec prefix 0x35121BEA of a type of module counter"},
+		{"broken", "msg msgCounter2 is not registered in the codec"},
+		{"broken", "msg msgCounter2 has no handler for its route msgCounter2"},
+		{"broken", "querier route counter2 is not registered"},
+	}, report)
+	require.Contains(t, report.String(), "broken: store key3 is not mounted\n")
+}
@@ -1,27 +1,33 @@
 package baseapp
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/snapshot"
+	sm "github.com/tendermint/tendermint/state"
 	tmstore "github.com/tendermint/tendermint/store"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -58,6 +64,16 @@ type BaseApp struct {
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 	preChecker  sdk.PreChecker
+	txPriority  sdk.TxPriorityHandler
+
+	// run around the handlers of the msgs, the first added outermost
+	msgInterceptors []sdk.MsgInterceptor
+
+	// gas metering of the store operations, a limit of 0 is unlimited
+	txGasLimit         sdk.Gas
+	blockGasLimit      sdk.Gas
+	kvGasConfig        sdk.GasConfig
+	transientGasConfig sdk.GasConfig
 
 	// may be nil
 	initChainer      sdk.InitChainer  // initialize state with validators and state blob
@@ -75,12 +91,30 @@ type BaseApp struct {
 	DeliverState *state // for DeliverTx
 
 	AccountStoreCache sdk.AccountStoreCache
+	// the account store and the codec of its cache, to cache the accounts of
+	// a past version
+	accountStore    sdk.KVStore
+	accountCdc      *codec.Codec
+	accountCacheCap int
 	txMsgCache        *lru.Cache
 	Pool              *sdk.Pool
 
 	// Snapshot for state sync related fields
 	StateSyncHelper *store.StateSyncHelper // manage state sync related status
 
+	// the metrics of the txs, no-ops unless the telemetry is enabled, and
+	// whether to observe the latency of the store accesses of the txs
+	metrics      *telemetry.Metrics
+	timeStoreOps bool
+
+	// may be nil, indexes the delivered txs for the app tx search
+	txIndexer TxIndexer
+
+	// the queries hold the read lock of queryLock, the writes of the committed
+	// multistore hold its write lock, so the queries do not wait on the txs
+	queryLock     sync.RWMutex
+	querySnapshot *querySnapshot // nil before the first commit
+
 	// flag for sealing
 	sealed bool
 }
@@ -113,6 +147,12 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		collect:     collectConfig,
 		txMsgCache:  cache,
 		Pool:        new(sdk.Pool),
+
+		kvGasConfig:        sdk.KVGasConfig(),
+		transientGasConfig: sdk.TransientGasConfig(),
+
+		metrics:      telemetry.Current(),
+		timeStoreOps: telemetry.Enabled(),
 	}
 
 	sdk.UpgradeMgr.AddConfig(sdk.MainNetConfig) // TODO: make this configurable
@@ -301,6 +341,7 @@ func (app *BaseApp) SetCheckState(header abci.Header) {
 		AccountCache: accountCache,
 		Ctx:          sdk.NewContext(ms, header, sdk.RunTxModeCheck, app.Logger).WithAccountCache(accountCache),
 	}
+	app.setQuerySnapshot(header)
 }
 
 func (app *BaseApp) SetDeliverState(header abci.Header) {
@@ -316,6 +357,42 @@ func (app *BaseApp) SetDeliverState(header abci.Header) {
 
 func (app *BaseApp) SetAccountStoreCache(cdc *codec.Codec, accountStore sdk.KVStore, cap int) {
 	app.AccountStoreCache = auth.NewAccountStoreCache(cdc, accountStore, cap)
+	app.accountStore = accountStore
+	app.accountCdc = cdc
+	app.accountCacheCap = cap
+}
+
+// versionedAccountCache returns an account cache over the account store of
+// ms, a cache wrap of the persisted version of the multistore.
+func (app *BaseApp) versionedAccountCache(ms sdk.CacheMultiStore) (sdk.AccountCache, error) {
+	accountStoreCache, err := app.versionedAccountStoreCache(ms)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewAccountCache(accountStoreCache), nil
+}
+
+// versionedAccountStoreCache returns an account store cache over the account
+// store of ms, a cache wrap of the persisted version of the multistore.
+func (app *BaseApp) versionedAccountStoreCache(ms sdk.CacheMultiStore) (sdk.AccountStoreCache, error) {
+	if app.accountStore == nil {
+		return nil, fmt.Errorf("no account store cache is set")
+	}
+	// the account store may be traced by the multistore
+	accountStore := app.accountStore
+	for {
+		traced, ok := accountStore.(*store.TraceKVStore)
+		if !ok {
+			break
+		}
+		accountStore = traced.Parent()
+	}
+	for key, commitStore := range app.cms.GetCommitKVStores() {
+		if commitStore == accountStore {
+			return auth.NewAccountStoreCache(app.accountCdc, ms.GetKVStore(key), app.accountCacheCap), nil
+		}
+	}
+	return nil, fmt.Errorf("the account store is not mounted")
 }
 
 //______________________________________________________________________________
@@ -388,6 +465,9 @@ func SplitPath(requestPath string) (path []string) {
 // Implements ABCI.
 // Delegates to CommitMultiStore if it implements Queryable
 func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	app.queryLock.RLock()
+	defer app.queryLock.RUnlock()
+
 	path := SplitPath(req.Path)
 	if len(path) == 0 {
 		msg := "no query path provided"
@@ -421,6 +501,16 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 			} else {
 				result = app.Simulate(txBytes, tx)
 			}
+		case "simulate_tx":
+			txBytes := req.Data
+			tx, err := app.TxDecoder(txBytes)
+			if err != nil {
+				return err.QueryResult()
+			}
+			return abci.ResponseQuery{
+				Code:  uint32(sdk.ABCICodeOK),
+				Value: codec.Cdc.MustMarshalBinaryLengthPrefixed(app.SimulateTx(txBytes, tx)),
+			}
 		case "version":
 			return abci.ResponseQuery{
 				Code:  uint32(sdk.ABCICodeOK),
@@ -437,7 +527,7 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 			Value: value,
 		}
 	}
-	msg := "Expected second parameter to be either simulate or version, neither was present"
+	msg := "Expected second parameter to be either simulate, simulate_tx or version, neither was present"
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
@@ -486,8 +576,40 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest("no custom querier found for route " + path[1]).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
-	ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	height := req.Height
+	if req.Prove && height == 0 {
+		// like the store queries, prove the last height whose app hash is
+		// already in a header
+		height = app.LastBlockHeight()
+		if height > 1 {
+			height--
+		}
+	}
+
+	var ctx sdk.Context
+	var recorder *store.QueryReadRecorder
+	if req.Prove || (height > 0 && height != app.LastBlockHeight()) {
+		// the querier reads the state of a past height
+		ms, err := app.cms.CacheMultiStoreWithVersion(height)
+		if err != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("cannot query height %d: %v", height, err)).QueryResult()
+		}
+		if req.Prove {
+			recorder = store.NewQueryReadRecorder()
+			ms = readRecordMultiStore{ms, recorder}
+		}
+		accountCache, err := app.versionedAccountCache(ms)
+		if err != nil {
+			return sdk.ErrInternal(err.Error()).QueryResult()
+		}
+		header := abci.Header{ChainID: app.CheckState.Ctx.ChainID(), Height: height}
+		ctx = sdk.NewContext(ms, header, sdk.RunTxModeCheck, app.Logger).WithAccountCache(accountCache)
+	} else if app.querySnapshot != nil {
+		ctx = app.querySnapshot.context(app)
+	} else {
+		ctx = sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
+		ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	}
 
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
@@ -498,10 +620,63 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 			Log:  err.ABCILog(),
 		}
 	}
-	return abci.ResponseQuery{
+	res = abci.ResponseQuery{
 		Code:  uint32(sdk.ABCICodeOK),
 		Value: resBytes,
 	}
+	if recorder != nil {
+		proof, err := app.proveQueryReads(recorder.Reads(), height)
+		if err != nil {
+			return err.QueryResult()
+		}
+		res.Proof = proof
+		res.Height = height
+	}
+	return res
+}
+
+// proveQueryReads proves the reads of a custom querier at height with the
+// store queries of the reads.
+func (app *BaseApp) proveQueryReads(reads []store.QueryRead, height int64) (*merkle.Proof, sdk.Error) {
+	queryable, ok := app.cms.(sdk.Queryable)
+	if !ok {
+		return nil, sdk.ErrUnknownRequest("multistore doesn't support queries")
+	}
+	proof := &merkle.Proof{Ops: make([]merkle.ProofOp, 0, len(reads))}
+	for _, read := range reads {
+		res := queryable.Query(abci.RequestQuery{
+			Path:   "/" + read.Store + read.Subpath,
+			Data:   read.Key,
+			Height: height,
+			Prove:  true,
+		})
+		if !res.IsOK() || res.Proof == nil {
+			return nil, sdk.ErrInternal(fmt.Sprintf("cannot prove the read of %s: %s", read.Path(), res.Log))
+		}
+		read.Value, read.Proof = res.Value, res.Proof
+		proof.Ops = append(proof.Ops, read.ProofOp())
+	}
+	return proof, nil
+}
+
+// readRecordMultiStore records the reads made on the KVStores it hands out,
+// and on those of its cache wraps. The transient stores are not recorded,
+// they are not part of the state.
+type readRecordMultiStore struct {
+	parentMultiStore
+	recorder *store.QueryReadRecorder
+}
+
+func (ms readRecordMultiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
+	kvStore := ms.parentMultiStore.GetKVStore(key)
+	if _, ok := key.(*sdk.KVStoreKey); !ok {
+		return kvStore
+	}
+	return ms.recorder.KVStore(key.Name(), kvStore)
+}
+
+func (ms readRecordMultiStore) CacheMultiStore() sdk.CacheMultiStore {
+	return readRecordMultiStore{ms.parentMultiStore.CacheMultiStore(), ms.recorder}
 }
 
 // BeginBlock implements the ABCI application interface.
@@ -527,8 +702,17 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockHash(req.Hash).WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// the txs of the block share its gas limit
+	var blockGasMeter sdk.GasMeter
+	if sdk.IsUpgrade(sdk.GasMetering) && app.blockGasLimit > 0 {
+		blockGasMeter = sdk.NewGasMeter(app.blockGasLimit)
+	}
+	app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockGasMeter(blockGasMeter)
+
 	if app.beginBlocker != nil {
-		res = app.beginBlocker(app.DeliverState.Ctx, req)
+		ctx := app.DeliverState.Ctx.WithEventManager(sdk.NewEventManager())
+		res = app.beginBlocker(ctx, req)
+		res.Events = append(res.Events, ctx.EventManager().ABCIEvents()...)
 	}
 
 	return
@@ -568,9 +752,9 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		app.Logger.Debug("Handle CheckTx", "Tx", txHash)
 		result = app.RunTx(sdk.RunTxModeCheckAfterPre, tx, txHash)
 	} else {
-		tx, err := app.TxDecoder(txBytes)
-		if err != nil {
-			result = err.Result()
+		var err sdk.Error
+		if tx, err = app.TxDecoder(txBytes); err != nil {
+			tx, result = nil, err.Result()
 		} else {
 			app.txMsgCache.Add(string(txBytes), tx) // for recheck
 			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
@@ -584,10 +768,12 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 	}
 
 	return abci.ResponseCheckTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    app.withPriorityEvent(result, tx),
 	}
 }
 
@@ -612,10 +798,12 @@ func (app *BaseApp) preCheck(txBytes []byte, mode sdk.RunTxMode) sdk.Result {
 func (app *BaseApp) PreCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
 	result := app.preCheck(req.Tx, sdk.RunTxModeCheck)
 	return abci.ResponseCheckTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    result.GetEvents(),
 	}
 }
 
@@ -623,6 +811,7 @@ func (app *BaseApp) PreCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckT
 // ReCheckTx runs the "minimun checks", after the inital check,
 // to see whether or not a transaction can possibly be executed.
 func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
+	defer observeSince(app.metrics.RecheckTime, time.Now())
 	// Decode the Tx.
 	var result sdk.Result
 	txBytes := req.Tx
@@ -630,19 +819,21 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 	if ok {
 		result = app.ReRunTx(txBytes, tx)
 	} else { // not suppose to enter here actually
-		var tx, err = app.TxDecoder(txBytes)
-		if err != nil {
-			result = err.Result()
+		var err sdk.Error
+		if tx, err = app.TxDecoder(txBytes); err != nil {
+			tx, result = nil, err.Result()
 		} else {
 			result = app.ReRunTx(txBytes, tx)
 		}
 	}
 
 	return abci.ResponseCheckTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    app.withPriorityEvent(result, tx),
 	}
 }
 
@@ -659,9 +850,9 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 		app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
 		result = app.RunTx(sdk.RunTxModeDeliverAfterPre, tx, txHash)
 	} else {
-		var tx, err = app.TxDecoder(txBytes)
-		if err != nil {
-			result = err.Result()
+		var err sdk.Error
+		if tx, err = app.TxDecoder(txBytes); err != nil {
+			tx, result = nil, err.Result()
 		} else {
 			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 			app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
@@ -671,13 +862,18 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 
 	// Even though the Result.Code is not OK, there are still effects,
 	// namely fee deductions and sequence incrementing.
+	if app.txIndexer != nil {
+		app.txIndexer.IndexTx(app.DeliverState.Ctx.BlockHeight(), txBytes, tx, result)
+	}
 
 	// Tell the blockchain engine (i.e. Tendermint).
 	return abci.ResponseDeliverTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    result.GetEvents(),
 	}
 }
 
@@ -686,16 +882,23 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 func (app *BaseApp) PreDeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
 	result := app.preCheck(req.Tx, sdk.RunTxModeDeliver)
 	return abci.ResponseDeliverTx{
-		Code:   uint32(result.Code),
-		Data:   result.Data,
-		Log:    result.Log,
-		Events: result.GetEvents(),
+		Code:      uint32(result.Code),
+		Data:      result.Data,
+		Log:       result.Log,
+		GasWanted: int64(result.GasWanted),
+		GasUsed:   int64(result.GasUsed),
+		Events:    result.GetEvents(),
 	}
 }
 
 // Basic validator for msgs
 func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
-	if msgs == nil || len(msgs) != 1 {
+	if sdk.IsUpgrade(sdk.TxLimitsParams) {
+		// the ante handler limits the number of msgs
+		if len(msgs) == 0 {
+			return sdk.ErrInternal("Tx.GetMsgs() must return at least one message")
+		}
+	} else if msgs == nil || len(msgs) != 1 {
 		// TODO: probably shouldn't be ErrInternal. Maybe new ErrInvalidMessage, or ?
 		return sdk.ErrInternal("Tx.GetMsgs() must return exactly one message")
 	}
@@ -718,10 +921,10 @@ func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
 }
 
 // retrieve the context with cache and store the tx bytes and tx hash
-func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Context,
+func (app *BaseApp) getContextWithCache(st *state, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Context,
 	sdk.CacheMultiStore, sdk.AccountCache) {
 	// Get the context
-	ctx := getState(app, mode).Ctx.WithTx(tx)
+	ctx := st.Ctx.WithTx(tx)
 	// Simulate a DeliverTx
 	if mode == sdk.RunTxModeSimulate {
 		ctx = ctx.WithRunTxMode(mode)
@@ -734,16 +937,71 @@ func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash st
 			map[string]interface{}{"txHash": txHash},
 		)).(sdk.CacheMultiStore)
 	}
-	accountCache := getAccountCache(app, mode).Cache()
+	if app.timeStoreOps {
+		msCache = latencyMultiStore{msCache, app.metrics.StoreAccess}
+	}
+	accountCache := st.AccountCache.Cache()
 
 	return ctx.WithMultiStore(msCache).WithAccountCache(accountCache), msCache, accountCache
 }
 
+// withGasMeter sets the gas meter of a tx, limited by the tx gas limit and by
+// the gas left in the block when the block gas is limited.
+func (app *BaseApp) withGasMeter(ctx sdk.Context) sdk.Context {
+	if !sdk.IsUpgrade(sdk.GasMetering) {
+		return ctx
+	}
+	limit := app.txGasLimit
+	if blockGasMeter := ctx.BlockGasMeter(); blockGasMeter != nil {
+		left := blockGasMeter.Limit() - blockGasMeter.GasConsumedToLimit()
+		if limit == 0 || left < limit {
+			limit = left
+		}
+	}
+	ctx = ctx.WithKVGasConfig(app.kvGasConfig).WithTransientKVGasConfig(app.transientGasConfig)
+	if limit == 0 {
+		return ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	}
+	return ctx.WithGasMeter(sdk.NewGasMeter(limit))
+}
+
+// recoverGas converts the panic of a gas meter into an out of gas result, it
+// returns false for any other panic.
+func recoverGas(r interface{}, gasMeter sdk.GasMeter) (sdk.Result, bool) {
+	var descriptor string
+	switch err := r.(type) {
+	case sdk.ErrorOutOfGas:
+		descriptor = err.Descriptor
+	case sdk.ErrorGasOverflow:
+		descriptor = err.Descriptor
+	default:
+		return sdk.Result{}, false
+	}
+	return sdk.ErrOutOfGas(fmt.Sprintf("out of gas in location: %s; gasLimit: %d, gasUsed: %d",
+		descriptor, gasMeter.Limit(), gasMeter.GasConsumed())).Result(), true
+}
+
+// setGasResult reports the gas of a tx in its result, and consumes it from the
+// gas of the block, whether the tx succeeded or not.
+func setGasResult(ctx sdk.Context, gasMeter sdk.GasMeter, result *sdk.Result) {
+	if gasMeter == nil {
+		return
+	}
+	result.GasWanted = gasMeter.Limit()
+	result.GasUsed = gasMeter.GasConsumedToLimit()
+	// the gas of the tx is limited by the gas left in the block, so the block
+	// gas meter cannot run out of gas here
+	if blockGasMeter := ctx.BlockGasMeter(); blockGasMeter != nil {
+		blockGasMeter.ConsumeGas(result.GasUsed, "block gas meter")
+	}
+}
+
 // Iterates through msgs and executes them
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode) (result sdk.Result) {
 	// accumulate results
 	logs := make([]string, 0, len(msgs))
 	var data []byte   // NOTE: we just append them all (?!)
+	var msgResponses [][]byte
 	var tags sdk.Tags // also just append them all
 	var events sdk.Events
 	var code sdk.ABCICodeType
@@ -755,13 +1013,20 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgRoute).Result()
 		}
 
-		msgResult := handler(ctx.WithRunTxMode(mode), msg)
+		// each msg gets its own event manager, so that the events emitted by
+		// the handler of a failed msg are discarded along with its state
+		msgCtx := ctx.WithRunTxMode(mode).WithEventManager(sdk.NewEventManager())
+		msgResult := app.interceptHandler(handler)(msgCtx, msg)
 		msgResult.Tags = append(msgResult.Tags, sdk.MakeTag("action", []byte(msg.Type())))
 
 		// Append Data and Tags
 		data = append(data, msgResult.Data...)
+		msgResponses = append(msgResponses, msgResult.Data)
 		tags = append(tags, msgResult.Tags...)
 		events = append(events, msgResult.Events...)
+		if msgResult.IsOK() {
+			events = append(events, msgCtx.EventManager().Events()...)
+		}
 
 		// Stop execution and return on first failed message.
 		if !msgResult.IsOK() {
@@ -783,8 +1048,9 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 		Data: data,
 		Log:  strings.Join(logs, "\n"),
 		// TODO: FeeAmount/FeeDenom
-		Tags:   tags,
-		Events: events,
+		Tags:         tags,
+		Events:       events,
+		MsgResponses: msgResponses,
 	}
 
 	return result
@@ -803,31 +1069,46 @@ func getState(app *BaseApp, mode sdk.RunTxMode) *state {
 	return app.DeliverState
 }
 
-// Returns AccountCache of CheckState or DeliverState according to the tx mode
-func getAccountCache(app *BaseApp, mode sdk.RunTxMode) sdk.AccountCache {
-	if mode == sdk.RunTxModeCheckAfterPre ||
-		mode == sdk.RunTxModeCheck ||
-		mode == sdk.RunTxModeSimulate ||
-		mode == sdk.RunTxModeReCheck {
-		return app.CheckState.AccountCache
-	}
-
-	return app.DeliverState.AccountCache
-}
-
 // RunTx processes a transaction. The transactions is proccessed via an
 // anteHandler. txBytes may be nil in some cases, eg. in tests. Also, in the
 // future we may support "internal" transactions.
 func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result) {
+	result = app.runTx(getState(app, mode), mode, tx, txHash)
+	app.recordTx(mode, tx, result)
+	if result.IsOK() && (mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre) {
+		if app.collect.CollectAccountBalance {
+			for _, msg := range tx.GetMsgs() {
+				app.Pool.AddAddrs(msg.GetInvolvedAddresses())
+			}
+		}
+		if app.collect.CollectTxs {
+			// Should we add all msg here with no distinction ？
+			app.Pool.AddTx(tx, txHash)
+		}
+	}
+	return
+}
+
+// runTx processes a transaction against the state st.
+func (app *BaseApp) runTx(st *state, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result) {
 	// meter so we initialize upfront.
-	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
+	ctx, msCache, accountCache := app.getContextWithCache(st, mode, tx, txHash)
+	if blockGasMeter := ctx.BlockGasMeter(); blockGasMeter != nil && blockGasMeter.IsOutOfGas() {
+		return sdk.ErrOutOfGas("no block gas left to run the tx").Result()
+	}
+	ctx = app.withGasMeter(ctx)
+	gasMeter := ctx.GasMeter()
 
 	defer func() {
 		if r := recover(); r != nil {
-			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
-			result = sdk.ErrInternal(log).Result()
+			if gasResult, ok := recoverGas(r, gasMeter); ok {
+				result = gasResult
+			} else {
+				log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+				result = sdk.ErrInternal(log).Result()
+			}
 		}
-
+		setGasResult(ctx, gasMeter, &result)
 	}()
 
 	var msgs = tx.GetMsgs()
@@ -863,15 +1144,6 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 
 	// only update state if all messages pass
 	if result.IsOK() {
-		if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
-			if app.collect.CollectAccountBalance {
-				app.Pool.AddAddrs(msgs[0].GetInvolvedAddresses())
-			}
-			if app.collect.CollectTxs {
-				// Should we add all msg here with no distinction ？
-				app.Pool.AddTx(tx, txHash)
-			}
-		}
 		accountCache.Write()
 		msCache.Write()
 	}
@@ -886,14 +1158,21 @@ func (app *BaseApp) ReRunTx(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	// meter so we initialize upfront.
 	mode := sdk.RunTxModeReCheck
 	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
+	ctx, msCache, accountCache := app.getContextWithCache(getState(app, mode), mode, tx, txHash)
+	ctx = app.withGasMeter(ctx)
+	gasMeter := ctx.GasMeter()
 
 	defer func() {
 		if r := recover(); r != nil {
-			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
-			result = sdk.ErrInternal(log).Result()
+			if gasResult, ok := recoverGas(r, gasMeter); ok {
+				result = gasResult
+			} else {
+				log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+				result = sdk.ErrInternal(log).Result()
+			}
 		}
-
+		setGasResult(ctx, gasMeter, &result)
+		app.recordTx(mode, tx, result)
 	}()
 
 	// run the ante handler
@@ -931,7 +1210,9 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	}
 
 	if app.endBlocker != nil {
-		res = app.endBlocker(app.DeliverState.Ctx, req)
+		ctx := app.DeliverState.Ctx.WithEventManager(sdk.NewEventManager())
+		res = app.endBlocker(ctx, req)
+		res.Events = append(res.Events, ctx.EventManager().ABCIEvents()...)
 	}
 
 	return
@@ -949,7 +1230,10 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 			app.db.SetSync(dbHeaderKey, headerBytes)
 	*/
 
-	// Write the Deliver state and commit the MultiStore
+	// Write the Deliver state and commit the MultiStore, the queries wait for
+	// the new snapshot
+	app.queryLock.Lock()
+	defer app.queryLock.Unlock()
 	app.DeliverState.WriteAccountCache()
 	app.DeliverState.ms.Write()
 	commitID := app.cms.Commit()
@@ -957,6 +1241,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
 	)
+	if app.txIndexer != nil {
+		app.txIndexer.Commit(header.Height)
+	}
 
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
@@ -973,15 +1260,24 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 }
 
 func (app *BaseApp) StartRecovery(manifest *abci.Manifest) error {
+	if app.StateSyncHelper == nil {
+		return errors.New("state sync is not enabled for the app")
+	}
 	return app.StateSyncHelper.StartRecovery(manifest)
 }
 
 func (app *BaseApp) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *abci.AppStateChunk, isComplete bool) error {
+	if app.StateSyncHelper == nil {
+		return errors.New("state sync is not enabled for the app")
+	}
 	if err := app.StateSyncHelper.WriteRecoveryChunk(hash, chunk, isComplete); err != nil {
 		return err
 	}
 
 	if isComplete {
+		app.queryLock.Lock()
+		defer app.queryLock.Unlock()
+
 		// load into memory from db
 		if err := app.LoadCMSLatestVersion(); err != nil {
 			return err
@@ -991,6 +1287,11 @@ func (app *BaseApp) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *abci.AppState
 		hashHex := fmt.Sprintf("%X", commitId.Hash)
 		app.Logger.Info("commit by state reactor", "version", commitId.Version, "hash", hashHex)
 
+		// the tendermint state of the snapshot is restored before the last chunk is written
+		if state := sm.LoadState(snapshot.Manager().GetStateDB()); !bytes.Equal(state.AppHash, commitId.Hash) {
+			return fmt.Errorf("restored app hash %s does not match the app hash %X of the snapshot", hashHex, state.AppHash)
+		}
+
 		// simulate we just "Commit()" :P
 		app.SetCheckState(abci.Header{Height: snapshot.Manager().RestorationManifest.Height})
 		app.DeliverState = nil
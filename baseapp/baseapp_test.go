@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 )
 
 var (
@@ -522,6 +524,55 @@ func TestCheckTx(t *testing.T) {
 	require.Nil(t, storedBytes)
 }
 
+// Test that CheckTx and ReCheckTx report the priorities of the txs passing
+// the check.
+func TestCheckTxPriority(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+		bapp.Router().AddRoute(routeMsgCounter2, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	priorityOpt := func(bapp *BaseApp) {
+		bapp.SetTxPriorityHandler(NewMsgTypePriorityHandler(map[string]int64{"counter2": 10}))
+	}
+	app := setupBaseApp(t, routerOpt, priorityOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	priority := func(res abci.ResponseCheckTx) string {
+		for _, event := range res.Events {
+			if event.Type == EventTypeTxPriority {
+				require.Equal(t, AttributeKeyTxPriority, string(event.Attributes[0].Key))
+				return string(event.Attributes[0].Value)
+			}
+		}
+		return ""
+	}
+
+	tx := &txTest{Msgs: []sdk.Msg{msgCounter2{0}}}
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+	res := app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, "10", priority(res))
+	res = app.ReCheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, "10", priority(res))
+
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(1, 1))
+	require.NoError(t, err)
+	res = app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, "0", priority(res))
+
+	// the txs failing the check have no priority
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(2, -1))
+	require.NoError(t, err)
+	res = app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.False(t, res.IsOK())
+	require.Equal(t, "", priority(res))
+}
+
 // Test that successive DeliverTx can see each others' effects
 // on the store, both within and across blocks.
 func TestDeliverTx(t *testing.T) {
@@ -558,6 +609,170 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+// Test that the events emitted through the event manager of the context are
+// returned by DeliverTx, BeginBlock and EndBlock.
+func TestDeliverTxEvents(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := msg.(*msgCounter).Counter
+			ctx.EventManager().EmitEvent(sdk.NewEvent("counter", sdk.NewAttribute("counter", strconv.FormatInt(counter, 10))))
+			if counter%2 == 1 {
+				return sdk.ErrInternal("odd counter").Result()
+			}
+			return sdk.Result{}
+		})
+	}
+	blockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			ctx.EventManager().EmitEvent(sdk.NewEvent("begin"))
+			return abci.ResponseBeginBlock{}
+		})
+		bapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+			ctx.EventManager().EmitEvent(sdk.NewEvent("end"))
+			return abci.ResponseEndBlock{}
+		})
+	}
+	app := setupBaseApp(t, routerOpt, blockerOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	beginRes := app.BeginBlock(abci.RequestBeginBlock{})
+	require.Equal(t, []abci.Event{{Type: "begin"}}, beginRes.Events)
+
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Len(t, res.Events, 2)
+	require.Equal(t, sdk.EventTypeMessage, res.Events[0].Type)
+	require.Equal(t, []byte(sdk.AttributeKeyAction), res.Events[0].Attributes[0].Key)
+	require.Equal(t, []byte("counter1"), res.Events[0].Attributes[0].Value)
+	require.Equal(t, abci.Event(sdk.NewEvent("counter", sdk.NewAttribute("counter", "0"))), res.Events[1])
+
+	// the events of a failed msg are discarded
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(1, 1))
+	require.NoError(t, err)
+	res = app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.False(t, res.IsOK())
+	require.Len(t, res.Events, 1)
+
+	endRes := app.EndBlock(abci.RequestEndBlock{})
+	require.Equal(t, []abci.Event{{Type: "end"}}, endRes.Events)
+}
+
+// Test that the msg interceptors run around the handlers in the order they
+// were added, and that a vetoed msg is not handled and fails the tx.
+func TestMsgInterceptors(t *testing.T) {
+	var calls []string
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			calls = append(calls, "handler")
+			return sdk.Result{Data: []byte("handled")}
+		})
+	}
+	interceptorOpt := func(bapp *BaseApp) {
+		bapp.AddMsgInterceptor(func(ctx sdk.Context, msg sdk.Msg, next sdk.Handler) sdk.Result {
+			calls = append(calls, "outer before")
+			result := next(ctx, msg)
+			calls = append(calls, "outer after")
+			return result
+		})
+		bapp.AddMsgInterceptor(NewMsgHooksInterceptor(
+			func(ctx sdk.Context, msg sdk.Msg) sdk.Error {
+				calls = append(calls, "inner before")
+				if msg.(*msgCounter).Counter%2 == 1 {
+					return sdk.ErrUnauthorized("odd counter")
+				}
+				return nil
+			},
+			func(ctx sdk.Context, msg sdk.Msg, result sdk.Result) {
+				calls = append(calls, "inner after")
+				require.Equal(t, "handled", string(result.Data))
+			},
+		))
+	}
+	app := setupBaseApp(t, routerOpt, interceptorOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	app.BeginBlock(abci.RequestBeginBlock{})
+
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, []string{"outer before", "inner before", "handler", "inner after", "outer after"}, calls)
+
+	// the vetoed msg is not handled
+	calls = nil
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(1, 1))
+	require.NoError(t, err)
+	res = app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), sdk.ABCICodeType(res.Code))
+	require.Equal(t, []string{"outer before", "inner before", "outer after"}, calls)
+}
+
+// Test that the store operations of the txs are metered against the tx and
+// block gas limits, and that a tx running out of gas is reverted.
+func TestDeliverTxOutOfGas(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		// each msg sets Counter values of 100 bytes
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := msg.(*msgCounter).Counter
+			store := ctx.KVStore(capKey1)
+			for i := int64(0); i < counter; i++ {
+				store.Set([]byte{byte(counter), byte(i)}, make([]byte, 100))
+			}
+			return sdk.Result{}
+		})
+	}
+	gasOpt := func(bapp *BaseApp) {
+		bapp.SetTxGasLimit(12000)
+		bapp.SetBlockGasLimit(25000)
+	}
+	app := setupBaseApp(t, routerOpt, gasOpt)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GasMetering, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	deliver := func(counter int64) abci.ResponseDeliverTx {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, counter))
+		require.NoError(t, err)
+		return app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	}
+	// a set costs 2000 + 30 * (2 + 100) gas
+	setCost := int64(5060)
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	res := deliver(2)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(12000), res.GasWanted)
+	require.Equal(t, 2*setCost, res.GasUsed)
+
+	// the tx gas limit is exceeded and the writes of the tx are reverted
+	res = deliver(3)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), sdk.ABCICodeType(res.Code), res.Log)
+	require.Equal(t, int64(12000), res.GasUsed)
+	require.False(t, app.DeliverState.Ctx.KVStore(capKey1).Has([]byte{3, 0}))
+
+	// the gas left in the block limits the tx
+	res = deliver(1)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), sdk.ABCICodeType(res.Code), res.Log)
+	require.Equal(t, int64(25000-12000-2*setCost), res.GasWanted)
+	res = deliver(0)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), sdk.ABCICodeType(res.Code), res.Log)
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the gas of the block is reset
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res = deliver(1)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, setCost, res.GasUsed)
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -708,3 +923,42 @@ func TestSimulateTx(t *testing.T) {
 		app.Commit()
 	}
 }
+
+func TestSimulateTxQuery(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := msg.(*msgCounter).Counter
+			ctx.KVStore(capKey1).Set([]byte("simulated"), i2b(counter))
+			ctx.EventManager().EmitEvent(sdk.NewEvent("counter", sdk.NewAttribute("counter", strconv.FormatInt(counter, 10))))
+			return sdk.Result{Data: i2b(counter)}
+		})
+	}
+	app := setupBaseApp(t, routerOpt)
+	app.InitChain(abci.RequestInitChain{})
+
+	fees.RegisterCalculator("counter1", fees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	defer fees.UnsetAllCalculators()
+
+	cdc := codec.New()
+	registerTestCodec(cdc)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTxCounter(0, 1))
+	require.NoError(t, err)
+
+	queryResult := app.Query(abci.RequestQuery{Path: "/app/simulate_tx", Data: txBytes})
+	require.True(t, queryResult.IsOK(), queryResult.Log)
+	var res sdk.SimulationResponse
+	codec.Cdc.MustUnmarshalBinaryLengthPrefixed(queryResult.Value, &res)
+	require.True(t, res.Code.IsOK(), res.Log)
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 10)}, sdk.FeeForProposer), res.Fee)
+	require.Equal(t, [][]byte{i2b(1)}, res.MsgResponses)
+	require.Equal(t, sdk.StringEvents{
+		{Type: "counter", Attributes: []sdk.Attribute{sdk.NewAttribute("counter", "1")}},
+		{Type: sdk.EventTypeMessage, Attributes: []sdk.Attribute{sdk.NewAttribute("action", "counter1")}},
+	}, res.Events)
+
+	// nothing is committed
+	require.Nil(t, app.CheckState.Ctx.KVStore(capKey1).Get([]byte("simulated")))
+
+	queryResult = app.Query(abci.RequestQuery{Path: "/app/simulate_tx", Data: []byte("invalid")})
+	require.False(t, queryResult.IsOK())
+}
@@ -2,6 +2,7 @@ package baseapp
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
@@ -18,6 +19,27 @@ func (app *BaseApp) Simulate(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	return app.RunTx(sdk.RunTxModeSimulate, tx, txHash)
 }
 
+// SimulateTx runs tx like Simulate, against a cache of the check state that is
+// discarded, and returns the fee of its msgs along with their responses and
+// events, to preview the tx before broadcasting it.
+func (app *BaseApp) SimulateTx(txBytes []byte, tx sdk.Tx) sdk.SimulationResponse {
+	result := app.Simulate(txBytes, tx)
+	var fee sdk.Fee
+	for _, msg := range tx.GetMsgs() {
+		if calculator := fees.GetCalculator(msg.Type()); calculator != nil {
+			fee.AddFee(calculator(msg))
+		}
+	}
+	return sdk.SimulationResponse{
+		Code:         result.Code,
+		Log:          result.Log,
+		Fee:          fee,
+		GasUsed:      result.GasUsed,
+		MsgResponses: result.MsgResponses,
+		Events:       sdk.StringifyEvents(result.GetEvents()),
+	}
+}
+
 // nolint
 func (app *BaseApp) Deliver(tx sdk.Tx) (result sdk.Result) {
 	txHash := cmn.HexBytes(tmhash.Sum(nil)).String()
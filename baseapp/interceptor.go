@@ -0,0 +1,45 @@
+package baseapp
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewMsgHooksInterceptor returns a MsgInterceptor running before ahead of the
+// handler of each msg and after with the result of the msg, either may be
+// nil, e.g.
+//
+//	NewMsgHooksInterceptor(nil, func(ctx sdk.Context, msg sdk.Msg, result sdk.Result) {
+//		msgCounter.With("type", msg.Type()).Add(1)
+//	})
+//
+// A msg failing before is not handled and fails with its error, after does
+// not run for it.
+func NewMsgHooksInterceptor(
+	before func(ctx sdk.Context, msg sdk.Msg) sdk.Error,
+	after func(ctx sdk.Context, msg sdk.Msg, result sdk.Result),
+) sdk.MsgInterceptor {
+	return func(ctx sdk.Context, msg sdk.Msg, next sdk.Handler) sdk.Result {
+		if before != nil {
+			if err := before(ctx, msg); err != nil {
+				return err.Result()
+			}
+		}
+		result := next(ctx, msg)
+		if after != nil {
+			after(ctx, msg, result)
+		}
+		return result
+	}
+}
+
+// interceptHandler wraps handler into the msg interceptors of the app, the
+// first added runs first.
+func (app *BaseApp) interceptHandler(handler sdk.Handler) sdk.Handler {
+	for i := len(app.msgInterceptors) - 1; i >= 0; i-- {
+		interceptor, next := app.msgInterceptors[i], handler
+		handler = func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return interceptor(ctx, msg, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,61 @@
+package baseapp
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// modeLabel returns the label of the metrics of the txs run in mode.
+func modeLabel(mode sdk.RunTxMode) string {
+	switch mode {
+	case sdk.RunTxModeCheck, sdk.RunTxModeCheckAfterPre:
+		return "check"
+	case sdk.RunTxModeReCheck:
+		return "recheck"
+	case sdk.RunTxModeSimulate:
+		return "simulate"
+	default:
+		return "deliver"
+	}
+}
+
+// txTypeLabel returns the label of the type of tx, the one of its first msg.
+func txTypeLabel(tx sdk.Tx) string {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return "none"
+	}
+	return msgs[0].Route() + "/" + msgs[0].Type()
+}
+
+// recordTx records the result of tx run in mode into the metrics of the app.
+func (app *BaseApp) recordTx(mode sdk.RunTxMode, tx sdk.Tx, result sdk.Result) {
+	modeLbl, typeLbl := modeLabel(mode), txTypeLabel(tx)
+	app.metrics.Txs.With("mode", modeLbl, "type", typeLbl, "code", strconv.FormatUint(uint64(result.Code), 10)).Add(1)
+	app.metrics.GasUsed.With("mode", modeLbl, "type", typeLbl).Observe(float64(result.GasUsed))
+}
+
+// observeSince observes the seconds elapsed since start into histogram.
+func observeSince(histogram metrics.Histogram, start time.Time) {
+	histogram.Observe(time.Since(start).Seconds())
+}
+
+// latencyMultiStore observes the latency of the operations on the KVStores
+// it hands out, and on those of its cache wraps.
+type latencyMultiStore struct {
+	parentMultiStore
+	histogram metrics.Histogram
+}
+
+func (ms latencyMultiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
+	return store.NewLatencyKVStore(ms.parentMultiStore.GetKVStore(key), key.Name(), ms.histogram)
+}
+
+func (ms latencyMultiStore) CacheMultiStore() sdk.CacheMultiStore {
+	return latencyMultiStore{ms.parentMultiStore.CacheMultiStore(), ms.histogram}
+}
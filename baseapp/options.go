@@ -2,8 +2,6 @@
 package baseapp
 
 import (
-	"fmt"
-
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -14,19 +12,71 @@ import (
 
 // SetPruning sets a pruning option on the multistore associated with the app
 func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("invalid pruning strategy: %s", pruning))
+	pruningStrategy, err := sdk.NewPruningStrategyFromString(pruning)
+	if err != nil {
+		panic(err)
+	}
+	return SetPruningStrategy(pruningStrategy)
+}
+
+// SetPruningStrategy sets a custom pruning strategy on the multistore
+// associated with the app
+func SetPruningStrategy(pruning sdk.PruningStrategy) func(*BaseApp) {
+	if err := pruning.Validate(); err != nil {
+		panic(err)
+	}
+	return func(bap *BaseApp) {
+		bap.cms.SetPruning(pruning)
+	}
+}
+
+// SetInterBlockCacheSize caches up to size values of each IAVL store of the
+// multistore associated with the app across blocks, 0 disables the cache
+func SetInterBlockCacheSize(size int) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.cms.SetInterBlockCacheSize(size)
+	}
+}
+
+// SetStateDiffDir records the writes of each block committed by the app into
+// a diff file of dir, an empty dir disables the recording
+func SetStateDiffDir(dir string) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.cms.SetStateDiffDir(dir)
 	}
+}
+
+// SetCommitBuffer writes the IAVL stores of the multistore associated with the
+// app in a single batch per block, synced on a background goroutine if async
+func SetCommitBuffer(enabled, async bool) func(*BaseApp) {
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.cms.SetCommitBuffer(enabled, async)
+	}
+}
+
+// TxIndexer indexes the txs delivered by the app apart from the state.
+type TxIndexer interface {
+	// IndexTx records the tx of bytes txBytes delivered at height, tx is nil
+	// if it could not be decoded.
+	IndexTx(height int64, txBytes []byte, tx sdk.Tx, result sdk.Result)
+	// Commit writes the txs recorded at height, once it is committed.
+	Commit(height int64)
+	// Querier answers the queries of the route TxIndexRoute.
+	Querier() sdk.Querier
+}
+
+// TxIndexRoute is the route of the custom queries of the TxIndexer.
+const TxIndexRoute = "txindex"
+
+// SetTxIndexer indexes the delivered txs with indexer, which serves its
+// queries at TxIndexRoute, a nil indexer disables the indexing
+func SetTxIndexer(indexer TxIndexer) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		if indexer == nil {
+			return
+		}
+		bap.txIndexer = indexer
+		bap.queryRouter.AddRoute(TxIndexRoute, indexer.Querier())
 	}
 }
 
@@ -86,6 +136,50 @@ func (app *BaseApp) SetPreChecker(pc sdk.PreChecker) {
 	app.preChecker = pc
 }
 
+func (app *BaseApp) SetTxPriorityHandler(ph sdk.TxPriorityHandler) {
+	if app.sealed {
+		panic("SetTxPriorityHandler() on sealed BaseApp")
+	}
+	app.txPriority = ph
+}
+
+// AddMsgInterceptor adds interceptor around the handlers of the msgs, inside
+// the interceptors added before.
+func (app *BaseApp) AddMsgInterceptor(interceptor sdk.MsgInterceptor) {
+	if app.sealed {
+		panic("AddMsgInterceptor() on sealed BaseApp")
+	}
+	app.msgInterceptors = append(app.msgInterceptors, interceptor)
+}
+
+// SetTxGasLimit limits the gas each tx consumes once the txs are metered,
+// 0 leaves the txs unlimited but for the gas left in the block.
+func (app *BaseApp) SetTxGasLimit(limit sdk.Gas) {
+	if app.sealed {
+		panic("SetTxGasLimit() on sealed BaseApp")
+	}
+	app.txGasLimit = limit
+}
+
+// SetBlockGasLimit limits the gas the txs of a block consume once the txs are
+// metered, 0 leaves the blocks unlimited.
+func (app *BaseApp) SetBlockGasLimit(limit sdk.Gas) {
+	if app.sealed {
+		panic("SetBlockGasLimit() on sealed BaseApp")
+	}
+	app.blockGasLimit = limit
+}
+
+// SetGasConfig sets the costs of the operations on the KVStores and on the
+// TransientStores.
+func (app *BaseApp) SetGasConfig(kvGasConfig, transientGasConfig sdk.GasConfig) {
+	if app.sealed {
+		panic("SetGasConfig() on sealed BaseApp")
+	}
+	app.kvGasConfig = kvGasConfig
+	app.transientGasConfig = transientGasConfig
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
@@ -0,0 +1,53 @@
+package baseapp
+
+import (
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The event of the priority of a tx in the response of CheckTx and ReCheckTx.
+// The ABCI of the tendermint in use has no priority in ResponseCheckTx, the
+// priority is reported as an event of the response for the mempool to order
+// the txs by it.
+const (
+	EventTypeTxPriority    = "tx"
+	AttributeKeyTxPriority = "priority"
+)
+
+// NewMsgTypePriorityHandler returns a TxPriorityHandler assigning to a tx the
+// highest of the priorities of the types of its msgs, e.g.
+//
+//	NewMsgTypePriorityHandler(map[string]int64{oracle.ClaimMsgType: 10})
+//
+// The msgs of the other types have the priority 0.
+func NewMsgTypePriorityHandler(priorities map[string]int64) sdk.TxPriorityHandler {
+	return func(_ sdk.Context, tx sdk.Tx) int64 {
+		var priority int64
+		for i, msg := range tx.GetMsgs() {
+			if p := priorities[msg.Type()]; i == 0 || p > priority {
+				priority = p
+			}
+		}
+		return priority
+	}
+}
+
+// withPriorityEvent returns the events of the result of the check of tx,
+// with the priority of tx if it passed the check.
+func (app *BaseApp) withPriorityEvent(result sdk.Result, tx sdk.Tx) []abci.Event {
+	events := result.GetEvents()
+	if app.txPriority == nil || tx == nil || !result.IsOK() {
+		return events
+	}
+	priority := app.txPriority(app.CheckState.Ctx, tx)
+	return append(events, abci.Event{
+		Type: EventTypeTxPriority,
+		Attributes: []cmn.KVPair{
+			{Key: []byte(AttributeKeyTxPriority), Value: []byte(strconv.FormatInt(priority, 10))},
+		},
+	})
+}
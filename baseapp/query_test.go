@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -94,3 +97,163 @@ func TestP2PQuery(t *testing.T) {
 	res = app.Query(pubkeyQuery)
 	require.Equal(t, uint32(4), res.Code)
 }
+
+// Test that the custom queriers read the state of the queried height.
+func TestCustomQueryHeight(t *testing.T) {
+	key := []byte("height")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.KVStore(capKey1).Set(key, []byte(fmt.Sprint(ctx.BlockHeight())))
+			return sdk.Result{}
+		})
+	}
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("height", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			require.Nil(t, ctx.AccountCache().GetAccount(sdk.AccAddress("addr")))
+			return []byte(fmt.Sprintf("%d:%s", ctx.BlockHeight(), ctx.KVStore(capKey1).Get(key))), nil
+		})
+	}
+	app := setupBaseApp(t, routerOpt, querierOpt)
+	app.SetAccountStoreCache(codec.New(), app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		resTx := app.Deliver(newTxCounter(height, 0))
+		require.True(t, resTx.IsOK(), fmt.Sprintf("%v", resTx))
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	for height, expected := range map[int64]string{0: "3:3", 1: "1:1", 2: "2:2", 3: "3:3"} {
+		res := app.Query(abci.RequestQuery{Path: "/custom/height", Height: height})
+		require.True(t, res.IsOK(), res.Log)
+		require.Equal(t, expected, string(res.Value))
+	}
+
+	res := app.Query(abci.RequestQuery{Path: "/custom/height", Height: 4})
+	require.Equal(t, uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest)), res.Code)
+}
+
+// Test that the custom queries of the last height are served from a snapshot
+// of the committed state, while the txs of the next block run and commit.
+func TestCustomQuerySnapshot(t *testing.T) {
+	key := []byte("height")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.KVStore(capKey1).Set(key, []byte(fmt.Sprint(ctx.BlockHeight())))
+			return sdk.Result{}
+		})
+	}
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("height", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			// the writes of the queriers are discarded
+			require.Nil(t, ctx.KVStore(capKey1).Get([]byte("query")))
+			ctx.KVStore(capKey1).Set([]byte("query"), []byte("written"))
+			return []byte(fmt.Sprintf("%d:%s", ctx.BlockHeight(), ctx.KVStore(capKey1).Get(key))), nil
+		})
+	}
+	app := setupBaseApp(t, routerOpt, querierOpt)
+	app.SetAccountStoreCache(codec.New(), app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+	require.Nil(t, app.querySnapshot)
+
+	query := abci.RequestQuery{Path: "/custom/height"}
+	require.True(t, app.IsConcurrentQuery(query))
+	require.True(t, app.IsConcurrentQuery(abci.RequestQuery{Path: "/store/key1/key"}))
+	require.False(t, app.IsConcurrentQuery(abci.RequestQuery{Path: "/app/simulate"}))
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	require.True(t, app.Deliver(newTxCounter(1, 0)).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Equal(t, int64(1), app.querySnapshot.header.Height)
+
+	// the queries see the last committed height until the next commit
+	done := make(chan struct{})
+	results := make(chan string, 100)
+	go func() {
+		defer close(results)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				res := app.Query(query)
+				require.True(t, res.IsOK(), res.Log)
+				results <- string(res.Value)
+			}
+		}
+	}()
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.True(t, app.Deliver(newTxCounter(2, 0)).IsOK())
+	require.Equal(t, "1:1", string(app.Query(query).Value))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	close(done)
+	for result := range results {
+		require.Contains(t, []string{"1:1", "2:2"}, result)
+	}
+	require.Equal(t, "2:2", string(app.Query(query).Value))
+}
+
+// Test that the reads of the custom queriers are proven.
+func TestCustomQueryProof(t *testing.T) {
+	key := []byte("height")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.KVStore(capKey1).Set(key, []byte(fmt.Sprint(ctx.BlockHeight())))
+			ctx.KVStore(capKey1).Set([]byte(fmt.Sprintf("block/%d", ctx.BlockHeight())), []byte("done"))
+			return sdk.Result{}
+		})
+	}
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("blocks", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			var blocks []string
+			iter := sdk.KVStorePrefixIterator(ctx.KVStore(capKey1), []byte("block/"))
+			for ; iter.Valid(); iter.Next() {
+				blocks = append(blocks, string(iter.Key()))
+			}
+			iter.Close()
+			return []byte(fmt.Sprintf("%s:%v", ctx.KVStore(capKey1).Get(key), blocks)), nil
+		})
+	}
+	app := setupBaseApp(t, routerOpt, querierOpt)
+	app.SetAccountStoreCache(codec.New(), app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+
+	appHashes := make(map[int64][]byte)
+	for height := int64(1); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		resTx := app.Deliver(newTxCounter(height, 0))
+		require.True(t, resTx.IsOK(), fmt.Sprintf("%v", resTx))
+		app.EndBlock(abci.RequestEndBlock{})
+		appHashes[height] = app.Commit().Data
+	}
+
+	// the last height with its app hash in a header is proven
+	res := app.Query(abci.RequestQuery{Path: "/custom/blocks", Prove: true})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(2), res.Height)
+	require.Equal(t, "2:[block/1 block/2]", string(res.Value))
+
+	reads, err := store.QueryReadsFromProof(res.Proof)
+	require.NoError(t, err)
+	require.Len(t, reads, 2)
+	require.Equal(t, "/store/key1/range", reads[0].Path())
+	require.Equal(t, "/store/key1/key", reads[1].Path())
+	require.Equal(t, []byte("2"), reads[1].Value)
+	prt := store.DefaultProofRuntime()
+	for _, read := range reads {
+		require.NoError(t, read.Verify(prt, appHashes[2]))
+		require.Error(t, read.Verify(prt, appHashes[3]))
+	}
+	reads[1].Value = []byte("3")
+	require.Error(t, reads[1].Verify(prt, appHashes[2]))
+
+	// the queries not proven have no proof
+	res = app.Query(abci.RequestQuery{Path: "/custom/blocks"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Nil(t, res.Proof)
+	require.Equal(t, "3:[block/1 block/2 block/3]", string(res.Value))
+}
@@ -0,0 +1,59 @@
+package baseapp
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// querySnapshot is a read-only view of the multistore at the last committed
+// height, the custom queries of the height are served from it without reading
+// the stores the txs write. It is replaced on Commit, under the write lock of
+// queryLock, so the version it reads is not pruned while it is queried.
+type querySnapshot struct {
+	ms                sdk.CacheMultiStore
+	accountStoreCache sdk.AccountStoreCache // nil without an account store
+	header            abci.Header
+}
+
+// context returns the context of a query served from the snapshot, the writes
+// of the querier are discarded.
+func (snapshot *querySnapshot) context(app *BaseApp) sdk.Context {
+	ctx := sdk.NewContext(snapshot.ms.CacheMultiStore(), snapshot.header, sdk.RunTxModeCheck, app.Logger)
+	if snapshot.accountStoreCache != nil {
+		ctx = ctx.WithAccountCache(auth.NewAccountCache(snapshot.accountStoreCache))
+	}
+	return ctx
+}
+
+// setQuerySnapshot snapshots the last committed version of the multistore for
+// the queries, with header as the header of their contexts.
+func (app *BaseApp) setQuerySnapshot(header abci.Header) {
+	app.querySnapshot = nil
+	height := app.LastBlockHeight()
+	if height == 0 {
+		return
+	}
+	ms, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		app.Logger.Error("cannot snapshot the multistore for the queries", "height", height, "err", err)
+		return
+	}
+	snapshot := &querySnapshot{ms: ms, header: header}
+	if app.accountStore != nil {
+		if snapshot.accountStoreCache, err = app.versionedAccountStoreCache(ms); err != nil {
+			app.Logger.Error("cannot snapshot the account store for the queries", "height", height, "err", err)
+			return
+		}
+	}
+	app.querySnapshot = snapshot
+}
+
+// IsConcurrentQuery reports whether req only reads the committed state, as the
+// store and custom queries do, which lets it run concurrently with the txs.
+// The other queries, e.g. the simulations, run on the check state.
+func (app *BaseApp) IsConcurrentQuery(req abci.RequestQuery) bool {
+	path := SplitPath(req.Path)
+	return len(path) > 0 && (path[0] == "store" || path[0] == "custom")
+}
@@ -0,0 +1,277 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// TxReplay is the result of a tx replayed against the state of its block,
+// along with the trace of every read and write of the tx, in the order they
+// happened. The store operations are those of the TraceKVStore, with the
+// name of their store in their metadata.
+type TxReplay struct {
+	Height     int64             `json:"height"`
+	Index      int               `json:"index"`
+	TxHash     string            `json:"tx_hash"`
+	Code       sdk.ABCICodeType  `json:"code"`
+	Log        string            `json:"log"`
+	GasUsed    sdk.Gas           `json:"gas_used"`
+	Operations []json.RawMessage `json:"operations"`
+}
+
+// ReplayTx re-executes the tx at index among the txs of the block begun by
+// req, against the persisted state before the block: the begin blocker and
+// the txs before it are replayed first, and the state is discarded.
+//
+// It sets the height of the upgrade manager, so it must not be called on an
+// app running the chain.
+func (app *BaseApp) ReplayTx(req abci.RequestBeginBlock, txs [][]byte, index int) (*TxReplay, error) {
+	height := req.Header.Height
+	if height <= 1 {
+		// the state of the first block is the one of InitChain, which is not
+		// persisted apart from the block
+		return nil, fmt.Errorf("cannot replay the txs of block %d", height)
+	}
+	if index < 0 || index >= len(txs) {
+		return nil, fmt.Errorf("tx index %d out of range of the %d txs of block %d", index, len(txs), height)
+	}
+	if app.accountStore == nil {
+		return nil, fmt.Errorf("no account store cache is set")
+	}
+	st, err := app.replayState(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.beginBlocker != nil {
+		app.beginBlocker(st.Ctx.WithEventManager(sdk.NewEventManager()), req)
+	}
+	for i, txBytes := range txs[:index] {
+		// the txs failing to decode are not delivered
+		if tx, err := app.TxDecoder(txBytes); err == nil {
+			app.runTx(st, sdk.RunTxModeDeliver, tx, cmn.HexBytes(tmhash.Sum(txs[i])).String())
+		}
+	}
+
+	txHash := cmn.HexBytes(tmhash.Sum(txs[index])).String()
+	tx, decodeErr := app.TxDecoder(txs[index])
+	if decodeErr != nil {
+		return nil, fmt.Errorf("cannot decode tx %s: %s", txHash, decodeErr.Error())
+	}
+	var trace bytes.Buffer
+	st.Ctx = st.Ctx.WithMultiStore(traceMultiStore{st.ms, &trace})
+	st.AccountCache = traceAccountCache{st.AccountCache, app.accountCdc, &trace}
+	result := app.runTx(st, sdk.RunTxModeDeliver, tx, txHash)
+
+	replay := &TxReplay{
+		Height:     height,
+		Index:      index,
+		TxHash:     txHash,
+		Code:       result.Code,
+		Log:        result.Log,
+		GasUsed:    result.GasUsed,
+		Operations: make([]json.RawMessage, 0),
+	}
+	for _, line := range bytes.Split(trace.Bytes(), []byte("\n")) {
+		if len(line) > 0 {
+			replay.Operations = append(replay.Operations, line)
+		}
+	}
+	return replay, nil
+}
+
+// BlockFees are the fees collected in a block replayed by ReplayBlockFees.
+// The fees of the begin and end blockers are the ones they commit to the fee
+// pool, e.g. for the slashing of side chain validators.
+type BlockFees struct {
+	Height        int64
+	Proposer      sdk.ConsAddress
+	BeginBlockFee sdk.Fee
+	Txs           []TxFee
+	EndBlockFee   sdk.Fee
+}
+
+// TxFee is the fee collected for a tx of a block. MsgType is the type of its
+// first msg, the txs of this chain carrying a single msg.
+type TxFee struct {
+	TxHash  string
+	MsgType string
+	Code    sdk.ABCICodeType
+	Fee     sdk.Fee
+}
+
+// ReplayBlockFees re-executes the block begun by req, with its txs, against
+// the persisted state before the block, and returns the fees collected in it:
+// the fees the txs that succeed were charged, which are committed to the fee
+// pool once they are delivered, and the fees committed to the pool while
+// executing the block. The state is discarded and the txs failing to decode,
+// which are not delivered, are skipped.
+//
+// It sets the height of the upgrade manager and clears the fee pool, so it
+// must not be called on an app running the chain.
+func (app *BaseApp) ReplayBlockFees(req abci.RequestBeginBlock, txs [][]byte) (*BlockFees, error) {
+	height := req.Header.Height
+	if height <= 1 {
+		return nil, fmt.Errorf("cannot replay block %d", height)
+	}
+	if app.accountStore == nil {
+		return nil, fmt.Errorf("no account store cache is set")
+	}
+	st, err := app.replayState(req)
+	if err != nil {
+		return nil, err
+	}
+	defer fees.Pool.Clear()
+
+	blockFees := &BlockFees{
+		Height:   height,
+		Proposer: sdk.ConsAddress(req.Header.ProposerAddress),
+		Txs:      make([]TxFee, 0, len(txs)),
+	}
+	fees.Pool.Clear()
+	if app.beginBlocker != nil {
+		app.beginBlocker(st.Ctx.WithEventManager(sdk.NewEventManager()), req)
+	}
+	blockFees.BeginBlockFee = fees.Pool.BlockFees()
+
+	for _, txBytes := range txs {
+		tx, err := app.TxDecoder(txBytes)
+		if err != nil {
+			continue
+		}
+		txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
+		fees.Pool.Clear()
+		result := app.runTx(st, sdk.RunTxModeDeliver, tx, txHash)
+
+		fee := fees.Pool.BlockFees()
+		if result.IsOK() {
+			if txFee := fees.Pool.GetFee(txHash); txFee != nil {
+				fee.AddFee(*txFee)
+			}
+		}
+		var msgType string
+		if msgs := tx.GetMsgs(); len(msgs) > 0 {
+			msgType = msgs[0].Type()
+		}
+		blockFees.Txs = append(blockFees.Txs, TxFee{TxHash: txHash, MsgType: msgType, Code: result.Code, Fee: fee})
+	}
+
+	fees.Pool.Clear()
+	if app.endBlocker != nil {
+		app.endBlocker(st.Ctx.WithEventManager(sdk.NewEventManager()), abci.RequestEndBlock{Height: height})
+	}
+	blockFees.EndBlockFee = fees.Pool.BlockFees()
+	return blockFees, nil
+}
+
+// replayState returns a deliver state over the version before the block
+// begun by req.
+func (app *BaseApp) replayState(req abci.RequestBeginBlock) (*state, error) {
+	height := req.Header.Height
+	ms, err := app.cms.CacheMultiStoreWithVersion(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	accountCache, err := app.versionedAccountCache(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	sdk.UpgradeMgr.SetHeight(height)
+	var blockGasMeter sdk.GasMeter
+	if sdk.IsUpgrade(sdk.GasMetering) && app.blockGasLimit > 0 {
+		blockGasMeter = sdk.NewGasMeter(app.blockGasLimit)
+	}
+	return &state{
+		ms:           ms,
+		AccountCache: accountCache,
+		Ctx: sdk.NewContext(ms, req.Header, sdk.RunTxModeDeliver, app.Logger).
+			WithAccountCache(accountCache).
+			WithVoteInfos(req.LastCommitInfo.GetVotes()).
+			WithBlockHash(req.Hash).
+			WithBlockGasMeter(blockGasMeter),
+	}, nil
+}
+
+// parentMultiStore names the embedded multistore apart from its
+// CacheMultiStore method.
+type parentMultiStore = sdk.CacheMultiStore
+
+// traceMultiStore traces the operations on the KVStores it hands out, and
+// on those of its cache wraps.
+type traceMultiStore struct {
+	parentMultiStore
+	writer io.Writer
+}
+
+func (ms traceMultiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
+	return store.NewTraceKVStore(ms.parentMultiStore.GetKVStore(key), ms.writer, sdk.TraceContext{"store": key.Name()})
+}
+
+func (ms traceMultiStore) CacheMultiStore() sdk.CacheMultiStore {
+	return traceMultiStore{ms.parentMultiStore.CacheMultiStore(), ms.writer}
+}
+
+// accountOperation is a traced operation on the account cache.
+type accountOperation struct {
+	Operation string          `json:"operation"`
+	Address   sdk.AccAddress  `json:"address"`
+	Account   json.RawMessage `json:"account,omitempty"`
+}
+
+// traceAccountCache traces the operations on the accounts, which bypass the
+// KVStores of the multistore, and on those of its caches.
+type traceAccountCache struct {
+	sdk.AccountCache
+	cdc    *codec.Codec
+	writer io.Writer
+}
+
+func (c traceAccountCache) GetAccount(addr sdk.AccAddress) sdk.Account {
+	acc := c.AccountCache.GetAccount(addr)
+	c.write("readAccount", addr, acc)
+	return acc
+}
+
+func (c traceAccountCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	c.write("writeAccount", addr, acc)
+	c.AccountCache.SetAccount(addr, acc)
+}
+
+func (c traceAccountCache) Delete(addr sdk.AccAddress) {
+	c.write("deleteAccount", addr, nil)
+	c.AccountCache.Delete(addr)
+}
+
+func (c traceAccountCache) Cache() sdk.AccountCache {
+	return traceAccountCache{c.AccountCache.Cache(), c.cdc, c.writer}
+}
+
+// nolint: errcheck
+func (c traceAccountCache) write(operation string, addr sdk.AccAddress, acc sdk.Account) {
+	op := accountOperation{Operation: operation, Address: addr}
+	if acc != nil {
+		bz, err := c.cdc.MarshalJSON(acc)
+		if err != nil {
+			panic(fmt.Sprintf("failed to serialize the account: %v", err))
+		}
+		op.Account = bz
+	}
+	raw, err := json.Marshal(op)
+	if err != nil {
+		panic(fmt.Sprintf("failed to serialize trace operation: %v", err))
+	}
+	c.writer.Write(raw)
+	io.WriteString(c.writer, "\n")
+}
@@ -0,0 +1,153 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+func TestReplayTx(t *testing.T) {
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+	app := setupBaseApp(t, routerOpt)
+	codec := codec.New()
+	registerTestCodec(codec)
+	app.SetAccountStoreCache(codec, app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+
+	var blocks [][][]byte
+	counter := int64(0)
+	for height := int64(1); height <= 3; height++ {
+		var txs [][]byte
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		for i := 0; i < 3; i++ {
+			txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(counter, counter))
+			require.NoError(t, err)
+			res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+			require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+			txs = append(txs, txBytes)
+			counter++
+		}
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+		blocks = append(blocks, txs)
+	}
+
+	// the tx sees the state of the block before it
+	replay, err := app.ReplayTx(abci.RequestBeginBlock{Header: abci.Header{Height: 2}}, blocks[1], 1)
+	require.NoError(t, err)
+	require.Equal(t, sdk.ABCICodeOK, replay.Code)
+	require.Equal(t, int64(2), replay.Height)
+	require.Len(t, replay.Operations, 2)
+
+	type operation struct {
+		Operation string                 `json:"operation"`
+		Key       string                 `json:"key"`
+		Value     string                 `json:"value"`
+		Metadata  map[string]interface{} `json:"metadata"`
+	}
+	var read, write operation
+	require.NoError(t, json.Unmarshal(replay.Operations[0], &read))
+	require.NoError(t, json.Unmarshal(replay.Operations[1], &write))
+	require.Equal(t, "read", read.Operation)
+	require.Equal(t, base64.StdEncoding.EncodeToString(deliverKey), read.Key)
+	require.Equal(t, "key1", read.Metadata["store"])
+	// the counter was incremented by the tx before it
+	value, err := base64.StdEncoding.DecodeString(read.Value)
+	require.NoError(t, err)
+	stored, err := binary.ReadVarint(bytes.NewBuffer(value))
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stored)
+	require.Equal(t, "write", write.Operation)
+
+	// the replay leaves the state untouched
+	require.Equal(t, int64(9), getIntFromStore(app.cms.GetKVStore(capKey1), deliverKey))
+
+	_, err = app.ReplayTx(abci.RequestBeginBlock{Header: abci.Header{Height: 2}}, blocks[1], 3)
+	require.Error(t, err)
+	_, err = app.ReplayTx(abci.RequestBeginBlock{Header: abci.Header{Height: 1}}, blocks[0], 0)
+	require.Error(t, err)
+}
+
+func TestReplayBlockFees(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (sdk.Context, sdk.Result, bool) {
+			fee := sdk.NewFee(sdk.Coins{sdk.NewCoin("BNB", 10)}, sdk.FeeForProposer)
+			fees.Pool.AddFee(ctx.Value(TxHashKey).(string), fee)
+			return ctx, sdk.Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			if m, ok := msg.(*msgCounter); ok && m.Counter == 4 {
+				return sdk.ErrInternal("failed").Result()
+			}
+			return sdk.Result{}
+		})
+	}
+	blockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			fees.Pool.AddAndCommitFee("slash", sdk.NewFee(sdk.Coins{sdk.NewCoin("BNB", 5)}, sdk.FeeForAll))
+			return abci.ResponseBeginBlock{}
+		})
+	}
+	app := setupBaseApp(t, anteOpt, routerOpt, blockerOpt)
+	codec := codec.New()
+	registerTestCodec(codec)
+	app.SetAccountStoreCache(codec, app.cms.GetKVStore(capKey2), 10)
+	app.InitChain(abci.RequestInitChain{})
+
+	var blocks [][][]byte
+	counter := int64(0)
+	for height := int64(1); height <= 2; height++ {
+		var txs [][]byte
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		for i := 0; i < 3; i++ {
+			txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(counter, counter))
+			require.NoError(t, err)
+			app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+			txs = append(txs, txBytes)
+			counter++
+		}
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+		fees.Pool.Clear()
+		blocks = append(blocks, txs)
+	}
+
+	proposer := []byte("proposer")
+	blockFees, err := app.ReplayBlockFees(abci.RequestBeginBlock{Header: abci.Header{Height: 2, ProposerAddress: proposer}}, blocks[1])
+	require.NoError(t, err)
+	require.Equal(t, int64(2), blockFees.Height)
+	require.Equal(t, sdk.ConsAddress(proposer), blockFees.Proposer)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 5)}, blockFees.BeginBlockFee.Tokens)
+	require.True(t, blockFees.EndBlockFee.IsEmpty())
+	require.Len(t, blockFees.Txs, 3)
+	for i, tx := range blockFees.Txs {
+		require.Equal(t, "counter1", tx.MsgType)
+		if i == 1 {
+			// the failed tx is not charged
+			require.NotEqual(t, sdk.ABCICodeOK, tx.Code)
+			require.True(t, tx.Fee.IsEmpty())
+			continue
+		}
+		require.Equal(t, sdk.ABCICodeOK, tx.Code)
+		require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 10)}, tx.Fee.Tokens)
+	}
+	require.True(t, fees.Pool.BlockFees().IsEmpty())
+
+	_, err = app.ReplayBlockFees(abci.RequestBeginBlock{Header: abci.Header{Height: 1}}, blocks[0])
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package context
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -23,12 +24,29 @@ func resultBroadcastTxToCommit(res *ctypes.ResultBroadcastTx) *ctypes.ResultBroa
 	}
 }
 
-// BroadcastTx broadcasts a transactions either synchronously or asynchronously
-// based on the context parameters. The result of the broadcast is parsed into
-// an intermediate structure which is logged if the context has a logger
-// defined.
+// The broadcast modes of BroadcastTx.
+const (
+	// BroadcastBlock returns once the tx is in a block. The node fails the
+	// broadcast if the tx is not committed within its
+	// timeout_broadcast_tx_commit, which happens under load.
+	BroadcastBlock = "block"
+	// BroadcastSync returns the result of the mempool check, and the result of
+	// the tx in a block if the context has a confirmation timeout.
+	BroadcastSync = "sync"
+	// BroadcastAsync returns as soon as the tx is sent.
+	BroadcastAsync = "async"
+)
+
+// confirmPollInterval is the interval of the polls of WaitForConfirmation.
+var confirmPollInterval = 500 * time.Millisecond
+
+// BroadcastTx broadcasts a transaction in the broadcast mode of the context,
+// BroadcastAsync if Async is set and no mode is, or else BroadcastBlock. The
+// result of the broadcast is parsed into an intermediate structure which is
+// logged if the context has a logger defined.
 func (ctx CLIContext) BroadcastTx(txBytes []byte) (*ctypes.ResultBroadcastTxCommit, error) {
-	if ctx.Async {
+	switch ctx.broadcastMode() {
+	case BroadcastAsync:
 		res, err := ctx.broadcastTxAsync(txBytes)
 		if err != nil {
 			return nil, err
@@ -36,9 +54,24 @@ func (ctx CLIContext) BroadcastTx(txBytes []byte) (*ctypes.ResultBroadcastTxComm
 
 		resCommit := resultBroadcastTxToCommit(res)
 		return resCommit, err
+	case BroadcastSync:
+		return ctx.broadcastTxSync(txBytes)
+	case BroadcastBlock:
+		return ctx.broadcastTxCommit(txBytes)
+	default:
+		return nil, errors.Errorf("unknown broadcast mode %q, expected %s, %s or %s",
+			ctx.BroadcastMode, BroadcastBlock, BroadcastSync, BroadcastAsync)
 	}
+}
 
-	return ctx.broadcastTxCommit(txBytes)
+func (ctx CLIContext) broadcastMode() string {
+	if ctx.BroadcastMode != "" {
+		return ctx.BroadcastMode
+	}
+	if ctx.Async {
+		return BroadcastAsync
+	}
+	return BroadcastBlock
 }
 
 // BroadcastTxAndAwaitCommit broadcasts transaction bytes to a Tendermint node
@@ -130,7 +163,99 @@ func (ctx CLIContext) broadcastTxCommit(txBytes []byte) (*ctypes.ResultBroadcast
 	if err != nil {
 		return res, err
 	}
+	return res, ctx.printCommitted(res)
+}
+
+// broadcastTxSync broadcasts txBytes synchronously, and waits for the tx in a
+// block if the context has a confirmation timeout.
+func (ctx CLIContext) broadcastTxSync(txBytes []byte) (*ctypes.ResultBroadcastTxCommit, error) {
+	res, err := ctx.BroadcastTxSync(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	resCommit := &ctypes.ResultBroadcastTxCommit{
+		CheckTx: abci.ResponseCheckTx{Code: res.Code, Data: res.Data, Log: res.Log},
+		Hash:    res.Hash,
+	}
+	if !resCommit.CheckTx.IsOK() {
+		return resCommit, errors.Errorf(res.Log)
+	}
+
+	if ctx.ConfirmTimeout <= 0 {
+		if ctx.Output != nil {
+			if ctx.JSON {
+				type toJSON struct {
+					TxHash   string
+					Response abci.ResponseCheckTx
+				}
+
+				bz, err := ctx.Codec.MarshalJSON(toJSON{res.Hash.String(), resCommit.CheckTx})
+				if err != nil {
+					return resCommit, err
+				}
+
+				ctx.Output.Write(bz)
+				io.WriteString(ctx.Output, "\n")
+			} else {
+				io.WriteString(ctx.Output, fmt.Sprintf("sync tx sent (tx hash: %s)\n", res.Hash))
+			}
+		}
+		return resCommit, nil
+	}
+
+	resTx, err := ctx.WaitForConfirmation(res.Hash, ctx.ConfirmTimeout)
+	if err != nil {
+		return resCommit, err
+	}
+	resCommit.Height, resCommit.DeliverTx = resTx.Height, resTx.TxResult
+	if !resCommit.DeliverTx.IsOK() {
+		return resCommit, errors.Errorf(resCommit.DeliverTx.Log)
+	}
+	return resCommit, ctx.printCommitted(resCommit)
+}
+
+// WaitForConfirmation polls the node for the tx of hash in a block until it
+// is found or timeout elapses, and returns its result. The inclusion of the
+// tx is proven unless the context trusts the node.
+func (ctx CLIContext) WaitForConfirmation(hash []byte, timeout time.Duration) (*ctypes.ResultTx, error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// the node fails the query until the tx is indexed
+		res, err := node.Tx(hash, !ctx.TrustNode)
+		if err == nil {
+			if !ctx.TrustNode {
+				if err := ctx.VerifyTxResult(res); err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		}
+		if time.Now().Add(confirmPollInterval).After(deadline) {
+			return nil, errors.Wrapf(err, "tx %X is not committed after %s", hash, timeout)
+		}
+		time.Sleep(confirmPollInterval)
+	}
+}
 
+// VerifyTxResult verifies the inclusion proof of the tx of res in the data of
+// the verified header of its block.
+func (ctx CLIContext) VerifyTxResult(res *ctypes.ResultTx) error {
+	check, err := ctx.Verify(res.Height)
+	if err != nil {
+		return err
+	}
+	return res.Proof.Validate(check.Header.DataHash)
+}
+
+// printCommitted writes the result of the committed tx of res to the output
+// of the context.
+func (ctx CLIContext) printCommitted(res *ctypes.ResultBroadcastTxCommit) error {
 	if ctx.JSON {
 		// Since JSON is intended for automated scripts, always include response in
 		// JSON mode.
@@ -144,14 +269,14 @@ func (ctx CLIContext) broadcastTxCommit(txBytes []byte) (*ctypes.ResultBroadcast
 			resJSON := toJSON{res.Height, res.Hash.String(), res.DeliverTx}
 			bz, err := ctx.Codec.MarshalJSON(resJSON)
 			if err != nil {
-				return res, err
+				return err
 			}
 
 			ctx.Output.Write(bz)
 			io.WriteString(ctx.Output, "\n")
 		}
 
-		return res, nil
+		return nil
 	}
 
 	if ctx.Output != nil {
@@ -166,5 +291,5 @@ func (ctx CLIContext) broadcastTxCommit(txBytes []byte) (*ctypes.ResultBroadcast
 		io.WriteString(ctx.Output, resStr)
 	}
 
-	return res, nil
+	return nil
 }
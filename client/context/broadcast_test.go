@@ -0,0 +1,87 @@
+package context
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// mockNode commits the txs broadcast to it after a number of polls of Tx.
+type mockNode struct {
+	rpcclient.Client
+
+	checkCode   uint32
+	deliverCode uint32
+	polls       int
+	committed   map[string]*ctypes.ResultTx
+}
+
+func (n *mockNode) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if n.checkCode == 0 {
+		n.committed[string(tx.Hash())] = &ctypes.ResultTx{
+			Hash: tx.Hash(), Height: 7, Tx: tx, TxResult: abci.ResponseDeliverTx{Code: n.deliverCode, Log: "delivered"},
+		}
+	}
+	return &ctypes.ResultBroadcastTx{Code: n.checkCode, Log: "checked", Hash: tx.Hash()}, nil
+}
+
+func (n *mockNode) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	if n.polls > 0 {
+		n.polls--
+		return nil, errors.New("tx not found")
+	}
+	res, ok := n.committed[string(hash)]
+	if !ok {
+		return nil, errors.New("tx not found")
+	}
+	return res, nil
+}
+
+func TestBroadcastTxSync(t *testing.T) {
+	confirmPollInterval = time.Millisecond
+	node := &mockNode{polls: 3, committed: make(map[string]*ctypes.ResultTx)}
+	var out bytes.Buffer
+	ctx := CLIContext{Codec: codec.New(), Client: node, Output: &out, TrustNode: true}.
+		WithBroadcastMode(BroadcastSync)
+
+	// returns with the mempool check
+	res, err := ctx.BroadcastTx([]byte("tx1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), res.Height)
+	require.Equal(t, "checked", res.CheckTx.Log)
+	require.Contains(t, out.String(), "sync tx sent")
+
+	// polls for the tx in a block
+	res, err = ctx.WithConfirmTimeout(time.Second).BroadcastTx([]byte("tx2"))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), res.Height)
+	require.Contains(t, out.String(), "Committed at block 7")
+
+	// a tx never committed times out
+	_, err = ctx.WaitForConfirmation([]byte("unknown"), 10*time.Millisecond)
+	require.Error(t, err)
+
+	// a tx failing the mempool check is not waited for
+	node.checkCode = 1
+	res, err = ctx.WithConfirmTimeout(time.Second).BroadcastTx([]byte("tx3"))
+	require.Error(t, err)
+	require.Equal(t, uint32(1), res.CheckTx.Code)
+
+	// a tx failing DeliverTx fails the broadcast
+	node.checkCode, node.deliverCode = 0, 2
+	res, err = ctx.WithConfirmTimeout(time.Second).BroadcastTx([]byte("tx4"))
+	require.Error(t, err)
+	require.Equal(t, uint32(2), res.DeliverTx.Code)
+
+	_, err = ctx.WithBroadcastMode("commit").BroadcastTx([]byte("tx5"))
+	require.Error(t, err)
+}
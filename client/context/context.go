@@ -2,24 +2,28 @@ package context
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/lite"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/keys"
+	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	cskeys "github.com/cosmos/cosmos-sdk/crypto/keys"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	tmlite "github.com/tendermint/tendermint/lite"
-	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
 
@@ -33,28 +37,30 @@ var (
 // CLIContext implements a typical CLI context created in SDK modules for
 // transaction handling and queries.
 type CLIContext struct {
-	Codec         *codec.Codec
-	AccDecoder    auth.AccountDecoder
-	Client        rpcclient.Client
-	Output        io.Writer
-	Height        int64
-	NodeURI       string
-	From          string
-	AccountStore  string
-	TrustNode     bool
-	UseLedger     bool
-	UseTss        bool
-	Async         bool
-	JSON          bool
-	PrintResponse bool
-	Verifier      tmlite.Verifier
-	VerifierHome  string
-	DryRun        bool
-	Dry           bool
-	GenerateOnly  bool
-	fromAddress   types.AccAddress
-	fromName      string
-	Indent        bool
+	Codec          *codec.Codec
+	AccDecoder     auth.AccountDecoder
+	Client         rpcclient.Client
+	Output         io.Writer
+	Height         int64
+	NodeURI        string
+	From           string
+	AccountStore   string
+	TrustNode      bool
+	UseLedger      bool
+	UseTss         bool
+	Async          bool
+	BroadcastMode  string
+	ConfirmTimeout time.Duration
+	JSON           bool
+	PrintResponse  bool
+	Verifier       tmlite.Verifier
+	VerifierHome   string
+	DryRun         bool
+	Dry            bool
+	GenerateOnly   bool
+	fromAddress    types.AccAddress
+	fromName       string
+	Indent         bool
 }
 
 // NewCLIContext returns a new initialized CLIContext with parameters from the
@@ -68,7 +74,7 @@ func NewCLIContext() CLIContext {
 	}
 
 	from := viper.GetString(client.FlagFrom)
-	fromAddress, fromName := fromFields(from)
+	fromAddress, fromName := fromFields(from, viper.GetBool(client.FlagGenerateOnly))
 
 	// We need to use a single verifier for all contexts
 	if verifier == nil || verifierHome != viper.GetString(cli.HomeFlag) {
@@ -76,26 +82,31 @@ func NewCLIContext() CLIContext {
 		verifierHome = viper.GetString(cli.HomeFlag)
 	}
 
+	// Ledger keys sign with the device selected by --ledger-device
+	ccrypto.SelectLedger(viper.GetString(client.FlagLedgerDevice))
+
 	return CLIContext{
-		Client:        rpc,
-		Output:        os.Stdout,
-		NodeURI:       nodeURI,
-		AccountStore:  ctxAccStoreName,
-		From:          viper.GetString(client.FlagFrom),
-		Height:        viper.GetInt64(client.FlagHeight),
-		TrustNode:     viper.GetBool(client.FlagTrustNode),
-		UseLedger:     viper.GetBool(client.FlagUseLedger),
-		UseTss:        viper.GetBool(client.FlagUseTss),
-		Async:         viper.GetBool(client.FlagAsync),
-		JSON:          viper.GetBool(client.FlagJson),
-		PrintResponse: viper.GetBool(client.FlagPrintResponse),
-		Verifier:      verifier,
-		DryRun:        viper.GetBool(client.FlagDryRun),
-		Dry:           viper.GetBool(client.FlagDry),
-		GenerateOnly:  viper.GetBool(client.FlagGenerateOnly),
-		fromAddress:   fromAddress,
-		fromName:      fromName,
-		Indent:        viper.GetBool(client.FlagIndentResponse),
+		Client:         rpc,
+		Output:         os.Stdout,
+		NodeURI:        nodeURI,
+		AccountStore:   ctxAccStoreName,
+		From:           viper.GetString(client.FlagFrom),
+		Height:         viper.GetInt64(client.FlagHeight),
+		TrustNode:      viper.GetBool(client.FlagTrustNode),
+		UseLedger:      viper.GetBool(client.FlagUseLedger),
+		UseTss:         viper.GetBool(client.FlagUseTss),
+		Async:          viper.GetBool(client.FlagAsync),
+		BroadcastMode:  viper.GetString(client.FlagBroadcastMode),
+		ConfirmTimeout: viper.GetDuration(client.FlagConfirmTimeout),
+		JSON:           viper.GetBool(client.FlagJson),
+		PrintResponse:  viper.GetBool(client.FlagPrintResponse),
+		Verifier:       verifier,
+		DryRun:         viper.GetBool(client.FlagDryRun),
+		Dry:            viper.GetBool(client.FlagDry),
+		GenerateOnly:   viper.GetBool(client.FlagGenerateOnly),
+		fromAddress:    fromAddress,
+		fromName:       fromName,
+		Indent:         viper.GetBool(client.FlagIndentResponse),
 	}
 }
 
@@ -128,11 +139,23 @@ func createVerifier() tmlite.Verifier {
 		fmt.Printf("Must specify these options: %s when --trust-node is false\n", errMsg.String())
 		os.Exit(1)
 	}
+	opts := lite.TrustOptions{
+		Period: viper.GetDuration(client.FlagTrustingPeriod),
+		Height: viper.GetInt64(client.FlagTrustHeight),
+	}
+	if trustHash := viper.GetString(client.FlagTrustHash); trustHash != "" {
+		hash, err := hex.DecodeString(trustHash)
+		if err != nil {
+			fmt.Printf("Invalid --%s: %s\n", client.FlagTrustHash, err.Error())
+			os.Exit(1)
+		}
+		opts.Hash = hash
+	}
+
 	node := rpcclient.NewHTTP(nodeURI, "/websocket")
-	cacheSize := 10 // TODO: determine appropriate cache size
-	verifier, err := tmliteProxy.NewVerifier(
-		chainID, filepath.Join(home, ".bnblite"),
-		node, log.NewNopLogger(), cacheSize,
+	verifier, err := lite.NewVerifier(
+		chainID, dbm.NewDB("trust-base", dbm.GoLevelDBBackend, filepath.Join(home, ".bnblite")),
+		lite.NewNodeProvider(chainID, node), opts, log.NewNopLogger(),
 	)
 
 	if err != nil {
@@ -144,10 +167,18 @@ func createVerifier() tmlite.Verifier {
 	return verifier
 }
 
-func fromFields(from string) (fromAddr types.AccAddress, fromName string) {
+// fromFields resolves the --from key. With genOnly an address needs no key,
+// so an online machine can generate transactions for keys held in cold
+// storage.
+func fromFields(from string, genOnly bool) (fromAddr types.AccAddress, fromName string) {
 	if from == "" {
 		return nil, ""
 	}
+	if genOnly {
+		if addr, err := types.AccAddressFromBech32(from); err == nil {
+			return addr, ""
+		}
+	}
 
 	keybase, err := keys.GetKeyBase()
 	if err != nil {
@@ -226,6 +257,20 @@ func (ctx CLIContext) WithClient(client rpcclient.Client) CLIContext {
 	return ctx
 }
 
+// WithBroadcastMode returns a copy of the context with an updated broadcast
+// mode.
+func (ctx CLIContext) WithBroadcastMode(mode string) CLIContext {
+	ctx.BroadcastMode = mode
+	return ctx
+}
+
+// WithConfirmTimeout returns a copy of the context with an updated timeout
+// of the confirmation of the txs broadcast in sync mode.
+func (ctx CLIContext) WithConfirmTimeout(timeout time.Duration) CLIContext {
+	ctx.ConfirmTimeout = timeout
+	return ctx
+}
+
 // WithUseLedger returns a copy of the context with an updated UseLedger flag.
 func (ctx CLIContext) WithUseLedger(useLedger bool) CLIContext {
 	ctx.UseLedger = useLedger
@@ -7,7 +7,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/crypto/merkle"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	tmliteErr "github.com/tendermint/tendermint/lite/errors"
 	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
@@ -15,6 +14,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/lite"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -177,6 +177,13 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 		return res, errors.Errorf(resp.Log)
 	}
 
+	if !ctx.TrustNode && strings.HasPrefix(path, "/custom/") {
+		if _, err := ctx.VerifyProof(resp); err != nil {
+			return nil, err
+		}
+		return resp.Value, nil
+	}
+
 	// data from trusted node or subspace query doesn't need verification
 	if ctx.TrustNode || !isQueryStoreWithProof(path) {
 		return resp.Value, nil
@@ -215,33 +222,29 @@ func (ctx CLIContext) verifyProof(queryPath string, resp abci.ResponseQuery) err
 		return err
 	}
 
-	// TODO: Instead of reconstructing, stash on CLIContext field?
-	prt := store.DefaultProofRuntime()
-
 	// TODO: Better convention for path?
 	storeName, err := parseQueryStorePath(queryPath)
 	if err != nil {
 		return err
 	}
 
-	kp := merkle.KeyPath{}
-	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
-	kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
+	return lite.VerifyStoreQuery(storeName, resp, commit.Header.AppHash)
+}
 
-	if resp.Value == nil {
-		err = prt.VerifyAbsence(resp.Proof, commit.Header.AppHash, kp.String())
-		if err != nil {
-			return errors.Wrap(err, "failed to prove merkle absence proof")
-		}
-		return nil
+// VerifyProof verifies the reads of the custom querier of a proven custom
+// query response against the app hash certified by the verifier, and returns
+// them. The response is trusted to be computed from the reads by the querier.
+func (ctx CLIContext) VerifyProof(resp abci.ResponseQuery) ([]store.QueryRead, error) {
+	if ctx.Verifier == nil {
+		return nil, fmt.Errorf("missing valid certifier to verify data from distrusted node")
 	}
-
-	err = prt.VerifyValue(resp.Proof, commit.Header.AppHash, kp.String(), resp.Value)
+	// the AppHash for height H is in header H+1
+	commit, err := ctx.Verify(resp.Height + 1)
 	if err != nil {
-		return errors.Wrap(err, "failed to prove merkle existence proof")
+		return nil, err
 	}
 
-	return nil
+	return lite.VerifyCustomQuery(resp, commit.Header.AppHash)
 }
 
 // queryStore performs a query from a Tendermint node with the provided a store
@@ -279,11 +282,11 @@ func parseQueryStorePath(path string) (storeName string, err error) {
 	paths := strings.SplitN(path[1:], "/", 3)
 	switch {
 	case len(paths) != 3:
-		return "", errors.New("expected format like /store/<storeName>/key|ics23-key")
+		return "", errors.New("expected format like /store/<storeName>/key|ics23-key|range")
 	case paths[0] != "store":
-		return "", errors.New("expected format like /store/<storeName>/key|ics23-key")
-	case paths[2] != "key" && paths[2] != "ics23-key":
-		return "", errors.New("expected format like /store/<storeName>/key|ics23-key")
+		return "", errors.New("expected format like /store/<storeName>/key|ics23-key|range")
+	case paths[2] != "key" && paths[2] != "ics23-key" && paths[2] != "range":
+		return "", errors.New("expected format like /store/<storeName>/key|ics23-key|range")
 	}
 
 	return paths[1], nil
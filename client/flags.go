@@ -3,16 +3,23 @@ package client
 import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/lite"
 )
 
 // nolint
 const (
 	FlagUseLedger      = "ledger"
+	FlagLedgerDevice   = "ledger-device"
 	FlagUseTss         = "tss"
+	FlagKeyringBackend = "keyring-backend"
 	FlagChainID        = "chain-id"
 	FlagNode           = "node"
 	FlagHeight         = "height"
 	FlagTrustNode      = "trust-node"
+	FlagTrustingPeriod = "trusting-period"
+	FlagTrustHeight    = "trust-height"
+	FlagTrustHash      = "trust-hash"
 	FlagFrom           = "from"
 	FlagName           = "name"
 	FlagAccountNumber  = "account-number"
@@ -20,6 +27,8 @@ const (
 	FlagMemo           = "memo"
 	FlagSource         = "source"
 	FlagAsync          = "async"
+	FlagBroadcastMode  = "broadcast-mode"
+	FlagConfirmTimeout = "confirm-timeout"
 	FlagJson           = "json"
 	FlagPrintResponse  = "print-response"
 	FlagDryRun         = "dry-run"
@@ -40,6 +49,7 @@ func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 	for _, c := range cmds {
 		c.Flags().Bool(FlagIndentResponse, false, "Add indent to JSON response")
 		c.Flags().Bool(FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+		AddTrustFlags(c)
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
@@ -52,6 +62,17 @@ func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 	return cmds
 }
 
+// AddTrustFlags adds the flags of the trust of the light client verifying
+// the responses of a distrusted node
+func AddTrustFlags(c *cobra.Command) {
+	c.Flags().Duration(FlagTrustingPeriod, lite.DefaultTrustingPeriod, "Trusting period of the verified headers, shorter than the unbonding time of the chain")
+	c.Flags().Int64(FlagTrustHeight, 0, "Height of the header trusted to initialize the trust, omit to trust the latest header of the node")
+	c.Flags().String(FlagTrustHash, "", "Hex hash of the header of --trust-height, omit to trust the header of the node")
+	viper.BindPFlag(FlagTrustingPeriod, c.Flags().Lookup(FlagTrustingPeriod))
+	viper.BindPFlag(FlagTrustHeight, c.Flags().Lookup(FlagTrustHeight))
+	viper.BindPFlag(FlagTrustHash, c.Flags().Lookup(FlagTrustHash))
+}
+
 // PostCommands adds common flags for commands to post tx
 func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 	for _, c := range cmds {
@@ -64,8 +85,12 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Bool(FlagUseLedger, false, "Use a connected Ledger device")
+		c.Flags().String(FlagLedgerDevice, "", "Index or serial of the Ledger device to use when several are connected")
 		c.Flags().Bool(FlagUseTss, false, "Use a tss vault")
+		c.Flags().String(FlagKeyringBackend, "db", "Storage of the keys: db, os, file or test")
 		c.Flags().Bool(FlagAsync, false, "Broadcast transactions asynchronously")
+		c.Flags().String(FlagBroadcastMode, "", "Broadcast mode: block, sync or async, omit to use --async or else block")
+		c.Flags().Duration(FlagConfirmTimeout, 0, "In sync mode, poll the node for the tx in a block up to this timeout, e.g. 30s")
 		c.Flags().Bool(FlagJson, false, "Return output in json format")
 		c.Flags().Bool(FlagPrintResponse, true, "Return tx response (only works with async = false)")
 		c.Flags().Bool(FlagTrustNode, true, "Trust connected full node (don't verify proofs for responses)")
@@ -75,7 +100,9 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Bool(FlagGenerateOnly, false, "Build an unsigned transaction and write it to STDOUT")
 		viper.BindPFlag(FlagTrustNode, c.Flags().Lookup(FlagTrustNode))
 		viper.BindPFlag(FlagUseLedger, c.Flags().Lookup(FlagUseLedger))
+		viper.BindPFlag(FlagLedgerDevice, c.Flags().Lookup(FlagLedgerDevice))
 		viper.BindPFlag(FlagUseTss, c.Flags().Lookup(FlagUseTss))
+		viper.BindPFlag(FlagKeyringBackend, c.Flags().Lookup(FlagKeyringBackend))
 		viper.BindPFlag(FlagChainID, c.Flags().Lookup(FlagChainID))
 		viper.BindPFlag(FlagNode, c.Flags().Lookup(FlagNode))
 	}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	gocontext "context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// accountQuerier reads the accounts from the store of the auth module.
+type accountQuerier struct {
+	cliCtx    context.CLIContext
+	decoder   auth.AccountDecoder
+	storeName string
+}
+
+// account returns the account of the bech32 address, or a NotFound error if
+// it does not exist.
+func (q accountQuerier) account(bech32Addr string) (sdk.Account, error) {
+	addr, err := sdk.AccAddressFromBech32(bech32Addr)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	res, err := q.cliCtx.QueryStore(auth.AddressStoreKey(addr), q.storeName)
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+	// the query will return empty if there is no data for this account
+	if len(res) == 0 {
+		return nil, status.Errorf(codes.NotFound, "account %s not found", bech32Addr)
+	}
+
+	account, err := q.decoder(res)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return account, nil
+}
+
+type authServer struct {
+	accounts accountQuerier
+}
+
+var _ types.AuthServer = authServer{}
+
+func (s authServer) Account(_ gocontext.Context, req *types.QueryAccountRequest) (*types.QueryAccountResponse, error) {
+	account, err := s.accounts.account(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var pubKey string
+	if account.GetPubKey() != nil {
+		pubKey, err = sdk.Bech32ifyAccPub(account.GetPubKey())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &types.QueryAccountResponse{
+		Account: &types.Account{
+			Address:       account.GetAddress().String(),
+			Coins:         toCoins(account.GetCoins()),
+			PublicKey:     pubKey,
+			AccountNumber: account.GetAccountNumber(),
+			Sequence:      account.GetSequence(),
+		},
+	}, nil
+}
+
+func toCoins(coins sdk.Coins) []*types.Coin {
+	res := make([]*types.Coin, len(coins))
+	for i, coin := range coins {
+		res[i] = &types.Coin{Denom: coin.Denom, Amount: coin.Amount}
+	}
+	return res
+}
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	gocontext "context"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+)
+
+type bankServer struct {
+	accounts accountQuerier
+}
+
+var _ types.BankServer = bankServer{}
+
+func (s bankServer) Balance(_ gocontext.Context, req *types.QueryBalanceRequest) (*types.QueryBalanceResponse, error) {
+	account, err := s.accounts.account(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryBalanceResponse{Balances: toCoins(account.GetCoins())}, nil
+}
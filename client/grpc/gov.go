@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	gocontext "context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+)
+
+type govServer struct {
+	cliCtx context.CLIContext
+}
+
+var _ types.GovServer = govServer{}
+
+func (s govServer) Proposals(_ gocontext.Context, req *types.QueryProposalsRequest) (*types.QueryProposalsResponse, error) {
+	params := gov.QueryProposalsParams{
+		BaseParams:         gov.NewBaseParams(req.SideChainId),
+		NumLatestProposals: req.Limit,
+	}
+	if len(req.Voter) != 0 {
+		voter, err := sdk.AccAddressFromBech32(req.Voter)
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+		params.Voter = voter
+	}
+	if len(req.Depositor) != 0 {
+		depositor, err := sdk.AccAddressFromBech32(req.Depositor)
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+		params.Depositer = depositor
+	}
+	if len(req.Status) != 0 {
+		normalized := govclient.NormalizeProposalStatus(req.Status)
+		if normalized == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "'%s' is not a valid proposal status", req.Status)
+		}
+		proposalStatus, err := gov.ProposalStatusFromString(normalized)
+		if err != nil {
+			return nil, invalidArgument(err)
+		}
+		params.ProposalStatus = proposalStatus
+	}
+
+	bz, err := s.cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := s.cliCtx.QueryWithData("custom/gov/proposals", bz)
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+
+	var proposals []gov.Proposal
+	if err := s.cliCtx.Codec.UnmarshalJSON(res, &proposals); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &types.QueryProposalsResponse{Proposals: make([]*types.Proposal, len(proposals))}
+	for i, proposal := range proposals {
+		resp.Proposals[i] = toProposal(proposal)
+	}
+	return resp, nil
+}
+
+func (s govServer) Proposal(_ gocontext.Context, req *types.QueryProposalRequest) (*types.QueryProposalResponse, error) {
+	bz, err := s.cliCtx.Codec.MarshalJSON(gov.QueryProposalParams{
+		BaseParams: gov.NewBaseParams(req.SideChainId),
+		ProposalID: req.ProposalId,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := s.cliCtx.QueryWithData("custom/gov/proposal", bz)
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+
+	var proposal gov.Proposal
+	if err := s.cliCtx.Codec.UnmarshalJSON(res, &proposal); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &types.QueryProposalResponse{Proposal: toProposal(proposal)}, nil
+}
+
+func toProposal(proposal gov.Proposal) *types.Proposal {
+	tally := proposal.GetTallyResult()
+	return &types.Proposal{
+		ProposalId:   proposal.GetProposalID(),
+		Title:        proposal.GetTitle(),
+		Description:  proposal.GetDescription(),
+		ProposalType: proposal.GetProposalType().String(),
+		Status:       proposal.GetStatus().String(),
+		TallyResult: &types.TallyResult{
+			Yes:        tally.Yes.String(),
+			Abstain:    tally.Abstain.String(),
+			No:         tally.No.String(),
+			NoWithVeto: tally.NoWithVeto.String(),
+			Total:      tally.Total.String(),
+		},
+		SubmitTime:      timestamppb.New(proposal.GetSubmitTime()),
+		TotalDeposit:    toCoins(proposal.GetTotalDeposit()),
+		VotingStartTime: timestamppb.New(proposal.GetVotingStartTime()),
+		VotingPeriod:    durationpb.New(proposal.GetVotingPeriod()),
+	}
+}
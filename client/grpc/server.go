@@ -0,0 +1,56 @@
+// Package grpc serves the light client queries of the auth, bank, staking and
+// gov modules and the broadcast of transactions over gRPC, next to the REST
+// server. The services are described by the .proto files of the types
+// package and the server supports reflection, so clients can discover them.
+package grpc
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+)
+
+// NewServer returns a gRPC server answering the queries with cliCtx. The
+// accounts are read from the store named accStoreName and decoded with cdc,
+// which must have the types of the application registered.
+func NewServer(cliCtx context.CLIContext, cdc *codec.Codec, accStoreName string) *grpc.Server {
+	cliCtx = cliCtx.WithCodec(cdc)
+	accounts := accountQuerier{cliCtx: cliCtx, decoder: authcmd.GetAccountDecoder(cdc), storeName: accStoreName}
+
+	server := grpc.NewServer()
+	types.RegisterAuthServer(server, authServer{accounts})
+	types.RegisterBankServer(server, bankServer{accounts})
+	types.RegisterStakingServer(server, stakingServer{cliCtx})
+	types.RegisterGovServer(server, govServer{cliCtx})
+	types.RegisterTxServer(server, txServer{cliCtx})
+	reflection.Register(server)
+	return server
+}
+
+// Serve serves the gRPC queries on listener in the background. The returned
+// channel receives the error which stopped the server.
+func Serve(server *grpc.Server, listener net.Listener) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	return errCh
+}
+
+// invalidArgument returns the error of a malformed request.
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// queryFailed returns the error of a query the node failed to answer.
+func queryFailed(err error) error {
+	return status.Error(codes.Unknown, err.Error())
+}
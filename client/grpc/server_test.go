@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	gocontext "context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func dialTestServer(t *testing.T) (*grpc.ClientConn, func()) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(context.NewCLIContext(), app.MakeCodec(), "acc")
+	Serve(server, listener)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(
+		func(gocontext.Context, string) (net.Conn, error) { return listener.Dial() },
+	))
+	require.NoError(t, err)
+	return conn, func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestServerReflection(t *testing.T) {
+	conn, cleanup := dialTestServer(t)
+	defer cleanup()
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(gocontext.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range res.GetListServicesResponse().Service {
+		services = append(services, service.Name)
+	}
+	require.ElementsMatch(t, []string{
+		"cosmos.sdk.grpc.v1.Auth",
+		"cosmos.sdk.grpc.v1.Bank",
+		"cosmos.sdk.grpc.v1.Staking",
+		"cosmos.sdk.grpc.v1.Gov",
+		"cosmos.sdk.grpc.v1.Tx",
+		"grpc.reflection.v1alpha.ServerReflection",
+	}, services)
+
+	// the descriptors of the services are served too
+	require.NoError(t, stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: "cosmos.sdk.grpc.v1.Staking"},
+	}))
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.NotEmpty(t, res.GetFileDescriptorResponse().FileDescriptorProto)
+}
+
+func TestServerInvalidArguments(t *testing.T) {
+	conn, cleanup := dialTestServer(t)
+	defer cleanup()
+	ctx := gocontext.Background()
+
+	_, err := types.NewAuthClient(conn).Account(ctx, &types.QueryAccountRequest{Address: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = types.NewBankClient(conn).Balance(ctx, &types.QueryBalanceRequest{Address: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = types.NewStakingClient(conn).Validator(ctx, &types.QueryValidatorRequest{ValidatorAddress: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = types.NewGovClient(conn).Proposals(ctx, &types.QueryProposalsRequest{Status: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = types.NewTxClient(conn).BroadcastTx(ctx, &types.BroadcastTxRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = types.NewTxClient(conn).BroadcastTx(ctx, &types.BroadcastTxRequest{Tx: []byte{1}, Mode: 3})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToValidator(t *testing.T) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	valAddr := sdk.ValAddress(pubKey.Address())
+	validator := stake.NewValidator(valAddr, pubKey, stake.Description{Moniker: "moniker"})
+	validator.Tokens = sdk.NewDecWithoutFra(10)
+
+	v, err := toValidator(validator)
+	require.NoError(t, err)
+	require.Equal(t, valAddr.String(), v.OperatorAddress)
+	require.Equal(t, sdk.MustBech32ifyConsPub(pubKey), v.ConsensusPubkey)
+	require.Equal(t, "Unbonded", v.Status)
+	require.Equal(t, validator.Tokens.String(), v.Tokens)
+	require.Equal(t, "moniker", v.Description.Moniker)
+	require.Equal(t, validator.Commission.Rate.String(), v.Commission.Rate)
+}
+
+func TestToProposal(t *testing.T) {
+	submitTime := time.Unix(1600000000, 0).UTC()
+	proposal := &gov.TextProposal{
+		ProposalID:   7,
+		Title:        "title",
+		ProposalType: gov.ProposalTypeText,
+		Status:       gov.StatusVotingPeriod,
+		TallyResult:  gov.EmptyTallyResult(),
+		SubmitTime:   submitTime,
+		TotalDeposit: sdk.Coins{sdk.NewCoin("steak", 10)},
+		VotingPeriod: time.Hour,
+	}
+
+	p := toProposal(proposal)
+	require.Equal(t, int64(7), p.ProposalId)
+	require.Equal(t, "Text", p.ProposalType)
+	require.Equal(t, "VotingPeriod", p.Status)
+	require.Equal(t, submitTime, p.SubmitTime.AsTime())
+	require.Equal(t, []*types.Coin{{Denom: "steak", Amount: 10}}, p.TotalDeposit)
+	require.Equal(t, time.Hour, p.VotingPeriod.AsDuration())
+}
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	gocontext "context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+type stakingServer struct {
+	cliCtx context.CLIContext
+}
+
+var _ types.StakingServer = stakingServer{}
+
+func (s stakingServer) Validators(_ gocontext.Context, req *types.QueryValidatorsRequest) (*types.QueryValidatorsResponse, error) {
+	bz, err := s.cliCtx.Codec.MarshalJSON(stake.NewBaseParams(req.SideChainId))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := s.cliCtx.QueryWithData("custom/stake/validators", bz)
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+
+	var validators []stake.Validator
+	if err := s.cliCtx.Codec.UnmarshalJSON(res, &validators); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &types.QueryValidatorsResponse{Validators: make([]*types.Validator, len(validators))}
+	for i, validator := range validators {
+		if resp.Validators[i], err = toValidator(validator); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (s stakingServer) Validator(_ gocontext.Context, req *types.QueryValidatorRequest) (*types.QueryValidatorResponse, error) {
+	valAddr, err := sdk.ValAddressFromBech32(req.ValidatorAddress)
+	if err != nil {
+		return nil, invalidArgument(err)
+	}
+	bz, err := s.cliCtx.Codec.MarshalJSON(stake.QueryValidatorParams{
+		BaseParams:    stake.NewBaseParams(req.SideChainId),
+		ValidatorAddr: valAddr,
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res, err := s.cliCtx.QueryWithData("custom/stake/validator", bz)
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+
+	var validator stake.Validator
+	if err := s.cliCtx.Codec.UnmarshalJSON(res, &validator); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	v, err := toValidator(validator)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryValidatorResponse{Validator: v}, nil
+}
+
+func toValidator(validator stake.Validator) (*types.Validator, error) {
+	var consPubKey string
+	if validator.ConsPubKey != nil {
+		var err error
+		consPubKey, err = sdk.Bech32ifyConsPub(validator.ConsPubKey)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	return &types.Validator{
+		OperatorAddress: validator.OperatorAddr.String(),
+		ConsensusPubkey: consPubKey,
+		Jailed:          validator.Jailed,
+		Status:          sdk.BondStatusToString(validator.Status),
+		Tokens:          validator.Tokens.String(),
+		DelegatorShares: validator.DelegatorShares.String(),
+		Description: &types.Description{
+			Moniker:  validator.Description.Moniker,
+			Identity: validator.Description.Identity,
+			Website:  validator.Description.Website,
+			Details:  validator.Description.Details,
+		},
+		BondHeight:      validator.BondHeight,
+		UnbondingHeight: validator.UnbondingHeight,
+		UnbondingTime:   timestamppb.New(validator.UnbondingMinTime),
+		Commission: &types.Commission{
+			Rate:          validator.Commission.Rate.String(),
+			MaxRate:       validator.Commission.MaxRate.String(),
+			MaxChangeRate: validator.Commission.MaxChangeRate.String(),
+			UpdateTime:    timestamppb.New(validator.Commission.UpdateTime),
+		},
+		FeeAddress:   validator.FeeAddr.String(),
+		SideChainId:  validator.SideChainId,
+		SideConsAddr: validator.SideConsAddr,
+		SideFeeAddr:  validator.SideFeeAddr,
+	}, nil
+}
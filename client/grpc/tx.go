@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	gocontext "context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc/types"
+)
+
+type txServer struct {
+	cliCtx context.CLIContext
+}
+
+var _ types.TxServer = txServer{}
+
+func (s txServer) BroadcastTx(_ gocontext.Context, req *types.BroadcastTxRequest) (*types.BroadcastTxResponse, error) {
+	if len(req.Tx) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tx is empty")
+	}
+
+	switch req.Mode {
+	case types.BroadcastMode_BROADCAST_MODE_SYNC:
+		return toBroadcastTxResponse(s.cliCtx.BroadcastTxSync(req.Tx))
+
+	case types.BroadcastMode_BROADCAST_MODE_ASYNC:
+		return toBroadcastTxResponse(s.cliCtx.BroadcastTxAsync(req.Tx))
+
+	case types.BroadcastMode_BROADCAST_MODE_COMMIT:
+		node, err := s.cliCtx.GetNode()
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		res, err := node.BroadcastTxCommit(req.Tx)
+		if err != nil {
+			return nil, queryFailed(err)
+		}
+		resp := &types.BroadcastTxResponse{Txhash: res.Hash.String(), Height: res.Height}
+		if !res.CheckTx.IsOK() {
+			resp.Code, resp.Log = res.CheckTx.Code, res.CheckTx.Log
+		} else {
+			resp.Code, resp.Log, resp.Data = res.DeliverTx.Code, res.DeliverTx.Log, res.DeliverTx.Data
+		}
+		return resp, nil
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown broadcast mode %d", req.Mode)
+	}
+}
+
+func toBroadcastTxResponse(res *ctypes.ResultBroadcastTx, err error) (*types.BroadcastTxResponse, error) {
+	if err != nil {
+		return nil, queryFailed(err)
+	}
+	return &types.BroadcastTxResponse{
+		Txhash: res.Hash.String(),
+		Code:   res.Code,
+		Log:    res.Log,
+		Data:   res.Data,
+	}, nil
+}
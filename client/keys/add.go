@@ -14,12 +14,14 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	"github.com/tendermint/tendermint/libs/cli"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 )
 
 const (
@@ -29,6 +31,8 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+	flagHDPath   = "hd-path"
+	flagMultisig = "multisig"
 
 	flagTssHome   = "tss-home"
 	flagTssVault  = "tss-vault"
@@ -45,20 +49,29 @@ func addKeyCommand() *cobra.Command {
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
 If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+phrase, otherwise, a new key will be generated.
+
+With --multisig, store a reference to a multisig threshold key made of the
+public keys of the given stored keys, in the given order, which requires
+--multisig-threshold of their signatures. It is the key printed by show for
+the same keys.`,
 		RunE: runAddCmd,
 	}
-	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519), or eth_secp256k1 for a key of the Ledger Ethereum app")
 	cmd.Flags().Bool(client.FlagUseLedger, false, "Store a local reference to a private key on a Ledger device")
+	cmd.Flags().String(client.FlagLedgerDevice, "", "Index or serial of the Ledger device to use when several are connected")
 	cmd.Flags().Bool(client.FlagUseTss, false, "Store a local reference to a private key on a Tss vault")
 	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover existing key instead of creating")
 	cmd.Flags().Bool(flagNoBackup, false, "Don't print out seed phrase (if others are watching the terminal)")
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().String(flagHDPath, "", "BIP44 derivation path of a recovered or Ledger key, e.g. m/44'/714'/0'/0/5; overrides --account and --index")
 	cmd.Flags().String(flagTssHome, "", "Path to home of tss client")
 	cmd.Flags().String(flagTssVault, "", "Vault under tss home, default value means there is no sub vault")
 	cmd.Flags().String(flagTssPubkey, "", "Hex encoded secp256k1.PubKeySecp256k1, only used when this command run as a child-process of tss cli")
+	cmd.Flags().StringSlice(flagMultisig, nil, "Construct and store a multisig public key made of the given comma separated key names")
+	cmd.Flags().Uint(flagMultiSigThreshold, 1, "K out of N required signatures of the --multisig key")
 	return cmd
 }
 
@@ -94,6 +107,20 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		multisigKeys := viper.GetStringSlice(flagMultisig)
+		if len(multisigKeys) != 0 {
+			pk, err := multisigPubKey(kb, multisigKeys, viper.GetInt(flagMultiSigThreshold))
+			if err != nil {
+				return err
+			}
+			info, err := kb.CreateMulti(name, pk)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Key %q saved to disk.\n", info.GetName())
+			return nil
+		}
+
 		// ask for a password when generating a local key
 		if !(viper.GetBool(client.FlagUseLedger) || viper.GetBool(client.FlagUseTss)) {
 			pass, err = client.GetCheckPassword(
@@ -109,7 +136,17 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		account := uint32(viper.GetInt(flagAccount))
 		index := uint32(viper.GetInt(flagIndex))
 		path := ccrypto.DerivationPath{44, 714, account, 0, index}
+		ccrypto.SelectLedger(viper.GetString(client.FlagLedgerDevice))
 		algo := keys.SigningAlgo(viper.GetString(flagType))
+		if algo == keys.EthSecp256k1 {
+			path = ccrypto.NewEthBIP44Path(account, index)
+		}
+		if hdPath := viper.GetString(flagHDPath); hdPath != "" {
+			path, err = ccrypto.ParseDerivationPath(hdPath)
+			if err != nil {
+				return err
+			}
+		}
 		info, err := kb.CreateLedger(name, path, algo)
 		if err != nil {
 			return err
@@ -148,7 +185,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		info, err := kb.CreateKey(name, seed, pass)
+		info, err := recoverKey(kb, name, seed, pass, viper.GetString(flagHDPath))
 		if err != nil {
 			return err
 		}
@@ -166,6 +203,44 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// recoverKey stores the key derived from the BIP39 mnemonic at hdPath, or at
+// the fundraiser path if hdPath is empty.
+func recoverKey(kb keys.Keybase, name, mnemonic, pass, hdPath string) (keys.Info, error) {
+	if hdPath == "" {
+		return kb.CreateKey(name, mnemonic, pass)
+	}
+
+	params, err := hd.NewParamsFromPath(hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return kb.Derive(name, mnemonic, "", pass, *params)
+}
+
+// multisigPubKey returns the multisig public key requiring threshold
+// signatures of the stored keys names.
+func multisigPubKey(kb keys.Keybase, names []string, threshold int) (crypto.PubKey, error) {
+	if err := validateMultisigThreshold(threshold, len(names)); err != nil {
+		return nil, err
+	}
+
+	pks := make([]crypto.PubKey, len(names))
+	for i, keyName := range names {
+		info, err := kb.Get(keyName)
+		if err != nil {
+			return nil, err
+		}
+		for _, pk := range pks[:i] {
+			if pk.Equals(info.GetPubKey()) {
+				return nil, fmt.Errorf("key %s is part of the multisig twice", keyName)
+			}
+		}
+		pks[i] = info.GetPubKey()
+	}
+
+	return multisig.NewPubKeyMultisigThreshold(threshold, pks), nil
+}
+
 func printCreate(info keys.Info, seed string) {
 	output := viper.Get(cli.OutputFlag)
 	switch output {
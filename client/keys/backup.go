@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+)
+
+func backupKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup <file>",
+		Short: "Back up all the local keys to an encrypted archive",
+		Long: `Write all the keys to <file> in a single ASCII armored archive, encrypted
+with a new passphrase and checksummed. The private keys of the local keys stay
+encrypted with their own passphrases, and the Ledger, offline, multisig and tss
+keys are backed up as references. The keys can be restored on another machine
+with the restore command.`,
+		RunE: runBackupCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func runBackupCmd(cmd *cobra.Command, args []string) error {
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	passphrase, err := client.GetCheckPassword(
+		"Enter a passphrase to encrypt the backup:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	armor, records, err := kb.Backup(passphrase)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(args[0], []byte(armor), 0600); err != nil {
+		return err
+	}
+	printBackupRecords(records)
+	fmt.Printf("%d keys backed up to %s\n", len(records), args[0])
+	return nil
+}
+
+func restoreKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore the keys of an archive written by the backup command",
+		Long: `Store all the keys of the archive <file> written by the backup command. The
+checksums of the archive are verified, and no key is restored if any is
+corrupted or has the name of an existing key. The local keys keep the
+passphrases they were backed up with.`,
+		RunE: runRestoreCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func runRestoreCmd(cmd *cobra.Command, args []string) error {
+	kb, err := GetKeyBaseWithWritePerm()
+	if err != nil {
+		return err
+	}
+
+	armor, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	passphrase, err := client.GetPassword("Enter the passphrase of the backup:", buf)
+	if err != nil {
+		return err
+	}
+
+	records, err := kb.Restore(string(armor), passphrase)
+	if err != nil {
+		return err
+	}
+	printBackupRecords(records)
+	fmt.Printf("%d keys restored\n", len(records))
+	return nil
+}
+
+func printBackupRecords(records []keys.BackupRecord) {
+	for _, record := range records {
+		kind := "key"
+		if record.Type != keys.TypeLocal.String() {
+			kind = "reference"
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", record.Name, record.Type, kind, record.Address)
+	}
+}
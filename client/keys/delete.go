@@ -26,7 +26,7 @@ func deleteKeyCommand() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 	}
 	cmd.Flags().BoolP(flagYes, "y", false,
-		"Skip confirmation prompt when deleting offline or tss key references")
+		"Skip confirmation prompt when deleting offline, tss or multisig key references")
 	return cmd
 }
 
@@ -46,7 +46,8 @@ func runDeleteCmd(cmd *cobra.Command, args []string) error {
 	buf := client.BufferStdin()
 	if info.GetType() == keys.TypeLedger ||
 		info.GetType() == keys.TypeOffline ||
-		info.GetType() == keys.TypeTss {
+		info.GetType() == keys.TypeTss ||
+		info.GetType() == keys.TypeMulti {
 		if !viper.GetBool(flagYes) {
 			if err := confirmDeletion(buf); err != nil {
 				return err
@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func exportKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export the private key of a local key",
+		Long: `Print the private key of the local key <name> in ASCII armored format,
+encrypted with a new passphrase. The key can be imported with the import
+command, the private key itself is never printed in clear.`,
+		RunE: runExportCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func runExportCmd(cmd *cobra.Command, args []string) error {
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	decryptPassphrase, err := client.GetPassword("Enter the passphrase of the key:", buf)
+	if err != nil {
+		return err
+	}
+	encryptPassphrase, err := client.GetCheckPassword(
+		"Enter a passphrase to encrypt the exported key:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	armor, err := kb.ExportPrivKey(args[0], decryptPassphrase, encryptPassphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Println(armor)
+	return nil
+}
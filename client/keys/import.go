@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func importKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name> <keyfile>",
+		Short: "Import a private key exported with the export command",
+		Long: `Store the ASCII armored private key of <keyfile>, as printed by the
+export command, under <name>. The passphrase the key was exported with also
+protects the imported key.`,
+		RunE: runImportCmd,
+		Args: cobra.ExactArgs(2),
+	}
+	return cmd
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) error {
+	kb, err := GetKeyBaseWithWritePerm()
+	if err != nil {
+		return err
+	}
+
+	armor, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	passphrase, err := client.GetPassword("Enter the passphrase of the exported key:", buf)
+	if err != nil {
+		return err
+	}
+
+	if err := kb.ImportPrivKey(args[0], string(armor), passphrase); err != nil {
+		return err
+	}
+	fmt.Printf("Key %q imported\n", args[0])
+	return nil
+}
@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
+)
+
+func listLedgersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ledgers",
+		Short: "List the connected Ledger devices",
+		Long: `Return the connected Ledger devices along with their app version and the
+address of their first account, so one of them can be selected with --ledger-device.`,
+		Args: cobra.NoArgs,
+		RunE: runListLedgersCmd,
+	}
+}
+
+func runListLedgersCmd(cmd *cobra.Command, args []string) error {
+	infos, err := ccrypto.EnumerateLedgers()
+	if err != nil {
+		return err
+	}
+
+	switch viper.Get(cli.OutputFlag) {
+	case "json":
+		out, err := MarshalJSON(infos)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	default:
+		fmt.Printf("INDEX:\tSERIAL:\tVERSION:\tADDRESS:\n")
+		for _, info := range infos {
+			fmt.Printf("%d\t%s\t%s\t%s\n", info.Index, info.Serial, info.Version, info.Address)
+		}
+	}
+	return nil
+}
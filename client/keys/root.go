@@ -2,8 +2,10 @@ package keys
 
 import (
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
 	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Commands registers a sub-tree of commands to interact with
@@ -18,14 +20,21 @@ func Commands() *cobra.Command {
     used by light-clients, full nodes, or any other application that
     needs to sign with a private key.`,
 	}
+	cmd.PersistentFlags().String(client.FlagKeyringBackend, keys.BackendDB, "Storage of the keys: db, os, file or test")
+	viper.BindPFlag(client.FlagKeyringBackend, cmd.PersistentFlags().Lookup(client.FlagKeyringBackend))
 	cmd.AddCommand(
 		mnemonicKeyCommand(),
 		addKeyCommand(),
 		listKeysCmd,
 		showKeysCmd(),
+		listLedgersCmd(),
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		exportKeyCommand(),
+		importKeyCommand(),
+		backupKeysCommand(),
+		restoreKeysCommand(),
 	)
 	return cmd
 }
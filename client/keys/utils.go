@@ -3,6 +3,7 @@ package keys
 import (
 	"fmt"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"os"
 	"path/filepath"
 
 	"github.com/spf13/viper"
@@ -12,14 +13,25 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"net/http"
 )
 
-// KeyDBName is the directory under root where we store the keys
-const KeyDBName = "keys"
+const (
+	// KeyDBName is the directory under root where we store the keys
+	KeyDBName = "keys"
+
+	// KeyringFileDirName is the directory under root where the file keyring
+	// backend stores the keys
+	KeyringFileDirName = "keyring-file"
+
+	// KeyringServiceName is the service the os keyring backend stores the
+	// keys under
+	KeyringServiceName = "cosmos-sdk"
+)
 
 // keybase is used to make GetKeyBase a singleton
 var keybase keys.Keybase
@@ -101,15 +113,52 @@ func GetKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
 
 func getKeyBaseFromDirWithOpts(rootDir string, o *opt.Options) (keys.Keybase, error) {
 	if keybase == nil {
-		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+		kb, err := newKeyBase(viper.GetString(client.FlagKeyringBackend), rootDir, o)
 		if err != nil {
 			return nil, err
 		}
-		keybase = client.GetKeyBase(db)
+		// tell the user on the terminal what to check on the Ledger and when
+		keybase = kb.WithLedgerOptions(keys.LedgerOptions{
+			EventHandler:  ccrypto.NewWriterEventHandler(os.Stderr),
+			PreviewWriter: os.Stderr,
+		})
 	}
 	return keybase, nil
 }
 
+// newKeyBase opens the keybase stored in the backendType backend, the LevelDB
+// database under rootDir by default.
+func newKeyBase(backendType, rootDir string, o *opt.Options) (keys.Keybase, error) {
+	switch backendType {
+	case "", keys.BackendDB:
+		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+		if err != nil {
+			return nil, err
+		}
+		return client.GetKeyBase(db), nil
+	case keys.BackendOS:
+		backend, err := keys.NewOSBackend(KeyringServiceName)
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewWithBackend(backend), nil
+	case keys.BackendFile:
+		passphrase, err := client.GetPassword("Enter keyring passphrase:", client.BufferStdin())
+		if err != nil {
+			return nil, err
+		}
+		backend, err := keys.NewFileBackend(filepath.Join(rootDir, KeyringFileDirName), passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewWithBackend(backend), nil
+	case keys.BackendTest:
+		return keys.NewInMemory(), nil
+	default:
+		return nil, keys.ValidateBackendType(backendType)
+	}
+}
+
 // used to set the keybase manually in test
 func SetKeyBase(kb keys.Keybase) {
 	keybase = kb
@@ -2,6 +2,7 @@ package keys
 
 import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
@@ -36,3 +37,28 @@ func TestGetKeyBaseLocks(t *testing.T) {
 
 	kb.CloseDB()
 }
+
+func TestRecoverKeyHDPath(t *testing.T) {
+	kb := keys.NewInMemory()
+	_, mnemonic, err := kb.CreateMnemonic("generated", keys.English, "12345678", keys.Secp256k1)
+	require.NoError(t, err)
+
+	// the fundraiser path by default
+	info, err := recoverKey(kb, "default", mnemonic, "12345678", "")
+	require.NoError(t, err)
+	generated, err := kb.Get("generated")
+	require.NoError(t, err)
+	require.Equal(t, generated.GetAddress(), info.GetAddress())
+
+	info, err = recoverKey(kb, "fifth", mnemonic, "12345678", "m/44'/714'/0'/0/5")
+	require.NoError(t, err)
+	require.NotEqual(t, generated.GetAddress(), info.GetAddress())
+	params, err := hd.NewParamsFromPath("m/44'/714'/0'/0/5")
+	require.NoError(t, err)
+	derived, err := kb.Derive("derived", mnemonic, "", "12345678", *params)
+	require.NoError(t, err)
+	require.Equal(t, derived.GetAddress(), info.GetAddress())
+
+	_, err = recoverKey(kb, "invalid", mnemonic, "12345678", "m/44'/714'/0'/0'/5")
+	require.Error(t, err)
+}
@@ -2,12 +2,12 @@ package lcd
 
 import (
 	"errors"
-	"net"
 	"net/http"
 	"os"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/grpc"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	"github.com/cosmos/cosmos-sdk/client/tx"
@@ -15,11 +15,15 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/client/rest"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution/client/rest"
 	gov "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
 	stake "github.com/cosmos/cosmos-sdk/x/stake/client/rest"
+	supply "github.com/cosmos/cosmos-sdk/x/supply/client/rest"
+	swap "github.com/cosmos/cosmos-sdk/x/swap/client/rest"
+	timelock "github.com/cosmos/cosmos-sdk/x/timelock/client/rest"
+	tokens "github.com/cosmos/cosmos-sdk/x/tokens/client/rest"
 	"github.com/gorilla/mux"
-	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/tendermint/tendermint/libs/log"
@@ -34,6 +38,7 @@ const (
 	flagSSLHosts           = "ssl-hosts"
 	flagSSLCertFile        = "ssl-certfile"
 	flagSSLKeyFile         = "ssl-keyfile"
+	flagGRPCListenAddr     = "grpc-laddr"
 )
 
 // ServeCommand will generate a long-running rest server
@@ -46,8 +51,9 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 		Short: "Start LCD (light-client daemon), a local REST server",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			listenAddr := viper.GetString(flagListenAddr)
-			handler := createHandler(cdc)
-			registerSwaggerUI(handler)
+			router := createHandler(cdc)
+			registerSwaggerUI(router)
+			handler := wrapHandler(router)
 			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "rest-server")
 			maxOpen := viper.GetInt(flagMaxOpenConnections)
 			sslHosts := viper.GetString(flagSSLHosts)
@@ -55,19 +61,31 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 			keyFile := viper.GetString(flagSSLKeyFile)
 			cleanupFunc := func() {}
 
-			var listener net.Listener
 			var fingerprint string
+			tlsConfig, err := acmeTLSConfig()
+			if err != nil {
+				return err
+			}
+			cfg := &tmserver.Config{MaxOpenConnections: maxOpen}
+			listener, err := tmserver.Listen(listenAddr, cfg)
+			if err != nil {
+				return err
+			}
 			if viper.GetBool(flagInsecure) {
-				cfg := &tmserver.Config{MaxOpenConnections: maxOpen}
-				listener, err := tmserver.Listen(listenAddr, cfg)
-				if err != nil {
-					return err
-				}
 				go func() {
 					if err = tmserver.StartHTTPServer(listener, handler, logger, cfg); err != nil {
 						panic(err)
 					}
 				}()
+			} else if tlsConfig != nil {
+				// the certificates are obtained from Let's Encrypt on the first
+				// TLS handshakes
+				go func() {
+					s := &http.Server{Handler: tmserver.RecoverAndLogHandler(handler, logger), TLSConfig: tlsConfig}
+					if err = s.ServeTLS(listener, "", ""); err != nil {
+						panic(err)
+					}
+				}()
 			} else {
 				if certFile != "" {
 					// validateCertKeyFiles() is needed to work around tendermint/tendermint#2460
@@ -92,11 +110,6 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 					}
 					defer cleanupFunc()
 				}
-				cfg := &tmserver.Config{MaxOpenConnections: maxOpen}
-				listener, err := tmserver.Listen(listenAddr, cfg)
-				if err != nil {
-					return err
-				}
 				go func() {
 					if err = tmserver.StartHTTPAndTLSServer(listener, handler, certFile, keyFile, logger, cfg); err != nil {
 						panic(err)
@@ -106,13 +119,37 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 			}
 			logger.Info("REST server started")
 
+			// the errors of the gRPC server, never sent if it is disabled
+			var grpcErrCh <-chan error
+			if grpcAddr := viper.GetString(flagGRPCListenAddr); grpcAddr != "" {
+				grpcListener, err := tmserver.Listen(grpcAddr, &tmserver.Config{MaxOpenConnections: maxOpen})
+				if err != nil {
+					return err
+				}
+				grpcServer := grpc.NewServer(context.NewCLIContext(), cdc, "acc")
+				grpcErrCh = grpc.Serve(grpcServer, grpcListener)
+				logger.Info("gRPC server started", "laddr", grpcAddr)
+			}
+
+			closeListener := func() {
+				if err := listener.Close(); err != nil {
+					logger.Error("error closing listener", "err", err)
+				}
+			}
+
 			// wait forever and cleanup
 			server.TrapSignal(func() {
 				defer cleanupFunc()
-				err := listener.Close()
-				logger.Error("error closing listener", "err", err)
+				closeListener()
 			})
 
+			// a failure of the gRPC server shuts the REST server down
+			if err := <-grpcErrCh; err != nil {
+				logger.Error("gRPC server failed", "err", err)
+				closeListener()
+				return err
+			}
+
 			return nil
 		},
 	}
@@ -122,11 +159,17 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagSSLHosts, "", "Comma-separated hostnames and IPs to generate a certificate for")
 	cmd.Flags().String(flagSSLCertFile, "", "Path to a SSL certificate file. If not supplied, a self-signed certificate will be generated.")
 	cmd.Flags().String(flagSSLKeyFile, "", "Path to a key file; ignored if a certificate file is not supplied.")
-	cmd.Flags().String(flagCORS, "", "Set the domains that can make CORS requests (* for all)")
+	cmd.Flags().String(flagGRPCListenAddr, "", "The address for the gRPC server to listen on, e.g. tcp://localhost:9090 (disabled if empty)")
+	cmd.Flags().String(flagACMEDomains, "", "Comma-separated domains to obtain the TLS certificate of from Let's Encrypt, the server must be reachable on port 443 at them")
+	cmd.Flags().String(flagACMECacheDir, "", "Directory of the certificates obtained from Let's Encrypt (default $HOME/acme)")
+	cmd.Flags().String(flagCORS, "", "Comma-separated origins that can make CORS requests (* for all)")
+	cmd.Flags().Float64(flagRateLimit, 0, "Max requests per second of each client IP (0 for no limit)")
+	cmd.Flags().Int(flagRateLimitBurst, 20, "Max burst of requests of each client IP over the rate limit")
 	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node")
 	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "Address of the node to connect to")
 	cmd.Flags().Int(flagMaxOpenConnections, 1000, "The number of maximum open connections")
 	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	client.AddTrustFlags(cmd)
 	cmd.Flags().Bool(client.FlagIndentResponse, false, "Add indent to JSON response")
 	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
 	viper.BindPFlag(client.FlagChainID, cmd.Flags().Lookup(client.FlagChainID))
@@ -157,19 +200,15 @@ func createHandler(cdc *codec.Codec) *mux.Router {
 	stake.RegisterRoutes(cliCtx, r, cdc, kb)
 	slashing.RegisterRoutes(cliCtx, r, cdc, kb)
 	gov.RegisterRoutes(cliCtx, r, cdc)
+	supply.RegisterRoutes(cliCtx, r, cdc, "supply")
+	timelock.RegisterRoutes(cliCtx, r, cdc, "timelock")
+	swap.RegisterRoutes(cliCtx, r, cdc, "atomicSwap")
+	tokens.RegisterRoutes(cliCtx, r, cdc, "tokens")
+	distr.RegisterRoutes(cliCtx, r, cdc, "distr")
 
 	return r
 }
 
-func registerSwaggerUI(r *mux.Router) {
-	statikFS, err := fs.New()
-	if err != nil {
-		panic(err)
-	}
-	staticServer := http.FileServer(statikFS)
-	r.PathPrefix("/swagger-ui/").Handler(http.StripPrefix("/swagger-ui/", staticServer))
-}
-
 func validateCertKeyFiles(certFile, keyFile string) error {
 	if keyFile == "" {
 		return errors.New("a key file is required")
@@ -0,0 +1,158 @@
+package lcd
+
+import (
+	"crypto/tls"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/cors"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	flagRateLimit      = "rate-limit"
+	flagRateLimitBurst = "rate-limit-burst"
+	flagACMEDomains    = "acme-domains"
+	flagACMECacheDir   = "acme-cache-dir"
+
+	// the clients idle for longer are forgotten by the rate limiter
+	rateLimitIdleTimeout = 10 * time.Minute
+)
+
+// wrapHandler wraps the handler of the REST server with the CORS policy and
+// the rate limiting of the flags.
+func wrapHandler(handler http.Handler) http.Handler {
+	if rate := viper.GetFloat64(flagRateLimit); rate > 0 {
+		handler = newRateLimitHandler(handler, rate, viper.GetInt(flagRateLimitBurst))
+	}
+	if origins := viper.GetString(flagCORS); origins != "" {
+		handler = newCORSHandler(handler, strings.Split(origins, ","))
+	}
+	return handler
+}
+
+// newCORSHandler allows the cross origin requests from the origins, * for all.
+func newCORSHandler(handler http.Handler, origins []string) http.Handler {
+	for i := range origins {
+		origins[i] = strings.TrimSpace(origins[i])
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders: []string{"Content-Type"},
+		// the total number of results of the paginated lists
+		ExposedHeaders: []string{"X-Total-Count"},
+	}).Handler(handler)
+}
+
+// tokenBucket holds up to burst tokens, refilled at the rate of its limiter.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimitHandler limits the requests of each client IP to rate per second,
+// with bursts of up to burst requests. The requests over the limit are
+// rejected with 429 Too Many Requests.
+type rateLimitHandler struct {
+	handler http.Handler
+	rate    float64
+	burst   float64
+	now     func() time.Time
+
+	mtx       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimitHandler(handler http.Handler, rate float64, burst int) *rateLimitHandler {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitHandler{
+		handler: handler,
+		rate:    rate,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !h.allow(ip) {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// allow takes a token of the bucket of ip, if any.
+func (h *rateLimitHandler) allow(ip string) bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	now := h.now()
+	if now.Sub(h.lastSweep) > rateLimitIdleTimeout {
+		for key, bucket := range h.buckets {
+			if now.Sub(bucket.last) > rateLimitIdleTimeout {
+				delete(h.buckets, key)
+			}
+		}
+		h.lastSweep = now
+	}
+
+	bucket, ok := h.buckets[ip]
+	if !ok {
+		bucket = &tokenBucket{tokens: h.burst, last: now}
+		h.buckets[ip] = bucket
+	} else {
+		bucket.tokens = math.Min(h.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*h.rate)
+		bucket.last = now
+	}
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// acmeTLSConfig returns the TLS config of the certificates obtained from Let's
+// Encrypt for the domains of the flags, nil if there are none. The
+// certificates are cached in the acme directory of the home by default.
+func acmeTLSConfig() (*tls.Config, error) {
+	domains := viper.GetString(flagACMEDomains)
+	if domains == "" {
+		return nil, nil
+	}
+	if viper.GetString(flagSSLCertFile) != "" {
+		return nil, errors.New("--acme-domains and --ssl-certfile are mutually exclusive")
+	}
+	hosts := strings.Split(domains, ",")
+	for i := range hosts {
+		hosts[i] = strings.TrimSpace(hosts[i])
+	}
+	cacheDir := viper.GetString(flagACMECacheDir)
+	if cacheDir == "" {
+		cacheDir = filepath.Join(viper.GetString(cli.HomeFlag), "acme")
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(hosts...),
+		Cache:      autocert.DirCache(cacheDir),
+	}
+	return manager.TLSConfig(), nil
+}
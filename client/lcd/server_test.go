@@ -0,0 +1,66 @@
+package lcd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := newRateLimitHandler(ok, 2, 3)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+
+	request := func(remoteAddr string) int {
+		r := httptest.NewRequest("GET", "/version", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// a burst of 3 requests
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, request("1.2.3.4:1000"))
+	}
+	require.Equal(t, http.StatusTooManyRequests, request("1.2.3.4:1001"))
+	// the other clients are not limited
+	require.Equal(t, http.StatusOK, request("5.6.7.8:1000"))
+
+	// 2 requests per second
+	now = now.Add(time.Second)
+	require.Equal(t, http.StatusOK, request("1.2.3.4:1000"))
+	require.Equal(t, http.StatusOK, request("1.2.3.4:1000"))
+	require.Equal(t, http.StatusTooManyRequests, request("1.2.3.4:1000"))
+
+	// the idle clients are forgotten
+	now = now.Add(rateLimitIdleTimeout + time.Second)
+	require.Equal(t, http.StatusOK, request("1.2.3.4:1000"))
+	require.Len(t, h.buckets, 1)
+}
+
+func TestCORSHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := newCORSHandler(ok, []string{"https://wallet.example.com", " https://explorer.example.com"})
+
+	for origin, allowed := range map[string]bool{
+		"https://wallet.example.com":   true,
+		"https://explorer.example.com": true,
+		"https://evil.example.com":     false,
+	} {
+		r := httptest.NewRequest("GET", "/stake/validators", nil)
+		r.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if allowed {
+			require.Equal(t, origin, w.Header().Get("Access-Control-Allow-Origin"))
+			require.Equal(t, "X-Total-Count", w.Header().Get("Access-Control-Expose-Headers"))
+		} else {
+			require.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		}
+	}
+}
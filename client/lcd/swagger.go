@@ -0,0 +1,159 @@
+package lcd
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
+)
+
+// the swagger UI, without the source maps, and the documentation of the
+// routes
+//
+//go:embed swagger-ui/*.html swagger-ui/*.js swagger-ui/*.css swagger-ui/*.png swagger-ui/swagger.yaml
+var swaggerUI embed.FS
+
+const (
+	swaggerDocFile  = "swagger-ui/swagger.yaml"
+	swaggerSpecPath = "swagger.json"
+)
+
+// matches the variables of a route path template, {name} or {name:pattern}
+var pathVariableRegexp = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+// GenerateSwagger generates the swagger 2.0 document of every route of the
+// router. The operations documented in swagger.yaml are kept as they are, the
+// others are generated from the path and method of their route, and the
+// documented operations with no route are dropped.
+func GenerateSwagger(r *mux.Router) (map[string]interface{}, error) {
+	bz, err := swaggerUI.ReadFile(swaggerDocFile)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(bz, &doc); err != nil {
+		return nil, err
+	}
+	documented, _ := doc["paths"].(map[string]interface{})
+
+	paths := make(map[string]interface{})
+	err = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			// the prefixes of the sub routers and the routes matching no path
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path := pathVariableRegexp.ReplaceAllString(template, "{$1}")
+
+		documentedPath, _ := documented[path].(map[string]interface{})
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			if parameters, ok := documentedPath["parameters"]; ok {
+				item["parameters"] = parameters
+			}
+			paths[path] = item
+		}
+		for _, method := range methods {
+			method = strings.ToLower(method)
+			if operation, ok := documentedPath[method]; ok {
+				item[method] = operation
+			} else {
+				item[method] = generateOperation(path, method, documentedPath["parameters"] == nil)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	doc["paths"] = paths
+	return doc, nil
+}
+
+// generateOperation generates the operation of an undocumented route, with its
+// path variables as parameters unless the path documents them.
+func generateOperation(path, method string, withPathParameters bool) map[string]interface{} {
+	var parameters []interface{}
+	if withPathParameters {
+		for _, match := range pathVariableRegexp.FindAllStringSubmatch(path, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"in":       "path",
+				"name":     match[1],
+				"required": true,
+				"type":     "string",
+			})
+		}
+	}
+	if method == "post" || method == "put" {
+		parameters = append(parameters, map[string]interface{}{
+			"in":     "body",
+			"name":   "body",
+			"schema": map[string]interface{}{"type": "object"},
+		})
+	}
+
+	operation := map[string]interface{}{
+		"summary":  fmt.Sprintf("%s %s", strings.ToUpper(method), path),
+		"produces": []interface{}{"application/json"},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{"description": "OK"},
+			"400": map[string]interface{}{"description": "Invalid request"},
+			"500": map[string]interface{}{"description": "Internal Server Error"},
+		},
+	}
+	// the module of the route, e.g. stake for /stake/validators
+	if segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2); segments[0] != "" && !strings.HasPrefix(segments[0], "{") {
+		operation["tags"] = []interface{}{segments[0]}
+	}
+	if len(parameters) != 0 {
+		operation["parameters"] = parameters
+	}
+	return operation
+}
+
+// registerSwaggerUI serves the swagger document of the routes of r registered
+// so far at /swagger/swagger.json and the swagger UI at /swagger/.
+func registerSwaggerUI(r *mux.Router) {
+	doc, err := GenerateSwagger(r)
+	if err != nil {
+		panic(err)
+	}
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	ui, err := fs.Sub(swaggerUI, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	index, err := fs.ReadFile(ui, "index.html")
+	if err != nil {
+		panic(err)
+	}
+	index = bytes.Replace(index, []byte(`"./swagger.yaml"`), []byte(`"./`+swaggerSpecPath+`"`), 1)
+
+	r.HandleFunc("/swagger/"+swaggerSpecPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}).Methods("GET")
+	r.HandleFunc("/swagger/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(index)
+	}).Methods("GET")
+	r.Handle("/swagger", http.RedirectHandler("/swagger/", http.StatusMovedPermanently)).Methods("GET")
+	r.PathPrefix("/swagger/").Handler(http.StripPrefix("/swagger/", http.FileServer(http.FS(ui))))
+	// the former path of the swagger UI
+	r.PathPrefix("/swagger-ui/").Handler(http.RedirectHandler("/swagger/", http.StatusMovedPermanently))
+}
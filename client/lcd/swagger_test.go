@@ -0,0 +1,97 @@
+package lcd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSwagger(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	r := mux.NewRouter()
+	// documented in swagger.yaml
+	r.HandleFunc("/stake/validators/{validatorAddr}", handler).Methods("GET")
+	// undocumented
+	r.HandleFunc("/tokens/{symbol:[A-Z0-9-]+}/owners/{owner}", handler).Methods("GET", "POST")
+
+	doc, err := GenerateSwagger(r)
+	require.Nil(t, err)
+	bz, err := json.Marshal(doc)
+	require.Nil(t, err)
+	var spec struct {
+		Swagger string
+		Paths   map[string]map[string]json.RawMessage
+	}
+	require.Nil(t, json.Unmarshal(bz, &spec))
+	require.Equal(t, "2.0", spec.Swagger)
+	type operation struct {
+		Summary    string
+		Tags       []string
+		Parameters []struct {
+			In       string
+			Name     string
+			Required bool
+		}
+		Responses map[string]interface{}
+	}
+
+	// the documented operations of the routes only
+	require.Len(t, spec.Paths, 2)
+	validator := spec.Paths["/stake/validators/{validatorAddr}"]
+	require.Len(t, validator, 2)
+	require.Contains(t, validator, "parameters")
+	var get operation
+	require.Nil(t, json.Unmarshal(validator["get"], &get))
+	require.Equal(t, "Query the information from a single validator", get.Summary)
+	require.Contains(t, get.Responses, "200")
+
+	owners := spec.Paths["/tokens/{symbol}/owners/{owner}"]
+	require.Len(t, owners, 2)
+	get = operation{}
+	require.Nil(t, json.Unmarshal(owners["get"], &get))
+	require.Equal(t, "GET /tokens/{symbol}/owners/{owner}", get.Summary)
+	require.Equal(t, []string{"tokens"}, get.Tags)
+	require.Len(t, get.Parameters, 2)
+	require.Equal(t, "symbol", get.Parameters[0].Name)
+	require.Equal(t, "path", get.Parameters[0].In)
+	require.True(t, get.Parameters[0].Required)
+	require.Equal(t, "owner", get.Parameters[1].Name)
+	var post operation
+	require.Nil(t, json.Unmarshal(owners["post"], &post))
+	require.Len(t, post.Parameters, 3)
+	require.Equal(t, "body", post.Parameters[2].In)
+}
+
+func TestServeSwagger(t *testing.T) {
+	r := mux.NewRouter()
+	r.HandleFunc("/version", CLIVersionRequestHandler).Methods("GET")
+	registerSwaggerUI(r)
+
+	for path, contentType := range map[string]string{
+		"/swagger/swagger.json":         "application/json",
+		"/swagger/":                     "text/html; charset=utf-8",
+		"/swagger/swagger-ui-bundle.js": "text/javascript; charset=utf-8",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusOK, w.Code, path)
+		require.Equal(t, contentType, w.Header().Get("Content-Type"), path)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/", nil))
+	require.Contains(t, w.Body.String(), `url: "./swagger.json"`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/swagger/swagger.json", nil))
+	var spec struct {
+		Paths map[string]interface{}
+	}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	require.Contains(t, spec.Paths, "/version")
+	require.NotContains(t, spec.Paths, "/swagger/swagger.json")
+}
@@ -0,0 +1,27 @@
+package lite
+
+import (
+	"fmt"
+	"time"
+)
+
+type errTrustExpired struct {
+	height int64
+	expiry time.Time
+}
+
+func (e errTrustExpired) Error() string {
+	return fmt.Sprintf("the trusting period of the header of height %d expired at %v, the trust must be initialized again", e.height, e.expiry)
+}
+
+// ErrTrustExpired returns an error reflecting that the trusting period of the
+// trusted header of height expired at expiry.
+func ErrTrustExpired(height int64, expiry time.Time) error {
+	return errTrustExpired{height: height, expiry: expiry}
+}
+
+// IsErrTrustExpired reports if err is a ErrTrustExpired.
+func IsErrTrustExpired(err error) bool {
+	_, ok := err.(errTrustExpired)
+	return ok
+}
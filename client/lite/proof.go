@@ -0,0 +1,48 @@
+package lite
+
+import (
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// VerifyStoreQuery verifies the value, or the absence, of the response of a
+// query on the store storeName against appHash, the app hash of the header
+// after the height of the response.
+func VerifyStoreQuery(storeName string, resp abci.ResponseQuery, appHash []byte) error {
+	prt := store.DefaultProofRuntime()
+
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
+
+	if resp.Value == nil {
+		if err := prt.VerifyAbsence(resp.Proof, appHash, kp.String()); err != nil {
+			return errors.Wrap(err, "failed to prove merkle absence proof")
+		}
+		return nil
+	}
+	if err := prt.VerifyValue(resp.Proof, appHash, kp.String(), resp.Value); err != nil {
+		return errors.Wrap(err, "failed to prove merkle existence proof")
+	}
+	return nil
+}
+
+// VerifyCustomQuery verifies the reads of the custom querier of the response
+// of a custom query against appHash, and returns them. The response is
+// trusted to be computed from the reads by the querier.
+func VerifyCustomQuery(resp abci.ResponseQuery, appHash []byte) ([]store.QueryRead, error) {
+	reads, err := store.QueryReadsFromProof(resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	prt := store.DefaultProofRuntime()
+	for _, read := range reads {
+		if err := read.Verify(prt, appHash); err != nil {
+			return nil, errors.Wrapf(err, "failed to prove the read of %s", read.Path())
+		}
+	}
+	return reads, nil
+}
@@ -0,0 +1,47 @@
+package lite
+
+import (
+	tmlite "github.com/tendermint/tendermint/lite"
+	tmliteClient "github.com/tendermint/tendermint/lite/client"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/types"
+)
+
+// HeaderProvider is a provider of the headers alone, cheaper to fetch than
+// the full commits. The headers before the trusted header are only checked
+// against the hashes of the blocks after them, so their headers are enough.
+type HeaderProvider interface {
+	// Headers returns the headers between minHeight and maxHeight, from
+	// maxHeight down. It may return fewer headers than asked for.
+	Headers(minHeight, maxHeight int64) ([]types.Header, error)
+}
+
+// nodeProvider provides the full commits and the headers of a node.
+type nodeProvider struct {
+	tmlite.Provider
+	node rpcclient.Client
+}
+
+var _ HeaderProvider = nodeProvider{}
+
+// NewNodeProvider returns the provider of the full commits and the headers of
+// the chain of node.
+func NewNodeProvider(chainID string, node rpcclient.Client) tmlite.Provider {
+	return nodeProvider{
+		Provider: tmliteClient.NewProvider(chainID, node),
+		node:     node,
+	}
+}
+
+// Implements HeaderProvider.
+func (p nodeProvider) Headers(minHeight, maxHeight int64) ([]types.Header, error) {
+	res, err := p.node.BlockchainInfo(minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	headers := make([]types.Header, len(res.BlockMetas))
+	for i, meta := range res.BlockMetas {
+		headers[i] = meta.Header
+	}
+	return headers, nil
+}
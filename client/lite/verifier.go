@@ -0,0 +1,263 @@
+// Package lite implements a light client verifying the headers of a chain,
+// and the proofs of the query responses against them, from a trusted header
+// and the validator set changes signed since.
+package lite
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmlite "github.com/tendermint/tendermint/lite"
+	lerr "github.com/tendermint/tendermint/lite/errors"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// DefaultTrustingPeriod is the default trusting period of the verifiers,
+	// it must be shorter than the unbonding time of the chain.
+	DefaultTrustingPeriod = 48 * time.Hour
+
+	// maxClockDrift is how far in the future of the local clock the time of
+	// a verified header can be.
+	maxClockDrift = 10 * time.Second
+
+	// cacheSize is the number of trusted full commits cached in memory.
+	cacheSize = 10
+)
+
+// TrustOptions are the options of the trust of a Verifier.
+type TrustOptions struct {
+	// Period is the trusting period: a trusted header older than Period can
+	// no longer verify the headers after it.
+	Period time.Duration
+	// Height and Hash are the header trusted out of band to initialize the
+	// trust. With no Height, the latest header of the source is trusted
+	// blindly, with no Hash the header of Height is. The headers before the
+	// trusted header are verified backwards from it.
+	Height int64
+	Hash   []byte
+}
+
+// Verifier implements tendermint's lite.Verifier. It verifies the headers of
+// the source by syncing the validator set changes from its latest trusted
+// header still in the trusting period, and persists the full commits it
+// verified as its trust state.
+type Verifier struct {
+	chainID string
+	period  time.Duration
+	trusted tmlite.PersistentProvider
+	source  tmlite.Provider
+	dynamic *tmlite.DynamicVerifier
+	now     func() time.Time
+
+	mtx sync.Mutex
+}
+
+var _ tmlite.Verifier = (*Verifier)(nil)
+
+// NewVerifier returns a Verifier of the headers of source, persisting its
+// trust state in db. The trust is initialized from opts when db holds no
+// trusted header in the trusting period.
+func NewVerifier(chainID string, db dbm.DB, source tmlite.Provider, opts TrustOptions, logger log.Logger) (*Verifier, error) {
+	if opts.Period <= 0 {
+		opts.Period = DefaultTrustingPeriod
+	}
+	trusted := tmlite.NewMultiProvider(
+		tmlite.NewDBProvider("trusted.mem", dbm.NewMemDB()).SetLimit(cacheSize),
+		tmlite.NewDBProvider("trusted.lvl", db),
+	)
+	dynamic := tmlite.NewDynamicVerifier(chainID, trusted, source)
+	dynamic.SetLogger(logger.With("module", "client/lite"))
+	v := &Verifier{
+		chainID: chainID,
+		period:  opts.Period,
+		trusted: trusted,
+		source:  source,
+		dynamic: dynamic,
+		now:     time.Now,
+	}
+
+	latest, err := trusted.LatestFullCommit(chainID, 1, 1<<63-1)
+	switch {
+	case err == nil && !v.expired(latest):
+		return v, nil
+	case err != nil && !lerr.IsErrCommitNotFound(err):
+		return nil, err
+	}
+	if err := v.initialize(opts); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// initialize trusts the header of the options.
+func (v *Verifier) initialize(opts TrustOptions) error {
+	var fc tmlite.FullCommit
+	var err error
+	if opts.Height > 0 {
+		fc, err = v.source.LatestFullCommit(v.chainID, opts.Height, opts.Height)
+		if err == nil && fc.Height() != opts.Height {
+			err = lerr.ErrCommitNotFound()
+		}
+	} else {
+		fc, err = v.source.LatestFullCommit(v.chainID, 1, 1<<63-1)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch the trusted header: %v", err)
+	}
+	if err := fc.ValidateFull(v.chainID); err != nil {
+		return err
+	}
+	if opts.Hash != nil && !bytes.Equal(fc.SignedHeader.Hash(), opts.Hash) {
+		return fmt.Errorf("the header of height %d has hash %X, expected %X", fc.Height(), fc.SignedHeader.Hash(), opts.Hash)
+	}
+	if v.expired(fc) {
+		return ErrTrustExpired(fc.Height(), fc.SignedHeader.Time.Add(v.period))
+	}
+	return v.trusted.SaveFullCommit(fc)
+}
+
+// expired reports if the trusting period of fc is over.
+func (v *Verifier) expired(fc tmlite.FullCommit) bool {
+	return !fc.SignedHeader.Time.Add(v.period).After(v.now())
+}
+
+// Implements tendermint's lite.Verifier.
+func (v *Verifier) ChainID() string {
+	return v.chainID
+}
+
+// Implements tendermint's lite.Verifier.
+//
+// The header is verified from the latest trusted header before it, which
+// must be in its trusting period. On success, the header is trusted. Without
+// such a header, it is verified backwards from the latest trusted header
+// after it.
+func (v *Verifier) Verify(sh types.SignedHeader) error {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if sh.ChainID != v.chainID {
+		return fmt.Errorf("cannot verify a header of chain %s on chain %s", sh.ChainID, v.chainID)
+	}
+	if sh.Time.After(v.now().Add(maxClockDrift)) {
+		return fmt.Errorf("the header of height %d is from the future: %v", sh.Height, sh.Time)
+	}
+	if fc, err := v.trusted.LatestFullCommit(v.chainID, sh.Height, sh.Height); err == nil {
+		if bytes.Equal(fc.SignedHeader.Hash(), sh.Hash()) {
+			return nil
+		}
+	} else if !lerr.IsErrCommitNotFound(err) {
+		return err
+	}
+
+	// a max height of 0 is no max height to the providers
+	trustedFC, err := tmlite.FullCommit{}, lerr.ErrCommitNotFound()
+	if sh.Height > 1 {
+		trustedFC, err = v.trusted.LatestFullCommit(v.chainID, 1, sh.Height-1)
+	}
+	if err != nil && !lerr.IsErrCommitNotFound(err) {
+		return err
+	}
+	if err != nil || v.expired(trustedFC) {
+		nextFC, nextErr := v.trusted.LatestFullCommit(v.chainID, sh.Height+1, 1<<63-1)
+		switch {
+		case nextErr == nil:
+			trustedFC, err = nextFC, nil
+		case !lerr.IsErrCommitNotFound(nextErr):
+			return nextErr
+		case err != nil:
+			return err
+		}
+		if v.expired(trustedFC) {
+			return ErrTrustExpired(trustedFC.Height(), trustedFC.SignedHeader.Time.Add(v.period))
+		}
+		if trustedFC.Height() > sh.Height {
+			return v.verifyBackwards(sh, trustedFC)
+		}
+	}
+	if !sh.Time.After(trustedFC.SignedHeader.Time) {
+		return fmt.Errorf("the header of height %d is not after the trusted header of height %d", sh.Height, trustedFC.Height())
+	}
+	return v.dynamic.Verify(sh)
+}
+
+// verifyBackwards verifies sh from the trusted full commit after it, by
+// following the hashes of the previous blocks from the trusted header down to
+// sh. The headers in between are fetched from the source.
+func (v *Verifier) verifyBackwards(sh types.SignedHeader, trustedFC tmlite.FullCommit) error {
+	lastBlockHash := trustedFC.SignedHeader.LastBlockID.Hash
+	for height := trustedFC.Height() - 1; height > sh.Height; {
+		headers, err := v.headers(sh.Height+1, height)
+		if err != nil {
+			return err
+		}
+		if len(headers) == 0 {
+			return lerr.ErrCommitNotFound()
+		}
+		for _, header := range headers {
+			if header.Height != height {
+				return lerr.ErrCommitNotFound()
+			}
+			if !bytes.Equal(header.Hash(), lastBlockHash) {
+				return fmt.Errorf("the header of height %d does not match the last block of the header of height %d", height, height+1)
+			}
+			lastBlockHash = header.LastBlockID.Hash
+			height--
+		}
+	}
+	if !bytes.Equal(sh.Hash(), lastBlockHash) {
+		return fmt.Errorf("the header of height %d does not match the last block of the header of height %d", sh.Height, sh.Height+1)
+	}
+	return nil
+}
+
+// headers returns headers of the source between minHeight and maxHeight, from
+// maxHeight down, fetched alone if the source is a HeaderProvider.
+func (v *Verifier) headers(minHeight, maxHeight int64) ([]types.Header, error) {
+	if hp, ok := v.source.(HeaderProvider); ok {
+		return hp.Headers(minHeight, maxHeight)
+	}
+	fc, err := v.source.LatestFullCommit(v.chainID, maxHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+	return []types.Header{*fc.SignedHeader.Header}, nil
+}
+
+// Certify returns the header of height of the source, once verified.
+func (v *Verifier) Certify(height int64) (types.SignedHeader, error) {
+	fc, err := v.source.LatestFullCommit(v.chainID, height, height)
+	if err != nil {
+		return types.SignedHeader{}, err
+	}
+	if fc.Height() != height {
+		return types.SignedHeader{}, lerr.ErrCommitNotFound()
+	}
+	if err := v.Verify(fc.SignedHeader); err != nil {
+		return types.SignedHeader{}, err
+	}
+	return fc.SignedHeader, nil
+}
+
+// Update verifies the latest header of the source, to keep the trust state
+// up with the validator set changes before the trusting period is over.
+func (v *Verifier) Update() (types.SignedHeader, error) {
+	fc, err := v.source.LatestFullCommit(v.chainID, 1, 1<<63-1)
+	if err != nil {
+		return types.SignedHeader{}, err
+	}
+	if err := v.Verify(fc.SignedHeader); err != nil {
+		return types.SignedHeader{}, err
+	}
+	return fc.SignedHeader, nil
+}
+
+// LastTrustedHeight returns the height of the latest trusted header.
+func (v *Verifier) LastTrustedHeight() int64 {
+	return v.dynamic.LastTrustedHeight()
+}
@@ -0,0 +1,168 @@
+package lite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	tmlite "github.com/tendermint/tendermint/lite"
+	"github.com/tendermint/tendermint/types"
+	tmtime "github.com/tendermint/tendermint/types/time"
+)
+
+func TestVerifier(t *testing.T) {
+	chainID := "lite-chain"
+	keys := tmlite.GenSecpPrivKeys(4)
+	vals := keys.ToValidators(10, 0)
+	// the validator set changes after height 3
+	newKeys := keys.ExtendSecp(2)
+	newVals := newKeys.ToValidators(10, 0)
+
+	source := tmlite.NewDBProvider("source", dbm.NewMemDB())
+	var fcs []tmlite.FullCommit
+	for height := int64(1); height <= 6; height++ {
+		signers, cur, next := keys, vals, vals
+		if height == 3 {
+			next = newVals
+		} else if height > 3 {
+			signers, cur, next = newKeys, newVals, newVals
+		}
+		fc := signers.GenFullCommit(chainID, height, nil, cur, next, []byte("app"), []byte("cons"), []byte("res"), 0, len(signers))
+		require.NoError(t, source.SaveFullCommit(fc))
+		fcs = append(fcs, fc)
+	}
+
+	// the trusted header must match its hash
+	db := dbm.NewMemDB()
+	opts := TrustOptions{Period: time.Hour, Height: 1, Hash: fcs[1].SignedHeader.Hash()}
+	_, err := NewVerifier(chainID, db, source, opts, log.NewNopLogger())
+	require.Error(t, err)
+
+	opts.Hash = fcs[0].SignedHeader.Hash()
+	v, err := NewVerifier(chainID, db, source, opts, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v.LastTrustedHeight())
+
+	// the headers are verified across the validator set change
+	sh, err := v.Certify(5)
+	require.NoError(t, err)
+	require.Equal(t, fcs[4].SignedHeader.Hash(), sh.Hash())
+	require.Equal(t, int64(5), v.LastTrustedHeight())
+
+	header := *fcs[4].SignedHeader.Header
+	header.AppHash = []byte("forged")
+	require.Error(t, v.Verify(types.SignedHeader{Header: &header, Commit: fcs[4].SignedHeader.Commit}))
+	header.Time = time.Now().Add(time.Hour)
+	require.Error(t, v.Verify(types.SignedHeader{Header: &header, Commit: fcs[4].SignedHeader.Commit}))
+
+	// the trust state is persisted, the options only initialize it
+	v, err = NewVerifier(chainID, db, source, TrustOptions{Period: time.Hour}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, int64(5), v.LastTrustedHeight())
+
+	// the trusted headers can not verify after their trusting period
+	v.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	err = v.Verify(fcs[5].SignedHeader)
+	require.True(t, IsErrTrustExpired(err), "%v", err)
+}
+
+func TestVerifierBackwards(t *testing.T) {
+	chainID := "lite-chain"
+	keys := tmlite.GenSecpPrivKeys(4)
+	source := tmlite.NewDBProvider("source", dbm.NewMemDB())
+	fcs := genLinkedFullCommits(t, chainID, keys, 5)
+	for _, fc := range fcs {
+		require.NoError(t, source.SaveFullCommit(fc))
+	}
+
+	// with no height, the latest header is trusted
+	v, err := NewVerifier(chainID, dbm.NewMemDB(), source, TrustOptions{Period: time.Hour}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, int64(5), v.LastTrustedHeight())
+
+	// the headers before it are verified backwards
+	for height := int64(1); height < 5; height++ {
+		sh, err := v.Certify(height)
+		require.NoError(t, err)
+		require.Equal(t, fcs[height-1].SignedHeader.Hash(), sh.Hash())
+	}
+
+	header := *fcs[1].SignedHeader.Header
+	header.AppHash = []byte("forged")
+	require.Error(t, v.Verify(types.SignedHeader{Header: &header, Commit: fcs[1].SignedHeader.Commit}))
+
+	// the headers of a HeaderProvider are fetched in batches
+	v, err = NewVerifier(chainID, dbm.NewMemDB(), batchProvider{source, fcs}, TrustOptions{Period: time.Hour}, log.NewNopLogger())
+	require.NoError(t, err)
+	sh, err := v.Certify(1)
+	require.NoError(t, err)
+	require.Equal(t, fcs[0].SignedHeader.Hash(), sh.Hash())
+
+	// but not once the trusted header expired
+	v.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	err = v.Verify(fcs[1].SignedHeader)
+	require.True(t, IsErrTrustExpired(err), "%v", err)
+}
+
+// batchProvider provides the headers of its full commits two at a time.
+type batchProvider struct {
+	tmlite.Provider
+	fcs []tmlite.FullCommit
+}
+
+func (p batchProvider) Headers(minHeight, maxHeight int64) ([]types.Header, error) {
+	var headers []types.Header
+	for height := maxHeight; height >= minHeight && len(headers) < 2; height-- {
+		headers = append(headers, *p.fcs[height-1].SignedHeader.Header)
+	}
+	return headers, nil
+}
+
+// genLinkedFullCommits generates the full commits of the heights 1 to n of a
+// chain, each header linking to the block of the previous one and signed by
+// all the keys.
+func genLinkedFullCommits(t *testing.T, chainID string, keys []crypto.PrivKey, n int64) []tmlite.FullCommit {
+	validators := make([]*types.Validator, len(keys))
+	for i, key := range keys {
+		validators[i] = types.NewValidator(key.PubKey(), 10)
+	}
+	vals := types.NewValidatorSet(validators)
+
+	var fcs []tmlite.FullCommit
+	var lastBlockID types.BlockID
+	for height := int64(1); height <= n; height++ {
+		header := &types.Header{
+			ChainID:            chainID,
+			Height:             height,
+			Time:               tmtime.Now(),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+		}
+		blockID := types.BlockID{Hash: header.Hash()}
+		sigs := make([]*types.CommitSig, len(keys))
+		for _, key := range keys {
+			idx, _ := vals.GetByAddress(key.PubKey().Address())
+			vote := &types.Vote{
+				ValidatorAddress: key.PubKey().Address(),
+				ValidatorIndex:   idx,
+				Height:           height,
+				Round:            1,
+				Timestamp:        tmtime.Now(),
+				Type:             types.PrecommitType,
+				BlockID:          blockID,
+			}
+			sig, err := key.Sign(vote.SignBytes(chainID))
+			require.NoError(t, err)
+			vote.Signature = sig
+			sigs[idx] = vote.CommitSig()
+		}
+		sh := types.SignedHeader{Header: header, Commit: types.NewCommit(blockID, sigs)}
+		fcs = append(fcs, tmlite.NewFullCommit(sh, vals, vals))
+		lastBlockID = blockID
+	}
+	return fcs
+}
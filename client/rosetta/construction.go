@@ -0,0 +1,289 @@
+package rosetta
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	txbuilder "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	curveSecp256k1 = "secp256k1"
+	// signatureECDSA is the type of the R||S signatures of the sha256 of the
+	// sign bytes of a tx
+	signatureECDSA = "ecdsa"
+)
+
+func (s *Server) constructionDerive(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionDeriveRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	pubKey, err := decodePublicKey(req.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return ConstructionDeriveResponse{
+		AccountIdentifier: AccountIdentifier{Address: sdk.AccAddress(pubKey.Address()).String()},
+	}, nil
+}
+
+func decodePublicKey(key PublicKey) (secp256k1.PubKeySecp256k1, *Error) {
+	if key.CurveType != curveSecp256k1 {
+		return nil, ErrUnsupported.wrapf("curve type %s", key.CurveType)
+	}
+	bz, err := hex.DecodeString(key.HexBytes)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	if len(bz) != secp256k1.PubKeySize {
+		return nil, ErrInvalidRequest.wrapf("expected a compressed public key of %d bytes", secp256k1.PubKeySize)
+	}
+	return secp256k1.PubKeySecp256k1(bz), nil
+}
+
+// singleSend returns the MsgSend of ops, the transactions constructed are
+// signed by a single sender.
+func singleSend(ops []Operation) (bank.MsgSend, *Error) {
+	msg, err := sendFromOperations(ops)
+	if err != nil {
+		return msg, err
+	}
+	if len(msg.Inputs) != 1 {
+		return msg, ErrUnsupported.wrapf("%d senders, only one is supported", len(msg.Inputs))
+	}
+	return msg, nil
+}
+
+func (s *Server) constructionPreprocess(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionPreprocessRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	msg, err := singleSend(req.Operations)
+	if err != nil {
+		return nil, err
+	}
+	return ConstructionPreprocessResponse{Options: map[string]string{
+		"sender": msg.Inputs[0].Address.String(),
+		"memo":   req.Metadata["memo"],
+	}}, nil
+}
+
+func (s *Server) constructionMetadata(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionMetadataRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	sender, err := sdk.AccAddressFromBech32(req.Options["sender"])
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	account, rerr := s.account(sender, 0)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if account == nil {
+		return nil, ErrNotFound.wrapf("account %s", sender)
+	}
+	return ConstructionMetadataResponse{Metadata: map[string]string{
+		"chain_id":       s.network.Network,
+		"account_number": strconv.FormatInt(account.GetAccountNumber(), 10),
+		"sequence":       strconv.FormatInt(account.GetSequence(), 10),
+		"memo":           req.Options["memo"],
+	}}, nil
+}
+
+func (s *Server) constructionPayloads(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionPayloadsRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	msg, rerr := singleSend(req.Operations)
+	if rerr != nil {
+		return nil, rerr
+	}
+	accNum, err := strconv.ParseInt(req.Metadata["account_number"], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrapf("invalid account_number: %v", err)
+	}
+	seq, err := strconv.ParseInt(req.Metadata["sequence"], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrapf("invalid sequence: %v", err)
+	}
+	if req.Metadata["chain_id"] != s.network.Network {
+		return nil, ErrInvalidRequest.wrapf("the metadata is of chain %s", req.Metadata["chain_id"])
+	}
+
+	signMsg := txbuilder.StdSignMsg{
+		ChainID:       s.network.Network,
+		AccountNumber: accNum,
+		Sequence:      seq,
+		Msgs:          []sdk.Msg{msg},
+		Memo:          req.Metadata["memo"],
+		Source:        auth.DefaultSource,
+	}
+	bz, err := s.cdc.MarshalJSON(signMsg)
+	if err != nil {
+		return nil, ErrInvalidTx.wrap(err)
+	}
+	hash := sha256.Sum256(signMsg.Bytes())
+	return ConstructionPayloadsResponse{
+		UnsignedTransaction: hex.EncodeToString(bz),
+		Payloads: []SigningPayload{{
+			AccountIdentifier: &AccountIdentifier{Address: msg.Inputs[0].Address.String()},
+			HexBytes:          hex.EncodeToString(hash[:]),
+			SignatureType:     signatureECDSA,
+		}},
+	}, nil
+}
+
+// decodeUnsignedTx decodes the unsigned transaction of the payloads.
+func (s *Server) decodeUnsignedTx(tx string) (txbuilder.StdSignMsg, *Error) {
+	var signMsg txbuilder.StdSignMsg
+	bz, err := hex.DecodeString(tx)
+	if err != nil {
+		return signMsg, ErrInvalidRequest.wrap(err)
+	}
+	if err := s.cdc.UnmarshalJSON(bz, &signMsg); err != nil {
+		return signMsg, ErrInvalidTx.wrap(err)
+	}
+	return signMsg, nil
+}
+
+// decodeSignedTx decodes a signed transaction, as broadcast to the node.
+func (s *Server) decodeSignedTx(tx string) ([]byte, auth.StdTx, *Error) {
+	bz, err := hex.DecodeString(tx)
+	if err != nil {
+		return nil, auth.StdTx{}, ErrInvalidRequest.wrap(err)
+	}
+	decoded, serr := auth.DefaultTxDecoder(s.cdc)(bz)
+	if serr != nil {
+		return nil, auth.StdTx{}, ErrInvalidTx.wrap(serr.Error())
+	}
+	stdTx, ok := decoded.(auth.StdTx)
+	if !ok {
+		return nil, auth.StdTx{}, ErrInvalidTx.wrapf("unexpected tx type %T", decoded)
+	}
+	return bz, stdTx, nil
+}
+
+func (s *Server) constructionCombine(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionCombineRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	signMsg, err := s.decodeUnsignedTx(req.UnsignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if len(signMsg.Msgs) != 1 {
+		return nil, ErrInvalidTx.wrapf("%d msgs, expected a single transfer", len(signMsg.Msgs))
+	}
+	if len(req.Signatures) != 1 {
+		return nil, ErrInvalidRequest.wrapf("%d signatures, expected the one of the sender", len(req.Signatures))
+	}
+	signature := req.Signatures[0]
+	if signature.SignatureType != signatureECDSA {
+		return nil, ErrUnsupported.wrapf("signature type %s", signature.SignatureType)
+	}
+	pubKey, err := decodePublicKey(signature.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sig, herr := hex.DecodeString(signature.HexBytes)
+	if herr != nil {
+		return nil, ErrInvalidRequest.wrap(herr)
+	}
+	signers := signMsg.Msgs[0].GetSigners()
+	if !sdk.AccAddress(pubKey.Address()).Equals(signers[0]) {
+		return nil, ErrInvalidTx.wrapf("the public key is not the one of the sender %s", signers[0])
+	}
+	if !pubKey.VerifyBytes(signMsg.Bytes(), sig) {
+		return nil, ErrInvalidTx.wrapf("invalid signature")
+	}
+
+	tx := auth.NewStdTx(signMsg.Msgs, []auth.StdSignature{{
+		PubKey:        pubKey,
+		Signature:     sig,
+		AccountNumber: signMsg.AccountNumber,
+		Sequence:      signMsg.Sequence,
+	}}, signMsg.Memo, signMsg.Source, signMsg.Data)
+	bz, merr := s.cdc.MarshalBinaryLengthPrefixed(tx)
+	if merr != nil {
+		return nil, ErrInvalidTx.wrap(merr)
+	}
+	return ConstructionCombineResponse{SignedTransaction: hex.EncodeToString(bz)}, nil
+}
+
+func (s *Server) constructionParse(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionParseRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	res := ConstructionParseResponse{AccountIdentifierSigners: make([]AccountIdentifier, 0)}
+	if !req.Signed {
+		signMsg, err := s.decodeUnsignedTx(req.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		res.Operations = msgOperations(signMsg.Msgs, "")
+		res.Metadata = map[string]string{"memo": signMsg.Memo}
+		return res, nil
+	}
+
+	_, tx, err := s.decodeSignedTx(req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	res.Operations = msgOperations(tx.GetMsgs(), "")
+	res.Metadata = map[string]string{"memo": tx.Memo}
+	for _, signer := range tx.GetSigners() {
+		res.AccountIdentifierSigners = append(res.AccountIdentifierSigners, AccountIdentifier{Address: signer.String()})
+	}
+	return res, nil
+}
+
+func (s *Server) constructionHash(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionHashRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	bz, _, err := s.decodeSignedTx(req.SignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	return TransactionIdentifierResponse{
+		TransactionIdentifier: TransactionIdentifier{Hash: cmn.HexBytes(types.Tx(bz).Hash()).String()},
+	}, nil
+}
+
+func (s *Server) constructionSubmit(dec *json.Decoder) (interface{}, *Error) {
+	var req ConstructionSubmitRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	bz, _, err := s.decodeSignedTx(req.SignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	res, serr := s.cliCtx.BroadcastTxSync(bz)
+	if serr != nil {
+		return nil, ErrNodeUnavailable.wrap(serr)
+	}
+	if res.Code != 0 {
+		return nil, ErrTxRejected.wrap(res.Log)
+	}
+	return TransactionIdentifierResponse{
+		TransactionIdentifier: TransactionIdentifier{Hash: res.Hash.String()},
+	}, nil
+}
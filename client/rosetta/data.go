@@ -0,0 +1,311 @@
+package rosetta
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// mempoolLimit is the maximum number of the mempool txs listed by /mempool.
+const mempoolLimit = 100
+
+func (s *Server) node() (rpcclient.Client, *Error) {
+	node, err := s.cliCtx.GetNode()
+	if err != nil {
+		return nil, ErrNodeUnavailable.wrap(err)
+	}
+	return node, nil
+}
+
+// fetchBlock returns the block identified by id, the latest block with no
+// index nor hash.
+func (s *Server) fetchBlock(id PartialBlockIdentifier) (*ctypes.ResultBlock, *Error) {
+	node, rerr := s.node()
+	if rerr != nil {
+		return nil, rerr
+	}
+	var res *ctypes.ResultBlock
+	var err error
+	switch {
+	case id.Index != nil:
+		res, err = node.Block(id.Index)
+	case id.Hash != nil:
+		var hash []byte
+		if hash, err = hex.DecodeString(*id.Hash); err != nil {
+			return nil, ErrInvalidRequest.wrap(err)
+		}
+		res, err = node.BlockByHash(hash)
+	default:
+		res, err = node.Block(nil)
+	}
+	if err != nil {
+		return nil, ErrNotFound.wrap(err)
+	}
+	if res.Block == nil {
+		return nil, ErrNotFound.wrapf("no block %v", id)
+	}
+	if id.Index != nil && id.Hash != nil && !strings.EqualFold(res.BlockMeta.BlockID.Hash.String(), *id.Hash) {
+		return nil, ErrNotFound.wrapf("the block of index %d has hash %s", *id.Index, res.BlockMeta.BlockID.Hash)
+	}
+	return res, nil
+}
+
+func blockIdentifier(res *ctypes.ResultBlock) BlockIdentifier {
+	return BlockIdentifier{Index: res.Block.Height, Hash: res.BlockMeta.BlockID.Hash.String()}
+}
+
+func (s *Server) networkList(dec *json.Decoder) (interface{}, *Error) {
+	var req MetadataRequest
+	if err := decode(dec, &req); err != nil {
+		return nil, err
+	}
+	return NetworkListResponse{NetworkIdentifiers: []NetworkIdentifier{s.network}}, nil
+}
+
+func (s *Server) networkStatus(dec *json.Decoder) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+	status, serr := node.Status()
+	if serr != nil {
+		return nil, ErrNodeUnavailable.wrap(serr)
+	}
+	genesisHeight := int64(1)
+	genesis, err := s.fetchBlock(PartialBlockIdentifier{Index: &genesisHeight})
+	if err != nil {
+		return nil, err
+	}
+	netInfo, serr := node.NetInfo()
+	if serr != nil {
+		return nil, ErrNodeUnavailable.wrap(serr)
+	}
+
+	peers := make([]Peer, len(netInfo.Peers))
+	for i, peer := range netInfo.Peers {
+		peers[i] = Peer{PeerID: string(peer.NodeInfo.ID())}
+	}
+	return NetworkStatusResponse{
+		CurrentBlockIdentifier: BlockIdentifier{
+			Index: status.SyncInfo.LatestBlockHeight,
+			Hash:  status.SyncInfo.LatestBlockHash.String(),
+		},
+		CurrentBlockTimestamp:  status.SyncInfo.LatestBlockTime.UnixNano() / 1e6,
+		GenesisBlockIdentifier: blockIdentifier(genesis),
+		Peers:                  peers,
+	}, nil
+}
+
+func (s *Server) networkOptions(dec *json.Decoder) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+	status, serr := node.Status()
+	if serr != nil {
+		return nil, ErrNodeUnavailable.wrap(serr)
+	}
+	return NetworkOptionsResponse{
+		Version: Version{RosettaVersion: RosettaVersion, NodeVersion: status.NodeInfo.Version},
+		Allow: Allow{
+			OperationStatuses: []OperationStatus{
+				{Status: StatusSuccess, Successful: true},
+				{Status: StatusFailure, Successful: false},
+			},
+			OperationTypes:          []string{OperationTransfer},
+			Errors:                  allErrors,
+			HistoricalBalanceLookup: true,
+		},
+	}, nil
+}
+
+func (s *Server) accountBalance(dec *json.Decoder) (interface{}, *Error) {
+	var req AccountBalanceRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	addr, err := sdk.AccAddressFromBech32(req.AccountIdentifier.Address)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+
+	var id PartialBlockIdentifier
+	if req.BlockIdentifier != nil {
+		id = *req.BlockIdentifier
+	}
+	block, rerr := s.fetchBlock(id)
+	if rerr != nil {
+		return nil, rerr
+	}
+	if id == (PartialBlockIdentifier{}) && !s.cliCtx.TrustNode && block.Block.Height > 1 {
+		// the state of the latest block cannot be proven before the next
+		// block commits its app hash
+		height := block.Block.Height - 1
+		if block, rerr = s.fetchBlock(PartialBlockIdentifier{Index: &height}); rerr != nil {
+			return nil, rerr
+		}
+	}
+	account, rerr := s.account(addr, block.Block.Height)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	res := AccountBalanceResponse{BlockIdentifier: blockIdentifier(block), Balances: make([]Amount, 0)}
+	if account == nil {
+		return res, nil
+	}
+	for _, coin := range account.GetCoins() {
+		res.Balances = append(res.Balances, Amount{
+			Value:    strconv.FormatInt(coin.Amount, 10),
+			Currency: Currency{Symbol: coin.Denom, Decimals: coinDecimals},
+		})
+	}
+	res.Metadata = map[string]string{
+		"account_number": strconv.FormatInt(account.GetAccountNumber(), 10),
+		"sequence":       strconv.FormatInt(account.GetSequence(), 10),
+	}
+	return res, nil
+}
+
+// account returns the account of addr at height, the latest height if 0, or
+// nil if it does not exist.
+func (s *Server) account(addr sdk.AccAddress, height int64) (sdk.Account, *Error) {
+	ctx := s.cliCtx
+	ctx.Height = height
+	res, err := ctx.QueryStore(auth.AddressStoreKey(addr), s.storeName)
+	if err != nil {
+		return nil, ErrNodeUnavailable.wrap(err)
+	}
+	// the query will return empty if there is no data for this account
+	if len(res) == 0 {
+		return nil, nil
+	}
+	account, err := s.decoder(res)
+	if err != nil {
+		return nil, ErrInvalidRequest.wrap(err)
+	}
+	return account, nil
+}
+
+func (s *Server) block(dec *json.Decoder) (interface{}, *Error) {
+	var req BlockRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	block, err := s.fetchBlock(req.BlockIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.blockTransactions(block)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := BlockIdentifier{Index: block.Block.Height - 1, Hash: block.Block.LastBlockID.Hash.String()}
+	if block.Block.Height == 1 {
+		// the genesis block is its own parent
+		parent = blockIdentifier(block)
+	}
+	return BlockResponse{Block: Block{
+		BlockIdentifier:       blockIdentifier(block),
+		ParentBlockIdentifier: parent,
+		Timestamp:             block.Block.Time.UnixNano() / 1e6,
+		Transactions:          txs,
+	}}, nil
+}
+
+// blockTransactions returns the transactions of block, the operations of
+// their failed msgs have the failure status.
+func (s *Server) blockTransactions(block *ctypes.ResultBlock) ([]Transaction, *Error) {
+	node, rerr := s.node()
+	if rerr != nil {
+		return nil, rerr
+	}
+	results, err := node.BlockResults(&block.Block.Height)
+	if err != nil {
+		return nil, ErrNodeUnavailable.wrap(err)
+	}
+
+	decoder := auth.DefaultTxDecoder(s.cdc)
+	txs := make([]Transaction, len(block.Block.Txs))
+	for i, txBytes := range block.Block.Txs {
+		txs[i] = Transaction{
+			TransactionIdentifier: TransactionIdentifier{Hash: cmn.HexBytes(txBytes.Hash()).String()},
+			Operations:            make([]Operation, 0),
+		}
+		tx, err := decoder(txBytes)
+		if err != nil {
+			continue
+		}
+		status := StatusSuccess
+		if results.Results == nil || i >= len(results.Results.DeliverTx) || results.Results.DeliverTx[i].Code != 0 {
+			status = StatusFailure
+		}
+		txs[i].Operations = msgOperations(tx.GetMsgs(), status)
+		if stdTx, ok := tx.(auth.StdTx); ok && stdTx.Memo != "" {
+			txs[i].Metadata = map[string]string{"memo": stdTx.Memo}
+		}
+	}
+	return txs, nil
+}
+
+func (s *Server) blockTransaction(dec *json.Decoder) (interface{}, *Error) {
+	var req BlockTransactionRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	block, err := s.fetchBlock(PartialBlockIdentifier{Index: &req.BlockIdentifier.Index, Hash: &req.BlockIdentifier.Hash})
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.blockTransactions(block)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if tx.TransactionIdentifier == req.TransactionIdentifier {
+			return BlockTransactionResponse{Transaction: tx}, nil
+		}
+	}
+	return nil, ErrNotFound.wrapf("no transaction %s in block %d", req.TransactionIdentifier.Hash, req.BlockIdentifier.Index)
+}
+
+func (s *Server) mempool(dec *json.Decoder) (interface{}, *Error) {
+	var req NetworkRequest
+	if err := s.decodeOnNetwork(dec, &req, &req.NetworkIdentifier); err != nil {
+		return nil, err
+	}
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+	mempool, ok := node.(rpcclient.MempoolClient)
+	if !ok {
+		return nil, ErrUnsupported.wrapf("the node client does not read the mempool")
+	}
+	res, serr := mempool.UnconfirmedTxs(mempoolLimit)
+	if serr != nil {
+		return nil, ErrNodeUnavailable.wrap(serr)
+	}
+	ids := make([]TransactionIdentifier, len(res.Txs))
+	for i, tx := range res.Txs {
+		ids[i] = TransactionIdentifier{Hash: cmn.HexBytes(tx.Hash()).String()}
+	}
+	return MempoolResponse{TransactionIdentifiers: ids}, nil
+}
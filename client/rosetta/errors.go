@@ -0,0 +1,40 @@
+package rosetta
+
+import "fmt"
+
+// Error is the error of a Rosetta API call.
+type Error struct {
+	Code      int32             `json:"code"`
+	Message   string            `json:"message"`
+	Retriable bool              `json:"retriable"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+var (
+	ErrInvalidRequest  = &Error{Code: 1, Message: "invalid request"}
+	ErrInvalidNetwork  = &Error{Code: 2, Message: "unknown network"}
+	ErrNodeUnavailable = &Error{Code: 3, Message: "the node is unavailable", Retriable: true}
+	ErrNotFound        = &Error{Code: 4, Message: "not found"}
+	ErrUnsupported     = &Error{Code: 5, Message: "unsupported operation"}
+	ErrInvalidTx       = &Error{Code: 6, Message: "invalid transaction"}
+	ErrTxRejected      = &Error{Code: 7, Message: "the transaction was rejected"}
+
+	// allErrors are all the errors the server returns, listed by
+	// /network/options
+	allErrors = []*Error{
+		ErrInvalidRequest, ErrInvalidNetwork, ErrNodeUnavailable, ErrNotFound,
+		ErrUnsupported, ErrInvalidTx, ErrTxRejected,
+	}
+)
+
+// wrap returns a copy of e detailed by the message of err.
+func (e *Error) wrap(err interface{}) *Error {
+	wrapped := *e
+	wrapped.Details = map[string]string{"error": fmt.Sprint(err)}
+	return &wrapped
+}
+
+// wrapf returns a copy of e detailed by the formatted message.
+func (e *Error) wrapf(format string, args ...interface{}) *Error {
+	return e.wrap(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,111 @@
+package rosetta
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	// OperationTransfer is the type of the operations moving the coins of
+	// the inputs and the outputs of a bank MsgSend
+	OperationTransfer = "transfer"
+
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+
+	// coinDecimals is the number of decimals of the amounts of the coins
+	coinDecimals = 8
+)
+
+// transferOperations returns the operations of msg, numbered from index: an
+// operation debiting each coin of the inputs, then one crediting each coin
+// of the outputs.
+func transferOperations(msg bank.MsgSend, status string, index int64) []Operation {
+	var ops []Operation
+	add := func(addr sdk.AccAddress, coin sdk.Coin, sign int64) {
+		ops = append(ops, Operation{
+			OperationIdentifier: OperationIdentifier{Index: index},
+			Type:                OperationTransfer,
+			Status:              status,
+			Account:             &AccountIdentifier{Address: addr.String()},
+			Amount: &Amount{
+				Value:    strconv.FormatInt(sign*coin.Amount, 10),
+				Currency: Currency{Symbol: coin.Denom, Decimals: coinDecimals},
+			},
+		})
+		index++
+	}
+	for _, in := range msg.Inputs {
+		for _, coin := range in.Coins {
+			add(in.Address, coin, -1)
+		}
+	}
+	for _, out := range msg.Outputs {
+		for _, coin := range out.Coins {
+			add(out.Address, coin, 1)
+		}
+	}
+	return ops
+}
+
+// msgOperations returns the operations of the msgs of a tx.
+func msgOperations(msgs []sdk.Msg, status string) []Operation {
+	ops := make([]Operation, 0)
+	for _, msg := range msgs {
+		if send, ok := msg.(bank.MsgSend); ok {
+			ops = append(ops, transferOperations(send, status, int64(len(ops)))...)
+		}
+	}
+	return ops
+}
+
+// sendFromOperations returns the MsgSend of the transfer operations: the
+// debits are its inputs and the credits its outputs.
+func sendFromOperations(ops []Operation) (bank.MsgSend, *Error) {
+	var inputs, outputs []sdk.AccAddress
+	coins := make(map[string]sdk.Coins)
+	for _, op := range ops {
+		if op.Type != OperationTransfer {
+			return bank.MsgSend{}, ErrUnsupported.wrapf("operation type %s", op.Type)
+		}
+		if op.Account == nil || op.Amount == nil {
+			return bank.MsgSend{}, ErrInvalidRequest.wrapf("operation %d has no account or amount", op.OperationIdentifier.Index)
+		}
+		addr, err := sdk.AccAddressFromBech32(op.Account.Address)
+		if err != nil {
+			return bank.MsgSend{}, ErrInvalidRequest.wrap(err)
+		}
+		amount, err := strconv.ParseInt(op.Amount.Value, 10, 64)
+		if err != nil || amount == 0 {
+			return bank.MsgSend{}, ErrInvalidRequest.wrapf("invalid amount %q", op.Amount.Value)
+		}
+
+		// the inputs and the outputs are keyed apart, an address can be both
+		key := "+" + addr.String()
+		if amount < 0 {
+			key, amount = "-"+addr.String(), -amount
+		}
+		if _, ok := coins[key]; !ok {
+			if key[0] == '-' {
+				inputs = append(inputs, addr)
+			} else {
+				outputs = append(outputs, addr)
+			}
+		}
+		coins[key] = coins[key].Plus(sdk.Coins{sdk.NewCoin(op.Amount.Currency.Symbol, amount)})
+	}
+
+	var msg bank.MsgSend
+	for _, addr := range inputs {
+		msg.Inputs = append(msg.Inputs, bank.NewInput(addr, coins["-"+addr.String()]))
+	}
+	for _, addr := range outputs {
+		msg.Outputs = append(msg.Outputs, bank.NewOutput(addr, coins["+"+addr.String()]))
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return bank.MsgSend{}, ErrInvalidTx.wrap(err.Error())
+	}
+	return msg, nil
+}
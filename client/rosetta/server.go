@@ -0,0 +1,161 @@
+// Package rosetta serves the Data and Construction APIs of the Rosetta
+// specification, see https://www.rosetta-api.org, over a node of the chain:
+// the balances of the accounts and the transfers of the blocks, and the
+// construction, signing and submission of the transfer transactions.
+package rosetta
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+	tmserver "github.com/tendermint/tendermint/rpc/lib/server"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+)
+
+const (
+	// RosettaVersion is the version of the Rosetta specification implemented
+	RosettaVersion = "1.4.10"
+
+	flagListenAddr         = "laddr"
+	flagBlockchain         = "blockchain"
+	flagMaxOpenConnections = "max-open"
+)
+
+// Server answers the Rosetta API calls of the network identified by the
+// blockchain name and the chain ID of its context.
+type Server struct {
+	cliCtx    context.CLIContext
+	cdc       *codec.Codec
+	decoder   auth.AccountDecoder
+	storeName string
+	network   NetworkIdentifier
+}
+
+// NewServer returns a Server answering the calls with cliCtx. The accounts
+// are read from the store named accStoreName, and the transactions encoded
+// with cdc, which must have the types of the application registered.
+func NewServer(cliCtx context.CLIContext, cdc *codec.Codec, accStoreName, blockchain, chainID string) *Server {
+	return &Server{
+		cliCtx:    cliCtx.WithCodec(cdc),
+		cdc:       cdc,
+		decoder:   authcmd.GetAccountDecoder(cdc),
+		storeName: accStoreName,
+		network:   NetworkIdentifier{Blockchain: blockchain, Network: chainID},
+	}
+}
+
+// Handler returns the handler of the API calls.
+func (s *Server) Handler() http.Handler {
+	r := mux.NewRouter()
+	routes := map[string]func(*json.Decoder) (interface{}, *Error){
+		"/network/list":            s.networkList,
+		"/network/status":          s.networkStatus,
+		"/network/options":         s.networkOptions,
+		"/account/balance":         s.accountBalance,
+		"/block":                   s.block,
+		"/block/transaction":       s.blockTransaction,
+		"/mempool":                 s.mempool,
+		"/construction/derive":     s.constructionDerive,
+		"/construction/preprocess": s.constructionPreprocess,
+		"/construction/metadata":   s.constructionMetadata,
+		"/construction/payloads":   s.constructionPayloads,
+		"/construction/combine":    s.constructionCombine,
+		"/construction/parse":      s.constructionParse,
+		"/construction/hash":       s.constructionHash,
+		"/construction/submit":     s.constructionSubmit,
+	}
+	for path, call := range routes {
+		r.HandleFunc(path, handler(call)).Methods("POST")
+	}
+	return r
+}
+
+// handler serves call, which decodes its request from the body.
+func handler(call func(*json.Decoder) (interface{}, *Error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		res, err := call(json.NewDecoder(r.Body))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(err)
+			return
+		}
+		json.NewEncoder(w).Encode(res)
+	}
+}
+
+// decode decodes the request of a call.
+func decode(dec *json.Decoder, req interface{}) *Error {
+	if err := dec.Decode(req); err != nil {
+		return ErrInvalidRequest.wrap(err)
+	}
+	return nil
+}
+
+// decodeOnNetwork decodes the request of a call, which must be on the
+// network of the server.
+func (s *Server) decodeOnNetwork(dec *json.Decoder, req interface{}, network *NetworkIdentifier) *Error {
+	if err := decode(dec, req); err != nil {
+		return err
+	}
+	if *network != s.network {
+		return ErrInvalidNetwork.wrapf("expected network %s/%s", s.network.Blockchain, s.network.Network)
+	}
+	return nil
+}
+
+// ServeCommand returns the command serving the Rosetta API of the node.
+func ServeCommand(cdc *codec.Codec, accStoreName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rosetta",
+		Short: "Start a server of the Rosetta Data and Construction APIs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "rosetta")
+			chainID := viper.GetString(client.FlagChainID)
+			s := NewServer(context.NewCLIContext(), cdc, accStoreName, viper.GetString(flagBlockchain), chainID)
+
+			cfg := &tmserver.Config{MaxOpenConnections: viper.GetInt(flagMaxOpenConnections)}
+			listener, err := tmserver.Listen(viper.GetString(flagListenAddr), cfg)
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := tmserver.StartHTTPServer(listener, s.Handler(), logger, cfg); err != nil {
+					panic(err)
+				}
+			}()
+			logger.Info("Rosetta server started", "chain-id", chainID)
+
+			server.TrapSignal(func() {
+				if err := listener.Close(); err != nil {
+					logger.Error("error closing listener", "err", err)
+				}
+			})
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagListenAddr, "tcp://localhost:8080", "The address for the server to listen on")
+	cmd.Flags().String(flagBlockchain, "bnbchain", "The blockchain name of the network identifier")
+	cmd.Flags().Int(flagMaxOpenConnections, 1000, "The number of maximum open connections")
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node")
+	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "Address of the node to connect to")
+	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	client.AddTrustFlags(cmd)
+	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
+	viper.BindPFlag(client.FlagChainID, cmd.Flags().Lookup(client.FlagChainID))
+	viper.BindPFlag(client.FlagNode, cmd.Flags().Lookup(client.FlagNode))
+
+	return cmd
+}
@@ -0,0 +1,138 @@
+package rosetta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// call posts req to path, and decodes the response into res, or the error.
+func call(t *testing.T, server *httptest.Server, path string, req, res interface{}) *Error {
+	bz, err := json.Marshal(req)
+	require.NoError(t, err)
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(bz))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var rerr Error
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&rerr))
+		return &rerr
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(res))
+	return nil
+}
+
+func TestConstruction(t *testing.T) {
+	s := NewServer(context.NewCLIContext(), app.MakeCodec(), "acc", "bnbchain", "test-chain")
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+	network := NetworkIdentifier{Blockchain: "bnbchain", Network: "test-chain"}
+
+	priv := secp256k1.GenPrivKey()
+	pubKey := PublicKey{HexBytes: hex.EncodeToString(priv.PubKey().(secp256k1.PubKeySecp256k1)), CurveType: curveSecp256k1}
+	from := sdk.AccAddress(priv.PubKey().Address()).String()
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String()
+
+	var derived ConstructionDeriveResponse
+	require.Nil(t, call(t, server, "/construction/derive", ConstructionDeriveRequest{NetworkIdentifier: network, PublicKey: pubKey}, &derived))
+	require.Equal(t, from, derived.AccountIdentifier.Address)
+
+	transfer := func(index int64, addr, value string) Operation {
+		return Operation{
+			OperationIdentifier: OperationIdentifier{Index: index},
+			Type:                OperationTransfer,
+			Account:             &AccountIdentifier{Address: addr},
+			Amount:              &Amount{Value: value, Currency: Currency{Symbol: "BNB", Decimals: coinDecimals}},
+		}
+	}
+	ops := []Operation{transfer(0, from, "-100"), transfer(1, to, "100")}
+
+	var preprocessed ConstructionPreprocessResponse
+	require.Nil(t, call(t, server, "/construction/preprocess", ConstructionPreprocessRequest{
+		NetworkIdentifier: network, Operations: ops, Metadata: map[string]string{"memo": "memo"},
+	}, &preprocessed))
+	require.Equal(t, from, preprocessed.Options["sender"])
+
+	// the calls are on the network of the server
+	rerr := call(t, server, "/construction/preprocess", ConstructionPreprocessRequest{
+		NetworkIdentifier: NetworkIdentifier{Blockchain: "bnbchain", Network: "other-chain"}, Operations: ops,
+	}, &preprocessed)
+	require.NotNil(t, rerr)
+	require.Equal(t, ErrInvalidNetwork.Code, rerr.Code)
+	// the amounts of the transfers balance
+	rerr = call(t, server, "/construction/preprocess", ConstructionPreprocessRequest{
+		NetworkIdentifier: network, Operations: []Operation{transfer(0, from, "-100"), transfer(1, to, "99")},
+	}, &preprocessed)
+	require.NotNil(t, rerr)
+	require.Equal(t, ErrInvalidTx.Code, rerr.Code)
+
+	metadata := map[string]string{"chain_id": "test-chain", "account_number": "3", "sequence": "7", "memo": "memo"}
+	var payloads ConstructionPayloadsResponse
+	require.Nil(t, call(t, server, "/construction/payloads", ConstructionPayloadsRequest{
+		NetworkIdentifier: network, Operations: ops, Metadata: metadata,
+	}, &payloads))
+	require.Len(t, payloads.Payloads, 1)
+	require.Equal(t, from, payloads.Payloads[0].AccountIdentifier.Address)
+
+	var parsed ConstructionParseResponse
+	require.Nil(t, call(t, server, "/construction/parse", ConstructionParseRequest{
+		NetworkIdentifier: network, Transaction: payloads.UnsignedTransaction,
+	}, &parsed))
+	require.Equal(t, ops, parsed.Operations)
+	require.Empty(t, parsed.AccountIdentifierSigners)
+
+	// the payload is the sha256 of the sign bytes
+	signMsg, rerr := s.decodeUnsignedTx(payloads.UnsignedTransaction)
+	require.Nil(t, rerr)
+	require.Equal(t, int64(3), signMsg.AccountNumber)
+	require.Equal(t, int64(7), signMsg.Sequence)
+	hash := sha256.Sum256(signMsg.Bytes())
+	require.Equal(t, hex.EncodeToString(hash[:]), payloads.Payloads[0].HexBytes)
+	sig, err := priv.Sign(signMsg.Bytes())
+	require.NoError(t, err)
+
+	signature := Signature{
+		SigningPayload: payloads.Payloads[0],
+		PublicKey:      pubKey,
+		SignatureType:  signatureECDSA,
+		HexBytes:       hex.EncodeToString(sig),
+	}
+	var combined ConstructionCombineResponse
+	require.Nil(t, call(t, server, "/construction/combine", ConstructionCombineRequest{
+		NetworkIdentifier: network, UnsignedTransaction: payloads.UnsignedTransaction, Signatures: []Signature{signature},
+	}, &combined))
+
+	require.Nil(t, call(t, server, "/construction/parse", ConstructionParseRequest{
+		NetworkIdentifier: network, Signed: true, Transaction: combined.SignedTransaction,
+	}, &parsed))
+	require.Equal(t, ops, parsed.Operations)
+	require.Equal(t, []AccountIdentifier{{Address: from}}, parsed.AccountIdentifierSigners)
+	require.Equal(t, "memo", parsed.Metadata["memo"])
+
+	var hashed TransactionIdentifierResponse
+	require.Nil(t, call(t, server, "/construction/hash", ConstructionHashRequest{
+		NetworkIdentifier: network, SignedTransaction: combined.SignedTransaction,
+	}, &hashed))
+	require.Len(t, hashed.TransactionIdentifier.Hash, 64)
+
+	// a signature of other bytes is rejected
+	sig, err = priv.Sign([]byte("other bytes"))
+	require.NoError(t, err)
+	signature.HexBytes = hex.EncodeToString(sig)
+	rerr = call(t, server, "/construction/combine", ConstructionCombineRequest{
+		NetworkIdentifier: network, UnsignedTransaction: payloads.UnsignedTransaction, Signatures: []Signature{signature},
+	}, &combined)
+	require.NotNil(t, rerr)
+	require.Equal(t, ErrInvalidTx.Code, rerr.Code)
+}
@@ -0,0 +1,379 @@
+package rpc
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+const (
+	// subscriberName is the name the LCD subscribes to the node with
+	subscriberName = "lcd"
+	// subscriberBuffer is the number of events buffered for a client, a
+	// client falling further behind is disconnected
+	subscriberBuffer = 100
+)
+
+// TopicFilter tells whether a message of a transaction belongs to a topic.
+// addr is the address the client subscribed with, nil if it did not give one.
+type TopicFilter func(msg sdk.Msg, addr sdk.AccAddress) bool
+
+var (
+	topicsMtx sync.RWMutex
+	topics    = map[string]TopicFilter{
+		"transfers":   transferFilter,
+		"delegations": delegationFilter,
+	}
+)
+
+// RegisterTopic registers a topic clients of the /subscribe endpoint can
+// subscribe to, so that applications can publish the events of their own
+// messages, e.g. orders. It panics if the topic is already registered.
+func RegisterTopic(topic string, filter TopicFilter) {
+	topicsMtx.Lock()
+	defer topicsMtx.Unlock()
+	if _, ok := topics[topic]; ok {
+		panic(fmt.Sprintf("topic %s already registered", topic))
+	}
+	topics[topic] = filter
+}
+
+func getTopic(topic string) (TopicFilter, bool) {
+	topicsMtx.RLock()
+	defer topicsMtx.RUnlock()
+	filter, ok := topics[topic]
+	return filter, ok
+}
+
+// transferFilter selects the transfers sent from or to addr.
+func transferFilter(msg sdk.Msg, addr sdk.AccAddress) bool {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return false
+	}
+	if addr == nil {
+		return true
+	}
+	for _, in := range send.Inputs {
+		if in.Address.Equals(addr) {
+			return true
+		}
+	}
+	for _, out := range send.Outputs {
+		if out.Address.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// delegationFilter selects the delegations, unbondings and redelegations of
+// the delegator addr, on the chain and on the side chains.
+func delegationFilter(msg sdk.Msg, addr sdk.AccAddress) bool {
+	var delegator sdk.AccAddress
+	switch msg := msg.(type) {
+	case stake.MsgDelegate:
+		delegator = msg.DelegatorAddr
+	case stake.MsgUndelegate:
+		delegator = msg.DelegatorAddr
+	case stake.MsgBeginUnbonding:
+		delegator = msg.DelegatorAddr
+	case stake.MsgRedelegate:
+		delegator = msg.DelegatorAddr
+	case stake.MsgSideChainDelegate:
+		delegator = msg.DelegatorAddr
+	case stake.MsgSideChainUndelegate:
+		delegator = msg.DelegatorAddr
+	case stake.MsgSideChainRedelegate:
+		delegator = msg.DelegatorAddr
+	default:
+		return false
+	}
+	return addr == nil || delegator.Equals(addr)
+}
+
+// SubscribeRequest is a message a client sends on the /subscribe WebSocket.
+type SubscribeRequest struct {
+	// Method is either "subscribe" or "unsubscribe".
+	Method string `json:"method"`
+	Topic  string `json:"topic"`
+	// Address is the bech32 address to filter the events of the topic with,
+	// every event of the topic is sent if it is empty.
+	Address string `json:"address,omitempty"`
+}
+
+// SubscribeEvent is an event sent to the clients of the /subscribe WebSocket:
+// a transaction with the messages matching a subscription of the client.
+type SubscribeEvent struct {
+	Topic   string    `json:"topic"`
+	Address string    `json:"address,omitempty"`
+	Height  int64     `json:"height"`
+	TxHash  string    `json:"txhash"`
+	Code    uint32    `json:"code"`
+	Log     string    `json:"log,omitempty"`
+	Msgs    []sdk.Msg `json:"msgs"`
+}
+
+// subscribeResponse answers a SubscribeRequest.
+type subscribeResponse struct {
+	Request SubscribeRequest `json:"request"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type subscription struct {
+	topic   string
+	address string
+}
+
+// subscriber is a client connected to the /subscribe WebSocket.
+type subscriber struct {
+	send chan []byte
+
+	mtx           sync.Mutex
+	subscriptions map[subscription]sdk.AccAddress
+}
+
+// eventHub shares one subscription to the transactions of the node between
+// the clients of the /subscribe WebSocket.
+type eventHub struct {
+	cliCtx    context.CLIContext
+	txDecoder sdk.TxDecoder
+	// subscribe subscribes to the transactions of the node
+	subscribe func() (<-chan ctypes.ResultEvent, error)
+
+	mtx         sync.Mutex
+	started     bool
+	subscribers map[*subscriber]struct{}
+}
+
+func newEventHub(cliCtx context.CLIContext) *eventHub {
+	h := &eventHub{
+		cliCtx:      cliCtx,
+		txDecoder:   auth.DefaultTxDecoder(cliCtx.Codec),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+	h.subscribe = h.subscribeNode
+	return h
+}
+
+func (h *eventHub) subscribeNode() (<-chan ctypes.ResultEvent, error) {
+	node, err := h.cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+	if !node.IsRunning() {
+		if err := node.Start(); err != nil {
+			return nil, err
+		}
+	}
+	return node.Subscribe(gocontext.Background(), subscriberName, tmtypes.EventQueryTx.String(), subscriberBuffer)
+}
+
+// add registers s, subscribing to the node on the first call.
+func (h *eventHub) add(s *subscriber) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if !h.started {
+		events, err := h.subscribe()
+		if err != nil {
+			return err
+		}
+		h.started = true
+		go func() {
+			for event := range events {
+				h.dispatch(event)
+			}
+		}()
+	}
+	h.subscribers[s] = struct{}{}
+	return nil
+}
+
+func (h *eventHub) remove(s *subscriber) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.send)
+	}
+}
+
+// dispatch sends the messages of the transaction of event to the subscribers
+// they match.
+func (h *eventHub) dispatch(event ctypes.ResultEvent) {
+	data, ok := event.Data.(tmtypes.EventDataTx)
+	if !ok {
+		return
+	}
+	tx, err := h.txDecoder(data.Tx)
+	if err != nil {
+		return
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for s := range h.subscribers {
+		for _, ev := range s.match(tx.GetMsgs()) {
+			ev.Height = data.Height
+			ev.TxHash = fmt.Sprintf("%X", data.Tx.Hash())
+			ev.Code = data.Result.Code
+			ev.Log = data.Result.Log
+			bz, err := h.cliCtx.Codec.MarshalJSON(ev)
+			if err != nil {
+				continue
+			}
+			if !h.deliver(s, bz) {
+				break
+			}
+		}
+	}
+}
+
+// deliver queues bz to s. A subscriber whose queue is full is too slow, it is
+// dropped rather than block the others. The lock must be held.
+func (h *eventHub) deliver(s *subscriber, bz []byte) bool {
+	select {
+	case s.send <- bz:
+		return true
+	default:
+		delete(h.subscribers, s)
+		close(s.send)
+		return false
+	}
+}
+
+// match returns an event per subscription of s matching msgs.
+func (s *subscriber) match(msgs []sdk.Msg) []SubscribeEvent {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var events []SubscribeEvent
+	for sub, addr := range s.subscriptions {
+		filter, _ := getTopic(sub.topic)
+		var matched []sdk.Msg
+		for _, msg := range msgs {
+			if filter(msg, addr) {
+				matched = append(matched, msg)
+			}
+		}
+		if len(matched) > 0 {
+			events = append(events, SubscribeEvent{Topic: sub.topic, Address: sub.address, Msgs: matched})
+		}
+	}
+	return events
+}
+
+// handle applies req to the subscriptions of s.
+func (s *subscriber) handle(req SubscribeRequest) error {
+	if _, ok := getTopic(req.Topic); !ok {
+		return fmt.Errorf("unknown topic %q", req.Topic)
+	}
+	var addr sdk.AccAddress
+	if req.Address != "" {
+		var err error
+		if addr, err = sdk.AccAddressFromBech32(req.Address); err != nil {
+			return err
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub := subscription{topic: req.Topic, address: req.Address}
+	switch req.Method {
+	case "subscribe":
+		s.subscriptions[sub] = addr
+	case "unsubscribe":
+		delete(s.subscriptions, sub)
+	default:
+		return fmt.Errorf("unknown method %q, expected subscribe or unsubscribe", req.Method)
+	}
+	return nil
+}
+
+var upgrader = websocket.Upgrader{
+	// the LCD serves clients of any origin, like its REST endpoints
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// SubscribeRequestHandlerFn returns the handler of the /subscribe WebSocket,
+// which streams the transactions of the node matching the subscriptions of
+// the client, decoded with the codec of cliCtx.
+func SubscribeRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return newEventHub(cliCtx).serveWS
+}
+
+func (h *eventHub) serveWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		return
+	}
+	defer conn.Close()
+
+	s := &subscriber{
+		send:          make(chan []byte, subscriberBuffer),
+		subscriptions: make(map[subscription]sdk.AccAddress),
+	}
+	if err := h.add(s); err != nil {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()))
+		return
+	}
+	defer h.remove(s)
+
+	// the write pump owns the writes to the connection
+	replies := make(chan []byte)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			var bz []byte
+			var ok bool
+			select {
+			case bz, ok = <-s.send:
+				if !ok {
+					conn.Close()
+					return
+				}
+			case bz = <-replies:
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, bz); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		_, bz, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req SubscribeRequest
+		res := subscribeResponse{}
+		if err := json.Unmarshal(bz, &req); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Request = req
+			if err := s.handle(req); err != nil {
+				res.Error = err.Error()
+			}
+		}
+		reply, _ := json.Marshal(res)
+		select {
+		case replies <- reply:
+		case <-done:
+			return
+		}
+	}
+}
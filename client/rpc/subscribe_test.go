@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func newTestAddr() sdk.AccAddress {
+	return sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+}
+
+func TestTopicFilters(t *testing.T) {
+	from, to, other := newTestAddr(), newTestAddr(), newTestAddr()
+	coins := sdk.Coins{sdk.NewCoin("steak", 10)}
+	send := bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+	delegate := stake.MsgDelegate{DelegatorAddr: from}
+
+	require.True(t, transferFilter(send, nil))
+	require.True(t, transferFilter(send, from))
+	require.True(t, transferFilter(send, to))
+	require.False(t, transferFilter(send, other))
+	require.False(t, transferFilter(delegate, nil))
+
+	require.True(t, delegationFilter(delegate, nil))
+	require.True(t, delegationFilter(delegate, from))
+	require.False(t, delegationFilter(delegate, to))
+	require.True(t, delegationFilter(stake.MsgSideChainUndelegate{DelegatorAddr: from}, from))
+	require.False(t, delegationFilter(send, nil))
+
+	require.Panics(t, func() { RegisterTopic("transfers", transferFilter) })
+}
+
+func TestSubscribe(t *testing.T) {
+	cdc := app.MakeCodec()
+	events := make(chan ctypes.ResultEvent)
+	hub := newEventHub(context.NewCLIContext().WithCodec(cdc))
+	hub.subscribe = func() (<-chan ctypes.ResultEvent, error) { return events, nil }
+	server := httptest.NewServer(http.HandlerFunc(hub.serveWS))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	readResponse := func() subscribeResponse {
+		var res subscribeResponse
+		require.NoError(t, conn.ReadJSON(&res))
+		return res
+	}
+
+	to := newTestAddr()
+	require.NoError(t, conn.WriteJSON(SubscribeRequest{Method: "subscribe", Topic: "orders"}))
+	require.Contains(t, readResponse().Error, "unknown topic")
+	require.NoError(t, conn.WriteJSON(SubscribeRequest{Method: "subscribe", Topic: "transfers", Address: "invalid"}))
+	require.NotEmpty(t, readResponse().Error)
+	require.NoError(t, conn.WriteJSON(SubscribeRequest{Method: "subscribe", Topic: "transfers", Address: to.String()}))
+	require.Empty(t, readResponse().Error)
+
+	coins := sdk.Coins{sdk.NewCoin("steak", 10)}
+	publish := func(msgs ...sdk.Msg) tmtypes.Tx {
+		tx := tmtypes.Tx(cdc.MustMarshalBinaryLengthPrefixed(auth.NewStdTx(msgs, nil, "", 0, nil)))
+		events <- ctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: tmtypes.TxResult{
+			Height: 5,
+			Tx:     tx,
+			Result: abci.ResponseDeliverTx{Code: 0},
+		}}}
+		return tx
+	}
+	// neither a transfer to the address nor a transfer at all
+	publish(bank.NewMsgSend([]bank.Input{bank.NewInput(newTestAddr(), coins)}, []bank.Output{bank.NewOutput(newTestAddr(), coins)}))
+	publish(stake.MsgDelegate{DelegatorAddr: to})
+	send := bank.NewMsgSend([]bank.Input{bank.NewInput(newTestAddr(), coins)}, []bank.Output{bank.NewOutput(to, coins)})
+	tx := publish(stake.MsgDelegate{DelegatorAddr: to}, send)
+
+	_, bz, err := conn.ReadMessage()
+	require.NoError(t, err)
+	var event SubscribeEvent
+	require.NoError(t, cdc.UnmarshalJSON(bz, &event))
+	require.Equal(t, "transfers", event.Topic)
+	require.Equal(t, to.String(), event.Address)
+	require.Equal(t, int64(5), event.Height)
+	require.Equal(t, fmt.Sprintf("%X", tx.Hash()), event.TxHash)
+	require.Equal(t, []sdk.Msg{send}, event.Msgs)
+}
@@ -3,25 +3,21 @@ package tx
 import (
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 )
 
-const (
-	// Returns with the response from CheckTx.
-	flagSync = "sync"
-	// Returns right away, with no response
-	flagAsync = "async"
-	// Only returns error if mempool.BroadcastTx errs (ie. problem with the app) or if we timeout waiting for tx to commit.
-	flagBlock = "block"
-)
-
 // BroadcastBody Tx Broadcast Body
 type BroadcastBody struct {
 	TxBytes []byte `json:"tx"`
-	Return  string `json:"return"`
+	// Return is the broadcast mode: block, sync or async
+	Return string `json:"return"`
+	// ConfirmTimeout is the duration, e.g. 30s, to wait for the tx in a block
+	// in sync mode
+	ConfirmTimeout string `json:"confirm_timeout,omitempty"`
 }
 
 // BroadcastTxRequest REST Handler
@@ -41,11 +37,20 @@ func BroadcastTxRequest(cliCtx context.CLIContext, cdc *codec.Codec) http.Handle
 		}
 		var res interface{}
 		switch m.Return {
-		case flagBlock:
-			res, err = cliCtx.BroadcastTx(m.TxBytes)
-		case flagSync:
-			res, err = cliCtx.BroadcastTxSync(m.TxBytes)
-		case flagAsync:
+		case context.BroadcastBlock:
+			res, err = cliCtx.WithBroadcastMode(context.BroadcastBlock).BroadcastTx(m.TxBytes)
+		case context.BroadcastSync:
+			if m.ConfirmTimeout == "" {
+				res, err = cliCtx.BroadcastTxSync(m.TxBytes)
+				break
+			}
+			timeout, perr := time.ParseDuration(m.ConfirmTimeout)
+			if perr != nil || timeout <= 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "confirm_timeout is not a valid positive duration, e.g. 30s")
+				return
+			}
+			res, err = cliCtx.WithBroadcastMode(context.BroadcastSync).WithConfirmTimeout(timeout).BroadcastTx(m.TxBytes)
+		case context.BroadcastAsync:
 			res, err = cliCtx.BroadcastTxAsync(m.TxBytes)
 		default:
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, "unsupported return type. supported types: block, sync, async")
@@ -0,0 +1,100 @@
+package tx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/txindex"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// IndexedTx is a tx found by the app tx search, with its indexed attributes.
+type IndexedTx struct {
+	Hash       string              `json:"hash"`
+	Height     int64               `json:"height"`
+	Index      uint32              `json:"index"`
+	Code       uint32              `json:"code"`
+	Log        string              `json:"log"`
+	Tx         sdk.Tx              `json:"tx"`
+	Attributes []txindex.Attribute `json:"attributes"`
+}
+
+// IndexSearchResult is a page of the txs found by the app tx search.
+type IndexSearchResult struct {
+	TotalCount int         `json:"total_count"`
+	Txs        []IndexedTx `json:"txs"`
+}
+
+// searchIndexedTxs searches the txs indexed by the app of the node. The index
+// is not part of the state, its results are trusted from the node.
+func searchIndexedTxs(cliCtx context.CLIContext, cdc *codec.Codec, params txindex.QuerySearchParams) (IndexSearchResult, error) {
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return IndexSearchResult{}, err
+	}
+	path := fmt.Sprintf("custom/%s/%s", baseapp.TxIndexRoute, txindex.QuerySearch)
+	res, err := cliCtx.WithTrustNode(true).QueryWithData(path, bz)
+	if err != nil {
+		return IndexSearchResult{}, err
+	}
+	var found txindex.SearchResult
+	if err := json.Unmarshal(res, &found); err != nil {
+		return IndexSearchResult{}, err
+	}
+
+	out := IndexSearchResult{TotalCount: found.TotalCount, Txs: make([]IndexedTx, len(found.Txs))}
+	for i, record := range found.Txs {
+		// the undecodable txs are indexed too, with no tx
+		tx, _ := parseTx(cdc, record.Tx)
+		out.Txs[i] = IndexedTx{
+			Hash:       record.Hash,
+			Height:     record.Height,
+			Index:      record.Index,
+			Code:       record.Code,
+			Log:        record.Log,
+			Tx:         tx,
+			Attributes: record.Attributes,
+		}
+	}
+	return out, nil
+}
+
+// SearchIndexedTxsRequestHandlerFn searches the txs indexed by the app with
+// the query parameter, e.g. transfer.recipient=bnb1... AND height>1000, see
+// txindex.ParseQuery. The node must run with the app tx index enabled.
+func SearchIndexedTxsRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := txindex.QuerySearchParams{Query: r.FormValue("query"), Page: 1, PerPage: txindex.DefaultPerPage}
+		if params.Query == "" {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "You need to provide a query, e.g. transfer.recipient=bnb1... AND height>1000")
+			return
+		}
+		var err error
+		if pageStr := r.FormValue("page"); pageStr != "" {
+			if params.Page, err = strconv.Atoi(pageStr); err != nil || params.Page < 1 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "page parameter is not a valid positive integer")
+				return
+			}
+		}
+		if perPageStr := r.FormValue("per_page"); perPageStr != "" {
+			if params.PerPage, err = strconv.Atoi(perPageStr); err != nil || params.PerPage <= 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "per_page parameter is not a valid positive integer")
+				return
+			}
+		}
+
+		res, err := searchIndexedTxs(cliCtx, cdc, params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.SetTotalCountHeader(w, res.TotalCount)
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
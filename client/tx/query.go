@@ -88,16 +88,7 @@ func queryTx(cdc *codec.Codec, cliCtx context.CLIContext, hashHexStr string) ([]
 
 // ValidateTxResult performs transaction verification
 func ValidateTxResult(cliCtx context.CLIContext, res *ctypes.ResultTx) error {
-	check, err := cliCtx.Verify(res.Height)
-	if err != nil {
-		return err
-	}
-
-	err = res.Proof.Validate(check.Header.DataHash)
-	if err != nil {
-		return err
-	}
-	return nil
+	return cliCtx.VerifyTxResult(res)
 }
 
 func formatTxResult(cdc *codec.Codec, res *ctypes.ResultTx) (Info, error) {
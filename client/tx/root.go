@@ -18,7 +18,10 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 
 // register REST routes
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+	// before /txs/{hash}, which would match it
+	r.HandleFunc("/txs/index", SearchIndexedTxsRequestHandlerFn(cliCtx, cdc)).Methods("GET")
 	r.HandleFunc("/txs/{hash}", QueryTxRequestHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc("/txs", SearchTxRequestHandlerFn(cliCtx, cdc)).Methods("GET")
 	r.HandleFunc("/txs", BroadcastTxRequest(cliCtx, cdc)).Methods("POST")
+	r.HandleFunc("/txs/simulate", SimulateTxRequest(cliCtx, cdc)).Methods("POST")
 }
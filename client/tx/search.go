@@ -52,7 +52,7 @@ $ gaiacli tendermint txs --tag test1,test2 --any --page 0 --perPage 30
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			txs, err := searchTxs(cliCtx, cdc, tags, page, perPage)
+			txs, _, err := searchTxs(cliCtx, cdc, tags, page, perPage)
 			if err != nil {
 				return err
 			}
@@ -84,9 +84,10 @@ $ gaiacli tendermint txs --tag test1,test2 --any --page 0 --perPage 30
 	return cmd
 }
 
-func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string, page, perPage int) ([]Info, error) {
+// searchTxs returns the page of the txs with the tags, with the number of them
+func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string, page, perPage int) ([]Info, int, error) {
 	if len(tags) == 0 {
-		return nil, errors.New("must declare at least one tag to search")
+		return nil, 0, errors.New("must declare at least one tag to search")
 	}
 
 	// XXX: implement ANY
@@ -95,31 +96,31 @@ func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string, page,
 	// get the node
 	node, err := cliCtx.GetNode()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	prove := !cliCtx.TrustNode
 
 	res, err := node.TxSearch(query, prove, page, perPage)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if prove {
 		for _, tx := range res.Txs {
 			err := ValidateTxResult(cliCtx, tx)
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
 		}
 	}
 
 	info, err := FormatTxResults(cdc, res.Txs)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return info, nil
+	return info, res.TotalCount, nil
 }
 
 // parse the indexed txs into an array of Info
@@ -190,11 +191,12 @@ func SearchTxRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.
 			tag = strings.TrimRight(key, "_bech32") + "='" + sdk.AccAddress(bz).String() + "'"
 		}
 
-		txs, err := searchTxs(cliCtx, cdc, []string{tag}, page, perPage)
+		txs, total, err := searchTxs(cliCtx, cdc, []string{tag}, page, perPage)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		utils.SetTotalCountHeader(w, total)
 
 		if len(txs) == 0 {
 			w.Write([]byte("[]"))
@@ -0,0 +1,53 @@
+package tx
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SimulateBody Tx Simulate Body
+type SimulateBody struct {
+	TxBytes []byte `json:"tx"`
+}
+
+// SimulateTxRequest REST Handler, it runs the signed tx against the latest
+// state without committing it and returns the fee, the msg responses and the
+// events of the tx.
+func SimulateTxRequest(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m SimulateBody
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		err = cdc.UnmarshalJSON(body, &m)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := SimulateTx(cliCtx, cdc, m.TxBytes)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// SimulateTx simulates the encoded tx through the /app/simulate_tx query.
+func SimulateTx(cliCtx context.CLIContext, cdc *codec.Codec, txBytes []byte) (sdk.SimulationResponse, error) {
+	var res sdk.SimulationResponse
+	bz, err := cliCtx.Query("/app/simulate_tx", txBytes)
+	if err != nil {
+		return res, err
+	}
+	err = cdc.UnmarshalBinaryLengthPrefixed(bz, &res)
+	return res, err
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,6 +21,10 @@ import (
 const (
 	queryArgDryRun       = "simulate"
 	queryArgGenerateOnly = "generate_only"
+	queryArgOffset       = "offset"
+	queryArgLimit        = "limit"
+
+	headerTotalCount = "X-Total-Count"
 )
 
 //----------------------------------------
@@ -47,6 +53,53 @@ func HasGenerateOnlyArg(r *http.Request) bool {
 	return urlQueryHasArg(r.URL, queryArgGenerateOnly)
 }
 
+// ParsePaginationOrReturnBadRequest parses the offset and limit query
+// parameters of a list request. The results aren't paginated if limit is not
+// set.
+func ParsePaginationOrReturnBadRequest(w http.ResponseWriter, r *http.Request) (pagination sdk.Pagination, ok bool) {
+	var err error
+	if offsetStr := r.FormValue(queryArgOffset); offsetStr != "" {
+		if pagination.Offset, err = strconv.Atoi(offsetStr); err != nil || pagination.Offset < 0 {
+			WriteErrorResponse(w, http.StatusBadRequest, "offset parameter is not a valid non negative integer")
+			return pagination, false
+		}
+	}
+	if limitStr := r.FormValue(queryArgLimit); limitStr != "" {
+		if pagination.Limit, err = strconv.Atoi(limitStr); err != nil || pagination.Limit <= 0 || pagination.Limit > sdk.MaxPageLimit {
+			WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("limit parameter is not a valid integer between 1 and %d", sdk.MaxPageLimit))
+			return pagination, false
+		}
+	}
+	return pagination, true
+}
+
+// WritePageResponse writes the results of the page returned by a paginated
+// query, with their total number in the X-Total-Count header.
+func WritePageResponse(w http.ResponseWriter, cdc *codec.Codec, res []byte, indent bool) {
+	var page sdk.Page
+	if err := json.Unmarshal(res, &page); err != nil {
+		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	items := []byte(page.Items)
+	if indent {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, items, "", "  "); err != nil {
+			WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		items = buf.Bytes()
+	}
+	SetTotalCountHeader(w, page.Total)
+	PostProcessResponse(w, cdc, items, indent)
+}
+
+// SetTotalCountHeader sets the X-Total-Count header of a list response to the
+// total number of results, of which the response may be a page.
+func SetTotalCountHeader(w http.ResponseWriter, total int) {
+	w.Header().Set(headerTotalCount, strconv.Itoa(total))
+}
+
 // ParseInt64OrReturnBadRequest converts s to a int64 value.
 func ParseInt64OrReturnBadRequest(w http.ResponseWriter, s string) (n int64, ok bool) {
 	var err error
@@ -0,0 +1,226 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+)
+
+// DefaultTxFactoryConcurrency is the default number of goroutines signing
+// the txs of a batch.
+const DefaultTxFactoryConcurrency = 4
+
+// maxResyncs bounds the resyncs of the sequence while broadcasting a batch.
+const maxResyncs = 3
+
+var (
+	invalidSequenceCode = uint32(sdk.ErrCodeInvalidSequence.ABCICode())
+	// the log of the ante handler when the sequence of a tx is not the one of
+	// the account in the check state
+	expectedSequenceRegexp = regexp.MustCompile(`Invalid sequence\. Got \d+, expected (\d+)`)
+)
+
+// BatchResult is the result of the broadcast of a tx of a batch. Err is set
+// if the tx could not be signed or broadcast, or if it failed CheckTx.
+type BatchResult struct {
+	Sequence int64
+	Res      *ctypes.ResultBroadcastTx
+	Err      error
+}
+
+// TxFactory builds, signs and broadcasts the txs of the from account of a
+// CLIContext with a local sequence counter, so that an account may send many
+// txs per block without querying its sequence for each of them. The counter
+// is synced from the account on the first tx, and resynced when the node
+// rejects a tx for its sequence.
+//
+// Batches are broadcast one at a time, in the order of their sequences. The
+// txs of a batch are signed by up to concurrency goroutines.
+type TxFactory struct {
+	txBldr      authtxb.TxBuilder
+	concurrency int
+
+	// overridden in tests
+	sign      func(msg authtxb.StdSignMsg) ([]byte, error)
+	broadcast func(txBytes []byte) (*ctypes.ResultBroadcastTx, error)
+	account   func() (sdk.Account, error)
+
+	mtx      sync.Mutex
+	synced   bool
+	sequence int64
+}
+
+// NewTxFactory returns a TxFactory for the from account of cliCtx, the txs
+// are broadcast synchronously, i.e. once they pass CheckTx. A concurrency
+// lower than 1 is DefaultTxFactoryConcurrency.
+func NewTxFactory(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, passphrase string, concurrency int) (*TxFactory, error) {
+	name, err := cliCtx.GetFromName()
+	if err != nil {
+		return nil, err
+	}
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return nil, err
+	}
+	if txBldr.Codec == nil {
+		txBldr = txBldr.WithCodec(cliCtx.Codec)
+	}
+	if concurrency < 1 {
+		concurrency = DefaultTxFactoryConcurrency
+	}
+	return &TxFactory{
+		txBldr:      txBldr,
+		concurrency: concurrency,
+		sign: func(msg authtxb.StdSignMsg) ([]byte, error) {
+			return txBldr.Sign(name, passphrase, msg)
+		},
+		broadcast: cliCtx.BroadcastTxSync,
+		account: func() (sdk.Account, error) {
+			return cliCtx.GetAccount(from)
+		},
+	}, nil
+}
+
+// Sequence returns the sequence of the next tx, syncing it from the account
+// if it is not yet.
+func (f *TxFactory) Sequence() (int64, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if err := f.ensureSynced(); err != nil {
+		return 0, err
+	}
+	return f.sequence, nil
+}
+
+// Resync drops the local sequence, the next tx syncs it from the account.
+func (f *TxFactory) Resync() {
+	f.mtx.Lock()
+	f.synced = false
+	f.mtx.Unlock()
+}
+
+// ensureSynced syncs the account number and the sequence from the account
+// if they are not. It must be called with mtx held.
+func (f *TxFactory) ensureSynced() error {
+	if f.synced {
+		return nil
+	}
+	acc, err := f.account()
+	if err != nil {
+		return err
+	}
+	f.txBldr = f.txBldr.WithAccountNumber(acc.GetAccountNumber())
+	f.sequence = acc.GetSequence()
+	f.synced = true
+	return nil
+}
+
+// Broadcast signs and broadcasts a tx of msgs with the next sequence.
+func (f *TxFactory) Broadcast(msgs []sdk.Msg) BatchResult {
+	return f.BroadcastBatch([][]sdk.Msg{msgs})[0]
+}
+
+// BroadcastBatch signs and broadcasts a tx for each element of batch, with
+// consecutive sequences. If the node rejects a tx for its sequence, the
+// sequence is resynced and the tx and the ones after it are signed and
+// broadcast again. If a tx cannot be signed or fails CheckTx, the txs after
+// it are signed again from its sequence. The results are in the order of
+// batch.
+func (f *TxFactory) BroadcastBatch(batch [][]sdk.Msg) []BatchResult {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	results := make([]BatchResult, len(batch))
+	next := 0
+	for resyncs := 0; next < len(batch); {
+		if err := f.ensureSynced(); err != nil {
+			for i := next; i < len(batch); i++ {
+				results[i].Err = err
+			}
+			break
+		}
+
+		signed := f.signAll(batch[next:], results[next:])
+		// the number of txs done, the ones after them are signed again
+		done := len(signed)
+		for i, txBytes := range signed {
+			result := &results[next+i]
+			if result.Err != nil {
+				// the sequence of a tx which is not sent is free again
+				done = i + 1
+				break
+			}
+			result.Res, result.Err = f.broadcast(txBytes)
+			if result.Err != nil {
+				// the tx may have reached the mempool, if not the next tx
+				// is rejected for its sequence
+				f.sequence++
+				continue
+			}
+			if result.Res.Code == invalidSequenceCode && resyncs < maxResyncs {
+				f.resyncFromLog(result.Res.Log)
+				resyncs++
+				done = i
+				break
+			}
+			if result.Res.Code != 0 {
+				// a tx failing CheckTx does not use its sequence, the txs
+				// after it are signed again from it
+				result.Err = errors.New(result.Res.Log)
+				done = i + 1
+				break
+			}
+			f.sequence++
+		}
+		next += done
+	}
+	return results
+}
+
+// signAll signs a tx of each msgs of batch with the sequences from the local
+// sequence on, the signing errors are set to results.
+func (f *TxFactory) signAll(batch [][]sdk.Msg, results []BatchResult) [][]byte {
+	signed := make([][]byte, len(batch))
+	sem := make(chan struct{}, f.concurrency)
+	var wg sync.WaitGroup
+	for i, msgs := range batch {
+		results[i] = BatchResult{Sequence: f.sequence + int64(i)}
+		msg, err := f.txBldr.WithSequence(results[i].Sequence).Build(msgs)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, msg authtxb.StdSignMsg) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			signed[i], results[i].Err = f.sign(msg)
+		}(i, msg)
+	}
+	wg.Wait()
+	return signed
+}
+
+// resyncFromLog sets the local sequence to the one expected by the node in
+// the log of a tx rejected for its sequence, which includes the txs in its
+// mempool. If the log has none, e.g. on a wrong account number, the sequence
+// is synced from the account.
+func (f *TxFactory) resyncFromLog(log string) {
+	if m := expectedSequenceRegexp.FindStringSubmatch(log); m != nil {
+		if sequence, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+			f.sequence = sequence
+			return
+		}
+	}
+	f.synced = false
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+)
+
+// mockNode checks the sequences of the txs like the ante handler in CheckTx.
+type mockNode struct {
+	mtx       sync.Mutex
+	committed int64 // the sequence of the account in the committed state
+	checked   int64 // the sequence of the account in the check state
+	received  []int64
+}
+
+func (n *mockNode) broadcast(txBytes []byte) (*ctypes.ResultBroadcastTx, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	sequence, err := strconv.ParseInt(string(txBytes), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if sequence != n.checked {
+		res := sdk.ErrInvalidSequence(fmt.Sprintf("Invalid sequence. Got %d, expected %d", sequence, n.checked)).Result()
+		return &ctypes.ResultBroadcastTx{Code: uint32(res.Code), Log: res.Log}, nil
+	}
+	n.checked++
+	n.received = append(n.received, sequence)
+	return &ctypes.ResultBroadcastTx{}, nil
+}
+
+func (n *mockNode) account() (sdk.Account, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	acc := &auth.BaseAccount{AccountNumber: 1}
+	err := acc.SetSequence(n.committed)
+	return acc, err
+}
+
+func TestTxFactoryBroadcastBatch(t *testing.T) {
+	node := &mockNode{committed: 3, checked: 5}
+	var mtx sync.Mutex
+	signedWith := make(map[int64]int64)
+	f := &TxFactory{
+		txBldr:      authtxb.TxBuilder{Codec: app.MakeCodec(), ChainID: "test-chain"},
+		concurrency: 4,
+		sign: func(msg authtxb.StdSignMsg) ([]byte, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			signedWith[msg.Sequence] = msg.AccountNumber
+			return []byte(strconv.FormatInt(msg.Sequence, 10)), nil
+		},
+		broadcast: node.broadcast,
+		account:   node.account,
+	}
+	batch := func(n int) [][]sdk.Msg {
+		return make([][]sdk.Msg, n)
+	}
+
+	// the committed sequence lags behind the txs in the mempool, the factory
+	// resyncs from the sequence expected by the node
+	results := f.BroadcastBatch(batch(20))
+	require.Len(t, results, 20)
+	for i, result := range results {
+		require.NoError(t, result.Err)
+		require.Equal(t, int64(5+i), result.Sequence)
+	}
+	sequence, err := f.Sequence()
+	require.NoError(t, err)
+	require.Equal(t, int64(25), sequence)
+	require.Equal(t, int64(1), signedWith[24])
+
+	// the next batches do not query the account
+	node.committed = 0
+	require.NoError(t, f.Broadcast(nil).Err)
+	// another client sent a tx of the account
+	node.checked++
+	results = f.BroadcastBatch(batch(3))
+	for i, result := range results {
+		require.NoError(t, result.Err)
+		require.Equal(t, int64(27+i), result.Sequence)
+	}
+	var expected []int64
+	for sequence := int64(5); sequence < 30; sequence++ {
+		if sequence != 26 {
+			expected = append(expected, sequence)
+		}
+	}
+	require.Equal(t, expected, node.received)
+
+	// concurrent batches do not race for the sequences
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, result := range f.BroadcastBatch(batch(10)) {
+				require.NoError(t, result.Err)
+			}
+		}()
+	}
+	wg.Wait()
+	sequence, err = f.Sequence()
+	require.NoError(t, err)
+	require.Equal(t, int64(70), sequence)
+	require.Equal(t, node.checked, sequence)
+
+	// a tx failing CheckTx does not use its sequence, the txs after it are
+	// signed again from it without resyncing, however many txs fail
+	calls := 0
+	f.broadcast = func(txBytes []byte) (*ctypes.ResultBroadcastTx, error) {
+		calls++
+		if calls%2 == 1 && calls < 2*maxResyncs+2 {
+			res := sdk.ErrInsufficientFunds("no funds").Result()
+			return &ctypes.ResultBroadcastTx{Code: uint32(res.Code), Log: res.Log}, nil
+		}
+		return node.broadcast(txBytes)
+	}
+	results = f.BroadcastBatch(batch(2*maxResyncs + 3))
+	var sequences []int64
+	for i, result := range results {
+		if i%2 == 0 && i < 2*maxResyncs+2 {
+			require.Error(t, result.Err)
+			require.Contains(t, result.Err.Error(), "no funds")
+		} else {
+			require.NoError(t, result.Err)
+		}
+		sequences = append(sequences, result.Sequence)
+	}
+	require.Equal(t, []int64{70, 70, 71, 71, 72, 72, 73, 73, 74}, sequences)
+	require.Equal(t, 2*maxResyncs+3, calls)
+	require.Equal(t, int64(75), node.checked)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/spf13/viper"
@@ -155,27 +156,62 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 		fmt.Fprintf(os.Stderr, "WARNING: The generated transaction's intended signer does not match the given signer: '%v'\n", name)
 	}
 
-	if !offline && txBldr.AccountNumber == 0 {
-		accNum, err := cliCtx.GetAccountNumber(addr)
+	if !offline {
+		txBldr, err = PopulateAccountFromAddr(txBldr, cliCtx, sdk.AccAddress(addr))
 		if err != nil {
 			return signedStdTx, err
 		}
-		txBldr = txBldr.WithAccountNumber(accNum)
 	}
 
-	if !offline && txBldr.Sequence == 0 {
-		accSeq, err := cliCtx.GetAccountSequence(addr)
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return signedStdTx, err
+	}
+	return txBldr.SignStdTx(name, passphrase, stdTx, appendSig)
+}
+
+// SignStdTxWithSignerAddress signs stdTx with the key name on behalf of the
+// signer addr, e.g. a multisig account the key takes part in, and returns the
+// signature alone. The account number and sequence are the ones of addr.
+// Don't perform online validation or lookups if offline is true.
+func SignStdTxWithSignerAddress(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, addr sdk.AccAddress, name string, stdTx auth.StdTx, offline bool) (sig auth.StdSignature, err error) {
+	if !isTxSigner(addr, stdTx.GetSigners()) {
+		return sig, fmt.Errorf("%s is not a signer of the transaction", addr)
+	}
+
+	if !offline {
+		txBldr, err = PopulateAccountFromAddr(txBldr, cliCtx, addr)
 		if err != nil {
-			return signedStdTx, err
+			return sig, err
 		}
-		txBldr = txBldr.WithSequence(accSeq)
 	}
 
 	passphrase, err := keys.GetPassphrase(name)
 	if err != nil {
-		return signedStdTx, err
+		return sig, err
 	}
-	return txBldr.SignStdTx(name, passphrase, stdTx, appendSig)
+	return authtxb.MakeSignature(name, passphrase, txBldr.StdSignMsgForTx(stdTx))
+}
+
+// PopulateAccountFromAddr sets the account number and sequence of addr on
+// txBldr, unless they are set already.
+func PopulateAccountFromAddr(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, addr sdk.AccAddress) (authtxb.TxBuilder, error) {
+	if txBldr.AccountNumber == 0 {
+		accNum, err := cliCtx.GetAccountNumber(addr)
+		if err != nil {
+			return txBldr, err
+		}
+		txBldr = txBldr.WithAccountNumber(accNum)
+	}
+
+	if txBldr.Sequence == 0 {
+		accSeq, err := cliCtx.GetAccountSequence(addr)
+		if err != nil {
+			return txBldr, err
+		}
+		txBldr = txBldr.WithSequence(accSeq)
+	}
+	return txBldr, nil
 }
 
 func parseQueryResponse(cdc *codec.Codec, rawRes []byte) (sdk.Result, error) {
@@ -229,11 +265,40 @@ func buildUnsignedStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msg
 }
 
 func buildUnsignedStdTxOffline(txBldr authtxb.TxBuilder, msgs []sdk.Msg) (stdTx auth.StdTx, err error) {
-	stdSignMsg, err := txBldr.Build(msgs)
+	return txBldr.BuildUnsignedStdTx(msgs)
+}
+
+// ReadStdTxFromFile reads the JSON encoded StdTx in filename, or on stdin if
+// filename is a dash (-).
+func ReadStdTxFromFile(cdc *codec.Codec, filename string) (stdTx auth.StdTx, err error) {
+	var bytes []byte
+	if filename == "-" {
+		bytes, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		bytes, err = ioutil.ReadFile(filename)
+	}
 	if err != nil {
 		return
 	}
-	return auth.NewStdTx(stdSignMsg.Msgs, nil, stdSignMsg.Memo, stdSignMsg.Source, nil), nil
+	err = cdc.UnmarshalJSON(bytes, &stdTx)
+	return
+}
+
+// CheckFullySigned returns an error unless stdTx carries a signature for each
+// of its signers, so an incomplete transaction is not broadcast. Signatures
+// without a public key are matched to their signer on chain.
+func CheckFullySigned(stdTx auth.StdTx) error {
+	signers := stdTx.GetSigners()
+	sigs := stdTx.GetSignatures()
+	if len(sigs) != len(signers) {
+		return fmt.Errorf("transaction has %d signatures but requires %d", len(sigs), len(signers))
+	}
+	for i, sig := range sigs {
+		if sig.PubKey != nil && !bytes.Equal(sig.Address(), signers[i]) {
+			return fmt.Errorf("signature %d is not made by signer %s", i, signers[i])
+		}
+	}
+	return nil
 }
 
 func isTxSigner(user sdk.AccAddress, signers []sdk.AccAddress) bool {
@@ -1,11 +1,18 @@
 package utils
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/stretchr/testify/assert"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 func TestParseQueryResponse(t *testing.T) {
@@ -16,3 +23,41 @@ func TestParseQueryResponse(t *testing.T) {
 	_, err = parseQueryResponse(cdc, []byte("fuzzy"))
 	assert.NotNil(t, err)
 }
+
+func TestReadStdTxFromFile(t *testing.T) {
+	cdc := app.MakeCodec()
+	stdTx := auth.NewStdTx(nil, nil, "memo", 0, nil)
+
+	dir, err := ioutil.TempDir("", "stdtx")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "tx.json")
+	require.NoError(t, ioutil.WriteFile(filename, cdc.MustMarshalJSON(stdTx), 0644))
+
+	read, err := ReadStdTxFromFile(cdc, filename)
+	require.NoError(t, err)
+	require.Equal(t, "memo", read.GetMemo())
+
+	_, err = ReadStdTxFromFile(cdc, filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
+
+func TestCheckFullySigned(t *testing.T) {
+	priv := ed25519.GenPrivKey()
+	signer := sdk.AccAddress(priv.PubKey().Address())
+	other := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	msgs := []sdk.Msg{sdk.NewTestMsg(signer)}
+
+	unsigned := auth.NewStdTx(msgs, nil, "", 0, nil)
+	require.Error(t, CheckFullySigned(unsigned))
+
+	signed := auth.NewStdTx(msgs, []auth.StdSignature{{PubKey: priv.PubKey()}}, "", 0, nil)
+	require.NoError(t, CheckFullySigned(signed))
+
+	// the public key may be left out of the signature
+	signed = auth.NewStdTx(msgs, []auth.StdSignature{{}}, "", 0, nil)
+	require.NoError(t, CheckFullySigned(signed))
+
+	wrongSigner := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(other)}, []auth.StdSignature{{PubKey: priv.PubKey()}}, "", 0, nil)
+	require.Error(t, CheckFullySigned(wrongSigner))
+}
@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,13 +10,13 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
-	tmtypes "github.com/tendermint/tendermint/types"
 
 	bam "github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
@@ -26,6 +25,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
@@ -63,6 +67,12 @@ type GaiaApp struct {
 	tkeyParams       *sdk.TransientStoreKey
 	keyIbc           *sdk.KVStoreKey
 	keySide          *sdk.KVStoreKey
+	keySupply        *sdk.KVStoreKey
+	tkeyCrisis       *sdk.TransientStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keyTimeLock      *sdk.KVStoreKey
+	keySwap          *sdk.KVStoreKey
+	keyTokens        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper       auth.AccountKeeper
@@ -75,6 +85,12 @@ type GaiaApp struct {
 	govKeeper           gov.Keeper
 	paramsKeeper        params.Keeper
 	ibcKeeper           ibc.Keeper
+	supplyKeeper        supply.Keeper
+	crisisKeeper        crisis.Keeper
+	upgradeKeeper       upgrade.Keeper
+	timeLockKeeper      timelock.Keeper
+	swapKeeper          swap.Keeper
+	tokensKeeper        tokens.Keeper
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -102,6 +118,12 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
 		keyIbc:           sdk.NewKVStoreKey("ibc"),
 		keySide:          sdk.NewKVStoreKey("sc"),
+		keySupply:        sdk.NewKVStoreKey("supply"),
+		tkeyCrisis:       sdk.NewTransientStoreKey("transient_crisis"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
+		keyTimeLock:      sdk.NewKVStoreKey("timelock"),
+		keySwap:          sdk.NewKVStoreKey("atomic_swap"),
+		keyTokens:        sdk.NewKVStoreKey("tokens"),
 	}
 
 	// define the accountKeeper
@@ -116,6 +138,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(
 		app.cdc,
 		app.keyFeeCollection,
+		app.accountKeeper,
 	)
 	app.paramsKeeper = params.NewKeeper(
 		app.cdc,
@@ -155,30 +178,76 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(gov.DefaultCodespace),
 		app.Pool,
 	)
+	// the params change proposals can update the params of any subspace
+	app.govKeeper.AddHooks(gov.ProposalTypeParamsChange, gov.NewParamsChangeHooks(app.paramsKeeper))
+	app.govKeeper.AddProposalHandler(gov.ProposalTypeParamsChange, gov.NewParamsChangeProposalHandler(app.paramsKeeper))
+	// the account flags proposals put holds on the accounts or lift them
+	app.govKeeper.AddHooks(gov.ProposalTypeAccountFlags, gov.NewAccountFlagsHooks(app.accountKeeper))
+	app.govKeeper.AddProposalHandler(gov.ProposalTypeAccountFlags, gov.NewAccountFlagsProposalHandler(app.accountKeeper))
+	// the software upgrade proposals schedule the halt of the chain
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade)
+	app.govKeeper.AddHooks(gov.ProposalTypeSoftwareUpgrade, upgrade.NewSoftwareUpgradeHooks(app.upgradeKeeper))
+	app.govKeeper.AddProposalHandler(gov.ProposalTypeSoftwareUpgrade, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
+
+	app.supplyKeeper = supply.NewKeeper(app.cdc, app.keySupply, app.accountKeeper)
+	// the burned deposits of the proposals leave the total supply
+	app.govKeeper.SetupForSupply(app.supplyKeeper)
+	// the distributed fees are burned and the paid rewards minted
+	app.distrKeeper.SetupForSupply(app.supplyKeeper)
+	app.timeLockKeeper = timelock.NewKeeper(app.cdc, app.keyTimeLock, app.bankKeeper,
+		timelock.DefaultMaxUnlocksPerBlock, app.RegisterCodespace(timelock.DefaultCodespace))
+	app.swapKeeper = swap.NewKeeper(app.cdc, app.keySwap, app.bankKeeper,
+		swap.DefaultMaxRefundsPerBlock, app.RegisterCodespace(swap.DefaultCodespace))
+	app.tokensKeeper = tokens.NewKeeper(app.cdc, app.keyTokens, app.bankKeeper, app.supplyKeeper,
+		app.RegisterCodespace(tokens.DefaultCodespace))
+	// any signer can request the check of the invariants, a broken invariant
+	// is reported in the block events rather than halting the chain
+	app.crisisKeeper = crisis.NewKeeper(app.cdc, app.tkeyCrisis, 0, false,
+		app.RegisterCodespace(crisis.DefaultCodespace))
 
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
 		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
 
+	// register the invariants, they are checked on request
+	bank.RegisterInvariants(&app.crisisKeeper, app.accountKeeper)
+	stake.RegisterInvariants(&app.crisisKeeper, app.stakeKeeper)
+	supply.RegisterInvariants(&app.crisisKeeper, app.supplyKeeper)
+	timelock.RegisterInvariants(&app.crisisKeeper, app.timeLockKeeper)
+	swap.RegisterInvariants(&app.crisisKeeper, app.swapKeeper)
+	tokens.RegisterInvariants(&app.crisisKeeper, app.tokensKeeper)
+
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.bankKeeper)).
 		AddRoute("stake", stake.NewStakeHandler(app.stakeKeeper)).
 		AddRoute("distr", distr.NewHandler(app.distrKeeper)).
 		AddRoute("slashing", slashing.NewSlashingHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("crisis", crisis.NewHandler(app.crisisKeeper)).
+		AddRoute("timelock", timelock.NewHandler(app.timeLockKeeper)).
+		AddRoute("atomicSwap", swap.NewHandler(app.swapKeeper)).
+		AddRoute("tokens", tokens.NewHandler(app.tokensKeeper))
 
 	app.QueryRouter().
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
-		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
+		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc)).
+		AddRoute("supply", supply.NewQuerier(app.supplyKeeper)).
+		AddRoute("upgrade", upgrade.NewQuerier(app.upgradeKeeper)).
+		AddRoute("timelock", timelock.NewQuerier(app.timeLockKeeper)).
+		AddRoute("atomicSwap", swap.NewQuerier(app.swapKeeper)).
+		AddRoute("tokens", tokens.NewQuerier(app.tokensKeeper)).
+		AddRoute("distr", distr.NewQuerier(app.distrKeeper))
 
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc)
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc, app.keySide, app.keySupply, app.keyUpgrade,
+		app.keyTimeLock, app.keySwap, app.keyTokens)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
-	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
+	app.SetAnteHandler(auth.NewAnteHandlerWithParams(app.accountKeeper, app.paramsKeeper.Subspace(auth.DefaultParamspace)))
+	app.SetPreChecker(auth.NewPreChecker())
+	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr, app.tkeyCrisis)
 	app.SetEndBlocker(app.EndBlocker)
 
 	err := app.LoadCMSLatestVersion()
@@ -205,6 +274,10 @@ func MakeCodec() *codec.Codec {
 	distr.RegisterCodec(cdc)
 	slashing.RegisterCodec(cdc)
 	gov.RegisterCodec(cdc)
+	crisis.RegisterCodec(cdc)
+	timelock.RegisterCodec(cdc)
+	swap.RegisterCodec(cdc)
+	tokens.RegisterCodec(cdc)
 	auth.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
@@ -213,6 +286,16 @@ func MakeCodec() *codec.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// halt at the scheduled upgrade, or apply it
+	upgrade.BeginBlocker(ctx, app.upgradeKeeper)
+
+	// the coins of the modules are held by module accounts once the supply is
+	// tracked, the total supply is seeded after them
+	if sdk.IsUpgradeHeight(sdk.SupplyTracking) {
+		app.migrateModuleAccounts(ctx)
+	}
+	supply.BeginBlocker(ctx, app.supplyKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	// distribute rewards from previous block
@@ -221,25 +304,38 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 	// mint new tokens for this new block
 	mint.BeginBlocker(ctx, app.mintKeeper)
 
+	// refund the atomic swaps expiring at this block
+	swap.BeginBlocker(ctx, app.swapKeeper)
+
 	return abci.ResponseBeginBlock{
 		Events: tags.ToEvents(),
 	}
 }
 
+// turn the accounts holding the collected fees, the delegated coins and the
+// deposits of the proposals into module accounts, the fee collector and the
+// deposits account burn the coins they hold from the total supply
+func (app *GaiaApp) migrateModuleAccounts(ctx sdk.Context) {
+	app.feeCollectionKeeper.MigrateCollectedFees(ctx)
+	app.accountKeeper.GetModuleAccount(ctx, stake.DelegationAccName)
+	app.accountKeeper.GetModuleAccount(ctx, gov.DepositedCoinsAccName, auth.Burner)
+}
+
 // application updates every end block
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	gov.EndBlocker(ctx, app.govKeeper)
+	distr.EndBlocker(ctx, app.distrKeeper)
 	validatorUpdates, _ := stake.EndBlocker(ctx, app.stakeKeeper)
 	ibc.EndBlocker(ctx, app.ibcKeeper)
 
 	// Add these new validators to the addr -> pubkey map.
 	app.slashingKeeper.AddValidators(ctx, validatorUpdates)
+	timelock.EndBlocker(ctx, app.timeLockKeeper)
+	crisis.EndBlocker(ctx, app.crisisKeeper)
 
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
-		Events:           ctx.EventManager().ABCIEvents(),
 	}
 }
 
@@ -256,11 +352,14 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	}
 
 	// load the accounts
+	var totalSupply sdk.Coins
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountKeeper.GetNextAccountNumber(ctx)
+		acc.SetAccountNumber(app.accountKeeper.GetNextAccountNumber(ctx))
 		app.accountKeeper.SetAccount(ctx, acc)
+		totalSupply = totalSupply.Plus(acc.GetCoins())
 	}
+	supply.InitGenesis(ctx, app.supplyKeeper, totalSupply)
 
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
@@ -273,6 +372,11 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
 	mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
 	distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
+	timelock.InitGenesis(ctx, app.timeLockKeeper, genesisState.TimeLockData)
+	swap.InitGenesis(ctx, app.swapKeeper, genesisState.SwapData)
+	tokens.InitGenesis(ctx, app.tokensKeeper, genesisState.TokensData)
+	// the stores are initialized at the versions of the binary
+	app.upgradeKeeper.InitModuleVersions(ctx)
 	err = GaiaValidateGenesisState(genesisState)
 	if err != nil {
 		panic(err) // TODO find a way to do this w/o panics
@@ -315,34 +419,6 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	}
 }
 
-// export the state of gaia for a genesis file
-func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
-	ctx := app.NewContext(sdk.RunTxModeCheck, abci.Header{})
-
-	// iterate to get the accounts
-	accounts := []GenesisAccount{}
-	appendAccount := func(acc sdk.Account) (stop bool) {
-		account := NewGenesisAccountI(acc)
-		accounts = append(accounts, account)
-		return false
-	}
-	app.accountKeeper.IterateAccounts(ctx, appendAccount)
-	genState := NewGenesisState(
-		accounts,
-		stake.WriteGenesis(ctx, app.stakeKeeper),
-		mint.WriteGenesis(ctx, app.mintKeeper),
-		distr.WriteGenesis(ctx, app.distrKeeper),
-		gov.WriteGenesis(ctx, app.govKeeper),
-		slashing.GenesisState{}, // TODO create write methods
-	)
-	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
-	if err != nil {
-		return nil, nil, err
-	}
-	validators = stake.WriteValidators(ctx, app.stakeKeeper)
-	return appState, validators, nil
-}
-
 //______________________________________________________________________________________________
 
 // Combined Staking Hooks
@@ -381,6 +457,9 @@ func (h Hooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valA
 func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 	h.dh.OnDelegationSharesModified(ctx, delAddr, valAddr)
 }
+func (h Hooks) OnDelegationSharesIncreased(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, shares sdk.Dec) {
+	h.dh.OnDelegationSharesIncreased(ctx, delAddr, valAddr, shares)
+}
 func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 	h.dh.OnDelegationRemoved(ctx, delAddr, valAddr)
 }
@@ -11,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
@@ -19,7 +20,13 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/db"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -40,22 +47,29 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 	bApp.SetCommitMultiStoreTracer(traceStore)
 
 	gApp := &GaiaApp{
-		BaseApp:        bApp,
-		cdc:            cdc,
-		keyMain:        sdk.NewKVStoreKey("main"),
-		keyAccount:     sdk.NewKVStoreKey("acc"),
-		keyStake:       sdk.NewKVStoreKey("stake"),
-		keyStakeReward: sdk.NewKVStoreKey("stake_reward"),
-		tkeyStake:      sdk.NewTransientStoreKey("transient_stake"),
-		keyMint:        sdk.NewKVStoreKey("mint"),
-		keyDistr:       sdk.NewKVStoreKey("distr"),
-		tkeyDistr:      sdk.NewTransientStoreKey("transient_distr"),
-		keySlashing:    sdk.NewKVStoreKey("slashing"),
-		keyGov:         sdk.NewKVStoreKey("gov"),
-		keyParams:      sdk.NewKVStoreKey("params"),
-		tkeyParams:     sdk.NewTransientStoreKey("transient_params"),
-		keyIbc:         sdk.NewKVStoreKey("ibc"),
-		keySide:        sdk.NewKVStoreKey("side"),
+		BaseApp:          bApp,
+		cdc:              cdc,
+		keyMain:          sdk.NewKVStoreKey("main"),
+		keyAccount:       sdk.NewKVStoreKey("acc"),
+		keyStake:         sdk.NewKVStoreKey("stake"),
+		keyStakeReward:   sdk.NewKVStoreKey("stake_reward"),
+		tkeyStake:        sdk.NewTransientStoreKey("transient_stake"),
+		keyMint:          sdk.NewKVStoreKey("mint"),
+		keyDistr:         sdk.NewKVStoreKey("distr"),
+		tkeyDistr:        sdk.NewTransientStoreKey("transient_distr"),
+		keySlashing:      sdk.NewKVStoreKey("slashing"),
+		keyGov:           sdk.NewKVStoreKey("gov"),
+		keyParams:        sdk.NewKVStoreKey("params"),
+		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
+		keyIbc:           sdk.NewKVStoreKey("ibc"),
+		keySide:          sdk.NewKVStoreKey("side"),
+		keySupply:        sdk.NewKVStoreKey("supply"),
+		tkeyCrisis:       sdk.NewTransientStoreKey("transient_crisis"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
+		keyFeeCollection: sdk.NewKVStoreKey("fee"),
+		keyTimeLock:      sdk.NewKVStoreKey("timelock"),
+		keySwap:          sdk.NewKVStoreKey("atomic_swap"),
+		keyTokens:        sdk.NewKVStoreKey("tokens"),
 	}
 
 	var app = &MockGaiaApp{gApp}
@@ -69,6 +83,11 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 
 	// add handlers
 	app.bankKeeper = bank.NewBaseKeeper(app.accountKeeper)
+	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(
+		app.cdc,
+		app.keyFeeCollection,
+		app.accountKeeper,
+	)
 	app.paramsKeeper = params.NewKeeper(
 		app.cdc,
 		app.keyParams, app.tkeyParams,
@@ -91,7 +110,7 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 		app.cdc,
 		app.keyDistr,
 		app.paramsKeeper.Subspace(distr.DefaultParamspace),
-		app.bankKeeper, app.stakeKeeper, nil,
+		app.bankKeeper, app.stakeKeeper, app.feeCollectionKeeper,
 		app.RegisterCodespace(stake.DefaultCodespace),
 	)
 	app.slashingKeeper = slashing.NewKeeper(
@@ -109,6 +128,17 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 		app.Pool,
 	)
 
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade)
+	app.supplyKeeper = supply.NewKeeper(app.cdc, app.keySupply, app.accountKeeper)
+	app.timeLockKeeper = timelock.NewKeeper(app.cdc, app.keyTimeLock, app.bankKeeper,
+		timelock.DefaultMaxUnlocksPerBlock, app.RegisterCodespace(timelock.DefaultCodespace))
+	app.swapKeeper = swap.NewKeeper(app.cdc, app.keySwap, app.bankKeeper,
+		swap.DefaultMaxRefundsPerBlock, app.RegisterCodespace(swap.DefaultCodespace))
+	app.tokensKeeper = tokens.NewKeeper(app.cdc, app.keyTokens, app.bankKeeper, app.supplyKeeper,
+		app.RegisterCodespace(tokens.DefaultCodespace))
+	app.crisisKeeper = crisis.NewKeeper(app.cdc, app.tkeyCrisis, 0, false,
+		app.RegisterCodespace(crisis.DefaultCodespace))
+
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
 		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
@@ -126,15 +156,24 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
 
 	// initialize BaseApp
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyParams)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc, app.keySupply, app.keyUpgrade,
+		app.keyTimeLock, app.keySwap, app.keyTokens)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
-	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
+	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr, app.tkeyCrisis)
 	app.SetEndBlocker(app.EndBlocker)
 
-	err := app.LoadLatestVersion(app.keyMain)
+	err := app.GetCommitMultiStore().LoadLatestVersion()
+	if err != nil {
+		cmn.Exit(err.Error())
+	}
+
+	accountStore := app.GetCommitMultiStore().GetKVStore(app.keyAccount)
+	app.SetAccountStoreCache(cdc, accountStore, accountCacheCap)
+
+	err = app.initFromStore(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
 	}
@@ -172,7 +211,9 @@ func setMockGenesis(gapp *MockGaiaApp, accs ...*auth.BaseAccount) error {
 	genesisState := GenesisState{
 		Accounts:     genaccs,
 		StakeData:    stake.DefaultGenesisState(),
+		MintData:     mint.DefaultGenesisState(),
 		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
 		SlashingData: slashing.DefaultGenesisState(),
 	}
 
@@ -196,6 +237,44 @@ func TestGaiadExport(t *testing.T) {
 
 	// Making a new app object with the db, so that initchain hasn't been called
 	newGapp := NewMockGaiaApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil)
-	_, _, err := newGapp.ExportAppStateAndValidators()
+	_, _, err := newGapp.ExportAppStateAndValidators(false)
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestGaiaAppWiring(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	report := gapp.AuditWiring()
+	require.True(t, report.OK(), report.String())
+}
+
+func TestSupplyTrackingUpgrade(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	gapp := NewMockGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	acc := auth.NewBaseAccountWithAddress(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+	acc.SetCoins(sdk.Coins{sdk.NewCoin("steak", 100)})
+	deposits := auth.NewBaseAccountWithAddress(gov.DepositedCoinsAccAddr)
+	deposits.SetCoins(sdk.Coins{sdk.NewCoin("steak", 10)})
+	require.NoError(t, setMockGenesis(gapp, &acc, &deposits))
+
+	gapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	ctx := gapp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 1})
+
+	// the holders of the coins of the modules are module accounts
+	depositsAcc, ok := gapp.accountKeeper.GetAccount(ctx, gov.DepositedCoinsAccAddr).(*auth.ModuleAccount)
+	require.True(t, ok)
+	require.True(t, depositsAcc.HasPermission(auth.Burner))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 10)}, depositsAcc.GetCoins())
+	_, ok = gapp.accountKeeper.GetAccount(ctx, stake.DelegationAccAddr).(*auth.ModuleAccount)
+	require.True(t, ok)
+	_, ok = gapp.accountKeeper.GetAccount(ctx, auth.FeeCollectorAddr).(*auth.ModuleAccount)
+	require.True(t, ok)
+
+	// and the total supply is seeded from the accounts
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 110)}, gapp.supplyKeeper.GetTotalSupply(ctx))
+	require.NoError(t, supply.TotalSupplyInvariant(gapp.supplyKeeper)(ctx))
+	require.NoError(t, gapp.supplyKeeper.BurnCoins(ctx, gov.DepositedCoinsAccName, sdk.Coins{sdk.NewCoin("steak", 10)}))
+	require.Error(t, gapp.supplyKeeper.BurnCoins(ctx, stake.DelegationAccName, sdk.Coins{sdk.NewCoin("steak", 1)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("steak", 100)}, gapp.supplyKeeper.GetTotalSupply(ctx))
+}
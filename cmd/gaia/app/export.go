@@ -0,0 +1,196 @@
+package app
+
+import (
+	"encoding/json"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+// load the state committed at a height to export it, the account cache reads
+// the account store of the loaded version
+func (app *GaiaApp) LoadHeight(height int64) error {
+	err := app.GetCommitMultiStore().LoadVersion(height)
+	if err != nil {
+		return err
+	}
+	accountStore := app.GetCommitMultiStore().GetKVStore(app.keyAccount)
+	app.SetAccountStoreCache(app.cdc, accountStore, accountCacheCap)
+	return app.InitFromStore(app.keyMain)
+}
+
+// export the state of gaia for a genesis file, the state is prepared to
+// restart the chain from height zero if forZeroHeight
+func (app *GaiaApp) ExportAppStateAndValidators(forZeroHeight bool) (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
+	// the state is read at the last committed height, under its upgrades
+	height := app.LastBlockHeight()
+	sdk.UpgradeMgr.SetHeight(height)
+	ctx := app.NewContext(sdk.RunTxModeCheck, abci.Header{Height: height})
+
+	if forZeroHeight {
+		app.prepForZeroHeightGenesis(ctx)
+	}
+
+	// iterate to get the accounts
+	accounts := []GenesisAccount{}
+	appendAccount := func(acc sdk.Account) (stop bool) {
+		account := NewGenesisAccountI(acc)
+		accounts = append(accounts, account)
+		return false
+	}
+	app.accountKeeper.IterateAccounts(ctx, appendAccount)
+	genState := NewGenesisState(
+		accounts,
+		stake.WriteGenesis(ctx, app.stakeKeeper),
+		mint.WriteGenesis(ctx, app.mintKeeper),
+		distr.WriteGenesis(ctx, app.distrKeeper),
+		gov.WriteGenesis(ctx, app.govKeeper),
+		slashing.WriteGenesis(ctx, app.slashingKeeper),
+	)
+	genState.TimeLockData = timelock.WriteGenesis(ctx, app.timeLockKeeper)
+	genState.SwapData = swap.WriteGenesis(ctx, app.swapKeeper)
+	genState.TokensData = tokens.WriteGenesis(ctx, app.tokensKeeper)
+
+	if forZeroHeight {
+		resetGenesisHeights(&genState, height)
+	}
+
+	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
+	if err != nil {
+		return nil, nil, err
+	}
+	validators = stake.WriteValidators(ctx, app.stakeKeeper)
+	return appState, validators, nil
+}
+
+// prepare the state for a restart from height zero, the fees collected for
+// the next block are distributed to the community pool
+func (app *GaiaApp) prepForZeroHeightGenesis(ctx sdk.Context) {
+	collectedFees := app.feeCollectionKeeper.GetCollectedFees(ctx)
+	if !collectedFees.IsZero() {
+		feePool := app.distrKeeper.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Plus(distr.NewDecCoins(collectedFees))
+		app.distrKeeper.SetFeePool(ctx, feePool)
+		app.feeCollectionKeeper.ClearCollectedFees(ctx)
+	}
+}
+
+// Reset the heights of an exported state for a restart from height zero. The
+// heights the rewards accumulate and the deadlines count from are shifted by
+// the exported height, so the last block becomes height zero. The heights
+// which order the validators or key the slashing periods are reset instead.
+func resetGenesisHeights(genState *GenesisState, height int64) {
+	shift := func(h int64) int64 {
+		return h - height
+	}
+
+	/* Handle stake state. */
+
+	// the validators are ordered by power rank, the older bonded first, and
+	// keep this order with a zero bond height and their counters
+	validators := genState.StakeData.Validators
+	sort.SliceStable(validators, func(i, j int) bool {
+		vi, vj := validators[i], validators[j]
+		if !vi.Tokens.Equal(vj.Tokens) {
+			return vi.Tokens.GT(vj.Tokens)
+		}
+		if vi.BondHeight != vj.BondHeight {
+			return vi.BondHeight < vj.BondHeight
+		}
+		return vi.BondIntraTxCounter < vj.BondIntraTxCounter
+	})
+	for i := range validators {
+		validators[i].BondHeight = 0
+		validators[i].BondIntraTxCounter = int16(i)
+		if validators[i].UnbondingHeight != 0 {
+			validators[i].UnbondingHeight = shift(validators[i].UnbondingHeight)
+		}
+	}
+	for i := range genState.StakeData.UnbondingDelegations {
+		ubd := &genState.StakeData.UnbondingDelegations[i]
+		ubd.CreationHeight = shift(ubd.CreationHeight)
+	}
+	for i := range genState.StakeData.Redelegations {
+		red := &genState.StakeData.Redelegations[i]
+		red.CreationHeight = shift(red.CreationHeight)
+	}
+
+	/* Handle distribution state. */
+
+	distrData := &genState.DistrData
+	distrData.FeePool.TotalValAccum.UpdateHeight = shift(distrData.FeePool.TotalValAccum.UpdateHeight)
+	for i := range distrData.ValidatorDistInfos {
+		vdi := &distrData.ValidatorDistInfos[i]
+		vdi.FeePoolWithdrawalHeight = shift(vdi.FeePoolWithdrawalHeight)
+		vdi.DelAccum.UpdateHeight = shift(vdi.DelAccum.UpdateHeight)
+	}
+	for i := range distrData.DelegationDistInfos {
+		ddi := &distrData.DelegationDistInfos[i]
+		ddi.WithdrawalHeight = shift(ddi.WithdrawalHeight)
+	}
+	for i := range distrData.DelegatorStartingInfos {
+		info := &distrData.DelegatorStartingInfos[i].StartingInfo
+		info.Height = shift(info.Height)
+	}
+
+	/* Handle slashing state. */
+
+	slashingData := &genState.SlashingData
+	for i := range slashingData.SigningInfos {
+		info := &slashingData.SigningInfos[i].Info
+		info.StartHeight = shift(info.StartHeight)
+	}
+
+	// the infractions of the ended slashing periods cannot be evidenced in the
+	// new chain, the current ones start before genesis as for the genesis
+	// validators
+	var slashingPeriods []slashing.ValidatorSlashingPeriod
+	for _, period := range slashingData.SlashingPeriods {
+		if period.EndHeight == 0 {
+			period.StartHeight = -stakeTypes.ValidatorUpdateDelay
+			slashingPeriods = append(slashingPeriods, period)
+		}
+	}
+	slashingData.SlashingPeriods = slashingPeriods
+
+	/* Handle swap state. */
+
+	for i := range genState.SwapData.Swaps {
+		atomicSwap := &genState.SwapData.Swaps[i].Swap
+		atomicSwap.ExpireHeight = shift(atomicSwap.ExpireHeight)
+		if atomicSwap.ClosedHeight != 0 {
+			atomicSwap.ClosedHeight = shift(atomicSwap.ClosedHeight)
+		}
+	}
+}
+
+// run the migrations of the module stores the next upgrade would run on the
+// last committed state, without writing it
+func (app *GaiaApp) DryRunMigrations() ([]string, error) {
+	height := app.LastBlockHeight()
+	sdk.UpgradeMgr.SetHeight(height)
+	ctx := app.NewContext(sdk.RunTxModeCheck, abci.Header{Height: height})
+
+	steps, err := app.upgradeKeeper.DryRunMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	descriptions := make([]string, len(steps))
+	for i, step := range steps {
+		descriptions[i] = step.String()
+	}
+	return descriptions, nil
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// a genesis state with a bonded validator and its self delegation
+func exportTestGenesis(t testing.TB) (json.RawMessage, crypto.PubKey) {
+	pubKey := ed25519.GenPrivKey().PubKey()
+	valAddr := sdk.ValAddress(pubKey.Address())
+	tokens := sdk.NewDecWithoutFra(10)
+
+	validator := stake.NewValidator(valAddr, pubKey, stake.Description{Moniker: "validator"})
+	validator.Status = sdk.Bonded
+	validator.Tokens = tokens
+	validator.DelegatorShares = tokens
+	stakeData := stake.DefaultGenesisState()
+	stakeData.Validators = []stake.Validator{validator}
+	stakeData.Bonds = []stake.Delegation{{
+		DelegatorAddr: sdk.AccAddress(valAddr),
+		ValidatorAddr: valAddr,
+		Shares:        tokens,
+	}}
+	stakeData.Pool.LooseTokens = sdk.NewDecWithoutFra(100)
+	stakeData.Pool.BondedTokens = tokens
+
+	acc := &auth.BaseAccount{
+		Address: sdk.AccAddress(valAddr),
+		Coins:   sdk.Coins{sdk.NewCoin("steak", sdk.NewDecWithoutFra(100).RawInt())},
+	}
+	genesisState := GenesisState{
+		Accounts:     []GenesisAccount{NewGenesisAccount(acc)},
+		StakeData:    stakeData,
+		MintData:     mint.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+	}
+	stateBytes, err := codec.MarshalJSONIndent(MakeCodec(), genesisState)
+	require.NoError(t, err)
+	return stateBytes, pubKey
+}
+
+// start a chain from a genesis state and commit the genesis block
+func initTestChain(t *testing.T, appState json.RawMessage) *MockGaiaApp {
+	gapp := NewMockGaiaApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), dbm.NewMemDB(), nil)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	gapp.Commit()
+	return gapp
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	genesis, _ := exportTestGenesis(t)
+	gapp := initTestChain(t, genesis)
+	appState, validators, err := gapp.ExportAppStateAndValidators(false)
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+
+	// a chain started from the exported state exports the same state
+	newGapp := initTestChain(t, appState)
+	newAppState, newValidators, err := newGapp.ExportAppStateAndValidators(false)
+	require.NoError(t, err)
+	require.JSONEq(t, string(appState), string(newAppState))
+	require.Equal(t, validators, newValidators)
+}
+
+func TestExportForZeroHeight(t *testing.T) {
+	genesis, pubKey := exportTestGenesis(t)
+	gapp := initTestChain(t, genesis)
+
+	// the validator proposes a few blocks, the rewards of the last one are
+	// not allocated yet
+	for height := int64(1); height <= 3; height++ {
+		header := abci.Header{Height: height, ProposerAddress: pubKey.Address()}
+		gapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+		gapp.EndBlock(abci.RequestEndBlock{Height: height})
+		gapp.Commit()
+	}
+
+	appState, validators, err := gapp.ExportAppStateAndValidators(true)
+	require.NoError(t, err)
+	require.Len(t, validators, 1)
+
+	var genesisState GenesisState
+	require.NoError(t, gapp.cdc.UnmarshalJSON(appState, &genesisState))
+	for _, validator := range genesisState.StakeData.Validators {
+		require.Equal(t, int64(0), validator.BondHeight)
+	}
+	for _, info := range genesisState.SlashingData.SigningInfos {
+		require.True(t, info.Info.StartHeight <= 0)
+	}
+
+	// the new chain starts with the same validators
+	newGapp := initTestChain(t, appState)
+	_, newValidators, err := newGapp.ExportAppStateAndValidators(true)
+	require.NoError(t, err)
+	require.Equal(t, validators, newValidators)
+	require.Equal(t, int64(1), newGapp.LastBlockHeight())
+}
@@ -0,0 +1,182 @@
+//go:build go1.18
+// +build go1.18
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distrTypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+// The fuzz tests run their seeds with go test, and are fuzzed with e.g.
+//   go test ./cmd/gaia/app -run ^$ -fuzz ^FuzzTxDecode$ -fuzztime 10m
+
+// start a chain from the genesis state of the export tests, the msg router is
+// captured before the app is sealed
+func newFuzzApp(tb testing.TB) (*GaiaApp, bam.Router) {
+	var router bam.Router
+	captureRouter := func(bapp *bam.BaseApp) { router = bapp.Router() }
+	gapp := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil, captureRouter)
+
+	genesis, _ := exportTestGenesis(tb)
+	gapp.InitChain(abci.RequestInitChain{AppStateBytes: genesis})
+	gapp.Commit()
+	return gapp, router
+}
+
+// a msg of each type registered by the codec of gaia, the zero msgs cover
+// the prefixes of the types and the filled ones reach the handlers
+func fuzzSeedMsgs() []sdk.Msg {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	valAddr := sdk.ValAddress(addr)
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+
+	return []sdk.Msg{
+		bank.MsgSend{}, bank.MsgSetAccountFlags{},
+		bank.NewMsgSend([]bank.Input{bank.NewInput(addr, coins)}, []bank.Output{bank.NewOutput(sdk.AccAddress(valAddr), coins)}),
+
+		stakeTypes.MsgCreateValidator{}, stakeTypes.MsgCreateValidatorOpen{}, stakeTypes.MsgRemoveValidator{},
+		stakeTypes.MsgCreateValidatorProposal{}, stakeTypes.MsgEditValidator{}, stakeTypes.MsgDelegate{},
+		stakeTypes.MsgBeginUnbonding{}, stakeTypes.MsgRedelegate{}, stakeTypes.MsgUndelegate{},
+		stakeTypes.MsgCreateSideChainValidator{}, stakeTypes.MsgCreateSideChainValidatorWithVoteAddr{},
+		stakeTypes.MsgEditSideChainValidator{}, stakeTypes.MsgEditSideChainValidatorWithVoteAddr{},
+		stakeTypes.MsgSideChainDelegate{}, stakeTypes.MsgSideChainRedelegate{}, stakeTypes.MsgSideChainUndelegate{},
+		stakeTypes.NewMsgDelegate(addr, valAddr, sdk.NewCoin("steak", 1e8)),
+
+		distrTypes.MsgWithdrawDelegatorRewardsAll{}, distrTypes.MsgWithdrawDelegatorReward{},
+		distrTypes.MsgWithdrawValidatorRewardsAll{}, distrTypes.MsgSetWithdrawAddress{}, distrTypes.MsgSetAutoRestake{},
+		distrTypes.NewMsgWithdrawDelegatorRewardsAll(addr),
+
+		slashing.MsgUnjail{}, slashing.MsgSideChainUnjail{}, slashing.MsgBscSubmitEvidence{},
+		slashing.NewMsgUnjail(valAddr),
+
+		gov.MsgSubmitProposal{}, gov.MsgDeposit{}, gov.MsgVote{},
+		gov.MsgSideChainSubmitProposal{}, gov.MsgSideChainDeposit{}, gov.MsgSideChainVote{},
+		gov.NewMsgDeposit(addr, 1, coins), gov.NewMsgVote(addr, 1, gov.OptionYes),
+
+		crisis.MsgVerifyInvariant{},
+
+		timelock.MsgTimeLock{}, timelock.MsgTimeRelock{}, timelock.MsgTimeUnlock{},
+		timelock.NewMsgTimeLock(addr, "lock", coins, time.Unix(2000000000, 0)),
+
+		swap.MsgHTLT{}, swap.MsgDepositHTLT{}, swap.MsgClaimHTLT{}, swap.MsgRefundHTLT{},
+
+		tokens.MsgIssue{}, tokens.MsgMint{}, tokens.MsgBurn{}, tokens.MsgFreeze{}, tokens.MsgUnfreeze{},
+		tokens.MsgTransferOwnership{},
+		tokens.NewMsgIssue(addr, "Token", "TKN", 1000, 1000, true),
+	}
+}
+
+// Run a decoded msg as a tx does, its ValidateBasic, then its signers and
+// sign bytes checked by the ante handler and its handler, in a cache of the
+// last state which is never written. A panic of a msg passing ValidateBasic
+// fails the fuzz, the txs are recovered but the validators would not agree on
+// the panic messages.
+func runFuzzMsg(t *testing.T, gapp *GaiaApp, router bam.Router, msg sdk.Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("%T panicked: %v\n%s", msg, r, debug.Stack())
+		}
+	}()
+
+	if msg.ValidateBasic() != nil {
+		return
+	}
+	msg.GetSigners()
+	msg.GetSignBytes()
+
+	handler := router.Route(msg.Route())
+	if handler == nil {
+		return
+	}
+	height := gapp.LastBlockHeight() + 1
+	sdk.UpgradeMgr.SetHeight(height)
+	header := abci.Header{Height: height, Time: time.Unix(1000, 0)}
+	ctx, _ := gapp.NewContext(sdk.RunTxModeCheck, header).CacheContext()
+	handler(ctx.WithRunTxMode(sdk.RunTxModeDeliver).WithEventManager(sdk.NewEventManager()), msg)
+}
+
+// FuzzTxDecode feeds arbitrary bytes to the tx decoder of gaia. CheckTx and
+// DeliverTx decode the txs out of the recovery of the txs, so a panic of the
+// decoder crashes the validators. The msgs of the decoded txs are run as by
+// FuzzMsgDecode.
+func FuzzTxDecode(f *testing.F) {
+	gapp, router := newFuzzApp(f)
+	decoder := auth.DefaultTxDecoder(gapp.cdc)
+	for _, msg := range fuzzSeedMsgs() {
+		tx := auth.NewStdTx([]sdk.Msg{msg}, nil, "memo", 0, nil)
+		if bz, err := gapp.cdc.MarshalBinaryLengthPrefixed(tx); err == nil {
+			f.Add(bz)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		var tx sdk.Tx
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("tx decoder panicked: %v\n%s", r, debug.Stack())
+				}
+			}()
+			tx, _ = decoder(bz)
+		}()
+		if tx == nil {
+			return
+		}
+		for _, msg := range tx.GetMsgs() {
+			runFuzzMsg(t, gapp, router, msg)
+		}
+	})
+}
+
+// FuzzMsgDecode feeds arbitrary bytes to the decoding of the msgs, it reaches
+// the fields of the msgs sooner than FuzzTxDecode.
+func FuzzMsgDecode(f *testing.F) {
+	gapp, router := newFuzzApp(f)
+	for _, msg := range fuzzSeedMsgs() {
+		if bz, err := gapp.cdc.MarshalBinaryBare(msg); err == nil {
+			f.Add(bz)
+		} else {
+			fmt.Fprintf(os.Stderr, "no seed for %T: %v\n", msg, err)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		var msg sdk.Msg
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("msg decoding panicked: %v\n%s", r, debug.Stack())
+				}
+			}()
+			if err := gapp.cdc.UnmarshalBinaryBare(bz, &msg); err != nil {
+				msg = nil
+			}
+		}()
+		if msg == nil {
+			return
+		}
+		runFuzzMsg(t, gapp, router, msg)
+	})
+}
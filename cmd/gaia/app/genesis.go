@@ -18,6 +18,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
@@ -35,6 +38,9 @@ type GenesisState struct {
 	DistrData    distr.GenesisState    `json:"distr"`
 	GovData      gov.GenesisState      `json:"gov"`
 	SlashingData slashing.GenesisState `json:"slashing"`
+	TimeLockData timelock.GenesisState `json:"timelock"`
+	SwapData     swap.GenesisState     `json:"swap"`
+	TokensData   tokens.GenesisState   `json:"tokens"`
 	GenTxs       []json.RawMessage     `json:"gentxs"`
 }
 
@@ -51,10 +57,17 @@ func NewGenesisState(accounts []GenesisAccount, stakeData stake.GenesisState, mi
 	}
 }
 
-// GenesisAccount doesn't need pubkey or sequence
+// GenesisAccount doesn't need pubkey or sequence. The accounts with original
+// vesting coins are vesting accounts: periodic if they have vesting periods,
+// continuous if they have a start time, delayed otherwise.
 type GenesisAccount struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+
+	OriginalVesting sdk.Coins    `json:"original_vesting,omitempty"`
+	StartTime       int64        `json:"start_time,omitempty"`
+	EndTime         int64        `json:"end_time,omitempty"`
+	VestingPeriods  auth.Periods `json:"vesting_periods,omitempty"`
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -65,18 +78,55 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc sdk.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
+	}
+	if pacc, ok := acc.(*auth.PeriodicVestingAccount); ok {
+		gacc.VestingPeriods = pacc.VestingPeriods
+	}
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to auth.BaseAccount, or to a vesting account
+func (ga *GenesisAccount) ToAccount() (acc sdk.Account) {
+	baseAcc := &auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+	if ga.OriginalVesting.IsZero() {
+		return baseAcc
+	}
+	baseVestingAcc := &auth.BaseVestingAccount{
+		BaseAccount:     baseAcc,
+		OriginalVesting: ga.OriginalVesting.Sort(),
+		EndTime:         ga.EndTime,
+	}
+	switch {
+	case len(ga.VestingPeriods) > 0:
+		return &auth.PeriodicVestingAccount{BaseVestingAccount: baseVestingAcc, StartTime: ga.StartTime, VestingPeriods: ga.VestingPeriods}
+	case ga.StartTime != 0:
+		return &auth.ContinuousVestingAccount{BaseVestingAccount: baseVestingAcc, StartTime: ga.StartTime}
+	default:
+		return &auth.DelayedVestingAccount{BaseVestingAccount: baseVestingAcc}
+	}
+}
+
+// validate checks the vesting schedule of the vesting genesis accounts.
+func (ga *GenesisAccount) validate() error {
+	vacc, ok := ga.ToAccount().(auth.VestingAccount)
+	if !ok {
+		return nil
+	}
+	if err := vacc.Validate(); err != nil {
+		return fmt.Errorf("invalid vesting genesis account %s: %v", ga.Address, err)
+	}
+	return nil
 }
 
 // get app init parameters for server init command
@@ -143,36 +193,161 @@ func genesisAccountFromMsgCreateValidator(msg stake.MsgCreateValidator, amount i
 	return NewGenesisAccount(&accAuth)
 }
 
-// GaiaValidateGenesisState ensures that the genesis state obeys the expected invariants
-// TODO: No validators are both bonded and jailed (#2088)
-// TODO: Error if there is a duplicate validator (#1708)
-// TODO: Ensure all state machine parameters are in genesis (#1704)
+// the validation of the genesis state of a module
+type genesisValidation struct {
+	module   string
+	validate func() error
+}
+
+// GaiaValidateGenesisState ensures that the genesis state obeys the expected
+// invariants of each module and across the modules, the errors locate the
+// invalid records by module and index
 func GaiaValidateGenesisState(genesisState GenesisState) (err error) {
 	err = validateGenesisStateAccounts(genesisState.Accounts)
 	if err != nil {
 		return
 	}
+
+	validations := []genesisValidation{
+		{"mint", func() error { return mint.ValidateGenesis(genesisState.MintData) }},
+		{"distr", func() error { return distr.ValidateGenesis(genesisState.DistrData) }},
+		{"gov", func() error { return gov.ValidateGenesis(genesisState.GovData) }},
+		{"slashing", func() error { return slashing.ValidateGenesis(genesisState.SlashingData) }},
+		{"timelock", func() error { return timelock.ValidateGenesis(genesisState.TimeLockData) }},
+		{"swap", func() error { return swap.ValidateGenesis(genesisState.SwapData) }},
+		{"tokens", func() error { return tokens.ValidateGenesis(genesisState.TokensData) }},
+	}
 	// skip stakeData validation as genesis is created from txs
-	if len(genesisState.GenTxs) > 0 {
-		return nil
+	if len(genesisState.GenTxs) == 0 {
+		validations = append(validations,
+			genesisValidation{"stake", func() error { return stake.ValidateGenesis(genesisState.StakeData) }},
+			genesisValidation{"distr", func() error { return validateGenesisStateDistrRecords(genesisState) }},
+		)
 	}
-	return stake.ValidateGenesis(genesisState.StakeData)
+	for _, validation := range validations {
+		if err = validation.validate(); err != nil {
+			return fmt.Errorf("%s: %v", validation.module, err)
+		}
+	}
+	return validateGenesisStateModuleCoins(genesisState)
 }
 
-// Ensures that there are no duplicate accounts in the genesis state,
+// Ensures that there are no duplicate accounts in the genesis state, and the
+// coins of the accounts are valid and not negative
 func validateGenesisStateAccounts(accs []GenesisAccount) (err error) {
 	addrMap := make(map[string]bool, len(accs))
 	for i := 0; i < len(accs); i++ {
 		acc := accs[i]
 		strAddr := string(acc.Address)
 		if _, ok := addrMap[strAddr]; ok {
-			return fmt.Errorf("Duplicate account in genesis state: Address %v", acc.Address)
+			return fmt.Errorf("accounts[%d]: Duplicate account in genesis state: Address %v", i, acc.Address)
 		}
 		addrMap[strAddr] = true
+		if !acc.Coins.IsValid() || !acc.Coins.IsNotNegative() {
+			return fmt.Errorf("accounts[%d]: coins %v of %v must be sorted, positive and without duplicates", i, acc.Coins, acc.Address)
+		}
+		if err := acc.validate(); err != nil {
+			return fmt.Errorf("accounts[%d]: %v", i, err)
+		}
 	}
 	return
 }
 
+// Ensures that the distribution records are of the validators and the
+// delegations of the stake genesis state
+func validateGenesisStateDistrRecords(genesisState GenesisState) error {
+	validators := make(map[string]bool, len(genesisState.StakeData.Validators))
+	for _, val := range genesisState.StakeData.Validators {
+		validators[val.OperatorAddr.String()] = true
+	}
+	bonds := make(map[string]bool, len(genesisState.StakeData.Bonds))
+	for _, bond := range genesisState.StakeData.Bonds {
+		bonds[bond.DelegatorAddr.String()+bond.ValidatorAddr.String()] = true
+	}
+
+	data := genesisState.DistrData
+	for i, vdi := range data.ValidatorDistInfos {
+		if !validators[vdi.OperatorAddr.String()] {
+			return fmt.Errorf("validator_dist_infos[%d]: validator %v not in the stake genesis state", i, vdi.OperatorAddr)
+		}
+	}
+	for i, ddi := range data.DelegationDistInfos {
+		if !bonds[ddi.DelegatorAddr.String()+ddi.ValOperatorAddr.String()] {
+			return fmt.Errorf("delegator_dist_infos[%d]: delegation of %v to %v not in the stake genesis state",
+				i, ddi.DelegatorAddr, ddi.ValOperatorAddr)
+		}
+	}
+	for i, record := range data.ValidatorCurrentRewards {
+		if !validators[record.ValidatorAddr.String()] {
+			return fmt.Errorf("validator_current_rewards[%d]: validator %v not in the stake genesis state", i, record.ValidatorAddr)
+		}
+	}
+	for i, record := range data.ValidatorHistoricalRewards {
+		if !validators[record.ValidatorAddr.String()] {
+			return fmt.Errorf("validator_historical_rewards[%d]: validator %v not in the stake genesis state", i, record.ValidatorAddr)
+		}
+	}
+	for i, record := range data.ValidatorAccumulatedCommissions {
+		if !validators[record.ValidatorAddr.String()] {
+			return fmt.Errorf("validator_accumulated_commissions[%d]: validator %v not in the stake genesis state", i, record.ValidatorAddr)
+		}
+	}
+	for i, record := range data.DelegatorStartingInfos {
+		if !bonds[record.DelegatorAddr.String()+record.ValidatorAddr.String()] {
+			return fmt.Errorf("delegator_starting_infos[%d]: delegation of %v to %v not in the stake genesis state",
+				i, record.DelegatorAddr, record.ValidatorAddr)
+		}
+	}
+	return nil
+}
+
+// Ensures that the module accounts hold the coins of the time locks, the open
+// swaps and the frozen coins, and the supplies of the tokens are the coins of
+// the accounts
+func validateGenesisStateModuleCoins(genesisState GenesisState) error {
+	balances := make(map[string]sdk.Coins, len(genesisState.Accounts))
+	supplies := make(map[string]int64)
+	for _, acc := range genesisState.Accounts {
+		balances[string(acc.Address)] = acc.Coins
+		for _, coin := range acc.Coins {
+			supplies[coin.Denom] += coin.Amount
+		}
+	}
+
+	var locked sdk.Coins
+	for _, lock := range genesisState.TimeLockData.TimeLocks {
+		locked = locked.Plus(lock.Amount)
+	}
+	if coins := balances[string(timelock.LockedCoinsAccAddr)]; !coins.IsEqual(locked) {
+		return fmt.Errorf("timelock: coins of the timelock account %s != locked coins %s", coins, locked)
+	}
+
+	var swapped sdk.Coins
+	for _, record := range genesisState.SwapData.Swaps {
+		if record.Swap.Status == swap.Open {
+			swapped = swapped.Plus(record.Swap.OutAmount).Plus(record.Swap.InAmount)
+		}
+	}
+	if coins := balances[string(swap.AtomicSwapCoinsAccAddr)]; !coins.IsEqual(swapped) {
+		return fmt.Errorf("swap: coins of the atomic swap account %s != coins of the open swaps %s", coins, swapped)
+	}
+
+	var frozen sdk.Coins
+	for _, record := range genesisState.TokensData.Frozen {
+		frozen = frozen.Plus(sdk.Coins{sdk.NewCoin(record.Symbol, record.Amount)})
+	}
+	if coins := balances[string(tokens.FrozenCoinsAccAddr)]; !coins.IsEqual(frozen) {
+		return fmt.Errorf("tokens: coins of the frozen account %s != frozen coins %s", coins, frozen)
+	}
+	for i, token := range genesisState.TokensData.Tokens {
+		if supply := supplies[token.Symbol]; supply != token.TotalSupply {
+			return fmt.Errorf("tokens: tokens[%d]: total supply %d of token %s != coins %d of the accounts",
+				i, token.TotalSupply, token.Symbol, supply)
+		}
+	}
+	return nil
+}
+
 // GaiaAppGenState but with JSON
 func GaiaAppGenStateJSON(cdc *codec.Codec, appGenTxs []json.RawMessage) (appState json.RawMessage, err error) {
 	// create the final app state
@@ -2,6 +2,7 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
@@ -9,9 +10,14 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
 )
 
 var (
@@ -43,9 +49,12 @@ func makeGenesisState(t *testing.T, genTxs []auth.StdTx) GenesisState {
 
 	// create the final app state
 	return GenesisState{
-		Accounts:  genAccs,
-		StakeData: stakeData,
-		GovData:   gov.DefaultGenesisState(),
+		Accounts:     genAccs,
+		StakeData:    stakeData,
+		MintData:     mint.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
 	}
 }
 
@@ -54,7 +63,75 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.AccAddress(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	require.Equal(t, &authAcc, genAcc.ToAccount())
+}
+
+func TestToVestingAccount(t *testing.T) {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	coins := sdk.Coins{sdk.NewCoin("steak", 100)}
+	periods := auth.Periods{{Length: 100, Amount: coins}}
+	baseAcc := func() *auth.BaseAccount {
+		return &auth.BaseAccount{Address: addr, Coins: coins}
+	}
+	for _, acc := range []auth.VestingAccount{
+		auth.NewContinuousVestingAccount(baseAcc(), 1000, 2000),
+		auth.NewDelayedVestingAccount(baseAcc(), 2000),
+		auth.NewPeriodicVestingAccount(baseAcc(), 1000, periods),
+	} {
+		genAcc := NewGenesisAccountI(acc)
+		require.Equal(t, acc, genAcc.ToAccount())
+		require.NoError(t, validateGenesisStateAccounts([]GenesisAccount{genAcc}))
+	}
+
+	genAcc := NewGenesisAccountI(auth.NewContinuousVestingAccount(baseAcc(), 2000, 1000))
+	require.Error(t, validateGenesisStateAccounts([]GenesisAccount{genAcc}))
+}
+
+func TestGaiaGenesisCrossModuleValidation(t *testing.T) {
+	require.NoError(t, GaiaValidateGenesisState(makeGenesisState(t, nil)))
+
+	tests := []struct {
+		name   string
+		mutate func(*GenesisState)
+		errMsg string
+	}{
+		{"negative balance", func(data *GenesisState) {
+			data.Accounts = []GenesisAccount{{Address: sdk.AccAddress(addr1), Coins: sdk.Coins{sdk.NewCoin("steak", -1)}}}
+		}, "accounts[0]"},
+		{"invalid params", func(data *GenesisState) {
+			data.SlashingData.Params.SignedBlocksWindow = 0
+		}, "slashing: slashing parameter SignedBlocksWindow"},
+		{"bonded tokens not bonded", func(data *GenesisState) {
+			data.StakeData.Pool.BondedTokens = sdk.OneDec()
+		}, "stake: pool"},
+		{"distribution info of an unknown validator", func(data *GenesisState) {
+			data.DistrData.ValidatorDistInfos = []distr.ValidatorDistInfo{{OperatorAddr: addr1}}
+		}, "distr: validator_dist_infos[0]"},
+		{"locked coins not in the module account", func(data *GenesisState) {
+			data.TimeLockData = timelock.GenesisState{
+				TimeLocks: []timelock.TimeLock{{
+					Owner:      sdk.AccAddress(addr1),
+					ID:         1,
+					Amount:     sdk.Coins{sdk.NewCoin("steak", 1)},
+					LockTime:   time.Unix(0, 0),
+					UnlockTime: time.Unix(1, 0),
+				}},
+				LastIDs: []timelock.LastIDRecord{{Owner: sdk.AccAddress(addr1), LastID: 1}},
+			}
+		}, "timelock: coins of the timelock account"},
+		{"token supply not in the accounts", func(data *GenesisState) {
+			data.TokensData.Tokens = []tokens.Token{{Symbol: "XYZ", TotalSupply: 1, MaxSupply: 1}}
+		}, "tokens: tokens[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genesisState := makeGenesisState(t, nil)
+			tt.mutate(&genesisState)
+			err := GaiaValidateGenesisState(genesisState)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
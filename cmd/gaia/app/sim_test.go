@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"testing"
@@ -48,11 +49,11 @@ func init() {
 func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 	var genesisAccounts []GenesisAccount
 
-	amt := int64(10000)
+	amt := sdk.NewDecWithoutFra(10000)
 
 	// Randomly generate some genesis accounts
 	for _, acc := range accs {
-		coins := sdk.Coins{sdk.Coin{"steak", amt}}
+		coins := sdk.Coins{sdk.NewCoin("steak", amt.RawInt())}
 		genesisAccounts = append(genesisAccounts, GenesisAccount{
 			Address: acc.Address,
 			Coins:   coins,
@@ -74,16 +75,29 @@ func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 		valAddrs[i] = valAddr
 
 		validator := stake.NewValidator(valAddr, accs[i].PubKey, stake.Description{})
-		validator.Tokens = sdk.NewDecWithoutFra(amt)
-		validator.DelegatorShares = sdk.NewDecWithoutFra(amt)
-		delegation := stake.Delegation{DelegatorAddr: accs[i].Address, ValidatorAddr: valAddr, Shares: sdk.NewDecWithoutFra(amt)}
+		validator.Tokens = amt
+		validator.DelegatorShares = amt
+		delegation := stake.Delegation{DelegatorAddr: accs[i].Address, ValidatorAddr: valAddr, Shares: amt}
 		validators = append(validators, validator)
 		delegations = append(delegations, delegation)
 	}
-	stakeGenesis.Pool.LooseTokens = sdk.NewDecWithoutFra(amt * (250 + (numInitiallyBonded * amt)))
+	// the delegation account holds the tokens of the validators, the pool
+	// counts them loose until the validators are bonded at genesis
+	bondedAmt := amt.RawInt() * numInitiallyBonded
+	genesisAccounts = append(genesisAccounts, GenesisAccount{
+		Address: stake.DelegationAccAddr,
+		Coins:   sdk.Coins{sdk.NewCoin("steak", bondedAmt)},
+	})
+	stakeGenesis.Pool.LooseTokens = sdk.NewDec(amt.RawInt()*int64(len(accs)) + bondedAmt)
 	stakeGenesis.Validators = validators
 	stakeGenesis.Bonds = delegations
+	// the mint inflates the loose tokens of the pool without minting the
+	// coins to any account, the supply invariants of stake cannot hold with it
 	mintGenesis := mint.DefaultGenesisState()
+	mintGenesis.Minter.Inflation = sdk.ZeroDec()
+	mintGenesis.Params.InflationRateChange = sdk.ZeroDec()
+	mintGenesis.Params.InflationMax = sdk.ZeroDec()
+	mintGenesis.Params.InflationMin = sdk.ZeroDec()
 
 	genesis := GenesisState{
 		Accounts:     genesisAccounts,
@@ -124,7 +138,7 @@ func testAndRunTxs(app *GaiaApp) []simulation.WeightedOperation {
 func invariants(app *GaiaApp) []simulation.Invariant {
 	return []simulation.Invariant{
 		banksim.NonnegativeBalanceInvariant(app.accountKeeper),
-		govsim.AllInvariants(),
+		govsim.AllInvariants(app.govKeeper, app.accountKeeper),
 		stakesim.AllInvariants(app.bankKeeper, app.stakeKeeper, app.distrKeeper, app.accountKeeper),
 		slashingsim.AllInvariants(),
 	}
@@ -137,7 +151,7 @@ func BenchmarkFullGaiaSimulation(b *testing.B) {
 	var logger log.Logger
 	logger = log.NewNopLogger()
 	var db dbm.DB
-	dir := os.TempDir()
+	dir, _ := ioutil.TempDir("", "goleveldb-gaia-sim")
 	db, _ = dbm.NewGoLevelDB("Simulation", dir)
 	defer func() {
 		db.Close()
@@ -163,6 +177,7 @@ func BenchmarkFullGaiaSimulation(b *testing.B) {
 	if commit {
 		fmt.Println("GoLevelDB Stats")
 		fmt.Println(db.Stats()["leveldb.stats"])
+		fmt.Println("Database Size", db.Stats()["database.size"])
 		fmt.Println("GoLevelDB cached block size", db.Stats()["leveldb.cachedblock"])
 	}
 }
@@ -179,12 +194,21 @@ func TestFullGaiaSimulation(t *testing.T) {
 	} else {
 		logger = log.NewNopLogger()
 	}
-	db := dbm.NewMemDB()
+	// the iterators of the memdb sort all its keys, the thousands of blocks
+	// committed run against a leveldb
+	dir, err := ioutil.TempDir("", "goleveldb-gaia-sim")
+	require.NoError(t, err)
+	db, err := dbm.NewGoLevelDB("Simulation", dir)
+	require.NoError(t, err)
+	defer func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}()
 	app := NewGaiaApp(logger, db, nil)
 	require.Equal(t, "GaiaApp", app.Name())
 
 	// Run randomized simulation
-	err := simulation.SimulateFromSeed(
+	err = simulation.SimulateFromSeed(
 		t, app.BaseApp, appStateFn, seed,
 		testAndRunTxs(app),
 		[]simulation.RandSetup{},
@@ -195,6 +219,7 @@ func TestFullGaiaSimulation(t *testing.T) {
 	)
 	if commit {
 		fmt.Println("Database Size", db.Stats()["database.size"])
+		fmt.Println("GoLevelDB cached block size", db.Stats()["leveldb.cachedblock"])
 	}
 	require.Nil(t, err)
 }
@@ -0,0 +1,136 @@
+package app
+
+import (
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+// AuditWiring checks the wiring of the app against the stores, msgs,
+// queriers and codec registrations of its modules.
+func (app *GaiaApp) AuditWiring() bam.WiringReport {
+	return app.AuditModuleWiring(
+		bam.ModuleWiring{
+			Name:      "main",
+			StoreKeys: []sdk.StoreKey{app.keyMain},
+		},
+		bam.ModuleWiring{
+			Name:          "auth",
+			StoreKeys:     []sdk.StoreKey{app.keyAccount, app.keyFeeCollection},
+			RegisterCodec: auth.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:          "bank",
+			Msgs:          []sdk.Msg{bank.MsgSend{}, bank.MsgSetAccountFlags{}},
+			RegisterCodec: bank.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "params",
+			StoreKeys: []sdk.StoreKey{app.keyParams, app.tkeyParams},
+		},
+		bam.ModuleWiring{
+			Name:      "ibc",
+			StoreKeys: []sdk.StoreKey{app.keyIbc},
+		},
+		bam.ModuleWiring{
+			Name:      "sidechain",
+			StoreKeys: []sdk.StoreKey{app.keySide},
+		},
+		bam.ModuleWiring{
+			Name:      "stake",
+			StoreKeys: []sdk.StoreKey{app.keyStake, app.keyStakeReward, app.tkeyStake},
+			Msgs: []sdk.Msg{
+				stake.MsgCreateValidator{}, stake.MsgCreateValidatorOpen{}, stake.MsgRemoveValidator{},
+				stake.MsgEditValidator{}, stake.MsgDelegate{}, stake.MsgBeginUnbonding{}, stake.MsgRedelegate{},
+				stake.MsgUndelegate{}, stake.MsgCreateSideChainValidator{}, stake.MsgCreateSideChainValidatorWithVoteAddr{},
+				stake.MsgEditSideChainValidator{}, stake.MsgEditSideChainValidatorWithVoteAddr{},
+				stake.MsgSideChainDelegate{}, stake.MsgSideChainRedelegate{}, stake.MsgSideChainUndelegate{},
+			},
+			QuerierRoutes: []string{"stake"},
+			RegisterCodec: stake.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "mint",
+			StoreKeys: []sdk.StoreKey{app.keyMint},
+		},
+		bam.ModuleWiring{
+			Name:      "distr",
+			StoreKeys: []sdk.StoreKey{app.keyDistr, app.tkeyDistr},
+			Msgs: []sdk.Msg{
+				distr.MsgSetWithdrawAddress{}, distr.MsgWithdrawDelegatorRewardsAll{}, distr.MsgWithdrawDelegatorReward{},
+				distr.MsgWithdrawValidatorRewardsAll{}, distr.MsgSetAutoRestake{},
+			},
+			QuerierRoutes: []string{"distr"},
+			RegisterCodec: distr.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "slashing",
+			StoreKeys: []sdk.StoreKey{app.keySlashing},
+			Msgs: []sdk.Msg{
+				slashing.MsgUnjail{}, slashing.MsgSideChainUnjail{}, slashing.MsgBscSubmitEvidence{},
+			},
+			RegisterCodec: slashing.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "gov",
+			StoreKeys: []sdk.StoreKey{app.keyGov},
+			Msgs: []sdk.Msg{
+				gov.MsgSubmitProposal{}, gov.MsgDeposit{}, gov.MsgVote{},
+				gov.MsgSideChainSubmitProposal{}, gov.MsgSideChainDeposit{}, gov.MsgSideChainVote{},
+			},
+			QuerierRoutes: []string{"gov"},
+			RegisterCodec: gov.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:          "supply",
+			StoreKeys:     []sdk.StoreKey{app.keySupply},
+			QuerierRoutes: []string{"supply"},
+		},
+		bam.ModuleWiring{
+			Name:          "crisis",
+			StoreKeys:     []sdk.StoreKey{app.tkeyCrisis},
+			Msgs:          []sdk.Msg{crisis.MsgVerifyInvariant{}},
+			RegisterCodec: crisis.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:          "upgrade",
+			StoreKeys:     []sdk.StoreKey{app.keyUpgrade},
+			QuerierRoutes: []string{"upgrade"},
+		},
+		bam.ModuleWiring{
+			Name:          "timelock",
+			StoreKeys:     []sdk.StoreKey{app.keyTimeLock},
+			Msgs:          []sdk.Msg{timelock.MsgTimeLock{}, timelock.MsgTimeRelock{}, timelock.MsgTimeUnlock{}},
+			QuerierRoutes: []string{"timelock"},
+			RegisterCodec: timelock.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "atomicSwap",
+			StoreKeys: []sdk.StoreKey{app.keySwap},
+			Msgs: []sdk.Msg{
+				swap.MsgHTLT{}, swap.MsgDepositHTLT{}, swap.MsgClaimHTLT{}, swap.MsgRefundHTLT{},
+			},
+			QuerierRoutes: []string{"atomicSwap"},
+			RegisterCodec: swap.RegisterCodec,
+		},
+		bam.ModuleWiring{
+			Name:      "tokens",
+			StoreKeys: []sdk.StoreKey{app.keyTokens},
+			Msgs: []sdk.Msg{
+				tokens.MsgIssue{}, tokens.MsgMint{}, tokens.MsgBurn{}, tokens.MsgFreeze{}, tokens.MsgUnfreeze{},
+				tokens.MsgTransferOwnership{},
+			},
+			QuerierRoutes: []string{"tokens"},
+			RegisterCodec: tokens.RegisterCodec,
+		},
+	)
+}
@@ -12,16 +12,23 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/client/lcd"
 	_ "github.com/cosmos/cosmos-sdk/client/lcd/statik"
+	"github.com/cosmos/cosmos-sdk/client/rosetta"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	crisiscmd "github.com/cosmos/cosmos-sdk/x/crisis/client/cli"
 	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
+	supplycmd "github.com/cosmos/cosmos-sdk/x/supply/client/cli"
+	swapcmd "github.com/cosmos/cosmos-sdk/x/swap/client/cli"
+	timelockcmd "github.com/cosmos/cosmos-sdk/x/timelock/client/cli"
+	tokenscmd "github.com/cosmos/cosmos-sdk/x/tokens/client/cli"
+	upgradecmd "github.com/cosmos/cosmos-sdk/x/upgrade/client/cli"
 )
 
 const (
@@ -29,6 +36,12 @@ const (
 	storeGov      = "gov"
 	storeSlashing = "slashing"
 	storeStake    = "stake"
+	querySupply   = "supply"
+	queryUpgrade  = "upgrade"
+	queryTimeLock = "timelock"
+	querySwap     = "atomicSwap"
+	queryTokens   = "tokens"
+	queryDistr    = "distr"
 )
 
 // rootCmd is the entry point for this binary
@@ -80,6 +93,21 @@ func main() {
 		stakecmd.GetCmdQueryUnbondingDelegations(storeStake, cdc),
 		stakecmd.GetCmdQueryValidator(storeStake, cdc),
 		stakecmd.GetCmdQueryValidators(storeStake, cdc),
+		supplycmd.GetCmdQuerySupply(querySupply, cdc),
+		upgradecmd.GetCmdQueryPlan(queryUpgrade, cdc),
+		upgradecmd.GetCmdQueryApplied(queryUpgrade, cdc),
+		timelockcmd.GetCmdQueryTimeLocks(queryTimeLock, cdc),
+		timelockcmd.GetCmdQueryDueTimeLocks(queryTimeLock, cdc),
+		swapcmd.GetCmdQuerySwap(querySwap, cdc),
+		swapcmd.GetCmdQuerySwapsByCreator(querySwap, cdc),
+		swapcmd.GetCmdQuerySwapsByRecipient(querySwap, cdc),
+		swapcmd.GetCmdQuerySwapsByRandomNumberHash(querySwap, cdc),
+		tokenscmd.GetCmdQueryToken(queryTokens, cdc),
+		tokenscmd.GetCmdQueryTokens(queryTokens, cdc),
+		tokenscmd.GetCmdQueryFrozen(queryTokens, cdc),
+		distrcmd.GetCmdQueryDelegatorRewards(queryDistr, cdc),
+		distrcmd.GetCmdQueryValidatorCommission(queryDistr, cdc),
+		distrcmd.GetCmdQueryCommunityPool(queryDistr, cdc),
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
 	)...)
@@ -95,6 +123,7 @@ func main() {
 		client.PostCommands(
 			bankcmd.GetBroadcastCommand(cdc),
 			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetMultiSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
 	txCmd.AddCommand(client.LineBreak)
 
@@ -107,17 +136,33 @@ func main() {
 			stakecmd.GetCmdUnbond(storeStake, cdc),
 			distrcmd.GetCmdWithdrawRewards(cdc),
 			distrcmd.GetCmdSetWithdrawAddr(cdc),
+			distrcmd.GetCmdSetAutoRestake(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			bankcmd.SendTxCmd(cdc),
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdSubmitListProposal(cdc),
 			slashingcmd.GetCmdUnjail(cdc),
 			govcmd.GetCmdVote(cdc),
+			crisiscmd.GetCmdVerifyInvariant(cdc),
+			timelockcmd.GetCmdTimeLock(cdc),
+			timelockcmd.GetCmdTimeRelock(cdc),
+			timelockcmd.GetCmdTimeUnlock(cdc),
+			swapcmd.GetCmdHTLT(cdc),
+			swapcmd.GetCmdDepositHTLT(cdc),
+			swapcmd.GetCmdClaimHTLT(cdc),
+			swapcmd.GetCmdRefundHTLT(cdc),
+			tokenscmd.GetCmdIssue(cdc),
+			tokenscmd.GetCmdMint(cdc),
+			tokenscmd.GetCmdBurn(cdc),
+			tokenscmd.GetCmdFreeze(cdc),
+			tokenscmd.GetCmdUnfreeze(cdc),
+			tokenscmd.GetCmdTransferOwnership(cdc),
 		)...)
 	rootCmd.AddCommand(
 		queryCmd,
 		txCmd,
 		lcd.ServeCommand(cdc),
+		rosetta.ServeCommand(cdc, storeAcc),
 		client.LineBreak,
 	)
 
@@ -33,8 +33,10 @@ func main() {
 	rootCmd.AddCommand(gaiaInit.InitCmd(ctx, cdc, appInit))
 	rootCmd.AddCommand(gaiaInit.TestnetFilesCmd(ctx, cdc, appInit))
 	rootCmd.AddCommand(gaiaInit.GenTxCmd(ctx, cdc))
+	rootCmd.AddCommand(gaiaInit.ValidateGenesisCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, exportAppStateAndTMValidators)
+	rootCmd.AddCommand(server.DebugCmd(ctx, newApp))
 
 	// prepare and add flags
 	executor := cli.PrepareBaseCmd(rootCmd, "GA", app.DefaultNodeHome)
@@ -46,14 +48,35 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(logger, db, traceStore,
-		baseapp.SetPruning(viper.GetString("pruning")),
-	)
+	pruning, err := server.PruningStrategyFromFlags()
+	if err != nil {
+		panic(err)
+	}
+	options := []func(*baseapp.BaseApp){
+		baseapp.SetPruningStrategy(pruning),
+		baseapp.SetInterBlockCacheSize(viper.GetInt("inter-block-cache-size")),
+		baseapp.SetStateDiffDir(viper.GetString("state-diff-dir")),
+		baseapp.SetCommitBuffer(viper.GetBool("commit-buffer"), viper.GetBool("commit-buffer-async")),
+	}
+	indexer, err := server.TxIndexerFromFlags()
+	if err != nil {
+		panic(err)
+	}
+	if indexer != nil {
+		options = append(options, baseapp.SetTxIndexer(indexer))
+	}
+	return app.NewGaiaApp(logger, db, traceStore, options...)
 }
 
 func exportAppStateAndTMValidators(
-	logger log.Logger, db dbm.DB, traceStore io.Writer,
+	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool,
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 	gApp := app.NewGaiaApp(logger, db, traceStore)
-	return gApp.ExportAppStateAndValidators()
+	if height != 0 {
+		err := gApp.LoadHeight(height)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return gApp.ExportAppStateAndValidators(forZeroHeight)
 }
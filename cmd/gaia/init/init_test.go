@@ -7,15 +7,24 @@ import (
 	"github.com/tendermint/tendermint/libs/cli"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/server/mock"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
 	abciServer "github.com/tendermint/tendermint/abci/server"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
 
 	"github.com/spf13/viper"
 )
@@ -65,9 +74,10 @@ func TestEmptyState(t *testing.T) {
 	err = cmd.RunE(nil, nil)
 	require.NoError(t, err)
 
-	old := os.Stdout
+	// the warning is printed on stderr, to keep the genesis file alone on stdout
+	oldOut, oldErr := os.Stdout, os.Stderr
 	r, w, _ := os.Pipe()
-	os.Stdout = w
+	os.Stdout, os.Stderr = w, w
 	cmd = server.ExportCmd(ctx, cdc, nil)
 	err = cmd.RunE(nil, nil)
 	require.NoError(t, err)
@@ -80,7 +90,7 @@ func TestEmptyState(t *testing.T) {
 	}()
 
 	w.Close()
-	os.Stdout = old
+	os.Stdout, os.Stderr = oldOut, oldErr
 	out := <-outC
 	require.Contains(t, out, "WARNING: State is not initialized")
 	require.Contains(t, out, "genesis_time")
@@ -143,3 +153,38 @@ func TestInitNodeValidatorFiles(t *testing.T) {
 	require.NotEqual(t, "", nodeID)
 	require.NotEqual(t, 0, len(valPubKey.Bytes()))
 }
+
+func TestValidateGenesisCmd(t *testing.T) {
+	defer server.SetupViper(t)()
+	logger := log.NewNopLogger()
+	cfg, err := tcmd.ParseConfig()
+	require.Nil(t, err)
+	ctx := server.NewContext(cfg, logger)
+	cdc := app.MakeCodec()
+	dir, err := os.MkdirTemp("", "validate-genesis")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeGenesis := func(genesisState app.GenesisState) string {
+		appState, err := codec.MarshalJSONIndent(cdc, genesisState)
+		require.NoError(t, err)
+		genDoc := types.GenesisDoc{ChainID: "test-chain", AppState: appState}
+		genesis := filepath.Join(dir, "genesis.json")
+		require.NoError(t, genDoc.SaveAs(genesis))
+		return genesis
+	}
+	genesisState := app.GenesisState{
+		StakeData:    stake.DefaultGenesisState(),
+		MintData:     mint.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
+	}
+	cmd := ValidateGenesisCmd(ctx, cdc)
+	require.NoError(t, cmd.RunE(nil, []string{writeGenesis(genesisState)}))
+
+	genesisState.GovData.TallyParams.Threshold = sdk.ZeroDec()
+	err = cmd.RunE(nil, []string{writeGenesis(genesisState)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "gov: governance vote threshold")
+}
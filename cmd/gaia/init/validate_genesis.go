@@ -0,0 +1,53 @@
+package init
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+// ValidateGenesisCmd validates a genesis file before the chain starts
+func ValidateGenesisCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-genesis [file]",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "Validate the genesis file at the default location or at the location passed as an arg",
+		Long: `Validate the genesis file at [--home]/config/genesis.json or at the location passed
+as an arg. Each module checks its genesis state, e.g. the params are in bounds and the
+validators are unique, and the states are checked across the modules, e.g. the bonded
+pool holds the tokens of the bonded validators. The error locates the invalid record
+by module and index.
+`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			genesis := config.GenesisFile()
+			if len(args) > 0 {
+				genesis = args[0]
+			}
+
+			genDoc, err := types.GenesisDocFromFile(genesis)
+			if err != nil {
+				return fmt.Errorf("error loading the genesis doc from %s: %s", genesis, err)
+			}
+			var genesisState app.GenesisState
+			if err = cdc.UnmarshalJSON(genDoc.AppState, &genesisState); err != nil {
+				return fmt.Errorf("error unmarshaling the app state of %s: %s", genesis, err)
+			}
+			if err = app.GaiaValidateGenesisState(genesisState); err != nil {
+				return fmt.Errorf("error validating the genesis file %s: %s", genesis, err)
+			}
+
+			fmt.Printf("File at %s is a valid genesis file\n", genesis)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,199 @@
+// Package canonicaljson produces the canonical JSON the sign bytes are built
+// from, e.g. the StdSignDoc of a transaction. It doesn't depend on the rest of
+// the sdk so that the signing libraries can produce the same bytes as the node.
+//
+// The canonical form of a JSON document is:
+//
+//   - without any white-space
+//   - with the keys of every object sorted by their bytes, the last value of a
+//     duplicated key is kept
+//   - with the numbers written as they are in the document, see Canonicalize,
+//     or as their float64 approximation, see CanonicalizeFloat64
+//   - with the strings escaped as follows: `"` and `\` are written `\"` and
+//     `\\`, the newline, carriage return and tab are written `\n`, `\r` and
+//     `\t`, the other control characters, `<`, `>`, `&`, U+2028 and U+2029 are
+//     written `\u00XX` or `\u20XX` with lowercase hexadecimal digits, and the
+//     invalid UTF-8 bytes are replaced by U+FFFD. Everything else is written as
+//     is.
+//
+// This is what encoding/json produced until go 1.22, which writes the
+// backspace and form feed `\b` and `\f`, so the sign bytes don't depend on the
+// go version the node or the client is built with. The node builds the sign
+// bytes with it from the CanonicalSignBytes upgrade.
+package canonicaljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+const hex = "0123456789abcdef"
+
+// Canonicalize returns the canonical form of the JSON document bz, with the
+// numbers written as they are in bz.
+func Canonicalize(bz []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(bz))
+	decoder.UseNumber()
+	return canonicalize(decoder)
+}
+
+// CanonicalizeFloat64 returns the canonical form of the JSON document bz, with
+// the numbers replaced by their float64 approximation. It is what the sign
+// bytes were made of before the FixSignBytesOverflow upgrade, the integers
+// above 2^53 lose their precision.
+func CanonicalizeFloat64(bz []byte) ([]byte, error) {
+	return canonicalize(json.NewDecoder(bytes.NewReader(bz)))
+}
+
+// Marshal returns the canonical form of the JSON encoding of v, with the
+// numbers written as they are encoded.
+func Marshal(v interface{}) ([]byte, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(bz)
+}
+
+func canonicalize(decoder *json.Decoder) ([]byte, error) {
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		buf.WriteString(string(v))
+	case float64:
+		return encodeFloat64(buf, v)
+	case string:
+		encodeString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encode(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodeString(buf, key)
+			buf.WriteByte(':')
+			if err := encode(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("unexpected JSON value of type %T", v)
+	}
+	return nil
+}
+
+// encodeFloat64 writes f in the shortest form that parses back to f, in the
+// exponent notation below 1e-6 and from 1e21 on, as the ECMAScript does.
+func encodeFloat64(buf *bytes.Buffer, f float64) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("unsupported number %v", f)
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	b := strconv.AppendFloat(nil, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	buf.Write(b)
+	return nil
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
@@ -0,0 +1,150 @@
+package canonicaljson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	cases := []struct {
+		json    string
+		want    string
+		wantErr bool
+	}{
+		{json: `{"cosmos":"foo", "atom":"bar",  "tendermint":"foobar"}`,
+			want: `{"atom":"bar","cosmos":"foo","tendermint":"foobar"}`},
+		{json: ` [ 1 , { "b" : null , "a" : [ true , false ] } ] `,
+			want: `[1,{"a":[true,false],"b":null}]`},
+		// the keys are sorted by their bytes
+		{json: `{"b":1,"B":2,"é":3,"a":4,"":5}`,
+			want: `{"":5,"B":2,"a":4,"b":1,"é":3}`},
+		// the last value of a duplicated key is kept
+		{json: `{"a":1,"a":2}`, want: `{"a":2}`},
+		// the numbers are written as they are
+		{json: `{"amount":9007199326368011,"rate":1.50,"small":1E-7,"neg":-0}`,
+			want: `{"amount":9007199326368011,"neg":-0,"rate":1.50,"small":1E-7}`},
+		{json: `"<a href=\"x\">&</a>"`, want: `"\u003ca href=\"x\"\u003e\u0026\u003c/a\u003e"`},
+		{json: `"\b\f\n\r\t\u0000\u001f\/é\u2028\u2029"`, want: `"\u0008\u000c\n\r\t\u0000\u001f/é\u2028\u2029"`},
+		{json: "\"\xff\"", want: "\"\ufffd\""},
+		{json: `"cosmos":"foo",,,, "atom":"bar"}`, wantErr: true},
+		{json: `{"a":1}}`, wantErr: true},
+		{json: `{"a":1} {"b":2}`, wantErr: true},
+		{json: ``, wantErr: true},
+	}
+
+	for i, tc := range cases {
+		got, err := Canonicalize([]byte(tc.json))
+		if tc.wantErr {
+			require.NotNil(t, err, "tc #%d", i)
+			continue
+		}
+		require.Nil(t, err, "tc #%d, err=%s", i, err)
+		require.Equal(t, tc.want, string(got), "tc #%d", i)
+	}
+}
+
+func TestCanonicalizeFloat64(t *testing.T) {
+	cases := []struct {
+		json string
+		want string
+	}{
+		{`{"amount":9007199326368011}`, `{"amount":9007199326368012}`},
+		{`[1.50,-0,1E-7,0.000001,1e20,1e21,123456789e-20]`, `[1.5,-0,1e-7,0.000001,100000000000000000000,1e+21,1.23456789e-12]`},
+	}
+
+	for i, tc := range cases {
+		got, err := CanonicalizeFloat64([]byte(tc.json))
+		require.Nil(t, err, "tc #%d, err=%s", i, err)
+		require.Equal(t, tc.want, string(got), "tc #%d", i)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	doc := struct {
+		ChainID  string            `json:"chain_id"`
+		Sequence int64             `json:"sequence"`
+		Msgs     []json.RawMessage `json:"msgs"`
+		Data     []byte            `json:"data"`
+	}{"bnbchain", 9007199326368011, []json.RawMessage{json.RawMessage(`{"z":1, "a":"<>"}`)}, nil}
+
+	got, err := Marshal(doc)
+	require.Nil(t, err)
+	require.Equal(t, `{"chain_id":"bnbchain","data":null,"msgs":[{"a":"\u003c\u003e","z":1}],"sequence":9007199326368011}`, string(got))
+}
+
+// randomValue returns a random JSON value made of the characters encoding/json
+// escapes the same way as the canonical form
+func randomValue(r *rand.Rand, depth int) interface{} {
+	kind := r.Intn(7)
+	if depth > 3 {
+		kind = r.Intn(4)
+	}
+	switch kind {
+	case 0:
+		return nil
+	case 1:
+		return r.Intn(2) == 0
+	case 2:
+		return json.Number(randomNumber(r))
+	case 3:
+		return randomString(r)
+	case 4:
+		array := make([]interface{}, r.Intn(5))
+		for i := range array {
+			array[i] = randomValue(r, depth+1)
+		}
+		return array
+	default:
+		object := make(map[string]interface{})
+		for i := r.Intn(5); i > 0; i-- {
+			object[randomString(r)] = randomValue(r, depth+1)
+		}
+		return object
+	}
+}
+
+func randomNumber(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return "-9007199326368011"
+	case 1:
+		return "1.5e-7"
+	default:
+		return "123"
+	}
+}
+
+func randomString(r *rand.Rand) string {
+	runes := []rune{'a', 'Z', '"', '\\', '/', '<', '>', '&', '\n', '\r', '\t', 0, 0x1f, 'é', '€', '\u2028', '\u2029', '😀'}
+	s := make([]rune, r.Intn(8))
+	for i := range s {
+		s[i] = runes[r.Intn(len(runes))]
+	}
+	return string(s)
+}
+
+func TestCanonicalizeMatchesEncodingJSON(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		v := randomValue(r, 0)
+		var indented bytes.Buffer
+		encoder := json.NewEncoder(&indented)
+		encoder.SetIndent(" ", "  ")
+		require.Nil(t, encoder.Encode(v))
+		want, err := json.Marshal(v)
+		require.Nil(t, err)
+
+		got, err := Canonicalize(indented.Bytes())
+		require.Nil(t, err)
+		require.Equal(t, string(want), string(got))
+
+		// canonical JSON is a fixed point
+		again, err := Canonicalize(got)
+		require.Nil(t, err)
+		require.Equal(t, got, again)
+	}
+}
@@ -14,6 +14,12 @@ func init() {
 
 // RegisterAmino registers all go-crypto related types in the given (amino) codec.
 func RegisterAmino(cdc *amino.Codec) {
-	cdc.RegisterConcrete(PrivKeyLedgerSecp256k1{},
+	cdc.RegisterConcrete(&PrivKeyLedgerSecp256k1{},
 		"tendermint/PrivKeyLedgerSecp256k1", nil)
+	cdc.RegisterConcrete(PubKeyEthSecp256k1{},
+		"cosmos-sdk/PubKeyEthSecp256k1", nil)
+	cdc.RegisterConcrete(&PrivKeyLedgerEthSecp256k1{},
+		"cosmos-sdk/PrivKeyLedgerEthSecp256k1", nil)
+	cdc.RegisterConcrete(&PrivKeyRemote{},
+		"cosmos-sdk/PrivKeyRemote", nil)
 }
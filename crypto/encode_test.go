@@ -53,6 +53,9 @@ func ExamplePrintRegisteredTypes() {
 	// Output: | Type | Name | Prefix | Length | Notes |
 	//| ---- | ---- | ------ | ----- | ------ |
 	//| PrivKeyLedgerSecp256k1 | tendermint/PrivKeyLedgerSecp256k1 | 0x10CAB393 | variable |  |
+	//| PubKeyEthSecp256k1 | cosmos-sdk/PubKeyEthSecp256k1 | 0xA01B0E0D | 0x21 |  |
+	//| PrivKeyLedgerEthSecp256k1 | cosmos-sdk/PrivKeyLedgerEthSecp256k1 | 0xBF041925 | variable |  |
+	//| PrivKeyRemote | cosmos-sdk/PrivKeyRemote | 0x97EF4FA0 | variable |  |
 	//| PubKeyEd25519 | tendermint/PubKeyEd25519 | 0x1624DE64 | 0x20 |  |
 	//| PubKeySecp256k1 | tendermint/PubKeySecp256k1 | 0xEB5AE987 | variable |  |
 	//| PubKeyMultisigThreshold | tendermint/PubKeyMultisigThreshold | 0x22C1F7E2 | variable |  |
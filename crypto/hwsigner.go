@@ -0,0 +1,213 @@
+package crypto
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	ledgergo "github.com/zondax/ledger-cosmos-go"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+// LedgerDeviceType is the device type of the Ledger backend, which is always
+// registered and uses the Ledger discovery of the build.
+const LedgerDeviceType = "ledger"
+
+type (
+	// HWSigner is the interface a hardware signer backend, e.g. a Trezor or a
+	// YubiHSM2, must implement for the secp256k1 scheme. Keys of any backend
+	// are managed through PrivKeyLedgerSecp256k1.
+	HWSigner interface {
+		// GetPubKey returns the SEC encoded public key at path.
+		GetPubKey(path DerivationPath) ([]byte, error)
+		// ShowAddress displays the bech32 address with prefix hrp of the key
+		// at path on the device, if it has a screen.
+		ShowAddress(path DerivationPath, hrp string) error
+		// Sign returns the DER signature of the SHA-256 hash of msg.
+		Sign(path DerivationPath, msg []byte) ([]byte, error)
+		// Version returns the version of the firmware or of the signing app
+		// of the device, for the callers to check it supports a feature.
+		Version() (string, error)
+	}
+
+	// HWSignerAddressConfirmer is implemented by the backends whose devices
+	// require the user to confirm the address of a key before signing with
+	// it. Signing with the other backends never asks for a confirmation.
+	HWSignerAddressConfirmer interface {
+		RequiresAddressConfirmation() (bool, error)
+	}
+
+	// HWSignerDiscoverFn discovers a connected device of a backend.
+	HWSignerDiscoverFn func() (HWSigner, error)
+)
+
+var (
+	hwSignerBackendsMtx sync.RWMutex
+	hwSignerBackends    = map[string]HWSignerDiscoverFn{
+		LedgerDeviceType: discoverLedgerHWSigner,
+	}
+)
+
+// RegisterHWSignerBackend registers the discovery function of the hardware
+// signer backend for deviceType. It panics if the type is already registered.
+func RegisterHWSignerBackend(deviceType string, discover HWSignerDiscoverFn) {
+	hwSignerBackendsMtx.Lock()
+	defer hwSignerBackendsMtx.Unlock()
+	if _, ok := hwSignerBackends[deviceType]; ok {
+		panic(fmt.Sprintf("hardware signer backend %q is already registered", deviceType))
+	}
+	hwSignerBackends[deviceType] = discover
+}
+
+// HWSignerBackends returns the registered device types, sorted.
+func HWSignerBackends() []string {
+	hwSignerBackendsMtx.RLock()
+	defer hwSignerBackendsMtx.RUnlock()
+	deviceTypes := make([]string, 0, len(hwSignerBackends))
+	for deviceType := range hwSignerBackends {
+		deviceTypes = append(deviceTypes, deviceType)
+	}
+	sort.Strings(deviceTypes)
+	return deviceTypes
+}
+
+// DiscoverHWSigner discovers a connected device of the deviceType backend.
+func DiscoverHWSigner(deviceType string) (HWSigner, error) {
+	hwSignerBackendsMtx.RLock()
+	discover, ok := hwSignerBackends[deviceType]
+	hwSignerBackendsMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown hardware signer backend %q", deviceType)
+	}
+
+	return discover()
+}
+
+// NewPrivKeyHWSecp256k1 discovers a device of the deviceType backend and
+// returns the key at path, caching its public key. The key records deviceType
+// and re-discovers the device through the same backend when required.
+func NewPrivKeyHWSecp256k1(deviceType string, path DerivationPath) (tmcrypto.PrivKey, error) {
+	device, err := hwSignerDiscovery(deviceType)()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s key", deviceType)
+	}
+
+	pkl := &PrivKeyLedgerSecp256k1{Path: path, DeviceType: deviceType, ledger: device}
+	pubKey, err := pkl.getPubKey()
+	if err != nil {
+		_ = closeLedger(device)
+		return nil, err
+	}
+
+	pkl.CachedPubKey = pubKey
+	return pkl, nil
+}
+
+// hwSignerDiscovery returns the discovery function of the device of the
+// deviceType backend, as the device interface of PrivKeyLedgerSecp256k1.
+func hwSignerDiscovery(deviceType string) discoverLedgerFn {
+	return func() (LedgerSECP256K1, error) {
+		signer, err := DiscoverHWSigner(deviceType)
+		if err != nil {
+			return nil, err
+		}
+		return hwSignerDevice(signer), nil
+	}
+}
+
+// hwSignerDevice adapts a backend to the device interface of
+// PrivKeyLedgerSecp256k1. Ledger devices are used as is.
+func hwSignerDevice(signer HWSigner) LedgerSECP256K1 {
+	if ledger, ok := signer.(ledgerHWSigner); ok {
+		return ledger.device
+	}
+	return hwSignerLedger{signer}
+}
+
+// discoverLedgerHWSigner is the discovery function of the Ledger backend.
+func discoverLedgerHWSigner() (HWSigner, error) {
+	discover := ledgerDiscovery()
+	if discover == nil {
+		return nil, errors.New("no Ledger discovery function defined")
+	}
+
+	device, err := discover()
+	if err != nil {
+		return nil, err
+	}
+	return ledgerHWSigner{device}, nil
+}
+
+// ledgerHWSigner exposes a Ledger device as a HWSigner.
+type ledgerHWSigner struct {
+	device LedgerSECP256K1
+}
+
+func (l ledgerHWSigner) GetPubKey(path DerivationPath) ([]byte, error) {
+	return l.device.GetPublicKeySECP256K1(path)
+}
+
+func (l ledgerHWSigner) ShowAddress(path DerivationPath, hrp string) error {
+	return l.device.ShowAddressSECP256K1(path, hrp)
+}
+
+func (l ledgerHWSigner) Sign(path DerivationPath, msg []byte) ([]byte, error) {
+	return l.device.SignSECP256K1(path, msg)
+}
+
+// Version returns the version of the Cosmos app running on the device, in the
+// major.minor.patch notation.
+func (l ledgerHWSigner) Version() (string, error) {
+	version, err := l.device.GetVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.String(), nil
+}
+
+func (l ledgerHWSigner) RequiresAddressConfirmation() (bool, error) {
+	return ledgerRequiresAddressConfirmation(l.device)
+}
+
+func (l ledgerHWSigner) Close() error {
+	return closeLedger(l.device)
+}
+
+// hwSignerLedger exposes a HWSigner as a Ledger device.
+type hwSignerLedger struct {
+	signer HWSigner
+}
+
+func (h hwSignerLedger) GetPublicKeySECP256K1(path []uint32) ([]byte, error) {
+	return h.signer.GetPubKey(path)
+}
+
+func (h hwSignerLedger) ShowAddressSECP256K1(path []uint32, hrp string) error {
+	return h.signer.ShowAddress(path, hrp)
+}
+
+func (h hwSignerLedger) SignSECP256K1(path []uint32, msg []byte) ([]byte, error) {
+	return h.signer.Sign(path, msg)
+}
+
+// GetVersion fails, the backend is not running the Ledger app.
+func (h hwSignerLedger) GetVersion() (*ledgergo.VersionInfo, error) {
+	return nil, errors.New("hardware signer is not a Ledger device")
+}
+
+func (h hwSignerLedger) requiresAddressConfirmation() (bool, error) {
+	if confirmer, ok := h.signer.(HWSignerAddressConfirmer); ok {
+		return confirmer.RequiresAddressConfirmation()
+	}
+	return false, nil
+}
+
+func (h hwSignerLedger) Close() error {
+	if closer, ok := h.signer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
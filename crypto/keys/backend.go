@@ -0,0 +1,105 @@
+package keys
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// Types of the backends a keybase can store its keys in.
+const (
+	// BackendDB stores the keys in a LevelDB database, the historical
+	// storage of the keybase.
+	BackendDB = "db"
+	// BackendOS stores the keys in the secret store of the operating system:
+	// the macOS Keychain, the Linux Secret Service or the Windows Credential
+	// Manager.
+	BackendOS = "os"
+	// BackendFile stores the keys in files encrypted with a passphrase.
+	BackendFile = "file"
+	// BackendTest stores the keys in memory, they are lost when the process
+	// exits.
+	BackendTest = "test"
+)
+
+// Backend is the storage a keybase persists its entries in. Get returns a nil
+// value and no error for a missing key.
+type Backend interface {
+	Get(key []byte) ([]byte, error)
+	Set(key, value []byte) error
+	Delete(key []byte) error
+	// Keys returns all the stored keys, in ascending order.
+	Keys() ([][]byte, error)
+	Close() error
+}
+
+// NewWithBackend creates a new keybase instance storing its keys in backend.
+func NewWithBackend(backend Backend) Keybase {
+	return dbKeybase{
+		backend: backend,
+	}
+}
+
+// NewInMemory creates a new keybase storing its keys in memory, for tests and
+// dry runs.
+func NewInMemory() Keybase {
+	return NewWithBackend(NewInMemoryBackend())
+}
+
+// ValidateBackendType returns an error if backendType is not one of the
+// backends a keybase can be created with.
+func ValidateBackendType(backendType string) error {
+	switch backendType {
+	case BackendDB, BackendOS, BackendFile, BackendTest:
+		return nil
+	default:
+		return fmt.Errorf("unknown keyring backend %q, expected one of %s, %s, %s or %s",
+			backendType, BackendDB, BackendOS, BackendFile, BackendTest)
+	}
+}
+
+var _ Backend = dbBackend{}
+
+// dbBackend stores the entries in a database.
+type dbBackend struct {
+	db dbm.DB
+}
+
+// NewDBBackend returns a backend storing the entries in db.
+func NewDBBackend(db dbm.DB) Backend {
+	return dbBackend{db: db}
+}
+
+// NewInMemoryBackend returns a backend storing the entries in memory.
+func NewInMemoryBackend() Backend {
+	return NewDBBackend(dbm.NewMemDB())
+}
+
+func (b dbBackend) Get(key []byte) ([]byte, error) {
+	return b.db.Get(key), nil
+}
+
+func (b dbBackend) Set(key, value []byte) error {
+	b.db.SetSync(key, value)
+	return nil
+}
+
+func (b dbBackend) Delete(key []byte) error {
+	b.db.DeleteSync(key)
+	return nil
+}
+
+func (b dbBackend) Keys() ([][]byte, error) {
+	var keys [][]byte
+	iter := b.db.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	return keys, nil
+}
+
+func (b dbBackend) Close() error {
+	b.db.Close()
+	return nil
+}
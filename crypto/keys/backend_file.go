@@ -0,0 +1,151 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/keyerror"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+)
+
+const (
+	// fileBackendKeyHash is the file holding the salt of the passphrase and
+	// a check value telling whether the passphrase is the right one.
+	fileBackendKeyHash = "keyhash"
+	// fileBackendSuffix is the suffix of the entry files, whose name is the
+	// hex encoded key of the entry.
+	fileBackendSuffix = ".entry"
+
+	fileBackendSaltLen = 16
+)
+
+// fileBackendCheck is encrypted in the keyhash file to check the passphrase.
+var fileBackendCheck = []byte("cosmos-sdk keyring")
+
+var _ Backend = fileBackend{}
+
+// fileBackend stores every entry in its own file, encrypted with a key derived
+// from a passphrase. The key is derived once, when the backend is opened.
+type fileBackend struct {
+	dir string
+	key []byte
+}
+
+// NewFileBackend opens the encrypted file backend stored in dir, creating it
+// if needed. A new backend is encrypted with passphrase, an existing one
+// returns an error if passphrase is not the one it was created with.
+func NewFileBackend(dir, passphrase string) (Backend, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	keyHashPath := filepath.Join(dir, fileBackendKeyHash)
+	keyHash, err := ioutil.ReadFile(keyHashPath)
+	switch {
+	case os.IsNotExist(err):
+		salt := crypto.CRandBytes(fileBackendSaltLen)
+		key, err := fileBackendKey(salt, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		keyHash = append(salt, xsalsa20symmetric.EncryptSymmetric(fileBackendCheck, key)...)
+		if err := ioutil.WriteFile(keyHashPath, keyHash, 0600); err != nil {
+			return nil, err
+		}
+		return fileBackend{dir: dir, key: key}, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	if len(keyHash) <= fileBackendSaltLen {
+		return nil, fmt.Errorf("invalid keyring key hash %s", keyHashPath)
+	}
+	key, err := fileBackendKey(keyHash[:fileBackendSaltLen], passphrase)
+	if err != nil {
+		return nil, err
+	}
+	check, err := xsalsa20symmetric.DecryptSymmetric(keyHash[fileBackendSaltLen:], key)
+	if err != nil || !bytes.Equal(check, fileBackendCheck) {
+		return nil, keyerror.NewErrWrongPassword()
+	}
+	return fileBackend{dir: dir, key: key}, nil
+}
+
+func fileBackendKey(salt []byte, passphrase string) ([]byte, error) {
+	key, err := bcrypt.GenerateFromPassword(salt, []byte(passphrase), mintkey.BcryptSecurityParameter)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sha256(key), nil
+}
+
+func (b fileBackend) path(key []byte) string {
+	return filepath.Join(b.dir, hex.EncodeToString(key)+fileBackendSuffix)
+}
+
+func (b fileBackend) Get(key []byte) ([]byte, error) {
+	bz, err := ioutil.ReadFile(b.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return xsalsa20symmetric.DecryptSymmetric(bz, b.key)
+}
+
+func (b fileBackend) Set(key, value []byte) error {
+	// write to a temporary file first so an entry is never left truncated
+	path := b.path(key)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, xsalsa20symmetric.EncryptSymmetric(value, b.key), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (b fileBackend) Delete(key []byte) error {
+	err := os.Remove(b.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (b fileBackend) Keys() ([][]byte, error) {
+	files, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys [][]byte
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, fileBackendSuffix) {
+			continue
+		}
+		key, err := hex.DecodeString(strings.TrimSuffix(name, fileBackendSuffix))
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	return keys, nil
+}
+
+func (b fileBackend) Close() error {
+	return nil
+}
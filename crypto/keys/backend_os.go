@@ -0,0 +1,148 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// osBackendIndex is the account of the entry listing the accounts of a
+// service, for the credential stores which cannot enumerate them. It is not
+// valid hex so it never collides with the account of an entry.
+const osBackendIndex = "keyring-index"
+
+type (
+	// credentialStore is the secret store of the operating system. Secrets
+	// are stored per account of a service. get returns false for a missing
+	// account.
+	credentialStore interface {
+		get(account string) (string, bool, error)
+		set(account, secret string) error
+		remove(account string) error
+	}
+
+	// credentialLister is implemented by the credential stores which can
+	// enumerate the accounts of their service.
+	credentialLister interface {
+		accounts() ([]string, error)
+	}
+)
+
+var _ Backend = &osBackend{}
+
+// osBackend stores the entries in the secret store of the operating system,
+// one secret per entry. Keys are hex encoded into account names and values
+// are base64 encoded, as the stores only hold text.
+type osBackend struct {
+	store credentialStore
+
+	// mtx serialises the updates of the index
+	mtx sync.Mutex
+}
+
+// NewOSBackend returns a backend storing the entries in the secret store of
+// the operating system, under service: the macOS Keychain, the Linux Secret
+// Service or the Windows Credential Manager.
+func NewOSBackend(service string) (Backend, error) {
+	store, err := newCredentialStore(service)
+	if err != nil {
+		return nil, err
+	}
+	return &osBackend{store: store}, nil
+}
+
+func (b *osBackend) Get(key []byte) ([]byte, error) {
+	secret, ok, err := b.store.get(hex.EncodeToString(key))
+	if err != nil || !ok {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(secret)
+}
+
+func (b *osBackend) Set(key, value []byte) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	account := hex.EncodeToString(key)
+	if err := b.store.set(account, base64.StdEncoding.EncodeToString(value)); err != nil {
+		return err
+	}
+	return b.updateIndex(account, true)
+}
+
+func (b *osBackend) Delete(key []byte) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	account := hex.EncodeToString(key)
+	if err := b.store.remove(account); err != nil {
+		return err
+	}
+	return b.updateIndex(account, false)
+}
+
+func (b *osBackend) Keys() ([][]byte, error) {
+	accounts, err := b.accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0, len(accounts))
+	for _, account := range accounts {
+		key, err := hex.DecodeString(account)
+		if err != nil {
+			// not an entry of the keybase
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	return keys, nil
+}
+
+func (b *osBackend) Close() error {
+	return nil
+}
+
+// accounts returns the accounts of the entries, from the store if it can
+// enumerate them and from the index otherwise.
+func (b *osBackend) accounts() ([]string, error) {
+	if lister, ok := b.store.(credentialLister); ok {
+		return lister.accounts()
+	}
+
+	index, ok, err := b.store.get(osBackendIndex)
+	if err != nil || !ok || index == "" {
+		return nil, err
+	}
+	return strings.Split(index, "\n"), nil
+}
+
+// updateIndex adds account to or removes it from the index, if the store
+// needs one.
+func (b *osBackend) updateIndex(account string, add bool) error {
+	if _, ok := b.store.(credentialLister); ok {
+		return nil
+	}
+
+	accounts, err := b.accounts()
+	if err != nil {
+		return err
+	}
+	updated := make([]string, 0, len(accounts)+1)
+	for _, existing := range accounts {
+		if existing != account {
+			updated = append(updated, existing)
+		}
+	}
+	if add {
+		updated = append(updated, account)
+	}
+	if !add && len(updated) == len(accounts) {
+		return nil
+	}
+	return b.store.set(osBackendIndex, strings.Join(updated, "\n"))
+}
@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// keychainItemNotFound is the exit status of security when no item matches.
+const keychainItemNotFound = 44
+
+// keychainStore stores the secrets as generic passwords of the login
+// Keychain, through the security command line tool.
+type keychainStore struct {
+	service string
+}
+
+func newCredentialStore(service string) (credentialStore, error) {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil, fmt.Errorf("macOS Keychain unavailable: %v", err)
+	}
+	return keychainStore{service: service}, nil
+}
+
+func (s keychainStore) get(account string) (string, bool, error) {
+	out, notFound, err := s.security(nil, "find-generic-password", "-s", s.service, "-a", account, "-w")
+	if notFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSuffix(out, "\n"), true, nil
+}
+
+func (s keychainStore) set(account, secret string) error {
+	if strings.ContainsAny(secret, "\r\n") {
+		return errors.New("the secret cannot contain a line break")
+	}
+	// -w without a value prompts for the secret and its confirmation, which
+	// are read from stdin so the secret never appears in the process list.
+	// -U updates the item if it already exists.
+	stdin := strings.NewReader(secret + "\n" + secret + "\n")
+	_, _, err := s.security(stdin, "add-generic-password", "-U", "-s", s.service, "-a", account, "-w")
+	return err
+}
+
+func (s keychainStore) remove(account string) error {
+	_, notFound, err := s.security(nil, "delete-generic-password", "-s", s.service, "-a", account)
+	if notFound {
+		return nil
+	}
+	return err
+}
+
+// security runs the security tool and returns its output, reporting whether
+// it failed because no item matched.
+func (s keychainStore) security(stdin *strings.Reader, args ...string) (out string, notFound bool, err error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if exitErr.ExitCode() == keychainItemNotFound {
+				return "", true, err
+			}
+			return "", false, fmt.Errorf("security %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+		}
+		return "", false, err
+	}
+	return stdout.String(), false, nil
+}
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretServiceStore stores the secrets in the Secret Service of the desktop
+// session, e.g. GNOME Keyring or KWallet, through the secret-tool command line
+// tool of libsecret.
+type secretServiceStore struct {
+	service string
+}
+
+func newCredentialStore(service string) (credentialStore, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, fmt.Errorf("Secret Service unavailable, please install libsecret: %v", err)
+	}
+	return secretServiceStore{service: service}, nil
+}
+
+func (s secretServiceStore) get(account string) (string, bool, error) {
+	out, err := s.secretTool(nil, "lookup", "service", s.service, "account", account)
+	if _, ok := err.(*exec.ExitError); ok {
+		// lookup exits with status 1 and no message when nothing matches
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSuffix(out, "\n"), true, nil
+}
+
+func (s secretServiceStore) set(account, secret string) error {
+	// the secret is read from stdin so it never appears in the process list
+	label := fmt.Sprintf("%s %s", s.service, account)
+	_, err := s.secretTool(strings.NewReader(secret), "store", "--label", label, "service", s.service, "account", account)
+	return err
+}
+
+func (s secretServiceStore) remove(account string) error {
+	_, err := s.secretTool(nil, "clear", "service", s.service, "account", account)
+	return err
+}
+
+// secretTool runs secret-tool and returns its output. A failure without a
+// message is returned as the *exec.ExitError.
+func (s secretServiceStore) secretTool(stdin *strings.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	if stdin != nil {
+		cmd.Stdin = stdin
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("secret-tool %s failed: %s", args[0], msg)
+			}
+			return "", exitErr
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
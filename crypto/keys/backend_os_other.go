@@ -0,0 +1,13 @@
+//go:build !darwin && !linux && !windows
+// +build !darwin,!linux,!windows
+
+package keys
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func newCredentialStore(service string) (credentialStore, error) {
+	return nil, fmt.Errorf("the %s keyring backend is not supported on %s", BackendOS, runtime.GOOS)
+}
@@ -0,0 +1,157 @@
+package keys
+
+import (
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32           = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW      = advapi32.NewProc("CredReadW")
+	procCredWriteW     = advapi32.NewProc("CredWriteW")
+	procCredDeleteW    = advapi32.NewProc("CredDeleteW")
+	procCredEnumerateW = advapi32.NewProc("CredEnumerateW")
+	procCredFree       = advapi32.NewProc("CredFree")
+)
+
+// credential is the CREDENTIALW structure of the Credential Manager API.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+var _ credentialLister = credentialManagerStore{}
+
+// credentialManagerStore stores the secrets as generic credentials of the
+// Windows Credential Manager, whose target is the service and the account
+// separated by a colon.
+type credentialManagerStore struct {
+	service string
+}
+
+func newCredentialStore(service string) (credentialStore, error) {
+	if err := advapi32.Load(); err != nil {
+		return nil, err
+	}
+	return credentialManagerStore{service: service}, nil
+}
+
+func (s credentialManagerStore) target(account string) string {
+	return s.service + ":" + account
+}
+
+func (s credentialManagerStore) get(account string) (string, bool, error) {
+	target, err := syscall.UTF16PtrFromString(s.target(account))
+	if err != nil {
+		return "", false, err
+	}
+
+	var cred *credential
+	ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if err == errorNotFound {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	return string(blob), true, nil
+}
+
+func (s credentialManagerStore) set(account, secret string) error {
+	target, err := syscall.UTF16PtrFromString(s.target(account))
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+func (s credentialManagerStore) remove(account string) error {
+	target, err := syscall.UTF16PtrFromString(s.target(account))
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ret == 0 && err != errorNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s credentialManagerStore) accounts() ([]string, error) {
+	filter, err := syscall.UTF16PtrFromString(s.target("*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var count uint32
+	var creds **credential
+	ret, _, err := procCredEnumerateW.Call(uintptr(unsafe.Pointer(filter)), 0,
+		uintptr(unsafe.Pointer(&count)), uintptr(unsafe.Pointer(&creds)))
+	if ret == 0 {
+		if err == errorNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(creds)))
+
+	prefix := s.target("")
+	accounts := make([]string, 0, count)
+	for _, cred := range unsafe.Slice(creds, count) {
+		target := syscall.UTF16ToString(unsafe.Slice(cred.TargetName, utf16Len(cred.TargetName)))
+		if strings.HasPrefix(target, prefix) {
+			accounts = append(accounts, strings.TrimPrefix(target, prefix))
+		}
+	}
+	return accounts, nil
+}
+
+// utf16Len returns the length of the NUL terminated string at p.
+func utf16Len(p *uint16) int {
+	n := 0
+	for ptr := unsafe.Pointer(p); *(*uint16)(ptr) != 0; n++ {
+		ptr = unsafe.Pointer(uintptr(ptr) + unsafe.Sizeof(*p))
+	}
+	return n
+}
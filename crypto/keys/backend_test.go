@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCredentialStore is an in-memory credentialStore.
+type fakeCredentialStore map[string]string
+
+func (s fakeCredentialStore) get(account string) (string, bool, error) {
+	secret, ok := s[account]
+	return secret, ok, nil
+}
+
+func (s fakeCredentialStore) set(account, secret string) error {
+	s[account] = secret
+	return nil
+}
+
+func (s fakeCredentialStore) remove(account string) error {
+	delete(s, account)
+	return nil
+}
+
+// fakeCredentialLister is a fakeCredentialStore which enumerates its
+// accounts, like the Windows Credential Manager.
+type fakeCredentialLister struct {
+	fakeCredentialStore
+}
+
+func (s fakeCredentialLister) accounts() ([]string, error) {
+	var accounts []string
+	for account := range s.fakeCredentialStore {
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func testBackendRoundTrip(t *testing.T, backend Backend) {
+	keys, err := backend.Keys()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	value, err := backend.Get([]byte("missing"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// binary keys and values must survive the round trip
+	binaryKey := []byte{'a', '\n', 0, 0xff, '.'}
+	require.NoError(t, backend.Set([]byte("b.info"), []byte("info of b")))
+	require.NoError(t, backend.Set(binaryKey, []byte{0, 1, '\n', 0xff}))
+	require.NoError(t, backend.Set([]byte("a.info"), []byte("info of a")))
+	require.NoError(t, backend.Set([]byte("a.info"), []byte("new info of a")))
+
+	value, err = backend.Get([]byte("a.info"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("new info of a"), value)
+	value, err = backend.Get(binaryKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, '\n', 0xff}, value)
+
+	keys, err = backend.Keys()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{binaryKey, []byte("a.info"), []byte("b.info")}, keys)
+
+	require.NoError(t, backend.Delete(binaryKey))
+	require.NoError(t, backend.Delete([]byte("missing")))
+	value, err = backend.Get(binaryKey)
+	require.NoError(t, err)
+	require.Nil(t, value)
+	keys, err = backend.Keys()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("a.info"), []byte("b.info")}, keys)
+
+	// a keybase works on top of the backend
+	kb := NewWithBackend(backend)
+	info, _, err := kb.CreateMnemonic("key", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+	byAddress, err := kb.GetByAddress(info.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), byAddress.GetPubKey())
+	require.NoError(t, kb.Delete("key", "1234567890"))
+	_, err = kb.Get("key")
+	require.Error(t, err)
+
+	require.NoError(t, backend.Close())
+}
+
+func TestInMemoryBackend(t *testing.T) {
+	testBackendRoundTrip(t, NewInMemoryBackend())
+}
+
+func TestFileBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backend, err := NewFileBackend(dir, "passphrase")
+	require.NoError(t, err)
+	testBackendRoundTrip(t, backend)
+
+	// the entries outlive the backend and need the passphrase
+	backend, err = NewFileBackend(dir, "passphrase")
+	require.NoError(t, err)
+	require.NoError(t, backend.Set([]byte("c.info"), []byte("info of c")))
+	_, err = NewFileBackend(dir, "wrong passphrase")
+	require.Error(t, err)
+
+	reopened, err := NewFileBackend(dir, "passphrase")
+	require.NoError(t, err)
+	value, err := reopened.Get([]byte("c.info"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("info of c"), value)
+
+	// entries are encrypted at rest
+	raw, err := ioutil.ReadFile(reopened.(fileBackend).path([]byte("c.info")))
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "info of c")
+}
+
+func TestOSBackend(t *testing.T) {
+	store := fakeCredentialStore{}
+	testBackendRoundTrip(t, &osBackend{store: store})
+	// accounts are hex encoded, so the index holds one per line
+	require.Len(t, store, 3)
+	require.Equal(t, "622e696e666f\n612e696e666f", store[osBackendIndex])
+
+	lister := fakeCredentialLister{fakeCredentialStore{}}
+	testBackendRoundTrip(t, &osBackend{store: lister})
+	require.Len(t, lister.fakeCredentialStore, 2)
+	require.NotContains(t, lister.fakeCredentialStore, osBackendIndex)
+}
+
+func TestValidateBackendType(t *testing.T) {
+	for _, backendType := range []string{BackendDB, BackendOS, BackendFile, BackendTest} {
+		require.NoError(t, ValidateBackendType(backendType))
+	}
+	require.Error(t, ValidateBackendType("kwallet"))
+}
@@ -0,0 +1,135 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+)
+
+const backupVersion = 1
+
+// BackupRecord is a key of a keybase backup. The info is stored as it is in
+// the keybase: the private keys of the local keys stay encrypted with their
+// own passphrases, and the Ledger, offline, multisig and tss keys are backed
+// up as the references they are, holding no secret.
+type BackupRecord struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Address  string `json:"address"`
+	Info     []byte `json:"info"`
+	Checksum string `json:"checksum"` // hex sha256 of the info
+}
+
+// backup is the content of the encrypted archive of a keybase backup
+type backup struct {
+	Version  int            `json:"version"`
+	Keys     []BackupRecord `json:"keys"`
+	Checksum string         `json:"checksum"` // hex sha256 of the checksums of the keys
+}
+
+func checksum(bz []byte) string {
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:])
+}
+
+func (b backup) checksum() string {
+	checksums := make([]string, len(b.Keys))
+	for i, record := range b.Keys {
+		checksums[i] = record.Name + ":" + record.Checksum
+	}
+	return checksum([]byte(strings.Join(checksums, "\n")))
+}
+
+// Backup returns all the keys of the keybase in a single archive in ASCII
+// armored format, encrypted with passphrase, along with the records of the
+// keys backed up.
+func (kb dbKeybase) Backup(passphrase string) (armor string, records []BackupRecord, err error) {
+	infos, err := kb.List()
+	if err != nil {
+		return "", nil, err
+	}
+	b := backup{Version: backupVersion, Keys: make([]BackupRecord, 0, len(infos))}
+	for _, info := range infos {
+		bz, err := kb.backend.Get(infoKey(info.GetName()))
+		if err != nil {
+			return "", nil, err
+		}
+		b.Keys = append(b.Keys, BackupRecord{
+			Name:     info.GetName(),
+			Type:     info.GetType().String(),
+			Address:  info.GetAddress().String(),
+			Info:     bz,
+			Checksum: checksum(bz),
+		})
+	}
+	sort.Slice(b.Keys, func(i, j int) bool { return b.Keys[i].Name < b.Keys[j].Name })
+	b.Checksum = b.checksum()
+
+	bz, err := json.Marshal(b)
+	if err != nil {
+		return "", nil, err
+	}
+	return mintkey.EncryptArmorBackup(bz, passphrase), b.Keys, nil
+}
+
+// Restore stores the keys of a backup armored by Backup and decrypted with
+// passphrase. The integrity of the backup is checked, and none of the keys
+// is restored if any is corrupted, is backed up twice or has the name of an
+// existing key.
+func (kb dbKeybase) Restore(armor, passphrase string) (records []BackupRecord, err error) {
+	bz, err := mintkey.UnarmorDecryptBackup(armor, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt backup")
+	}
+	var b backup
+	if err := json.Unmarshal(bz, &b); err != nil {
+		return nil, errors.Wrap(err, "failed to decode backup")
+	}
+	if b.Version != backupVersion {
+		return nil, fmt.Errorf("unrecognized backup version %d", b.Version)
+	}
+	if b.checksum() != b.Checksum {
+		return nil, errors.New("backup checksum mismatch")
+	}
+
+	infos := make([]Info, len(b.Keys))
+	names := make(map[string]bool, len(b.Keys))
+	for i, record := range b.Keys {
+		if names[record.Name] {
+			return nil, fmt.Errorf("duplicate key %s in backup", record.Name)
+		}
+		names[record.Name] = true
+		if checksum(record.Info) != record.Checksum {
+			return nil, fmt.Errorf("checksum mismatch of key %s", record.Name)
+		}
+		info, err := readInfo(record.Info)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode key %s", record.Name)
+		}
+		if info.GetName() != record.Name || info.GetAddress().String() != record.Address {
+			return nil, fmt.Errorf("key %s does not match its record", record.Name)
+		}
+		existing, err := kb.backend.Get(infoKey(record.Name))
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, errors.New("Cannot overwrite data for name " + record.Name)
+		}
+		infos[i] = info
+	}
+
+	for i, info := range infos {
+		if err := kb.writeInfo(info, b.Keys[i].Name); err != nil {
+			return nil, err
+		}
+	}
+	return b.Keys, nil
+}
@@ -11,10 +11,15 @@ var cdc = amino.NewCodec()
 func init() {
 	cryptoAmino.RegisterAmino(cdc)
 	cdc.RegisterInterface((*Info)(nil), nil)
-	cdc.RegisterConcrete(ccrypto.PrivKeyLedgerSecp256k1{},
+	cdc.RegisterConcrete(&ccrypto.PrivKeyLedgerSecp256k1{},
 		"tendermint/PrivKeyLedgerSecp256k1", nil)
+	cdc.RegisterConcrete(ccrypto.PubKeyEthSecp256k1{},
+		"cosmos-sdk/PubKeyEthSecp256k1", nil)
+	cdc.RegisterConcrete(&ccrypto.PrivKeyLedgerEthSecp256k1{},
+		"cosmos-sdk/PrivKeyLedgerEthSecp256k1", nil)
 	cdc.RegisterConcrete(localInfo{}, "crypto/keys/localInfo", nil)
 	cdc.RegisterConcrete(ledgerInfo{}, "crypto/keys/ledgerInfo", nil)
 	cdc.RegisterConcrete(offlineInfo{}, "crypto/keys/offlineInfo", nil)
 	cdc.RegisterConcrete(tssInfo{}, "crypto/keys/tssInfo", nil)
+	cdc.RegisterConcrete(multiInfo{}, "crypto/keys/multiInfo", nil)
 }
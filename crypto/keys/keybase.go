@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/keyerror"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	dbm "github.com/tendermint/tendermint/libs/db"
 )
@@ -70,19 +71,23 @@ var (
 
 	// ErrTssUnsupported is raised when the caller tries to use TSS, which is not supported.
 	ErrTssUnsupported = errors.New("tss unsupported: tss is not supported")
+
+	// ErrMultiSign is raised when the caller tries to sign with a multisig
+	// key, whose signatures are combined from the ones of its participants.
+	ErrMultiSign = errors.New("multisig keys cannot sign: sign with the keys of the participants and combine the signatures")
 )
 
 // dbKeybase combines encryption and storage implementation to provide
 // a full-featured key manager
 type dbKeybase struct {
-	db dbm.DB
+	backend Backend
+
+	ledgerOptions LedgerOptions
 }
 
 // New creates a new keybase instance using the passed DB for reading and writing keys.
 func New(db dbm.DB) Keybase {
-	return dbKeybase{
-		db: db,
-	}
+	return NewWithBackend(NewDBBackend(db))
 }
 
 // CreateMnemonic generates a new key and persists it to storage, encrypted
@@ -161,15 +166,22 @@ func (kb dbKeybase) Derive(name, mnemonic, bip39Passphrase, encryptPasswd string
 // CreateLedger creates a new locally-stored reference to a Ledger keypair
 // It returns the created key info and an error if the Ledger could not be queried
 func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
-	if algo != Secp256k1 {
-		return nil, ErrUnsupportedSigningAlgo
+	info, err := NewLedgerInfo(name, path, algo)
+	if err != nil {
+		return nil, err
 	}
-	priv, err := crypto.NewPrivKeyLedgerSecp256k1(path)
+	return info, kb.writeInfo(info, name)
+}
+
+// CreateHWSigner creates a new reference to a key held by the deviceType
+// hardware signer backend, recording the backend so Sign discovers the device
+// through it.
+func (kb dbKeybase) CreateHWSigner(name, deviceType string, path crypto.DerivationPath) (Info, error) {
+	info, err := NewHWSignerInfo(name, deviceType, path)
 	if err != nil {
 		return nil, err
 	}
-	pub := priv.PubKey()
-	return kb.writeLedgerKey(pub, path, name), nil
+	return info, kb.writeInfo(info, name)
 }
 
 func (kb dbKeybase) CreateTss(name, tssHome, tssVault string, pubkey tmcrypto.PubKey) (info Info, err error) {
@@ -179,7 +191,18 @@ func (kb dbKeybase) CreateTss(name, tssHome, tssVault string, pubkey tmcrypto.Pu
 // CreateOffline creates a new reference to an offline keypair
 // It returns the created key info
 func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error) {
-	return kb.writeOfflineKey(pub, name), nil
+	return kb.writeOfflineKey(pub, name)
+}
+
+// CreateMulti creates a new reference to a multisig threshold key, which must
+// be a multisig.PubKeyMultisigThreshold.
+// It returns the created key info
+func (kb dbKeybase) CreateMulti(name string, pub tmcrypto.PubKey) (Info, error) {
+	if _, ok := pub.(multisig.PubKeyMultisigThreshold); !ok {
+		return nil, fmt.Errorf("%T is not a multisig threshold public key", pub)
+	}
+	info := newMultiInfo(name, pub)
+	return info, kb.writeInfo(info, name)
 }
 
 func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
@@ -193,10 +216,10 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 	// if we have a password, use it to encrypt the private key and store it
 	// else store the public key only
 	if passwd != "" {
-		info = kb.writeLocalKey(secp256k1.PrivKeySecp256k1(derivedPriv[:]), name, passwd)
+		info, err = kb.writeLocalKey(secp256k1.PrivKeySecp256k1(derivedPriv[:]), name, passwd)
 	} else {
 		pubk := secp256k1.PrivKeySecp256k1(derivedPriv[:]).PubKey()
-		info = kb.writeOfflineKey(pubk, name)
+		info, err = kb.writeOfflineKey(pubk, name)
 	}
 	return
 }
@@ -204,14 +227,20 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 // List returns the keys from storage in alphabetical order.
 func (kb dbKeybase) List() ([]Info, error) {
 	var res []Info
-	iter := kb.db.Iterator(nil, nil)
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		key := string(iter.Key())
+	keys, err := kb.backend.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		key := string(k)
 
 		// need to include only keys in storage that have an info suffix
 		if strings.HasSuffix(key, infoSuffix) {
-			info, err := readInfo(iter.Value())
+			bs, err := kb.backend.Get(k)
+			if err != nil {
+				return nil, err
+			}
+			info, err := readInfo(bs)
 			if err != nil {
 				name := nameFromInfoKey(key)
 				return nil, fmt.Errorf("cannot read %s, please use a compatible bnbcli", name)
@@ -224,7 +253,10 @@ func (kb dbKeybase) List() ([]Info, error) {
 
 // Get returns the public information about one key.
 func (kb dbKeybase) Get(name string) (Info, error) {
-	bs := kb.db.Get(infoKey(name))
+	bs, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return nil, err
+	}
 	if len(bs) == 0 {
 		return nil, keyerror.NewErrKeyNotFound(name)
 	}
@@ -232,11 +264,17 @@ func (kb dbKeybase) Get(name string) (Info, error) {
 }
 
 func (kb dbKeybase) GetByAddress(address types.AccAddress) (Info, error) {
-	ik := kb.db.Get(addrKey(address))
+	ik, err := kb.backend.Get(addrKey(address))
+	if err != nil {
+		return nil, err
+	}
 	if len(ik) == 0 {
 		return nil, fmt.Errorf("key with address %s not found", address)
 	}
-	bs := kb.db.Get(ik)
+	bs, err := kb.backend.Get(ik)
+	if err != nil {
+		return nil, err
+	}
 	return readInfo(bs)
 }
 
@@ -260,14 +298,32 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 			return nil, nil, err
 		}
 	case ledgerInfo:
-		linfo := info.(ledgerInfo)
-		priv, err = crypto.NewPrivKeyLedgerSecp256k1(linfo.Path)
+		if info.(ledgerInfo).Algo == EthSecp256k1 {
+			ethPriv, err := LedgerEthPrivKey(info)
+			if err != nil {
+				return nil, nil, err
+			}
+			defer ethPriv.Close() // nolint: errcheck
+			priv = ethPriv
+			break
+		}
+		ledgerPriv, err := LedgerPrivKey(info)
 		if err != nil {
-			return
+			return nil, nil, err
 		}
+		// release the device once signed so other processes can use it
+		defer ledgerPriv.Close() // nolint: errcheck
+		ledgerPriv.ConfirmPolicy = kb.ledgerOptions.ConfirmPolicy
+		ledgerPriv.ConfirmFn = kb.ledgerOptions.ConfirmFn
+		ledgerPriv.EventHandler = kb.ledgerOptions.EventHandler
+		ledgerPriv.PreviewWriter = kb.ledgerOptions.PreviewWriter
+		priv = ledgerPriv
 	case tssInfo:
 		err = ErrTssUnsupported
 		return
+	case multiInfo:
+		err = ErrMultiSign
+		return
 	case offlineInfo:
 		linfo := info.(offlineInfo)
 		_, err := fmt.Fprintf(os.Stderr, "Bytes to sign:\n%s", msg)
@@ -316,14 +372,17 @@ func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcr
 		return nil, errors.New("Only works on local private keys")
 	case tssInfo:
 		return nil, errors.New("Only works on local private keys")
-	case offlineInfo:
+	case offlineInfo, multiInfo:
 		return nil, errors.New("Only works on local private keys")
 	}
 	return priv, nil
 }
 
 func (kb dbKeybase) Export(name string) (armor string, err error) {
-	bz := kb.db.Get(infoKey(name))
+	bz, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return "", err
+	}
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
@@ -334,7 +393,10 @@ func (kb dbKeybase) Export(name string) (armor string, err error) {
 // Retrieve a Info object by its name and return the public key in
 // a portable format.
 func (kb dbKeybase) ExportPubKey(name string) (armor string, err error) {
-	bz := kb.db.Get(infoKey(name))
+	bz, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return "", err
+	}
 	if bz == nil {
 		return "", fmt.Errorf("no key to export with name %s", name)
 	}
@@ -345,8 +407,21 @@ func (kb dbKeybase) ExportPubKey(name string) (armor string, err error) {
 	return mintkey.ArmorPubKeyBytes(info.GetPubKey().Bytes()), nil
 }
 
+// ExportPrivKey returns the private key of a local key in ASCII armored
+// format, encrypted with encryptPassphrase so it is never exported in clear.
+func (kb dbKeybase) ExportPrivKey(name, decryptPassphrase, encryptPassphrase string) (armor string, err error) {
+	priv, err := kb.ExportPrivateKeyObject(name, decryptPassphrase)
+	if err != nil {
+		return "", err
+	}
+	return mintkey.EncryptArmorPrivKey(priv, encryptPassphrase), nil
+}
+
 func (kb dbKeybase) Import(name string, armor string) (err error) {
-	bz := kb.db.Get(infoKey(name))
+	bz, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return
+	}
 	if len(bz) > 0 {
 		return errors.New("Cannot overwrite data for name " + name)
 	}
@@ -354,15 +429,35 @@ func (kb dbKeybase) Import(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.db.Set(infoKey(name), infoBytes)
-	return nil
+	return kb.backend.Set(infoKey(name), infoBytes)
+}
+
+// ImportPrivKey imports a private key in ASCII armored format, as returned by
+// ExportPrivKey, and stores it as a local key encrypted with passphrase.
+func (kb dbKeybase) ImportPrivKey(name, armor, passphrase string) error {
+	bz, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return err
+	}
+	if len(bz) > 0 {
+		return errors.New("Cannot overwrite data for name " + name)
+	}
+	priv, err := mintkey.UnarmorDecryptPrivKey(armor, passphrase)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt private key")
+	}
+	_, err = kb.writeLocalKey(priv, name, passphrase)
+	return err
 }
 
 // ImportPubKey imports ASCII-armored public keys.
 // Store a new Info object holding a public key only, i.e. it will
 // not be possible to sign with it as it lacks the secret key.
 func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
-	bz := kb.db.Get(infoKey(name))
+	bz, err := kb.backend.Get(infoKey(name))
+	if err != nil {
+		return
+	}
 	if len(bz) > 0 {
 		return errors.New("Cannot overwrite data for name " + name)
 	}
@@ -374,7 +469,7 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	kb.writeOfflineKey(pubKey, name)
+	_, err = kb.writeOfflineKey(pubKey, name)
 	return
 }
 
@@ -395,16 +490,12 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 		if err != nil {
 			return err
 		}
-		kb.db.DeleteSync(addrKey(linfo.GetAddress()))
-		kb.db.DeleteSync(infoKey(name))
-		return nil
-	case ledgerInfo, tssInfo, offlineInfo:
+		return kb.deleteInfo(linfo, name)
+	case ledgerInfo, tssInfo, offlineInfo, multiInfo:
 		if passphrase != "yes" {
 			return fmt.Errorf("enter 'yes' to delete the key - this cannot be undone")
 		}
-		kb.db.DeleteSync(addrKey(info.GetAddress()))
-		kb.db.DeleteSync(infoKey(name))
-		return nil
+		return kb.deleteInfo(info, name)
 	}
 
 	return nil
@@ -432,46 +523,53 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 		if err != nil {
 			return err
 		}
-		kb.writeLocalKey(key, name, newpass)
-		return nil
+		_, err = kb.writeLocalKey(key, name, newpass)
+		return err
 	default:
 		return fmt.Errorf("locally stored key required")
 	}
 }
 
+// WithLedgerOptions returns a copy of the keybase signing with opts.
+func (kb dbKeybase) WithLedgerOptions(opts LedgerOptions) Keybase {
+	kb.ledgerOptions = opts
+	return kb
+}
+
 // CloseDB releases the lock and closes the storage backend.
 func (kb dbKeybase) CloseDB() {
-	kb.db.Close()
+	kb.backend.Close()
 }
 
-func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) Info {
+func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) (Info, error) {
 	// encrypt private key using passphrase
 	privArmor := mintkey.EncryptArmorPrivKey(priv, passphrase)
 	// make Info
 	pub := priv.PubKey()
 	info := newLocalInfo(name, pub, privArmor)
-	kb.writeInfo(info, name)
-	return info
+	return info, kb.writeInfo(info, name)
 }
 
-func (kb dbKeybase) writeLedgerKey(pub tmcrypto.PubKey, path crypto.DerivationPath, name string) Info {
-	info := newLedgerInfo(name, pub, path)
-	kb.writeInfo(info, name)
-	return info
-}
-
-func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) Info {
+func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) (Info, error) {
 	info := newOfflineInfo(name, pub)
-	kb.writeInfo(info, name)
-	return info
+	return info, kb.writeInfo(info, name)
 }
 
-func (kb dbKeybase) writeInfo(info Info, name string) {
+func (kb dbKeybase) writeInfo(info Info, name string) error {
 	// write the info by key
 	key := infoKey(name)
-	kb.db.SetSync(key, writeInfo(info))
+	if err := kb.backend.Set(key, writeInfo(info)); err != nil {
+		return err
+	}
 	// store a pointer to the infokey by address for fast lookup
-	kb.db.SetSync(addrKey(info.GetAddress()), key)
+	return kb.backend.Set(addrKey(info.GetAddress()), key)
+}
+
+func (kb dbKeybase) deleteInfo(info Info, name string) error {
+	if err := kb.backend.Delete(addrKey(info.GetAddress())); err != nil {
+		return err
+	}
+	return kb.backend.Delete(infoKey(name))
 }
 
 func addrKey(address types.AccAddress) []byte {
@@ -1,17 +1,20 @@
 package keys
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
 
 	"github.com/cosmos/cosmos-sdk/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -227,6 +230,38 @@ func TestExportImport(t *testing.T) {
 	require.Equal(t, john, john2)
 }
 
+func TestExportImportPrivKey(t *testing.T) {
+	cstore := NewInMemory()
+	info, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+
+	_, err = cstore.ExportPrivKey("john", "wrongpw", "exportpw")
+	require.Error(t, err)
+	armor, err := cstore.ExportPrivKey("john", "secretcpw", "exportpw")
+	require.NoError(t, err)
+	require.Contains(t, armor, "TENDERMINT PRIVATE KEY")
+
+	// the armor needs the export passphrase
+	other := NewInMemory()
+	require.Error(t, other.ImportPrivKey("john", armor, "secretcpw"))
+	require.NoError(t, other.ImportPrivKey("john", armor, "exportpw"))
+	imported, err := other.Get("john")
+	require.NoError(t, err)
+	require.Equal(t, TypeLocal, imported.GetType())
+	require.Equal(t, info.GetPubKey(), imported.GetPubKey())
+
+	// the imported key is protected by the export passphrase
+	_, _, err = other.Sign("john", "exportpw", []byte("msg"))
+	require.NoError(t, err)
+	require.Error(t, other.ImportPrivKey("john", armor, "exportpw"))
+
+	// only local keys hold a private key
+	_, err = cstore.CreateOffline("offline", info.GetPubKey())
+	require.NoError(t, err)
+	_, err = cstore.ExportPrivKey("offline", "", "exportpw")
+	require.Error(t, err)
+}
+
 //
 func TestExportImportPubKey(t *testing.T) {
 	// make the storage with reasonable defaults
@@ -352,6 +387,110 @@ func TestSeedPhrase(t *testing.T) {
 	require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
 }
 
+// TestLedgerInfo makes sure a stored Ledger key loads without the device
+func TestLedgerInfo(t *testing.T) {
+	cstore := NewInMemory()
+	pub := ed25519.GenPrivKey().PubKey()
+	path := ccrypto.DefaultBIP44Path()
+	info := newLedgerInfo("cold", pub, path, Secp256k1)
+	require.NoError(t, cstore.(dbKeybase).writeInfo(info, "cold"))
+
+	loaded, err := cstore.Get("cold")
+	require.NoError(t, err)
+	require.Equal(t, TypeLedger, loaded.GetType())
+	require.Equal(t, info.GetAddress(), loaded.GetAddress())
+
+	priv, err := LedgerPrivKey(loaded)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().Equals(pub))
+	require.Equal(t, path, priv.Path)
+
+	_, err = LedgerEthPrivKey(loaded)
+	require.Error(t, err)
+
+	// keys stored before the algo was recorded are secp256k1 keys
+	legacy := ledgerInfo{Name: "legacy", PubKey: pub, Path: path}
+	priv, err = LedgerPrivKey(legacy)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().Equals(pub))
+
+	ethPath := ccrypto.NewEthBIP44Path(0, 0)
+	eth := newLedgerInfo("eth", pub, ethPath, EthSecp256k1)
+	_, err = LedgerPrivKey(eth)
+	require.Error(t, err)
+	ethPriv, err := LedgerEthPrivKey(eth)
+	require.NoError(t, err)
+	require.True(t, ethPriv.PubKey().Equals(pub))
+	require.Equal(t, ethPath, ethPriv.Path)
+
+	// only Ledger keys have a Ledger signing key
+	offline, err := cstore.CreateOffline("offline", pub)
+	require.NoError(t, err)
+	_, err = LedgerPrivKey(offline)
+	require.Error(t, err)
+
+	_, err = cstore.CreateLedger("ed", path, Ed25519)
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+
+	// keys of other hardware signers keep their backend
+	trezor := newLedgerInfo("trezor", pub, path, Secp256k1).(*ledgerInfo)
+	trezor.DeviceType = "trezor"
+	require.NoError(t, cstore.(dbKeybase).writeInfo(trezor, "trezor"))
+	loaded, err = cstore.Get("trezor")
+	require.NoError(t, err)
+	priv, err = LedgerPrivKey(loaded)
+	require.NoError(t, err)
+	require.Equal(t, "trezor", priv.DeviceType)
+
+	_, err = cstore.CreateHWSigner("yubihsm", "yubihsm", path)
+	require.Error(t, err)
+}
+
+func TestCreateMulti(t *testing.T) {
+	cstore := NewInMemory()
+	pubs := []crypto.PubKey{ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()}
+	multi := multisig.NewPubKeyMultisigThreshold(2, pubs)
+
+	info, err := cstore.CreateMulti("multi", multi)
+	require.NoError(t, err)
+	require.Equal(t, TypeMulti, info.GetType())
+	require.Equal(t, "multi", info.GetType().String())
+
+	loaded, err := cstore.Get("multi")
+	require.NoError(t, err)
+	require.Equal(t, multi, loaded.GetPubKey())
+	require.Equal(t, types.AccAddress(multi.Address()), loaded.GetAddress())
+
+	// the participants sign, never the multisig key
+	_, _, err = cstore.Sign("multi", "", []byte("msg"))
+	require.Equal(t, ErrMultiSign, err)
+	_, err = cstore.ExportPrivateKeyObject("multi", "")
+	require.Error(t, err)
+
+	_, err = cstore.CreateMulti("single", pubs[0])
+	require.Error(t, err)
+
+	require.Error(t, cstore.Delete("multi", ""))
+	require.NoError(t, cstore.Delete("multi", "yes"))
+	_, err = cstore.Get("multi")
+	require.Error(t, err)
+}
+
+func TestWithLedgerOptions(t *testing.T) {
+	cstore := NewInMemory()
+	opts := LedgerOptions{ConfirmPolicy: ccrypto.ConfirmFail}
+	configured := cstore.WithLedgerOptions(opts)
+	require.Equal(t, opts, configured.(dbKeybase).ledgerOptions)
+	require.Equal(t, LedgerOptions{}, cstore.(dbKeybase).ledgerOptions)
+
+	// both share the storage
+	pub := ed25519.GenPrivKey().PubKey()
+	_, err := configured.CreateOffline("offline", pub)
+	require.NoError(t, err)
+	_, err = cstore.Get("offline")
+	require.NoError(t, err)
+}
+
 func ExampleNew() {
 	// Select the encryption and storage for your cryptostore
 	cstore := New(
@@ -407,3 +546,78 @@ func ExampleNew() {
 func accAddr(info Info) types.AccAddress {
 	return (types.AccAddress)(info.GetPubKey().Address())
 }
+
+func TestBackupRestore(t *testing.T) {
+	cstore := NewInMemory()
+	local, _, err := cstore.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	pub := ed25519.GenPrivKey().PubKey()
+	ledger := newLedgerInfo("cold", pub, ccrypto.DefaultBIP44Path(), Secp256k1)
+	require.NoError(t, cstore.(dbKeybase).writeInfo(ledger, "cold"))
+	_, err = cstore.CreateOffline("offline", pub)
+	require.NoError(t, err)
+
+	armor, records, err := cstore.Backup("backuppw")
+	require.NoError(t, err)
+	require.Contains(t, armor, "TENDERMINT KEYS BACKUP")
+	require.Len(t, records, 3)
+	require.Equal(t, "cold", records[0].Name)
+	require.Equal(t, "ledger", records[0].Type)
+	require.Equal(t, "john", records[1].Name)
+	require.Equal(t, "local", records[1].Type)
+
+	// the backup needs its passphrase, and doesn't overwrite keys
+	other := NewInMemory()
+	_, err = other.Restore(armor, "secretcpw")
+	require.Error(t, err)
+	_, err = other.CreateOffline("offline", pub)
+	require.NoError(t, err)
+	_, err = other.Restore(armor, "backuppw")
+	require.Error(t, err)
+	_, err = other.Get("john")
+	require.Error(t, err)
+
+	require.NoError(t, other.Delete("offline", "yes"))
+	restored, err := other.Restore(armor, "backuppw")
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+
+	// the local key keeps its passphrase, the Ledger key its path
+	_, _, err = other.Sign("john", "secretcpw", []byte("msg"))
+	require.NoError(t, err)
+	loaded, err := other.GetByAddress(local.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "john", loaded.GetName())
+	loaded, err = other.Get("cold")
+	require.NoError(t, err)
+	priv, err := LedgerPrivKey(loaded)
+	require.NoError(t, err)
+	require.Equal(t, ccrypto.DefaultBIP44Path(), priv.Path)
+
+	// the corrupted backups are rejected
+	bz, err := mintkey.UnarmorDecryptBackup(armor, "backuppw")
+	require.NoError(t, err)
+	var b backup
+	require.NoError(t, json.Unmarshal(bz, &b))
+	b.Keys[1].Info[len(b.Keys[1].Info)-1] ^= 0xff
+	bz, err = json.Marshal(b)
+	require.NoError(t, err)
+	_, err = NewInMemory().Restore(mintkey.EncryptArmorBackup(bz, "backuppw"), "backuppw")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch of key john")
+
+	// a key backed up twice is rejected before any key is written
+	require.NoError(t, json.Unmarshal(bz, &b))
+	b.Keys[1].Info[len(b.Keys[1].Info)-1] ^= 0xff
+	b.Keys = append(b.Keys, b.Keys[1])
+	b.Checksum = b.checksum()
+	bz, err = json.Marshal(b)
+	require.NoError(t, err)
+	dup := NewInMemory()
+	_, err = dup.Restore(mintkey.EncryptArmorBackup(bz, "backuppw"), "backuppw")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate key john")
+	keys, err := dup.List()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
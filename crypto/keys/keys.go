@@ -9,4 +9,8 @@ const (
 	// Ed25519 represents the Ed25519 signature system.
 	// It is currently not supported for end-user keys (wallets/ledgers).
 	Ed25519 = SigningAlgo("ed25519")
+	// EthSecp256k1 uses secp256k1 keys with Ethereum addresses and EIP-191
+	// personal message signatures. It is only supported for keys held by the
+	// Ethereum app of a Ledger.
+	EthSecp256k1 = SigningAlgo("eth_secp256k1")
 )
@@ -19,6 +19,7 @@ const (
 	blockTypePrivKey = "TENDERMINT PRIVATE KEY"
 	blockTypeKeyInfo = "TENDERMINT KEY INFO"
 	blockTypePubKey  = "TENDERMINT PUBLIC KEY"
+	blockTypeBackup  = "TENDERMINT KEYS BACKUP"
 )
 
 // Make bcrypt security parameter var, so it can be changed within the lcd test
@@ -104,14 +105,19 @@ func EncryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) string {
 // generated salt and the xsalsa20 cipher. returns the salt and the
 // encrypted priv key.
 func encryptPrivKey(privKey crypto.PrivKey, passphrase string) (saltBytes []byte, encBytes []byte) {
+	return encryptBytes(privKey.Bytes(), passphrase)
+}
+
+// encrypt the given bytes with the passphrase using a randomly generated salt
+// and the xsalsa20 cipher. returns the salt and the encrypted bytes.
+func encryptBytes(bz []byte, passphrase string) (saltBytes []byte, encBytes []byte) {
 	saltBytes = crypto.CRandBytes(16)
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
 	if err != nil {
 		cmn.Exit("Error generating bcrypt key from passphrase: " + err.Error())
 	}
 	key = crypto.Sha256(key) // get 32 bytes
-	privKeyBytes := privKey.Bytes()
-	return saltBytes, xsalsa20symmetric.EncryptSymmetric(privKeyBytes, key)
+	return saltBytes, xsalsa20symmetric.EncryptSymmetric(bz, key)
 }
 
 // Unarmor and decrypt the private key.
@@ -139,17 +145,56 @@ func UnarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey,
 }
 
 func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privKey crypto.PrivKey, err error) {
+	privKeyBytes, err := decryptBytes(saltBytes, encBytes, passphrase)
+	if err != nil {
+		return privKey, err
+	}
+	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
+	return privKey, err
+}
+
+func decryptBytes(saltBytes []byte, encBytes []byte, passphrase string) ([]byte, error) {
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), BcryptSecurityParameter)
 	if err != nil {
 		cmn.Exit("Error generating bcrypt key from passphrase: " + err.Error())
 	}
 	key = crypto.Sha256(key) // Get 32 bytes
-	privKeyBytes, err := xsalsa20symmetric.DecryptSymmetric(encBytes, key)
+	bz, err := xsalsa20symmetric.DecryptSymmetric(encBytes, key)
 	if err != nil && err.Error() == "Ciphertext decryption failed" {
-		return privKey, keyerror.NewErrWrongPassword()
-	} else if err != nil {
-		return privKey, err
+		return nil, keyerror.NewErrWrongPassword()
 	}
-	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
-	return privKey, err
+	return bz, err
+}
+
+// Encrypt and armor the backup of a keybase.
+func EncryptArmorBackup(bz []byte, passphrase string) string {
+	saltBytes, encBytes := encryptBytes(bz, passphrase)
+	header := map[string]string{
+		"kdf":     "bcrypt",
+		"salt":    fmt.Sprintf("%X", saltBytes),
+		"version": "0.0.0",
+	}
+	return armor.EncodeArmor(blockTypeBackup, header, encBytes)
+}
+
+// Unarmor and decrypt the backup of a keybase.
+func UnarmorDecryptBackup(armorStr string, passphrase string) ([]byte, error) {
+	blockType, header, encBytes, err := armor.DecodeArmor(armorStr)
+	if err != nil {
+		return nil, err
+	}
+	if blockType != blockTypeBackup {
+		return nil, fmt.Errorf("Unrecognized armor type %q, expected: %q", blockType, blockTypeBackup)
+	}
+	if header["version"] != "0.0.0" {
+		return nil, fmt.Errorf("Unrecognized version: %v", header["version"])
+	}
+	if header["kdf"] != "bcrypt" {
+		return nil, fmt.Errorf("Unrecognized KDF type: %v", header["kdf"])
+	}
+	saltBytes, err := hex.DecodeString(header["salt"])
+	if err != nil || len(saltBytes) == 0 {
+		return nil, fmt.Errorf("Missing or invalid salt bytes")
+	}
+	return decryptBytes(saltBytes, encBytes, passphrase)
 }
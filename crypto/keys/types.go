@@ -1,6 +1,9 @@
 package keys
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/tendermint/tendermint/crypto"
 
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
@@ -34,9 +37,14 @@ type Keybase interface {
 		encryptPasswd string, params hd.BIP44Params) (Info, error)
 	// Create, store, and return a new Ledger key reference
 	CreateLedger(name string, path ccrypto.DerivationPath, algo SigningAlgo) (info Info, err error)
+	// Create, store, and return a new reference to a secp256k1 key held by
+	// the deviceType hardware signer backend
+	CreateHWSigner(name, deviceType string, path ccrypto.DerivationPath) (info Info, err error)
 	CreateTss(name, home, vault string, pubkey crypto.PubKey) (info Info, err error)
 	// Create, store, and return a new offline key reference
 	CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error)
+	// Create, store, and return a new reference to a multisig threshold key
+	CreateMulti(name string, pubkey crypto.PubKey) (info Info, err error)
 
 	// The following operations will *only* work on locally-stored keys
 	Update(name, oldpass string, getNewpass func() (string, error)) error
@@ -44,14 +52,40 @@ type Keybase interface {
 	ImportPubKey(name string, armor string) (err error)
 	Export(name string) (armor string, err error)
 	ExportPubKey(name string) (armor string, err error)
+	// ExportPrivKey returns the private key of a local key, decrypted with
+	// decryptPassphrase, armored and encrypted with encryptPassphrase.
+	ExportPrivKey(name, decryptPassphrase, encryptPassphrase string) (armor string, err error)
+	// ImportPrivKey stores a private key armored by ExportPrivKey. passphrase
+	// decrypts the armor and encrypts the stored key.
+	ImportPrivKey(name, armor, passphrase string) error
+	// Backup returns all the keys in a single archive in ASCII armored format,
+	// encrypted with passphrase, and the records of the keys backed up.
+	Backup(passphrase string) (armor string, records []BackupRecord, err error)
+	// Restore stores all the keys of an archive returned by Backup, or none.
+	Restore(armor, passphrase string) (records []BackupRecord, err error)
 
 	// *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
 
+	// WithLedgerOptions returns a keybase on the same storage which signs
+	// with its Ledger keys configured by opts.
+	WithLedgerOptions(opts LedgerOptions) Keybase
+
 	// Close closes the database.
 	CloseDB()
 }
 
+// LedgerOptions are the runtime options a keybase signs with its Ledger keys
+// with, see the fields of the same name of ccrypto.PrivKeyLedgerSecp256k1.
+// The zero value confirms addresses on the terminal, reports no events and
+// previews nothing.
+type LedgerOptions struct {
+	ConfirmPolicy ccrypto.ConfirmPolicy
+	ConfirmFn     ccrypto.ConfirmFn
+	EventHandler  ccrypto.EventHandler
+	PreviewWriter io.Writer
+}
+
 // KeyType reflects a human-readable type for key listing.
 type KeyType uint
 
@@ -61,6 +95,7 @@ const (
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
 	TypeTss     KeyType = 3
+	TypeMulti   KeyType = 4
 )
 
 var keyTypes = map[KeyType]string{
@@ -68,6 +103,7 @@ var keyTypes = map[KeyType]string{
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
 	TypeTss:     "tss",
+	TypeMulti:   "multi",
 }
 
 // String implements the stringer interface for KeyType.
@@ -91,6 +127,7 @@ var _ Info = &localInfo{}
 var _ Info = &ledgerInfo{}
 var _ Info = &offlineInfo{}
 var _ Info = &tssInfo{}
+var _ Info = &multiInfo{}
 
 // localInfo is the public information about a locally stored key
 type localInfo struct {
@@ -128,13 +165,20 @@ type ledgerInfo struct {
 	Name   string                 `json:"name"`
 	PubKey crypto.PubKey          `json:"pubkey"`
 	Path   ccrypto.DerivationPath `json:"path"`
+	// Algo is empty for the keys created before it was recorded, which are
+	// all secp256k1 keys
+	Algo SigningAlgo `json:"algo"`
+	// DeviceType is the hardware signer backend holding the key, empty for
+	// a Ledger
+	DeviceType string `json:"device_type,omitempty"`
 }
 
-func newLedgerInfo(name string, pub crypto.PubKey, path ccrypto.DerivationPath) Info {
+func newLedgerInfo(name string, pub crypto.PubKey, path ccrypto.DerivationPath, algo SigningAlgo) Info {
 	return &ledgerInfo{
 		Name:   name,
 		PubKey: pub,
 		Path:   path,
+		Algo:   algo,
 	}
 }
 
@@ -154,6 +198,96 @@ func (i ledgerInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// NewLedgerInfo queries the connected Ledger for the algo key at path and
+// returns the public information naming it. secp256k1 keys are held by the
+// Cosmos app and eth_secp256k1 keys by the Ethereum app. The device is released
+// once the public key has been read.
+func NewLedgerInfo(name string, path ccrypto.DerivationPath, algo SigningAlgo) (Info, error) {
+	var pub crypto.PubKey
+	switch algo {
+	case Secp256k1:
+		priv, err := ccrypto.NewPrivKeyLedgerSecp256k1(path)
+		if err != nil {
+			return nil, err
+		}
+		defer priv.(*ccrypto.PrivKeyLedgerSecp256k1).Close() // nolint: errcheck
+		pub = priv.PubKey()
+	case EthSecp256k1:
+		priv, err := ccrypto.NewPrivKeyLedgerEthSecp256k1(path)
+		if err != nil {
+			return nil, err
+		}
+		defer priv.(*ccrypto.PrivKeyLedgerEthSecp256k1).Close() // nolint: errcheck
+		pub = priv.PubKey()
+	default:
+		return nil, ErrUnsupportedSigningAlgo
+	}
+
+	return newLedgerInfo(name, pub, path, algo), nil
+}
+
+// NewHWSignerInfo queries the connected device of the deviceType hardware
+// signer backend for the secp256k1 key at path and returns the public
+// information naming it. The device is released once the public key has been
+// read.
+func NewHWSignerInfo(name, deviceType string, path ccrypto.DerivationPath) (Info, error) {
+	priv, err := ccrypto.NewPrivKeyHWSecp256k1(deviceType, path)
+	if err != nil {
+		return nil, err
+	}
+	defer priv.(*ccrypto.PrivKeyLedgerSecp256k1).Close() // nolint: errcheck
+
+	info := newLedgerInfo(name, priv.PubKey(), path, Secp256k1).(*ledgerInfo)
+	if deviceType != ccrypto.LedgerDeviceType {
+		info.DeviceType = deviceType
+	}
+	return info, nil
+}
+
+// LedgerPrivKey reconstructs the signing key of a secp256k1 Ledger key without
+// the device. The device is discovered through the backend of the key, and
+// checked to hold the stored public key, on first use.
+func LedgerPrivKey(info Info) (*ccrypto.PrivKeyLedgerSecp256k1, error) {
+	linfo, err := toLedgerInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	if linfo.Algo != "" && linfo.Algo != Secp256k1 {
+		return nil, fmt.Errorf("key %s is a %s Ledger key", info.GetName(), linfo.Algo)
+	}
+
+	return &ccrypto.PrivKeyLedgerSecp256k1{
+		CachedPubKey: linfo.PubKey,
+		Path:         linfo.Path,
+		DeviceType:   linfo.DeviceType,
+	}, nil
+}
+
+// LedgerEthPrivKey reconstructs the signing key of an eth_secp256k1 Ledger
+// key without the device, which is discovered on first use.
+func LedgerEthPrivKey(info Info) (*ccrypto.PrivKeyLedgerEthSecp256k1, error) {
+	linfo, err := toLedgerInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	if linfo.Algo != EthSecp256k1 {
+		return nil, fmt.Errorf("key %s is not a %s Ledger key", info.GetName(), EthSecp256k1)
+	}
+
+	return &ccrypto.PrivKeyLedgerEthSecp256k1{CachedPubKey: linfo.PubKey, Path: linfo.Path}, nil
+}
+
+func toLedgerInfo(info Info) (ledgerInfo, error) {
+	switch i := info.(type) {
+	case ledgerInfo:
+		return i, nil
+	case *ledgerInfo:
+		return *i, nil
+	default:
+		return ledgerInfo{}, fmt.Errorf("key %s is not stored on a Ledger", info.GetName())
+	}
+}
+
 // offlineInfo is the public information about an offline key
 type offlineInfo struct {
 	Name   string        `json:"name"`
@@ -183,6 +317,36 @@ func (i offlineInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// multiInfo is the public information about a multisig threshold key. It
+// cannot sign, its participants sign with their own keys.
+type multiInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+}
+
+func newMultiInfo(name string, pub crypto.PubKey) Info {
+	return &multiInfo{
+		Name:   name,
+		PubKey: pub,
+	}
+}
+
+func (i multiInfo) GetType() KeyType {
+	return TypeMulti
+}
+
+func (i multiInfo) GetName() string {
+	return i.Name
+}
+
+func (i multiInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+func (i multiInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
 // tssInfo is the public information about a tss key
 type tssInfo struct {
 	Name   string        `json:"name"` // alias of this tss vault registered in bnbcli
@@ -1,11 +1,40 @@
+//go:build cgo && ledger
 // +build cgo,ledger
 
 package crypto
 
 import (
+	"fmt"
+	"math"
+
+	ledger_go "github.com/cosmos/ledger-go"
+	"github.com/zondax/hid"
 	ledger "github.com/zondax/ledger-cosmos-go"
 )
 
+// APDU of the Cosmos user app, see github.com/zondax/ledger-cosmos-go.
+const (
+	cosmosUserCLA                 = 0xBC
+	cosmosINSGetVersion           = 0
+	cosmosINSPublicKeySECP256K1   = 1
+	cosmosINSSignSECP256K1        = 2
+	cosmosINSShowAddressSECP256K1 = 3
+	cosmosMessageChunkSize        = 250
+)
+
+// APDU of the Ethereum app, see
+// https://github.com/LedgerHQ/app-ethereum/blob/master/doc/ethapp.adoc
+const (
+	ethCLA                 = 0xE0
+	ethINSGetPublicKey     = 0x02
+	ethINSGetConfiguration = 0x06
+	ethINSSignPersonalMsg  = 0x08
+	ethP1FirstChunk        = 0x00
+	ethP1NextChunk         = 0x80
+	ethMessageChunkSize    = 255
+	ethMaxPathDepth        = 10
+)
+
 // If ledger support (build tag) has been enabled, which implies a CGO dependency,
 // set the discoverLedger function which is responsible for loading the Ledger
 // device at runtime or returning an error.
@@ -18,4 +47,251 @@ func init() {
 
 		return device, nil
 	}
+
+	enumerateLedgers = enumerateHIDLedgers
+	discoverLedgerETH = findLedgerEthereumApp
+}
+
+// ledgerHIDDevices returns the HID interfaces of the connected Ledger devices,
+// matched the way ledger-go does.
+func ledgerHIDDevices() []hid.DeviceInfo {
+	var devices []hid.DeviceInfo
+	for _, info := range hid.Enumerate(ledger_go.VendorLedger, 0) {
+		found := info.UsagePage == ledger_go.UsagePageLedgerNanoS ||
+			// workarounds for possible empty usage pages
+			(info.Product == "Nano S" || info.Product == "Nano X") && info.Interface == 0
+		if found {
+			devices = append(devices, info)
+		}
+	}
+	return devices
+}
+
+// enumerateHIDLedgers opens every connected Ledger device running the Cosmos
+// app. The Cosmos app library can only open the first device it finds, so
+// each device is opened by its HID path and driven by hidLedgerCosmos.
+func enumerateHIDLedgers() ([]LedgerDevice, error) {
+	var devices []LedgerDevice
+	for _, info := range ledgerHIDDevices() {
+		dev, err := info.Open()
+		if err != nil {
+			continue
+		}
+
+		device := &hidLedgerCosmos{api: ledger_go.NewLedger(dev)}
+		version, err := device.GetVersion()
+		if err != nil || !ledger.CheckVersion(*version, ledger.RequiredCosmosUserAppVersion()) {
+			// the Cosmos app is not open or is too old
+			_ = device.Close()
+			continue
+		}
+
+		devices = append(devices, LedgerDevice{Serial: info.Serial, Device: device})
+	}
+
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no Ledger device running the Cosmos app found")
+	}
+	return devices, nil
+}
+
+// hidLedgerCosmos drives the Cosmos user app of a Ledger opened by HID path.
+type hidLedgerCosmos struct {
+	api     *ledger_go.Ledger
+	version ledger.VersionInfo
+}
+
+var _ LedgerSECP256K1 = &hidLedgerCosmos{}
+
+func (l *hidLedgerCosmos) Close() error {
+	return l.api.Close()
+}
+
+func (l *hidLedgerCosmos) GetVersion() (*ledger.VersionInfo, error) {
+	response, err := l.api.Exchange([]byte{cosmosUserCLA, cosmosINSGetVersion, 0, 0, 0})
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < 4 {
+		return nil, fmt.Errorf("invalid response")
+	}
+
+	l.version = ledger.VersionInfo{
+		AppMode: response[0],
+		Major:   response[1],
+		Minor:   response[2],
+		Patch:   response[3],
+	}
+	return &l.version, nil
+}
+
+func (l *hidLedgerCosmos) GetPublicKeySECP256K1(path []uint32) ([]byte, error) {
+	pathBytes, err := ledger.GetBip32bytes(path, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	message := append([]byte{cosmosUserCLA, cosmosINSPublicKeySECP256K1, 0, 0, byte(len(pathBytes))}, pathBytes...)
+	response, err := l.api.Exchange(message)
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < 4 {
+		return nil, fmt.Errorf("invalid response")
+	}
+	return response, nil
+}
+
+func (l *hidLedgerCosmos) ShowAddressSECP256K1(path []uint32, hrp string) error {
+	if len(hrp) > 83 {
+		return fmt.Errorf("hrp len should be <83")
+	}
+	for _, b := range []byte(hrp) {
+		if b < 33 || b > 126 {
+			return fmt.Errorf("all characters in the HRP must be in the [33, 126] range")
+		}
+	}
+	if required := (ledger.VersionInfo{Major: 1, Minor: 1}); !ledger.CheckVersion(l.version, required) {
+		return fmt.Errorf("command requires at least app version %v", required)
+	}
+
+	pathBytes, err := ledger.GetBip32bytes(path, 3)
+	if err != nil {
+		return err
+	}
+
+	message := []byte{cosmosUserCLA, cosmosINSShowAddressSECP256K1, 0, 0, 0, byte(len(hrp))}
+	message = append(message, hrp...)
+	message = append(message, pathBytes...)
+	message[4] = byte(len(message) - 5)
+	_, err = l.api.Exchange(message)
+	return err
+}
+
+// SignSECP256K1 sends the path in the first packet and the transaction in
+// chunks in the following ones.
+func (l *hidLedgerCosmos) SignSECP256K1(path []uint32, transaction []byte) ([]byte, error) {
+	pathBytes, err := ledger.GetBip32bytes(path, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	packetCount := 1 + byte(math.Ceil(float64(len(transaction))/float64(cosmosMessageChunkSize)))
+	message := append([]byte{cosmosUserCLA, cosmosINSSignSECP256K1, 1, packetCount, byte(len(pathBytes))}, pathBytes...)
+	response, err := l.api.Exchange(message)
+	for packetIndex := byte(2); err == nil && packetIndex <= packetCount; packetIndex++ {
+		chunk := cosmosMessageChunkSize
+		if len(transaction) < chunk {
+			chunk = len(transaction)
+		}
+		message = append([]byte{cosmosUserCLA, cosmosINSSignSECP256K1, packetIndex, packetCount, byte(chunk)}, transaction[:chunk]...)
+		transaction = transaction[chunk:]
+		response, err = l.api.Exchange(message)
+	}
+	if err != nil {
+		if err.Error() == "[APDU_CODE_BAD_KEY_HANDLE] The parameters in the data field are incorrect" && len(response) > 0 {
+			// the app explains why it rejected the transaction
+			return nil, fmt.Errorf("%s", response)
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// findLedgerEthereumApp opens the first connected Ledger device running the
+// Ethereum app.
+func findLedgerEthereumApp() (LedgerETHSECP256K1, error) {
+	for _, info := range ledgerHIDDevices() {
+		dev, err := info.Open()
+		if err != nil {
+			continue
+		}
+
+		device := &hidLedgerEthereum{api: ledger_go.NewLedger(dev)}
+		if _, err := device.api.Exchange([]byte{ethCLA, ethINSGetConfiguration, 0, 0, 0}); err != nil {
+			// the Ethereum app is not open
+			_ = device.Close()
+			continue
+		}
+
+		return device, nil
+	}
+
+	return nil, fmt.Errorf("no Ledger device running the Ethereum app found")
+}
+
+// hidLedgerEthereum drives the Ethereum app of a Ledger opened by HID path.
+type hidLedgerEthereum struct {
+	api *ledger_go.Ledger
+}
+
+var _ LedgerETHSECP256K1 = &hidLedgerEthereum{}
+
+func (l *hidLedgerEthereum) Close() error {
+	return l.api.Close()
+}
+
+// ethPathBytes encodes path as the Ethereum app expects it: the number of
+// components followed by each component in big endian.
+func ethPathBytes(path []uint32) ([]byte, error) {
+	if len(path) == 0 || len(path) > ethMaxPathDepth {
+		return nil, fmt.Errorf("path should have between 1 and %d components", ethMaxPathDepth)
+	}
+
+	bz := make([]byte, 1, 1+4*len(path))
+	bz[0] = byte(len(path))
+	for _, component := range path {
+		bz = append(bz, byte(component>>24), byte(component>>16), byte(component>>8), byte(component))
+	}
+	return bz, nil
+}
+
+// GetPublicKeyETH returns the uncompressed public key at path, without
+// displaying the address on the device.
+func (l *hidLedgerEthereum) GetPublicKeyETH(path []uint32) ([]byte, error) {
+	pathBytes, err := ethPathBytes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	message := append([]byte{ethCLA, ethINSGetPublicKey, 0, 0, byte(len(pathBytes))}, pathBytes...)
+	response, err := l.api.Exchange(message)
+	if err != nil {
+		return nil, err
+	}
+	// the response is the length of the key, the key, then the address
+	if len(response) < 1 || len(response) < 1+int(response[0]) {
+		return nil, fmt.Errorf("invalid response")
+	}
+	return response[1 : 1+int(response[0])], nil
+}
+
+// SignPersonalMessageETH sends the path and the length of the message followed
+// by the message, in chunks.
+func (l *hidLedgerEthereum) SignPersonalMessageETH(path []uint32, msg []byte) ([]byte, error) {
+	pathBytes, err := ethPathBytes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	length := len(msg)
+	data := append(pathBytes, byte(length>>24), byte(length>>16), byte(length>>8), byte(length))
+	data = append(data, msg...)
+
+	var response []byte
+	for p1 := byte(ethP1FirstChunk); len(data) > 0; p1 = ethP1NextChunk {
+		chunk := ethMessageChunkSize
+		if len(data) < chunk {
+			chunk = len(data)
+		}
+		message := append([]byte{ethCLA, ethINSSignPersonalMsg, p1, 0, byte(chunk)}, data[:chunk]...)
+		data = data[chunk:]
+		if response, err = l.api.Exchange(message); err != nil {
+			return nil, err
+		}
+	}
+	if len(response) < 65 {
+		return nil, fmt.Errorf("invalid response")
+	}
+	return response[:65], nil
 }
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	ledgergo "github.com/zondax/ledger-cosmos-go"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// enumerateLedgersFn defines a function returning all the connected
+	// Ledger devices running the app. The devices are opened and must be
+	// closed by the caller.
+	enumerateLedgersFn func() ([]LedgerDevice, error)
+
+	// LedgerDevice is a connected Ledger device.
+	LedgerDevice struct {
+		// Serial is the USB serial number of the device, if known.
+		Serial string
+		Device LedgerSECP256K1
+	}
+
+	// LedgerDeviceInfo describes a connected Ledger device, see
+	// EnumerateLedgers.
+	LedgerDeviceInfo struct {
+		Index   int                  `json:"index"`
+		Serial  string               `json:"serial"`
+		Version ledgergo.VersionInfo `json:"version"`
+		// Address is the address of the default path m/44'/714'/0'/0/0, so
+		// users can tell their devices apart.
+		Address sdk.AccAddress `json:"address"`
+	}
+)
+
+var (
+	// enumerateLedgers defines a function to be invoked at runtime for
+	// enumerating the connected Ledger devices.
+	enumerateLedgers enumerateLedgersFn
+
+	// ledgerSelector, if set, is the index or the serial of the device new
+	// keys are created with, see SelectLedger.
+	ledgerSelectorMtx sync.RWMutex
+	ledgerSelector    string
+)
+
+// EnumerateLedgers returns all the connected Ledger devices, with their app
+// version and the address of their default path. The devices are released
+// before returning.
+func EnumerateLedgers() ([]LedgerDeviceInfo, error) {
+	devices, err := listLedgers()
+	if err != nil {
+		return nil, err
+	}
+	defer closeLedgers(devices)
+
+	infos := make([]LedgerDeviceInfo, len(devices))
+	for i, device := range devices {
+		version, err := device.Device.GetVersion()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the app version of Ledger %d", i)
+		}
+
+		pkl := PrivKeyLedgerSecp256k1{Path: DefaultBIP44Path(), ledger: device.Device}
+		pub, err := pkl.getPubKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the public key of Ledger %d", i)
+		}
+
+		infos[i] = LedgerDeviceInfo{
+			Index:   i,
+			Serial:  device.Serial,
+			Version: *version,
+			Address: sdk.AccAddress(pub.Address()),
+		}
+	}
+
+	return infos, nil
+}
+
+// SelectLedger selects the Ledger device keys are created and re-discovered
+// with, when several are connected. The selector is either the index of the
+// device as returned by EnumerateLedgers or its serial. An empty selector
+// restores the default, which uses the first device found.
+func SelectLedger(selector string) {
+	ledgerSelectorMtx.Lock()
+	defer ledgerSelectorMtx.Unlock()
+	ledgerSelector = selector
+}
+
+// ledgerDiscovery returns the function discovering the device selected with
+// SelectLedger, or discoverLedger if none is selected. It returns nil when no
+// discovery is possible.
+func ledgerDiscovery() discoverLedgerFn {
+	ledgerSelectorMtx.RLock()
+	selector := ledgerSelector
+	ledgerSelectorMtx.RUnlock()
+
+	if selector == "" {
+		return discoverLedger
+	}
+	if enumerateLedgers == nil {
+		return nil
+	}
+
+	enumerate := enumerateLedgers
+	return func() (LedgerSECP256K1, error) {
+		return selectLedger(enumerate, selector)
+	}
+}
+
+// selectLedger enumerates the connected devices and returns the one matching
+// selector, releasing the others.
+func selectLedger(enumerate enumerateLedgersFn, selector string) (LedgerSECP256K1, error) {
+	devices, err := enumerate()
+	if err != nil {
+		return nil, err
+	}
+
+	selected := -1
+	for i, device := range devices {
+		if device.Serial != "" && device.Serial == selector {
+			selected = i
+			break
+		}
+	}
+	if index, err := strconv.Atoi(selector); selected < 0 && err == nil && index >= 0 && index < len(devices) {
+		selected = index
+	}
+	if selected < 0 {
+		closeLedgers(devices)
+		return nil, fmt.Errorf("no Ledger device matches %q among the %d connected", selector, len(devices))
+	}
+
+	device := devices[selected].Device
+	closeLedgers(append(devices[:selected:selected], devices[selected+1:]...))
+	return device, nil
+}
+
+func listLedgers() ([]LedgerDevice, error) {
+	if enumerateLedgers == nil {
+		return nil, errors.New("no Ledger enumeration function defined")
+	}
+	return enumerateLedgers()
+}
+
+func closeLedgers(devices []LedgerDevice) {
+	for _, device := range devices {
+		_ = closeLedger(device.Device)
+	}
+}
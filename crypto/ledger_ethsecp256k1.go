@@ -0,0 +1,284 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	// ETHCoinType is the SLIP-0044 coin type registered for Ether, used by
+	// the Ethereum Ledger app and BSC compatible wallets.
+	ETHCoinType uint32 = 60
+
+	// PubKeyEthSecp256k1Size is the size of a compressed eth_secp256k1 key.
+	PubKeyEthSecp256k1Size = 33
+
+	// eip191Prefix prefixes personal messages before they are hashed, see
+	// https://eips.ethereum.org/EIPS/eip-191
+	eip191Prefix = "\x19Ethereum Signed Message:\n"
+)
+
+var (
+	// discoverLedgerETH defines a function to be invoked at runtime for
+	// discovering a connected Ledger device running the Ethereum app.
+	discoverLedgerETH discoverLedgerETHFn
+)
+
+type (
+	// discoverLedgerETHFn defines a discovery function for the Ethereum app.
+	discoverLedgerETHFn func() (LedgerETHSECP256K1, error)
+
+	// LedgerETHSECP256K1 reflects an interface the Ethereum app of a Ledger
+	// must implement for the eth_secp256k1 scheme.
+	LedgerETHSECP256K1 interface {
+		// GetPublicKeyETH returns the SEC encoded public key at path.
+		GetPublicKeyETH([]uint32) ([]byte, error)
+		// SignPersonalMessageETH signs msg as an EIP-191 personal message and
+		// returns the 65-byte signature as the app does, i.e. V||R||S.
+		SignPersonalMessageETH([]uint32, []byte) ([]byte, error)
+	}
+
+	// PubKeyEthSecp256k1 is a compressed secp256k1 public key whose address
+	// is derived the Ethereum way, i.e. the last 20 bytes of the Keccak-256
+	// hash of the uncompressed key.
+	PubKeyEthSecp256k1 [PubKeyEthSecp256k1Size]byte
+
+	// PrivKeyLedgerEthSecp256k1 implements PrivKey for keys held by the
+	// Ethereum app of a Ledger. Like PrivKeyLedgerSecp256k1 it caches the
+	// public key so it can be used without the device attached.
+	PrivKeyLedgerEthSecp256k1 struct {
+		CachedPubKey tmcrypto.PubKey
+		Path         DerivationPath
+
+		ledger LedgerETHSECP256K1
+	}
+)
+
+var _ tmcrypto.PubKey = PubKeyEthSecp256k1{}
+var _ tmcrypto.PrivKey = &PrivKeyLedgerEthSecp256k1{}
+
+// NewEthBIP44Path returns the standard Ethereum derivation path
+// m/44'/60'/account'/0/index.
+func NewEthBIP44Path(account, index uint32) DerivationPath {
+	return DerivationPath{
+		HardenedOffset + BIP44Purpose,
+		HardenedOffset + ETHCoinType,
+		HardenedOffset + account,
+		0,
+		index,
+	}
+}
+
+// Keccak256 returns the legacy Keccak-256 hash of bz, as used by Ethereum.
+func Keccak256(bz []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(bz)
+	return hasher.Sum(nil)
+}
+
+// EIP191Hash returns the hash signed for msg by Ethereum personal_sign.
+func EIP191Hash(msg []byte) []byte {
+	prefixed := make([]byte, 0, len(eip191Prefix)+20+len(msg))
+	prefixed = append(prefixed, eip191Prefix...)
+	prefixed = strconv.AppendInt(prefixed, int64(len(msg)), 10)
+	prefixed = append(prefixed, msg...)
+	return Keccak256(prefixed)
+}
+
+// Address returns the Ethereum address of the key, or an empty address if the
+// key is not a valid point of the curve.
+func (pubKey PubKeyEthSecp256k1) Address() tmcrypto.Address {
+	pub, err := btcec.ParsePubKey(pubKey[:])
+	if err != nil {
+		return tmcrypto.Address{}
+	}
+	return tmcrypto.Address(Keccak256(pub.SerializeUncompressed()[1:])[12:])
+}
+
+// Bytes returns the amino encoding of the key.
+func (pubKey PubKeyEthSecp256k1) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pubKey)
+}
+
+// VerifyBytes checks an EIP-191 personal message signature of msg. Both the
+// 64-byte R||S and the 65-byte R||S||V forms are accepted, S must be low.
+func (pubKey PubKeyEthSecp256k1) VerifyBytes(msg []byte, sig []byte) bool {
+	if len(sig) == 65 {
+		sig = sig[:64]
+	}
+	if len(sig) != 64 {
+		return false
+	}
+
+	var r, s btcec.ModNScalar
+	if r.SetByteSlice(sig[:32]) || r.IsZero() || s.SetByteSlice(sig[32:]) || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+	pub, err := btcec.ParsePubKey(pubKey[:])
+	if err != nil {
+		return false
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(EIP191Hash(msg), pub)
+}
+
+// Equals reports whether other is the same eth_secp256k1 key.
+func (pubKey PubKeyEthSecp256k1) Equals(other tmcrypto.PubKey) bool {
+	if otherEth, ok := other.(PubKeyEthSecp256k1); ok {
+		return bytes.Equal(pubKey[:], otherEth[:])
+	}
+	return false
+}
+
+func (pubKey PubKeyEthSecp256k1) String() string {
+	return fmt.Sprintf("PubKeyEthSecp256k1{%X}", pubKey[:])
+}
+
+// EthAddressHex formats an address with the EIP-55 mixed-case checksum.
+func EthAddressHex(address []byte) string {
+	lower := hex.EncodeToString(address)
+	hash := Keccak256([]byte(lower))
+
+	var sb strings.Builder
+	sb.WriteString("0x")
+	for i, c := range lower {
+		// a letter is upper-cased when the matching nibble of the hash is >= 8
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// NewPrivKeyLedgerEthSecp256k1 discovers the Ethereum app of a Ledger and
+// returns the key at path, caching its public key.
+func NewPrivKeyLedgerEthSecp256k1(path DerivationPath) (tmcrypto.PrivKey, error) {
+	if discoverLedgerETH == nil {
+		return nil, errors.New("no Ledger Ethereum app discovery function defined")
+	}
+
+	device, err := discoverLedgerETH()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create PrivKeyLedgerEthSecp256k1")
+	}
+
+	pkl := &PrivKeyLedgerEthSecp256k1{Path: path, ledger: device}
+	pubKey, err := pkl.getPubKey()
+	if err != nil {
+		_ = pkl.Close()
+		return nil, err
+	}
+
+	pkl.CachedPubKey = pubKey
+	return pkl, nil
+}
+
+// PubKey returns the cached public key.
+func (pkl PrivKeyLedgerEthSecp256k1) PubKey() tmcrypto.PubKey {
+	return pkl.CachedPubKey
+}
+
+// Close releases the device handle, if the device supports it. The key remains
+// usable, the device is discovered again by the next Sign.
+func (pkl *PrivKeyLedgerEthSecp256k1) Close() error {
+	device := pkl.ledger
+	pkl.ledger = nil
+	if closer, ok := device.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// EthAddress returns the checksummed Ethereum address of the cached key.
+func (pkl PrivKeyLedgerEthSecp256k1) EthAddress() string {
+	return EthAddressHex(pkl.CachedPubKey.Address())
+}
+
+// Bytes implements the PrivKey interface. Only the cached public key and the
+// path are encoded.
+func (pkl PrivKeyLedgerEthSecp256k1) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pkl)
+}
+
+// Equals implements the PrivKey interface. It makes sure two private keys
+// refer to the same public key.
+func (pkl PrivKeyLedgerEthSecp256k1) Equals(other tmcrypto.PrivKey) bool {
+	if ledger, ok := other.(*PrivKeyLedgerEthSecp256k1); ok {
+		return pkl.CachedPubKey.Equals(ledger.CachedPubKey)
+	}
+
+	return false
+}
+
+// Sign asks the device to sign msg as an EIP-191 personal message and returns
+// the 65-byte R||S||V signature. The signature is checked against the cached
+// key so a device holding another key is never trusted.
+func (pkl *PrivKeyLedgerEthSecp256k1) Sign(msg []byte) ([]byte, error) {
+	if pkl.ledger == nil {
+		if discoverLedgerETH == nil {
+			return nil, ErrLedgerNotConnected
+		}
+		device, err := discoverLedgerETH()
+		if err != nil {
+			return nil, errors.Wrapf(ErrLedgerNotConnected, "failed to discover Ledger (%v)", err)
+		}
+		pkl.ledger = device
+	}
+
+	vrs, err := pkl.ledger.SignPersonalMessageETH(pkl.Path, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(vrs) != 65 {
+		return nil, fmt.Errorf("unexpected signature length %d returned by the device, expected 65 bytes", len(vrs))
+	}
+	// the app returns V||R||S, Ethereum tooling expects R||S||V
+	sig := append(append(make([]byte, 0, 65), vrs[1:]...), vrs[0])
+	if !pkl.CachedPubKey.VerifyBytes(msg, sig) {
+		return nil, errors.New("signature returned by the device does not match the cached key")
+	}
+
+	return sig, nil
+}
+
+func (pkl PrivKeyLedgerEthSecp256k1) getPubKey() (tmcrypto.PubKey, error) {
+	if pkl.ledger == nil {
+		return nil, ErrLedgerNotConnected
+	}
+
+	key, err := pkl.ledger.GetPublicKeyETH(pkl.Path)
+	if err != nil {
+		return nil, fmt.Errorf("please open Ethereum app on the Ledger device - error: %v", err)
+	}
+	switch len(key) {
+	case pubKeyLenCompressed, pubKeyLenUncompressed:
+	default:
+		return nil, fmt.Errorf("unexpected public key length %d returned by the device, expected %d or %d bytes",
+			len(key), pubKeyLenCompressed, pubKeyLenUncompressed)
+	}
+
+	cmp, err := btcec.ParsePubKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %v", err)
+	}
+
+	var pk PubKeyEthSecp256k1
+	copy(pk[:], cmp.SerializeCompressed())
+	return pk, nil
+}
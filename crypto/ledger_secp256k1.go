@@ -2,10 +2,16 @@ package crypto
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/asn1"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
@@ -15,16 +21,58 @@ import (
 	tmbtcec "github.com/tendermint/btcd/btcec"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 	tmsecp256k1 "github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/bech32"
+	"github.com/tendermint/tendermint/libs/log"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+const (
+	// HardenedOffset is added to a BIP32 path component to mark it as hardened.
+	HardenedOffset uint32 = 0x80000000
+
+	// BIP44Purpose is the purpose component of BIP44 derivation paths.
+	BIP44Purpose uint32 = 44
+
+	// BNBCoinType is the SLIP-0044 coin type registered for BNB.
+	BNBCoinType uint32 = 714
+
+	// DefaultConfirmationMessage is the prompt Sign prints when the user has to
+	// confirm the address displayed on the device.
+	DefaultConfirmationMessage = "Please confirm if address displayed on ledger is identical to %s (yes/no)?"
+
+	// lengths of the SEC encoded public keys a device may return
+	pubKeyLenCompressed   = 33
+	pubKeyLenUncompressed = 65
+)
+
 var (
+	// ErrGasLimitTooHigh is returned when a sign doc requests more gas than the
+	// MaxGasGuard of the key allows.
+	ErrGasLimitTooHigh = errors.New("gas limit exceeds the configured maximum")
+
+	// ErrLedgerNotConnected is returned when a key has no device handle, e.g.
+	// because it was decoded offline, and no device could be discovered.
+	ErrLedgerNotConnected = errors.New("Ledger device not connected")
+
 	// discoverLedger defines a function to be invoked at runtime for discovering
 	// a connected Ledger device.
 	discoverLedger discoverLedgerFn
 )
 
+// Policies confirming the address of a key before Sign.
+const (
+	// ConfirmInteractive displays the address on the device, then asks
+	// ConfirmFn, or the user on stdin if none is set, whether it matches.
+	ConfirmInteractive ConfirmPolicy = iota
+	// ConfirmAuto signs without displaying the address, for automation that
+	// has verified the key by other means.
+	ConfirmAuto
+	// ConfirmFail refuses to sign without engaging the device, for signers
+	// which cannot have the address confirmed.
+	ConfirmFail
+)
+
 type (
 	// discoverLedgerFn defines a Ledger discovery function that returns a
 	// connected device or an error upon failure. Its allows a method to avoid CGO
@@ -43,37 +91,291 @@ type (
 		GetVersion() (*ledgergo.VersionInfo, error)
 	}
 
+	// ConfirmFn is called once the address has been displayed on the device.
+	// It returns whether the user confirmed that it matches the address in
+	// prompt, an error aborts signing.
+	ConfirmFn func(prompt string) (bool, error)
+
+	// ConfirmPolicy decides how Sign has the address of the key confirmed
+	// when the device app requires it. It is applied before the address is
+	// displayed on the device.
+	ConfirmPolicy int
+
+	// EventHandler receives the progress of operations that involve the
+	// Ledger device. Calls are made synchronously from the signing goroutine
+	// and should return promptly.
+	EventHandler interface {
+		// OnWaitingForDevice is called before the device is contacted.
+		OnWaitingForDevice()
+		// OnAwaitingConfirmation is called when the user has to confirm the
+		// transaction on the device.
+		OnAwaitingConfirmation()
+		// OnSigned is called once the device has returned a signature.
+		OnSigned()
+	}
+
 	// PrivKeyLedgerSecp256k1 implements PrivKey, calling the ledger nano we
 	// cache the PubKey from the first call to use it later.
+	//
+	// Only CachedPubKey, Path and DeviceType are persisted, the other
+	// exported fields are runtime options of Sign.
 	PrivKeyLedgerSecp256k1 struct {
 		// CachedPubKey should be private, but we want to encode it via
 		// go-amino so we can view the address later, even without having the
 		// ledger attached.
 		CachedPubKey tmcrypto.PubKey
 		Path         DerivationPath
-		ledger       LedgerSECP256K1
+
+		// MaxGasGuard, if non-zero, is the highest gas limit a sign doc may
+		// request before Sign refuses it without engaging the device.
+		MaxGasGuard uint64 `json:"-"`
+
+		// EventHandler, if set, is notified of the progress of Sign so a UI
+		// can tell the user when to interact with the device.
+		EventHandler EventHandler `json:"-"`
+
+		// AddressHRP, if set, is the bech32 prefix of the address the device
+		// displays for confirmation in Sign, and of the address printed in
+		// the prompt. It defaults to the account prefix of the sdk config.
+		AddressHRP string `json:"-"`
+
+		// ConfirmationMessage, if set, replaces DefaultConfirmationMessage. It
+		// must contain a single %s verb, which is replaced with the address
+		// displayed on the device.
+		ConfirmationMessage string `json:"-"`
+
+		// ConfirmFn, if set, is asked whether the address displayed on the
+		// device matches the prompt instead of reading a yes/no answer from
+		// stdin, which lets Sign run without a terminal.
+		ConfirmFn ConfirmFn `json:"-"`
+
+		// ConfirmPolicy selects how the address is confirmed, ConfirmInteractive
+		// by default.
+		ConfirmPolicy ConfirmPolicy `json:"-"`
+
+		// PreviewWriter, if set, receives a summary of the sign doc rendered
+		// with RenderSignDoc before Sign engages the device, so the user can
+		// check it against what the device shows. Bytes which are not a sign
+		// doc are previewed hex encoded.
+		PreviewWriter io.Writer `json:"-"`
+
+		// Logger, if set, logs the progress of Sign.
+		Logger log.Logger `json:"-"`
+
+		// DeviceType is the hardware signer backend holding the key, see
+		// RegisterHWSignerBackend. It is empty for Ledger keys, which
+		// re-discover their device through discoverLedger.
+		DeviceType string `json:",omitempty"`
+
+		ledger LedgerSECP256K1
 	}
 )
 
+// Validate checks that the path is a BIP44 path the Ledger app can derive,
+// i.e. m/44'/714'/account'/change/index. The purpose, coin type and account
+// components may be given with or without the hardened offset, as the device
+// hardens them anyway.
+func (path DerivationPath) Validate() error {
+	if len(path) != 5 {
+		return fmt.Errorf("invalid derivation path length %d, expected 5 components", len(path))
+	}
+	if purpose := path[0] &^ HardenedOffset; purpose != BIP44Purpose {
+		return fmt.Errorf("invalid derivation path purpose %d, expected %d", purpose, BIP44Purpose)
+	}
+	if coinType := path[1] &^ HardenedOffset; coinType != BNBCoinType {
+		return fmt.Errorf("invalid derivation path coin type %d, expected %d", coinType, BNBCoinType)
+	}
+	if change := path[3]; change != 0 && change != 1 {
+		return fmt.Errorf("invalid derivation path change component %d, expected 0 or 1", change)
+	}
+	if path[4]&HardenedOffset != 0 {
+		return fmt.Errorf("invalid derivation path address index %d, it must not be hardened", path[4])
+	}
+
+	return nil
+}
+
+// String returns the path in the m/44'/714'/0'/0/0 notation, with hardened
+// components suffixed by an apostrophe.
+func (path DerivationPath) String() string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, component := range path {
+		sb.WriteString("/")
+		sb.WriteString(strconv.FormatUint(uint64(component&^HardenedOffset), 10))
+		if component&HardenedOffset != 0 {
+			sb.WriteString("'")
+		}
+	}
+	return sb.String()
+}
+
+// ParseDerivationPath parses a path in the notation returned by String. The
+// leading "m/" is optional and hardened components may be suffixed by either
+// an apostrophe or "h".
+func ParseDerivationPath(str string) (DerivationPath, error) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "m/")
+	if str == "" || str == "m" {
+		return nil, fmt.Errorf("empty derivation path")
+	}
+
+	components := strings.Split(str, "/")
+	path := make(DerivationPath, len(components))
+	for i, component := range components {
+		var hardened bool
+		if strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h") {
+			hardened = true
+			component = component[:len(component)-1]
+		}
+
+		value, err := strconv.ParseUint(component, 10, 32)
+		if err != nil || uint32(value) >= HardenedOffset {
+			return nil, fmt.Errorf("invalid component %q in derivation path %q", components[i], str)
+		}
+		path[i] = uint32(value)
+		if hardened {
+			path[i] += HardenedOffset
+		}
+	}
+
+	return path, nil
+}
+
+// MarshalJSON encodes the path as a string in the notation returned by String.
+func (path DerivationPath) MarshalJSON() ([]byte, error) {
+	if path == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(path.String())
+}
+
+// UnmarshalJSON decodes a path encoded with MarshalJSON. The numeric array the
+// path used to be encoded as is still accepted.
+func (path *DerivationPath) UnmarshalJSON(bz []byte) error {
+	bz = bytes.TrimSpace(bz)
+	switch {
+	case bytes.Equal(bz, []byte("null")):
+		*path = nil
+		return nil
+	case len(bz) > 0 && bz[0] == '[':
+		var components []uint32
+		if err := json.Unmarshal(bz, &components); err != nil {
+			return err
+		}
+		*path = components
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(bz, &str); err != nil {
+		return err
+	}
+	parsed, err := ParseDerivationPath(str)
+	if err != nil {
+		return err
+	}
+	*path = parsed
+	return nil
+}
+
+// NewBIP44Path returns the standard BNB derivation path
+// m/44'/714'/account'/0/index, with the purpose, coin type and account
+// components hardened. The account and the index must be below the hardened
+// offset, the account would overflow into another hardened account otherwise.
+func NewBIP44Path(account, index uint32) (DerivationPath, error) {
+	if account >= HardenedOffset {
+		return nil, fmt.Errorf("invalid derivation path account %d, it must be below %d", account, HardenedOffset)
+	}
+	if index >= HardenedOffset {
+		return nil, fmt.Errorf("invalid derivation path address index %d, it must be below %d", index, HardenedOffset)
+	}
+
+	return DerivationPath{
+		HardenedOffset + BIP44Purpose,
+		HardenedOffset + BNBCoinType,
+		HardenedOffset + account,
+		0,
+		index,
+	}, nil
+}
+
+// DefaultBIP44Path returns the derivation path of the first BNB address,
+// m/44'/714'/0'/0/0.
+func DefaultBIP44Path() DerivationPath {
+	return DerivationPath{HardenedOffset + BIP44Purpose, HardenedOffset + BNBCoinType, HardenedOffset, 0, 0}
+}
+
 // NewPrivKeyLedgerSecp256k1 will generate a new key and store the public key
 // for later use.
 //
 // CONTRACT: The ledger device, ledgerDevice, must be loaded and set prior to
 // any creation of a PrivKeyLedgerSecp256k1.
 func NewPrivKeyLedgerSecp256k1(path DerivationPath) (tmcrypto.PrivKey, error) {
-	if discoverLedger == nil {
+	discover := ledgerDiscovery()
+	if discover == nil {
 		return nil, errors.New("no Ledger discovery function defined")
 	}
 
-	device, err := discoverLedger()
+	device, err := discover()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create PrivKeyLedgerSecp256k1")
 	}
 
+	return newPrivKeyLedgerWithDevice(path, device)
+}
+
+// NewPrivKeyLedgerSecp256k1WithTimeout behaves as NewPrivKeyLedgerSecp256k1
+// but gives up if the device could not be discovered within timeout. A device
+// discovered after the timeout has expired is closed so its handle is not
+// leaked.
+func NewPrivKeyLedgerSecp256k1WithTimeout(path DerivationPath, timeout time.Duration) (tmcrypto.PrivKey, error) {
+	discover := ledgerDiscovery()
+	if discover == nil {
+		return nil, errors.New("no Ledger discovery function defined")
+	}
+
+	type discovery struct {
+		device LedgerSECP256K1
+		err    error
+	}
+
+	// buffered so the discovery goroutine never blocks on an abandoned result
+	result := make(chan discovery, 1)
+	go func() {
+		device, err := discover()
+		result <- discovery{device, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-result:
+		if res.err != nil {
+			return nil, errors.Wrap(res.err, "failed to create PrivKeyLedgerSecp256k1")
+		}
+		return newPrivKeyLedgerWithDevice(path, res.device)
+
+	case <-timer.C:
+		// release the device should discovery still succeed
+		go func() {
+			if res := <-result; res.err == nil {
+				_ = closeLedger(res.device)
+			}
+		}()
+		return nil, fmt.Errorf("failed to create PrivKeyLedgerSecp256k1: no Ledger device found within %v", timeout)
+	}
+}
+
+// newPrivKeyLedgerWithDevice creates a key at path with the given device and
+// caches its public key. The device is closed if its public key cannot be
+// read, as no key holds it then.
+func newPrivKeyLedgerWithDevice(path DerivationPath, device LedgerSECP256K1) (tmcrypto.PrivKey, error) {
 	pkl := &PrivKeyLedgerSecp256k1{Path: path, ledger: device}
 
 	pubKey, err := pkl.getPubKey()
 	if err != nil {
+		_ = closeLedger(device)
 		return nil, err
 	}
 
@@ -86,9 +388,37 @@ func (pkl PrivKeyLedgerSecp256k1) PubKey() tmcrypto.PubKey {
 	return pkl.CachedPubKey
 }
 
+// AccAddress returns the account address of the cached public key. It does
+// not need the device.
+func (pkl PrivKeyLedgerSecp256k1) AccAddress() sdk.AccAddress {
+	return sdk.AccAddress(pkl.CachedPubKey.Address())
+}
+
+// Close releases the device handle, if the device supports it, so other
+// processes can use the Ledger. The key remains usable: its offline methods
+// rely on the cached public key and the next operation involving the device
+// discovers it again.
+func (pkl *PrivKeyLedgerSecp256k1) Close() error {
+	device := pkl.ledger
+	pkl.ledger = nil
+	return closeLedger(device)
+}
+
+// closeLedger closes device if it implements io.Closer.
+func closeLedger(device LedgerSECP256K1) error {
+	if closer, ok := device.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // ValidateKey allows us to verify the sanity of a public key after loading it
 // from disk.
 func (pkl PrivKeyLedgerSecp256k1) ValidateKey() error {
+	if pkl.ledger == nil {
+		return ErrLedgerNotConnected
+	}
+
 	// getPubKey will return an error if the ledger is not
 	pub, err := pkl.getPubKey()
 	if err != nil {
@@ -107,11 +437,30 @@ func (pkl PrivKeyLedgerSecp256k1) ValidateKey() error {
 func (pkl *PrivKeyLedgerSecp256k1) AssertIsPrivKeyInner() {}
 
 // Bytes implements the PrivKey interface. It stores the cached public key so
-// we can verify the same key when we reconnect to a ledger.
+// we can verify the same key when we reconnect to a ledger. Only the cached
+// public key and the path are encoded, runtime state such as the device handle
+// or MaxGasGuard is not, so the encoding of a key never changes.
 func (pkl PrivKeyLedgerSecp256k1) Bytes() []byte {
 	return cdc.MustMarshalBinaryBare(pkl)
 }
 
+// PrivKeyLedgerSecp256k1FromBytes decodes a key encoded with Bytes. No device
+// is needed, it is discovered on first use of the key.
+func PrivKeyLedgerSecp256k1FromBytes(bz []byte) (*PrivKeyLedgerSecp256k1, error) {
+	pkl := new(PrivKeyLedgerSecp256k1)
+	if err := cdc.UnmarshalBinaryBare(bz, pkl); err != nil {
+		return nil, errors.Wrap(err, "failed to decode PrivKeyLedgerSecp256k1")
+	}
+	if pkl.CachedPubKey == nil {
+		return nil, errors.New("decoded PrivKeyLedgerSecp256k1 has no cached public key")
+	}
+	if err := pkl.Path.Validate(); err != nil {
+		return nil, err
+	}
+
+	return pkl, nil
+}
+
 // Equals implements the PrivKey interface. It makes sure two private keys
 // refer to the same public key.
 func (pkl PrivKeyLedgerSecp256k1) Equals(other tmcrypto.PrivKey) bool {
@@ -126,54 +475,533 @@ func (pkl PrivKeyLedgerSecp256k1) Equals(other tmcrypto.PrivKey) bool {
 //
 // Communication is checked on NewPrivKeyLedger and PrivKeyFromBytes, returning
 // an error, so this should only trigger if the private key is held in memory
-// for a while before use.
-func (pkl PrivKeyLedgerSecp256k1) Sign(msg []byte) ([]byte, error) {
-	ledgerAppVersion, err := pkl.ledger.GetVersion()
+// for a while before use. A transient transport error causes the device to be
+// re-discovered once, see withLedger.
+func (pkl *PrivKeyLedgerSecp256k1) Sign(msg []byte) ([]byte, error) {
+	if err := pkl.checkGasLimit(msg); err != nil {
+		return nil, err
+	}
+	if err := pkl.writePreview(msg); err != nil {
+		return nil, err
+	}
+
+	events, logger := pkl.events(), pkl.logger()
+	events.OnWaitingForDevice()
+	logger.Debug("Waiting for the Ledger device", "path", pkl.Path.String())
+
+	requiresConfirmation, err := pkl.RequiresAddressConfirmation()
 	if err != nil {
 		return nil, err
 	}
-	if ledgerAppVersion.Major > 1 || ledgerAppVersion.Major == 1 && ledgerAppVersion.Minor >= 1 {
-		fmt.Print(fmt.Sprintf("Please confirm if address displayed on ledger is identical to %s (yes/no)?", sdk.AccAddress(pkl.CachedPubKey.Address()).String()))
-		err = pkl.ledger.ShowAddressSECP256K1(pkl.Path, sdk.GetConfig().GetBech32AccountAddrPrefix())
-		if err != nil {
+	if requiresConfirmation {
+		if err := pkl.confirmAddress(); err != nil {
 			return nil, err
 		}
+	}
+	events.OnAwaitingConfirmation()
+	logger.Info("Confirm the transaction on the Ledger device")
+
+	var sig []byte
+	err = pkl.withLedger(func(device LedgerSECP256K1) (err error) {
+		sig, err = device.SignSECP256K1(pkl.Path, msg)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	events.OnSigned()
+	logger.Debug("Signed with the Ledger device", "path", pkl.Path.String())
+
+	return convertDERtoBER(sig)
+}
+
+// confirmAddress has the address of the key confirmed following ConfirmPolicy.
+// The policy is applied before the address is displayed on the device, so
+// ConfirmFail never engages it.
+func (pkl *PrivKeyLedgerSecp256k1) confirmAddress() error {
+	switch pkl.ConfirmPolicy {
+	case ConfirmAuto:
+		return nil
+	case ConfirmFail:
+		return errors.New("the Ledger app requires the address to be confirmed, which is not possible without a terminal")
+	}
+
+	hrp, prompt, err := pkl.addressConfirmation()
+	if err != nil {
+		return err
+	}
+
+	confirm := pkl.ConfirmFn
+	if confirm == nil {
+		confirm = readStdinConfirmation
+	}
+
+	pkl.logger().Debug("Displaying the address on the Ledger device", "hrp", hrp)
+	err = pkl.withLedger(func(device LedgerSECP256K1) error {
+		return device.ShowAddressSECP256K1(pkl.Path, hrp)
+	})
+	if err != nil {
+		return err
+	}
+
+	confirmed, err := confirm(prompt)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		pkl.logger().Error("The address displayed on the Ledger device was not confirmed")
+		return fmt.Errorf("ledger account doesn't match")
+	}
+	return nil
+}
+
+// readStdinConfirmation prints prompt to stderr, keeping stdout for the
+// output of the commands, and reads a yes/no answer from stdin.
+func readStdinConfirmation(prompt string) (bool, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	buf, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	confirm := strings.ToLower(strings.TrimSpace(buf))
+	return confirm == "y" || confirm == "yes", nil
+}
+
+// addressConfirmation returns the bech32 prefix of the address to display on
+// the device and the prompt asking the user to confirm it. Both use the same
+// prefix so the printed address is exactly the one the device shows.
+func (pkl PrivKeyLedgerSecp256k1) addressConfirmation() (hrp string, prompt string, err error) {
+	hrp = pkl.AddressHRP
+	if hrp == "" {
+		hrp = sdk.GetConfig().GetBech32AccountAddrPrefix()
+	}
+	address, err := bech32.ConvertAndEncode(hrp, pkl.CachedPubKey.Address().Bytes())
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid address prefix %q", hrp)
+	}
+
+	message := pkl.ConfirmationMessage
+	if message == "" {
+		message = DefaultConfirmationMessage
+	}
+	if strings.Count(message, "%s") != 1 || strings.Count(message, "%") != 1 {
+		return "", "", fmt.Errorf("confirmation message %q must contain a single %%s verb", message)
+	}
+
+	return hrp, fmt.Sprintf(message, address), nil
+}
+
+// events returns the EventHandler of the key, or a no-op one if none is set.
+func (pkl PrivKeyLedgerSecp256k1) events() EventHandler {
+	if pkl.EventHandler == nil {
+		return nopEventHandler{}
+	}
+	return pkl.EventHandler
+}
 
-		buf, err := bufio.NewReader(os.Stdin).ReadString('\n')
+func (pkl PrivKeyLedgerSecp256k1) logger() log.Logger {
+	if pkl.Logger == nil {
+		return log.NewNopLogger()
+	}
+	return pkl.Logger.With("module", "ledger")
+}
+
+// nopEventHandler is the EventHandler used when none is set.
+type nopEventHandler struct{}
+
+func (nopEventHandler) OnWaitingForDevice()     {}
+func (nopEventHandler) OnAwaitingConfirmation() {}
+func (nopEventHandler) OnSigned()               {}
+
+// NewWriterEventHandler returns an EventHandler telling the user on w when to
+// act on the device, for command line tools.
+func NewWriterEventHandler(w io.Writer) EventHandler {
+	return writerEventHandler{w: w}
+}
+
+type writerEventHandler struct {
+	w io.Writer
+}
+
+func (writerEventHandler) OnWaitingForDevice() {}
+
+func (h writerEventHandler) OnAwaitingConfirmation() {
+	fmt.Fprintln(h.w, "Please verify the transaction data on ledger")
+}
+
+func (writerEventHandler) OnSigned() {}
+
+// writePreview writes the rendered sign doc to PreviewWriter, if set. Bytes
+// which are not a sign doc are written hex encoded, so the preview never
+// prevents signing nor writes raw binary to a terminal.
+func (pkl PrivKeyLedgerSecp256k1) writePreview(msg []byte) error {
+	if pkl.PreviewWriter == nil {
+		return nil
+	}
+
+	preview, err := RenderSignDoc(msg)
+	if err != nil {
+		preview = fmt.Sprintf("Bytes to sign:\n%X\n", msg)
+	}
+	_, err = io.WriteString(pkl.PreviewWriter, preview)
+	return err
+}
+
+// RequiresAddressConfirmation reports whether Sign will ask the user to confirm
+// the address displayed on the device. For a Ledger it depends on the version
+// of the connected app, other backends decide through HWSignerAddressConfirmer.
+func (pkl *PrivKeyLedgerSecp256k1) RequiresAddressConfirmation() (required bool, err error) {
+	err = pkl.withLedger(func(device LedgerSECP256K1) (err error) {
+		if confirmer, ok := device.(interface {
+			requiresAddressConfirmation() (bool, error)
+		}); ok {
+			required, err = confirmer.requiresAddressConfirmation()
+			return err
+		}
+		required, err = ledgerRequiresAddressConfirmation(device)
+		return err
+	})
+	return required, err
+}
+
+// ledgerRequiresAddressConfirmation reports whether the app running on the
+// Ledger requires the address to be confirmed before signing.
+func ledgerRequiresAddressConfirmation(device LedgerSECP256K1) (bool, error) {
+	version, err := device.GetVersion()
+	if err != nil {
+		return false, err
+	}
+	return appRequiresAddressConfirmation(version), nil
+}
+
+// appRequiresAddressConfirmation reports whether the app version requires the
+// address to be confirmed on the device before signing, i.e. app >= 1.1.
+func appRequiresAddressConfirmation(version *ledgergo.VersionInfo) bool {
+	return version.Major > 1 || version.Major == 1 && version.Minor >= 1
+}
+
+// checkGasLimit enforces MaxGasGuard against the gas limit of the fee in the
+// sign doc. Sign docs without a fee, like the ones of BNB Beacon Chain, carry
+// no gas limit and pass.
+func (pkl PrivKeyLedgerSecp256k1) checkGasLimit(msg []byte) error {
+	if pkl.MaxGasGuard == 0 {
+		return nil
+	}
+
+	var doc struct {
+		Fee *struct {
+			Gas json.Number `json:"gas"`
+		} `json:"fee"`
+	}
+	if err := json.Unmarshal(msg, &doc); err != nil {
+		return fmt.Errorf("cannot enforce gas limit on sign doc: %v", err)
+	}
+	if doc.Fee == nil || doc.Fee.Gas == "" {
+		return nil
+	}
+
+	gas, err := strconv.ParseUint(doc.Fee.Gas.String(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid gas limit %q in sign doc: %v", doc.Fee.Gas, err)
+	}
+	if gas > pkl.MaxGasGuard {
+		return errors.Wrapf(ErrGasLimitTooHigh, "requested %d, maximum %d", gas, pkl.MaxGasGuard)
+	}
+
+	return nil
+}
+
+// SignWithDualConfirmation signs msg only if both the device and a caller
+// supplied second factor approve it. The device is asked first; the signature
+// is then released only if codeVerifier reports success, otherwise it is wiped
+// and an error is returned.
+func (pkl *PrivKeyLedgerSecp256k1) SignWithDualConfirmation(msg []byte, codeVerifier func() (bool, error)) ([]byte, error) {
+	if codeVerifier == nil {
+		return nil, errors.New("no second factor verifier provided")
+	}
+
+	sig, err := pkl.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := codeVerifier()
+	if err != nil || !ok {
+		for i := range sig {
+			sig[i] = 0
+		}
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "second factor verification failed")
 		}
-		confirm := strings.ToLower(strings.TrimSpace(buf))
-		if confirm != "y" && confirm != "yes" {
-			return nil, fmt.Errorf("ledger account doesn't match")
+		return nil, errors.New("second factor verification rejected")
+	}
+
+	return sig, nil
+}
+
+// withLedger invokes fn with the cached device handle, discovering the device
+// first if no handle is cached. If fn fails with a transport error the handle
+// is considered dead: it is dropped, the device is re-discovered once and fn is
+// retried against the re-acquired handle. Status words returned by the app
+// itself (e.g. a rejection on the device) are never retried.
+func (pkl *PrivKeyLedgerSecp256k1) withLedger(fn func(LedgerSECP256K1) error) error {
+	// keys loaded offline discover their device on first use
+	if pkl.ledger == nil {
+		if err := pkl.reconnect(); err != nil {
+			return err
 		}
+		return fn(pkl.ledger)
+	}
+
+	err := fn(pkl.ledger)
+	if err == nil || !isLedgerTransportError(err) {
+		return err
 	}
-	fmt.Println("Please verify the transaction data on ledger")
 
-	sig, err := pkl.signLedgerSecp256k1(msg)
+	if rerr := pkl.reconnect(); rerr != nil {
+		return errors.Wrapf(err, "failed to reconnect to Ledger (%v)", rerr)
+	}
+
+	return fn(pkl.ledger)
+}
+
+// reconnect drops the cached device handle and discovers the device again. The
+// re-acquired device must hold the cached public key, otherwise it is rejected
+// so we never sign with a different key than the one we were created with.
+func (pkl *PrivKeyLedgerSecp256k1) reconnect() error {
+	// release the stale handle first, the device can only be opened once
+	_ = pkl.Close()
+	discover := ledgerDiscovery()
+	if pkl.DeviceType != "" && pkl.DeviceType != LedgerDeviceType {
+		discover = hwSignerDiscovery(pkl.DeviceType)
+	}
+	if discover == nil {
+		return errors.Wrap(ErrLedgerNotConnected, "no Ledger discovery function defined")
+	}
+
+	device, err := discover()
 	if err != nil {
+		return errors.Wrapf(ErrLedgerNotConnected, "failed to discover Ledger (%v)", err)
+	}
+
+	candidate := PrivKeyLedgerSecp256k1{CachedPubKey: pkl.CachedPubKey, Path: pkl.Path, ledger: device}
+	if err := candidate.ValidateKey(); err != nil {
+		_ = closeLedger(device)
+		return err
+	}
+
+	pkl.ledger = device
+	return nil
+}
+
+// ledgerTransportErrors are the prefixes of the errors raised by the HID
+// layer, github.com/zondax/hid, and by the APDU framing of
+// github.com/cosmos/ledger-go when the device stops answering.
+var ledgerTransportErrors = []string{
+	"hid: ",
+	"hidapi: ",
+	"Invalid channel",
+	"Invalid tag",
+	"Wrong sequenceIdx",
+	"Cannot deserialize the packet",
+	"len(response) < 2",
+}
+
+// isLedgerTransportError reports whether err was raised while talking to the
+// device. Errors of the app, e.g. an APDU status word or an invalid argument,
+// are not: reconnecting would not help.
+func isLedgerTransportError(err error) bool {
+	msg := err.Error()
+	for _, prefix := range ledgerTransportErrors {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditNonceDeterminism verifies that the device derives its signing nonces
+// deterministically as described in RFC 6979. The same message is signed twice
+// and must yield identical signatures, then a variant of the message (with an
+// amended memo) is signed and must yield a different nonce point R. Any
+// anomaly is reported as an error, as it may indicate a key-reuse attack.
+func (pkl *PrivKeyLedgerSecp256k1) AuditNonceDeterminism(msg []byte) error {
+	variant, err := nonceAuditVariant(msg)
+	if err != nil {
+		return err
+	}
+
+	sign := func(msg []byte) (sig []byte, err error) {
+		err = pkl.withLedger(func(device LedgerSECP256K1) error {
+			sig, err = device.SignSECP256K1(pkl.Path, msg)
+			return err
+		})
+		return sig, err
+	}
+
+	first, err := sign(msg)
+	if err != nil {
+		return err
+	}
+	second, err := sign(msg)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("nonce audit failed: signing the same message twice produced different signatures")
+	}
+
+	other, err := sign(variant)
+	if err != nil {
+		return err
+	}
+
+	r1, err := signatureNonceR(first)
+	if err != nil {
+		return err
+	}
+	r2, err := signatureNonceR(other)
+	if err != nil {
+		return err
+	}
+	if r1.Cmp(r2) == 0 {
+		return fmt.Errorf("nonce audit failed: different messages were signed with the same nonce")
+	}
+
+	return nil
+}
+
+// nonceAuditVariant returns a sign doc which differs from msg only by its memo.
+// The device only signs well formed sign docs, so the variant is re-encoded as
+// sorted JSON like the original. Numbers are decoded as json.Number so they
+// are re-encoded as is.
+func nonceAuditVariant(msg []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(msg))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("nonce audit requires a JSON sign doc: %v", err)
+	}
+
+	memo, _ := doc["memo"].(string)
+	doc["memo"] = memo + " (nonce audit)"
+
+	return json.Marshal(doc)
+}
+
+// signatureNonceR extracts the R component of a DER encoded signature, which
+// is the x coordinate of the nonce point used to produce it.
+func signatureNonceR(signatureDER []byte) (*big.Int, error) {
+	var sig struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(signatureDER, &sig); err != nil {
+		return nil, fmt.Errorf("error parsing signature: %v", err)
+	}
+
+	return sig.R, nil
+}
+
+// SignatureToCompact converts an ASN.1 encoded secp256k1 signature, as
+// returned by the device, into the fixed-width 64-byte R||S form, with both
+// components left-padded with zeros. A high S is flipped to N-S so the result
+// is always in the canonical low-S form. This is the form Sign returns.
+func SignatureToCompact(ber []byte) ([]byte, error) {
+	var sig struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(ber, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing signature: %v", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error parsing signature: %d trailing bytes", len(rest))
+	}
+	if err := validateSignatureScalars(sig.R, sig.S); err != nil {
 		return nil, err
 	}
 
-	return convertDERtoBER(sig)
+	// Serialize normalizes S and pads both components to 32 bytes
+	return (&tmbtcec.Signature{R: sig.R, S: sig.S}).Serialize(), nil
 }
 
+// CompactToBER is the inverse of SignatureToCompact, it converts a 64-byte
+// R||S signature into its ASN.1 encoding. S is normalized to the low-S form.
+func CompactToBER(compact []byte) ([]byte, error) {
+	if len(compact) != 64 {
+		return nil, fmt.Errorf("invalid compact signature length %d, expected 64 bytes", len(compact))
+	}
+
+	r := new(big.Int).SetBytes(compact[:32])
+	s := new(big.Int).SetBytes(compact[32:])
+	if err := validateSignatureScalars(r, s); err != nil {
+		return nil, err
+	}
+	if order := tmbtcec.S256().N; s.Cmp(new(big.Int).Rsh(order, 1)) > 0 {
+		s.Sub(order, s)
+	}
+
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+// validateSignatureScalars checks that R and S are within [1, N-1].
+func validateSignatureScalars(r, s *big.Int) error {
+	order := tmbtcec.S256().N
+	if r.Sign() <= 0 || r.Cmp(order) >= 0 {
+		return fmt.Errorf("signature R is out of range")
+	}
+	if s.Sign() <= 0 || s.Cmp(order) >= 0 {
+		return fmt.Errorf("signature S is out of range")
+	}
+	return nil
+}
+
+// convertDERtoBER converts a DER signature of the device into the 64-byte
+// R||S form with a low S that tendermint secp256k1 keys verify. It is on the
+// hot path of batch signing, so R and S are read straight from the validated
+// DER bytes and only the output is allocated besides the parsing itself.
 func convertDERtoBER(signatureDER []byte) ([]byte, error) {
-	sigDER, err := ecdsa.ParseDERSignature(signatureDER[:])
-	if err != nil {
+	// validates the encoding and the ranges of R and S
+	if _, err := ecdsa.ParseDERSignature(signatureDER); err != nil {
 		return nil, err
 	}
-	sig := sigDER.Serialize() // 0x30 <total length> 0x02 <length of R> <R> 0x02 <length of S> <S>
-	r := new(big.Int).SetBytes(sig[4:36])
-	s := new(big.Int).SetBytes(sig[38:70])
-	sigBER := tmbtcec.Signature{R: r, S: s}
-	return sigBER.Serialize(), nil
+	// the parser ignores the bytes past the total length, which would let the
+	// same signature have several encodings
+	if len(signatureDER) != int(signatureDER[1])+2 {
+		return nil, fmt.Errorf("malformed signature: %d trailing bytes", len(signatureDER)-int(signatureDER[1])-2)
+	}
+
+	// 0x30 <total length> 0x02 <length of R> <R> 0x02 <length of S> <S>
+	rLen := int(signatureDER[3])
+	rBytes := signatureDER[4 : 4+rLen]
+	sLen := int(signatureDER[5+rLen])
+	sBytes := signatureDER[6+rLen : 6+rLen+sLen]
+
+	var r, s btcec.ModNScalar
+	r.SetByteSlice(trimLeadingZeros(rBytes))
+	s.SetByteSlice(trimLeadingZeros(sBytes))
+	// low 'S' malleability breaker
+	if s.IsOverHalfOrder() {
+		s.Negate()
+	}
+
+	sigBER := make([]byte, 64)
+	r.PutBytesUnchecked(sigBER[:32])
+	s.PutBytesUnchecked(sigBER[32:])
+	return sigBER, nil
+}
+
+func trimLeadingZeros(bz []byte) []byte {
+	for len(bz) > 0 && bz[0] == 0x00 {
+		bz = bz[1:]
+	}
+	return bz
 }
 
 // getPubKey reads the pubkey the ledger itself
 // since this involves IO, it may return an error, which is not exposed
 // in the PubKey interface, so this function allows better error handling
 func (pkl PrivKeyLedgerSecp256k1) getPubKey() (key tmcrypto.PubKey, err error) {
+	if pkl.ledger == nil {
+		return nil, ErrLedgerNotConnected
+	}
+
 	key, err = pkl.pubkeyLedgerSecp256k1()
 	if err != nil {
 		return key, fmt.Errorf("please open Cosmos app on the Ledger device - error: %v", err)
@@ -182,18 +1010,23 @@ func (pkl PrivKeyLedgerSecp256k1) getPubKey() (key tmcrypto.PubKey, err error) {
 	return key, err
 }
 
-func (pkl PrivKeyLedgerSecp256k1) signLedgerSecp256k1(msg []byte) ([]byte, error) {
-	return pkl.ledger.SignSECP256K1(pkl.Path, msg)
-}
-
 func (pkl PrivKeyLedgerSecp256k1) pubkeyLedgerSecp256k1() (pub tmcrypto.PubKey, err error) {
 	key, err := pkl.ledger.GetPublicKeySECP256K1(pkl.Path)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching public key: %v", err)
 	}
 
+	// the device must return exactly one compressed or uncompressed key,
+	// anything else means a truncated response or a non-Cosmos app
+	switch len(key) {
+	case pubKeyLenCompressed, pubKeyLenUncompressed:
+	default:
+		return nil, fmt.Errorf("unexpected public key length %d returned by the device, expected %d or %d bytes",
+			len(key), pubKeyLenCompressed, pubKeyLenUncompressed)
+	}
+
 	// re-serialize in the 33-byte compressed format
-	cmp, err := btcec.ParsePubKey(key[:])
+	cmp, err := btcec.ParsePubKey(key)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing public key: %v", err)
 	}
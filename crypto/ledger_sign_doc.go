@@ -0,0 +1,267 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SignDocMsgRenderer renders the sign bytes of a single message into a
+// human-readable text. The first line is used as the title of the message and
+// any following lines as its details.
+type SignDocMsgRenderer func(msg json.RawMessage) (string, error)
+
+var (
+	signDocRenderersMtx sync.RWMutex
+	signDocRenderers    = map[string]SignDocMsgRenderer{
+		"cosmos-sdk/Send":                            renderSendMsg,
+		"cosmos-sdk/MsgDelegate":                     renderDelegateMsg,
+		"cosmos-sdk/MsgVote":                         renderVoteMsg,
+		"cosmos-sdk/MsgWithdrawDelegationReward":     renderWithdrawRewardMsg,
+		"cosmos-sdk/MsgWithdrawDelegationRewardsAll": renderWithdrawRewardsAllMsg,
+	}
+)
+
+// RegisterSignDocRenderer registers a renderer used by RenderSignDoc for the
+// messages of the given amino type, replacing any previous renderer.
+func RegisterSignDocRenderer(msgType string, renderer SignDocMsgRenderer) {
+	signDocRenderersMtx.Lock()
+	defer signDocRenderersMtx.Unlock()
+	signDocRenderers[msgType] = renderer
+}
+
+func getSignDocRenderer(msgType string) (SignDocMsgRenderer, bool) {
+	signDocRenderersMtx.RLock()
+	defer signDocRenderersMtx.RUnlock()
+	renderer, ok := signDocRenderers[msgType]
+	return renderer, ok
+}
+
+// signDoc is the decoded form of the bytes produced by auth.StdSignBytes. The
+// fee is optional as BNB Beacon Chain sign docs don't carry one.
+type signDoc struct {
+	AccountNumber json.Number       `json:"account_number"`
+	ChainID       string            `json:"chain_id"`
+	Memo          string            `json:"memo"`
+	Msgs          []json.RawMessage `json:"msgs"`
+	Sequence      json.Number       `json:"sequence"`
+	Fee           *struct {
+		Amount []signDocCoin `json:"amount"`
+		Gas    json.Number   `json:"gas"`
+	} `json:"fee"`
+}
+
+type signDocCoin struct {
+	Denom  string      `json:"denom"`
+	Amount json.Number `json:"amount"`
+}
+
+// String returns the display form of the coin, e.g. "1.50000000 BNB", or its
+// raw amount if it is not an amount of the smallest unit.
+func (c signDocCoin) String() string {
+	amount, err := c.Amount.Int64()
+	if err != nil {
+		return fmt.Sprintf("%v%v", c.Amount, c.Denom)
+	}
+	return sdk.NewCoin(c.Denom, amount).Display()
+}
+
+func coinsString(coins []signDocCoin) string {
+	if len(coins) == 0 {
+		return "none"
+	}
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = coin.String()
+	}
+	return strings.Join(out, ", ")
+}
+
+// RenderSignDoc decodes a sign doc and renders it into a multi-line summary
+// suitable to be displayed to the user before signing. Messages are rendered
+// by the renderer registered for their type, unknown messages are displayed
+// as raw JSON.
+func RenderSignDoc(msg []byte) (string, error) {
+	var doc signDoc
+	decoder := json.NewDecoder(bytes.NewReader(msg))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return "", errors.Wrap(err, "failed to decode sign doc")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Chain ID: %s\n", doc.ChainID)
+	fmt.Fprintf(&sb, "Account number: %v\n", doc.AccountNumber)
+	fmt.Fprintf(&sb, "Sequence: %v\n", doc.Sequence)
+	if doc.Fee != nil {
+		fmt.Fprintf(&sb, "Fee: %s (gas %v)\n", coinsString(doc.Fee.Amount), doc.Fee.Gas)
+	}
+	if doc.Memo != "" {
+		fmt.Fprintf(&sb, "Memo: %s\n", doc.Memo)
+	}
+	fmt.Fprintf(&sb, "Messages (%d):\n", len(doc.Msgs))
+	for i, raw := range doc.Msgs {
+		text, err := renderSignDocMsg(raw)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to render message %d", i+1)
+		}
+		for j, line := range strings.Split(text, "\n") {
+			if j == 0 {
+				fmt.Fprintf(&sb, "  %d. %s\n", i+1, line)
+			} else {
+				fmt.Fprintf(&sb, "     %s\n", line)
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func renderSignDocMsg(raw json.RawMessage) (string, error) {
+	msgType, value := signDocMsgType(raw)
+	if renderer, ok := getSignDocRenderer(msgType); ok {
+		return renderer(value)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Unknown message\n%s", compact.String()), nil
+}
+
+// signDocMsgType returns the type of a message and its value. Most messages
+// are amino encoded with a type and value, but some of them sign plain JSON
+// and are recognised by their fields instead.
+func signDocMsgType(raw json.RawMessage) (string, json.RawMessage) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return "", raw
+	}
+
+	if msgType, ok := fields["type"]; ok && len(fields) == 2 {
+		var name string
+		if value, ok := fields["value"]; ok && json.Unmarshal(msgType, &name) == nil {
+			return name, value
+		}
+	}
+
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := fields[key]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case len(fields) == 2 && has("inputs", "outputs"):
+		return "cosmos-sdk/Send", raw
+	case len(fields) == 3 && has("proposal_id", "voter", "option"):
+		return "cosmos-sdk/MsgVote", raw
+	}
+	return "", raw
+}
+
+func decodeSignDocMsg(value json.RawMessage, ptr interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	return decoder.Decode(ptr)
+}
+
+func renderSendMsg(value json.RawMessage) (string, error) {
+	var msg struct {
+		Inputs []struct {
+			Address string        `json:"address"`
+			Coins   []signDocCoin `json:"coins"`
+		} `json:"inputs"`
+		Outputs []struct {
+			Address string        `json:"address"`
+			Coins   []signDocCoin `json:"coins"`
+		} `json:"outputs"`
+	}
+	if err := decodeSignDocMsg(value, &msg); err != nil {
+		return "", err
+	}
+
+	lines := []string{"Send"}
+	for _, in := range msg.Inputs {
+		lines = append(lines, fmt.Sprintf("From: %s %s", in.Address, coinsString(in.Coins)))
+	}
+	for _, out := range msg.Outputs {
+		lines = append(lines, fmt.Sprintf("To: %s %s", out.Address, coinsString(out.Coins)))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func renderDelegateMsg(value json.RawMessage) (string, error) {
+	var msg struct {
+		DelegatorAddr string      `json:"delegator_addr"`
+		ValidatorAddr string      `json:"validator_addr"`
+		Delegation    signDocCoin `json:"delegation"`
+	}
+	if err := decodeSignDocMsg(value, &msg); err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		"Delegate",
+		fmt.Sprintf("Delegator: %s", msg.DelegatorAddr),
+		fmt.Sprintf("Validator: %s", msg.ValidatorAddr),
+		fmt.Sprintf("Amount: %s", msg.Delegation),
+	}, "\n"), nil
+}
+
+func renderVoteMsg(value json.RawMessage) (string, error) {
+	var msg struct {
+		ProposalID json.Number `json:"proposal_id"`
+		Voter      string      `json:"voter"`
+		Option     string      `json:"option"`
+	}
+	if err := decodeSignDocMsg(value, &msg); err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		"Vote",
+		fmt.Sprintf("Voter: %s", msg.Voter),
+		fmt.Sprintf("Proposal: %v", msg.ProposalID),
+		fmt.Sprintf("Option: %s", msg.Option),
+	}, "\n"), nil
+}
+
+func renderWithdrawRewardMsg(value json.RawMessage) (string, error) {
+	var msg struct {
+		DelegatorAddr string `json:"delegator_addr"`
+		ValidatorAddr string `json:"validator_addr"`
+	}
+	if err := decodeSignDocMsg(value, &msg); err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		"Withdraw delegation reward",
+		fmt.Sprintf("Delegator: %s", msg.DelegatorAddr),
+		fmt.Sprintf("Validator: %s", msg.ValidatorAddr),
+	}, "\n"), nil
+}
+
+func renderWithdrawRewardsAllMsg(value json.RawMessage) (string, error) {
+	var msg struct {
+		DelegatorAddr string `json:"delegator_addr"`
+	}
+	if err := decodeSignDocMsg(value, &msg); err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		"Withdraw all delegation rewards",
+		fmt.Sprintf("Delegator: %s", msg.DelegatorAddr),
+	}, "\n"), nil
+}
@@ -1,12 +1,25 @@
 package crypto
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/libs/bech32"
+	ledgergo "github.com/zondax/ledger-cosmos-go"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 var ledgerEnabledEnv = "TEST_WITH_LEDGER"
@@ -62,3 +75,1181 @@ func TestRealLedgerErrorHandling(t *testing.T) {
 	_, err := NewPrivKeyLedgerSecp256k1(path)
 	require.Error(t, err)
 }
+
+// mockLedger emulates a Ledger device holding a single secp256k1 key. It signs
+// with RFC 6979 deterministic nonces, like the real Cosmos app.
+type mockLedger struct {
+	priv    *btcec.PrivateKey
+	version ledgergo.VersionInfo
+
+	// faultyNonce makes every signature depend on a call counter, emulating a
+	// device which does not derive its nonces deterministically.
+	faultyNonce bool
+	signCalls   int
+
+	// pubKey, if set, is returned as is instead of the key of priv.
+	pubKey []byte
+
+	// rejectSign emulates the user rejecting the transaction on the device.
+	rejectSign bool
+
+	// shownHRP is the prefix of the last address shown on the device.
+	shownHRP string
+}
+
+func newMockLedger() *mockLedger {
+	priv, _ := btcec.PrivKeyFromBytes(tmcrypto.Sha256([]byte("mock ledger")))
+	return &mockLedger{priv: priv, version: ledgergo.VersionInfo{Major: 1, Minor: 0}}
+}
+
+func (m *mockLedger) GetPublicKeySECP256K1([]uint32) ([]byte, error) {
+	if m.pubKey != nil {
+		return m.pubKey, nil
+	}
+	return m.priv.PubKey().SerializeUncompressed(), nil
+}
+
+func (m *mockLedger) ShowAddressSECP256K1(_ []uint32, hrp string) error {
+	m.shownHRP = hrp
+	return nil
+}
+
+func (m *mockLedger) SignSECP256K1(_ []uint32, msg []byte) ([]byte, error) {
+	m.signCalls++
+	if m.rejectSign {
+		return nil, errors.New("[APDU_CODE_COMMAND_NOT_ALLOWED] Command not allowed (no current EF)")
+	}
+	if m.faultyNonce {
+		msg = append([]byte{byte(m.signCalls)}, msg...)
+	}
+	return ecdsa.Sign(m.priv, tmcrypto.Sha256(msg)).Serialize(), nil
+}
+
+func (m *mockLedger) GetPublicKeyETH([]uint32) ([]byte, error) {
+	return m.priv.PubKey().SerializeUncompressed(), nil
+}
+
+func (m *mockLedger) SignPersonalMessageETH(_ []uint32, msg []byte) ([]byte, error) {
+	if m.rejectSign {
+		return nil, errors.New("[APDU_CODE_CONDITIONS_NOT_SATISFIED] Conditions not satisfied")
+	}
+	// SignCompact returns V||R||S with V offset by 27, as the Ethereum app does
+	return ecdsa.SignCompact(m.priv, EIP191Hash(msg), false)
+}
+
+func (m *mockLedger) GetVersion() (*ledgergo.VersionInfo, error) {
+	version := m.version
+	return &version, nil
+}
+
+func newMockPrivKeyLedger(t *testing.T, device LedgerSECP256K1) *PrivKeyLedgerSecp256k1 {
+	pkl := &PrivKeyLedgerSecp256k1{Path: DerivationPath{44, 714, 0, 0, 0}, ledger: device}
+	pub, err := pkl.getPubKey()
+	require.NoError(t, err)
+	pkl.CachedPubKey = pub
+	return pkl
+}
+
+var mockSignDoc = []byte(`{"account_number":"3","chain_id":"1234","data":null,"memo":"memo","msgs":["msg"],"sequence":"6","source":"0"}`)
+
+func TestAuditNonceDeterminism(t *testing.T) {
+	pkl := newMockPrivKeyLedger(t, newMockLedger())
+	require.NoError(t, pkl.AuditNonceDeterminism(mockSignDoc))
+
+	faulty := newMockLedger()
+	faulty.faultyNonce = true
+	pkl = newMockPrivKeyLedger(t, faulty)
+	require.Error(t, pkl.AuditNonceDeterminism(mockSignDoc))
+
+	require.Error(t, pkl.AuditNonceDeterminism([]byte("not a sign doc")))
+
+	// a key without a device handle discovers the device
+	offline := newMockPrivKeyLedger(t, newMockLedger())
+	offline.ledger = nil
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return newMockLedger(), nil })
+	require.NoError(t, offline.AuditNonceDeterminism(mockSignDoc))
+
+	// numbers are re-encoded as is
+	variant, err := nonceAuditVariant([]byte(`{"account_number":12345678901234567890,"gas":1e3,"memo":"m"}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"account_number":12345678901234567890,"gas":1e3,"memo":"m (nonce audit)"}`, string(variant))
+}
+
+// flakyLedger fails the given number of device calls with a transport error
+// before delegating to the wrapped mock.
+type flakyLedger struct {
+	*mockLedger
+	failures int
+}
+
+func (f *flakyLedger) SignSECP256K1(path []uint32, msg []byte) ([]byte, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("hidapi: failed to write to device")
+	}
+	return f.mockLedger.SignSECP256K1(path, msg)
+}
+
+func setDiscoverLedger(t *testing.T, fn discoverLedgerFn) {
+	prev := discoverLedger
+	discoverLedger = fn
+	t.Cleanup(func() { discoverLedger = prev })
+}
+
+func TestIsLedgerTransportError(t *testing.T) {
+	for _, msg := range []string{"hid: device closed", "hidapi: failed to write to device", "Invalid channel", "len(response) < 2"} {
+		require.True(t, isLedgerTransportError(errors.New(msg)), msg)
+	}
+	for _, msg := range []string{
+		"[APDU_CODE_CONDITIONS_NOT_SATISFIED] Conditions of use not satisfied",
+		"Error code: 6a81",
+		"hrp len should be <10",
+		"invalid response",
+		"command requires at least app version 1.1.0",
+	} {
+		require.False(t, isLedgerTransportError(errors.New(msg)), msg)
+	}
+}
+
+func TestSignReconnectsAfterTransportError(t *testing.T) {
+	device := &flakyLedger{mockLedger: newMockLedger(), failures: 1}
+	pkl := newMockPrivKeyLedger(t, device)
+
+	discoveries := 0
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) {
+		discoveries++
+		return device, nil
+	})
+
+	sig, err := pkl.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, 1, discoveries)
+	require.True(t, pkl.PubKey().VerifyBytes(mockSignDoc, sig))
+
+	// the retry is bounded to a single re-discovery
+	device.failures = 2
+	_, err = pkl.Sign(mockSignDoc)
+	require.Error(t, err)
+	require.Equal(t, 2, discoveries)
+}
+
+func TestSignReconnectRejectsDifferentKey(t *testing.T) {
+	pkl := newMockPrivKeyLedger(t, &flakyLedger{mockLedger: newMockLedger(), failures: 1})
+
+	other := newMockLedger()
+	other.priv, _ = btcec.PrivKeyFromBytes(tmcrypto.Sha256([]byte("other ledger")))
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) {
+		return other, nil
+	})
+
+	_, err := pkl.Sign(mockSignDoc)
+	require.Error(t, err)
+	require.Nil(t, pkl.ledger)
+}
+
+func TestNewBIP44Path(t *testing.T) {
+	require.Equal(t, DerivationPath{0x8000002c, 0x800002ca, 0x80000000, 0, 0}, DefaultBIP44Path())
+	path, err := NewBIP44Path(3, 7)
+	require.NoError(t, err)
+	require.Equal(t, DerivationPath{0x8000002c, 0x800002ca, 0x80000003, 0, 7}, path)
+
+	path, err = NewBIP44Path(1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x80000000+44), path[0])
+	require.Equal(t, uint32(0x80000000+714), path[1])
+	require.Equal(t, uint32(0x80000000+1), path[2])
+	require.Equal(t, uint32(0), path[3])
+	require.Equal(t, uint32(2), path[4])
+
+	// the account and the index cannot overflow into the hardened bit
+	_, err = NewBIP44Path(HardenedOffset, 0)
+	require.Error(t, err)
+	_, err = NewBIP44Path(0, HardenedOffset)
+	require.Error(t, err)
+	path, err = NewBIP44Path(HardenedOffset-1, HardenedOffset-1)
+	require.NoError(t, err)
+	require.NoError(t, path.Validate())
+}
+
+func TestSignWithDualConfirmation(t *testing.T) {
+	device := newMockLedger()
+	pkl := newMockPrivKeyLedger(t, device)
+
+	verified := 0
+	approve := func() (bool, error) { verified++; return true, nil }
+	deny := func() (bool, error) { verified++; return false, nil }
+	failing := func() (bool, error) { verified++; return false, errors.New("totp service unavailable") }
+
+	// both factors pass
+	sig, err := pkl.SignWithDualConfirmation(mockSignDoc, approve)
+	require.NoError(t, err)
+	require.True(t, pkl.PubKey().VerifyBytes(mockSignDoc, sig))
+
+	// the device signs but the second factor fails
+	sig, err = pkl.SignWithDualConfirmation(mockSignDoc, deny)
+	require.Error(t, err)
+	require.Nil(t, sig)
+	sig, err = pkl.SignWithDualConfirmation(mockSignDoc, failing)
+	require.Error(t, err)
+	require.Nil(t, sig)
+	require.Equal(t, 3, verified)
+	require.Equal(t, 3, device.signCalls)
+
+	// the device rejects, the second factor is never consulted
+	device.rejectSign = true
+	sig, err = pkl.SignWithDualConfirmation(mockSignDoc, approve)
+	require.Error(t, err)
+	require.Nil(t, sig)
+	require.Equal(t, 3, verified)
+	require.Equal(t, 4, device.signCalls)
+}
+
+func TestPubKeyLengthValidation(t *testing.T) {
+	device := newMockLedger()
+	uncompressed := device.priv.PubKey().SerializeUncompressed()
+	compressed := device.priv.PubKey().SerializeCompressed()
+	pkl := &PrivKeyLedgerSecp256k1{Path: DefaultBIP44Path(), ledger: device}
+
+	for _, key := range [][]byte{uncompressed, compressed} {
+		device.pubKey = key
+		pub, err := pkl.getPubKey()
+		require.NoError(t, err)
+		require.Equal(t, compressed, pub.Bytes()[len(pub.Bytes())-33:])
+	}
+
+	for _, key := range [][]byte{{}, compressed[:20], uncompressed[:64], append(uncompressed, 0x00, 0x01)} {
+		device.pubKey = key
+		_, err := pkl.getPubKey()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected public key length")
+	}
+}
+
+func TestSignMaxGasGuard(t *testing.T) {
+	device := newMockLedger()
+	pkl := newMockPrivKeyLedger(t, device)
+	pkl.MaxGasGuard = 200000
+
+	within := []byte(`{"account_number":"3","chain_id":"1234","fee":{"amount":[{"amount":"150","denom":"bnb"}],"gas":"200000"},"memo":"memo","msgs":["msg"],"sequence":"6"}`)
+	above := []byte(`{"account_number":"3","chain_id":"1234","fee":{"amount":[{"amount":"150","denom":"bnb"}],"gas":"200001"},"memo":"memo","msgs":["msg"],"sequence":"6"}`)
+
+	_, err := pkl.Sign(within)
+	require.NoError(t, err)
+	require.Equal(t, 1, device.signCalls)
+
+	// sign docs without a fee carry no gas limit
+	_, err = pkl.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, 2, device.signCalls)
+
+	// the device is never engaged above the guard
+	_, err = pkl.Sign(above)
+	require.Equal(t, ErrGasLimitTooHigh, pkgerrors.Cause(err))
+	require.Equal(t, 2, device.signCalls)
+
+	// unlimited by default
+	pkl.MaxGasGuard = 0
+	_, err = pkl.Sign(above)
+	require.NoError(t, err)
+
+	// the guard is not persisted
+	pkl.MaxGasGuard = 1
+	require.NotContains(t, string(cdc.MustMarshalJSON(pkl)), "Gas")
+}
+
+func TestRequiresAddressConfirmation(t *testing.T) {
+	device := newMockLedger()
+	pkl := newMockPrivKeyLedger(t, device)
+
+	cases := []struct {
+		version ledgergo.VersionInfo
+		expect  bool
+	}{
+		{ledgergo.VersionInfo{Major: 1, Minor: 0, Patch: 9}, false},
+		{ledgergo.VersionInfo{Major: 1, Minor: 1, Patch: 0}, true},
+		{ledgergo.VersionInfo{Major: 2, Minor: 0, Patch: 0}, true},
+		{ledgergo.VersionInfo{Major: 0, Minor: 9, Patch: 0}, false},
+	}
+	for _, tc := range cases {
+		device.version = tc.version
+		required, err := pkl.RequiresAddressConfirmation()
+		require.NoError(t, err)
+		require.Equal(t, tc.expect, required, "version %v", tc.version)
+	}
+
+	// a key loaded offline keeps the device it discovered for the check
+	discoveries := 0
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) {
+		discoveries++
+		return device, nil
+	})
+	pkl.ledger = nil
+	_, err := pkl.RequiresAddressConfirmation()
+	require.NoError(t, err)
+	require.Equal(t, device, pkl.ledger)
+	_, err = pkl.RequiresAddressConfirmation()
+	require.NoError(t, err)
+	require.Equal(t, 1, discoveries)
+}
+
+// closableLedger records whether the device handle has been released.
+type closableLedger struct {
+	*mockLedger
+	closed chan struct{}
+}
+
+func (c *closableLedger) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func TestNewPrivKeyLedgerSecp256k1WithTimeout(t *testing.T) {
+	device := &closableLedger{mockLedger: newMockLedger(), closed: make(chan struct{})}
+
+	// discovery within the timeout
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return device, nil })
+	priv, err := NewPrivKeyLedgerSecp256k1WithTimeout(DefaultBIP44Path(), time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, priv.PubKey())
+
+	// discovery failing within the timeout
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return nil, errors.New("no ledger connected") })
+	_, err = NewPrivKeyLedgerSecp256k1WithTimeout(DefaultBIP44Path(), time.Second)
+	require.Error(t, err)
+
+	// a device whose public key cannot be read is released
+	broken := &closableLedger{mockLedger: newMockLedger(), closed: make(chan struct{})}
+	broken.pubKey = []byte{}
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return broken, nil })
+	_, err = NewPrivKeyLedgerSecp256k1WithTimeout(DefaultBIP44Path(), time.Second)
+	require.Error(t, err)
+	select {
+	case <-broken.closed:
+	default:
+		t.Fatal("device was not closed after the key creation failed")
+	}
+
+	// a late discovery times out and its device is released
+	release := make(chan struct{})
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) {
+		<-release
+		return device, nil
+	})
+	_, err = NewPrivKeyLedgerSecp256k1WithTimeout(DefaultBIP44Path(), 10*time.Millisecond)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no Ledger device found")
+
+	close(release)
+	select {
+	case <-device.closed:
+	case <-time.After(time.Second):
+		t.Fatal("late discovered device was not closed")
+	}
+}
+
+func TestRenderSignDoc(t *testing.T) {
+	signDoc := `{"account_number":"3","chain_id":"Binance-Chain-Tigris","data":null,"memo":"memo","msgs":[` +
+		`{"inputs":[{"address":"bnb1from","coins":[{"amount":150000000,"denom":"BNB"}]}],"outputs":[{"address":"bnb1to","coins":[{"amount":150000000,"denom":"BNB"}]}]},` +
+		`{"type":"cosmos-sdk/MsgDelegate","value":{"delegation":{"amount":"5","denom":"BNB"},"delegator_addr":"bnb1from","validator_addr":"bva1val"}},` +
+		`{"option":"Yes","proposal_id":"1","voter":"bnb1from"},` +
+		`{"type":"cosmos-sdk/MsgWithdrawDelegationReward","value":{"delegator_addr":"bnb1from","validator_addr":"bva1val"}},` +
+		`{"type":"custom/MsgPing","value":{"nonce":"7"}}` +
+		`],"sequence":"6","source":"0"}`
+
+	expected := `Chain ID: Binance-Chain-Tigris
+Account number: 3
+Sequence: 6
+Memo: memo
+Messages (5):
+  1. Send
+     From: bnb1from 1.50000000 BNB
+     To: bnb1to 1.50000000 BNB
+  2. Delegate
+     Delegator: bnb1from
+     Validator: bva1val
+     Amount: 0.00000005 BNB
+  3. Vote
+     Voter: bnb1from
+     Proposal: 1
+     Option: Yes
+  4. Withdraw delegation reward
+     Delegator: bnb1from
+     Validator: bva1val
+  5. Unknown message
+     {"type":"custom/MsgPing","value":{"nonce":"7"}}
+`
+	preview, err := RenderSignDoc([]byte(signDoc))
+	require.NoError(t, err)
+	require.Equal(t, expected, preview)
+
+	// extensions render their own messages
+	RegisterSignDocRenderer("custom/MsgPing", func(msg json.RawMessage) (string, error) {
+		return "Ping\n" + string(msg), nil
+	})
+	defer func() {
+		signDocRenderersMtx.Lock()
+		delete(signDocRenderers, "custom/MsgPing")
+		signDocRenderersMtx.Unlock()
+	}()
+	preview, err = RenderSignDoc([]byte(signDoc))
+	require.NoError(t, err)
+	require.Contains(t, preview, "  5. Ping\n     {\"nonce\":\"7\"}\n")
+
+	// a fee is rendered when the sign doc has one
+	preview, err = RenderSignDoc([]byte(`{"account_number":"1","chain_id":"c","fee":{"amount":[{"amount":"10","denom":"BNB"}],"gas":"200000"},"msgs":[],"sequence":"0"}`))
+	require.NoError(t, err)
+	require.Contains(t, preview, "Fee: 0.00000010 BNB (gas 200000)\n")
+
+	_, err = RenderSignDoc([]byte("not a sign doc"))
+	require.Error(t, err)
+}
+
+func TestSignPreviewWriter(t *testing.T) {
+	priv := newMockPrivKeyLedger(t, newMockLedger())
+
+	var preview strings.Builder
+	priv.PreviewWriter = &preview
+	sig, err := priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(mockSignDoc, sig))
+	expected, err := RenderSignDoc(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, expected, preview.String())
+	require.Contains(t, preview.String(), "Chain ID: 1234\n")
+	require.Contains(t, preview.String(), "Memo: memo\n")
+
+	// bytes which are not a sign doc are still signed
+	preview.Reset()
+	sig, err = priv.Sign([]byte("raw bytes"))
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes([]byte("raw bytes"), sig))
+	// and previewed hex encoded
+	require.Equal(t, "Bytes to sign:\n726177206279746573\n", preview.String())
+
+	// the preview is not persisted
+	decoded, err := PrivKeyLedgerSecp256k1FromBytes(priv.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, decoded.PreviewWriter)
+}
+
+func TestPrivKeyLedgerSecp256k1FromBytes(t *testing.T) {
+	priv := newMockPrivKeyLedger(t, newMockLedger())
+	priv.MaxGasGuard = 100
+
+	bz := priv.Bytes()
+	decoded, err := PrivKeyLedgerSecp256k1FromBytes(bz)
+	require.NoError(t, err)
+	require.True(t, priv.Equals(decoded))
+	require.Equal(t, priv.Path, decoded.Path)
+	require.NoError(t, decoded.Path.Validate())
+	require.Nil(t, decoded.ledger)
+
+	// the encoding only depends on the persisted fields
+	priv.MaxGasGuard = 0
+	require.Equal(t, bz, priv.Bytes())
+	require.Equal(t, bz, decoded.Bytes())
+
+	_, err = PrivKeyLedgerSecp256k1FromBytes([]byte("garbage"))
+	require.Error(t, err)
+
+	invalid := *priv
+	invalid.Path = DerivationPath{44, 60, 0, 0, 0}
+	_, err = PrivKeyLedgerSecp256k1FromBytes(invalid.Bytes())
+	require.Error(t, err)
+}
+
+func TestDerivationPathValidate(t *testing.T) {
+	require.NoError(t, DefaultBIP44Path().Validate())
+	path, err := NewBIP44Path(3, 7)
+	require.NoError(t, err)
+	require.NoError(t, path.Validate())
+	require.NoError(t, DerivationPath{44, 714, 0, 1, 0}.Validate())
+
+	require.Error(t, DerivationPath{44, 714, 0, 0}.Validate())
+	require.Error(t, DerivationPath{49, 714, 0, 0, 0}.Validate())
+	require.Error(t, DerivationPath{44, 118, 0, 0, 0}.Validate())
+	require.Error(t, DerivationPath{44, 714, 0, 2, 0}.Validate())
+	require.Error(t, DerivationPath{44, 714, 0, 0, HardenedOffset}.Validate())
+}
+
+type recordingEventHandler struct {
+	events []string
+}
+
+func (h *recordingEventHandler) OnWaitingForDevice()     { h.events = append(h.events, "waiting") }
+func (h *recordingEventHandler) OnAwaitingConfirmation() { h.events = append(h.events, "confirm") }
+func (h *recordingEventHandler) OnSigned()               { h.events = append(h.events, "signed") }
+
+func TestSignEventHandler(t *testing.T) {
+	device := newMockLedger()
+	priv := newMockPrivKeyLedger(t, device)
+
+	// signing without a handler is fine
+	_, err := priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+
+	handler := &recordingEventHandler{}
+	priv.EventHandler = handler
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, []string{"waiting", "confirm", "signed"}, handler.events)
+
+	// a rejected signature is never reported as signed
+	handler.events = nil
+	device.rejectSign = true
+	_, err = priv.Sign(mockSignDoc)
+	require.Error(t, err)
+	require.Equal(t, []string{"waiting", "confirm"}, handler.events)
+
+	// confirming the address only notifies the transaction confirmation
+	handler.events = nil
+	device.rejectSign = false
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 1}
+	priv.ConfirmFn = func(string) (bool, error) { return true, nil }
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, []string{"waiting", "confirm", "signed"}, handler.events)
+	priv.ConfirmFn = nil
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 0}
+
+	// the handler is not part of the encoding
+	decoded, err := PrivKeyLedgerSecp256k1FromBytes(priv.Bytes())
+	require.NoError(t, err)
+	require.Nil(t, decoded.EventHandler)
+
+	// the writer handler tells the user when to check the device
+	var out strings.Builder
+	priv.EventHandler = NewWriterEventHandler(&out)
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, "Please verify the transaction data on ledger\n", out.String())
+}
+
+func TestDerivationPathString(t *testing.T) {
+	require.Equal(t, "m/44'/714'/0'/0/0", DefaultBIP44Path().String())
+	require.Equal(t, "m/44/714/0/0/3", DerivationPath{44, 714, 0, 0, 3}.String())
+
+	for _, str := range []string{"m/44'/714'/2'/1/7", "m/44/714/0/0/0"} {
+		path, err := ParseDerivationPath(str)
+		require.NoError(t, err)
+		require.Equal(t, str, path.String())
+	}
+
+	path, err := ParseDerivationPath("44h/714h/0h/0/0")
+	require.NoError(t, err)
+	require.Equal(t, DefaultBIP44Path(), path)
+
+	for _, str := range []string{"", "m", "m/", "m/44'/x/0", "m/44''/714", "m/4294967295"} {
+		_, err := ParseDerivationPath(str)
+		require.Error(t, err, str)
+	}
+}
+
+func TestDerivationPathJSON(t *testing.T) {
+	path, err := NewBIP44Path(1, 2)
+	require.NoError(t, err)
+	bz, err := json.Marshal(path)
+	require.NoError(t, err)
+	require.Equal(t, `"m/44'/714'/1'/0/2"`, string(bz))
+
+	var decoded DerivationPath
+	require.NoError(t, json.Unmarshal(bz, &decoded))
+	require.Equal(t, path, decoded)
+
+	// the legacy numeric form is still accepted
+	legacy, err := json.Marshal([]uint32(path))
+	require.NoError(t, err)
+	decoded = nil
+	require.NoError(t, json.Unmarshal(legacy, &decoded))
+	require.Equal(t, path, decoded)
+
+	// the string form is used within keys, also through amino
+	key := newMockPrivKeyLedger(t, newMockLedger())
+	key.Path = path
+	bz, err = cdc.MarshalJSON(key)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), `"Path":"m/44'/714'/1'/0/2"`)
+	var decodedKey PrivKeyLedgerSecp256k1
+	require.NoError(t, cdc.UnmarshalJSON(bz, &decodedKey))
+	require.Equal(t, key.Path, decodedKey.Path)
+
+	require.Error(t, json.Unmarshal([]byte(`"m/44'/abc"`), &decoded))
+	require.Error(t, json.Unmarshal([]byte(`{}`), &decoded))
+}
+
+func TestNilLedgerHandle(t *testing.T) {
+	offline := newMockPrivKeyLedger(t, newMockLedger())
+	offline.ledger = nil
+
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return nil, errors.New("no ledger connected") })
+	require.NotPanics(t, func() {
+		_, err := offline.Sign(mockSignDoc)
+		require.Error(t, err)
+		require.Equal(t, ErrLedgerNotConnected, pkgerrors.Cause(err))
+
+		require.Equal(t, ErrLedgerNotConnected, offline.ValidateKey())
+		_, err = offline.getPubKey()
+		require.Equal(t, ErrLedgerNotConnected, err)
+		require.Error(t, offline.AuditNonceDeterminism(mockSignDoc))
+	})
+
+	setDiscoverLedger(t, nil)
+	_, err := offline.Sign(mockSignDoc)
+	require.Equal(t, ErrLedgerNotConnected, pkgerrors.Cause(err))
+
+	// once a device is connected the key is usable again
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return newMockLedger(), nil })
+	sig, err := offline.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.True(t, offline.PubKey().VerifyBytes(mockSignDoc, sig))
+	require.NoError(t, offline.ValidateKey())
+}
+
+func TestSignatureToCompact(t *testing.T) {
+	mustHex := func(str string) []byte {
+		bz, err := hex.DecodeString(str)
+		require.NoError(t, err)
+		return bz
+	}
+	zeros := func(n int) string { return strings.Repeat("00", n) }
+
+	// R = 0x0102, S = 0x03
+	shortDER := mustHex("3007" + "02020102" + "020103")
+	shortCompact := mustHex(zeros(30) + "0102" + zeros(31) + "03")
+
+	compact, err := SignatureToCompact(shortDER)
+	require.NoError(t, err)
+	require.Equal(t, shortCompact, compact)
+
+	der, err := CompactToBER(shortCompact)
+	require.NoError(t, err)
+	require.Equal(t, shortDER, der)
+
+	// S = N-3 is flipped to 3
+	highDER := mustHex("3027" + "02020102" + "022100" + "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd036413e")
+	compact, err = SignatureToCompact(highDER)
+	require.NoError(t, err)
+	require.Equal(t, shortCompact, compact)
+
+	highCompact := mustHex(zeros(30) + "0102" + "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd036413e")
+	der, err = CompactToBER(highCompact)
+	require.NoError(t, err)
+	require.Equal(t, shortDER, der)
+
+	// signatures of the device round trip
+	device := newMockLedger()
+	priv := newMockPrivKeyLedger(t, device)
+	deviceDER, err := device.SignSECP256K1(priv.Path, mockSignDoc)
+	require.NoError(t, err)
+	compact, err = SignatureToCompact(deviceDER)
+	require.NoError(t, err)
+	sig, err := priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, sig, compact)
+	der, err = CompactToBER(compact)
+	require.NoError(t, err)
+	require.Equal(t, deviceDER, der)
+
+	// malformed input
+	_, err = SignatureToCompact(append(shortDER, 0))
+	require.Error(t, err)
+	_, err = SignatureToCompact(mustHex("3006" + "020100" + "020103"))
+	require.Error(t, err)
+	_, err = CompactToBER(shortCompact[1:])
+	require.Error(t, err)
+	_, err = CompactToBER(make([]byte, 64))
+	require.Error(t, err)
+}
+
+func TestSignAddressConfirmation(t *testing.T) {
+	device := newMockLedger()
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 1}
+	priv := newMockPrivKeyLedger(t, device)
+	address := priv.CachedPubKey.Address().Bytes()
+
+	hrp, prompt, err := priv.addressConfirmation()
+	require.NoError(t, err)
+	require.Equal(t, sdk.GetConfig().GetBech32AccountAddrPrefix(), hrp)
+	require.Equal(t, fmt.Sprintf(DefaultConfirmationMessage, sdk.AccAddress(address).String()), prompt)
+
+	priv.AddressHRP = "bva"
+	priv.ConfirmationMessage = "Does the device show %s?"
+	valAddress, err := bech32.ConvertAndEncode("bva", address)
+	require.NoError(t, err)
+	hrp, prompt, err = priv.addressConfirmation()
+	require.NoError(t, err)
+	require.Equal(t, "bva", hrp)
+	require.Equal(t, "Does the device show "+valAddress+"?", prompt)
+
+	// the device shows the address with the configured prefix
+	stdin := os.Stdin
+	defer func() { os.Stdin = stdin }()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdin = r
+	_, err = w.WriteString("yes\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, "bva", device.shownHRP)
+
+	// invalid templates are rejected before the device is engaged
+	for _, message := range []string{"no verb", "%s and %s", "%d", "%s %v"} {
+		priv.ConfirmationMessage = message
+		_, _, err = priv.addressConfirmation()
+		require.Error(t, err, message)
+	}
+	priv.ConfirmationMessage = ""
+	priv.AddressHRP = "INVALID prefix"
+	_, err = priv.Sign(mockSignDoc)
+	require.Error(t, err)
+}
+
+// derToBERVectors were produced by the previous implementation of
+// convertDERtoBER, which re-serialized the parsed signature, so the output must
+// stay identical.
+var derToBERVectors = []struct {
+	der, ber string
+}{
+	{
+		"30440220478de2a78bef722cb73cf4d511d77495fcc610c565b3dd2e121f4b2be7a03d01022002208f4150e20e4bcce2de8e15bcf5c615e5fde17aef3e55f56bf7de5a8a5060",
+		"478de2a78bef722cb73cf4d511d77495fcc610c565b3dd2e121f4b2be7a03d0102208f4150e20e4bcce2de8e15bcf5c615e5fde17aef3e55f56bf7de5a8a5060",
+	},
+	{
+		"304502206a26669f46385ccaa15d6386281157dc2320b81a1c0a70c12a3cd3efdeb4cfe4022100977a20b35973f4e88562eb1cca528ab9309163718c97181334ff31f6a6af00f9",
+		"6a26669f46385ccaa15d6386281157dc2320b81a1c0a70c12a3cd3efdeb4cfe46885df4ca68c0b177a9d14e335ad75458a1d797522b188288ad32c9629874048",
+	},
+	{
+		"304402202917f79f76b70011b7d35e233d5d523a52c1163443beed0aac5f5a8b7e0adf9302206ac8431562cb1f085a1331eacdb04ce9c10ecd027273aff60cfb444e82243d67",
+		"2917f79f76b70011b7d35e233d5d523a52c1163443beed0aac5f5a8b7e0adf936ac8431562cb1f085a1331eacdb04ce9c10ecd027273aff60cfb444e82243d67",
+	},
+	{
+		"3045022031d89649a336afef82791e515c85efadd9a85d5b7699a978af3fd7c873a5cfe60221009eca6c31624f30f934f19dc00571cbda294462936948186ba21ee81e4da21c71",
+		"31d89649a336afef82791e515c85efadd9a85d5b7699a978af3fd7c873a5cfe6613593ce9db0cf06cb0e623ffa8e3424916a7a53460087d01db3766e829424d0",
+	},
+}
+
+func TestConvertDERtoBER(t *testing.T) {
+	for i, vector := range derToBERVectors {
+		der, err := hex.DecodeString(vector.der)
+		require.NoError(t, err)
+		ber, err := convertDERtoBER(der)
+		require.NoError(t, err, i)
+		require.Equal(t, vector.ber, hex.EncodeToString(ber), i)
+	}
+
+	// a padded R, which the previous implementation sliced incorrectly
+	der, err := hex.DecodeString("30450221009e0f7bb3d73d1f740b5fe41d742218015b2e073e10693ec7fed4b73d29242a9d02206c5c7f275fa321de86922935d6a765931cd53c1cabe9b6155b3d57818f984695")
+	require.NoError(t, err)
+	ber, err := convertDERtoBER(der)
+	require.NoError(t, err)
+	compact, err := SignatureToCompact(der)
+	require.NoError(t, err)
+	require.Equal(t, compact, ber)
+
+	_, err = convertDERtoBER(der[:len(der)-1])
+	require.Error(t, err)
+	_, err = convertDERtoBER(nil)
+	require.Error(t, err)
+
+	// the BER encodings of a signature which are not its DER encoding
+	for i, malleated := range []string{
+		// R padded with a needless zero
+		"3045022100478de2a78bef722cb73cf4d511d77495fcc610c565b3dd2e121f4b2be7a03d01022002208f4150e20e4bcce2de8e15bcf5c615e5fde17aef3e55f56bf7de5a8a5060",
+		// long form of the total length
+		"3081440220478de2a78bef722cb73cf4d511d77495fcc610c565b3dd2e121f4b2be7a03d01022002208f4150e20e4bcce2de8e15bcf5c615e5fde17aef3e55f56bf7de5a8a5060",
+		// trailing byte
+		"30440220478de2a78bef722cb73cf4d511d77495fcc610c565b3dd2e121f4b2be7a03d01022002208f4150e20e4bcce2de8e15bcf5c615e5fde17aef3e55f56bf7de5a8a506000",
+		// negative S
+		"304402206a26669f46385ccaa15d6386281157dc2320b81a1c0a70c12a3cd3efdeb4cfe40220977a20b35973f4e88562eb1cca528ab9309163718c97181334ff31f6a6af00f9",
+	} {
+		der, err := hex.DecodeString(malleated)
+		require.NoError(t, err)
+		_, err = convertDERtoBER(der)
+		require.Error(t, err, i)
+		_, err = SignatureToCompact(der)
+		require.Error(t, err, i)
+	}
+}
+
+func BenchmarkConvertDERtoBER(b *testing.B) {
+	der, err := hex.DecodeString(derToBERVectors[1].der)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := convertDERtoBER(der); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ledgerHub emulates a device which, like a HID device, can only be held by a
+// single process at a time.
+type ledgerHub struct {
+	device *mockLedger
+	held   bool
+}
+
+func (h *ledgerHub) acquire() (LedgerSECP256K1, error) {
+	if h.held {
+		return nil, errors.New("hidapi: failed to open device: device busy")
+	}
+	h.held = true
+	return &hubLedger{mockLedger: h.device, hub: h}, nil
+}
+
+// hubLedger is a handle on the device of a ledgerHub.
+type hubLedger struct {
+	*mockLedger
+	hub *ledgerHub
+}
+
+func (l *hubLedger) Close() error {
+	l.hub.held = false
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	hub := &ledgerHub{device: newMockLedger()}
+	setDiscoverLedger(t, hub.acquire)
+
+	first, err := NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+	require.NoError(t, err)
+	pkl := first.(*PrivKeyLedgerSecp256k1)
+	address := pkl.AccAddress()
+	require.Equal(t, sdk.AccAddress(pkl.PubKey().Address()), address)
+
+	// a second process can't use the device while it is held
+	_, err = NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+	require.Error(t, err)
+
+	require.NoError(t, pkl.Close())
+	require.Nil(t, pkl.ledger)
+	require.Equal(t, address, pkl.AccAddress())
+
+	second, err := NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+	require.NoError(t, err)
+	require.True(t, second.Equals(pkl))
+	require.NoError(t, second.(*PrivKeyLedgerSecp256k1).Close())
+
+	// the closed key discovers the device again on its next use
+	sig, err := pkl.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.True(t, pkl.PubKey().VerifyBytes(mockSignDoc, sig))
+	require.True(t, hub.held)
+
+	// closing is idempotent and works for devices without a Close method
+	require.NoError(t, pkl.Close())
+	require.NoError(t, pkl.Close())
+	require.False(t, hub.held)
+	require.NoError(t, newMockPrivKeyLedger(t, newMockLedger()).Close())
+}
+
+func TestEthSecp256k1(t *testing.T) {
+	require.Equal(t, "a1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2",
+		hex.EncodeToString(EIP191Hash([]byte("Hello World"))))
+
+	privBytes, err := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	priv, _ := btcec.PrivKeyFromBytes(privBytes)
+	var pub PubKeyEthSecp256k1
+	copy(pub[:], priv.PubKey().SerializeCompressed())
+	require.Equal(t, "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", EthAddressHex(pub.Address()))
+
+	address, err := hex.DecodeString("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	require.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", EthAddressHex(address))
+
+	require.Equal(t, HardenedOffset+ETHCoinType, NewEthBIP44Path(0, 0)[1])
+
+	// a key off the curve has no address
+	var invalid PubKeyEthSecp256k1
+	require.NotPanics(t, func() { require.Empty(t, invalid.Address()) })
+}
+
+func TestPrivKeyLedgerEthSecp256k1(t *testing.T) {
+	device := newMockLedger()
+	discover := discoverLedgerETH
+	defer func() { discoverLedgerETH = discover }()
+
+	discoverLedgerETH = nil
+	_, err := NewPrivKeyLedgerEthSecp256k1(NewEthBIP44Path(0, 0))
+	require.Error(t, err)
+
+	discoverLedgerETH = func() (LedgerETHSECP256K1, error) { return device, nil }
+	key, err := NewPrivKeyLedgerEthSecp256k1(NewEthBIP44Path(0, 0))
+	require.NoError(t, err)
+	priv := key.(*PrivKeyLedgerEthSecp256k1)
+
+	pub, ok := priv.PubKey().(PubKeyEthSecp256k1)
+	require.True(t, ok)
+	require.Equal(t, device.priv.PubKey().SerializeCompressed(), pub[:])
+	require.Equal(t, tmcrypto.Address(Keccak256(device.priv.PubKey().SerializeUncompressed()[1:])[12:]), pub.Address())
+	require.Equal(t, EthAddressHex(pub.Address()), priv.EthAddress())
+
+	msg := []byte("bsc personal message")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.Contains(t, []byte{27, 28}, sig[64])
+	require.True(t, pub.VerifyBytes(msg, sig))
+	require.True(t, pub.VerifyBytes(msg, sig[:64]))
+	require.False(t, pub.VerifyBytes([]byte("other message"), sig))
+
+	// the recovery id recovers the key, as Ethereum tooling expects
+	recovered, _, err := ecdsa.RecoverCompact(append([]byte{sig[64]}, sig[:64]...), EIP191Hash(msg))
+	require.NoError(t, err)
+	require.True(t, recovered.IsEqual(device.priv.PubKey()))
+
+	// the key survives the amino encoding and signs once a device is found
+	var decoded *PrivKeyLedgerEthSecp256k1
+	require.NoError(t, cdc.UnmarshalBinaryBare(priv.Bytes(), &decoded))
+	require.True(t, priv.Equals(decoded))
+	discoverLedgerETH = nil
+	_, err = decoded.Sign(msg)
+	require.Equal(t, ErrLedgerNotConnected, err)
+	discoverLedgerETH = func() (LedgerETHSECP256K1, error) { return device, nil }
+	_, err = decoded.Sign(msg)
+	require.NoError(t, err)
+
+	// a device holding another key is not trusted
+	other := newMockLedger()
+	other.priv, _ = btcec.PrivKeyFromBytes(tmcrypto.Sha256([]byte("other ledger")))
+	decoded.ledger = other
+	_, err = decoded.Sign(msg)
+	require.Error(t, err)
+
+	device.rejectSign = true
+	_, err = priv.Sign(msg)
+	require.Error(t, err)
+
+	// a closed key discovers the device again
+	device.rejectSign = false
+	require.NoError(t, priv.Close())
+	require.Nil(t, priv.ledger)
+	_, err = priv.Sign(msg)
+	require.NoError(t, err)
+}
+
+func TestSignConfirmFn(t *testing.T) {
+	device := newMockLedger()
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 1}
+	priv := newMockPrivKeyLedger(t, device)
+
+	var prompts []string
+	priv.ConfirmFn = func(prompt string) (bool, error) {
+		// the address is displayed before the caller is asked
+		require.NotEmpty(t, device.shownHRP)
+		prompts = append(prompts, prompt)
+		return true, nil
+	}
+	sig, err := priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(mockSignDoc, sig))
+	_, expected, err := priv.addressConfirmation()
+	require.NoError(t, err)
+	require.Equal(t, []string{expected}, prompts)
+
+	priv.ConfirmFn = func(string) (bool, error) { return false, nil }
+	_, err = priv.Sign(mockSignDoc)
+	require.Error(t, err)
+
+	// the policies other than ConfirmInteractive never display the address
+	device.shownHRP = ""
+	priv.ConfirmPolicy = ConfirmAuto
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Empty(t, device.shownHRP)
+
+	device.signCalls = 0
+	priv.ConfirmPolicy = ConfirmFail
+	_, err = priv.Sign(mockSignDoc)
+	require.Error(t, err)
+	require.Empty(t, device.shownHRP)
+	require.Zero(t, device.signCalls)
+
+	// apps which don't require a confirmation never call it
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 0}
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+}
+
+// mockHWSigner emulates a non Ledger backend, signing with the key of a mock
+// Ledger.
+type mockHWSigner struct {
+	*mockLedger
+	closed              bool
+	requireConfirmation bool
+}
+
+func (m *mockHWSigner) GetPubKey(path DerivationPath) ([]byte, error) {
+	return m.GetPublicKeySECP256K1(path)
+}
+
+func (m *mockHWSigner) ShowAddress(path DerivationPath, hrp string) error {
+	return m.ShowAddressSECP256K1(path, hrp)
+}
+
+func (m *mockHWSigner) Sign(path DerivationPath, msg []byte) ([]byte, error) {
+	return m.SignSECP256K1(path, msg)
+}
+
+func (m *mockHWSigner) Version() (string, error) {
+	return "2.4.1", nil
+}
+
+func (m *mockHWSigner) RequiresAddressConfirmation() (bool, error) {
+	return m.requireConfirmation, nil
+}
+
+func (m *mockHWSigner) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestHWSignerBackends(t *testing.T) {
+	signer := &mockHWSigner{mockLedger: newMockLedger()}
+	discoveries := 0
+	RegisterHWSignerBackend("trezor", func() (HWSigner, error) {
+		discoveries++
+		return signer, nil
+	})
+	t.Cleanup(func() {
+		hwSignerBackendsMtx.Lock()
+		delete(hwSignerBackends, "trezor")
+		hwSignerBackendsMtx.Unlock()
+	})
+	require.Equal(t, []string{LedgerDeviceType, "trezor"}, HWSignerBackends())
+	require.Panics(t, func() { RegisterHWSignerBackend("trezor", nil) })
+
+	_, err := DiscoverHWSigner("yubihsm")
+	require.Error(t, err)
+
+	key, err := NewPrivKeyHWSecp256k1("trezor", DefaultBIP44Path())
+	require.NoError(t, err)
+	priv := key.(*PrivKeyLedgerSecp256k1)
+	require.Equal(t, newMockPrivKeyLedger(t, newMockLedger()).PubKey(), priv.PubKey())
+
+	sig, err := priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(mockSignDoc, sig))
+
+	// the device is released and re-discovered through the backend
+	require.NoError(t, priv.Close())
+	require.True(t, signer.closed)
+	_, err = priv.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, 2, discoveries)
+
+	// the backend, not the Ledger app version, decides on the confirmation
+	signer.version = ledgergo.VersionInfo{Major: 2}
+	required, err := priv.RequiresAddressConfirmation()
+	require.NoError(t, err)
+	require.False(t, required)
+	signer.requireConfirmation = true
+	priv.ConfirmPolicy = ConfirmFail
+	_, err = priv.Sign(mockSignDoc)
+	require.Error(t, err)
+	signer.requireConfirmation = false
+
+	// the backend is persisted, so a decoded key re-discovers through it
+	decoded, err := PrivKeyLedgerSecp256k1FromBytes(priv.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "trezor", decoded.DeviceType)
+	_, err = decoded.Sign(mockSignDoc)
+	require.NoError(t, err)
+	require.Equal(t, 3, discoveries)
+
+	// the Ledger backend uses the Ledger discovery
+	device := newMockLedger()
+	setDiscoverLedger(t, func() (LedgerSECP256K1, error) { return device, nil })
+	key, err = NewPrivKeyHWSecp256k1(LedgerDeviceType, DefaultBIP44Path())
+	require.NoError(t, err)
+	require.Equal(t, device, key.(*PrivKeyLedgerSecp256k1).ledger)
+
+	// and keeps the address confirmation rule of the Ledger app
+	ledgerSigner, err := DiscoverHWSigner(LedgerDeviceType)
+	require.NoError(t, err)
+	device.version = ledgergo.VersionInfo{Major: 1, Minor: 1}
+	required, err = ledgerSigner.(HWSignerAddressConfirmer).RequiresAddressConfirmation()
+	require.NoError(t, err)
+	require.True(t, required)
+
+	// the version of a Ledger is the one of its Cosmos app
+	version, err := ledgerSigner.Version()
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", version)
+	version, err = signer.Version()
+	require.NoError(t, err)
+	require.Equal(t, "2.4.1", version)
+}
+
+func setEnumerateLedgers(t *testing.T, devices ...*ledgerHub) {
+	previous := enumerateLedgers
+	t.Cleanup(func() {
+		enumerateLedgers = previous
+		SelectLedger("")
+	})
+	enumerateLedgers = func() ([]LedgerDevice, error) {
+		var connected []LedgerDevice
+		for i, hub := range devices {
+			device, err := hub.acquire()
+			if err != nil {
+				closeLedgers(connected)
+				return nil, err
+			}
+			connected = append(connected, LedgerDevice{Serial: fmt.Sprintf("serial-%d", i), Device: device})
+		}
+		return connected, nil
+	}
+}
+
+func TestEnumerateLedgers(t *testing.T) {
+	first := &ledgerHub{device: newMockLedger()}
+	second := &ledgerHub{device: newMockLedger()}
+	second.device.priv, _ = btcec.PrivKeyFromBytes(tmcrypto.Sha256([]byte("second ledger")))
+	second.device.version = ledgergo.VersionInfo{Major: 1, Minor: 1, Patch: 2}
+
+	_, err := EnumerateLedgers()
+	require.Error(t, err)
+
+	setEnumerateLedgers(t, first, second)
+	infos, err := EnumerateLedgers()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.False(t, first.held || second.held)
+
+	firstKey := newMockPrivKeyLedger(t, first.device)
+	secondKey := newMockPrivKeyLedger(t, second.device)
+	require.Equal(t, LedgerDeviceInfo{Index: 0, Serial: "serial-0", Version: first.device.version, Address: firstKey.AccAddress()}, infos[0])
+	require.Equal(t, LedgerDeviceInfo{Index: 1, Serial: "serial-1", Version: second.device.version, Address: secondKey.AccAddress()}, infos[1])
+
+	// devices are selected by index or serial, the others are released
+	setDiscoverLedger(t, first.acquire)
+	for _, selector := range []string{"1", "serial-1"} {
+		SelectLedger(selector)
+		key, err := NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+		require.NoError(t, err)
+		require.True(t, key.Equals(secondKey))
+		require.False(t, first.held)
+		require.True(t, second.held)
+		require.NoError(t, key.(*PrivKeyLedgerSecp256k1).Close())
+	}
+
+	for _, selector := range []string{"2", "-1", "serial-9"} {
+		SelectLedger(selector)
+		_, err = NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+		require.Error(t, err, selector)
+		require.False(t, first.held || second.held)
+	}
+
+	// without a selection the discovery is used
+	SelectLedger("")
+	key, err := NewPrivKeyLedgerSecp256k1(DefaultBIP44Path())
+	require.NoError(t, err)
+	require.True(t, key.Equals(firstKey))
+}
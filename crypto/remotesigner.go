@@ -0,0 +1,286 @@
+package crypto
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	amino "github.com/tendermint/go-amino"
+
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	p2pconn "github.com/tendermint/tendermint/p2p/conn"
+	"github.com/tendermint/tendermint/privval"
+)
+
+const (
+	// DefaultRemoteSignerTimeout is the timeout of a request to a remote
+	// signer, connection included, when the key sets none.
+	DefaultRemoteSignerTimeout = 5 * time.Second
+
+	// maxRemoteSignerMsgSize bounds the messages read from a remote signer
+	maxRemoteSignerMsgSize = 1024 * 1024
+)
+
+type (
+	// RemoteSignerMsg is sent between the clients and the servers of the
+	// remote signer protocol. Each connection carries a single request
+	// followed by its response, amino encoded and length prefixed.
+	RemoteSignerMsg interface{}
+
+	// RemoteSignerPubKeyRequest requests the public key of KeyID.
+	RemoteSignerPubKeyRequest struct {
+		KeyID string
+	}
+
+	// RemoteSignerPubKeyResponse is the response to a RemoteSignerPubKeyRequest.
+	RemoteSignerPubKeyResponse struct {
+		PubKey tmcrypto.PubKey
+		Error  *privval.RemoteSignerError
+	}
+
+	// RemoteSignerSignRequest requests the signature of Msg with KeyID.
+	RemoteSignerSignRequest struct {
+		KeyID string
+		Msg   []byte
+	}
+
+	// RemoteSignerSignResponse is the response to a RemoteSignerSignRequest.
+	RemoteSignerSignResponse struct {
+		Signature []byte
+		Error     *privval.RemoteSignerError
+	}
+
+	// PrivKeyRemote implements PrivKey for a key held by a remote signer, a
+	// separate process which signs on request, so the keys of automated
+	// services such as oracles or relayers need not live in their process.
+	// We cache the PubKey from the signer to use it later.
+	//
+	// The signer is reached over a unix socket, whose access is controlled by
+	// its file permissions, or over TCP, encrypted and authenticated by the
+	// handshake of the tendermint secret connection.
+	//
+	// Only CachedPubKey, Address, KeyID and SignerPubKey are persisted, the
+	// other exported fields are runtime options.
+	PrivKeyRemote struct {
+		CachedPubKey tmcrypto.PubKey
+		// Address is the address of the signer, either unix:///path/to/socket
+		// or tcp://host:port.
+		Address string
+		// KeyID identifies the key among the keys of the signer.
+		KeyID string
+		// SignerPubKey is the identity of the signer, which must match the
+		// key the signer authenticates with over TCP.
+		SignerPubKey tmcrypto.PubKey
+
+		// ClientKey is the identity the client authenticates with over TCP,
+		// for the signer to authorize it. An ephemeral key is used if unset.
+		ClientKey tmcrypto.PrivKey `json:"-"`
+
+		// Timeout, if non-zero, replaces DefaultRemoteSignerTimeout.
+		Timeout time.Duration `json:"-"`
+	}
+)
+
+// remoteSignerCdc encodes the messages of the remote signer protocol, apart
+// from the codec of the keys.
+var remoteSignerCdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(remoteSignerCdc)
+	RegisterRemoteSignerMsg(remoteSignerCdc)
+}
+
+// RegisterRemoteSignerMsg registers the messages of the remote signer protocol
+// in the given (amino) codec.
+func RegisterRemoteSignerMsg(cdc *amino.Codec) {
+	cdc.RegisterInterface((*RemoteSignerMsg)(nil), nil)
+	cdc.RegisterConcrete(&RemoteSignerPubKeyRequest{}, "cosmos-sdk/remotesigner/PubKeyRequest", nil)
+	cdc.RegisterConcrete(&RemoteSignerPubKeyResponse{}, "cosmos-sdk/remotesigner/PubKeyResponse", nil)
+	cdc.RegisterConcrete(&RemoteSignerSignRequest{}, "cosmos-sdk/remotesigner/SignRequest", nil)
+	cdc.RegisterConcrete(&RemoteSignerSignResponse{}, "cosmos-sdk/remotesigner/SignResponse", nil)
+}
+
+// NewPrivKeyRemote returns the key keyID of the signer at address, caching its
+// public key. signerPubKey is the identity of the signer, required over TCP,
+// and clientKey the identity of the client, see PrivKeyRemote.
+func NewPrivKeyRemote(address, keyID string, signerPubKey tmcrypto.PubKey, clientKey tmcrypto.PrivKey) (*PrivKeyRemote, error) {
+	pkr := &PrivKeyRemote{
+		Address:      address,
+		KeyID:        keyID,
+		SignerPubKey: signerPubKey,
+		ClientKey:    clientKey,
+	}
+	if err := pkr.validateAddress(); err != nil {
+		return nil, err
+	}
+
+	pubKey, err := pkr.getPubKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create PrivKeyRemote")
+	}
+
+	pkr.CachedPubKey = pubKey
+	return pkr, nil
+}
+
+// PubKey returns the cached public key.
+func (pkr PrivKeyRemote) PubKey() tmcrypto.PubKey {
+	return pkr.CachedPubKey
+}
+
+// ValidateKey checks that the signer is reachable and still holds the key
+// of the cached public key.
+func (pkr PrivKeyRemote) ValidateKey() error {
+	pub, err := pkr.getPubKey()
+	if err != nil {
+		return err
+	}
+	if !pub.Equals(pkr.CachedPubKey) {
+		return fmt.Errorf("cached key does not match retrieved key")
+	}
+	return nil
+}
+
+// AssertIsPrivKeyInner implements the PrivKey interface. It performs a no-op.
+func (pkr *PrivKeyRemote) AssertIsPrivKeyInner() {}
+
+// Bytes implements the PrivKey interface. It stores the cached public key and
+// where to reach the signer, so we can verify the same key when we reconnect.
+func (pkr PrivKeyRemote) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pkr)
+}
+
+// Equals implements the PrivKey interface. It makes sure two private keys
+// refer to the same public key.
+func (pkr PrivKeyRemote) Equals(other tmcrypto.PrivKey) bool {
+	if remote, ok := other.(*PrivKeyRemote); ok {
+		return pkr.CachedPubKey.Equals(remote.CachedPubKey)
+	}
+
+	return false
+}
+
+// Sign requests the signature of msg from the signer. The signature is
+// verified against the cached public key, so a signer answering with another
+// key is detected before the signature is used.
+func (pkr *PrivKeyRemote) Sign(msg []byte) ([]byte, error) {
+	res, err := pkr.request(&RemoteSignerSignRequest{KeyID: pkr.KeyID, Msg: msg})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, ok := res.(*RemoteSignerSignResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T from remote signer", res)
+	}
+	if signed.Error != nil {
+		return nil, signed.Error
+	}
+	if !pkr.CachedPubKey.VerifyBytes(msg, signed.Signature) {
+		return nil, fmt.Errorf("invalid signature of key %s from remote signer", pkr.KeyID)
+	}
+
+	return signed.Signature, nil
+}
+
+// getPubKey requests the public key of the key from the signer.
+func (pkr PrivKeyRemote) getPubKey() (tmcrypto.PubKey, error) {
+	res, err := pkr.request(&RemoteSignerPubKeyRequest{KeyID: pkr.KeyID})
+	if err != nil {
+		return nil, err
+	}
+
+	pub, ok := res.(*RemoteSignerPubKeyResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T from remote signer", res)
+	}
+	if pub.Error != nil {
+		return nil, pub.Error
+	}
+	if pub.PubKey == nil {
+		return nil, fmt.Errorf("no public key of key %s from remote signer", pkr.KeyID)
+	}
+
+	return pub.PubKey, nil
+}
+
+// request sends req to the signer over a new connection and returns its
+// response.
+func (pkr PrivKeyRemote) request(req RemoteSignerMsg) (res RemoteSignerMsg, err error) {
+	timeout := pkr.Timeout
+	if timeout == 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+
+	conn, err := pkr.dial(timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := remoteSignerCdc.MarshalBinaryLengthPrefixedWriter(conn, req); err != nil {
+		return nil, errors.Wrap(err, "failed to write to remote signer")
+	}
+	if _, err := remoteSignerCdc.UnmarshalBinaryLengthPrefixedReader(conn, &res, maxRemoteSignerMsgSize); err != nil {
+		return nil, errors.Wrap(err, "failed to read from remote signer")
+	}
+
+	return res, nil
+}
+
+// dial connects to the signer, authenticating it over TCP. The deadline of
+// the connection covers the whole request.
+func (pkr PrivKeyRemote) dial(timeout time.Duration) (net.Conn, error) {
+	if err := pkr.validateAddress(); err != nil {
+		return nil, err
+	}
+
+	protocol, address := cmn.ProtocolAndAddress(pkr.Address)
+	conn, err := net.DialTimeout(protocol, address, timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to remote signer")
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if protocol == "unix" {
+		return conn, nil
+	}
+
+	clientKey := pkr.ClientKey
+	if clientKey == nil {
+		clientKey = ed25519.GenPrivKey()
+	}
+	sc, err := p2pconn.MakeSecretConnection(conn, clientKey)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to establish secret connection with remote signer")
+	}
+	if !sc.RemotePubKey().Equals(pkr.SignerPubKey) {
+		sc.Close()
+		return nil, fmt.Errorf("remote signer authenticated with unexpected key %X", sc.RemotePubKey().Bytes())
+	}
+
+	return sc, nil
+}
+
+// validateAddress checks that the signer is reached over a unix socket, or
+// over TCP with a known identity.
+func (pkr PrivKeyRemote) validateAddress() error {
+	switch protocol, _ := cmn.ProtocolAndAddress(pkr.Address); protocol {
+	case "unix":
+		return nil
+	case "tcp":
+		if pkr.SignerPubKey == nil {
+			return errors.New("the public key of the remote signer is required over tcp")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported protocol %q of remote signer %s, expected unix or tcp", protocol, pkr.Address)
+	}
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	p2pconn "github.com/tendermint/tendermint/p2p/conn"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// testRemoteSigner serves the keys of its map, authenticating with identity
+// over TCP.
+type testRemoteSigner struct {
+	listener net.Listener
+	identity tmcrypto.PrivKey
+	keys     map[string]tmcrypto.PrivKey
+}
+
+func newTestRemoteSigner(t *testing.T, protocol, address string) *testRemoteSigner {
+	listener, err := net.Listen(protocol, address)
+	require.NoError(t, err)
+	signer := &testRemoteSigner{
+		listener: listener,
+		identity: ed25519.GenPrivKey(),
+		keys:     map[string]tmcrypto.PrivKey{"oracle": secp256k1.GenPrivKey()},
+	}
+	go signer.serve()
+	return signer
+}
+
+func (s *testRemoteSigner) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testRemoteSigner) handle(conn net.Conn) {
+	defer conn.Close()
+	var rw io.ReadWriter = conn
+	if _, ok := conn.(*net.TCPConn); ok {
+		sc, err := p2pconn.MakeSecretConnection(conn, s.identity)
+		if err != nil {
+			return
+		}
+		rw = sc
+	}
+
+	var req RemoteSignerMsg
+	if _, err := remoteSignerCdc.UnmarshalBinaryLengthPrefixedReader(rw, &req, maxRemoteSignerMsgSize); err != nil {
+		return
+	}
+	var res RemoteSignerMsg
+	switch req := req.(type) {
+	case *RemoteSignerPubKeyRequest:
+		key, ok := s.keys[req.KeyID]
+		if !ok {
+			res = &RemoteSignerPubKeyResponse{Error: &privval.RemoteSignerError{Code: 1, Description: "unknown key"}}
+			break
+		}
+		res = &RemoteSignerPubKeyResponse{PubKey: key.PubKey()}
+	case *RemoteSignerSignRequest:
+		sig, _ := s.keys[req.KeyID].Sign(req.Msg)
+		res = &RemoteSignerSignResponse{Signature: sig}
+	}
+	_, _ = remoteSignerCdc.MarshalBinaryLengthPrefixedWriter(rw, res)
+}
+
+func TestPrivKeyRemoteTCP(t *testing.T) {
+	signer := newTestRemoteSigner(t, "tcp", "127.0.0.1:0")
+	defer signer.listener.Close()
+	address := "tcp://" + signer.listener.Addr().String()
+
+	// the identity of the signer is required and checked
+	_, err := NewPrivKeyRemote(address, "oracle", nil, nil)
+	require.Error(t, err)
+	_, err = NewPrivKeyRemote(address, "oracle", ed25519.GenPrivKey().PubKey(), nil)
+	require.Error(t, err)
+	_, err = NewPrivKeyRemote(address, "relayer", signer.identity.PubKey(), nil)
+	require.Error(t, err)
+
+	priv, err := NewPrivKeyRemote(address, "oracle", signer.identity.PubKey(), ed25519.GenPrivKey())
+	require.NoError(t, err)
+	require.Equal(t, signer.keys["oracle"].PubKey(), priv.PubKey())
+	require.NoError(t, priv.ValidateKey())
+
+	msg := []byte("{\"account_number\":\"3\",\"chain_id\":\"1234\"}")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(msg, sig))
+
+	// the connection settings are persisted, the client key is not
+	var decoded tmcrypto.PrivKey
+	require.NoError(t, cdc.UnmarshalBinaryBare(priv.Bytes(), &decoded))
+	remote, ok := decoded.(*PrivKeyRemote)
+	require.True(t, ok)
+	require.True(t, remote.Equals(priv))
+	require.Equal(t, address, remote.Address)
+	require.Equal(t, "oracle", remote.KeyID)
+	require.Nil(t, remote.ClientKey)
+	sig, err = remote.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, priv.PubKey().VerifyBytes(msg, sig))
+
+	// a signature of another key is rejected
+	remote.CachedPubKey = secp256k1.GenPrivKey().PubKey()
+	_, err = remote.Sign(msg)
+	require.Error(t, err)
+}
+
+func TestPrivKeyRemoteUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "signer.sock")
+
+	signer := newTestRemoteSigner(t, "unix", socket)
+	defer signer.listener.Close()
+
+	priv, err := NewPrivKeyRemote("unix://"+socket, "oracle", nil, nil)
+	require.NoError(t, err)
+	msg := []byte("message")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, signer.keys["oracle"].PubKey().VerifyBytes(msg, sig))
+
+	_, err = NewPrivKeyRemote("udp://127.0.0.1:0", "oracle", nil, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const (
+	flagAppDBBackend = "app-db-backend"
+
+	// the writes of a batch of the migration of the application db
+	migrateBatchSize = 10000
+)
+
+// MigrateAppDBCmd migrates the application db of a stopped node to another
+// backend.
+func MigrateAppDBCmd(ctx *Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate-app-db [backend]",
+		Short: "Migrate the application db to another backend",
+		Long: `Copy the application db of the stopped node from the backend of --app-db-backend,
+db_backend of the config by default, into a db of backend, e.g. boltdb, then
+move it in place of data/application.db. The former db is kept as
+data/application.db.<former backend>.bak until it is removed by hand. The node
+must then be started with --app-db-backend set to backend.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := appDBBackend(ctx.Config), dbm.DBBackendType(args[0])
+			if from == to {
+				return errors.Errorf("the application db is already a %s db", to)
+			}
+			count, err := migrateAppDB(ctx.Config.DBDir(), from, to)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Migrated %d entries of the application db from %s to %s, start the node with --%s %s\n",
+				count, from, to, flagAppDBBackend, to)
+			return nil
+		},
+	}
+}
+
+// migrateAppDB copies the application db of dataDir from the backend from to
+// the backend to, then moves the copy in place of the former db which is kept
+// as a backup. It returns the number of the copied entries.
+func migrateAppDB(dataDir string, from, to dbm.DBBackendType) (int, error) {
+	dbPath := filepath.Join(dataDir, "application.db")
+	backupPath := fmt.Sprintf("%s.%s.bak", dbPath, from)
+	if _, err := os.Stat(dbPath); err != nil {
+		return 0, errors.Wrap(err, "no application db to migrate")
+	}
+	if _, err := os.Stat(backupPath); err == nil {
+		return 0, errors.Errorf("the backup %s already exists", backupPath)
+	}
+
+	// the copy is made aside so a failed migration leaves the db untouched
+	tmpDir := filepath.Join(dataDir, "application-migration")
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tmpDir)
+	count, err := copyAppDB(dataDir, from, tmpDir, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(dbPath, backupPath); err != nil {
+		return 0, err
+	}
+	if err = os.Rename(filepath.Join(tmpDir, "application.db"), dbPath); err != nil {
+		// restore the former db
+		if restoreErr := os.Rename(backupPath, dbPath); restoreErr != nil {
+			return 0, errors.Wrapf(err, "failed to restore %s from %s: %v", dbPath, backupPath, restoreErr)
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// copyAppDB copies the entries of the application db of srcDir into the one
+// of dstDir, which is created.
+func copyAppDB(srcDir string, from dbm.DBBackendType, dstDir string, to dbm.DBBackendType) (int, error) {
+	src, err := openDataDB(srcDir, from)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+	if err = os.MkdirAll(dstDir, 0700); err != nil {
+		return 0, err
+	}
+	dst, err := openDataDB(dstDir, to)
+	if err != nil {
+		return 0, err
+	}
+	defer dst.Close()
+	return copyDB(dst, src, migrateBatchSize), nil
+}
+
+// copyDB writes the entries of src into dst by batches of batchSize, the last
+// batch is written synchronously.
+func copyDB(dst, src dbm.DB, batchSize int) int {
+	iter := src.Iterator(nil, nil)
+	defer iter.Close()
+
+	count := 0
+	batch := dst.NewBatch()
+	for ; iter.Valid(); iter.Next() {
+		batch.Set(iter.Key(), iter.Value())
+		count++
+		if count%batchSize == 0 {
+			batch.Write()
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	batch.WriteSync()
+	batch.Close()
+	return count
+}
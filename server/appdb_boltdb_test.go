@@ -0,0 +1,14 @@
+//go:build boltdb
+// +build boltdb
+
+package server
+
+import (
+	"testing"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestMigrateAppDBToBoltDB(t *testing.T) {
+	testMigrateAppDB(t, dbm.BoltDBBackend)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// write an application db of n entries into dataDir
+func writeTestAppDB(t *testing.T, dataDir string, backend dbm.DBBackendType, n int) {
+	db, err := openDataDB(dataDir, backend)
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		db.Set([]byte(fmt.Sprintf("key%05d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	db.Close()
+}
+
+func testMigrateAppDB(t *testing.T, to dbm.DBBackendType) {
+	dataDir, err := os.MkdirTemp("", "migrate-app-db")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, err = migrateAppDB(dataDir, dbm.GoLevelDBBackend, to)
+	require.Error(t, err, "no db to migrate")
+
+	// the entries span several batches
+	n := migrateBatchSize + 10
+	writeTestAppDB(t, dataDir, dbm.GoLevelDBBackend, n)
+	count, err := migrateAppDB(dataDir, dbm.GoLevelDBBackend, to)
+	require.NoError(t, err)
+	require.Equal(t, n, count)
+
+	db, err := openDataDB(dataDir, to)
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), db.Get([]byte(fmt.Sprintf("key%05d", i))))
+	}
+	db.Close()
+
+	// the former db is kept, and is not overwritten by another migration
+	_, err = os.Stat(filepath.Join(dataDir, "application.db.goleveldb.bak"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dataDir, "application-migration"))
+	require.True(t, os.IsNotExist(err))
+	_, err = migrateAppDB(dataDir, dbm.GoLevelDBBackend, to)
+	require.Error(t, err)
+}
+
+func TestMigrateAppDB(t *testing.T) {
+	testMigrateAppDB(t, dbm.GoLevelDBBackend)
+}
+
+func TestOpenDBUnknownBackend(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "open-app-db")
+	require.NoError(t, err)
+	defer os.RemoveAll(dataDir)
+
+	_, err = openDataDB(dataDir, "unknown")
+	require.Error(t, err)
+}
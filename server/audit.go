@@ -0,0 +1,59 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+const flagAuditWiring = "audit-wiring"
+
+// wiringAuditor is an app checking its wiring against the expectations of
+// its modules, see BaseApp.AuditModuleWiring.
+type wiringAuditor interface {
+	AuditWiring() baseapp.WiringReport
+}
+
+// auditWiring fails with the report of the issues of the wiring of app.
+func auditWiring(app abci.Application) error {
+	auditor, ok := app.(wiringAuditor)
+	if !ok {
+		return errors.New("the app cannot audit its wiring")
+	}
+	if report := auditor.AuditWiring(); !report.OK() {
+		return errors.Errorf("the wiring of the app is broken:\n%s", report)
+	}
+	return nil
+}
+
+// AuditWiringCmd checks the wiring of the app: its stores, msg routes,
+// querier routes and codec registrations.
+func AuditWiringCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit-wiring",
+		Short: "Check the wiring of the stores, routes and codec of the app",
+		Long: `Create the app over the application db of the stopped node and check that the
+store of every module is mounted, every msg has a handler for its route,
+every querier route is registered, and no two modules share a store or the
+codec prefix of a type. Every issue found is reported. Start the node with
+--audit-wiring to run the same checks before it starts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := openDB(viper.GetString("home"), appDBBackend(ctx.Config))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			if err := auditWiring(appCreator(ctx.Logger, db, nil)); err != nil {
+				return err
+			}
+			fmt.Println("The wiring of the app has no issue")
+			return nil
+		},
+	}
+}
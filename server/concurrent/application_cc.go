@@ -7,3 +7,10 @@ type ApplicationCC interface {
 	PreCheckTx(req types.RequestCheckTx) types.ResponseCheckTx
 	PreDeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx
 }
+
+// ConcurrentQuerier is implemented by the apps serving some queries from the
+// committed state only, with their own locking against Commit, so that these
+// queries run concurrently with CheckTx and DeliverTx.
+type ConcurrentQuerier interface {
+	IsConcurrentQuery(req types.RequestQuery) bool
+}
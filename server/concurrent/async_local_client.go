@@ -18,6 +18,8 @@ var _ abcicli.Client = (*asyncLocalClient)(nil)
 // It makes ABCI calling more complex:
 // 1. CheckTx/DeliverTx/Query/Info can be called concurrently
 // 2. Other API would block calling CheckTx/DeliverTx/Query
+// 3. With query workers, the queries of a ConcurrentQuerier reading the
+//    committed state do not wait on CheckTx/DeliverTx at all
 
 const (
 	WorkerPoolSize  = 16
@@ -39,6 +41,7 @@ type localAsyncClientCreator struct {
 	checkTxMidLock *sync.Mutex
 	wgCommit       *sync.WaitGroup
 	rwLock         *sync.RWMutex
+	queryWorkers   chan struct{}
 }
 
 type asyncLocalClient struct {
@@ -58,6 +61,9 @@ type asyncLocalClient struct {
 	checkTxQueue   chan WorkItem
 	deliverTxQueue chan WorkItem
 	log            log.Logger
+
+	// may be nil, a slot per concurrent query
+	queryWorkers chan struct{}
 }
 
 func NewAsyncLocalClient(app types.Application, log log.Logger,
@@ -245,9 +251,7 @@ func (app *asyncLocalClient) ReCheckTxAsync(req types.RequestCheckTx) *abcicli.R
 
 // QueryAsync is supposed to run concurrently when there is no CheckTx/DeliverTx/Commit
 func (app *asyncLocalClient) QueryAsync(req types.RequestQuery) *abcicli.ReqRes {
-	app.rwLock.RLock()
-	res := app.Application.Query(req)
-	app.rwLock.RUnlock()
+	res := app.query(req)
 	return app.callback(
 		types.ToRequestQuery(req),
 		types.ToResponseQuery(res),
@@ -356,12 +360,25 @@ func (app *asyncLocalClient) CheckTxSync(req types.RequestCheckTx) (*types.Respo
 }
 
 func (app *asyncLocalClient) QuerySync(req types.RequestQuery) (*types.ResponseQuery, error) {
-	app.rwLock.RLock()
-	res := app.Application.Query(req)
-	app.rwLock.RUnlock()
+	res := app.query(req)
 	return &res, nil
 }
 
+// query runs req on a query worker if the app serves it from the committed
+// state, otherwise along the other read ABCI calls.
+func (app *asyncLocalClient) query(req types.RequestQuery) types.ResponseQuery {
+	if app.queryWorkers != nil {
+		if querier, ok := app.Application.(ConcurrentQuerier); ok && querier.IsConcurrentQuery(req) {
+			app.queryWorkers <- struct{}{}
+			defer func() { <-app.queryWorkers }()
+			return app.Application.Query(req)
+		}
+	}
+	app.rwLock.RLock()
+	defer app.rwLock.RUnlock()
+	return app.Application.Query(req)
+}
+
 func (app *asyncLocalClient) CommitSync() (*types.ResponseCommit, error) {
 	app.log.Debug("Trying to get CommitSync Lock")
 	app.checkTxMidLock.Lock()
@@ -440,8 +457,11 @@ func newLocalReqRes(req *types.Request, res *types.Response) *abcicli.ReqRes {
 	return reqRes
 }
 
-func NewAsyncLocalClientCreator(app types.Application, log log.Logger) proxy.ClientCreator {
-	return &localAsyncClientCreator{
+// NewAsyncLocalClientCreator returns the creator of the async clients of app,
+// up to queryWorkers of their queries run concurrently with the txs if app is
+// a ConcurrentQuerier, 0 runs all the queries along the other read ABCI calls.
+func NewAsyncLocalClientCreator(app types.Application, log log.Logger, queryWorkers int) proxy.ClientCreator {
+	creator := &localAsyncClientCreator{
 		app:            app,
 		log:            log,
 		rwLock:         new(sync.RWMutex),
@@ -450,9 +470,17 @@ func NewAsyncLocalClientCreator(app types.Application, log log.Logger) proxy.Cli
 		checkTxLowLock: new(sync.Mutex),
 		checkTxMidLock: new(sync.Mutex),
 	}
+	if queryWorkers > 0 {
+		creator.queryWorkers = make(chan struct{}, queryWorkers)
+	}
+	return creator
 }
 
 func (l *localAsyncClientCreator) NewABCIClient() (abcicli.Client, error) {
-	return NewAsyncLocalClient(l.app, l.log, l.rwLock, l.wgCommit,
-		l.commitLock, l.checkTxLowLock, l.checkTxMidLock), nil
+	cli := NewAsyncLocalClient(l.app, l.log, l.rwLock, l.wgCommit,
+		l.commitLock, l.checkTxLowLock, l.checkTxMidLock)
+	if cli != nil {
+		cli.queryWorkers = l.queryWorkers
+	}
+	return cli, nil
 }
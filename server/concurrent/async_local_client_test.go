@@ -157,3 +157,46 @@ func TestReadAPI(t *testing.T) {
 	assert.True(time.Now().Before(expectStop), "Run too slow")
 	cli.Stop()
 }
+
+type concurrentQueryApp struct {
+	*TimedApplication
+}
+
+func (app concurrentQueryApp) IsConcurrentQuery(types.RequestQuery) bool {
+	return true
+}
+
+func TestConcurrentQuery(t *testing.T) {
+	assert := assert.New(t)
+	app := concurrentQueryApp{&TimedApplication{}}
+	app.deliverTxSpan = time.Millisecond * 10
+	app.querySpan = time.Millisecond * 50
+	creator := NewAsyncLocalClientCreator(app, logger, 1)
+	abciCli, err := creator.NewABCIClient()
+	assert.NoError(err)
+	cli := abciCli.(*asyncLocalClient)
+	cli.Start()
+	cli.SetResponseCallback(func(*types.Request, *types.Response) {})
+	tx := make([]byte, 8)
+
+	// the txs do not wait on the queries in flight
+	start := time.Now()
+	go cli.QuerySync(types.RequestQuery{})
+	time.Sleep(time.Millisecond * 5) //wait for go routine to start.
+	cli.DeliverTxSync(types.RequestDeliverTx{Tx: tx})
+	assert.True(time.Since(start) < app.querySpan, "DeliverTx waited on the query")
+
+	// the queries wait on the query workers
+	start = time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cli.QuerySync(types.RequestQuery{})
+		}()
+	}
+	wg.Wait()
+	assert.True(time.Since(start) >= 2*app.querySpan, "Run too quick")
+	cli.Stop()
+}
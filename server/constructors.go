@@ -2,11 +2,14 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -17,15 +20,38 @@ type (
 	// application using various configurations.
 	AppCreator func(log.Logger, dbm.DB, io.Writer) abci.Application
 
-	// AppExporter is a function that dumps all app state to
-	// JSON-serializable structure and returns the current validator set.
-	AppExporter func(log.Logger, dbm.DB, io.Writer) (json.RawMessage, []tmtypes.GenesisValidator, error)
+	// AppExporter is a function that dumps all app state at a height, the
+	// latest if 0, to JSON-serializable structure and returns the validator
+	// set. The state is prepared for a restart from height zero if
+	// forZeroHeight.
+	AppExporter func(logger log.Logger, db dbm.DB, traceStore io.Writer, height int64, forZeroHeight bool) (
+		json.RawMessage, []tmtypes.GenesisValidator, error)
 )
 
-func openDB(rootDir string) (dbm.DB, error) {
-	dataDir := filepath.Join(rootDir, "data")
-	db, err := dbm.NewGoLevelDB("application", dataDir)
-	return db, err
+// appDBBackend returns the backend of the application db, the app-db-backend
+// flag or else the db_backend of the tendermint config.
+func appDBBackend(config *cfg.Config) dbm.DBBackendType {
+	if backend := viper.GetString(flagAppDBBackend); backend != "" {
+		return dbm.DBBackendType(backend)
+	}
+	return dbm.DBBackendType(config.DBBackend)
+}
+
+// openDB opens the application db of the data directory of rootDir, the
+// backends other than goleveldb and memdb are built in with the build tags
+// of tendermint, e.g. boltdb or cleveldb.
+func openDB(rootDir string, backend dbm.DBBackendType) (dbm.DB, error) {
+	return openDataDB(filepath.Join(rootDir, "data"), backend)
+}
+
+// openDataDB opens the application db of dataDir.
+func openDataDB(dataDir string, backend dbm.DBBackendType) (db dbm.DB, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to open the application db: %v", r)
+		}
+	}()
+	return dbm.NewDB("application", backend, dataDir), nil
 }
 
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
@@ -13,9 +13,11 @@ import (
 	"path"
 )
 
+const flagForZeroHeight = "for-zero-height"
+
 // ExportCmd dumps app state to JSON.
 func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export state to JSON",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -27,7 +29,7 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 			}
 
 			if emptyState {
-				fmt.Println("WARNING: State is not initialized. Returning genesis file.")
+				fmt.Fprintln(os.Stderr, "WARNING: State is not initialized. Returning genesis file.")
 				genesisFile := path.Join(home, "config", "genesis.json")
 				genesis, err := os.ReadFile(genesisFile)
 				if err != nil {
@@ -37,7 +39,7 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 				return nil
 			}
 
-			db, err := openDB(home)
+			db, err := openDB(home, appDBBackend(ctx.Config))
 			if err != nil {
 				return err
 			}
@@ -45,7 +47,9 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 			if err != nil {
 				return err
 			}
-			appState, validators, err := appExporter(ctx.Logger, db, traceWriter)
+			height := viper.GetInt64(flagHeight)
+			forZeroHeight := viper.GetBool(flagForZeroHeight)
+			appState, validators, err := appExporter(ctx.Logger, db, traceWriter, height, forZeroHeight)
 			if err != nil {
 				return errors.Errorf("error exporting state: %v\n", err)
 			}
@@ -67,6 +71,10 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 			return nil
 		},
 	}
+	cmd.Flags().Int64(flagHeight, 0, "Height of the state to export, the latest state if 0")
+	cmd.Flags().Bool(flagForZeroHeight, false,
+		"Export the state to restart the chain from height zero, the upgrade heights must be configured for the new chain")
+	return cmd
 }
 
 func isEmptyState(home string) (bool, error) {
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	tmstore "github.com/tendermint/tendermint/store"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagFrom   = "from"
+	flagTo     = "to"
+	flagFormat = "format"
+
+	// the msg types of the fees committed by the begin and end blockers
+	feeReportBeginBlock = "begin_block"
+	feeReportEndBlock   = "end_block"
+)
+
+// blockFeesReplayer is an app replaying the fees of its past blocks, e.g. an
+// app built on the BaseApp.
+type blockFeesReplayer interface {
+	ReplayBlockFees(req abci.RequestBeginBlock, txs [][]byte) (*baseapp.BlockFees, error)
+}
+
+// feeReport is the fees collected in the blocks between two heights, grouped
+// by msg type, by proposer and by denom.
+type feeReport struct {
+	FromHeight int64            `json:"from_height"`
+	ToHeight   int64            `json:"to_height"`
+	ByMsgType  []feeReportEntry `json:"by_msg_type"`
+	ByProposer []feeReportEntry `json:"by_proposer"`
+	ByDenom    []feeReportEntry `json:"by_denom"`
+}
+
+// feeReportEntry is the fees of a group. Count is the number of txs of a msg
+// type, of blocks of a proposer, or of txs and blockers paying a denom.
+type feeReportEntry struct {
+	Key   string    `json:"key"`
+	Fees  sdk.Coins `json:"fees"`
+	Count int64     `json:"count"`
+}
+
+// feeReportGroup accumulates the entries of a group of a report.
+type feeReportGroup map[string]*feeReportEntry
+
+func (g feeReportGroup) add(key string, fees sdk.Coins, count int64) {
+	entry, ok := g[key]
+	if !ok {
+		entry = &feeReportEntry{Key: key, Fees: sdk.Coins{}}
+		g[key] = entry
+	}
+	entry.Fees = entry.Fees.Plus(fees)
+	entry.Count += count
+}
+
+// entries returns the entries of the group sorted by key.
+func (g feeReportGroup) entries() []feeReportEntry {
+	entries := make([]feeReportEntry, 0, len(g))
+	for _, entry := range g {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// newFeeReport aggregates the fees of the blocks replayed between from and to.
+func newFeeReport(from, to int64, blocks []*baseapp.BlockFees) feeReport {
+	byMsgType, byProposer, byDenom := feeReportGroup{}, feeReportGroup{}, feeReportGroup{}
+	addFee := func(msgType string, fee sdk.Fee, count int64) sdk.Coins {
+		if fee.IsEmpty() {
+			if count > 0 {
+				byMsgType.add(msgType, nil, count)
+			}
+			return nil
+		}
+		byMsgType.add(msgType, fee.Tokens, count)
+		for _, coin := range fee.Tokens {
+			byDenom.add(coin.Denom, sdk.Coins{coin}, 1)
+		}
+		return fee.Tokens
+	}
+
+	for _, block := range blocks {
+		blockFees := sdk.Coins{}
+		blockFees = blockFees.Plus(addFee(feeReportBeginBlock, block.BeginBlockFee, 0))
+		for _, tx := range block.Txs {
+			blockFees = blockFees.Plus(addFee(tx.MsgType, tx.Fee, 1))
+		}
+		blockFees = blockFees.Plus(addFee(feeReportEndBlock, block.EndBlockFee, 0))
+		byProposer.add(block.Proposer.String(), blockFees, 1)
+	}
+
+	return feeReport{
+		FromHeight: from,
+		ToHeight:   to,
+		ByMsgType:  byMsgType.entries(),
+		ByProposer: byProposer.entries(),
+		ByDenom:    byDenom.entries(),
+	}
+}
+
+// writeCSV writes a row per group, key and denom of the report, and a row
+// without denom for the entries that collected no fee.
+func (r feeReport) writeCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"group", "key", "denom", "amount", "count"}); err != nil {
+		return err
+	}
+	groups := []struct {
+		name    string
+		entries []feeReportEntry
+	}{{"msg_type", r.ByMsgType}, {"proposer", r.ByProposer}, {"denom", r.ByDenom}}
+	for _, group := range groups {
+		for _, entry := range group.entries {
+			count := strconv.FormatInt(entry.Count, 10)
+			if len(entry.Fees) == 0 {
+				if err := out.Write([]string{group.name, entry.Key, "", "0", count}); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, coin := range entry.Fees {
+				if err := out.Write([]string{group.name, entry.Key, coin.Denom, strconv.FormatInt(coin.Amount, 10), count}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// FeeReportCmd replays the blocks of a height range and reports the fees
+// collected in them.
+func FeeReportCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fee-report",
+		Short: "Report the fees collected in a range of blocks",
+		Long: `Re-execute the blocks from --from up to --to, each against the state persisted
+before it, and print the fees collected in them grouped by msg type, by
+proposer and by denom, as JSON or as CSV. The fees of a tx are counted when
+it succeeds, and the fees committed by the begin and end blockers are
+reported under the begin_block and end_block msg types. The state is left
+untouched, so the report of a range is always the same. The node must be
+stopped and must keep the states of the heights before the blocks.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, to := viper.GetInt64(flagFrom), viper.GetInt64(flagTo)
+			if from < 2 {
+				return errors.Errorf("--%s must be at least 2, the first block cannot be replayed", flagFrom)
+			}
+			if to < from {
+				return errors.Errorf("--%s %d must not be below --%s %d", flagTo, to, flagFrom, from)
+			}
+			format := viper.GetString(flagFormat)
+			if format != "json" && format != "csv" {
+				return errors.Errorf("unsupported --%s %s, expected json or csv", flagFormat, format)
+			}
+			cfg := ctx.Config
+			dbType := dbm.DBBackendType(cfg.DBBackend)
+
+			blockStoreDB := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+			defer blockStoreDB.Close()
+			blockStore := tmstore.NewBlockStore(blockStoreDB)
+			if to > blockStore.Height() {
+				return errors.Errorf("--%s %d is above the last block %d", flagTo, to, blockStore.Height())
+			}
+			stateDB := dbm.NewDB("state", dbType, cfg.DBDir())
+			defer stateDB.Close()
+
+			db, err := openDB(viper.GetString("home"), appDBBackend(cfg))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			replayer, ok := appCreator(ctx.Logger, db, nil).(blockFeesReplayer)
+			if !ok {
+				return errors.New("the app cannot replay blocks")
+			}
+
+			blocks := make([]*baseapp.BlockFees, 0, to-from+1)
+			for height := from; height <= to; height++ {
+				block := blockStore.LoadBlock(height)
+				if block == nil {
+					return errors.Errorf("no block at height %d", height)
+				}
+				req, err := beginBlockRequest(stateDB, block)
+				if err != nil {
+					return err
+				}
+				txs := make([][]byte, len(block.Txs))
+				for i, tx := range block.Txs {
+					txs[i] = tx
+				}
+				blockFees, err := replayer.ReplayBlockFees(req, txs)
+				if err != nil {
+					return errors.Wrapf(err, "failed to replay block %d", height)
+				}
+				blocks = append(blocks, blockFees)
+			}
+
+			report := newFeeReport(from, to, blocks)
+			if format == "csv" {
+				return report.writeCSV(os.Stdout)
+			}
+			out, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFrom, 0, "Height of the first block of the report")
+	cmd.Flags().Int64(flagTo, 0, "Height of the last block of the report")
+	cmd.Flags().String(flagFormat, "json", "Format of the report, json or csv")
+	return cmd
+}
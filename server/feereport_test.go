@@ -0,0 +1,56 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFeeReport(t *testing.T) {
+	proposer1, proposer2 := sdk.ConsAddress([]byte("proposer1")), sdk.ConsAddress([]byte("proposer2"))
+	fee := func(coins ...sdk.Coin) sdk.Fee { return sdk.NewFee(coins, sdk.FeeForProposer) }
+	blocks := []*baseapp.BlockFees{{
+		Height:        2,
+		Proposer:      proposer1,
+		BeginBlockFee: fee(sdk.NewCoin("BNB", 5)),
+		Txs: []baseapp.TxFee{
+			{MsgType: "send", Fee: fee(sdk.NewCoin("BNB", 10))},
+			{MsgType: "send"},
+		},
+	}, {
+		Height:   3,
+		Proposer: proposer2,
+		Txs: []baseapp.TxFee{
+			{MsgType: "vote", Fee: fee(sdk.NewCoin("BNB", 1), sdk.NewCoin("XYZ", 2))},
+		},
+	}}
+
+	report := newFeeReport(2, 3, blocks)
+	require.Equal(t, []feeReportEntry{
+		{Key: "begin_block", Fees: sdk.Coins{sdk.NewCoin("BNB", 5)}},
+		{Key: "send", Fees: sdk.Coins{sdk.NewCoin("BNB", 10)}, Count: 2},
+		{Key: "vote", Fees: sdk.Coins{sdk.NewCoin("BNB", 1), sdk.NewCoin("XYZ", 2)}, Count: 1},
+	}, report.ByMsgType)
+	require.Len(t, report.ByProposer, 2)
+	require.Equal(t, feeReportEntry{Key: proposer1.String(), Fees: sdk.Coins{sdk.NewCoin("BNB", 15)}, Count: 1},
+		report.ByProposer[0])
+	require.Equal(t, []feeReportEntry{
+		{Key: "BNB", Fees: sdk.Coins{sdk.NewCoin("BNB", 16)}, Count: 3},
+		{Key: "XYZ", Fees: sdk.Coins{sdk.NewCoin("XYZ", 2)}, Count: 1},
+	}, report.ByDenom)
+
+	var out bytes.Buffer
+	require.NoError(t, newFeeReport(3, 3, blocks[1:]).writeCSV(&out))
+	require.Equal(t, `group,key,denom,amount,count
+msg_type,vote,BNB,1,1
+msg_type,vote,XYZ,2,1
+proposer,`+proposer2.String()+`,BNB,1,1
+proposer,`+proposer2.String()+`,XYZ,2,1
+denom,BNB,BNB,1,1
+denom,XYZ,XYZ,2,1
+`, out.String())
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrationRunner is an app migrating its stores at the upgrades.
+type migrationRunner interface {
+	DryRunMigrations() ([]string, error)
+}
+
+// DryRunMigrationsCmd runs the store migrations of the binary against a copy
+// of the application db.
+func DryRunMigrationsCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "dry-run-migrations",
+		Short: "Run the store migrations of the binary against a copy of the state",
+		Long: `Copy the application db of the stopped node to a temporary directory and run
+the migrations the next upgrade would run from the versions of the stores to the
+versions of the binary. The steps are printed in the order they run, the first
+failing step aborts. The db of the node is never written.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tmpDir, err := ioutil.TempDir("", "application-dry-run")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+
+			dataDir := filepath.Join(viper.GetString("home"), "data")
+			if err = copyPath(filepath.Join(dataDir, "application.db"), filepath.Join(tmpDir, "application.db")); err != nil {
+				return errors.Wrap(err, "failed to copy the application db")
+			}
+			db, err := openDataDB(tmpDir, appDBBackend(ctx.Config))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			runner, ok := appCreator(ctx.Logger, db, nil).(migrationRunner)
+			if !ok {
+				return errors.New("the app has no store migrations")
+			}
+			steps, err := runner.DryRunMigrations()
+			if err != nil {
+				return err
+			}
+			if len(steps) == 0 {
+				fmt.Println("The stores are at the versions of the binary")
+				return nil
+			}
+			for _, step := range steps {
+				fmt.Println(step)
+			}
+			return nil
+		},
+	}
+}
+
+// copyPath copies the files of a flat directory, e.g. a leveldb db, or a
+// file, e.g. a boltdb db.
+func copyPath(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(src, dst)
+	}
+	files, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if err = copyFile(filepath.Join(src, file.Name()), filepath.Join(dst, file.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	sm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/state/txindex/kv"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+// txReplayer is an app replaying its past txs, e.g. an app built on the
+// BaseApp.
+type txReplayer interface {
+	ReplayTx(req abci.RequestBeginBlock, txs [][]byte, index int) (*baseapp.TxReplay, error)
+}
+
+// DebugCmd groups the commands debugging the state of a stopped node.
+func DebugCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debugging subcommands",
+	}
+	cmd.AddCommand(
+		ReplayTxCmd(ctx, appCreator),
+		StateDiffCmd(),
+		DryRunMigrationsCmd(ctx, appCreator),
+		FeeReportCmd(ctx, appCreator),
+		AuditWiringCmd(ctx, appCreator),
+	)
+	return cmd
+}
+
+// ReplayTxCmd re-executes a committed tx against the state of its block and
+// dumps the trace of its reads and writes as JSON.
+func ReplayTxCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-tx [hash]",
+		Short: "Replay a committed tx and trace its store operations",
+		Long: `Re-execute the tx of hash against the state persisted before its block, after
+the begin blocker and the txs before it in the block, and print its result
+along with every read and write it made on the stores and on the accounts.
+The block of the tx is found in the tx index, or at --height when the txs
+are not indexed. The node must be stopped and must keep the state of the
+height before the block.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return errors.Errorf("invalid tx hash %s: %v", args[0], err)
+			}
+			cfg := ctx.Config
+			dbType := dbm.DBBackendType(cfg.DBBackend)
+
+			height := viper.GetInt64(flagHeight)
+			if height == 0 {
+				txIndexDB := dbm.NewDB("tx_index", dbType, cfg.DBDir())
+				txResult, err := kv.NewTxIndex(txIndexDB).Get(hash)
+				txIndexDB.Close()
+				if err != nil {
+					return err
+				}
+				if txResult == nil {
+					return errors.Errorf("tx %X is not indexed, its --height is required", hash)
+				}
+				height = txResult.Height
+			}
+
+			blockStoreDB := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+			defer blockStoreDB.Close()
+			block := tmstore.NewBlockStore(blockStoreDB).LoadBlock(height)
+			if block == nil {
+				return errors.Errorf("no block at height %d", height)
+			}
+			index := block.Txs.IndexByHash(hash)
+			if index < 0 {
+				return errors.Errorf("tx %X is not in block %d", hash, height)
+			}
+			stateDB := dbm.NewDB("state", dbType, cfg.DBDir())
+			defer stateDB.Close()
+			req, err := beginBlockRequest(stateDB, block)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(viper.GetString("home"), appDBBackend(cfg))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			replayer, ok := appCreator(ctx.Logger, db, nil).(txReplayer)
+			if !ok {
+				return errors.New("the app cannot replay txs")
+			}
+			txs := make([][]byte, len(block.Txs))
+			for i, tx := range block.Txs {
+				txs[i] = tx
+			}
+			replay, err := replayer.ReplayTx(req, txs, index)
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(replay, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Height of the block of the tx, looked up in the tx index if 0")
+	return cmd
+}
+
+// beginBlockRequest rebuilds the BeginBlock request of block, the same way
+// tendermint does when executing it.
+func beginBlockRequest(stateDB dbm.DB, block *tmtypes.Block) (abci.RequestBeginBlock, error) {
+	lastValSet := tmtypes.NewValidatorSet(nil)
+	if block.Height > 1 {
+		valSet, err := sm.LoadValidators(stateDB, block.Height-1)
+		if err != nil {
+			return abci.RequestBeginBlock{}, err
+		}
+		lastValSet = valSet
+	}
+	voteInfos := make([]abci.VoteInfo, len(lastValSet.Validators))
+	for i, val := range lastValSet.Validators {
+		var vote *tmtypes.CommitSig
+		if i < len(block.LastCommit.Precommits) {
+			vote = block.LastCommit.Precommits[i]
+		}
+		voteInfos[i] = abci.VoteInfo{
+			Validator:       tmtypes.TM2PB.Validator(val),
+			SignedLastBlock: vote != nil,
+		}
+	}
+
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valSet, err := sm.LoadValidators(stateDB, ev.Height())
+		if err != nil {
+			return abci.RequestBeginBlock{}, err
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valSet, block.Time)
+	}
+
+	return abci.RequestBeginBlock{
+		Hash:   block.Hash(),
+		Header: tmtypes.TM2PB.Header(&block.Header),
+		LastCommitInfo: abci.LastCommitInfo{
+			Round: int32(block.LastCommit.Round()),
+			Votes: voteInfos,
+		},
+		ByzantineValidators: byzVals,
+	}, nil
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"crypto/sha256"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	amino "github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/snapshot"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const flagHeight = "height"
+
+// snapshotCdc decodes the manifest and the chunks of the snapshots, the same
+// way the state sync reactor of tendermint does
+var snapshotCdc = amino.NewCodec()
+
+func init() {
+	snapshot.RegisterSnapshotMessages(snapshotCdc)
+	tmtypes.RegisterBlockAmino(snapshotCdc)
+}
+
+// RestoreSnapshotCmd restores a fresh node from the snapshot files under its
+// data directory, e.g. copied from the data directory of another node, instead
+// of syncing them from peers.
+func RestoreSnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-snapshot",
+		Short: "Restore the state of a fresh node from a local snapshot",
+		Long: `Restore the application and tendermint state of a fresh node from the
+snapshot of --height found under data/snapshot, the latest one by default.
+Every chunk is verified against the hashes of the manifest and the restored
+app hash against the one of the snapshot.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := ctx.Config
+			reader := abci.SnapshotReader{DbDir: cfg.DBDir()}
+			height := viper.GetInt64(flagHeight)
+			if height == 0 {
+				height = reader.InitSnapshotHeight()
+			}
+			if height == 0 {
+				return errors.New("no snapshot found")
+			}
+			reader.Height = height
+
+			manifest, manifestHash, err := loadManifest(&reader)
+			if err != nil {
+				return err
+			}
+
+			dbType := dbm.DBBackendType(cfg.DBBackend)
+			stateDB := dbm.NewDB("state", dbType, cfg.DBDir())
+			defer stateDB.Close()
+			if state := sm.LoadState(stateDB); !state.IsEmpty() {
+				return errors.Errorf("the node already has a state at height %d", state.LastBlockHeight)
+			}
+			blockStoreDB := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+			defer blockStoreDB.Close()
+			txIndexDB := dbm.NewDB("tx_index", dbType, cfg.DBDir())
+			defer txIndexDB.Close()
+			blockStore := tmstore.NewBlockStore(blockStoreDB)
+			snapshot.InitSnapshotManager(stateDB, txIndexDB, blockStore, cfg.DBDir(), ctx.Logger)
+			if err := snapshot.Manager().WriteManifest(manifestHash, manifest); err != nil {
+				return err
+			}
+
+			db, err := openDB(viper.GetString("home"), appDBBackend(cfg))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			app := appCreator(ctx.Logger, db, nil)
+			if err := app.StartRecovery(manifest); err != nil {
+				return err
+			}
+
+			// restore the tendermint state and block first, the app checks its
+			// hash against the state once the last app state chunk is written
+			for _, hash := range manifest.StateHashes {
+				chunk, err := loadChunk(&reader, hash)
+				if err != nil {
+					return err
+				}
+				stateChunk, ok := chunk.(*abci.StateChunk)
+				if !ok {
+					return errors.Errorf("chunk %x is not a state chunk", hash)
+				}
+				var state sm.State
+				if err := snapshotCdc.UnmarshalBinaryBare(stateChunk.Statepart, &state); err != nil {
+					return err
+				}
+				sm.SaveState(stateDB, state)
+			}
+			for _, hash := range manifest.BlockHashes {
+				chunk, err := loadChunk(&reader, hash)
+				if err != nil {
+					return err
+				}
+				blockChunk, ok := chunk.(*abci.BlockChunk)
+				if !ok {
+					return errors.Errorf("chunk %x is not a block chunk", hash)
+				}
+				var block tmtypes.Block
+				var seenCommit tmtypes.Commit
+				if err := snapshotCdc.UnmarshalBinaryBare(blockChunk.Block, &block); err != nil {
+					return err
+				}
+				if err := snapshotCdc.UnmarshalBinaryBare(blockChunk.SeenCommit, &seenCommit); err != nil {
+					return err
+				}
+				blockStore.SetHeight(block.Height - 1)
+				blockStore.SaveBlock(&block, block.MakePartSet(tmtypes.BlockPartSizeBytes), &seenCommit)
+			}
+			for idx, hash := range manifest.AppStateHashes {
+				chunk, err := loadChunk(&reader, hash)
+				if err != nil {
+					return err
+				}
+				appStateChunk, ok := chunk.(*abci.AppStateChunk)
+				if !ok {
+					return errors.Errorf("chunk %x is not an app state chunk", hash)
+				}
+				if err := app.WriteRecoveryChunk(hash, appStateChunk, idx == len(manifest.AppStateHashes)-1); err != nil {
+					return err
+				}
+			}
+
+			ctx.Logger.Info("Restored the snapshot", "height", height)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Height of the snapshot to restore, the latest snapshot if 0")
+	return cmd
+}
+
+// loadManifest reads and decodes the manifest of the snapshot of reader.
+func loadManifest(reader *abci.SnapshotReader) (*abci.Manifest, abci.SHA256Sum, error) {
+	_, compressed, err := reader.LoadManifest(reader.Height)
+	if err != nil {
+		return nil, abci.SHA256Sum{}, err
+	}
+	hash := sha256.Sum256(compressed)
+	decompressed, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, hash, err
+	}
+	var manifest abci.Manifest
+	if err := snapshotCdc.UnmarshalBinaryBare(decompressed, &manifest); err != nil {
+		return nil, hash, err
+	}
+	if manifest.Version != abci.ManifestVersion {
+		return nil, hash, errors.Errorf("snapshot manifest version mismatch, expected: %d, actual: %d", abci.ManifestVersion, manifest.Version)
+	}
+	if manifest.Height != reader.Height {
+		return nil, hash, errors.Errorf("manifest of the snapshot at height %d is for height %d", reader.Height, manifest.Height)
+	}
+	return &manifest, hash, nil
+}
+
+// loadChunk reads the chunk of hash of the snapshot of reader, verifying it
+// hashes to hash.
+func loadChunk(reader *abci.SnapshotReader, hash abci.SHA256Sum) (abci.SnapshotChunk, error) {
+	compressed, err := reader.Load(hash)
+	if err != nil {
+		return nil, err
+	}
+	if sha256.Sum256(compressed) != hash {
+		return nil, errors.Errorf("chunk %x is corrupted", hash)
+	}
+	decompressed, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, err
+	}
+	var chunk abci.SnapshotChunk
+	if err := snapshotCdc.UnmarshalBinaryBare(decompressed, &chunk); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
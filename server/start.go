@@ -1,15 +1,21 @@
 package server
 
 import (
+	"path/filepath"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/server/concurrent"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	"github.com/cosmos/cosmos-sdk/txindex"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/tendermint/tendermint/abci/server"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
@@ -23,6 +29,18 @@ const (
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
 	flagSequentialABCI = "seq-abci"
+
+	flagPruningKeepRecent = "pruning-keep-recent"
+	flagPruningKeepEvery  = "pruning-keep-every"
+	flagPruningInterval   = "pruning-interval"
+
+	flagInterBlockCacheSize = "inter-block-cache-size"
+	flagStateDiffDir        = "state-diff-dir"
+	flagCommitBuffer        = "commit-buffer"
+	flagCommitBufferAsync   = "commit-buffer-async"
+	flagMetricsListenAddr   = "metrics-laddr"
+	flagAppTxIndex          = "app-tx-index"
+	flagQueryWorkers        = "query-workers"
 )
 
 var BlockStore *tmstore.BlockStore
@@ -34,6 +52,11 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 		Use:   "start",
 		Short: "Run the full node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// fail before the app creator panics on an invalid strategy
+			if _, err := PruningStrategyFromFlags(); err != nil {
+				return err
+			}
+
 			if !viper.GetBool(flagWithTendermint) {
 				ctx.Logger.Info("Starting ABCI without Tendermint")
 				return startStandAlone(ctx, appCreator)
@@ -51,19 +74,73 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().Bool(flagSequentialABCI, false, "Run abci app in sync mode")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, 0, "Number of recent versions to keep, with --pruning custom")
+	cmd.Flags().Int64(flagPruningKeepEvery, 0, "Keep every n-th version on top of the recent ones, with --pruning custom")
+	cmd.Flags().Int64(flagPruningInterval, 1, "Number of versions between two prunings, with --pruning custom")
+	cmd.Flags().Int(flagInterBlockCacheSize, 0, "Number of values of each store cached across blocks, 0 to disable the cache")
+	cmd.Flags().String(flagStateDiffDir, "", "Record the writes of each block into content-addressed diff files of the directory")
+	cmd.Flags().Bool(flagCommitBuffer, false, "Write the IAVL stores of each block into the application db in a single batch at the commit")
+	cmd.Flags().Bool(flagCommitBufferAsync, false, "Sync the batch of --commit-buffer on a background goroutine, waited at the next commit")
+	cmd.Flags().Int(flagQueryWorkers, 0, "Number of store and custom queries served concurrently with the txs from the last committed state, "+
+		"0 serves them between the txs, ignored with --seq-abci")
+	cmd.Flags().Bool(flagAppTxIndex, false, "Index the delivered txs into the data/app_tx_index.db DB, for the app tx search of the LCD")
+	cmd.Flags().Bool(flagAuditWiring, false, "Check the wiring of the stores, routes and codec of the app before starting, and refuse to start on any issue")
+	cmd.Flags().String(flagMetricsListenAddr, "", "Serve the Prometheus metrics of the app at /metrics of the host:port address, "+
+		"they are also served by the Tendermint instrumentation when enabled")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
 	return cmd
 }
 
+// PruningStrategyFromFlags returns the pruning strategy set by the pruning
+// flags of StartCmd, for the app creators.
+func PruningStrategyFromFlags() (sdk.PruningStrategy, error) {
+	if viper.GetString(flagPruning) != "custom" {
+		return sdk.NewPruningStrategyFromString(viper.GetString(flagPruning))
+	}
+	pruning := sdk.NewPruningStrategy(
+		viper.GetInt64(flagPruningKeepRecent),
+		viper.GetInt64(flagPruningKeepEvery),
+		viper.GetInt64(flagPruningInterval),
+	)
+	return pruning, pruning.Validate()
+}
+
+// TxIndexerFromFlags returns the indexer of the delivered txs enabled by the
+// app-tx-index flag of StartCmd, for the app creators, or nil.
+func TxIndexerFromFlags() (*txindex.Indexer, error) {
+	if !viper.GetBool(flagAppTxIndex) {
+		return nil, nil
+	}
+	db, err := dbm.NewGoLevelDB("app_tx_index", filepath.Join(viper.GetString("home"), "data"))
+	if err != nil {
+		return nil, err
+	}
+	return txindex.NewIndexer(db), nil
+}
+
+// startTelemetry enables the metrics of the app when they are served, by the
+// metrics endpoint of the app or by the Prometheus one of Tendermint, which
+// serves the default registry too. It must run before the app is created.
+func startTelemetry(ctx *Context, tmPrometheus bool) error {
+	if laddr := viper.GetString(flagMetricsListenAddr); laddr != "" {
+		_, err := telemetry.StartServer(laddr, ctx.Logger.With("module", "telemetry"))
+		return err
+	}
+	if tmPrometheus {
+		telemetry.Enable()
+	}
+	return nil
+}
+
 func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	addr := viper.GetString(flagAddress)
 	home := viper.GetString("home")
 	traceWriterFile := viper.GetString(flagTraceStore)
 
-	db, err := openDB(home)
+	db, err := openDB(home, appDBBackend(ctx.Config))
 	if err != nil {
 		return err
 	}
@@ -71,8 +148,16 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	if err != nil {
 		return err
 	}
+	if err := startTelemetry(ctx, false); err != nil {
+		return err
+	}
 
 	app := appCreator(ctx.Logger, db, traceWriter)
+	if viper.GetBool(flagAuditWiring) {
+		if err := auditWiring(app); err != nil {
+			return err
+		}
+	}
 
 	svr, err := server.NewServer(addr, "socket", app)
 	if err != nil {
@@ -104,8 +189,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	traceWriterFile := viper.GetString(flagTraceStore)
 	isSequentialABCI := viper.GetBool(flagSequentialABCI)
 
-	dbProvider := node.DefaultDBProvider
-	db, err := dbProvider(&node.DBContext{"application", cfg})
+	db, err := openDataDB(cfg.DBDir(), appDBBackend(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -113,8 +197,16 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := startTelemetry(ctx, cfg.Instrumentation.Prometheus); err != nil {
+		return nil, err
+	}
 
 	app := appCreator(ctx.Logger, db, traceWriter)
+	if viper.GetBool(flagAuditWiring) {
+		if err := auditWiring(app); err != nil {
+			return nil, err
+		}
+	}
 
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
 	if err != nil {
@@ -126,7 +218,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		cliCreator = proxy.NewLocalClientCreator(app)
 	} else {
 		cliCreator = concurrent.NewAsyncLocalClientCreator(app,
-			ctx.Logger.With("module", "abciCli"))
+			ctx.Logger.With("module", "abciCli"), viper.GetInt(flagQueryWorkers))
 	}
 
 	// create & start tendermint node
@@ -136,7 +228,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		nodeKey,
 		cliCreator,
 		node.DefaultGenesisDocProviderFunc(cfg),
-		dbProvider,
+		node.DefaultDBProvider,
 		node.DefaultMetricsProvider(cfg.Instrumentation),
 		ctx.Logger.With("module", "node"),
 	)
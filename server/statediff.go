@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// stateDiff is the net writes of the blocks between two heights.
+type stateDiff struct {
+	FromHeight int64              `json:"from_height"`
+	ToHeight   int64              `json:"to_height"`
+	Writes     []store.StateWrite `json:"writes"`
+}
+
+// StateDiffCmd prints the net writes made on the state between two heights,
+// from the diff files recorded by a node started with --state-diff-dir.
+func StateDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff [height1] [height2]",
+		Short: "Print the writes changing the state of height1 into the one of height2",
+		Long: `Merge the diff files of the blocks after height1 up to height2, recorded by a
+node started with --state-diff-dir, and print the last write of each key of
+each store. The content of each diff file is checked against its hash.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid height %s: %v", args[0], err)
+			}
+			to, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid height %s: %v", args[1], err)
+			}
+			if from < 0 || to <= from {
+				return errors.Errorf("height2 %d must be above height1 %d", to, from)
+			}
+			dir := viper.GetString(flagStateDiffDir)
+			if dir == "" {
+				return errors.Errorf("--%s is required", flagStateDiffDir)
+			}
+
+			diffs := make([]store.StateDiff, 0, to-from)
+			for height := from + 1; height <= to; height++ {
+				diff, err := store.LoadStateDiff(dir, height)
+				if err != nil {
+					return err
+				}
+				diffs = append(diffs, diff)
+			}
+
+			out, err := json.MarshalIndent(stateDiff{
+				FromHeight: from,
+				ToHeight:   to,
+				Writes:     store.MergeStateDiffs(diffs...).Writes,
+			}, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagStateDiffDir, "", "Directory of the diff files recorded by the node")
+	return cmd
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"encoding/json"
+	"io"
 	"net"
 	"os"
 	"os/signal"
@@ -58,8 +59,7 @@ func PersistentPreRunEFn(context *Context) func(*cobra.Command, []string) error
 		if err != nil {
 			return err
 		}
-		logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
-		logger, err = tmflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel())
+		logger, err := newLogger(config, os.Stdout)
 		if err != nil {
 			return err
 		}
@@ -73,6 +73,23 @@ func PersistentPreRunEFn(context *Context) func(*cobra.Command, []string) error
 	}
 }
 
+// newLogger returns the logger of config writing to w, in the plain or JSON
+// log format, and filtering the levels of the modules by the log level, e.g.
+// "bank:info,stake:debug,*:error". The modules are the values of the
+// "module" keys of the log lines.
+func newLogger(config *cfg.Config, w io.Writer) (log.Logger, error) {
+	var logger log.Logger
+	switch config.LogFormat {
+	case cfg.LogFormatPlain, "":
+		logger = log.NewTMLogger(log.NewSyncWriter(w))
+	case cfg.LogFormatJSON:
+		logger = log.NewTMJSONLogger(log.NewSyncWriter(w))
+	default:
+		return nil, errors.Errorf("unknown log format %q, expected %q or %q", config.LogFormat, cfg.LogFormatPlain, cfg.LogFormatJSON)
+	}
+	return tmflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel())
+}
+
 // If a new config is created, change some of the default tendermint settings
 func interceptLoadConfig() (conf *cfg.Config, err error) {
 	tmpConf := cfg.DefaultConfig()
@@ -130,7 +147,11 @@ func AddCommands(
 	ctx *Context, cdc *codec.Codec,
 	rootCmd *cobra.Command, appExport AppExporter) {
 
-	rootCmd.PersistentFlags().String("log_level", ctx.Config.LogLevel, "Log level")
+	rootCmd.PersistentFlags().String("log_level", ctx.Config.LogLevel,
+		`Log level, a level or a list of module:level pairs, e.g. "bank:info,stake:debug,*:error"`)
+	rootCmd.PersistentFlags().String("log_format", ctx.Config.LogFormat, "Log format, plain or json")
+	rootCmd.PersistentFlags().String(flagAppDBBackend, "",
+		"Backend of data/application.db, e.g. goleveldb, or boltdb and cleveldb with their build tags, db_backend of the config by default")
 
 	tendermintCmd := &cobra.Command{
 		Use:   "tendermint",
@@ -145,6 +166,7 @@ func AddCommands(
 
 	rootCmd.AddCommand(
 		UnsafeResetAllCmd(ctx),
+		MigrateAppDBCmd(ctx),
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
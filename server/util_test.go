@@ -1,11 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/stretchr/testify/require"
+	cfg "github.com/tendermint/tendermint/config"
 )
 
 func TestInsertKeyJSON(t *testing.T) {
@@ -38,3 +41,30 @@ func TestInsertKeyJSON(t *testing.T) {
 
 	require.Equal(t, bar, resBar, "appended: %v", appended)
 }
+
+func TestNewLogger(t *testing.T) {
+	config := cfg.DefaultConfig()
+	config.LogLevel = "bank:info,stake:debug,*:error"
+	config.LogFormat = cfg.LogFormatJSON
+	var buf bytes.Buffer
+	logger, err := newLogger(config, &buf)
+	require.NoError(t, err)
+
+	logger.With("module", "bank").Debug("filtered")
+	logger.With("module", "bank").Info("bank info")
+	logger.With("module", "main").With("module", "stake").Debug("stake debug")
+	logger.With("module", "gov").Info("filtered")
+	logger.With("module", "gov").Error("gov error")
+
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]string
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		msgs = append(msgs, entry["_msg"])
+	}
+	require.Equal(t, []string{"bank info", "stake debug", "gov error"}, msgs)
+
+	config.LogFormat = "xml"
+	_, err = newLogger(config, &buf)
+	require.Error(t, err)
+}
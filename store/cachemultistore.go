@@ -32,6 +32,14 @@ func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
 	}
 
 	for key, store := range rms.stores {
+		if diffStore, ok := rms.diffKVStore(key); ok {
+			// a TraceKVStore cannot be cache wrapped
+			if cms.TracingEnabled() {
+				diffStore = NewTraceKVStore(diffStore, cms.traceWriter, cms.traceContext)
+			}
+			cms.stores[key] = NewCacheKVStore(diffStore)
+			continue
+		}
 		if cms.TracingEnabled() {
 			cms.stores[key] = store.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
 		} else {
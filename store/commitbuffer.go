@@ -0,0 +1,225 @@
+package store
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// commitBuffer is a dbm.DB buffering the writes into its db until they are
+// flushed, the writes of all the IAVL stores of a version and its commit info
+// are then written in a single sorted batch instead of a batch per store. The
+// buffered writes are read through, so the stores read their writes before
+// they are flushed.
+type commitBuffer struct {
+	dbm.DB
+
+	mtx sync.RWMutex
+	// the buffered writes, nil values are deletes
+	writes map[string][]byte
+	// the writes of the flush in progress, read until they are written
+	flushing map[string][]byte
+}
+
+var _ dbm.DB = (*commitBuffer)(nil)
+
+func newCommitBuffer(db dbm.DB) *commitBuffer {
+	return &commitBuffer{
+		DB:     db,
+		writes: make(map[string][]byte),
+	}
+}
+
+// lookup returns the buffered write of key, if any.
+func (cb *commitBuffer) lookup(key []byte) (value []byte, ok bool) {
+	if value, ok = cb.writes[string(key)]; ok {
+		return value, true
+	}
+	value, ok = cb.flushing[string(key)]
+	return value, ok
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) Get(key []byte) []byte {
+	cb.mtx.RLock()
+	defer cb.mtx.RUnlock()
+	if value, ok := cb.lookup(key); ok {
+		return value
+	}
+	return cb.DB.Get(key)
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) Has(key []byte) bool {
+	return cb.Get(key) != nil
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) Set(key, value []byte) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	cb.set(key, value)
+}
+
+// Implements dbm.DB, the write is synced at the flush.
+func (cb *commitBuffer) SetSync(key, value []byte) {
+	cb.Set(key, value)
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) Delete(key []byte) {
+	cb.mtx.Lock()
+	defer cb.mtx.Unlock()
+	cb.writes[string(key)] = nil
+}
+
+// Implements dbm.DB, the write is synced at the flush.
+func (cb *commitBuffer) DeleteSync(key []byte) {
+	cb.Delete(key)
+}
+
+func (cb *commitBuffer) set(key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	// CONTRACT: the values are readonly, as for the writes of dbm.DB
+	cb.writes[string(key)] = value
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) Iterator(start, end []byte) dbm.Iterator {
+	return cb.iterator(start, end, true)
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) ReverseIterator(start, end []byte) dbm.Iterator {
+	return cb.iterator(start, end, false)
+}
+
+// the iterators merge the buffered writes of their creation into the db
+func (cb *commitBuffer) iterator(start, end []byte, ascending bool) dbm.Iterator {
+	var items []cmn.KVPair
+	cb.mtx.RLock()
+	for key, value := range cb.flushing {
+		if _, ok := cb.writes[key]; !ok && dbm.IsKeyInDomain([]byte(key), start, end) {
+			items = append(items, cmn.KVPair{Key: []byte(key), Value: value})
+		}
+	}
+	for key, value := range cb.writes {
+		if dbm.IsKeyInDomain([]byte(key), start, end) {
+			items = append(items, cmn.KVPair{Key: []byte(key), Value: value})
+		}
+	}
+	cb.mtx.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		if ascending {
+			return bytes.Compare(items[i].Key, items[j].Key) < 0
+		}
+		return bytes.Compare(items[i].Key, items[j].Key) > 0
+	})
+	var parent dbm.Iterator
+	if ascending {
+		parent = cb.DB.Iterator(start, end)
+	} else {
+		parent = cb.DB.ReverseIterator(start, end)
+	}
+	return newCacheMergeIterator(parent, newMemIterator(start, end, items), ascending)
+}
+
+// Implements dbm.DB.
+func (cb *commitBuffer) NewBatch() dbm.Batch {
+	return &commitBufferBatch{cb: cb}
+}
+
+// flush writes the buffered writes into the db in a single batch sorted by
+// key, synced if sync. The batch is written on a background goroutine if
+// async, the returned function waits for it to be written. The previous flush
+// must be written.
+func (cb *commitBuffer) flush(sync, async bool) (wait func()) {
+	cb.mtx.Lock()
+	writes := cb.writes
+	cb.flushing, cb.writes = writes, make(map[string][]byte)
+	cb.mtx.Unlock()
+
+	done := make(chan struct{})
+	write := func() {
+		defer close(done)
+		keys := make([]string, 0, len(writes))
+		for key := range writes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		batch := cb.DB.NewBatch()
+		defer batch.Close()
+		for _, key := range keys {
+			if value := writes[key]; value != nil {
+				batch.Set([]byte(key), value)
+			} else {
+				batch.Delete([]byte(key))
+			}
+		}
+		if sync {
+			batch.WriteSync()
+		} else {
+			batch.Write()
+		}
+
+		cb.mtx.Lock()
+		cb.flushing = nil
+		cb.mtx.Unlock()
+	}
+	if async {
+		go write()
+	} else {
+		write()
+	}
+	return func() { <-done }
+}
+
+// commitBufferBatch buffers its writes into the commit buffer when written.
+type commitBufferBatch struct {
+	cb  *commitBuffer
+	ops []cmn.KVPair // nil values are deletes
+}
+
+// Implements dbm.Batch.
+func (b *commitBufferBatch) Set(key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	b.ops = append(b.ops, cmn.KVPair{Key: key, Value: value})
+}
+
+// Implements dbm.Batch.
+func (b *commitBufferBatch) Delete(key []byte) {
+	b.ops = append(b.ops, cmn.KVPair{Key: key})
+}
+
+// Implements dbm.Batch.
+func (b *commitBufferBatch) Write() {
+	b.cb.mtx.Lock()
+	defer b.cb.mtx.Unlock()
+	for _, op := range b.ops {
+		if op.Value != nil {
+			b.cb.set(op.Key, op.Value)
+		} else {
+			b.cb.writes[string(op.Key)] = nil
+		}
+	}
+	b.ops = nil
+}
+
+// Implements dbm.Batch, the writes are synced at the flush.
+func (b *commitBufferBatch) WriteSync() {
+	b.Write()
+}
+
+// Implements dbm.Batch.
+func (b *commitBufferBatch) Close() {
+	b.ops = nil
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the key-values of the iterator
+func iteratorItems(iter dbm.Iterator) (items []string) {
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		items = append(items, fmt.Sprintf("%s=%s", iter.Key(), iter.Value()))
+	}
+	return items
+}
+
+func TestCommitBuffer(t *testing.T) {
+	db := dbm.NewMemDB()
+	db.Set(bz("a"), bz("1"))
+	db.Set(bz("b"), bz("2"))
+	db.Set(bz("c"), bz("3"))
+	cb := newCommitBuffer(db)
+
+	// the writes are read through and not written until the flush
+	cb.Set(bz("b"), bz("20"))
+	cb.Delete(bz("c"))
+	batch := cb.NewBatch()
+	batch.Set(bz("d"), bz("4"))
+	batch.Delete(bz("a"))
+	require.Nil(t, cb.Get(bz("d")), "the batch is not written")
+	batch.Write()
+	batch.Close()
+
+	require.Nil(t, cb.Get(bz("a")))
+	require.False(t, cb.Has(bz("c")))
+	require.Equal(t, bz("20"), cb.Get(bz("b")))
+	require.Equal(t, bz("4"), cb.Get(bz("d")))
+	require.Equal(t, []string{"b=20", "d=4"}, iteratorItems(cb.Iterator(nil, nil)))
+	require.Equal(t, []string{"d=4", "b=20"}, iteratorItems(cb.ReverseIterator(nil, nil)))
+	require.Equal(t, []string{"b=20"}, iteratorItems(cb.Iterator(bz("b"), bz("d"))))
+	require.Equal(t, bz("1"), db.Get(bz("a")))
+	require.Equal(t, bz("2"), db.Get(bz("b")))
+
+	cb.flush(false, false)()
+	require.Equal(t, []string{"b=20", "d=4"}, iteratorItems(db.Iterator(nil, nil)))
+	require.Empty(t, cb.writes)
+	require.Nil(t, cb.flushing)
+
+	// the writes of a background flush are read until they are written
+	cb.Set(bz("e"), bz("5"))
+	wait := cb.flush(true, true)
+	require.Equal(t, bz("5"), cb.Get(bz("e")))
+	cb.Set(bz("e"), bz("50"))
+	require.Equal(t, bz("50"), cb.Get(bz("e")))
+	wait()
+	require.Equal(t, bz("5"), db.Get(bz("e")))
+	require.Equal(t, bz("50"), cb.Get(bz("e")))
+}
+
+// The buffered commits write the same db as the unbuffered ones, including
+// the pruning of the versions written in the same commit.
+func TestMultistoreCommitBuffer(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		for _, pruning := range []sdk.PruningStrategy{sdk.PruneEverything, sdk.PruneNothing} {
+			db, bufferedDB := dbm.NewMemDB(), dbm.NewMemDB()
+			store, buffered := newMultiStoreWithMounts(db), newMultiStoreWithMounts(bufferedDB)
+			buffered.SetCommitBuffer(true, async)
+			for _, ms := range []*rootMultiStore{store, buffered} {
+				ms.SetPruning(pruning)
+				require.Nil(t, ms.LoadLatestVersion())
+			}
+
+			for version := 1; version <= 5; version++ {
+				for _, ms := range []*rootMultiStore{store, buffered} {
+					for _, name := range []string{"store1", "store2"} {
+						kv := ms.GetKVStore(ms.keysByName[name])
+						kv.Set(bz(fmt.Sprintf("key%d", version)), bz(name))
+						kv.Delete(bz(fmt.Sprintf("key%d", version-1)))
+					}
+				}
+				require.Equal(t, store.Commit(), buffered.Commit())
+				require.Empty(t, buffered.commitBuffer.writes)
+			}
+			// the background flush of the last version overlaps with the
+			// next one until it is waited
+			buffered.waitFlush()
+			require.Nil(t, buffered.commitBuffer.flushing)
+			// the commit infos list the stores in the order of a map
+			storesPrefix := dbm.NewPrefixDB(db, bz("s/k:"))
+			bufferedStoresPrefix := dbm.NewPrefixDB(bufferedDB, bz("s/k:"))
+			require.Equal(t, iteratorItems(storesPrefix.Iterator(nil, nil)), iteratorItems(bufferedStoresPrefix.Iterator(nil, nil)))
+
+			// the flushed versions are loaded without the buffer
+			reloaded := newMultiStoreWithMounts(bufferedDB)
+			require.Nil(t, reloaded.LoadLatestVersion())
+			require.Equal(t, store.LastCommitID(), reloaded.LastCommitID())
+		}
+	}
+}
+
+// commit blocks of benchDBBatchSize writes into the stores of a goleveldb db
+func benchmarkMultistoreCommit(b *testing.B, buffer, async bool) {
+	dir, err := os.MkdirTemp("", "bench-commit")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+	db, err := dbm.NewGoLevelDB("bench", dir)
+	require.NoError(b, err)
+	defer db.Close()
+
+	ms := newMultiStoreWithMounts(db)
+	ms.SetCommitBuffer(buffer, async)
+	require.Nil(b, ms.LoadLatestVersion())
+	r := rand.New(rand.NewSource(0))
+	value := make([]byte, benchDBValueBytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range []string{"store1", "store2", "store3"} {
+			kv := ms.GetKVStore(ms.keysByName[name])
+			for j := 0; j < benchDBBatchSize/3; j++ {
+				r.Read(value)
+				kv.Set(keyFmt(r.Intn(benchDBEntries)), value)
+			}
+		}
+		ms.Commit()
+	}
+}
+
+func BenchmarkMultistoreCommit(b *testing.B)       { benchmarkMultistoreCommit(b, false, false) }
+func BenchmarkMultistoreCommitBuffer(b *testing.B) { benchmarkMultistoreCommit(b, true, false) }
+func BenchmarkMultistoreCommitBufferAsync(b *testing.B) {
+	benchmarkMultistoreCommit(b, true, true)
+}
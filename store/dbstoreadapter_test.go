@@ -0,0 +1,109 @@
+package store
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// The backends of the application db, the ones not built in are skipped, e.g.
+//
+//	go test ./store -run ^$ -bench DBBackend -tags "boltdb cleveldb"
+var benchDBBackends = []dbm.DBBackendType{
+	dbm.GoLevelDBBackend, dbm.CLevelDBBackend, dbm.BoltDBBackend, dbm.MemDBBackend,
+}
+
+const (
+	benchDBEntries    = 100000
+	benchDBBatchSize  = 1000
+	benchDBValueBytes = 128
+)
+
+// open a db of backend in a temporary directory, filled with benchDBEntries
+// entries if filled
+func newBenchDBStore(b *testing.B, backend dbm.DBBackendType, filled bool) (dbStoreAdapter, func()) {
+	dir, err := os.MkdirTemp("", "bench-db")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var db dbm.DB
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				os.RemoveAll(dir)
+				b.Skipf("backend %s is not built in: %v", backend, r)
+			}
+		}()
+		db = dbm.NewDB("bench", backend, dir)
+	}()
+	if filled {
+		writeBenchDBBatches(db, rand.New(rand.NewSource(0)), benchDBEntries/benchDBBatchSize)
+	}
+	return dbStoreAdapter{db}, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// write n batches of random keys, as the iavl stores write their nodes at
+// the commits
+func writeBenchDBBatches(db dbm.DB, r *rand.Rand, n int) {
+	value := make([]byte, benchDBValueBytes)
+	for i := 0; i < n; i++ {
+		batch := db.NewBatch()
+		for j := 0; j < benchDBBatchSize; j++ {
+			r.Read(value)
+			batch.Set(keyFmt(r.Intn(benchDBEntries)), value)
+		}
+		batch.WriteSync()
+		batch.Close()
+	}
+}
+
+func BenchmarkDBBackendWriteBatch(b *testing.B) {
+	for _, backend := range benchDBBackends {
+		b.Run(string(backend), func(b *testing.B) {
+			st, cleanup := newBenchDBStore(b, backend, false)
+			defer cleanup()
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			writeBenchDBBatches(st.DB, r, b.N)
+		})
+	}
+}
+
+func BenchmarkDBBackendGet(b *testing.B) {
+	for _, backend := range benchDBBackends {
+		b.Run(string(backend), func(b *testing.B) {
+			st, cleanup := newBenchDBStore(b, backend, true)
+			defer cleanup()
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				st.Get(keyFmt(r.Intn(benchDBEntries)))
+			}
+		})
+	}
+}
+
+func BenchmarkDBBackendIterate(b *testing.B) {
+	for _, backend := range benchDBBackends {
+		b.Run(string(backend), func(b *testing.B) {
+			st, cleanup := newBenchDBStore(b, backend, true)
+			defer cleanup()
+			r := rand.New(rand.NewSource(1))
+			b.ResetTimer()
+			// a range of about a hundred entries from a random key
+			for i := 0; i < b.N; i++ {
+				start := r.Intn(benchDBEntries - 100)
+				iter := st.Iterator(keyFmt(start), keyFmt(start+100))
+				for ; iter.Valid(); iter.Next() {
+					iter.Value()
+				}
+				iter.Close()
+			}
+		})
+	}
+}
@@ -54,6 +54,10 @@ type IavlStore struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// The number of versions between two prunings.
+	// A value of 0 or 1 means prune on every commit.
+	pruneInterval int64
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -84,11 +88,18 @@ func (st *IavlStore) Commit() CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
+	// Release the old versions of history released since the last pruning,
+	// if not sync waypoints.
+	interval := st.pruneInterval
+	if interval < 1 {
+		interval = 1
+	}
+	if version%interval == 0 {
+		previous := version - 1
+		for toRelease := previous - st.numRecent; toRelease > 0 && toRelease > previous-st.numRecent-interval; toRelease-- {
+			if st.storeEvery != 0 && toRelease%st.storeEvery == 0 {
+				continue
+			}
 			err := st.Tree.DeleteVersion(toRelease)
 			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
 				panic(err)
@@ -112,16 +123,9 @@ func (st *IavlStore) LastCommitID() CommitID {
 
 // Implements Committer.
 func (st *IavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100000 // fork github.com/cosmos/cosmos-sdk/blob/9a16e2675f392b083dd1074ff92ff1f9fbda750d/store/types/pruning.go#L34
-		st.storeEvery = 100000
-	}
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
+	st.pruneInterval = pruning.Interval
 }
 
 // VersionExists returns whether or not a given version is stored.
@@ -180,6 +184,67 @@ func (st *IavlStore) ReverseIterator(start, end []byte) Iterator {
 	return newIAVLIterator(st.Tree.ImmutableTree, start, end, false)
 }
 
+//----------------------------------------
+
+var _ KVStore = immutableIavlStore{}
+
+// immutableIavlStore is a read only KVStore over a past version of an IAVL
+// tree, to be cache wrapped.
+type immutableIavlStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// Implements Store.
+func (st immutableIavlStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st immutableIavlStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st immutableIavlStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Get(key []byte) []byte {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Has(key []byte) bool {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Set(key, value []byte) {
+	panic("cannot write a past version of an IAVL store")
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Delete(key []byte) {
+	panic("cannot write a past version of an IAVL store")
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
+
 // Handle gatest the latest height, if height is 0
 func getHeight(tree *iavl.MutableTree, req abci.RequestQuery) int64 {
 	height := req.Height
@@ -271,6 +336,30 @@ func (st *IavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 		// get proof from tree and convert to merkle.Proof before adding to result
 		res.Proof = getProofFromTree(mtree, req.Data, res.Value != nil)
+	case "/range": // Get the pairs of a range of keys
+		res.Key = req.Data // Data holds the encoded KeyRange
+		var kr KeyRange
+		if err := cdc.UnmarshalBinaryLengthPrefixed(req.Data, &kr); err != nil {
+			return sdk.ErrTxDecode(err.Error()).QueryResult()
+		}
+		if !st.VersionExists(res.Height) {
+			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
+			break
+		}
+		iTree, err := tree.GetImmutable(res.Height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
+		KVs, op, err := NewRangeCommitmentOp(iTree, kr)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
+		res.Value = cdc.MustMarshalBinaryLengthPrefixed(KVs)
+		if req.Prove {
+			res.Proof = &merkle.Proof{Ops: []merkle.ProofOp{op.ProofOp()}}
+		}
 	case "/subspace":
 		subspace := req.Data
 		res.Key = subspace
@@ -342,6 +342,31 @@ func testPruning(t *testing.T, numRecent int64, storeEvery int64, states []prune
 	}
 }
 
+func TestIAVLPruneInterval(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, int64(0), int64(0))
+	iavlStore.SetPruning(sdk.NewPruningStrategy(2, 5, 4))
+	for i := 0; i < 11; i++ {
+		nextVersion(iavlStore)
+	}
+	// versions 1 to 4 were pruned with the versions 4 and 8, 5 is a waypoint
+	// and 6 to 9 are pruned with the version 12
+	for _, ver := range []int64{1, 2, 3, 4} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	for _, ver := range []int64{5, 6, 7, 8, 9, 10, 11} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+	nextVersion(iavlStore)
+	for _, ver := range []int64{6, 7, 8, 9} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	for _, ver := range []int64{5, 10, 11, 12} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+}
+
 func TestIAVLNoPrune(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
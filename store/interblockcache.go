@@ -0,0 +1,92 @@
+package store
+
+import (
+	"io"
+
+	lru "github.com/hashicorp/golang-lru"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+var _ CommitKVStore = (*interBlockCacheStore)(nil)
+var _ Queryable = (*interBlockCacheStore)(nil)
+
+// interBlockCacheStore is a write-through cache of the latest values of a
+// CommitKVStore. Unlike cacheKVStore, it outlives the blocks: the frequently
+// read keys, e.g. params, validators and accounts, are read from the memory
+// instead of traversing the tree block after block. Missing keys are cached too.
+type interBlockCacheStore struct {
+	CommitKVStore
+
+	cache *lru.Cache
+}
+
+func newInterBlockCacheStore(parent CommitKVStore, size int) *interBlockCacheStore {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &interBlockCacheStore{
+		CommitKVStore: parent,
+		cache:         cache,
+	}
+}
+
+// parentIavlStore returns the IavlStore wrapped by store, if any.
+func parentIavlStore(store CommitStore) (*IavlStore, bool) {
+	if cached, ok := store.(*interBlockCacheStore); ok {
+		store = cached.CommitKVStore
+	}
+	iavlStore, ok := store.(*IavlStore)
+	return iavlStore, ok
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Get(key []byte) []byte {
+	if value, ok := st.cache.Get(string(key)); ok {
+		return value.([]byte)
+	}
+	value := st.CommitKVStore.Get(key)
+	st.cache.Add(string(key), value)
+	return value
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Has(key []byte) bool {
+	if value, ok := st.cache.Get(string(key)); ok {
+		return value.([]byte) != nil
+	}
+	return st.CommitKVStore.Has(key)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Set(key, value []byte) {
+	st.CommitKVStore.Set(key, value)
+	st.cache.Add(string(key), value)
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Delete(key []byte) {
+	st.CommitKVStore.Delete(key)
+	st.cache.Add(string(key), []byte(nil))
+}
+
+// Implements KVStore.
+func (st *interBlockCacheStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements Store.
+func (st *interBlockCacheStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// Implements Store.
+func (st *interBlockCacheStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements Queryable, the queries may be for past versions so they skip
+// the cache.
+func (st *interBlockCacheStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	return st.CommitKVStore.(Queryable).Query(req)
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestInterBlockCacheStore(t *testing.T) {
+	db := dbm.NewMemDB()
+	parent := newIAVLStore(iavl.NewMutableTree(db, cacheSize), numRecent, storeEvery)
+	store := newInterBlockCacheStore(parent, 2)
+
+	parent.Set([]byte("k1"), []byte("v1"))
+	require.Equal(t, []byte("v1"), store.Get([]byte("k1")))
+	require.Nil(t, store.Get([]byte("k2")))
+	require.False(t, store.Has([]byte("k2")))
+
+	// the cached values are served without reading the parent
+	parent.Set([]byte("k1"), []byte("v1 bypassing the cache"))
+	require.Equal(t, []byte("v1"), store.Get([]byte("k1")))
+	require.Nil(t, store.Get([]byte("k2")))
+
+	// writes go through the cache to the parent
+	store.Set([]byte("k2"), []byte("v2"))
+	require.Equal(t, []byte("v2"), parent.Get([]byte("k2")))
+	require.True(t, store.Has([]byte("k2")))
+	store.Delete([]byte("k1"))
+	require.Nil(t, store.Get([]byte("k1")))
+	require.False(t, parent.Has([]byte("k1")))
+
+	// so do the writes of the blocks
+	cacheWrap := store.CacheWrap().(CacheKVStore)
+	cacheWrap.Set([]byte("k3"), []byte("v3"))
+	cacheWrap.Write()
+	require.Equal(t, []byte("v3"), parent.Get([]byte("k3")))
+	require.Equal(t, []byte("v3"), store.Get([]byte("k3")))
+
+	// the least recently used values are evicted
+	parent.Set([]byte("k2"), []byte("v2 bypassing the cache"))
+	require.Equal(t, []byte("v2 bypassing the cache"), store.Get([]byte("k2")))
+}
+
+func TestMultistoreInterBlockCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	multi.SetInterBlockCacheSize(100)
+	require.NoError(t, multi.LoadLatestVersion())
+
+	cached, ok := multi.getStoreByName("store1").(*interBlockCacheStore)
+	require.True(t, ok)
+	iavlStore, ok := parentIavlStore(cached)
+	require.True(t, ok)
+	require.Equal(t, cached.CommitKVStore, iavlStore)
+
+	cacheMulti := multi.CacheMultiStore()
+	cacheMulti.GetKVStore(multi.keysByName["store1"]).Set([]byte("key"), []byte("value"))
+	cacheMulti.Write()
+	commitID := multi.Commit()
+	require.Equal(t, []byte("value"), iavlStore.Get([]byte("key")))
+
+	res := multi.Query(abci.RequestQuery{Path: "/store1/key", Data: []byte("key"), Height: commitID.Version})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(res.Code))
+	require.Equal(t, []byte("value"), res.Value)
+
+	// the stores reloaded with the multistore start with an empty cache
+	require.NoError(t, multi.LoadLatestVersion())
+	reloaded := multi.getStoreByName("store1").(*interBlockCacheStore)
+	require.Equal(t, 0, reloaded.cache.Len())
+	require.Equal(t, []byte("value"), reloaded.Get([]byte("key")))
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LatencyKVStore observes the latency in seconds of the operations on its
+// parent into a histogram, labelled by the store name and the operation.
+type LatencyKVStore struct {
+	parent    sdk.KVStore
+	name      string
+	histogram metrics.Histogram
+}
+
+// NewLatencyKVStore returns a LatencyKVStore of the parent store named name.
+func NewLatencyKVStore(parent sdk.KVStore, name string, histogram metrics.Histogram) *LatencyKVStore {
+	return &LatencyKVStore{parent: parent, name: name, histogram: histogram}
+}
+
+func (lkv *LatencyKVStore) observe(op string, start time.Time) {
+	lkv.histogram.With("store", lkv.name, "operation", op).Observe(time.Since(start).Seconds())
+}
+
+// Get implements the KVStore interface.
+func (lkv *LatencyKVStore) Get(key []byte) []byte {
+	defer lkv.observe("get", time.Now())
+	return lkv.parent.Get(key)
+}
+
+// Set implements the KVStore interface.
+func (lkv *LatencyKVStore) Set(key []byte, value []byte) {
+	defer lkv.observe("set", time.Now())
+	lkv.parent.Set(key, value)
+}
+
+// Delete implements the KVStore interface.
+func (lkv *LatencyKVStore) Delete(key []byte) {
+	defer lkv.observe("delete", time.Now())
+	lkv.parent.Delete(key)
+}
+
+// Has implements the KVStore interface.
+func (lkv *LatencyKVStore) Has(key []byte) bool {
+	defer lkv.observe("has", time.Now())
+	return lkv.parent.Has(key)
+}
+
+// Prefix implements the KVStore interface.
+func (lkv *LatencyKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{lkv, prefix}
+}
+
+// Iterator implements the KVStore interface, the creation of the iterator
+// and its moves are observed.
+func (lkv *LatencyKVStore) Iterator(start, end []byte) sdk.Iterator {
+	defer lkv.observe("iterator", time.Now())
+	return &latencyIterator{lkv.parent.Iterator(start, end), lkv}
+}
+
+// ReverseIterator implements the KVStore interface, the creation of the
+// iterator and its moves are observed.
+func (lkv *LatencyKVStore) ReverseIterator(start, end []byte) sdk.Iterator {
+	defer lkv.observe("iterator", time.Now())
+	return &latencyIterator{lkv.parent.ReverseIterator(start, end), lkv}
+}
+
+// GetStoreType implements the KVStore interface.
+func (lkv *LatencyKVStore) GetStoreType() sdk.StoreType {
+	return lkv.parent.GetStoreType()
+}
+
+// CacheWrap implements the KVStore interface.
+func (lkv *LatencyKVStore) CacheWrap() sdk.CacheWrap {
+	return NewCacheKVStore(lkv)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (lkv *LatencyKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(lkv, w, tc))
+}
+
+type latencyIterator struct {
+	sdk.Iterator
+	store *LatencyKVStore
+}
+
+// Next implements the Iterator interface.
+func (li *latencyIterator) Next() {
+	defer li.store.observe("next", time.Now())
+	li.Iterator.Next()
+}
@@ -30,9 +30,9 @@ func (proof *MultiStoreProof) ComputeRootHash() []byte {
 // RequireProof return whether proof is require for the subpath
 func RequireProof(subpath string) bool {
 	// XXX: create a better convention.
-	// Currently, only when query subpath is "/store" or "/key", will proof be included in response.
+	// Currently, only when query subpath is "/store", "/key", "/ics23-key" or "/range", will proof be included in response.
 	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go:212
-	if subpath == "/store" || subpath == "/key" || subpath == "/ics23-key" {
+	if subpath == "/store" || subpath == "/key" || subpath == "/ics23-key" || subpath == "/range" {
 		return true
 	}
 	return false
@@ -135,6 +135,7 @@ func DefaultProofRuntime() (prt *merkle.ProofRuntime) {
 	prt.RegisterOpDecoder(ProofOpIAVLCommitment, CommitmentOpDecoder)
 	prt.RegisterOpDecoder(ProofOpSimpleMerkleCommitment, CommitmentOpDecoder)
 	prt.RegisterOpDecoder(iavl.ProofOpIAVLAbsence, iavl.IAVLAbsenceOpDecoder)
+	prt.RegisterOpDecoder(ProofOpIAVLRange, RangeCommitmentOpDecoder)
 	prt.RegisterOpDecoder(ProofOpMultiStore, MultiStoreProofOpDecoder)
 	return
 }
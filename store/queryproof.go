@@ -0,0 +1,220 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProofOpQueryRead is the type of the ProofOps carrying the reads of a custom
+// querier, each one proven by the store query of the read.
+const ProofOpQueryRead = "query:read"
+
+// QueryRead is a read made by a custom querier on a store: the key it read,
+// at the "/key" subpath, or the range of keys it iterated, at the "/range"
+// subpath with the encoded KeyRange as key. The value and the proof are those
+// of the store query of the read.
+type QueryRead struct {
+	Store   string        `json:"store"`
+	Subpath string        `json:"subpath"`
+	Key     []byte        `json:"key"`
+	Value   []byte        `json:"value"`
+	Proof   *merkle.Proof `json:"proof"`
+}
+
+// Path returns the path of the store query of the read.
+func (r QueryRead) Path() string {
+	return fmt.Sprintf("/store/%s%s", r.Store, r.Subpath)
+}
+
+// ProofOp wraps the read into a ProofOp of a custom query response. It is
+// not run by a ProofRuntime, see Verify.
+func (r QueryRead) ProofOp() merkle.ProofOp {
+	return merkle.ProofOp{
+		Type: ProofOpQueryRead,
+		Key:  []byte(r.Store),
+		Data: cdc.MustMarshalBinaryLengthPrefixed(r),
+	}
+}
+
+// Verify verifies the value of the read against the app hash of the height
+// it was read at.
+func (r QueryRead) Verify(prt *merkle.ProofRuntime, appHash []byte) error {
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(r.Store), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(r.Key, merkle.KeyEncodingURL)
+
+	if r.Value == nil {
+		return prt.VerifyAbsence(r.Proof, appHash, kp.String())
+	}
+	return prt.VerifyValue(r.Proof, appHash, kp.String(), r.Value)
+}
+
+// QueryReadsFromProof decodes the reads of a custom query response proof.
+func QueryReadsFromProof(proof *merkle.Proof) ([]QueryRead, error) {
+	if proof == nil {
+		return nil, cmn.NewError("no proof of the query reads")
+	}
+	reads := make([]QueryRead, len(proof.Ops))
+	for i, op := range proof.Ops {
+		if op.Type != ProofOpQueryRead {
+			return nil, cmn.NewError("unexpected ProofOp.Type; got %v, want %v", op.Type, ProofOpQueryRead)
+		}
+		if err := cdc.UnmarshalBinaryLengthPrefixed(op.Data, &reads[i]); err != nil {
+			return nil, cmn.ErrorWrap(err, "decoding ProofOp.Data into QueryRead")
+		}
+		if reads[i].Store != string(op.Key) {
+			return nil, cmn.NewError("read of store %s in the ProofOp of store %s", reads[i].Store, op.Key)
+		}
+	}
+	return reads, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// QueryReadRecorder records the keys read and the ranges iterated on the
+// stores it wraps, to prove them once the query is done.
+type QueryReadRecorder struct {
+	mtx   sync.Mutex
+	reads []QueryRead
+	seen  map[string]bool
+}
+
+func NewQueryReadRecorder() *QueryReadRecorder {
+	return &QueryReadRecorder{seen: make(map[string]bool)}
+}
+
+// KVStore wraps parent, the store named name, to record its reads.
+func (r *QueryReadRecorder) KVStore(name string, parent KVStore) KVStore {
+	return readRecordKVStore{parent: parent, recorder: r, name: name}
+}
+
+// Reads returns the reads recorded so far, in the order of their first
+// occurrence, without their values and proofs.
+func (r *QueryReadRecorder) Reads() []QueryRead {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	reads := make([]QueryRead, len(r.reads))
+	copy(reads, r.reads)
+	return reads
+}
+
+func (r *QueryReadRecorder) record(name, subpath string, key []byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	id := fmt.Sprintf("%s%s/%X", name, subpath, key)
+	if r.seen[id] {
+		return
+	}
+	r.seen[id] = true
+	r.reads = append(r.reads, QueryRead{Store: name, Subpath: subpath, Key: key})
+}
+
+// readRecordKVStore records the reads made on its parent.
+type readRecordKVStore struct {
+	parent   KVStore
+	recorder *QueryReadRecorder
+	name     string
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Get(key []byte) []byte {
+	st.recorder.record(st.name, "/key", key)
+	return st.parent.Get(key)
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Has(key []byte) bool {
+	st.recorder.record(st.name, "/key", key)
+	return st.parent.Has(key)
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Set(key, value []byte) {
+	st.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Delete(key []byte) {
+	st.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) Iterator(start, end []byte) Iterator {
+	return &readRecordIterator{Iterator: st.parent.Iterator(start, end), store: st, start: start, end: end, ascending: true}
+}
+
+// Implements KVStore.
+func (st readRecordKVStore) ReverseIterator(start, end []byte) Iterator {
+	return &readRecordIterator{Iterator: st.parent.ReverseIterator(start, end), store: st, start: start, end: end}
+}
+
+// Implements Store.
+func (st readRecordKVStore) GetStoreType() StoreType {
+	return st.parent.GetStoreType()
+}
+
+// Implements Store.
+func (st readRecordKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st readRecordKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// readRecordIterator records the range of keys iterated when closed: the
+// range of the iterator if it reached its end, otherwise the part of the
+// range up to the last key seen.
+type readRecordIterator struct {
+	sdk.Iterator
+	store      readRecordKVStore
+	start, end []byte
+	ascending  bool
+
+	last      []byte
+	exhausted bool
+}
+
+// Implements Iterator.
+func (it *readRecordIterator) Valid() bool {
+	valid := it.Iterator.Valid()
+	if valid {
+		it.last = it.Iterator.Key()
+	} else {
+		it.exhausted = true
+	}
+	return valid
+}
+
+// Implements Iterator.
+func (it *readRecordIterator) Close() {
+	kr := KeyRange{Start: it.start, End: it.end}
+	switch {
+	case it.exhausted:
+	case it.last == nil:
+		// nothing was read
+		it.Iterator.Close()
+		return
+	case it.ascending:
+		kr.End = append(append([]byte{}, it.last...), 0)
+	default:
+		kr.Start = it.last
+	}
+	if kr.Start == nil || kr.End == nil || bytes.Compare(kr.Start, kr.End) < 0 {
+		it.store.recorder.record(it.store.name, "/range", kr.Bytes())
+	}
+	it.Iterator.Close()
+}
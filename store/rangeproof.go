@@ -0,0 +1,184 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bnb-chain/ics23"
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+const ProofOpIAVLRange = "ics23:iavl-range"
+
+// KeyRange is the range of keys [Start, End) of a range query, a nil bound
+// leaves the range open on its side.
+type KeyRange struct {
+	Start []byte `json:"start"`
+	End   []byte `json:"end"`
+}
+
+// Contains reports if key is in the range.
+func (kr KeyRange) Contains(key []byte) bool {
+	return (kr.Start == nil || bytes.Compare(key, kr.Start) >= 0) &&
+		(kr.End == nil || bytes.Compare(key, kr.End) < 0)
+}
+
+// Bytes returns the encoding of the range, the data of its range query.
+func (kr KeyRange) Bytes() []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(kr)
+}
+
+//-----------------------------------------------------------------------------
+
+var _ merkle.ProofOperator = RangeCommitmentOp{}
+
+// RangeCommitmentOp implements merkle.ProofOperator by wrapping a batch of
+// ics23 ExistenceProofs of consecutive leaves of an IAVL tree: the leaves in
+// a range of keys, along with the leaf before the range and the one after it
+// when they exist. It proves the key/value pairs of the range are all those
+// of the range.
+type RangeCommitmentOp struct {
+	// the encoded KeyRange
+	Key   []byte
+	Proof *ics23.CommitmentProof
+}
+
+// NewRangeCommitmentOp proves the range kr of tree, returning the pairs of
+// the range along with their proof.
+func NewRangeCommitmentOp(tree *iavl.ImmutableTree, kr KeyRange) ([]KVPair, RangeCommitmentOp, error) {
+	op := RangeCommitmentOp{Key: kr.Bytes()}
+	var keys [][]byte
+	pairs := make([]KVPair, 0)
+	if kr.Start != nil {
+		// the leaf before the range
+		if index, _ := tree.Get(kr.Start); index > 0 {
+			key, _ := tree.GetByIndex(index - 1)
+			keys = append(keys, key)
+		}
+	}
+	tree.IterateRange(kr.Start, kr.End, true, func(key, value []byte) bool {
+		keys = append(keys, key)
+		pairs = append(pairs, KVPair{Key: key, Value: value})
+		return false
+	})
+	if kr.End != nil {
+		// the leaf after the range
+		if index, _ := tree.Get(kr.End); index < tree.Size() {
+			key, _ := tree.GetByIndex(index)
+			keys = append(keys, key)
+		}
+	}
+
+	entries := make([]*ics23.BatchEntry, len(keys))
+	for i, key := range keys {
+		proof, err := tree.GetMembershipProof(key)
+		if err != nil {
+			return nil, op, err
+		}
+		entries[i] = &ics23.BatchEntry{Proof: &ics23.BatchEntry_Exist{Exist: proof.GetExist()}}
+	}
+	op.Proof = &ics23.CommitmentProof{Proof: &ics23.CommitmentProof_Batch{Batch: &ics23.BatchProof{Entries: entries}}}
+	return pairs, op, nil
+}
+
+// RangeCommitmentOpDecoder decodes a ProofOp into a RangeCommitmentOp.
+func RangeCommitmentOpDecoder(pop merkle.ProofOp) (merkle.ProofOperator, error) {
+	if pop.Type != ProofOpIAVLRange {
+		return nil, fmt.Errorf("unexpected ProofOp.Type; got %s, want %s", pop.Type, ProofOpIAVLRange)
+	}
+	proof := &ics23.CommitmentProof{}
+	if err := proof.Unmarshal(pop.Data); err != nil {
+		return nil, err
+	}
+	return RangeCommitmentOp{Key: pop.Key, Proof: proof}, nil
+}
+
+func (op RangeCommitmentOp) GetKey() []byte {
+	return op.Key
+}
+
+// Run takes the encoded key/value pairs of the range as its only argument,
+// and returns the root wrapped in [][]byte if the proof op proves them. The
+// root of the proof of an empty tree is nil.
+func (op RangeCommitmentOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("args must be length 1, got: %d", len(args))
+	}
+	var kr KeyRange
+	if err := cdc.UnmarshalBinaryLengthPrefixed(op.Key, &kr); err != nil {
+		return nil, fmt.Errorf("could not decode the key range: %v", err)
+	}
+	var pairs []KVPair
+	if err := cdc.UnmarshalBinaryLengthPrefixed(args[0], &pairs); err != nil {
+		return nil, fmt.Errorf("could not decode the pairs of the range: %v", err)
+	}
+	batch := op.Proof.GetBatch()
+	if batch == nil {
+		return nil, fmt.Errorf("expected a batch proof")
+	}
+	leaves := make([]*ics23.ExistenceProof, len(batch.Entries))
+	for i, entry := range batch.Entries {
+		if leaves[i] = entry.GetExist(); leaves[i] == nil {
+			return nil, fmt.Errorf("expected an existence proof for leaf #%d", i)
+		}
+	}
+	if len(leaves) == 0 {
+		if len(pairs) != 0 {
+			return nil, fmt.Errorf("%d pairs in the range of an empty tree", len(pairs))
+		}
+		return [][]byte{nil}, nil
+	}
+
+	root, err := leaves[0].Calculate()
+	if err != nil {
+		return nil, fmt.Errorf("could not calculate root for proof: %v", err)
+	}
+	spec := ics23.IavlSpec
+	i := 0
+	for j, leaf := range leaves {
+		if err := leaf.Verify(spec, root, leaf.Key, leaf.Value); err != nil {
+			return nil, fmt.Errorf("leaf #%d does not verify: %v", j, err)
+		}
+		if j > 0 && !ics23.IsLeftNeighbor(spec.InnerSpec, leaves[j-1].Path, leaf.Path) {
+			return nil, fmt.Errorf("leaf #%d is not the neighbor of leaf #%d", j, j-1)
+		}
+		if !kr.Contains(leaf.Key) {
+			continue
+		}
+		if i >= len(pairs) || !bytes.Equal(leaf.Key, pairs[i].Key) || !bytes.Equal(leaf.Value, pairs[i].Value) {
+			return nil, fmt.Errorf("pair #%d of the range is not proven", i)
+		}
+		i++
+	}
+	if i != len(pairs) {
+		return nil, fmt.Errorf("pair #%d of the range is not proven", i)
+	}
+
+	// the consecutive leaves span the range
+	first, last := leaves[0], leaves[len(leaves)-1]
+	before := kr.Start != nil && bytes.Compare(first.Key, kr.Start) < 0
+	if !before && !bytes.Equal(first.Key, kr.Start) && !ics23.IsLeftMost(spec.InnerSpec, first.Path) {
+		return nil, fmt.Errorf("the leaves do not span the start of the range")
+	}
+	after := kr.End != nil && bytes.Compare(last.Key, kr.End) >= 0
+	if !after && !ics23.IsRightMost(spec.InnerSpec, last.Path) {
+		return nil, fmt.Errorf("the leaves do not span the end of the range")
+	}
+	return [][]byte{root}, nil
+}
+
+// ProofOp implements ProofOperator interface and converts a RangeCommitmentOp
+// into a merkle.ProofOp format that can later be decoded by
+// RangeCommitmentOpDecoder.
+func (op RangeCommitmentOp) ProofOp() merkle.ProofOp {
+	bz, err := op.Proof.Marshal()
+	if err != nil {
+		panic(err.Error())
+	}
+	return merkle.ProofOp{
+		Type: ProofOpIAVLRange,
+		Key:  op.Key,
+		Data: bz,
+	}
+}
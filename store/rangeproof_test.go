@@ -0,0 +1,52 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestIAVLRangeProof(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	kv := store.GetKVStore(store.keysByName["store1"])
+	for _, key := range []string{"a", "ab", "ab\x01", "b", "c"} {
+		kv.Set([]byte(key), []byte("value-"+key))
+	}
+	commitID := store.Commit()
+
+	prt := DefaultProofRuntime()
+	prove := func(kr KeyRange) ([]KVPair, abci.ResponseQuery) {
+		res := store.Query(abci.RequestQuery{Path: "/store1/range", Data: kr.Bytes(), Height: 1, Prove: true})
+		require.True(t, res.IsOK(), res.Log)
+		var pairs []KVPair
+		cdc.MustUnmarshalBinaryLengthPrefixed(res.Value, &pairs)
+		kp := merkle.KeyPath{}.AppendKey([]byte("store1"), merkle.KeyEncodingURL).AppendKey(res.Key, merkle.KeyEncodingURL)
+		require.Nil(t, prt.VerifyValue(res.Proof, commitID.Hash, kp.String(), res.Value))
+		return pairs, res
+	}
+
+	pairs, _ := prove(KeyRange{})
+	require.Len(t, pairs, 5)
+	// the keys just before the end are in the range
+	pairs, res := prove(KeyRange{Start: []byte("aa"), End: []byte("ac")})
+	require.Equal(t, []KVPair{
+		{Key: []byte("ab"), Value: []byte("value-ab")},
+		{Key: []byte("ab\x01"), Value: []byte("value-ab\x01")},
+	}, pairs)
+	pairs, _ = prove(KeyRange{Start: []byte("d")})
+	require.Len(t, pairs, 0)
+
+	// the pairs left out of the range are not proven
+	kp := merkle.KeyPath{}.AppendKey([]byte("store1"), merkle.KeyEncodingURL).AppendKey(res.Key, merkle.KeyEncodingURL)
+	for _, tampered := range [][]KVPair{pairs[:0], {{Key: []byte("ab"), Value: []byte("value-ab")}}} {
+		value := cdc.MustMarshalBinaryLengthPrefixed(tampered)
+		require.NotNil(t, prt.VerifyValue(res.Proof, commitID.Hash, kp.String(), value))
+	}
+	// the range of the proof is the range of the query
+	other := merkle.KeyPath{}.AppendKey([]byte("store1"), merkle.KeyEncodingURL).AppendKey(KeyRange{Start: []byte("aa"), End: []byte("ab")}.Bytes(), merkle.KeyEncodingURL)
+	require.NotNil(t, prt.VerifyValue(res.Proof, commitID.Hash, other.String(), res.Value))
+}
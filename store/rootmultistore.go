@@ -31,6 +31,18 @@ type rootMultiStore struct {
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
 
+	// number of values cached across blocks per IAVL store, 0 to disable
+	interBlockCacheSize int
+
+	// records the writes of each version, nil to disable
+	stateDiff *stateDiffRecorder
+
+	// buffers the writes of each version into db, nil to disable
+	commitBuffer *commitBuffer
+	asyncFlush   bool
+	// waits for the background flush of the last version, nil if none
+	pendingFlush func()
+
 	traceWriter  io.Writer
 	traceContext TraceContext
 }
@@ -42,6 +54,7 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -56,6 +69,33 @@ func (rs *rootMultiStore) SetPruning(pruning sdk.PruningStrategy) {
 	}
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetInterBlockCacheSize(size int) {
+	rs.interBlockCacheSize = size
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetStateDiffDir(dir string) {
+	rs.stateDiff = nil
+	if dir != "" {
+		rs.stateDiff = newStateDiffRecorder(dir)
+	}
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetCommitBuffer(enabled, async bool) {
+	rs.waitFlush()
+	if rs.commitBuffer != nil {
+		rs.db = rs.commitBuffer.DB
+		rs.commitBuffer = nil
+	}
+	if enabled {
+		rs.commitBuffer = newCommitBuffer(rs.db)
+		rs.db = rs.commitBuffer
+	}
+	rs.asyncFlush = async
+}
+
 // Implements Store.
 func (rs *rootMultiStore) GetStoreType() StoreType {
 	return sdk.StoreTypeMulti
@@ -159,6 +199,42 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	return nil
 }
 
+// Implements CommitMultiStore. The IAVL stores are read at ver from the
+// trees of the loaded stores, which must keep the version.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(ver int64) (CacheMultiStore, error) {
+	cInfo, err := getCommitInfo(rs.db, ver)
+	if err != nil {
+		return nil, err
+	}
+	committed := make(map[string]bool, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		committed[storeInfo.Name] = true
+	}
+
+	cms := cacheMultiStore{
+		db:         NewCacheKVStore(dbStoreAdapter{rs.db}),
+		stores:     make(map[StoreKey]CacheWrap, len(rs.stores)),
+		keysByName: rs.keysByName,
+	}
+	for key, store := range rs.stores {
+		iavlStore, ok := parentIavlStore(store)
+		switch {
+		case ok && committed[key.Name()]:
+			tree, err := iavlStore.Tree.GetImmutable(ver)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load version %d of store %s: %v", ver, key.Name(), err)
+			}
+			cms.stores[key] = immutableIavlStore{tree}.CacheWrap()
+		case ok || store.GetStoreType() == sdk.StoreTypeTransient:
+			// the store was empty at ver
+			cms.stores[key] = newTransientStore().CacheWrap()
+		default:
+			return nil, fmt.Errorf("cannot load version %d of store %s", ver, key.Name())
+		}
+	}
+	return cms, nil
+}
+
 // WithTracer sets the tracer for the MultiStore that the underlying
 // stores will utilize to trace operations. A MultiStore is returned.
 func (rs *rootMultiStore) WithTracer(w io.Writer) MultiStore {
@@ -206,6 +282,10 @@ func (rs *rootMultiStore) SetVersion(version int64) {}
 
 // Implements Committer/CommitStore.
 func (rs *rootMultiStore) Commit() CommitID {
+	// the background flush of the last version overlaps with the execution
+	// of this one, it is written before this version is buffered
+	rs.waitFlush()
+
 	version := rs.lastCommitID.Version + 1
 	// Commit stores.
 	commitInfo := commitStores(version, rs.stores)
@@ -217,6 +297,18 @@ func (rs *rootMultiStore) Commit() CommitID {
 	setLatestVersion(batch, version)
 	batch.Write()
 
+	// the buffered writes of the version are written at once, a background
+	// flush is synced while the next version executes
+	if rs.commitBuffer != nil {
+		wait := rs.commitBuffer.flush(rs.asyncFlush, rs.asyncFlush)
+		if rs.asyncFlush {
+			rs.pendingFlush = wait
+		}
+	}
+	if rs.stateDiff != nil {
+		rs.stateDiff.flush(version)
+	}
+
 	// Prepare for next version.
 	commitID := CommitID{
 		Version: version,
@@ -226,6 +318,14 @@ func (rs *rootMultiStore) Commit() CommitID {
 	return commitID
 }
 
+// waitFlush waits for the background flush of the last version, if any.
+func (rs *rootMultiStore) waitFlush() {
+	if rs.pendingFlush != nil {
+		rs.pendingFlush()
+		rs.pendingFlush = nil
+	}
+}
+
 // Implements CacheWrapper/Store/CommitStore.
 func (rs *rootMultiStore) CacheWrap() CacheWrap {
 	return rs.CacheMultiStore().(CacheWrap)
@@ -254,6 +354,9 @@ func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 // tracer, otherwise, the original KVStore will be returned.
 func (rs *rootMultiStore) GetKVStore(key StoreKey) KVStore {
 	store := rs.stores[key].(KVStore)
+	if diffStore, ok := rs.diffKVStore(key); ok {
+		store = diffStore
+	}
 
 	if rs.TracingEnabled() {
 		store = NewTraceKVStore(store, rs.traceWriter, rs.traceContext)
@@ -262,6 +365,20 @@ func (rs *rootMultiStore) GetKVStore(key StoreKey) KVStore {
 	return store
 }
 
+// diffKVStore returns the store of key recording its writes into the state
+// diff, if the writes of the store are recorded. The transient stores are not
+// part of the state.
+func (rs *rootMultiStore) diffKVStore(key StoreKey) (KVStore, bool) {
+	if rs.stateDiff == nil {
+		return nil, false
+	}
+	store, ok := rs.stores[key].(KVStore)
+	if !ok || store.GetStoreType() == sdk.StoreTypeTransient {
+		return nil, false
+	}
+	return rs.stateDiff.kvStore(key.Name(), store), true
+}
+
 // Implements MultiStore
 
 // getStoreByName will first convert the original name to
@@ -360,6 +477,9 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning)
+		if err == nil && rs.interBlockCacheSize > 0 {
+			store = newInterBlockCacheStore(store.(CommitKVStore), rs.interBlockCacheSize)
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -98,6 +98,44 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	key := store.keysByName["store1"]
+	k, v1, v2 := []byte("key"), []byte("value1"), []byte("value2")
+
+	store.GetKVStore(key).Set(k, v1)
+	store.Commit()
+	store.GetKVStore(key).Set(k, v2)
+	store.Commit()
+
+	// the past version is read and written apart from the latest one
+	cms, err := store.CacheMultiStoreWithVersion(1)
+	require.Nil(t, err)
+	require.Equal(t, v1, cms.GetKVStore(key).Get(k))
+	cms.GetKVStore(key).Set(k, []byte("value3"))
+	require.Equal(t, []byte("value3"), cms.GetKVStore(key).Get(k))
+	require.Panics(t, cms.Write)
+	require.Equal(t, v2, store.GetKVStore(key).Get(k))
+	require.Equal(t, int64(2), store.LastCommitID().Version)
+
+	// the stores mounted after the version are empty
+	newKey := sdk.NewKVStoreKey("store4")
+	store = newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(newKey, sdk.StoreTypeIAVL, nil)
+	require.Nil(t, store.LoadLatestVersion())
+	store.GetKVStore(newKey).Set(k, v2)
+	store.Commit()
+	cms, err = store.CacheMultiStoreWithVersion(1)
+	require.Nil(t, err)
+	require.Equal(t, v1, cms.GetKVStore(store.keysByName["store1"]).Get(k))
+	require.Nil(t, cms.GetKVStore(newKey).Get(k))
+
+	_, err = store.CacheMultiStoreWithVersion(4)
+	require.NotNil(t, err)
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
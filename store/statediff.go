@@ -0,0 +1,181 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// StateDiff is the net writes of one or more committed versions on the
+// stores of a multistore, sorted by store and key.
+type StateDiff struct {
+	Height int64        `json:"height"`
+	Writes []StateWrite `json:"writes"`
+}
+
+// StateWrite is the last write of a key of a store, a delete when Deleted.
+type StateWrite struct {
+	Store   string `json:"store"`
+	Key     []byte `json:"key"`
+	Value   []byte `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// stateDiffFile returns the name of the diff file of height, addressed by the
+// sha256 of its content.
+func stateDiffFile(height int64, bz []byte) string {
+	hash := sha256.Sum256(bz)
+	return fmt.Sprintf("%d-%s.json", height, hex.EncodeToString(hash[:]))
+}
+
+// WriteStateDiff writes diff into its content-addressed file of dir.
+func WriteStateDiff(dir string, diff StateDiff) error {
+	bz, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, stateDiffFile(diff.Height, bz)), bz, 0644)
+}
+
+// LoadStateDiff reads the diff file of height in dir, and checks its content
+// against its address.
+func LoadStateDiff(dir string, height int64) (StateDiff, error) {
+	var diff StateDiff
+	paths, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%d-*.json", height)))
+	if err != nil {
+		return diff, err
+	}
+	if len(paths) != 1 {
+		return diff, fmt.Errorf("found %d diff files of height %d in %s", len(paths), height, dir)
+	}
+	bz, err := ioutil.ReadFile(paths[0])
+	if err != nil {
+		return diff, err
+	}
+	if filepath.Base(paths[0]) != stateDiffFile(height, bz) {
+		return diff, fmt.Errorf("the content of %s does not match its hash", paths[0])
+	}
+	if err := json.Unmarshal(bz, &diff); err != nil {
+		return diff, err
+	}
+	if diff.Height != height {
+		return diff, fmt.Errorf("%s is the diff of height %d", paths[0], diff.Height)
+	}
+	return diff, nil
+}
+
+// MergeStateDiffs merges the diffs of consecutive versions, in order, into
+// their net diff: the last write of each key wins.
+func MergeStateDiffs(diffs ...StateDiff) StateDiff {
+	recorder := newStateDiffRecorder("")
+	var merged StateDiff
+	for _, diff := range diffs {
+		for _, write := range diff.Writes {
+			recorder.record(write)
+		}
+		merged.Height = diff.Height
+	}
+	merged.Writes = recorder.pop()
+	return merged
+}
+
+// stateDiffRecorder records the writes made on the committed stores, from
+// the write TraceKVStores over them.
+type stateDiffRecorder struct {
+	dir string
+
+	mtx    sync.Mutex
+	writes map[string]map[string]StateWrite
+}
+
+func newStateDiffRecorder(dir string) *stateDiffRecorder {
+	return &stateDiffRecorder{dir: dir, writes: make(map[string]map[string]StateWrite)}
+}
+
+// kvStore wraps parent, the store named name, to record its writes.
+func (r *stateDiffRecorder) kvStore(name string, parent KVStore) KVStore {
+	return NewWriteTraceKVStore(parent, stateDiffWriter{r, name}, nil)
+}
+
+func (r *stateDiffRecorder) record(write StateWrite) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	keys, ok := r.writes[write.Store]
+	if !ok {
+		keys = make(map[string]StateWrite)
+		r.writes[write.Store] = keys
+	}
+	keys[string(write.Key)] = write
+}
+
+// pop returns the sorted writes recorded so far, and resets the recorder.
+func (r *stateDiffRecorder) pop() []StateWrite {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	writes := make([]StateWrite, 0)
+	for _, keys := range r.writes {
+		for _, write := range keys {
+			writes = append(writes, write)
+		}
+	}
+	r.writes = make(map[string]map[string]StateWrite)
+	sort.Slice(writes, func(i, j int) bool {
+		if writes[i].Store != writes[j].Store {
+			return writes[i].Store < writes[j].Store
+		}
+		return bytes.Compare(writes[i].Key, writes[j].Key) < 0
+	})
+	return writes
+}
+
+// flush writes the diff file of the version committing the recorded writes.
+func (r *stateDiffRecorder) flush(version int64) {
+	if err := WriteStateDiff(r.dir, StateDiff{Height: version, Writes: r.pop()}); err != nil {
+		panic(fmt.Sprintf("failed to write the state diff of version %d: %v", version, err))
+	}
+}
+
+// stateDiffWriter records the operations traced on the store named store.
+type stateDiffWriter struct {
+	recorder *stateDiffRecorder
+	store    string
+}
+
+// Write implements io.Writer, p is either an operation or its line break.
+func (w stateDiffWriter) Write(p []byte) (int, error) {
+	if len(bytes.TrimSpace(p)) == 0 {
+		return len(p), nil
+	}
+	var op traceOperation
+	if err := json.Unmarshal(p, &op); err != nil {
+		return 0, err
+	}
+	key, err := base64.StdEncoding.DecodeString(op.Key)
+	if err != nil {
+		return 0, err
+	}
+	write := StateWrite{Store: w.store, Key: key}
+	switch op.Operation {
+	case writeOp:
+		if write.Value, err = base64.StdEncoding.DecodeString(op.Value); err != nil {
+			return 0, err
+		}
+	case deleteOp:
+		write.Deleted = true
+	default:
+		return 0, fmt.Errorf("unexpected %s operation on a write trace", op.Operation)
+	}
+	w.recorder.record(write)
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestStateDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state-diff")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	transientKey := sdk.NewTransientStoreKey("transient")
+	store.MountStoreWithDB(transientKey, sdk.StoreTypeTransient, nil)
+	store.SetStateDiffDir(dir)
+	require.Nil(t, store.LoadLatestVersion())
+	key1, key2 := store.keysByName["store1"], store.keysByName["store2"]
+	k1, k2 := []byte("key1"), []byte("key2")
+
+	// the writes of the cache wraps are recorded when written
+	cms := store.CacheMultiStore()
+	cms.GetKVStore(key1).Set(k2, []byte("value1"))
+	cms.GetKVStore(key1).Set(k2, []byte("value2"))
+	cms.GetKVStore(key1).Set(k1, []byte("value"))
+	cms.GetKVStore(transientKey).Set(k1, []byte("value"))
+	cms.Write()
+	store.GetKVStore(key2).Set(k1, []byte("value"))
+	store.Commit()
+
+	// the reads are not recorded
+	store.GetKVStore(key1).Get(k1)
+	store.GetKVStore(key2).Delete(k1)
+	store.Commit()
+
+	diff1, err := LoadStateDiff(dir, 1)
+	require.Nil(t, err)
+	require.Equal(t, StateDiff{Height: 1, Writes: []StateWrite{
+		{Store: "store1", Key: k1, Value: []byte("value")},
+		{Store: "store1", Key: k2, Value: []byte("value2")},
+		{Store: "store2", Key: k1, Value: []byte("value")},
+	}}, diff1)
+	diff2, err := LoadStateDiff(dir, 2)
+	require.Nil(t, err)
+	require.Equal(t, []StateWrite{{Store: "store2", Key: k1, Deleted: true}}, diff2.Writes)
+
+	require.Equal(t, StateDiff{Height: 2, Writes: []StateWrite{
+		{Store: "store1", Key: k1, Value: []byte("value")},
+		{Store: "store1", Key: k2, Value: []byte("value2")},
+		{Store: "store2", Key: k1, Deleted: true},
+	}}, MergeStateDiffs(diff1, diff2))
+
+	// the diff files are checked against their address
+	_, err = LoadStateDiff(dir, 3)
+	require.NotNil(t, err)
+	paths, err := filepath.Glob(filepath.Join(dir, "2-*.json"))
+	require.Nil(t, err)
+	require.Len(t, paths, 1)
+	require.Nil(t, ioutil.WriteFile(paths[0], []byte(`{"height":2,"writes":[]}`), 0644))
+	_, err = LoadStateDiff(dir, 2)
+	require.NotNil(t, err)
+}
@@ -68,6 +68,7 @@ type StateSyncHelper struct {
 	stateSyncStoreInfos []StoreInfo
 
 	hashesToIdx      map[abci.SHA256Sum]int          // chunkhash -> idx in manifest
+	writtenChunks    map[abci.SHA256Sum]struct{}     // app state chunks written so far, to detect duplicated and missing ones
 	incompleteChunks map[int64][]incompleteChunkItem // node idx -> incomplete chunk items, for caching incomplete nodes temporally
 	prefixNodeDBs    []PrefixNodeDB
 	chunksSynced     int // no need to reset after recover, as statesync only happened once
@@ -105,13 +106,11 @@ func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
 			continue
 		}
 
-		switch store.(type) {
-		case *IavlStore:
+		if _, ok := parentIavlStore(store); ok {
 			nameToKey[key.Name()] = key
 			names = append(names, key.Name())
-		default:
-			// deliberately do nothing other store type doesn't effect app hash
 		}
+		// deliberately do nothing other store type doesn't effect app hash
 	}
 	sort.Strings(names)
 	storeKeys := make([]sdk.StoreKey, 0, len(names))
@@ -145,6 +144,7 @@ func (helper *StateSyncHelper) StartRecovery(manifest *abci.Manifest) error {
 	helper.manifest = manifest
 	helper.stateSyncStoreInfos = make([]StoreInfo, 0, len(storeKeys))
 	helper.hashesToIdx = make(map[abci.SHA256Sum]int, len(manifest.AppStateHashes))
+	helper.writtenChunks = make(map[abci.SHA256Sum]struct{}, len(manifest.AppStateHashes))
 	helper.incompleteChunks = make(map[int64][]incompleteChunkItem, 0)
 	helper.prefixNodeDBs = make([]PrefixNodeDB, 0, len(storeKeys))
 
@@ -179,66 +179,80 @@ func (helper *StateSyncHelper) WriteRecoveryChunk(hash abci.SHA256Sum, chunk *ab
 	defer helper.reloadingMtx.Unlock()
 
 	if chunk != nil {
-		numOfNodes := len(chunk.Nodes)
-		nodes := make([]*iavl.Node, 0, numOfNodes)
-		
-		if numOfNodes == 0 {
-			return fmt.Errorf("length of nodes is 0")
+		chunkIdx, ok := helper.hashesToIdx[hash]
+		if !ok {
+			return fmt.Errorf("app state chunk %x is not in the manifest", hash)
 		}
+		if _, written := helper.writtenChunks[hash]; written {
+			// the chunk was loaded from disk and received from a peer again
+			helper.logger.Info("skip written recovery chunk", "hash", fmt.Sprintf("%x", hash))
+		} else if err := helper.writeChunk(chunkIdx, hash, chunk, isComplete); err != nil {
+			return err
+		}
+	}
+
+	if isComplete {
+		err = helper.finishCompleteChunkWrite()
+	}
 
-		helper.logger.Info("start write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+	return err
+}
+
+func (helper *StateSyncHelper) writeChunk(chunkIdx int, hash abci.SHA256Sum, chunk *abci.AppStateChunk, isComplete bool) error {
+	numOfNodes := len(chunk.Nodes)
+	if numOfNodes == 0 {
+		return fmt.Errorf("length of nodes is 0")
+	}
+	nodes := make([]*iavl.Node, 0, numOfNodes)
 
-		switch chunk.Completeness {
-		case abci.Complete: // chunk is independent and complete
-			for idx := 0; idx < numOfNodes; idx++ {
-				node, _ := iavl.MakeNode(chunk.Nodes[idx])
+	helper.logger.Info("start write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+
+	switch chunk.Completeness {
+	case abci.Complete: // chunk is independent and complete
+		for idx := 0; idx < numOfNodes; idx++ {
+			node, _ := iavl.MakeNode(chunk.Nodes[idx])
+			iavl.Hash(node)
+			nodes = append(nodes, node)
+		}
+	case abci.InComplete_First:
+		for idx := 0; idx < numOfNodes-1; idx++ {
+			if node, err := iavl.MakeNode(chunk.Nodes[idx]); err == nil {
 				iavl.Hash(node)
 				nodes = append(nodes, node)
+			} else {
+				return err
 			}
-		case abci.InComplete_First:
-			for idx := 0; idx < numOfNodes-1; idx++ {
-				if node, err := iavl.MakeNode(chunk.Nodes[idx]); err == nil {
-					iavl.Hash(node)
-					nodes = append(nodes, node)
-				} else {
-					return err
-				}
-			}
-
-			nodeIdx := chunk.StartIdx + int64(numOfNodes-1)
-			helper.incompleteChunks[nodeIdx] = append(helper.incompleteChunks[nodeIdx],
-				incompleteChunkItem{
-					helper.hashesToIdx[hash],
-					chunk.Completeness,
-					chunk.Nodes[numOfNodes-1]})
-		case abci.InComplete_Mid, abci.InComplete_Last:
-			if numOfNodes != 1 {
-				helper.logger.Error("incomplete chunk should has only one node", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
-			}
-
-			helper.incompleteChunks[chunk.StartIdx] = append(helper.incompleteChunks[chunk.StartIdx], incompleteChunkItem{helper.hashesToIdx[hash], chunk.Completeness, chunk.Nodes[0]})
-		default:
-			helper.logger.Error("unknown completeness status", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
 		}
 
-		// write complete nodes right now
-		for idx, node := range nodes {
-			nodeIdx := chunk.StartIdx + int64(idx)
-			helper.saveNode(nodeIdx, node)
+		nodeIdx := chunk.StartIdx + int64(numOfNodes-1)
+		helper.incompleteChunks[nodeIdx] = append(helper.incompleteChunks[nodeIdx],
+			incompleteChunkItem{
+				chunkIdx,
+				chunk.Completeness,
+				chunk.Nodes[numOfNodes-1]})
+	case abci.InComplete_Mid, abci.InComplete_Last:
+		if numOfNodes != 1 {
+			helper.logger.Error("incomplete chunk should has only one node", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
 		}
 
-		helper.chunksSynced++
-		if helper.chunksSynced%chunksToFlushBatch == 0 {
-			helper.flushBatch()
-		}
-		helper.logger.Info("finished write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+		helper.incompleteChunks[chunk.StartIdx] = append(helper.incompleteChunks[chunk.StartIdx], incompleteChunkItem{chunkIdx, chunk.Completeness, chunk.Nodes[0]})
+	default:
+		helper.logger.Error("unknown completeness status", "hash", hash, "startIdx", chunk.StartIdx, "completeness", chunk.Completeness, "numOfNodes", numOfNodes)
 	}
 
-	if isComplete {
-		err = helper.finishCompleteChunkWrite()
+	// write complete nodes right now
+	for idx, node := range nodes {
+		nodeIdx := chunk.StartIdx + int64(idx)
+		helper.saveNode(nodeIdx, node)
 	}
 
-	return err
+	helper.chunksSynced++
+	if helper.chunksSynced%chunksToFlushBatch == 0 {
+		helper.flushBatch()
+	}
+	helper.logger.Info("finished write recovery chunk", "isComplete", isComplete, "hash", fmt.Sprintf("%x", hash), "startIdx", chunk.StartIdx, "numOfNodes", numOfNodes, "chunkCompletion", chunk.Completeness)
+	helper.writtenChunks[hash] = struct{}{}
+	return nil
 }
 
 func (helper *StateSyncHelper) DeleteSnapshot(height int64) error {
@@ -248,6 +262,9 @@ func (helper *StateSyncHelper) DeleteSnapshot(height int64) error {
 }
 
 func (helper *StateSyncHelper) finishCompleteChunkWrite() error {
+	if missing := len(helper.manifest.AppStateHashes) - len(helper.writtenChunks); missing > 0 {
+		return fmt.Errorf("%d of %d app state chunks of the manifest are missing", missing, len(helper.manifest.AppStateHashes))
+	}
 	helper.prepareEmptyStores()
 	if err := helper.saveIncompleteChunks(); err != nil {
 		return err
@@ -432,10 +449,10 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 		var currChunkTotalBytes int
 		for _, key := range storeKeys {
 			var currStoreKeys int64
-			store := helper.commitMS.GetKVStore(key)
 			// TODO: use Iterator method of store interface, no longer rely on implementation of KVStore
 			// as we only append storeKeys for IavlStore at constructor, so this type assertion should never fail
-			mutableTree := store.(*IavlStore).Tree
+			iavlStore, _ := parentIavlStore(helper.commitMS.GetCommitKVStore(key))
+			mutableTree := iavlStore.Tree
 			if tree, err := mutableTree.GetImmutable(height); err == nil {
 				tree.IterateFirst(func(nodeBytes []byte) {
 					nodeBytesLength := len(nodeBytes)
@@ -28,6 +28,9 @@ type (
 		parent  sdk.KVStore
 		writer  io.Writer
 		context TraceContext
+
+		// trace the writes and the deletes only
+		writesOnly bool
 	}
 
 	// operation represents an IO operation
@@ -48,12 +51,25 @@ func NewTraceKVStore(parent sdk.KVStore, writer io.Writer, tc TraceContext) *Tra
 	return &TraceKVStore{parent: parent, writer: writer, context: tc}
 }
 
+// NewWriteTraceKVStore returns a reference to a new traceKVStore tracing only
+// the operations changing the parent KVStore, i.e. its writes and deletes.
+func NewWriteTraceKVStore(parent sdk.KVStore, writer io.Writer, tc TraceContext) *TraceKVStore {
+	return &TraceKVStore{parent: parent, writer: writer, context: tc, writesOnly: true}
+}
+
+// Parent returns the KVStore traced by the TraceKVStore.
+func (tkv *TraceKVStore) Parent() sdk.KVStore {
+	return tkv.parent
+}
+
 // Get implements the KVStore interface. It traces a read operation and
 // delegates a Get call to the parent KVStore.
 func (tkv *TraceKVStore) Get(key []byte) []byte {
 	value := tkv.parent.Get(key)
 
-	writeOperation(tkv.writer, readOp, tkv.context, key, value)
+	if !tkv.writesOnly {
+		writeOperation(tkv.writer, readOp, tkv.context, key, value)
+	}
 	return value
 }
 
@@ -104,6 +120,9 @@ func (tkv *TraceKVStore) iterator(start, end []byte, ascending bool) sdk.Iterato
 	} else {
 		parent = tkv.parent.ReverseIterator(start, end)
 	}
+	if tkv.writesOnly {
+		return parent
+	}
 
 	return newTraceIterator(tkv.writer, parent, tkv.context)
 }
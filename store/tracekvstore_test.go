@@ -281,3 +281,23 @@ func TestTraceKVStoreCacheWrapWithTrace(t *testing.T) {
 	store := newEmptyTraceKVStore(nil)
 	require.Panics(t, func() { store.CacheWrapWithTrace(nil, nil) })
 }
+
+func TestWriteTraceKVStore(t *testing.T) {
+	var buf bytes.Buffer
+
+	store := NewWriteTraceKVStore(dbStoreAdapter{dbm.NewMemDB()}, &buf, nil)
+	store.Set(kvPairs[0].Key, kvPairs[0].Value)
+	require.Equal(t, kvPairs[0].Value, store.Get(kvPairs[0].Key))
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		iterator.Key()
+		iterator.Value()
+	}
+	iterator.Close()
+	store.Delete(kvPairs[0].Key)
+
+	require.Equal(t,
+		"{\"operation\":\"write\",\"key\":\"a2V5MDAwMDAwMDE=\",\"value\":\"dmFsdWUwMDAwMDAwMQ==\",\"metadata\":null}\n"+
+			"{\"operation\":\"delete\",\"key\":\"a2V5MDAwMDAwMDE=\",\"value\":\"\",\"metadata\":null}\n",
+		buf.String())
+}
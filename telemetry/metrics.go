@@ -0,0 +1,124 @@
+// Package telemetry exposes the metrics of the application to Prometheus:
+// the txs run by the baseapp, the accesses to its stores and the counters
+// of the module keepers. The metrics are no-ops until Enable is called.
+package telemetry
+
+import (
+	"sync"
+
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Metrics contains the metrics of the application.
+type Metrics struct {
+	// Txs counts the txs run, by mode, type of their first msg and result code
+	Txs metricsPkg.Counter
+	// GasUsed is the gas used by the txs run, by mode and type
+	GasUsed metricsPkg.Histogram
+	// StoreAccess is the latency in seconds of the store accesses of the txs,
+	// by store and operation
+	StoreAccess metricsPkg.Histogram
+	// RecheckTime is the time in seconds to recheck a tx of the mempool
+	// after a commit
+	RecheckTime metricsPkg.Histogram
+	// KeeperEvents counts the events of the module keepers, by module and
+	// event
+	KeeperEvents metricsPkg.Counter
+}
+
+var (
+	mtx     sync.RWMutex
+	enabled bool
+	current = NopMetrics()
+
+	promOnce    sync.Once
+	promMetrics *Metrics
+)
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// The metrics are registered once to the default registry, every call
+// returns the same Metrics.
+func PrometheusMetrics() *Metrics {
+	promOnce.Do(func() {
+		promMetrics = &Metrics{
+			Txs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Subsystem: "baseapp",
+				Name:      "txs",
+				Help:      "Number of txs run by mode, msg type and result code",
+			}, []string{"mode", "type", "code"}),
+			GasUsed: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Subsystem: "baseapp",
+				Name:      "gas_used",
+				Help:      "Gas used by the txs run by mode and msg type",
+				Buckets:   stdprometheus.ExponentialBuckets(1000, 2, 12),
+			}, []string{"mode", "type"}),
+			StoreAccess: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Subsystem: "store",
+				Name:      "access_seconds",
+				Help:      "Latency of the store accesses of the txs by store and operation",
+				Buckets:   stdprometheus.ExponentialBuckets(1e-6, 4, 10),
+			}, []string{"store", "operation"}),
+			RecheckTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Subsystem: "mempool",
+				Name:      "recheck_seconds",
+				Help:      "Time to recheck a tx of the mempool after a commit",
+				Buckets:   stdprometheus.ExponentialBuckets(1e-4, 4, 10),
+			}, nil),
+			KeeperEvents: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Subsystem: "keeper",
+				Name:      "events",
+				Help:      "Number of events of the module keepers by module and event",
+			}, []string{"module", "event"}),
+		}
+	})
+	return promMetrics
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Txs:          discard.NewCounter(),
+		GasUsed:      discard.NewHistogram(),
+		StoreAccess:  discard.NewHistogram(),
+		RecheckTime:  discard.NewHistogram(),
+		KeeperEvents: discard.NewCounter(),
+	}
+}
+
+// Enable switches the metrics of the application to the Prometheus ones.
+// It must be called before the app is created.
+func Enable() {
+	mtx.Lock()
+	defer mtx.Unlock()
+	enabled = true
+	current = PrometheusMetrics()
+}
+
+// Enabled returns whether the Prometheus metrics are enabled.
+func Enabled() bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return enabled
+}
+
+// Current returns the metrics of the application, no-ops unless enabled.
+func Current() *Metrics {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return current
+}
+
+// IncrKeeperCounter counts an event of the keeper of module. Only the events
+// of the delivered state are counted, the checks and simulations of the txs
+// would count them twice.
+func IncrKeeperCounter(ctx sdk.Context, module, event string) {
+	if !ctx.IsDeliverTx() {
+		return
+	}
+	Current().KeeperEvents.With("module", module, "event", event).Add(1)
+}
@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// scrape returns the metrics served by the metrics endpoint.
+func scrape(t *testing.T) string {
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+	resp, err := server.Client().Get(server.URL + MetricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	bz, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(bz)
+}
+
+func TestMetrics(t *testing.T) {
+	require.False(t, Enabled())
+	deliverCtx := sdk.NewContext(nil, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	// the events are discarded until enabled
+	IncrKeeperCounter(deliverCtx, "bank", "send")
+
+	Enable()
+	require.True(t, Enabled())
+	require.True(t, Current() == PrometheusMetrics())
+	IncrKeeperCounter(deliverCtx, "bank", "send")
+	IncrKeeperCounter(deliverCtx.WithRunTxMode(sdk.RunTxModeCheck), "bank", "send")
+
+	key := sdk.NewKVStoreKey("acc")
+	cms := store.NewCommitMultiStore(dbm.NewMemDB())
+	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+	kvStore := store.NewLatencyKVStore(cms.GetKVStore(key), key.Name(), Current().StoreAccess)
+	kvStore.Set([]byte("key"), []byte("value"))
+	require.Equal(t, []byte("value"), kvStore.Get([]byte("key")))
+	kvStore.Get([]byte("other"))
+
+	metrics := scrape(t)
+	require.Contains(t, metrics, `keeper_events{event="send",module="bank"} 1`)
+	require.Contains(t, metrics, `store_access_seconds_count{operation="get",store="acc"} 2`)
+	require.Contains(t, metrics, `store_access_seconds_count{operation="set",store="acc"} 1`)
+}
@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MetricsPath is the path of the metrics endpoint.
+const MetricsPath = "/metrics"
+
+// Handler returns the handler of the metrics endpoint, serving the default
+// registry in the Prometheus text format.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{}),
+	))
+	return mux
+}
+
+// StartServer enables the metrics and serves them at the metrics path of
+// laddr, a host:port address, until the returned server is closed.
+func StartServer(laddr string, logger log.Logger) (*http.Server, error) {
+	Enable()
+	listener, err := net.Listen("tcp", laddr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{Handler: Handler()}
+	go func() {
+		if err := srv.Serve(listener); err != http.ErrServerClosed {
+			logger.Error("metrics server stopped", "err", err)
+		}
+	}()
+	logger.Info("Serving the app metrics", "laddr", laddr, "path", MetricsPath)
+	return srv, nil
+}
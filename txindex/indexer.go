@@ -0,0 +1,326 @@
+// Package txindex indexes the txs delivered by the app into a DB apart from
+// the state, with the signers, the types and the amounts of their msgs and
+// the events of their results, to search them with queries like
+//
+//	transfer.recipient=bnb1... AND height>1000
+//
+// The index is written at Commit, it is not part of the state and its search
+// results are not proven.
+package txindex
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// The keys of the attributes of every indexed tx.
+const (
+	KeyHeight = "tx.height"
+	KeyHash   = "tx.hash"
+	KeyCode   = "tx.code"
+)
+
+// DefaultPerPage and MaxPerPage are the default and the maximum numbers of
+// txs per page of the search results.
+const (
+	DefaultPerPage = 30
+	MaxPerPage     = 100
+)
+
+var (
+	txPrefix   = []byte("t/") // position -> TxRecord
+	hashPrefix = []byte("h/") // hash -> position
+	attrPrefix = []byte("a/") // key, value, position -> nil
+)
+
+// Attribute is an indexed attribute of a tx, a tx has any number of values
+// of a key.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// TxRecord is the indexed metadata of a delivered tx.
+type TxRecord struct {
+	Hash       string      `json:"hash"`
+	Height     int64       `json:"height"`
+	Index      uint32      `json:"index"`
+	Code       uint32      `json:"code"`
+	Log        string      `json:"log"`
+	Tx         []byte      `json:"tx"`
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Indexer indexes the txs delivered at a height into its DB once the height
+// is committed.
+type Indexer struct {
+	db dbm.DB
+
+	mtx     sync.Mutex
+	height  int64
+	pending []TxRecord
+}
+
+var _ baseapp.TxIndexer = (*Indexer)(nil)
+
+// NewIndexer returns an Indexer writing to db.
+func NewIndexer(db dbm.DB) *Indexer {
+	return &Indexer{db: db}
+}
+
+// IndexTx records the tx of bytes txBytes delivered at height, tx is nil if
+// it could not be decoded. The txs of a height must be recorded in the order
+// of the block.
+func (idx *Indexer) IndexTx(height int64, txBytes []byte, tx sdk.Tx, result sdk.Result) {
+	idx.mtx.Lock()
+	defer idx.mtx.Unlock()
+	if height != idx.height {
+		// the txs of a height which was not committed were not final
+		idx.height, idx.pending = height, nil
+	}
+
+	record := TxRecord{
+		Hash:   cmn.HexBytes(types.Tx(txBytes).Hash()).String(),
+		Height: height,
+		Index:  uint32(len(idx.pending)),
+		Code:   uint32(result.Code),
+		Log:    result.Log,
+		Tx:     txBytes,
+	}
+	record.Attributes = append(txAttributes(tx, result),
+		Attribute{KeyHeight, strconv.FormatInt(height, 10)},
+		Attribute{KeyHash, record.Hash},
+		Attribute{KeyCode, strconv.FormatUint(uint64(record.Code), 10)},
+	)
+	idx.pending = append(idx.pending, record)
+}
+
+// txAttributes returns the attributes of the msgs of tx and of the events of
+// its result.
+func txAttributes(tx sdk.Tx, result sdk.Result) []Attribute {
+	var attrs []Attribute
+	if tx != nil {
+		for _, msg := range tx.GetMsgs() {
+			attrs = append(attrs,
+				Attribute{"message.module", msg.Route()},
+				Attribute{"message.action", msg.Type()},
+			)
+			for _, signer := range msg.GetSigners() {
+				attrs = append(attrs, Attribute{"message.sender", signer.String()})
+			}
+			if send, ok := msg.(bank.MsgSend); ok {
+				attrs = append(attrs, transferAttributes(send)...)
+			}
+		}
+	}
+	for _, event := range result.GetEvents() {
+		if event.Type == "" {
+			continue
+		}
+		for _, kv := range event.Attributes {
+			attrs = append(attrs, Attribute{event.Type + "." + string(kv.Key), string(kv.Value)})
+		}
+	}
+	return attrs
+}
+
+// transferAttributes returns the senders, the recipients and the amounts of
+// the coins moved by msg.
+func transferAttributes(msg bank.MsgSend) []Attribute {
+	var attrs []Attribute
+	coins := func(cs sdk.Coins) {
+		for _, coin := range cs {
+			attrs = append(attrs,
+				Attribute{"transfer.denom", coin.Denom},
+				Attribute{"transfer.amount", strconv.FormatInt(coin.Amount, 10)},
+			)
+		}
+	}
+	for _, in := range msg.Inputs {
+		attrs = append(attrs, Attribute{"transfer.sender", in.Address.String()})
+		coins(in.Coins)
+	}
+	for _, out := range msg.Outputs {
+		attrs = append(attrs, Attribute{"transfer.recipient", out.Address.String()})
+	}
+	return attrs
+}
+
+// Commit writes the txs recorded at height to the DB.
+func (idx *Indexer) Commit(height int64) {
+	idx.mtx.Lock()
+	pending := idx.pending
+	if idx.height != height {
+		pending = nil
+	}
+	idx.pending = nil
+	idx.mtx.Unlock()
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+	for _, record := range pending {
+		bz, err := json.Marshal(record)
+		if err != nil {
+			panic(err)
+		}
+		pos := position(record.Height, record.Index)
+		batch.Set(prefixed(txPrefix, pos), bz)
+		batch.Set(prefixed(hashPrefix, []byte(record.Hash)), pos)
+		for _, attr := range record.Attributes {
+			batch.Set(attrKey(attr.Key, attr.Value, pos), []byte{})
+		}
+	}
+	batch.WriteSync()
+}
+
+// position returns the key of the tx of index in the block of height, in the
+// order of the txs.
+func position(height int64, index uint32) []byte {
+	pos := make([]byte, 12)
+	binary.BigEndian.PutUint64(pos, uint64(height))
+	binary.BigEndian.PutUint32(pos[8:], index)
+	return pos
+}
+
+func prefixed(prefix, key []byte) []byte {
+	return append(append([]byte{}, prefix...), key...)
+}
+
+// attrKey returns the key of the value of attribute key of the tx at pos,
+// the key and the value are terminated by 0 so that neither prefixes another.
+func attrKey(key, value string, pos []byte) []byte {
+	bz := prefixed(attrPrefix, []byte(key))
+	bz = append(bz, 0)
+	bz = append(bz, value...)
+	bz = append(bz, 0)
+	return append(bz, pos...)
+}
+
+// SearchResult is a page of the txs matching a query, in the order of the
+// blocks, and the number of all the matching txs.
+type SearchResult struct {
+	TotalCount int        `json:"total_count"`
+	Txs        []TxRecord `json:"txs"`
+}
+
+// Search returns the page, numbered from 1, of perPage txs matching query.
+// The query must have an equality condition, or a condition on the height,
+// to bound the txs scanned.
+func (idx *Indexer) Search(query string, page, perPage int) (SearchResult, error) {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	if page < 1 {
+		return SearchResult{}, fmt.Errorf("invalid page %d, the pages are numbered from 1", page)
+	}
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	res := SearchResult{Txs: make([]TxRecord, 0)}
+	skip := (page - 1) * perPage
+	err = idx.scan(q, func(record TxRecord) {
+		if !q.Matches(record.Attributes) {
+			return
+		}
+		if res.TotalCount >= skip && len(res.Txs) < perPage {
+			res.Txs = append(res.Txs, record)
+		}
+		res.TotalCount++
+	})
+	return res, err
+}
+
+// scan calls fn with the candidate txs of q in the order of the blocks: the
+// txs with the value of the first equality condition, or else the txs in
+// the height range of q.
+func (idx *Indexer) scan(q Query, fn func(TxRecord)) error {
+	min, max, bounded := q.heightRange()
+	if min > max {
+		return nil
+	}
+	inRange := func(pos []byte) bool {
+		height := int64(binary.BigEndian.Uint64(pos))
+		return height >= min && height <= max
+	}
+
+	for _, cond := range q {
+		if cond.Op != OpEqual || cond.Key == KeyHeight {
+			continue
+		}
+		if cond.Key == KeyHash {
+			pos := idx.db.Get(prefixed(hashPrefix, []byte(cond.Value)))
+			if pos == nil || !inRange(pos) {
+				return nil
+			}
+			return idx.visit(pos, fn)
+		}
+
+		prefix := attrKey(cond.Key, cond.Value, nil)
+		it := dbm.IteratePrefix(idx.db, prefix)
+		defer it.Close()
+		for ; it.Valid(); it.Next() {
+			pos := it.Key()[len(prefix):]
+			if !inRange(pos) {
+				continue
+			}
+			if err := idx.visit(pos, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !bounded {
+		return fmt.Errorf("the query needs an equality condition or a condition on %s", KeyHeight)
+	}
+	end := sdk.PrefixEndBytes(txPrefix)
+	if max < maxHeight {
+		end = prefixed(txPrefix, position(max+1, 0))
+	}
+	it := idx.db.Iterator(prefixed(txPrefix, position(min, 0)), end)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		record, err := decodeRecord(it.Value())
+		if err != nil {
+			return err
+		}
+		fn(record)
+	}
+	return nil
+}
+
+// visit calls fn with the tx at pos.
+func (idx *Indexer) visit(pos []byte, fn func(TxRecord)) error {
+	bz := idx.db.Get(prefixed(txPrefix, pos))
+	if bz == nil {
+		return fmt.Errorf("the index has no tx at %X", pos)
+	}
+	record, err := decodeRecord(bz)
+	if err != nil {
+		return err
+	}
+	fn(record)
+	return nil
+}
+
+func decodeRecord(bz []byte) (TxRecord, error) {
+	var record TxRecord
+	err := json.Unmarshal(bz, &record)
+	return record, err
+}
@@ -0,0 +1,102 @@
+package txindex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery("transfer.recipient=bnb1abc AND height>1000 and message.action CONTAINS 'se nd' AND tx.hash<=5")
+	require.NoError(t, err)
+	require.Equal(t, Query{
+		{Key: "transfer.recipient", Op: OpEqual, Value: "bnb1abc"},
+		{Key: KeyHeight, Op: OpGreater, Value: "1000", number: 1000},
+		{Key: "message.action", Op: OpContains, Value: "se nd"},
+		{Key: KeyHash, Op: OpLessEq, Value: "5", number: 5},
+	}, q)
+
+	for _, query := range []string{
+		"",
+		"height",
+		"height>abc",
+		"a=1 b=2",
+		"a=1 AND",
+		"a=1 OR b=2",
+	} {
+		_, err := ParseQuery(query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := NewIndexer(dbm.NewMemDB())
+	addrs := make([]sdk.AccAddress, 3)
+	for i := range addrs {
+		addrs[i] = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	}
+	send := func(from, to sdk.AccAddress, amount int64) sdk.Tx {
+		coins := sdk.Coins{sdk.NewCoin("BNB", amount)}
+		msg := bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+		return auth.NewStdTx([]sdk.Msg{msg}, nil, fmt.Sprintf("%d", amount), 0, nil)
+	}
+	deliver := func(height int64, txs ...sdk.Tx) {
+		for i, tx := range txs {
+			idx.IndexTx(height, []byte(fmt.Sprintf("tx %d %d", height, i)), tx, sdk.Result{})
+		}
+		idx.Commit(height)
+	}
+
+	deliver(1, send(addrs[0], addrs[1], 100), send(addrs[0], addrs[2], 200))
+	// an undecodable failed tx
+	idx.IndexTx(2, []byte("invalid"), nil, sdk.ErrTxDecode("invalid").Result())
+	idx.Commit(2)
+	// the txs of a height which is not committed are dropped
+	idx.IndexTx(3, []byte("dropped"), send(addrs[1], addrs[2], 5), sdk.Result{})
+	deliver(4, send(addrs[1], addrs[2], 300))
+
+	search := func(query string, page, perPage int) []string {
+		res, err := idx.Search(query, page, perPage)
+		require.NoError(t, err, query)
+		memos := make([]string, len(res.Txs))
+		for i, record := range res.Txs {
+			memos[i] = fmt.Sprintf("%d/%d", record.Height, record.Index)
+		}
+		return memos
+	}
+	require.Equal(t, []string{"1/0", "1/1"}, search("transfer.sender="+addrs[0].String(), 1, 0))
+	require.Equal(t, []string{"1/1", "4/0"}, search("transfer.recipient="+addrs[2].String(), 1, 0))
+	require.Equal(t, []string{"4/0"}, search("transfer.recipient="+addrs[2].String()+" AND height>1", 1, 0))
+	require.Equal(t, []string{"1/1", "4/0"}, search("message.action=send AND transfer.amount>=200", 1, 0))
+	require.Equal(t, []string{"1/1"}, search("message.sender="+addrs[0].String()+" AND transfer.amount>100", 1, 0))
+	require.Equal(t, []string{"2/0"}, search("tx.code>0 AND height>=1", 1, 0))
+	require.Equal(t, []string{"1/0", "1/1", "2/0", "4/0"}, search("height>=1", 1, 0))
+	require.Equal(t, []string{"1/1"}, search("height>=1", 2, 1))
+	require.Empty(t, search("height<1", 1, 0))
+	require.Empty(t, search("height=3", 1, 0))
+
+	res, err := idx.Search("height>=1", 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, 4, res.TotalCount)
+	require.Len(t, res.Txs, 2)
+
+	// the hashes are matched in any case
+	hash := res.Txs[1].Hash
+	require.Equal(t, []string{"4/0"}, search("tx.hash="+strings.ToLower(hash), 1, 0))
+	require.Equal(t, []string{"4/0"}, search("tx.hash='"+hash+"' AND height=4", 1, 0))
+	require.Empty(t, search("tx.hash="+hash+" AND height<4", 1, 0))
+
+	// the txs scanned must be bounded
+	_, err = idx.Search("transfer.amount>1", 1, 0)
+	require.Error(t, err)
+	_, err = idx.Search("height>1", 0, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package txindex
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QuerySearch is the path of the search at baseapp.TxIndexRoute, the data of
+// the search is a JSON QuerySearchParams.
+const QuerySearch = "search"
+
+// QuerySearchParams are the params of a search of the txs.
+type QuerySearchParams struct {
+	Query   string `json:"query"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
+
+// Querier returns the querier of the index, answering the searches with a
+// JSON SearchResult.
+func (idx *Indexer) Querier() sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 || path[0] != QuerySearch {
+			return nil, sdk.ErrUnknownRequest("unknown txindex query endpoint")
+		}
+		var params QuerySearchParams
+		if err := json.Unmarshal(req.Data, &params); err != nil {
+			return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+		}
+		res, err := idx.Search(params.Query, params.Page, params.PerPage)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(err.Error())
+		}
+		bz, err := json.Marshal(res)
+		if err != nil {
+			return nil, sdk.ErrInternal(err.Error())
+		}
+		return bz, nil
+	}
+}
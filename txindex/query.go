@@ -0,0 +1,175 @@
+package txindex
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Op is the operator of a condition of a query.
+type Op string
+
+// The operators of the conditions, the order operators compare integers.
+const (
+	OpEqual     Op = "="
+	OpLess      Op = "<"
+	OpLessEq    Op = "<="
+	OpGreater   Op = ">"
+	OpGreaterEq Op = ">="
+	OpContains  Op = "CONTAINS"
+)
+
+// maxHeight bounds the heights of the queries with no upper bound.
+const maxHeight = int64(^uint64(0) >> 1)
+
+// Condition is a condition on the values of an attribute of the txs.
+type Condition struct {
+	Key   string
+	Op    Op
+	Value string
+
+	// number is the value of the order operators
+	number int64
+}
+
+// Query is a conjunction of conditions, e.g.
+//
+//	transfer.recipient=bnb1... AND height>1000
+//
+// A tx matches a condition if one of the values of the attribute does, the
+// values may be quoted with single quotes.
+type Query []Condition
+
+var (
+	conditionRegexp = regexp.MustCompile(`^\s*([\w.]+)\s*(<=|>=|=|<|>|\s+CONTAINS\s+)\s*(?:'([^']*)'|([^\s']+))\s*`)
+	andRegexp       = regexp.MustCompile(`^(?i)AND\s`)
+)
+
+// ParseQuery parses the conditions of s joined by AND. The key height is
+// short for KeyHeight.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+	rest := s
+	for {
+		m := conditionRegexp.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("invalid condition at %q of query %q", rest, s)
+		}
+		cond := Condition{Key: m[1], Op: Op(strings.TrimSpace(m[2])), Value: m[3] + m[4]}
+		switch cond.Key {
+		case "height":
+			cond.Key = KeyHeight
+		case KeyHash:
+			// the hashes are indexed in upper case hex
+			cond.Value = strings.ToUpper(cond.Value)
+		}
+		if cond.isOrder() {
+			n, err := strconv.ParseInt(cond.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the operator %s of %s expects an integer, got %q", cond.Op, cond.Key, cond.Value)
+			}
+			cond.number = n
+		}
+		q = append(q, cond)
+
+		rest = rest[len(m[0]):]
+		if rest == "" {
+			return q, nil
+		}
+		loc := andRegexp.FindStringIndex(rest)
+		if loc == nil {
+			return nil, fmt.Errorf("expected AND at %q of query %q", rest, s)
+		}
+		rest = rest[loc[1]:]
+	}
+}
+
+func (c Condition) isOrder() bool {
+	return c.Op == OpLess || c.Op == OpLessEq || c.Op == OpGreater || c.Op == OpGreaterEq
+}
+
+// matches returns whether value satisfies the condition.
+func (c Condition) matches(value string) bool {
+	switch c.Op {
+	case OpEqual:
+		return value == c.Value
+	case OpContains:
+		return strings.Contains(value, c.Value)
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	switch c.Op {
+	case OpLess:
+		return n < c.number
+	case OpLessEq:
+		return n <= c.number
+	case OpGreater:
+		return n > c.number
+	default:
+		return n >= c.number
+	}
+}
+
+// Matches returns whether the attributes of a tx satisfy all the conditions.
+func (q Query) Matches(attrs []Attribute) bool {
+	for _, cond := range q {
+		matched := false
+		for _, attr := range attrs {
+			if attr.Key == cond.Key && cond.matches(attr.Value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// heightRange returns the range [min, max] of the heights allowed by the
+// conditions on KeyHeight, and whether one bounds it.
+func (q Query) heightRange() (min, max int64, bounded bool) {
+	min, max = 1, maxHeight
+	for _, cond := range q {
+		if cond.Key != KeyHeight {
+			continue
+		}
+		bounded = true
+		switch cond.Op {
+		case OpEqual:
+			n, err := strconv.ParseInt(cond.Value, 10, 64)
+			if err != nil {
+				// no height matches
+				return 1, 0, true
+			}
+			min, max = maxInt64(min, n), minInt64(max, n)
+		case OpLess:
+			max = minInt64(max, cond.number-1)
+		case OpLessEq:
+			max = minInt64(max, cond.number)
+		case OpGreater:
+			min = maxInt64(min, cond.number+1)
+		case OpGreaterEq:
+			min = maxInt64(min, cond.number)
+		}
+	}
+	return min, max, bounded
+}
+
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
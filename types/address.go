@@ -65,6 +65,9 @@ func AccAddressFromBech32(address string) (addr AccAddress, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = verifyParsedAddress(bz); err != nil {
+		return nil, err
+	}
 
 	return AccAddress(bz), nil
 }
@@ -181,6 +184,9 @@ func ValAddressFromBech32(address string) (addr ValAddress, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = verifyParsedAddress(bz); err != nil {
+		return nil, err
+	}
 
 	return ValAddress(bz), nil
 }
@@ -298,6 +304,9 @@ func ConsAddressFromBech32(address string) (addr ConsAddress, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = verifyParsedAddress(bz); err != nil {
+		return nil, err
+	}
 
 	return ConsAddress(bz), nil
 }
@@ -599,8 +608,71 @@ func GetFromBech32(bech32str, prefix string) ([]byte, error) {
 	}
 
 	if hrp != prefix {
-		return nil, fmt.Errorf("invalid Bech32 prefix; expected %s, got %s", prefix, hrp)
+		return nil, bech32PrefixError(prefix, hrp)
 	}
 
 	return bz, nil
 }
+
+// bech32PrefixError returns the error of a Bech32 string of prefix got where
+// prefix expected is expected, naming the kinds of the known prefixes.
+func bech32PrefixError(expected, got string) error {
+	config := GetConfig()
+	expectedDesc := expected
+	if kind, _, ok := config.LookupBech32Prefix(expected); ok {
+		expectedDesc = fmt.Sprintf("%s (%s)", expected, kind)
+	}
+	kind, configured, ok := config.LookupBech32Prefix(got)
+	switch {
+	case !ok:
+		return fmt.Errorf("invalid Bech32 prefix; expected %s, got %s", expectedDesc, got)
+	case configured:
+		return fmt.Errorf("invalid Bech32 prefix; expected %s, got %s (%s)", expectedDesc, got, kind)
+	default:
+		return fmt.Errorf("invalid Bech32 prefix; expected %s, got %s (%s of another network)", expectedDesc, got, kind)
+	}
+}
+
+// VerifyAddressFormat verifies that bz is the bytes of a valid address.
+func VerifyAddressFormat(bz []byte) error {
+	if len(bz) != AddrLen {
+		return fmt.Errorf("invalid address length; expected %d bytes, got %d", AddrLen, len(bz))
+	}
+	return nil
+}
+
+// verifyParsedAddress verifies the bytes of a parsed address, empty bytes are
+// the encoding of an empty address.
+func verifyParsedAddress(bz []byte) error {
+	if len(bz) == 0 {
+		return nil
+	}
+	return VerifyAddressFormat(bz)
+}
+
+// ConvertBech32Prefix converts the Bech32 string address to prefix, e.g. a
+// testnet account address to the mainnet one. The prefixes of address and
+// prefix must be configured or registered, for the same kind.
+func ConvertBech32Prefix(address, prefix string) (string, error) {
+	if len(address) == 0 {
+		return "", errors.New("decoding Bech32 address failed: must provide an address")
+	}
+	hrp, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", err
+	}
+
+	config := GetConfig()
+	fromKind, _, ok := config.LookupBech32Prefix(hrp)
+	if !ok {
+		return "", fmt.Errorf("unknown Bech32 prefix %s", hrp)
+	}
+	toKind, _, ok := config.LookupBech32Prefix(prefix)
+	if !ok {
+		return "", fmt.Errorf("unknown Bech32 prefix %s", prefix)
+	}
+	if fromKind != toKind {
+		return "", fmt.Errorf("cannot convert the %s %s to the %s prefix %s", fromKind, address, toKind, prefix)
+	}
+	return bech32.ConvertAndEncode(prefix, bz)
+}
@@ -3,10 +3,12 @@ package types_test
 import (
 	"encoding/hex"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/bech32"
 
 	"github.com/cosmos/cosmos-sdk/types"
 )
@@ -193,3 +195,58 @@ func TestSmartChainAddress(t *testing.T) {
 	_, err = types.NewSmartChainAddress(addrStr)
 	require.NotNil(t, err, "err should not be nil")
 }
+
+func TestBech32PrefixRegistry(t *testing.T) {
+	config := types.GetConfig()
+	config.RegisterBech32Prefix(types.Bech32PrefixKindAccAddr, "tcosmos")
+	config.RegisterBech32Prefix(types.Bech32PrefixKindValAddr, "tcosmosvaloper")
+	require.Panics(t, func() { config.RegisterBech32Prefix(types.Bech32PrefixKindConsAddr, "tcosmos") })
+
+	kind, configured, ok := config.LookupBech32Prefix(types.Bech32PrefixValAddr)
+	require.True(t, ok)
+	require.True(t, configured)
+	require.Equal(t, types.Bech32PrefixKindValAddr, kind)
+	kind, configured, ok = config.LookupBech32Prefix("tcosmos")
+	require.True(t, ok)
+	require.False(t, configured)
+	require.Equal(t, types.Bech32PrefixKindAccAddr, kind)
+	_, _, ok = config.LookupBech32Prefix("unknown")
+	require.False(t, ok)
+
+	var pub ed25519.PubKeyEd25519
+	rand.Read(pub[:])
+	acc := types.AccAddress(pub.Address())
+
+	// the addresses of the other networks are reported as such, not accepted
+	testnetAcc, err := types.ConvertBech32Prefix(acc.String(), "tcosmos")
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(testnetAcc, "tcosmos1"))
+	_, err = types.AccAddressFromBech32(testnetAcc)
+	require.EqualError(t, err, "invalid Bech32 prefix; expected cosmos (account address), got tcosmos (account address of another network)")
+	err = (&types.AccAddress{}).UnmarshalJSON([]byte("\"" + testnetAcc + "\""))
+	require.NotNil(t, err)
+
+	// the addresses of the other kinds are reported as such
+	_, err = types.ValAddressFromBech32(acc.String())
+	require.EqualError(t, err, "invalid Bech32 prefix; expected cosmosvaloper (validator operator address), got cosmos (account address)")
+
+	converted, err := types.ConvertBech32Prefix(testnetAcc, types.Bech32PrefixAccAddr)
+	require.Nil(t, err)
+	require.Equal(t, acc.String(), converted)
+	_, err = types.ConvertBech32Prefix(testnetAcc, "tcosmosvaloper")
+	require.NotNil(t, err)
+	_, err = types.ConvertBech32Prefix(testnetAcc, "unknown")
+	require.NotNil(t, err)
+
+	// the bytes must be of an address
+	short, err := bech32.ConvertAndEncode(types.Bech32PrefixAccAddr, []byte("short"))
+	require.Nil(t, err)
+	_, err = types.AccAddressFromBech32(short)
+	require.NotNil(t, err)
+	empty := types.AccAddress{}
+	res, err := types.AccAddressFromBech32(empty.String())
+	require.Nil(t, err)
+	require.True(t, res.Empty())
+	require.Nil(t, types.VerifyAddressFormat(acc))
+	require.NotNil(t, types.VerifyAddressFormat([]byte("short")))
+}
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The display form of the amounts, coins and decimals shown to and input by
+// the users, e.g. "1.5 BNB" for 150000000 of the smallest unit of BNB. The
+// amounts have Precision decimals, a dot as the decimal separator and no
+// grouping, whatever the locale.
+
+var (
+	reDenom        = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDnm))
+	reAmountDigits = regexp.MustCompile(`^[[:digit:]]+$`)
+)
+
+// FormatAmount formats an amount of the smallest unit with Precision decimals,
+// e.g. "1.50000000" for 150000000.
+func FormatAmount(amount int64) string {
+	sign := ""
+	// the magnitude of math.MinInt64 does not fit an int64
+	magnitude := uint64(amount)
+	if amount < 0 {
+		sign, magnitude = "-", uint64(-(amount+1))+1
+	}
+	unit := uint64(precisionReuse)
+	return fmt.Sprintf("%s%d.%0*d", sign, magnitude/unit, Precision, magnitude%unit)
+}
+
+// ParseAmount parses an amount of the display form into the smallest unit,
+// e.g. 150000000 for "1.5". The amount must have at most Precision decimals,
+// a dot as the decimal separator and no grouping separators nor exponent.
+func ParseAmount(amountStr string) (int64, error) {
+	str := amountStr
+	negative := strings.HasPrefix(str, "-")
+	if negative {
+		str = str[1:]
+	}
+	whole, fraction := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		whole, fraction = str[:i], str[i+1:]
+		if len(fraction) == 0 {
+			return 0, fmt.Errorf("invalid amount %s: no decimals after the decimal separator", amountStr)
+		}
+	}
+	if !reAmountDigits.MatchString(whole) || (fraction != "" && !reAmountDigits.MatchString(fraction)) {
+		return 0, fmt.Errorf("invalid amount %s: expected digits with an optional dot decimal separator, e.g. 1.5", amountStr)
+	}
+	if len(fraction) > Precision {
+		return 0, fmt.Errorf("invalid amount %s: more than %d decimals", amountStr, Precision)
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+	overflow := fmt.Errorf("invalid amount %s: out of range", amountStr)
+	wholeUnits, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil || wholeUnits > limit/uint64(precisionReuse) {
+		return 0, overflow
+	}
+	fractionUnits := uint64(0)
+	if fraction != "" {
+		fractionUnits, _ = strconv.ParseUint(fraction, 10, 64)
+		fractionUnits *= uint64(precisionMultiplier(int64(len(fraction))))
+	}
+	units := wholeUnits*uint64(precisionReuse) + fractionUnits
+	if units > limit {
+		return 0, overflow
+	}
+	if negative && units == limit {
+		return math.MinInt64, nil
+	}
+	if negative {
+		return -int64(units), nil
+	}
+	return int64(units), nil
+}
+
+// ValidateDenom validates the symbol of a coin, e.g. BNB or XYZ-000.
+func ValidateDenom(denom string) error {
+	if !reDenom.MatchString(denom) {
+		return fmt.Errorf("invalid coin symbol %s", denom)
+	}
+	return nil
+}
+
+// Display returns the display form of the coin, e.g. "1.50000000 BNB".
+func (coin Coin) Display() string {
+	return fmt.Sprintf("%s %s", FormatAmount(coin.Amount), coin.Denom)
+}
+
+// Display returns the display form of the coins separated by commas, e.g.
+// "1.50000000 BNB, 2.00000000 XYZ-000".
+func (coins Coins) Display() string {
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = coin.Display()
+	}
+	return strings.Join(out, ", ")
+}
+
+// Display returns the display form of the decimal, e.g. "0.05000000".
+func (d Dec) Display() string {
+	return FormatAmount(d.int64)
+}
+
+// ParseDisplayDec parses a decimal of the display form, e.g. "0.05".
+func ParseDisplayDec(decStr string) (Dec, error) {
+	value, err := ParseAmount(strings.TrimSpace(decStr))
+	if err != nil {
+		return Dec{}, err
+	}
+	return Dec{value}, nil
+}
+
+// ParseDisplayCoin parses a coin of the display form, an amount and a symbol
+// separated by spaces, e.g. "1.5 BNB".
+func ParseDisplayCoin(coinStr string) (coin Coin, err error) {
+	fields := strings.Fields(coinStr)
+	if len(fields) != 2 {
+		return coin, fmt.Errorf("invalid coin expression: %s, expected an amount and a symbol, e.g. 1.5 BNB", coinStr)
+	}
+	if err = ValidateDenom(fields[1]); err != nil {
+		return coin, err
+	}
+	amount, err := ParseAmount(fields[0])
+	if err != nil {
+		return coin, err
+	}
+	if amount < 0 {
+		return coin, fmt.Errorf("invalid coin expression: %s, the amount is negative", coinStr)
+	}
+	return Coin{fields[1], amount}, nil
+}
+
+// ParseDisplayCoins parses a list of coins of the display form separated by
+// commas, e.g. "1.5 BNB, 2 XYZ-000". If nothing is provided, it returns nil
+// Coins. Returned coins are sorted.
+func ParseDisplayCoins(coinsStr string) (Coins, error) {
+	return parseCoinsWith(coinsStr, ParseDisplayCoin)
+}
+
+// ParseUserCoin parses a coin input by a user, either of the display form,
+// e.g. "1.5 BNB", or of the form of the smallest unit, e.g. "150000000:BNB".
+func ParseUserCoin(coinStr string) (Coin, error) {
+	if strings.Contains(coinStr, ":") {
+		return ParseCoin(coinStr)
+	}
+	return ParseDisplayCoin(coinStr)
+}
+
+// ParseUserCoins parses a list of coins input by a user separated by commas,
+// each of the forms of ParseUserCoin. Returned coins are sorted.
+func ParseUserCoins(coinsStr string) (Coins, error) {
+	return parseCoinsWith(coinsStr, ParseUserCoin)
+}
+
+func parseCoinsWith(coinsStr string, parseCoin func(string) (Coin, error)) (coins Coins, err error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	for _, coinStr := range strings.Split(coinsStr, ",") {
+		coin, err := parseCoin(coinStr)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, coin)
+	}
+
+	coins.Sort()
+	if !coins.IsValid() {
+		return nil, fmt.Errorf("invalid coins %s: expected non-zero amounts of distinct symbols", coinsStr)
+	}
+	return coins, nil
+}
+
+// DisplayCoin is the display form of a coin in JSON, e.g.
+// {"denom":"BNB","amount":"1.50000000"}.
+type DisplayCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// ToDisplayCoins returns the display form of the coins in JSON.
+func (coins Coins) ToDisplayCoins() []DisplayCoin {
+	out := make([]DisplayCoin, len(coins))
+	for i, coin := range coins {
+		out[i] = DisplayCoin{Denom: coin.Denom, Amount: FormatAmount(coin.Amount)}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+	cases := []struct {
+		amount   int64
+		expected string
+	}{
+		{0, "0.00000000"},
+		{1, "0.00000001"},
+		{150000000, "1.50000000"},
+		{-150000000, "-1.50000000"},
+		{TokenMaxTotalSupply, "90000000000.00000000"},
+		{math.MaxInt64, "92233720368.54775807"},
+		{math.MinInt64, "-92233720368.54775808"},
+	}
+
+	for tcIndex, tc := range cases {
+		require.Equal(t, tc.expected, FormatAmount(tc.amount), "tc #%d", tcIndex)
+		amount, err := ParseAmount(tc.expected)
+		require.Nil(t, err, "tc #%d", tcIndex)
+		require.Equal(t, tc.amount, amount, "tc #%d", tcIndex)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected int64
+	}{
+		{"1", true, 100000000},
+		{"1.5", true, 150000000},
+		{"0.00000001", true, 1},
+		{"-0.5", true, -50000000},
+		{"007.10", true, 710000000},
+		{"0.000000001", false, 0},
+		{"1,5", false, 0},
+		{"1,000.5", false, 0},
+		{"1.", false, 0},
+		{".5", false, 0},
+		{"1e8", false, 0},
+		{"+1", false, 0},
+		{"", false, 0},
+		{"92233720368.54775808", false, 0},
+		{"100000000000", false, 0},
+	}
+
+	for tcIndex, tc := range cases {
+		amount, err := ParseAmount(tc.input)
+		require.Equal(t, tc.valid, err == nil, "%s validity is incorrect, tc #%d", tc.input, tcIndex)
+		require.Equal(t, tc.expected, amount, "tc #%d", tcIndex)
+	}
+}
+
+func TestParseDisplayCoins(t *testing.T) {
+	cases := []struct {
+		input    string
+		valid    bool
+		expected Coins
+	}{
+		{"", true, nil},
+		{"1.5 BNB", true, Coins{{"BNB", 150000000}}},
+		{"  2 XYZ-000 ,0.1  BNB", true, Coins{{"BNB", 10000000}, {"XYZ-000", 200000000}}},
+		{"1.5BNB", false, nil},
+		{"1.5 BNB$", false, nil},
+		{"-1 BNB", false, nil},
+		{"0 BNB", false, nil},
+		{"1 BNB, 2 BNB", false, nil},
+		{"150000000:BNB", false, nil},
+	}
+
+	for tcIndex, tc := range cases {
+		coins, err := ParseDisplayCoins(tc.input)
+		require.Equal(t, tc.valid, err == nil, "%s validity is incorrect, tc #%d", tc.input, tcIndex)
+		require.Equal(t, tc.expected, coins, "tc #%d", tcIndex)
+	}
+
+	coins := Coins{{"BNB", 150000000}, {"XYZ-000", 2}}
+	require.Equal(t, "1.50000000 BNB, 0.00000002 XYZ-000", coins.Display())
+	parsed, err := ParseDisplayCoins(coins.Display())
+	require.Nil(t, err)
+	require.Equal(t, coins, parsed)
+	require.Equal(t, []DisplayCoin{{"BNB", "1.50000000"}, {"XYZ-000", "0.00000002"}}, coins.ToDisplayCoins())
+}
+
+func TestParseUserCoins(t *testing.T) {
+	coins, err := ParseUserCoins("1.5 BNB,200000000:XYZ-000")
+	require.Nil(t, err)
+	require.Equal(t, Coins{{"BNB", 150000000}, {"XYZ-000", 200000000}}, coins)
+
+	coin, err := ParseUserCoin("150000000:BNB")
+	require.Nil(t, err)
+	require.Equal(t, NewCoin("BNB", 150000000), coin)
+	_, err = ParseUserCoin("1.5:BNB")
+	require.NotNil(t, err)
+}
+
+func TestDisplayDec(t *testing.T) {
+	d, err := ParseDisplayDec("0.05")
+	require.Nil(t, err)
+	require.Equal(t, NewDecWithPrec(5, 2), d)
+	require.Equal(t, "0.05000000", d.Display())
+	_, err = ParseDisplayDec("5%")
+	require.NotNil(t, err)
+}
+
+func TestValidateDenom(t *testing.T) {
+	require.Nil(t, ValidateDenom("BNB"))
+	require.Nil(t, ValidateDenom("XYZ-000"))
+	require.Nil(t, ValidateDenom("BTC.B-918"))
+	require.NotNil(t, ValidateDenom("B"))
+	require.NotNil(t, ValidateDenom("BNB "))
+	require.NotNil(t, ValidateDenom("XYZ-0000000"))
+}
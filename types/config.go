@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"sync"
 )
 
@@ -9,21 +10,56 @@ import (
 type Config struct {
 	mtx                 sync.RWMutex
 	sealed              bool
-	bech32AddressPrefix map[string]string
+	bech32AddressPrefix map[Bech32PrefixKind]string
+	// the prefixes of the other networks, e.g. the testnet prefixes on the
+	// mainnet, which are known but not accepted
+	registeredPrefixes map[string]Bech32PrefixKind
+}
+
+// Bech32PrefixKind is the kind of the addresses or public keys of a Bech32
+// prefix.
+type Bech32PrefixKind string
+
+// nolint
+const (
+	Bech32PrefixKindAccAddr  Bech32PrefixKind = "account_addr"
+	Bech32PrefixKindValAddr  Bech32PrefixKind = "validator_addr"
+	Bech32PrefixKindConsAddr Bech32PrefixKind = "consensus_addr"
+	Bech32PrefixKindAccPub   Bech32PrefixKind = "account_pub"
+	Bech32PrefixKindValPub   Bech32PrefixKind = "validator_pub"
+	Bech32PrefixKindConsPub  Bech32PrefixKind = "consensus_pub"
+)
+
+var bech32PrefixKindNames = map[Bech32PrefixKind]string{
+	Bech32PrefixKindAccAddr:  "account address",
+	Bech32PrefixKindValAddr:  "validator operator address",
+	Bech32PrefixKindConsAddr: "consensus node address",
+	Bech32PrefixKindAccPub:   "account public key",
+	Bech32PrefixKindValPub:   "validator operator public key",
+	Bech32PrefixKindConsPub:  "consensus node public key",
+}
+
+// String returns the name of the kind, e.g. "account address".
+func (kind Bech32PrefixKind) String() string {
+	if name, ok := bech32PrefixKindNames[kind]; ok {
+		return name
+	}
+	return string(kind)
 }
 
 var (
 	// Initializing an instance of Config
 	sdkConfig = &Config{
 		sealed: false,
-		bech32AddressPrefix: map[string]string{
-			"account_addr":   Bech32PrefixAccAddr,
-			"validator_addr": Bech32PrefixValAddr,
-			"consensus_addr": Bech32PrefixConsAddr,
-			"account_pub":    Bech32PrefixAccPub,
-			"validator_pub":  Bech32PrefixValPub,
-			"consensus_pub":  Bech32PrefixConsPub,
+		bech32AddressPrefix: map[Bech32PrefixKind]string{
+			Bech32PrefixKindAccAddr:  Bech32PrefixAccAddr,
+			Bech32PrefixKindValAddr:  Bech32PrefixValAddr,
+			Bech32PrefixKindConsAddr: Bech32PrefixConsAddr,
+			Bech32PrefixKindAccPub:   Bech32PrefixAccPub,
+			Bech32PrefixKindValPub:   Bech32PrefixValPub,
+			Bech32PrefixKindConsPub:  Bech32PrefixConsPub,
 		},
+		registeredPrefixes: map[string]Bech32PrefixKind{},
 	}
 )
 
@@ -45,24 +81,58 @@ func (config *Config) assertNotSealed() {
 // and returns the config instance
 func (config *Config) SetBech32PrefixForAccount(addressPrefix, pubKeyPrefix string) {
 	config.assertNotSealed()
-	config.bech32AddressPrefix["account_addr"] = addressPrefix
-	config.bech32AddressPrefix["account_pub"] = pubKeyPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindAccAddr] = addressPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindAccPub] = pubKeyPrefix
 }
 
 // SetBech32PrefixForValidator builds the Config with Bech32 addressPrefix and publKeyPrefix for validators
 //  and returns the config instance
 func (config *Config) SetBech32PrefixForValidator(addressPrefix, pubKeyPrefix string) {
 	config.assertNotSealed()
-	config.bech32AddressPrefix["validator_addr"] = addressPrefix
-	config.bech32AddressPrefix["validator_pub"] = pubKeyPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindValAddr] = addressPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindValPub] = pubKeyPrefix
 }
 
 // SetBech32PrefixForConsensusNode builds the Config with Bech32 addressPrefix and publKeyPrefix for consensus nodes
 // and returns the config instance
 func (config *Config) SetBech32PrefixForConsensusNode(addressPrefix, pubKeyPrefix string) {
 	config.assertNotSealed()
-	config.bech32AddressPrefix["consensus_addr"] = addressPrefix
-	config.bech32AddressPrefix["consensus_pub"] = pubKeyPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindConsAddr] = addressPrefix
+	config.bech32AddressPrefix[Bech32PrefixKindConsPub] = pubKeyPrefix
+}
+
+// RegisterBech32Prefix registers prefix as the Bech32 prefix of kind of
+// another network, e.g. the testnet account prefix on the mainnet. The
+// addresses and public keys of the registered prefixes are not accepted in
+// place of the ones of the configured prefixes, but they are reported as such
+// when parsed and they can be converted with ConvertBech32Prefix. It panics if
+// prefix is registered for another kind.
+func (config *Config) RegisterBech32Prefix(kind Bech32PrefixKind, prefix string) {
+	config.assertNotSealed()
+	if _, ok := bech32PrefixKindNames[kind]; !ok {
+		panic(fmt.Sprintf("unknown Bech32 prefix kind %s", kind))
+	}
+	if registered, ok := config.registeredPrefixes[prefix]; ok && registered != kind {
+		panic(fmt.Sprintf("Bech32 prefix %s already registered for %s", prefix, registered))
+	}
+	config.registeredPrefixes[prefix] = kind
+}
+
+// GetBech32Prefix returns the configured Bech32 prefix of kind.
+func (config *Config) GetBech32Prefix(kind Bech32PrefixKind) string {
+	return config.bech32AddressPrefix[kind]
+}
+
+// LookupBech32Prefix returns the kind of prefix, either configured or
+// registered, configured is false for the prefixes of the other networks.
+func (config *Config) LookupBech32Prefix(prefix string) (kind Bech32PrefixKind, configured bool, ok bool) {
+	for kind, configuredPrefix := range config.bech32AddressPrefix {
+		if configuredPrefix == prefix {
+			return kind, true, true
+		}
+	}
+	kind, ok = config.registeredPrefixes[prefix]
+	return kind, false, ok
 }
 
 // Seal seals the config such that the config state could not be modified further
@@ -76,32 +146,32 @@ func (config *Config) Seal() *Config {
 
 // GetBech32AccountAddrPrefix returns the Bech32 prefix for account address
 func (config *Config) GetBech32AccountAddrPrefix() string {
-	return config.bech32AddressPrefix["account_addr"]
+	return config.bech32AddressPrefix[Bech32PrefixKindAccAddr]
 }
 
 // GetBech32ValidatorAddrPrefix returns the Bech32 prefix for validator address
 func (config *Config) GetBech32ValidatorAddrPrefix() string {
-	return config.bech32AddressPrefix["validator_addr"]
+	return config.bech32AddressPrefix[Bech32PrefixKindValAddr]
 }
 
 // GetBech32ConsensusAddrPrefix returns the Bech32 prefix for consensus node address
 func (config *Config) GetBech32ConsensusAddrPrefix() string {
-	return config.bech32AddressPrefix["consensus_addr"]
+	return config.bech32AddressPrefix[Bech32PrefixKindConsAddr]
 }
 
 // GetBech32AccountPubPrefix returns the Bech32 prefix for account public key
 func (config *Config) GetBech32AccountPubPrefix() string {
-	return config.bech32AddressPrefix["account_pub"]
+	return config.bech32AddressPrefix[Bech32PrefixKindAccPub]
 }
 
 // GetBech32ValidatorPubPrefix returns the Bech32 prefix for validator public key
 func (config *Config) GetBech32ValidatorPubPrefix() string {
-	return config.bech32AddressPrefix["validator_pub"]
+	return config.bech32AddressPrefix[Bech32PrefixKindValPub]
 }
 
 // GetBech32ConsensusPubPrefix returns the Bech32 prefix for consensus node public key
 func (config *Config) GetBech32ConsensusPubPrefix() string {
-	return config.bech32AddressPrefix["consensus_pub"]
+	return config.bech32AddressPrefix[Bech32PrefixKindConsPub]
 }
 
 // CollectConfig is the structure that holds configuration parameters whether to collect specified info during apply blocks.
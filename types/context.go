@@ -30,20 +30,26 @@ type Context struct {
 	sideChainKeyPrefix []byte
 	sideChainId        string
 	crossStake         bool
+	gasMeter           GasMeter
+	blockGasMeter      GasMeter
+	kvGasConfig        GasConfig
+	transientGasConfig GasConfig
 }
 
 // create a new context
 func NewContext(ms MultiStore, header abci.Header, runTxMode RunTxMode, logger log.Logger) Context {
 	return Context{
-		ctx:              context.Background(),
-		ms:               ms,
-		blockHeader:      header,
-		blockHeight:      header.Height,
-		chainID:          header.ChainID,
-		mode:             runTxMode,
-		logger:           logger,
-		routerCallRecord: make(map[string]bool),
-		eventManager:     NewEventManager(),
+		ctx:                context.Background(),
+		ms:                 ms,
+		blockHeader:        header,
+		blockHeight:        header.Height,
+		chainID:            header.ChainID,
+		mode:               runTxMode,
+		logger:             logger,
+		routerCallRecord:   make(map[string]bool),
+		eventManager:       NewEventManager(),
+		kvGasConfig:        KVGasConfig(),
+		transientGasConfig: TransientGasConfig(),
 	}
 }
 
@@ -123,6 +129,24 @@ func (c Context) CrossStake() bool {
 	return c.crossStake
 }
 
+// GasMeter returns the gas meter of the tx, the stores are not metered if it
+// is nil.
+func (c Context) GasMeter() GasMeter {
+	return c.gasMeter
+}
+
+func (c Context) BlockGasMeter() GasMeter {
+	return c.blockGasMeter
+}
+
+func (c Context) KVGasConfig() GasConfig {
+	return c.kvGasConfig
+}
+
+func (c Context) TransientKVGasConfig() GasConfig {
+	return c.transientGasConfig
+}
+
 //----------------------------------------
 // With* (setting a value)
 
@@ -234,6 +258,26 @@ func (c Context) WithCrossStake(crossStake bool) Context {
 	return c
 }
 
+func (c Context) WithGasMeter(meter GasMeter) Context {
+	c.gasMeter = meter
+	return c
+}
+
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	c.blockGasMeter = meter
+	return c
+}
+
+func (c Context) WithKVGasConfig(gasConfig GasConfig) Context {
+	c.kvGasConfig = gasConfig
+	return c
+}
+
+func (c Context) WithTransientKVGasConfig(gasConfig GasConfig) Context {
+	c.transientGasConfig = gasConfig
+	return c
+}
+
 // is context nil
 func (c Context) IsZero() bool {
 	return c.ctx == nil && c.ms == nil
@@ -266,14 +310,21 @@ func (c Context) Value(key interface{}) interface{} {
 func (c Context) KVStore(key StoreKey) KVStore {
 	kvStore := c.MultiStore().GetKVStore(key)
 	if c.sideChainKeyPrefix != nil {
-		return kvStore.Prefix(c.sideChainKeyPrefix)
+		kvStore = kvStore.Prefix(c.sideChainKeyPrefix)
+	}
+	if c.gasMeter != nil {
+		return NewGasKVStore(c.gasMeter, c.kvGasConfig, kvStore)
 	}
 	return kvStore
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key StoreKey) KVStore {
-	return c.MultiStore().GetKVStore(key)
+	kvStore := c.MultiStore().GetKVStore(key)
+	if c.gasMeter != nil {
+		return NewGasKVStore(c.gasMeter, c.transientGasConfig, kvStore)
+	}
+	return kvStore
 }
 
 // Cache the multistore and return a new cached context. The cached context is
@@ -85,6 +85,16 @@ type CrossChainApplication interface {
 	ExecuteFailAckPackage(ctx Context, payload []byte) ExecuteResult
 }
 
+// CrossChainTimeoutApplication is a cross chain application expecting an ack
+// or a fail ack package for each of its syn packages. Registered with a
+// timeout, its syn packages not acknowledged in time are passed to
+// ExecuteTimeoutPackage, and their late acks are not executed.
+type CrossChainTimeoutApplication interface {
+	CrossChainApplication
+	// payload is the payload of the origin package, nil if it was cleaned up.
+	ExecuteTimeoutPackage(ctx Context, payload []byte) ExecuteResult
+}
+
 type ExecuteResult struct {
 	Err     Error
 	Tags    Tags
@@ -3,7 +3,9 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
+	"math/bits"
 	"strconv"
 	"testing"
 )
@@ -168,43 +170,48 @@ func (d Dec) Sub(d2 Dec) Dec {
 	return Dec{c}
 }
 
-// multiplication
+// multiplication, the product of the magnitudes is computed on 128 bits
 func (d Dec) Mul(d2 Dec) Dec {
-	mul := new(big.Int).Mul(big.NewInt(d.int64), big.NewInt(d2.int64))
-	chopped := chopPrecisionAndRound(mul)
-
-	if !chopped.IsInt64() {
-		panic("Int overflow")
+	hi, lo := bits.Mul64(abs64(d.int64), abs64(d2.int64))
+	chopped, ok := chopPrecisionAndRound128(hi, lo)
+	if ok {
+		var c int64
+		if c, ok = signed64(chopped, (d.int64 < 0) != (d2.int64 < 0)); ok {
+			return Dec{c}
+		}
 	}
-	return Dec{chopped.Int64()}
+	panic("Int overflow")
 }
 
 // multiplication
 func (d Dec) MulInt(i int64) Dec {
-	mul := new(big.Int).Mul(big.NewInt(d.int64), big.NewInt(i))
-
-	if !mul.IsInt64() {
+	mul, ok := Mul64(d.int64, i)
+	if !ok {
 		panic("Int overflow")
 	}
-	return Dec{mul.Int64()}
+	return Dec{mul}
 }
 
-// quotient
+// quotient, d * 10^16 / d2 is computed on 128 bits
 func (d Dec) Quo(d2 Dec) Dec {
 	if d2.IsZero() {
 		panic("Dived can not be zero")
 	}
 	// multiply precision twice
-	mul := new(big.Int).Mul(big.NewInt(d.int64), big.NewInt(precisionReuse))
-	mul.Mul(mul, big.NewInt(precisionReuse))
-
-	quo := new(big.Int).Quo(mul, big.NewInt(d2.int64))
-	chopped := chopPrecisionAndRound(quo)
-
-	if !chopped.IsInt64() {
-		panic("Int overflow")
+	hi, lo := bits.Mul64(abs64(d.int64), uint64(precisionReuse*precisionReuse))
+
+	// the quotient is truncated, then chopped
+	divisor := abs64(d2.int64)
+	quoHi := hi / divisor
+	quoLo, _ := bits.Div64(hi%divisor, lo, divisor)
+	chopped, ok := chopPrecisionAndRound128(quoHi, quoLo)
+	if ok {
+		var c int64
+		if c, ok = signed64(chopped, (d.int64 < 0) != (d2.int64 < 0)); ok {
+			return Dec{c}
+		}
 	}
-	return Dec{chopped.Int64()}
+	panic("Int overflow")
 }
 
 // quotient
@@ -274,6 +281,24 @@ func chopPrecisionAndRoundNonMutative(d *big.Int) *big.Int {
 	return chopPrecisionAndRound(tmp)
 }
 
+// chopPrecisionAndRound128 is chopPrecisionAndRound of the 128 bits magnitude
+// hi, lo. ok is false if the result overflows an uint64.
+func chopPrecisionAndRound128(hi, lo uint64) (chopped uint64, ok bool) {
+	precision := uint64(precisionReuse)
+	if hi >= precision {
+		return 0, false
+	}
+	quo, rem := bits.Div64(hi, lo, precision)
+	// bankers rounding, to an even number at the half
+	if rem > uint64(fivePrecision) || rem == uint64(fivePrecision) && quo&1 == 1 {
+		if quo == math.MaxUint64 {
+			return 0, false
+		}
+		quo++
+	}
+	return quo, true
+}
+
 //___________________________________________________________________________________
 
 // similar to chopPrecisionAndRound, but always rounds down
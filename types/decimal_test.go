@@ -2,6 +2,9 @@ package types
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -336,3 +339,126 @@ func TestDecMulInt(t *testing.T) {
 		require.Equal(t, tc.want, got, "Incorrect result on test case %d", i)
 	}
 }
+
+// the arithmetic of Dec on big.Int, ok is false on overflow
+func bigDecMul(d, d2 Dec) (Dec, bool) {
+	chopped := chopPrecisionAndRound(new(big.Int).Mul(big.NewInt(d.int64), big.NewInt(d2.int64)))
+	return Dec{chopped.Int64()}, chopped.IsInt64()
+}
+
+func bigDecQuo(d, d2 Dec) (Dec, bool) {
+	mul := new(big.Int).Mul(big.NewInt(d.int64), big.NewInt(precisionReuse*precisionReuse))
+	chopped := chopPrecisionAndRound(mul.Quo(mul, big.NewInt(d2.int64)))
+	return Dec{chopped.Int64()}, chopped.IsInt64()
+}
+
+// the former MulQuoDec, falling back to big.Int from int64
+func bigMulQuoDec(a, b, c Dec) (Dec, bool) {
+	if r, ok := Mul64(a.int64, b.int64); ok {
+		return Dec{r / c.int64}, true
+	}
+	var bi big.Int
+	bi.Quo(bi.Mul(big.NewInt(a.int64), big.NewInt(b.int64)), big.NewInt(c.int64))
+	return Dec{bi.Int64()}, bi.IsInt64()
+}
+
+// random decimals of every magnitude, with the edge values
+func randomDecs(r *rand.Rand, n int) []Dec {
+	decs := []Dec{{0}, {1}, {-1}, {fivePrecision}, {precisionReuse}, {-precisionReuse}, {math.MaxInt64}, {math.MinInt64}}
+	for len(decs) < n {
+		d := r.Int63() >> uint(r.Intn(63))
+		if r.Intn(2) == 0 {
+			d = -d
+		}
+		decs = append(decs, Dec{d})
+	}
+	return decs
+}
+
+func TestArithmeticMatchesBigInt(t *testing.T) {
+	decs := randomDecs(rand.New(rand.NewSource(1)), 200)
+	for _, d := range decs {
+		for _, d2 := range decs {
+			expected, ok := bigDecMul(d, d2)
+			if ok {
+				require.Equal(t, expected, d.Mul(d2), "%v * %v", d, d2)
+			} else {
+				require.Panics(t, func() { d.Mul(d2) }, "%v * %v", d, d2)
+			}
+
+			if !d2.IsZero() {
+				expected, ok = bigDecQuo(d, d2)
+				if ok {
+					require.Equal(t, expected, d.Quo(d2), "%v / %v", d, d2)
+				} else {
+					require.Panics(t, func() { d.Quo(d2) }, "%v / %v", d, d2)
+				}
+			}
+		}
+	}
+}
+
+func TestMulQuoDecMatchesBigInt(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	decs := randomDecs(r, 60)
+	for _, a := range decs {
+		for _, b := range decs {
+			for _, c := range decs {
+				if c.IsZero() {
+					continue
+				}
+				expected, ok := bigMulQuoDec(a, b, c)
+				res, err := MulQuoDec(a, b, c)
+				if ok {
+					require.Nil(t, err, "%v * %v / %v", a, b, c)
+					require.Equal(t, expected, res, "%v * %v / %v", a, b, c)
+				} else {
+					require.NotNil(t, err, "%v * %v / %v", a, b, c)
+				}
+			}
+		}
+	}
+}
+
+// the operands of the staking rewards, tokens and shares of about 10^16 and
+// ratios, e.g. the commission rates
+var (
+	benchAmounts = []Dec{{1234567890123456}, {9876543210987654}, {5000000000000000}, {3300000000000000}}
+	benchRatios  = []Dec{{10000000}, {2500000}, {99999999}, {1}}
+)
+
+func BenchmarkDecMul(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchAmounts[i%4].Mul(benchRatios[(i+1)%4])
+	}
+}
+
+func BenchmarkDecMulBigInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bigDecMul(benchAmounts[i%4], benchRatios[(i+1)%4])
+	}
+}
+
+func BenchmarkDecQuo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchAmounts[i%4].Quo(benchAmounts[(i+1)%4])
+	}
+}
+
+func BenchmarkDecQuoBigInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bigDecQuo(benchAmounts[i%4], benchAmounts[(i+1)%4])
+	}
+}
+
+func BenchmarkMulQuoDec(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		MulQuoDec(benchAmounts[i%4], benchAmounts[(i+1)%4], benchAmounts[(i+2)%4])
+	}
+}
+
+func BenchmarkMulQuoDecBigInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		bigMulQuoDec(benchAmounts[i%4], benchAmounts[(i+1)%4], benchAmounts[(i+2)%4])
+	}
+}
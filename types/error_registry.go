@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The registry of the error codes of the codespaces. Each module registers the
+// codes of its codespace with Register, and the clients identify the errors
+// by their registered errors rather than by the arithmetic of the ABCI codes,
+// e.g.
+//
+//	if errors.Is(err, sdk.ErrCodeInsufficientCoins) {
+//		...
+//	}
+//
+// The registered errors are also found by errors.As, and their descriptions
+// are part of the ABCI logs of the errors.
+var errorRegistry = map[ABCICodeType]*RegisteredError{}
+
+// RegisteredError is the registered identifier of the errors of a code of a
+// codespace.
+type RegisteredError struct {
+	codespace   CodespaceType
+	code        CodeType
+	description string
+}
+
+// Register registers the code of codespace with its description, it panics if
+// the code is already registered.
+func Register(codespace CodespaceType, code CodeType, description string) *RegisteredError {
+	if code == CodeOK {
+		panic(fmt.Sprintf("code %d of codespace %d is reserved for no error", code, codespace))
+	}
+	abciCode := ToABCICode(codespace, code)
+	if registered, ok := errorRegistry[abciCode]; ok {
+		panic(fmt.Sprintf("code %d of codespace %d already registered as %q", code, codespace, registered.description))
+	}
+	registered := &RegisteredError{codespace: codespace, code: code, description: description}
+	errorRegistry[abciCode] = registered
+	return registered
+}
+
+// LookupError returns the registered error of the code of codespace.
+func LookupError(codespace CodespaceType, code CodeType) (*RegisteredError, bool) {
+	registered, ok := errorRegistry[ToABCICode(codespace, code)]
+	return registered, ok
+}
+
+// RegisteredErrors returns the registered errors ordered by ABCI code.
+func RegisteredErrors() []*RegisteredError {
+	registered := make([]*RegisteredError, 0, len(errorRegistry))
+	for _, err := range errorRegistry {
+		registered = append(registered, err)
+	}
+	sort.Slice(registered, func(i, j int) bool {
+		return registered[i].ABCICode() < registered[j].ABCICode()
+	})
+	return registered
+}
+
+// nolint
+func (e *RegisteredError) Codespace() CodespaceType { return e.codespace }
+func (e *RegisteredError) Code() CodeType           { return e.code }
+func (e *RegisteredError) ABCICode() ABCICodeType   { return ToABCICode(e.codespace, e.code) }
+func (e *RegisteredError) Description() string      { return e.description }
+
+// Error implements error, the registered errors are the targets of errors.Is.
+func (e *RegisteredError) Error() string {
+	return fmt.Sprintf("%s (codespace %d, code %d)", e.description, e.codespace, e.code)
+}
+
+// Wrap returns an Error of the code with msg, or with the description if msg
+// is empty.
+func (e *RegisteredError) Wrap(msg string) Error {
+	if msg == "" {
+		msg = e.description
+	}
+	return newError(e.codespace, e.code, "%s", msg)
+}
+
+// Wrapf returns an Error of the code with the formatted message.
+func (e *RegisteredError) Wrapf(format string, args ...interface{}) Error {
+	return e.Wrap(fmt.Sprintf(format, args...))
+}
+
+// WrapError returns an Error of the code with the message of err, which it
+// wraps for errors.Is and errors.As.
+func (e *RegisteredError) WrapError(err error) Error {
+	wrapped := newError(e.codespace, e.code, "%s", err.Error())
+	wrapped.cause = err
+	return wrapped
+}
@@ -59,6 +59,9 @@ const (
 	CodeMsgNotSupported     CodeType = 14
 	CodeInvalidAccountFlags CodeType = 15
 	CodeInvalidTxMemo       CodeType = 16
+	CodeOutOfGas            CodeType = 17
+	CodeTxTooLarge          CodeType = 18
+	CodeTooManyMsgs         CodeType = 19
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -74,44 +77,36 @@ func unknownCodeMsg(code CodeType) string {
 	return fmt.Sprintf("unknown code %d", code)
 }
 
-// NOTE: Don't stringer this, we'll put better messages in later.
+// The registered errors of the codes of the root codespace.
+var (
+	ErrCodeInternal            = Register(CodespaceRoot, CodeInternal, "internal error")
+	ErrCodeTxDecode            = Register(CodespaceRoot, CodeTxDecode, "tx parse error")
+	ErrCodeInvalidSequence     = Register(CodespaceRoot, CodeInvalidSequence, "invalid sequence")
+	ErrCodeUnauthorized        = Register(CodespaceRoot, CodeUnauthorized, "unauthorized")
+	ErrCodeInsufficientFunds   = Register(CodespaceRoot, CodeInsufficientFunds, "insufficient funds")
+	ErrCodeUnknownRequest      = Register(CodespaceRoot, CodeUnknownRequest, "unknown request")
+	ErrCodeInvalidAddress      = Register(CodespaceRoot, CodeInvalidAddress, "invalid address")
+	ErrCodeInvalidPubKey       = Register(CodespaceRoot, CodeInvalidPubKey, "invalid pubkey")
+	ErrCodeUnknownAddress      = Register(CodespaceRoot, CodeUnknownAddress, "unknown address")
+	ErrCodeInsufficientCoins   = Register(CodespaceRoot, CodeInsufficientCoins, "insufficient coins")
+	ErrCodeInvalidCoins        = Register(CodespaceRoot, CodeInvalidCoins, "invalid coins")
+	ErrCodeMemoTooLarge        = Register(CodespaceRoot, CodeMemoTooLarge, "memo too large")
+	ErrCodeInsufficientFee     = Register(CodespaceRoot, CodeInsufficientFee, "insufficient fee")
+	ErrCodeMsgNotSupported     = Register(CodespaceRoot, CodeMsgNotSupported, "msg not supported")
+	ErrCodeInvalidAccountFlags = Register(CodespaceRoot, CodeInvalidAccountFlags, "account flags is invalid")
+	ErrCodeInvalidTxMemo       = Register(CodespaceRoot, CodeInvalidTxMemo, "transaction memo is invalid")
+	ErrCodeOutOfGas            = Register(CodespaceRoot, CodeOutOfGas, "out of gas")
+	ErrCodeTxTooLarge          = Register(CodespaceRoot, CodeTxTooLarge, "tx too large")
+	ErrCodeTooManyMsgs         = Register(CodespaceRoot, CodeTooManyMsgs, "too many msgs")
+)
+
+// CodeToDefaultMsg returns the registered description of the code of the root
+// codespace.
 func CodeToDefaultMsg(code CodeType) string {
-	switch code {
-	case CodeInternal:
-		return "internal error"
-	case CodeTxDecode:
-		return "tx parse error"
-	case CodeInvalidSequence:
-		return "invalid sequence"
-	case CodeUnauthorized:
-		return "unauthorized"
-	case CodeInsufficientFunds:
-		return "insufficient funds"
-	case CodeUnknownRequest:
-		return "unknown request"
-	case CodeInvalidAddress:
-		return "invalid address"
-	case CodeInvalidPubKey:
-		return "invalid pubkey"
-	case CodeUnknownAddress:
-		return "unknown address"
-	case CodeInsufficientCoins:
-		return "insufficient coins"
-	case CodeInvalidCoins:
-		return "invalid coins"
-	case CodeMemoTooLarge:
-		return "memo too large"
-	case CodeInsufficientFee:
-		return "insufficient fee"
-	case CodeMsgNotSupported:
-		return "msg not supported"
-	case CodeInvalidAccountFlags:
-		return "account flags is invalid"
-	case CodeInvalidTxMemo:
-		return "transaction memo is invalid"
-	default:
-		return unknownCodeMsg(code)
+	if registered, ok := LookupError(CodespaceRoot, code); ok {
+		return registered.description
 	}
+	return unknownCodeMsg(code)
 }
 
 //--------------------------------------------------------------------------------
@@ -155,6 +150,12 @@ func ErrInvalidCoins(msg string) Error {
 func ErrMemoTooLarge(msg string) Error {
 	return newErrorWithRootCodespace(CodeMemoTooLarge, msg)
 }
+func ErrTxTooLarge(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxTooLarge, msg)
+}
+func ErrTooManyMsgs(msg string) Error {
+	return newErrorWithRootCodespace(CodeTooManyMsgs, msg)
+}
 func ErrMsgNotSupported(msg string) Error {
 	return newErrorWithRootCodespace(CodeMsgNotSupported, msg)
 }
@@ -164,6 +165,9 @@ func ErrInvalidAccountFlags(msg string) Error {
 func ErrInvalidTxMemo(msg string) Error {
 	return newErrorWithRootCodespace(CodeInvalidTxMemo, msg)
 }
+func ErrOutOfGas(msg string) Error {
+	return newErrorWithRootCodespace(CodeOutOfGas, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -218,6 +222,9 @@ type sdkError struct {
 	codespace CodespaceType
 	code      CodeType
 	cmnError
+
+	// may be nil, the error wrapped by the error
+	cause error
 }
 
 // Implements Error.
@@ -230,6 +237,7 @@ func (err *sdkError) WithDefaultCodespace(cs CodespaceType) Error {
 		codespace: cs,
 		code:      err.code,
 		cmnError:  err.cmnError,
+		cause:     err.cause,
 	}
 }
 
@@ -269,6 +277,35 @@ func (err *sdkError) Code() CodeType {
 	return err.code
 }
 
+// Is reports whether err has the codespace and the code of target, a
+// registered error or an Error, for errors.Is.
+func (err *sdkError) Is(target error) bool {
+	switch target := target.(type) {
+	case *RegisteredError:
+		return err.codespace == target.codespace && err.code == target.code
+	case Error:
+		return err.codespace == target.Codespace() && err.code == target.Code()
+	}
+	return false
+}
+
+// As sets the registered error of the code of err to target, a
+// **RegisteredError, for errors.As.
+func (err *sdkError) As(target interface{}) bool {
+	if target, ok := target.(**RegisteredError); ok {
+		if registered, ok := LookupError(err.codespace, err.code); ok {
+			*target = registered
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap returns the error wrapped by err, if any.
+func (err *sdkError) Unwrap() error {
+	return err.cause
+}
+
 // Implements ABCIError.
 func (err *sdkError) ABCILog() string {
 	cdc := codec.New()
@@ -279,6 +316,9 @@ func (err *sdkError) ABCILog() string {
 		ABCICode:  err.ABCICode(),
 		Message:   errMsg,
 	}
+	if registered, ok := LookupError(err.codespace, err.code); ok {
+		jsonErr.Description = registered.description
+	}
 	bz, er := cdc.MarshalJSON(jsonErr)
 	if er != nil {
 		panic(er)
@@ -334,5 +374,8 @@ type humanReadableError struct {
 	Codespace CodespaceType `json:"codespace"`
 	Code      CodeType      `json:"code"`
 	ABCICode  ABCICodeType  `json:"abci_code"`
-	Message   string        `json:"message"`
+	// the registered description of the code, the message is the last field
+	// for AppendMsgToErr
+	Description string `json:"description,omitempty"`
+	Message     string `json:"message"`
 }
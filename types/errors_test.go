@@ -1,7 +1,10 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -20,6 +23,9 @@ var codeTypes = []CodeType{
 	CodeInsufficientCoins,
 	CodeInvalidCoins,
 	CodeMemoTooLarge,
+	CodeOutOfGas,
+	CodeTxTooLarge,
+	CodeTooManyMsgs,
 }
 
 type errFn func(msg string) Error
@@ -37,6 +43,9 @@ var errFns = []errFn{
 	ErrInsufficientCoins,
 	ErrInvalidCoins,
 	ErrMemoTooLarge,
+	ErrOutOfGas,
+	ErrTxTooLarge,
+	ErrTooManyMsgs,
 }
 
 func TestCodeType(t *testing.T) {
@@ -89,3 +98,52 @@ func TestAppendMsgToErr(t *testing.T) {
 			fmt.Sprintf("Should have formatted the error message of ABCI Log. tc #%d", i))
 	}
 }
+
+func TestRegisteredErrors(t *testing.T) {
+	testCodespace := MaximumCodespace - 1
+	registered := Register(testCodespace, 1, "test error")
+	require.Panics(t, func() { Register(testCodespace, 1, "duplicated") })
+	require.Panics(t, func() { Register(testCodespace, CodeOK, "no error") })
+	found, ok := LookupError(testCodespace, 1)
+	require.True(t, ok)
+	require.Equal(t, registered, found)
+	_, ok = LookupError(testCodespace, 2)
+	require.False(t, ok)
+
+	all := RegisteredErrors()
+	for i := 1; i < len(all); i++ {
+		require.True(t, all[i-1].ABCICode() < all[i].ABCICode())
+	}
+	require.Equal(t, registered, all[len(all)-1])
+
+	// the errors of the code are the registered error, through the wrappings
+	err := registered.Wrapf("failed at %d", 1)
+	require.Equal(t, "failed at 1", err.RawError())
+	require.Equal(t, ToABCICode(testCodespace, 1), err.ABCICode())
+	require.True(t, errors.Is(err, registered))
+	require.True(t, errors.Is(fmt.Errorf("context: %w", err), registered))
+	require.True(t, errors.Is(err, NewError(testCodespace, 1, "other")))
+	require.False(t, errors.Is(err, ErrCodeInternal))
+	require.False(t, errors.Is(ErrInternal(""), registered))
+	require.True(t, errors.Is(ErrInsufficientCoins("not enough"), ErrCodeInsufficientCoins))
+
+	var target *RegisteredError
+	require.True(t, errors.As(fmt.Errorf("context: %w", err), &target))
+	require.Equal(t, registered, target)
+	require.False(t, errors.As(NewError(testCodespace, 2, ""), &target))
+	require.Equal(t, "test error", registered.Wrap("").RawError())
+
+	wrapped := registered.WrapError(io.EOF)
+	require.Equal(t, io.EOF.Error(), wrapped.RawError())
+	require.True(t, errors.Is(wrapped, io.EOF))
+	require.True(t, errors.Is(wrapped.WithDefaultCodespace(testCodespace), io.EOF))
+
+	// the log of the errors has the registered description
+	var log humanReadableError
+	require.NoError(t, json.Unmarshal([]byte(err.ABCILog()), &log))
+	require.Equal(t, "test error", log.Description)
+	require.Equal(t, "failed at 1", log.Message)
+	var unregisteredLog humanReadableError
+	require.NoError(t, json.Unmarshal([]byte(NewError(testCodespace, 2, "unregistered").ABCILog()), &unregisteredLog))
+	require.Equal(t, "", unregisteredLog.Description)
+}
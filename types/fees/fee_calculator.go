@@ -1,6 +1,9 @@
 package fees
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/cosmos/cosmos-sdk/types"
 	param "github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
@@ -8,11 +11,43 @@ import (
 type FeeCalculator func(msg types.Msg) types.Fee
 type FeeCalculatorGenerator func(params param.FeeParam) FeeCalculator
 
-var calculators = make(map[string]FeeCalculator)
+// calculators is the in-memory fee table built from the fee params, it is read
+// by the concurrent CheckTx workers while a fee param change rebuilds it, so
+// it is never modified in place but replaced as a whole.
+var calculators atomic.Value // map[string]FeeCalculator
+var calculatorsMtx sync.Mutex
 var CalculatorsGen = make(map[string]FeeCalculatorGenerator)
 
+func init() {
+	calculators.Store(make(map[string]FeeCalculator))
+}
+
+func loadCalculators() map[string]FeeCalculator {
+	return calculators.Load().(map[string]FeeCalculator)
+}
+
 func RegisterCalculator(msgType string, feeCalc FeeCalculator) {
-	calculators[msgType] = feeCalc
+	calculatorsMtx.Lock()
+	defer calculatorsMtx.Unlock()
+	origin := loadCalculators()
+	updated := make(map[string]FeeCalculator, len(origin)+1)
+	for k, v := range origin {
+		updated[k] = v
+	}
+	updated[msgType] = feeCalc
+	calculators.Store(updated)
+}
+
+// SetCalculators replaces the whole fee table at once, so that the readers see
+// either the previous table or the new one.
+func SetCalculators(feeCalcs map[string]FeeCalculator) {
+	calculatorsMtx.Lock()
+	defer calculatorsMtx.Unlock()
+	updated := make(map[string]FeeCalculator, len(feeCalcs))
+	for k, v := range feeCalcs {
+		updated[k] = v
+	}
+	calculators.Store(updated)
 }
 
 func GetCalculatorGenerator(msgType string) FeeCalculatorGenerator {
@@ -20,13 +55,11 @@ func GetCalculatorGenerator(msgType string) FeeCalculatorGenerator {
 }
 
 func GetCalculator(msgType string) FeeCalculator {
-	return calculators[msgType]
+	return loadCalculators()[msgType]
 }
 
 func UnsetAllCalculators() {
-	for key := range calculators {
-		delete(calculators, key)
-	}
+	SetCalculators(nil)
 }
 
 func FixedFeeCalculator(amount int64, feeType types.FeeDistributeType) FeeCalculator {
@@ -54,6 +54,37 @@ func TestRegisterAndGetCalculators(t *testing.T) {
 	require.Nil(t, GetCalculator(msg.Type()))
 }
 
+func TestSetCalculators(t *testing.T) {
+	_, addr := privAndAddr()
+	msg := types.NewTestMsg(addr)
+	RegisterCalculator("other", FreeFeeCalculator())
+
+	feeCalcs := map[string]FeeCalculator{msg.Type(): FixedFeeCalculator(10, types.FeeForAll)}
+	SetCalculators(feeCalcs)
+	require.Nil(t, GetCalculator("other"))
+	require.Equal(t, types.FeeForAll, GetCalculator(msg.Type())(msg).Type)
+
+	// the table is copied, it does not change along with the given map
+	delete(feeCalcs, msg.Type())
+	require.NotNil(t, GetCalculator(msg.Type()))
+
+	// the readers keep seeing a complete table while it is replaced
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			SetCalculators(map[string]FeeCalculator{msg.Type(): FixedFeeCalculator(int64(i), types.FeeForProposer)})
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		require.NotNil(t, GetCalculator(msg.Type()))
+	}
+	<-done
+
+	UnsetAllCalculators()
+	require.Nil(t, GetCalculator(msg.Type()))
+}
+
 func privAndAddr() (crypto.PrivKey, types.AccAddress) {
 	priv := secp256k1.GenPrivKey()
 	addr := types.AccAddress(priv.PubKey().Address())
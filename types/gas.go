@@ -0,0 +1,178 @@
+package types
+
+import (
+	"math"
+)
+
+// Gas consumption descriptors.
+const (
+	GasIterNextCostFlatDesc = "IterNextFlat"
+	GasValuePerByteDesc     = "ValuePerByte"
+	GasWritePerByteDesc     = "WritePerByte"
+	GasReadPerByteDesc      = "ReadPerByte"
+	GasWriteCostFlatDesc    = "WriteFlat"
+	GasReadCostFlatDesc     = "ReadFlat"
+	GasHasDesc              = "Has"
+	GasDeleteDesc           = "Delete"
+)
+
+// Gas measured by the SDK
+type Gas = uint64
+
+// ErrorOutOfGas is the panic of a gas meter consuming past its limit, it is
+// recovered into an ErrOutOfGas by the baseapp.
+type ErrorOutOfGas struct {
+	Descriptor string
+}
+
+// ErrorGasOverflow is the panic of a gas meter whose consumption overflows.
+type ErrorGasOverflow struct {
+	Descriptor string
+}
+
+// GasMeter interface to track gas consumption
+type GasMeter interface {
+	GasConsumed() Gas
+	GasConsumedToLimit() Gas
+	Limit() Gas
+	ConsumeGas(amount Gas, descriptor string)
+	IsPastLimit() bool
+	IsOutOfGas() bool
+}
+
+type basicGasMeter struct {
+	limit    Gas
+	consumed Gas
+}
+
+// NewGasMeter returns a gas meter panicking with an ErrorOutOfGas once its
+// consumption goes past limit.
+func NewGasMeter(limit Gas) GasMeter {
+	return &basicGasMeter{
+		limit:    limit,
+		consumed: 0,
+	}
+}
+
+func (g *basicGasMeter) GasConsumed() Gas {
+	return g.consumed
+}
+
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
+func (g *basicGasMeter) GasConsumedToLimit() Gas {
+	if g.IsPastLimit() {
+		return g.limit
+	}
+	return g.consumed
+}
+
+// addUint64Overflow performs the addition operation on two uint64 integers and
+// returns a boolean on whether or not the result overflows.
+func addUint64Overflow(a, b uint64) (uint64, bool) {
+	if math.MaxUint64-a < b {
+		return 0, true
+	}
+	return a + b, false
+}
+
+func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
+	var overflow bool
+	g.consumed, overflow = addUint64Overflow(g.consumed, amount)
+	if overflow {
+		g.consumed = math.MaxUint64
+		panic(ErrorGasOverflow{descriptor})
+	}
+
+	if g.consumed > g.limit {
+		panic(ErrorOutOfGas{descriptor})
+	}
+}
+
+func (g *basicGasMeter) IsPastLimit() bool {
+	return g.consumed > g.limit
+}
+
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
+type infiniteGasMeter struct {
+	consumed Gas
+}
+
+// NewInfiniteGasMeter returns a gas meter counting the consumption without a
+// limit.
+func NewInfiniteGasMeter() GasMeter {
+	return &infiniteGasMeter{
+		consumed: 0,
+	}
+}
+
+func (g *infiniteGasMeter) GasConsumed() Gas {
+	return g.consumed
+}
+
+func (g *infiniteGasMeter) GasConsumedToLimit() Gas {
+	return g.consumed
+}
+
+func (g *infiniteGasMeter) Limit() Gas {
+	return 0
+}
+
+func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
+	var overflow bool
+	g.consumed, overflow = addUint64Overflow(g.consumed, amount)
+	if overflow {
+		g.consumed = math.MaxUint64
+		panic(ErrorGasOverflow{descriptor})
+	}
+}
+
+func (g *infiniteGasMeter) IsPastLimit() bool {
+	return false
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
+}
+
+// GasConfig defines gas cost for each operation on KVStores
+type GasConfig struct {
+	HasCost          Gas `json:"has_cost"`
+	DeleteCost       Gas `json:"delete_cost"`
+	ReadCostFlat     Gas `json:"read_cost_flat"`
+	ReadCostPerByte  Gas `json:"read_cost_per_byte"`
+	WriteCostFlat    Gas `json:"write_cost_flat"`
+	WriteCostPerByte Gas `json:"write_cost_per_byte"`
+	IterNextCostFlat Gas `json:"iter_next_cost_flat"`
+}
+
+// KVGasConfig returns a default gas config for KVStores.
+func KVGasConfig() GasConfig {
+	return GasConfig{
+		HasCost:          1000,
+		DeleteCost:       1000,
+		ReadCostFlat:     1000,
+		ReadCostPerByte:  3,
+		WriteCostFlat:    2000,
+		WriteCostPerByte: 30,
+		IterNextCostFlat: 30,
+	}
+}
+
+// TransientGasConfig returns a default gas config for TransientStores.
+func TransientGasConfig() GasConfig {
+	return GasConfig{
+		HasCost:          100,
+		DeleteCost:       100,
+		ReadCostFlat:     100,
+		ReadCostPerByte:  0,
+		WriteCostFlat:    200,
+		WriteCostPerByte: 3,
+		IterNextCostFlat: 3,
+	}
+}
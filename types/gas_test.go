@@ -0,0 +1,74 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestGasMeter(t *testing.T) {
+	meter := types.NewGasMeter(100)
+	meter.ConsumeGas(60, "first")
+	require.Equal(t, types.Gas(60), meter.GasConsumed())
+	require.False(t, meter.IsOutOfGas())
+
+	meter.ConsumeGas(40, "second")
+	require.True(t, meter.IsOutOfGas())
+	require.False(t, meter.IsPastLimit())
+
+	require.PanicsWithValue(t, types.ErrorOutOfGas{Descriptor: "third"}, func() { meter.ConsumeGas(1, "third") })
+	require.True(t, meter.IsPastLimit())
+	require.Equal(t, types.Gas(101), meter.GasConsumed())
+	require.Equal(t, types.Gas(100), meter.GasConsumedToLimit())
+
+	infinite := types.NewInfiniteGasMeter()
+	infinite.ConsumeGas(math.MaxUint64-1, "first")
+	require.False(t, infinite.IsOutOfGas())
+	require.PanicsWithValue(t, types.ErrorGasOverflow{Descriptor: "second"}, func() { infinite.ConsumeGas(2, "second") })
+}
+
+func TestGasKVStore(t *testing.T) {
+	key := types.NewKVStoreKey(t.Name())
+	config := types.KVGasConfig()
+	meter := types.NewInfiniteGasMeter()
+	ctx := defaultContext(key).WithGasMeter(meter)
+	store := ctx.KVStore(key)
+
+	store.Set([]byte("key"), []byte("value"))
+	setCost := config.WriteCostFlat + config.WriteCostPerByte*8
+	require.Equal(t, setCost, meter.GasConsumed())
+
+	require.Equal(t, []byte("value"), store.Get([]byte("key")))
+	getCost := config.ReadCostFlat + config.ReadCostPerByte*8
+	require.Equal(t, setCost+getCost, meter.GasConsumed())
+
+	require.True(t, store.Has([]byte("key")))
+	store.Delete([]byte("other"))
+	require.Equal(t, setCost+getCost+config.HasCost+config.DeleteCost, meter.GasConsumed())
+
+	// the iterators consume the gas of each pair they seek
+	consumed := meter.GasConsumed()
+	iter := store.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+	require.Equal(t, consumed+config.IterNextCostFlat+config.ReadCostPerByte*5, meter.GasConsumed())
+
+	// the prefixed stores are metered
+	consumed = meter.GasConsumed()
+	ctx.WithSideChainKeyPrefix([]byte{0x01}).KVStore(key).Set([]byte("key"), []byte("value"))
+	require.Equal(t, consumed+setCost, meter.GasConsumed())
+
+	// the stores are not metered without a gas meter
+	consumed = meter.GasConsumed()
+	ctx.WithGasMeter(nil).KVStore(key).Set([]byte("key"), []byte("value"))
+	require.Equal(t, consumed, meter.GasConsumed())
+
+	// the operation running out of gas is not applied
+	ctx = ctx.WithGasMeter(types.NewGasMeter(setCost - 1))
+	require.Panics(t, func() { ctx.KVStore(key).Set([]byte("new"), []byte("value")) })
+	require.False(t, ctx.WithGasMeter(nil).KVStore(key).Has([]byte("new")))
+}
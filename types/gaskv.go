@@ -0,0 +1,163 @@
+package types
+
+import (
+	"io"
+)
+
+var _ KVStore = &gasKVStore{}
+
+// gasKVStore applies gas tracking to an underlying KVStore. It lives in the
+// types package, rather than in store, so that the Context can wrap the
+// stores it hands out.
+type gasKVStore struct {
+	gasMeter  GasMeter
+	gasConfig GasConfig
+	parent    KVStore
+}
+
+// NewGasKVStore returns a KVStore consuming the gas of each operation on
+// parent from gasMeter, with the costs of gasConfig.
+func NewGasKVStore(gasMeter GasMeter, gasConfig GasConfig, parent KVStore) KVStore {
+	return &gasKVStore{
+		gasMeter:  gasMeter,
+		gasConfig: gasConfig,
+		parent:    parent,
+	}
+}
+
+// Implements Store.
+func (gs *gasKVStore) GetStoreType() StoreType {
+	return gs.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) Get(key []byte) (value []byte) {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostFlat, GasReadCostFlatDesc)
+	value = gs.parent.Get(key)
+
+	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostPerByte*Gas(len(key)), GasReadPerByteDesc)
+	gs.gasMeter.ConsumeGas(gs.gasConfig.ReadCostPerByte*Gas(len(value)), GasReadPerByteDesc)
+	return value
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) Set(key []byte, value []byte) {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostFlat, GasWriteCostFlatDesc)
+	// the key is charged along with the value, both are persisted
+	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*Gas(len(key)), GasWritePerByteDesc)
+	gs.gasMeter.ConsumeGas(gs.gasConfig.WriteCostPerByte*Gas(len(value)), GasWritePerByteDesc)
+	gs.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) Has(key []byte) bool {
+	gs.gasMeter.ConsumeGas(gs.gasConfig.HasCost, GasHasDesc)
+	return gs.parent.Has(key)
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) Delete(key []byte) {
+	// charge gas to prevent certain attack vectors even though space is being freed
+	gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, GasDeleteDesc)
+	gs.parent.Delete(key)
+}
+
+// Implements KVStore, the prefixed store keeps consuming from the same meter.
+func (gs *gasKVStore) Prefix(prefix []byte) KVStore {
+	return NewGasKVStore(gs.gasMeter, gs.gasConfig, gs.parent.Prefix(prefix))
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) Iterator(start, end []byte) Iterator {
+	return gs.iterator(start, end, true)
+}
+
+// Implements KVStore.
+func (gs *gasKVStore) ReverseIterator(start, end []byte) Iterator {
+	return gs.iterator(start, end, false)
+}
+
+// Implements CacheWrapper, the stores of a tx are cache wrapped before they
+// are metered.
+func (gs *gasKVStore) CacheWrap() CacheWrap {
+	panic("cannot CacheWrap a GasKVStore")
+}
+
+// Implements CacheWrapper.
+func (gs *gasKVStore) CacheWrapWithTrace(_ io.Writer, _ TraceContext) CacheWrap {
+	panic("cannot CacheWrapWithTrace a GasKVStore")
+}
+
+func (gs *gasKVStore) iterator(start, end []byte, ascending bool) Iterator {
+	var parent Iterator
+	if ascending {
+		parent = gs.parent.Iterator(start, end)
+	} else {
+		parent = gs.parent.ReverseIterator(start, end)
+	}
+
+	gi := newGasIterator(gs.gasMeter, gs.gasConfig, parent)
+	if gi.Valid() {
+		gi.(*gasIterator).consumeSeekGas()
+	}
+
+	return gi
+}
+
+type gasIterator struct {
+	gasMeter  GasMeter
+	gasConfig GasConfig
+	parent    Iterator
+}
+
+func newGasIterator(gasMeter GasMeter, gasConfig GasConfig, parent Iterator) Iterator {
+	return &gasIterator{
+		gasMeter:  gasMeter,
+		gasConfig: gasConfig,
+		parent:    parent,
+	}
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Domain() (start []byte, end []byte) {
+	return gi.parent.Domain()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Valid() bool {
+	return gi.parent.Valid()
+}
+
+// Next implements the Iterator interface. It seeks to the next key/value pair
+// in the iterator. It incurs a flat gas cost for seeking and a variable gas
+// cost based on the current value's length if the iterator is valid.
+func (gi *gasIterator) Next() {
+	gi.parent.Next()
+	if gi.Valid() {
+		gi.consumeSeekGas()
+	}
+}
+
+// Implements Iterator, the gas of the pair is consumed when seeking it.
+func (gi *gasIterator) Key() (key []byte) {
+	return gi.parent.Key()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Value() (value []byte) {
+	return gi.parent.Value()
+}
+
+// Implements Iterator.
+func (gi *gasIterator) Close() {
+	gi.parent.Close()
+}
+
+// consumeSeekGas consumes a flat gas cost for seeking and a variable gas cost
+// based on the current value's length.
+func (gi *gasIterator) consumeSeekGas() {
+	value := gi.Value()
+
+	gi.gasMeter.ConsumeGas(gi.gasConfig.ReadCostPerByte*Gas(len(value)), GasValuePerByteDesc)
+	gi.gasMeter.ConsumeGas(gi.gasConfig.IterNextCostFlat, GasIterNextCostFlatDesc)
+}
@@ -29,4 +29,45 @@ const (
 type AnteHandler func(ctx Context, tx Tx,
 	runTxMode RunTxMode) (newCtx Context, result Result, abort bool)
 
+// AnteDecorator is a step of a chain of decorators making an AnteHandler, it
+// checks the tx and calls next to run the rest of the chain.
+type AnteDecorator interface {
+	AnteHandle(ctx Context, tx Tx, runTxMode RunTxMode, next AnteHandler) (newCtx Context, result Result, abort bool)
+}
+
+// AnteDecoratorFunc lets a function be used as an AnteDecorator.
+type AnteDecoratorFunc func(ctx Context, tx Tx, runTxMode RunTxMode, next AnteHandler) (newCtx Context, result Result, abort bool)
+
+func (f AnteDecoratorFunc) AnteHandle(ctx Context, tx Tx, runTxMode RunTxMode, next AnteHandler) (Context, Result, bool) {
+	return f(ctx, tx, runTxMode, next)
+}
+
+// ChainAnteDecorators chains the decorators, in order, into an AnteHandler.
+// The chain ends with a handler accepting the tx.
+func ChainAnteDecorators(decorators ...AnteDecorator) AnteHandler {
+	handler := AnteHandler(func(ctx Context, _ Tx, _ RunTxMode) (Context, Result, bool) {
+		return ctx, Result{}, false
+	})
+	for i := len(decorators) - 1; i >= 0; i-- {
+		decorator, next := decorators[i], handler
+		handler = func(ctx Context, tx Tx, runTxMode RunTxMode) (Context, Result, bool) {
+			return decorator.AnteHandle(ctx, tx, runTxMode, next)
+		}
+	}
+	return handler
+}
+
 type PreChecker func(ctx Context, txBytes []byte, tx Tx) Result
+
+// TxPriorityHandler assigns the priority in the mempool of a tx passing
+// CheckTx, e.g. to let the oracle claims of the relayers go before the user
+// txs when the mempool is congested. The default priority is 0.
+type TxPriorityHandler func(ctx Context, tx Tx) int64
+
+// MsgInterceptor runs around the handler of each msg of a tx, next runs the
+// handler, or the next interceptor, and returns its result. An interceptor
+// observes the msgs and their results, e.g. for metrics or tracing, and vetoes
+// a msg by returning a failed result without calling next. The interceptors
+// run in every mode of the txs, in DeliverTx they are part of the consensus
+// and must be deterministic like the handlers.
+type MsgInterceptor func(ctx Context, msg Msg, next Handler) Result
@@ -0,0 +1,10 @@
+package types
+
+// An Invariant is a check of the state of a module, it returns an error
+// describing the breach when the state is corrupted.
+type Invariant func(ctx Context) error
+
+// InvariantRouter is where the modules register their invariants.
+type InvariantRouter interface {
+	RegisterRoute(moduleName, route string, invar Invariant)
+}
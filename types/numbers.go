@@ -2,7 +2,9 @@ package types
 
 import (
 	"errors"
+	"math"
 	"math/big"
+	"math/bits"
 )
 
 const (
@@ -29,16 +31,58 @@ func MulQuoDec(a, b, c Dec) (Dec, error) {
 	}
 	r, ok := Mul64(a.RawInt(), b.RawInt())
 	if !ok {
-		var bi big.Int
-		bi.Quo(bi.Mul(big.NewInt(a.RawInt()), big.NewInt(b.RawInt())), big.NewInt(c.RawInt()))
-		if !bi.IsInt64() {
+		// the product of the magnitudes fits 128 bits, the quotient is
+		// truncated toward zero as the one of big.Int
+		quo, _, ok := MulQuoRem64(abs64(a.RawInt()), abs64(b.RawInt()), abs64(c.RawInt()))
+		if ok {
+			negative := (a.RawInt() < 0) != (b.RawInt() < 0) != (c.RawInt() < 0)
+			r, ok = signed64(quo, negative)
+		}
+		if !ok {
 			return Dec{}, errors.New(ErrIntOverflow)
 		}
-		return NewDec(bi.Int64()), nil
+		return NewDec(r), nil
 	}
 	return NewDec(r / c.RawInt()), nil
 }
 
+// MulQuoRem64 returns a * b / c and its remainder, with the product of a and b
+// computed on 128 bits. ok is false if the quotient overflows an uint64.
+func MulQuoRem64(a, b, c uint64) (quo, rem uint64, ok bool) {
+	if c == 0 {
+		panic("division by zero")
+	}
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return 0, 0, false
+	}
+	quo, rem = bits.Div64(hi, lo, c)
+	return quo, rem, true
+}
+
+// abs64 returns the magnitude of a, math.MinInt64 included.
+func abs64(a int64) uint64 {
+	if a < 0 {
+		return uint64(-(a + 1)) + 1
+	}
+	return uint64(a)
+}
+
+// signed64 returns the int64 of the magnitude u, negated if negative. ok is
+// false if it overflows an int64.
+func signed64(u uint64, negative bool) (int64, bool) {
+	if negative {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return -int64(u-1) - 1, true
+	}
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
 func MulBigInt(a, b *big.Int) *big.Int {
 	if a == nil || b == nil {
 		panic("arguments can not be nil")
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MaxPageLimit is the maximum number of results of a page.
+const MaxPageLimit = 1000
+
+// Pagination selects up to Limit results from the Offset-th one of a list
+// query. The zero Pagination selects all of them.
+type Pagination struct {
+	Offset int
+	Limit  int
+}
+
+// NewPagination creates a Pagination of up to limit results from offset.
+func NewPagination(offset, limit int) Pagination {
+	return Pagination{Offset: offset, Limit: limit}
+}
+
+// IsPaginated returns true if the results are paginated.
+func (p Pagination) IsPaginated() bool {
+	return p.Limit > 0
+}
+
+// ValidateBasic checks the offset and limit are in range.
+func (p Pagination) ValidateBasic() error {
+	if p.Offset < 0 {
+		return fmt.Errorf("offset %d must not be negative", p.Offset)
+	}
+	if p.Limit < 0 || p.Limit > MaxPageLimit {
+		return fmt.Errorf("limit %d must be between 0 and %d", p.Limit, MaxPageLimit)
+	}
+	return nil
+}
+
+// InPage returns true if the index-th result, from 0, belongs to the page.
+func (p Pagination) InPage(index int) bool {
+	if !p.IsPaginated() {
+		return true
+	}
+	return index >= p.Offset && index < p.Offset+p.Limit
+}
+
+// Page is the result of a paginated list query, the results of the page with
+// the total number of results.
+type Page struct {
+	Total int             `json:"total"`
+	Items json.RawMessage `json:"items"`
+}
+
+// NewPage creates the Page of the JSON encoded results items.
+func NewPage(total int, items []byte) Page {
+	return Page{Total: total, Items: items}
+}
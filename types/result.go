@@ -18,9 +18,18 @@ type Result struct {
 	FeeAmount int64
 	FeeDenom  string
 
+	// GasWanted is the gas limit of the tx and GasUsed the gas its store
+	// operations consumed, both are 0 when the gas is not metered.
+	GasWanted Gas
+	GasUsed   Gas
+
 	// Tags are used for transaction indexing and pubsub.
 	Tags   Tags
 	Events Events
+
+	// MsgResponses are the Data returned by each msg, Data being their
+	// concatenation.
+	MsgResponses [][]byte
 }
 
 // TODO: In the future, more codes may be OK.
@@ -28,10 +37,25 @@ func (res Result) IsOK() bool {
 	return res.Code.IsOK()
 }
 
+// GetEvents returns the events of the result for the ABCI response. The tags
+// are carried by a message event since tendermint only indexes typed events.
 func (res Result) GetEvents() []abci.Event {
-	events := res.Tags.ToEvents()
+	events := []abci.Event{{Type: EventTypeMessage, Attributes: res.Tags}}
 	if res.Events != nil {
 		events = append(events, res.Events.ToABCIEvents()...)
 	}
 	return events
 }
+
+// SimulationResponse is the preview of a tx returned by the /app/simulate_tx
+// query. Fee is the fee charged for the msgs of the tx, this chain charges
+// fixed fees per msg type, and GasUsed the gas its store operations consumed
+// once they are metered.
+type SimulationResponse struct {
+	Code         ABCICodeType `json:"code"`
+	Log          string       `json:"log"`
+	Fee          Fee          `json:"fee"`
+	GasUsed      Gas          `json:"gas_used,omitempty"`
+	MsgResponses [][]byte     `json:"msg_responses"`
+	Events       StringEvents `json:"events"`
+}
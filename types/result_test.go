@@ -16,3 +16,15 @@ func TestResult(t *testing.T) {
 	res.Code = ABCICodeType(1)
 	require.False(t, res.IsOK())
 }
+
+func TestResultGetEvents(t *testing.T) {
+	res := Result{
+		Tags:   NewTags("action", []byte("send")),
+		Events: Events{NewEvent("transfer", NewAttribute("recipient", "foo"))},
+	}
+	events := res.GetEvents()
+	require.Len(t, events, 2)
+	require.Equal(t, EventTypeMessage, events[0].Type)
+	require.Equal(t, []byte("action"), events[0].Attributes[0].Key)
+	require.Equal(t, "transfer", events[1].Type)
+}
@@ -135,9 +135,10 @@ type StakingHooks interface {
 	OnValidatorBonded(ctx Context, address ConsAddress, operator ValAddress)         // Must be called when a validator is bonded
 	OnValidatorBeginUnbonding(ctx Context, address ConsAddress, operator ValAddress) // Must be called when a validator begins unbonding
 
-	OnDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called when a delegation is created
-	OnDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress) // Must be called when a delegation's shares are modified
-	OnDelegationRemoved(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called when a delegation is removed
+	OnDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress)                     // Must be called when a delegation is created
+	OnDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress)              // Must be called when a delegation's shares are modified
+	OnDelegationSharesIncreased(ctx Context, delAddr AccAddress, valAddr ValAddress, shares Dec) // Must be called after shares are added to a delegation
+	OnDelegationRemoved(ctx Context, delAddr AccAddress, valAddr ValAddress)                     // Must be called when a delegation is removed
 
 	OnSideChainValidatorBonded(ctx Context, sideConsAddr []byte, operator ValAddress)
 	OnSideChainValidatorBeginUnbonding(ctx Context, sideConsAddr []byte, operator ValAddress)
@@ -12,20 +12,70 @@ import (
 
 // NOTE: These are implemented in cosmos-sdk/store.
 
-// PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+// PruningStrategy specifies how old states will be deleted over time
+type PruningStrategy struct {
+	// KeepRecent is the number of versions kept before the latest one.
+	KeepRecent int64
+	// KeepEvery keeps the versions which are a multiple of it, as state-sync
+	// waypoints, on top of the recent ones. A value of 1 keeps every version
+	// and a value of 0 keeps no waypoints.
+	KeepEvery int64
+	// Interval is the number of versions between two prunings, each pruning
+	// deletes the versions released since the previous one.
+	// A value of 1 prunes on every commit.
+	Interval int64
+}
 
-const (
-	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+var (
+	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100000 + every 100000th)
+	PruneSyncable = NewPruningStrategy(100000, 100000, 1)
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = NewPruningStrategy(0, 0, 1)
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = NewPruningStrategy(0, 1, 1)
 )
 
+// NewPruningStrategy returns a pruning strategy keeping the keepRecent last
+// versions and every keepEvery-th version, pruning every interval versions.
+func NewPruningStrategy(keepRecent, keepEvery, interval int64) PruningStrategy {
+	return PruningStrategy{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// NewPruningStrategyFromString returns the named pruning strategy, one of
+// syncable, nothing and everything.
+func NewPruningStrategyFromString(strategy string) (PruningStrategy, error) {
+	switch strategy {
+	case "syncable":
+		return PruneSyncable, nil
+	case "nothing":
+		return PruneNothing, nil
+	case "everything":
+		return PruneEverything, nil
+	default:
+		return PruningStrategy{}, fmt.Errorf("invalid pruning strategy: %s", strategy)
+	}
+}
+
+// Validate checks the fields of the strategy are in range.
+func (s PruningStrategy) Validate() error {
+	if s.KeepRecent < 0 {
+		return fmt.Errorf("pruning keep-recent must not be negative, got %d", s.KeepRecent)
+	}
+	if s.KeepEvery < 0 {
+		return fmt.Errorf("pruning keep-every must not be negative, got %d", s.KeepEvery)
+	}
+	if s.Interval < 1 {
+		return fmt.Errorf("pruning interval must be positive, got %d", s.Interval)
+	}
+	return nil
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -111,6 +161,20 @@ type CommitMultiStore interface {
 
 	GetCommitKVStores() map[StoreKey]CommitKVStore
 
+	// Cache up to size values of each IAVL store across blocks, 0 disables
+	// the cache. Must be called before loading a version.
+	SetInterBlockCacheSize(size int)
+
+	// Record the writes of each committed version into a content-addressed
+	// diff file of dir, an empty dir disables the recording. Must be called
+	// before the stores are handed out.
+	SetStateDiffDir(dir string)
+
+	// Buffer the writes of the IAVL stores of each version and its commit
+	// info, and write them at the commit in a single batch, synced on a
+	// background goroutine if async. Must be called before loading a version.
+	SetCommitBuffer(enabled, async bool)
+
 	// Load the latest persisted version.  Called once after all
 	// calls to Mount*Store() are complete.
 	LoadLatestVersion() error
@@ -120,6 +184,10 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// Cache wrap the persisted version ver, e.g. to query the state or to
+	// replay the txs of a past block. The cache cannot be written back.
+	CacheMultiStoreWithVersion(ver int64) (CacheMultiStore, error)
 }
 
 //---------subsp-------------------------------
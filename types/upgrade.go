@@ -23,7 +23,23 @@ const (
 	BEP171                      = "BEP171" //https://github.com/bnb-chain/BEPs/pull/171
 	BEP173                      = "BEP173" // https://github.com/bnb-chain/BEPs/pull/173
 	FixDoubleSignChainId        = "FixDoubleSignChainId"
-	BEP126                      = "BEP126" //https://github.com/binance-chain/BEPs/pull/126
+	BEP126                      = "BEP126"                   //https://github.com/binance-chain/BEPs/pull/126
+	RewardsMinBondedDuration    = "RewardsMinBondedDuration" // delegated shares earn rewards only after a minimum bonded duration
+	UndelegateWithValidator     = "UndelegateWithValidator"  // undelegations from an unbonding validator complete no earlier than the validator
+	ParamsChangeProposal        = "ParamsChangeProposal"     // gov proposals changing the params of any registered subspace
+	ScheduledUpgrade            = "ScheduledUpgrade"         // software upgrade proposals schedule a plan halting the chain at its height or time
+	SlashingEvidenceParams      = "SlashingEvidenceParams"   // evidences are checked against the consensus params and penalized per type
+	DowntimeAutoUnjail          = "DowntimeAutoUnjail"       // validators jailed for downtime are unjailed automatically within a grace window
+	CanonicalSignature          = "CanonicalSignature"       // signatures must be canonical so that a signed tx has a single tx hash
+	GasMetering                 = "GasMetering"              // the store operations of the txs are metered against the tx and block gas limits
+	TxLimitsParams              = "TxLimitsParams"           // the limits of the tx size, the msgs per tx and the memo length are auth params
+	AccountFlags                = "AccountFlags"             // accounts have flags holding their transfers or requiring a memo on their deposits
+	F1Distribution              = "F1Distribution"           // the rewards are distributed with the F1 periods of the validators instead of the height accumulation
+	ValidatorUpdatesThrottle    = "ValidatorUpdatesThrottle" // the validator updates sent to Tendermint are diffed against the last sent ones and throttled per block
+	GovProposalPolicies         = "GovProposalPolicies"      // the deposits of the tallied proposals follow the deposit policy, and the proposals can be expedited
+	GovWeightedVotes            = "GovWeightedVotes"         // the votes on the proposals can split the voting power of the voters among the options
+	CanonicalSignBytes          = "CanonicalSignBytes"       // the sign bytes are the canonical JSON of the canonicaljson package, which doesn't depend on the go version
+	SupplyTracking              = "SupplyTracking"           // the total supply is seeded from the accounts at the upgrade height and tracked by the minting and burning modules
 
 )
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/codec/canonicaljson"
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
@@ -14,7 +15,17 @@ import (
 // This method can be used to canonicalize JSON to be returned by GetSignBytes,
 // e.g. for the ledger integration.
 // If the passed JSON isn't valid it will return an error.
+// From the CanonicalSignBytes upgrade, see the canonicaljson package for the
+// rules of the canonical form. Before it, the escaping of the strings is the
+// one of the encoding/json package the node is built with.
 func SortJSON(toSortJSON []byte) ([]byte, error) {
+	if IsUpgrade(CanonicalSignBytes) {
+		if !IsUpgrade(FixSignBytesOverflow) {
+			return canonicaljson.CanonicalizeFloat64(toSortJSON)
+		}
+		return canonicaljson.Canonicalize(toSortJSON)
+	}
+
 	var c interface{}
 	var err error
 	if !IsUpgrade(FixSignBytesOverflow) {
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -75,6 +77,38 @@ func TestSortJSONOverflow(t *testing.T) {
 	}
 }
 
+func TestSortJSONCanonicalSignBytes(t *testing.T) {
+	defer UpgradeMgr.Reset()
+	UpgradeMgr.AddUpgradeHeight(FixSignBytesOverflow, 10)
+	UpgradeMgr.AddUpgradeHeight(CanonicalSignBytes, 100)
+	UpgradeMgr.SetHeight(99)
+
+	unsortedJSON := []byte(`{"memo":"\b\f<&>","amount":9007199326368011}`)
+	trailingJSON := []byte(`{"memo":"foo"} {"memo":"bar"}`)
+
+	// before the upgrade, the sign bytes are the ones of encoding/json
+	var c interface{}
+	decoder := json.NewDecoder(bytes.NewReader(unsortedJSON))
+	decoder.UseNumber()
+	require.NoError(t, decoder.Decode(&c))
+	want, err := json.Marshal(c)
+	require.NoError(t, err)
+	got, err := SortJSON(unsortedJSON)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	got, err = SortJSON(trailingJSON)
+	require.NoError(t, err)
+	require.Equal(t, `{"memo":"foo"}`, string(got))
+
+	// from the upgrade, they don't depend on the go version
+	UpgradeMgr.SetHeight(100)
+	got, err = SortJSON(unsortedJSON)
+	require.NoError(t, err)
+	require.Equal(t, `{"amount":9007199326368011,"memo":"\u0008\u000c\u003c\u0026\u003e"}`, string(got))
+	_, err = SortJSON(trailingJSON)
+	require.Error(t, err)
+}
+
 func TestTimeFormatAndParse(t *testing.T) {
 	cases := []struct {
 		RFC3339NanoStr     string
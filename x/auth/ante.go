@@ -5,7 +5,9 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
@@ -19,72 +21,258 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers
 func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
-	return func(
-		ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode,
-	) (newCtx sdk.Context, res sdk.Result, abort bool) {
-		newCtx = ctx
-		// This AnteHandler requires Txs to be StdTxs
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	return newAnteHandler(am, NewTxLimitsDecorator(am))
+}
+
+// NewAnteHandlerWithParams returns the AnteHandler of NewAnteHandler which
+// checks the txs against the limits of the auth params of paramSpace rather
+// than the default limits, see Params.
+func NewAnteHandlerWithParams(am AccountKeeper, paramSpace params.Subspace) sdk.AnteHandler {
+	return newAnteHandler(am, NewParamsTxLimitsDecorator(am, paramSpace))
+}
+
+func newAnteHandler(am AccountKeeper, limits TxLimitsDecorator) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(
+		NewSetUpContextDecorator(),
+		NewValidateBasicDecorator(),
+		limits,
+		NewSigVerificationDecorator(am),
+		NewIncrementSequenceDecorator(am),
+	)
+}
+
+// DefaultAnteDecorators returns the decorators of the ante handler, in order,
+// but the TxLimitsDecorator which the apps insert after the
+// ValidateBasicDecorator from the TxLimitsParams upgrade. The apps can insert
+// their own decorators, e.g. a whitelist check, and chain them with
+// sdk.ChainAnteDecorators. There is no fee decorator as the fees are
+// calculated per msg, see the fees package.
+func DefaultAnteDecorators(am AccountKeeper) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		NewSetUpContextDecorator(),
+		NewValidateBasicDecorator(),
+		NewSigVerificationDecorator(am),
+		NewIncrementSequenceDecorator(am),
+	}
+}
+
+// SetUpContextDecorator requires the txs to be StdTxs, it must be the first
+// decorator of the chain.
+type SetUpContextDecorator struct{}
+
+func NewSetUpContextDecorator() SetUpContextDecorator {
+	return SetUpContextDecorator{}
+}
+
+func (d SetUpContextDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	// This AnteHandler requires Txs to be StdTxs
+	if _, ok := tx.(StdTx); !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	// AnteHandlers must have their own defer/recover in order
+	defer func() {
+		if r := recover(); r != nil {
+			panic(r)
 		}
+	}()
+	return next(ctx, tx, mode)
+}
 
-		// AnteHandlers must have their own defer/recover in order
-		defer func() {
-			if r := recover(); r != nil {
-				panic(r)
-			}
-		}()
-		if mode != sdk.RunTxModeReCheck {
-			err := validateBasic(stdTx)
-			if err != nil {
-				return newCtx, err.Result(), true
-			}
+// ValidateBasicDecorator checks the signatures count and the memo of the tx,
+// the rechecked txs were already validated.
+type ValidateBasicDecorator struct{}
+
+func NewValidateBasicDecorator() ValidateBasicDecorator {
+	return ValidateBasicDecorator{}
+}
+
+func (d ValidateBasicDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	if mode != sdk.RunTxModeReCheck {
+		if err := validateBasic(tx.(StdTx)); err != nil {
+			return ctx, err.Result(), true
 		}
+	}
+	return next(ctx, tx, mode)
+}
 
-		// stdSigs contains the sequence number, account number, and signatures
-		stdSigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
-		signerAddrs := stdTx.GetSigners()
+// TxLimitsDecorator checks the size, the number of msgs and the memo of the
+// tx against the auth params from the TxLimitsParams upgrade, it must follow
+// the SetUpContextDecorator. The rechecked txs were already checked.
+type TxLimitsDecorator struct {
+	cdc        *codec.Codec
+	paramSpace params.Subspace
+	withParams bool
+}
 
-		signerAccs, res := getSignerAccs(newCtx, am, signerAddrs)
-		if !res.IsOK() {
-			return newCtx, res, true
+// NewTxLimitsDecorator returns a TxLimitsDecorator checking the default
+// limits of DefaultParams, for the apps with no auth params.
+func NewTxLimitsDecorator(am AccountKeeper) TxLimitsDecorator {
+	return TxLimitsDecorator{cdc: am.cdc}
+}
+
+// NewParamsTxLimitsDecorator returns a TxLimitsDecorator checking the limits
+// of the auth params of paramSpace.
+func NewParamsTxLimitsDecorator(am AccountKeeper, paramSpace params.Subspace) TxLimitsDecorator {
+	return TxLimitsDecorator{cdc: am.cdc, paramSpace: paramSpace.WithTypeTable(ParamTypeTable()), withParams: true}
+}
+
+func (d TxLimitsDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	if mode != sdk.RunTxModeReCheck && sdk.IsUpgrade(sdk.TxLimitsParams) {
+		p := DefaultParams()
+		if d.withParams {
+			p = GetParams(ctx, d.paramSpace)
 		}
-		res = validateAccNumAndSequence(ctx, signerAccs, stdSigs)
-		if !res.IsOK() {
-			return newCtx, res, true
+		if err := checkLimits(d.cdc, p, tx.(StdTx)); err != nil {
+			return ctx, err.Result(), true
+		}
+	}
+	return next(ctx, tx, mode)
+}
+
+func checkLimits(cdc *codec.Codec, p Params, tx StdTx) sdk.Error {
+	if p.MaxMsgsPerTx > 0 && int64(len(tx.Msgs)) > p.MaxMsgsPerTx {
+		return sdk.ErrTooManyMsgs(
+			fmt.Sprintf("maximum number of msgs is %d but received %d msgs", p.MaxMsgsPerTx, len(tx.Msgs)))
+	}
+	if p.MaxMemoCharacters > 0 && int64(len(tx.Memo)) > p.MaxMemoCharacters {
+		return sdk.ErrMemoTooLarge(
+			fmt.Sprintf("maximum number of characters is %d but received %d characters",
+				p.MaxMemoCharacters, len(tx.Memo)))
+	}
+	if p.MaxTxBytes > 0 {
+		// the size of the encoding of the tx by the tx decoder of the app
+		bz, err := cdc.MarshalBinaryLengthPrefixed(tx)
+		if err != nil {
+			return sdk.ErrInternal(err.Error())
+		}
+		if int64(len(bz)) > p.MaxTxBytes {
+			return sdk.ErrTxTooLarge(
+				fmt.Sprintf("maximum size of a tx is %d bytes but received %d bytes", p.MaxTxBytes, len(bz)))
 		}
+	}
+	return nil
+}
 
-		var signBytesList [][]byte
+// SigVerificationDecorator checks the account numbers and the sequences of the
+// signers and verifies their signatures, setting the public keys the accounts
+// miss. The signer accounts are cached in the context, see GetSigners.
+type SigVerificationDecorator struct {
+	am AccountKeeper
+}
+
+func NewSigVerificationDecorator(am AccountKeeper) SigVerificationDecorator {
+	return SigVerificationDecorator{am: am}
+}
 
+func (d SigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	stdTx := tx.(StdTx)
+	// stdSigs contains the sequence number, account number, and signatures
+	stdSigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
+	signerAddrs := stdTx.GetSigners()
+
+	signerAccs, res := getSignerAccs(ctx, d.am, signerAddrs)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	res = validateAccNumAndSequence(ctx, signerAccs, stdSigs)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+
+	var signBytesList [][]byte
+
+	if mode != sdk.RunTxModeReCheck {
+		// create the list of all sign bytes
+		signBytesList = getSignBytesList(ctx.ChainID(), stdTx, stdSigs)
+	}
+
+	for i := 0; i < len(stdSigs); i++ {
+		// check signature
+		var signBytes []byte
 		if mode != sdk.RunTxModeReCheck {
-			// create the list of all sign bytes
-			signBytesList = getSignBytesList(newCtx.ChainID(), stdTx, stdSigs)
+			signBytes = signBytesList[i]
 		}
+		signerAccs[i], res = processSig(ctx, signerAccs[i], stdSigs[i], signBytes, mode)
+		if !res.IsOK() {
+			return ctx, res, true
+		}
+	}
 
-		for i := 0; i < len(stdSigs); i++ {
-			// check signature, return account with incremented nonce
-			var signBytes []byte
-			if mode != sdk.RunTxModeReCheck {
-				signBytes = signBytesList[i]
-			} else {
-				signBytes = nil
-			}
-			signerAccs[i], res = processSig(newCtx, signerAccs[i],
-				stdSigs[i], signBytes, mode)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+	// cache the signer accounts in the context
+	return next(WithSigners(ctx, signerAccs), tx, mode)
+}
+
+// IncrementSequenceDecorator increments the sequences of the signer accounts
+// cached in the context and saves them, it must follow the
+// SigVerificationDecorator.
+type IncrementSequenceDecorator struct {
+	am AccountKeeper
+}
 
-			// Save the account.
-			am.SetAccount(newCtx, signerAccs[i])
+func NewIncrementSequenceDecorator(am AccountKeeper) IncrementSequenceDecorator {
+	return IncrementSequenceDecorator{am: am}
+}
+
+func (d IncrementSequenceDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+	for _, acc := range GetSigners(ctx) {
+		err := acc.SetSequence(acc.GetSequence() + 1)
+		if err != nil {
+			// Handle w/ #870
+			panic(err)
 		}
+		// Save the account.
+		d.am.SetAccount(ctx, acc)
+	}
+	return next(ctx, tx, mode)
+}
 
-		// cache the signer accounts in the context
-		newCtx = WithSigners(newCtx, signerAccs)
+// NewPreChecker returns a PreChecker verifying the signatures of the StdTxs
+// which carry the public keys of their signers. It does not read the state so
+// that it can run on the worker pools of the concurrent ABCI client while the
+// previous transactions are checked or delivered, the ante handler verifies
+// the other signatures against the public keys of the accounts.
+func NewPreChecker() sdk.PreChecker {
+	return func(ctx sdk.Context, txBytes []byte, tx sdk.Tx) sdk.Result {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return sdk.ErrInternal("tx must be StdTx").Result()
+		}
+		if err := validateBasic(stdTx); err != nil {
+			return err.Result()
+		}
 
-		// TODO: tx tags (?)
-		return newCtx, sdk.Result{}, false // continue...
+		stdSigs := stdTx.GetSignatures()
+		signerAddrs := stdTx.GetSigners()
+		signBytesList := getSignBytesList(ctx.ChainID(), stdTx, stdSigs)
+		for i, sig := range stdSigs {
+			if sig.PubKey == nil {
+				continue
+			}
+			if !bytes.Equal(sig.PubKey.Address(), signerAddrs[i]) {
+				return sdk.ErrInvalidPubKey(
+					fmt.Sprintf("PubKey does not match Signer address %v", signerAddrs[i])).Result()
+			}
+			if sdk.IsUpgrade(sdk.CanonicalSignature) {
+				if err := checkCanonicalSignature(sig.PubKey, sig.Signature); err != nil {
+					return err.Result()
+				}
+			}
+			if !sig.PubKey.VerifyBytes(signBytesList[i], sig.Signature) {
+				return sdk.ErrUnauthorized("signature verification failed").Result()
+			}
+		}
+		return sdk.Result{}
 	}
 }
 
@@ -102,8 +290,10 @@ func validateBasic(tx StdTx) (err sdk.Error) {
 		return sdk.ErrUnauthorized("wrong number of signers")
 	}
 
+	// from the TxLimitsParams upgrade the memo is checked by the
+	// TxLimitsDecorator against the params
 	memo := tx.GetMemo()
-	if len(memo) > maxMemoCharacters {
+	if !sdk.IsUpgrade(sdk.TxLimitsParams) && len(memo) > maxMemoCharacters {
 		return sdk.ErrMemoTooLarge(
 			fmt.Sprintf("maximum number of characters is %d but received %d characters",
 				maxMemoCharacters, len(memo)))
@@ -147,7 +337,7 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []sdk.Account, sigs []StdSi
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
+// verify the signature.
 // if the account doesn't have a pubkey, set it.
 func processSig(ctx sdk.Context,
 	acc sdk.Account, sig StdSignature, signBytes []byte, mode sdk.RunTxMode) (updatedAcc sdk.Account, res sdk.Result) {
@@ -160,14 +350,16 @@ func processSig(ctx sdk.Context,
 	if err != nil {
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
-	if (mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeDeliver) && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
+	// the pre-checked signatures are the ones carrying their public key
+	verified := mode == sdk.RunTxModeReCheck || mode == sdk.RunTxModeSimulate ||
+		((mode == sdk.RunTxModeCheckAfterPre || mode == sdk.RunTxModeDeliverAfterPre) && sig.PubKey != nil)
+	if !verified && sdk.IsUpgrade(sdk.CanonicalSignature) {
+		if err := checkCanonicalSignature(pubKey, sig.Signature); err != nil {
+			return nil, err.Result()
+		}
 	}
-	// increment the sequence number
-	err = acc.SetSequence(acc.GetSequence() + 1)
-	if err != nil {
-		// Handle w/ #870
-		panic(err)
+	if !verified && !pubKey.VerifyBytes(signBytes, sig.Signature) {
+		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
 
 	return acc, res
@@ -2,14 +2,18 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -335,6 +339,56 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
 }
 
+type notStdTx struct{}
+
+func (tx notStdTx) GetMsgs() []sdk.Msg { return nil }
+
+func TestAnteHandlerCustomDecorator(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		acc := mapper.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(newCoins())
+		mapper.SetAccount(ctx, acc)
+	}
+
+	// a whitelist of the signers inserted before the sequences are incremented
+	whitelist := sdk.AnteDecoratorFunc(func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode, next sdk.AnteHandler) (sdk.Context, sdk.Result, bool) {
+		for _, signer := range GetSigners(ctx) {
+			if !signer.GetAddress().Equals(addr1) {
+				return ctx, sdk.ErrUnauthorized("signer not whitelisted").Result(), true
+			}
+		}
+		return next(ctx, tx, mode)
+	})
+	decorators := DefaultAnteDecorators(mapper)
+	decorators = append(decorators[:3], whitelist, decorators[3])
+	anteHandler := sdk.ChainAnteDecorators(decorators...)
+
+	tx := newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addr1).GetSequence())
+
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr2)}, []crypto.PrivKey{priv2}, []int64{1}, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+	require.Equal(t, int64(0), mapper.GetAccount(ctx, addr2).GetSequence())
+
+	// the txs which are not StdTxs are rejected by the first decorator
+	_, res, abort := anteHandler(ctx, notStdTx{}, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInternal), res.Code)
+}
+
 func TestAnteHandlerMultiSigner(t *testing.T) {
 	// setup
 	ms, capKey, _ := setupMultiStore()
@@ -514,6 +568,113 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	require.Nil(t, acc2.GetPubKey())
 }
 
+func TestPreChecker(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	preChecker := NewPreChecker()
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	ctx = ctx.WithBlockHeight(1)
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+
+	// set the account and its public key
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	tx := newTestTx(ctx, msgs, privs, accnums, []int64{0})
+	require.True(t, preChecker(ctx, nil, tx).IsOK())
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliverAfterPre)
+
+	// invalid signatures carrying their public key are rejected
+	tx = newTestTxWithSignBytes(msgs, privs, accnums, []int64{1}, []byte("invalid sign bytes"), "")
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), preChecker(ctx, nil, tx).Code)
+	tx = newTestTx(ctx, msgs, []crypto.PrivKey{priv2}, accnums, []int64{1})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidPubKey), preChecker(ctx, nil, tx).Code)
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr2)}, privs, accnums, []int64{1})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidPubKey), preChecker(ctx, nil, tx).Code)
+
+	// the signatures without public key are left to the ante handler
+	tx = newTestTxWithSignBytes(msgs, privs, accnums, []int64{1}, []byte("invalid sign bytes"), "")
+	tx.(StdTx).GetSignatures()[0].PubKey = nil
+	require.True(t, preChecker(ctx, nil, tx).IsOK())
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheckAfterPre, sdk.CodeUnauthorized)
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1})
+	tx.(StdTx).GetSignatures()[0].PubKey = nil
+	require.True(t, preChecker(ctx, nil, tx).IsOK())
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeCheckAfterPre)
+}
+
+func TestAnteHandlerTxLimits(t *testing.T) {
+	// setup
+	db := dbm.NewMemDB()
+	capKey := sdk.NewKVStoreKey("capkey")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	cdc.RegisterConcrete(&sdk.TestMsg{}, "test/TestMsg", nil)
+	codec.RegisterCrypto(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+	paramSpace := paramsKeeper.Subspace(DefaultParamspace)
+	anteHandler := NewAnteHandlerWithParams(mapper, paramSpace)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, capKey))
+	ctx = ctx.WithBlockHeight(1)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	longMemo := strings.Repeat("m", maxMemoCharacters+1)
+
+	// before the upgrade the memo has the constant limit
+	tx := newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, longMemo)
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeMemoTooLarge)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.TxLimitsParams, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+
+	// the default params apply until the params are set
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeMemoTooLarge)
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr1), newTestMsg(addr1)}, privs, accnums, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeTooManyMsgs)
+
+	// the params change the limits
+	SetParams(ctx, paramSpace, Params{MaxTxBytes: 300, MaxMsgsPerTx: 2, MaxMemoCharacters: 200})
+	require.Equal(t, Params{MaxTxBytes: 300, MaxMsgsPerTx: 2, MaxMemoCharacters: 200}, GetParams(ctx, paramSpace))
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, longMemo)
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{1}, strings.Repeat("m", 201))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeMemoTooLarge)
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{1}, strings.Repeat("m", 190))
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeTxTooLarge)
+
+	// a limit of 0 is no limit
+	SetParams(ctx, paramSpace, Params{})
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{1}, strings.Repeat("m", 1000))
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+}
+
 func TestProcessPubKey(t *testing.T) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/multisig"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+)
+
+// GetMultiSignCommand returns the multisign command
+func GetMultiSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisign <file> <name> <signature>...",
+		Short: "Combine the signatures of the participants of a multisig account",
+		Long: `Sign a transaction on behalf of the multisig key <name>.
+Read a transaction from <file>, or from standard input if <file> is a dash (-),
+combine the signatures made by the participants of the multisig with
+sign --multisig and stored in the <signature> files, append the result to the
+transaction and print its JSON encoding.
+
+The --offline flag skips the account number and sequence lookups of the
+multisig account, which must then be set with --account-number and --sequence.`,
+		RunE: makeMultiSignCmd(codec, decoder),
+		Args: cobra.MinimumNArgs(3),
+	}
+	cmd.Flags().String(flagOutputDocument, "", "Write the signed transaction to the given file instead of STDOUT")
+	return cmd
+}
+
+func makeMultiSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
+		if err != nil {
+			return err
+		}
+
+		keybase, err := keys.GetKeyBase()
+		if err != nil {
+			return err
+		}
+		info, err := keybase.Get(args[1])
+		if err != nil {
+			return err
+		}
+		multisigPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+		if !ok {
+			return fmt.Errorf("%s is not a multisig key", args[1])
+		}
+
+		cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+		txBldr := authtxb.NewTxBuilderFromCLI()
+		if len(txBldr.ChainID) == 0 {
+			return fmt.Errorf("chain-id is missing")
+		}
+		if !viper.GetBool(client.FlagOffline) {
+			txBldr, err = utils.PopulateAccountFromAddr(txBldr, cliCtx, info.GetAddress())
+			if err != nil {
+				return err
+			}
+		}
+
+		sigs := make([]auth.StdSignature, 0, len(args)-2)
+		for _, filename := range args[2:] {
+			bz, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			var sig auth.StdSignature
+			if err := cdc.UnmarshalJSON(bz, &sig); err != nil {
+				return err
+			}
+			sigs = append(sigs, sig)
+		}
+
+		newTx, err := txBldr.MultiSignStdTx(multisigPub, stdTx, sigs)
+		if err != nil {
+			return err
+		}
+		return printSigned(cdc, cliCtx, newTx)
+	}
+}
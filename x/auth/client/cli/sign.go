@@ -16,9 +16,11 @@ import (
 )
 
 const (
-	flagAppend    = "append"
-	flagPrintSigs = "print-sigs"
-	flagOffline   = "offline"
+	flagAppend         = "append"
+	flagPrintSigs      = "print-sigs"
+	flagOffline        = "offline"
+	flagOutputDocument = "output-document"
+	flagMultisig       = "multisig"
 )
 
 // GetSignCommand returns the sign command
@@ -27,23 +29,33 @@ func GetSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Comm
 		Use:   "sign <file>",
 		Short: "Sign transactions generated offline",
 		Long: `Sign transactions created with the --generate-only flag.
-Read a transaction from <file>, sign it, and print its JSON encoding.
+Read a transaction from <file>, or from standard input if <file> is a dash (-),
+sign it, and print its JSON encoding. The signed transaction is broadcast with
+the broadcast command.
 
 The --offline flag makes sure that the client will not reach out to the local cache.
-Thus account number or sequence number lookups will not be performed and it is
-recommended to set such parameters manually.`,
+Thus account number or sequence number lookups will not be performed and they
+must be set manually with --account-number and --sequence. This lets the
+transaction be signed on an air-gapped machine, including with a Ledger key.
+
+With --multisig, the key signs on behalf of the given multisig account it takes
+part in and only the signature is printed, using the account number and
+sequence of the multisig account. The signatures of the participants are
+combined with the multisign command.`,
 		RunE: makeSignCmd(codec, decoder),
 		Args: cobra.ExactArgs(1),
 	}
 	cmd.Flags().String(client.FlagName, "", "Name of private key with which to sign")
 	cmd.Flags().Bool(flagAppend, true, "Append the signature to the existing ones. If disabled, old signatures would be overwritten")
 	cmd.Flags().Bool(flagPrintSigs, false, "Print the addresses that must sign the transaction and those who have already signed it, then exit")
+	cmd.Flags().String(flagOutputDocument, "", "Write the signed transaction to the given file instead of STDOUT")
+	cmd.Flags().String(flagMultisig, "", "Address of the multisig account on behalf of which the transaction is signed")
 	return cmd
 }
 
 func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
-		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+		stdTx, err := utils.ReadStdTxFromFile(cdc, args[0])
 		if err != nil {
 			return
 		}
@@ -60,24 +72,46 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 			return fmt.Errorf("chain-id is missing")
 		}
 
-		newTx, err := utils.SignStdTx(txBldr, cliCtx, name, stdTx, viper.GetBool(flagAppend), viper.GetBool(flagOffline))
-		if err != nil {
-			return err
-		}
-		var json []byte
-		if cliCtx.Indent {
-			json, err = cdc.MarshalJSONIndent(newTx, "", "  ")
+		// a participant of a multisig only outputs its signature
+		var signed interface{}
+		if multisigAddrStr := viper.GetString(flagMultisig); multisigAddrStr != "" {
+			multisigAddr, err := sdk.AccAddressFromBech32(multisigAddrStr)
+			if err != nil {
+				return err
+			}
+			signed, err = utils.SignStdTxWithSignerAddress(txBldr, cliCtx, multisigAddr, name, stdTx, viper.GetBool(flagOffline))
+			if err != nil {
+				return err
+			}
 		} else {
-			json, err = cdc.MarshalJSON(newTx)
-		}
-		if err != nil {
-			return err
+			signed, err = utils.SignStdTx(txBldr, cliCtx, name, stdTx, viper.GetBool(flagAppend), viper.GetBool(flagOffline))
+			if err != nil {
+				return err
+			}
 		}
-		fmt.Printf("%s\n", json)
-		return
+		return printSigned(cdc, cliCtx, signed)
 	}
 }
 
+// printSigned writes the JSON encoding of a signed transaction or signature to
+// the --output-document file, or to STDOUT.
+func printSigned(cdc *amino.Codec, cliCtx context.CLIContext, signed interface{}) (err error) {
+	var json []byte
+	if cliCtx.Indent {
+		json, err = cdc.MarshalJSONIndent(signed, "", "  ")
+	} else {
+		json, err = cdc.MarshalJSON(signed)
+	}
+	if err != nil {
+		return err
+	}
+	if output := viper.GetString(flagOutputDocument); output != "" {
+		return os.WriteFile(output, append(json, '\n'), 0644)
+	}
+	fmt.Printf("%s\n", json)
+	return nil
+}
+
 func printSignatures(stdTx auth.StdTx) {
 	fmt.Println("Signers:")
 	for i, signer := range stdTx.GetSigners() {
@@ -90,14 +124,3 @@ func printSignatures(stdTx auth.StdTx) {
 	}
 	return
 }
-
-func readAndUnmarshalStdTx(cdc *amino.Codec, filename string) (stdTx auth.StdTx, err error) {
-	var bytes []byte
-	if bytes, err = os.ReadFile(filename); err != nil {
-		return
-	}
-	if err = cdc.UnmarshalJSON(bytes, &stdTx); err != nil {
-		return
-	}
-	return
-}
@@ -67,7 +67,8 @@ func QueryAccountRequestHandlerFn(
 	}
 }
 
-// query accountREST Handler
+// query the balances REST Handler, the amounts are displayed with
+// ?display=true
 func QueryBalancesRequestHandlerFn(
 	storeName string, cdc *codec.Codec,
 	decoder auth.AccountDecoder, cliCtx context.CLIContext,
@@ -102,6 +103,11 @@ func QueryBalancesRequestHandlerFn(
 			return
 		}
 
+		// the amounts are of the smallest unit unless displayed, e.g. "1.50000000"
+		if r.URL.Query().Get("display") == "true" {
+			utils.PostProcessResponse(w, cdc, account.GetCoins().ToDisplayCoins(), cliCtx.Indent)
+			return
+		}
 		utils.PostProcessResponse(w, cdc, account.GetCoins(), cliCtx.Indent)
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/crypto/multisig"
 )
 
 // TxBuilder implements a transaction context created in SDK modules.
@@ -86,6 +87,17 @@ func (bldr TxBuilder) Build(msgs []sdk.Msg) (StdSignMsg, error) {
 	}, nil
 }
 
+// BuildUnsignedStdTx builds a transaction of msgs without any signature, to be
+// signed later with SignStdTx, possibly on another machine.
+func (bldr TxBuilder) BuildUnsignedStdTx(msgs []sdk.Msg) (auth.StdTx, error) {
+	msg, err := bldr.Build(msgs)
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+
+	return auth.NewStdTx(msg.Msgs, nil, msg.Memo, msg.Source, msg.Data), nil
+}
+
 // Sign signs a transaction given a name, passphrase, and a single message to
 // signed. An error is returned if signing fails.
 func (bldr TxBuilder) Sign(name, passphrase string, msg StdSignMsg) ([]byte, error) {
@@ -135,10 +147,10 @@ func (bldr TxBuilder) BuildWithPubKey(name string, msgs []sdk.Msg) ([]byte, erro
 	return bldr.Codec.MarshalBinaryLengthPrefixed(auth.NewStdTx(msg.Msgs, sigs, msg.Memo, msg.Source, msg.Data))
 }
 
-// SignStdTx appends a signature to a StdTx and returns a copy of a it. If append
-// is false, it replaces the signatures already attached with the new signature.
-func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appendSig bool) (signedStdTx auth.StdTx, err error) {
-	stdSignature, err := MakeSignature(name, passphrase, StdSignMsg{
+// StdSignMsgForTx returns the message signing stdTx with the chain ID, account
+// number and sequence of the builder.
+func (bldr TxBuilder) StdSignMsgForTx(stdTx auth.StdTx) StdSignMsg {
+	return StdSignMsg{
 		ChainID:       bldr.ChainID,
 		AccountNumber: bldr.AccountNumber,
 		Sequence:      bldr.Sequence,
@@ -146,7 +158,13 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 		Memo:          stdTx.GetMemo(),
 		Source:        stdTx.GetSource(),
 		Data:          stdTx.GetData(),
-	})
+	}
+}
+
+// SignStdTx appends a signature to a StdTx and returns a copy of a it. If append
+// is false, it replaces the signatures already attached with the new signature.
+func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appendSig bool) (signedStdTx auth.StdTx, err error) {
+	stdSignature, err := MakeSignature(name, passphrase, bldr.StdSignMsgForTx(stdTx))
 	if err != nil {
 		return
 	}
@@ -161,6 +179,37 @@ func (bldr TxBuilder) SignStdTx(name, passphrase string, stdTx auth.StdTx, appen
 	return
 }
 
+// MultiSignStdTx combines sigs, made by the participants of multisigPub over
+// the sign message of the builder, into the signature of the multisig account
+// and appends it to stdTx. It returns an error if a signature is invalid or
+// made by another key, or if there are fewer signatures than the threshold.
+func (bldr TxBuilder) MultiSignStdTx(multisigPub multisig.PubKeyMultisigThreshold, stdTx auth.StdTx, sigs []auth.StdSignature) (signedStdTx auth.StdTx, err error) {
+	if len(sigs) < int(multisigPub.K) {
+		return signedStdTx, errors.Errorf("multisig requires %d signatures, got %d", multisigPub.K, len(sigs))
+	}
+
+	signBytes := bldr.StdSignMsgForTx(stdTx).Bytes()
+	multiSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+	for i, sig := range sigs {
+		if sig.PubKey == nil || !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return signedStdTx, errors.Errorf("signature %d is invalid for the transaction", i)
+		}
+		if err = multiSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys); err != nil {
+			return
+		}
+	}
+
+	stdSignature := auth.StdSignature{
+		AccountNumber: bldr.AccountNumber,
+		Sequence:      bldr.Sequence,
+		PubKey:        multisigPub,
+		Signature:     multiSig.Marshal(),
+	}
+	sigsWithMulti := append(append([]auth.StdSignature{}, stdTx.GetSignatures()...), stdSignature)
+	signedStdTx = auth.NewStdTx(stdTx.GetMsgs(), sigsWithMulti, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
+	return
+}
+
 // MakeSignature builds a StdSignature given key name, passphrase, and a StdSignMsg.
 func MakeSignature(name, passphrase string, msg StdSignMsg) (sig auth.StdSignature, err error) {
 	keybase, err := keys.GetKeyBase()
@@ -5,10 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 var (
@@ -69,3 +73,59 @@ func TestTxBuilderBuild(t *testing.T) {
 		}
 	}
 }
+
+func TestTxBuilderBuildUnsignedStdTx(t *testing.T) {
+	msgs := []sdk.Msg{sdk.NewTestMsg(addr)}
+	bldr := TxBuilder{Codec: codec.New(), ChainID: "test-chain", Memo: "hello", Source: 1}
+
+	stdTx, err := bldr.BuildUnsignedStdTx(msgs)
+	require.NoError(t, err)
+	require.Equal(t, msgs, stdTx.GetMsgs())
+	require.Equal(t, "hello", stdTx.GetMemo())
+	require.Equal(t, int64(1), stdTx.GetSource())
+	require.Empty(t, stdTx.GetSignatures())
+
+	_, err = TxBuilder{}.BuildUnsignedStdTx(msgs)
+	require.Error(t, err)
+}
+
+func TestTxBuilderMultiSignStdTx(t *testing.T) {
+	privs := []secp256k1.PrivKeySecp256k1{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	multisigPub := multisig.NewPubKeyMultisigThreshold(2, pubs).(multisig.PubKeyMultisigThreshold)
+	multisigAddr := sdk.AccAddress(multisigPub.Address())
+
+	bldr := TxBuilder{Codec: codec.New(), ChainID: "test-chain", AccountNumber: 3, Sequence: 7}
+	stdTx, err := bldr.BuildUnsignedStdTx([]sdk.Msg{sdk.NewTestMsg(multisigAddr)})
+	require.NoError(t, err)
+	signBytes := bldr.StdSignMsgForTx(stdTx).Bytes()
+	sign := func(priv secp256k1.PrivKeySecp256k1) auth.StdSignature {
+		sig, err := priv.Sign(signBytes)
+		require.NoError(t, err)
+		return auth.StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: 3, Sequence: 7}
+	}
+
+	// the participants may sign in any order
+	signed, err := bldr.MultiSignStdTx(multisigPub, stdTx, []auth.StdSignature{sign(privs[2]), sign(privs[0])})
+	require.NoError(t, err)
+	require.Len(t, signed.GetSignatures(), 1)
+	sig := signed.GetSignatures()[0]
+	require.Equal(t, multisigPub, sig.PubKey)
+	require.Equal(t, int64(3), sig.AccountNumber)
+	require.Equal(t, int64(7), sig.Sequence)
+	require.True(t, multisigPub.VerifyBytes(signBytes, sig.Signature))
+	require.Empty(t, stdTx.GetSignatures())
+
+	// below the threshold
+	_, err = bldr.MultiSignStdTx(multisigPub, stdTx, []auth.StdSignature{sign(privs[0])})
+	require.Error(t, err)
+
+	// signed over other bytes
+	other := bldr.WithSequence(8)
+	_, err = other.MultiSignStdTx(multisigPub, stdTx, []auth.StdSignature{sign(privs[0]), sign(privs[1])})
+	require.Error(t, err)
+
+	// by a key not part of the multisig
+	_, err = bldr.MultiSignStdTx(multisigPub, stdTx, []auth.StdSignature{sign(privs[0]), sign(secp256k1.GenPrivKey())})
+	require.Error(t, err)
+}
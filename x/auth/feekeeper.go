@@ -9,6 +9,13 @@ var (
 	collectedFeesKey = []byte("collectedFees")
 )
 
+// FeeCollectorName is the name of the module account holding the collected
+// fees from the SupplyTracking upgrade, they are burned once distributed.
+const FeeCollectorName = "FeeCollector"
+
+// FeeCollectorAddr is the address of the fee collector module account.
+var FeeCollectorAddr = NewModuleAddress(FeeCollectorName)
+
 // This FeeCollectionKeeper handles collection of fees in the anteHandler
 // and setting of MinFees for different fee tokens
 type FeeCollectionKeeper struct {
@@ -18,17 +25,30 @@ type FeeCollectionKeeper struct {
 
 	// The codec codec for binary encoding/decoding of accounts.
 	cdc *codec.Codec
+
+	// The fees are held by the fee collector account from the SupplyTracking
+	// upgrade, so that they are part of the total supply.
+	am AccountKeeper
 }
 
-func NewFeeCollectionKeeper(cdc *codec.Codec, key sdk.StoreKey) FeeCollectionKeeper {
+func NewFeeCollectionKeeper(cdc *codec.Codec, key sdk.StoreKey, am AccountKeeper) FeeCollectionKeeper {
 	return FeeCollectionKeeper{
 		key: key,
 		cdc: cdc,
+		am:  am,
 	}
 }
 
 // retrieves the collected fee pool
 func (fck FeeCollectionKeeper) GetCollectedFees(ctx sdk.Context) sdk.Coins {
+	if sdk.IsUpgrade(sdk.SupplyTracking) {
+		acc := fck.am.GetAccount(ctx, FeeCollectorAddr)
+		if acc == nil {
+			return sdk.Coins{}
+		}
+		return acc.GetCoins()
+	}
+
 	store := ctx.KVStore(fck.key)
 	bz := store.Get(collectedFeesKey)
 	if bz == nil {
@@ -41,6 +61,15 @@ func (fck FeeCollectionKeeper) GetCollectedFees(ctx sdk.Context) sdk.Coins {
 }
 
 func (fck FeeCollectionKeeper) setCollectedFees(ctx sdk.Context, coins sdk.Coins) {
+	if sdk.IsUpgrade(sdk.SupplyTracking) {
+		acc := fck.am.GetModuleAccount(ctx, FeeCollectorName, Burner)
+		if err := acc.SetCoins(coins); err != nil {
+			panic(err)
+		}
+		fck.am.SetAccount(ctx, acc)
+		return
+	}
+
 	bz := fck.cdc.MustMarshalBinaryLengthPrefixed(coins)
 	store := ctx.KVStore(fck.key)
 	store.Set(collectedFeesKey, bz)
@@ -58,3 +87,20 @@ func (fck FeeCollectionKeeper) AddCollectedFees(ctx sdk.Context, coins sdk.Coins
 func (fck FeeCollectionKeeper) ClearCollectedFees(ctx sdk.Context) {
 	fck.setCollectedFees(ctx, sdk.Coins{})
 }
+
+// MigrateCollectedFees moves the fees collected in the fee store to the fee
+// collector module account, at the height of the SupplyTracking upgrade.
+func (fck FeeCollectionKeeper) MigrateCollectedFees(ctx sdk.Context) {
+	store := ctx.KVStore(fck.key)
+	var fees sdk.Coins
+	if bz := store.Get(collectedFeesKey); bz != nil {
+		fck.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &fees)
+		store.Delete(collectedFeesKey)
+	}
+
+	acc := fck.am.GetModuleAccount(ctx, FeeCollectorName, Burner)
+	if err := acc.SetCoins(acc.GetCoins().Plus(fees)); err != nil {
+		panic(err)
+	}
+	fck.am.SetAccount(ctx, acc)
+}
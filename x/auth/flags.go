@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The flags of an account, a bit mask checked by the bank send handler once
+// the AccountFlags upgrade is active.
+const (
+	// FlagTransfersDisabled holds the coins of the account, it cannot send
+	// them. It is set by governance or by the issuer of an asset.
+	FlagTransfersDisabled uint64 = 1 << 0
+	// FlagMemoRequired rejects the txs sending coins to the account without a
+	// memo, e.g. for the deposit addresses of an exchange.
+	FlagMemoRequired uint64 = 1 << 1
+
+	// OwnerFlags are the flags an account may set on itself.
+	OwnerFlags = FlagMemoRequired
+	// AllFlags are all the known flags.
+	AllFlags = FlagTransfersDisabled | FlagMemoRequired
+)
+
+// FlaggedAccount is an account with flags, BaseAccount and the accounts
+// embedding it are.
+type FlaggedAccount interface {
+	sdk.Account
+	GetFlags() uint64
+	SetFlags(flags uint64)
+}
+
+// ValidateFlags returns an error if flags has unknown bits.
+func ValidateFlags(flags uint64) sdk.Error {
+	if flags&^AllFlags != 0 {
+		return sdk.ErrInvalidAccountFlags(fmt.Sprintf("unknown account flags %#x", flags&^AllFlags))
+	}
+	return nil
+}
+
+// GetFlags returns the flags of the account at addr, an account without
+// flags has none.
+func (am AccountKeeper) GetFlags(ctx sdk.Context, addr sdk.AccAddress) (uint64, sdk.Error) {
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return 0, sdk.ErrUnknownAddress(addr.String())
+	}
+	if flagged, ok := acc.(FlaggedAccount); ok {
+		return flagged.GetFlags(), nil
+	}
+	return 0, nil
+}
+
+// SetFlags sets the flags of the account at addr. It is the API for the
+// modules controlling the holds of the accounts, e.g. the issuers of assets.
+func (am AccountKeeper) SetFlags(ctx sdk.Context, addr sdk.AccAddress, flags uint64) sdk.Error {
+	if err := ValidateFlags(flags); err != nil {
+		return err
+	}
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.ErrUnknownAddress(addr.String())
+	}
+	flagged, ok := acc.(FlaggedAccount)
+	if !ok {
+		return sdk.ErrInvalidAccountFlags(fmt.Sprintf("account %s does not support flags", addr))
+	}
+	flagged.SetFlags(flags)
+	am.SetAccount(ctx, flagged)
+	return nil
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 
@@ -75,6 +76,27 @@ func (am AccountKeeper) NewAccount(ctx sdk.Context, acc sdk.Account) sdk.Account
 	return acc
 }
 
+// GetModuleAccount returns the module account name, creating it with
+// permissions if there is none. A base account created before at the address
+// of name, e.g. by sending coins to it, is turned into the module account
+// with its coins and account number.
+func (am AccountKeeper) GetModuleAccount(ctx sdk.Context, name string, permissions ...string) *ModuleAccount {
+	switch acc := am.GetAccount(ctx, NewModuleAddress(name)).(type) {
+	case *ModuleAccount:
+		return acc
+	case *BaseAccount:
+		moduleAcc := &ModuleAccount{BaseAccount: acc, Name: name, Permissions: permissions}
+		am.SetAccount(ctx, moduleAcc)
+		return moduleAcc
+	case nil:
+		moduleAcc := am.NewAccount(ctx, NewModuleAccount(name, permissions...)).(*ModuleAccount)
+		am.SetAccount(ctx, moduleAcc)
+		return moduleAcc
+	default:
+		panic(fmt.Sprintf("account %s of module %s is a %T", acc.GetAddress(), name, acc))
+	}
+}
+
 // Turn an address to key used to get it from the account store
 func AddressStoreKey(addr sdk.AccAddress) []byte {
 	return append([]byte("account:"), addr.Bytes()...)
@@ -105,21 +127,41 @@ func (am AccountKeeper) RemoveAccount(ctx sdk.Context, acc sdk.Account) {
 	cache.Delete(addr)
 }
 
-// Implements sdk.AccountKeeper.
+// Implements sdk.AccountKeeper. The accounts are iterated by address, with the
+// mutations of the block not yet written to the store.
 func (am AccountKeeper) IterateAccounts(ctx sdk.Context, process func(sdk.Account) (stop bool)) {
+	var dirty []cachedAccount
+	if cache, ok := ctx.AccountCache().(*accountCache); ok {
+		dirty = cache.dirtyAccounts()
+	}
+
 	store := ctx.KVStore(am.key)
-	iter := sdk.KVStorePrefixIterator(store, []byte("account:"))
+	prefix := []byte("account:")
+	iter := sdk.KVStorePrefixIterator(store, prefix)
 	defer iter.Close()
 	for {
-		if !iter.Valid() {
+		var acc sdk.Account
+		switch {
+		case len(dirty) != 0 && (!iter.Valid() || dirty[0].addr <= string(iter.Key()[len(prefix):])):
+			if iter.Valid() && dirty[0].addr == string(iter.Key()[len(prefix):]) {
+				iter.Next()
+			}
+			cached := dirty[0]
+			dirty = dirty[1:]
+			if cached.acc == nil {
+				// deleted
+				continue
+			}
+			acc = cached.acc.Clone()
+		case iter.Valid():
+			acc = am.decodeAccount(iter.Value())
+			iter.Next()
+		default:
 			return
 		}
-		val := iter.Value()
-		acc := am.decodeAccount(val)
 		if process(acc) {
 			return
 		}
-		iter.Next()
 	}
 }
 
@@ -254,7 +296,7 @@ func (ac *accountStoreCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
 }
 
 func (ac *accountStoreCache) Delete(addr sdk.AccAddress) {
-	ac.setAccountToCache(addr, nil)
+	ac.cache.Remove(string(addr))
 	ac.store.Delete(AddressStoreKey(addr))
 }
 
@@ -353,6 +395,38 @@ func (ac *accountCache) Write() {
 	ac.cache = sync.Map{}
 }
 
+// an account of the cache, nil if deleted
+type cachedAccount struct {
+	addr string
+	acc  sdk.Account
+}
+
+// dirtyAccounts returns the accounts mutated in the cache and its parent
+// caches, sorted by address.
+func (ac *accountCache) dirtyAccounts() []cachedAccount {
+	accounts := make(map[string]sdk.Account)
+	if parent, ok := ac.parent.(*accountCache); ok {
+		for _, cached := range parent.dirtyAccounts() {
+			accounts[cached.addr] = cached.acc
+		}
+	}
+	ac.cache.Range(func(key, value interface{}) bool {
+		if cacheValue := value.(cValue); cacheValue.dirty {
+			accounts[key.(string)] = cacheValue.acc
+		}
+		return true
+	})
+
+	dirty := make([]cachedAccount, 0, len(accounts))
+	for addr, acc := range accounts {
+		dirty = append(dirty, cachedAccount{addr: addr, acc: acc})
+	}
+	sort.Slice(dirty, func(i, j int) bool {
+		return dirty[i].addr < dirty[j].addr
+	})
+	return dirty
+}
+
 func (ac *accountCache) getAccountFromCache(addr sdk.AccAddress) (acc sdk.Account) {
 	cacheVal, ok := ac.cache.Load(string(addr))
 	if !ok {
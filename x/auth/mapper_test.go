@@ -106,6 +106,142 @@ func TestAccountMapperRemoveAccount(t *testing.T) {
 	require.Equal(t, accSeq2, acc2.GetSequence())
 }
 
+func TestAccountMapperIterateAccounts(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addrs := []sdk.AccAddress{[]byte("addr1"), []byte("addr2"), []byte("addr3"), []byte("addr4")}
+	setSequence := func(ctx sdk.Context, addr sdk.AccAddress, sequence int64) {
+		acc := mapper.GetAccount(ctx, addr)
+		if acc == nil {
+			acc = mapper.NewAccountWithAddress(ctx, addr)
+		}
+		acc.SetSequence(sequence)
+		mapper.SetAccount(ctx, acc)
+	}
+	iterate := func(ctx sdk.Context) (sequences map[string]int64, order []string) {
+		sequences = make(map[string]int64)
+		mapper.IterateAccounts(ctx, func(acc sdk.Account) bool {
+			sequences[string(acc.GetAddress())] = acc.GetSequence()
+			order = append(order, string(acc.GetAddress()))
+			return false
+		})
+		return sequences, order
+	}
+
+	setSequence(ctx, addrs[0], 1)
+	setSequence(ctx, addrs[2], 3)
+	accountCache.Write()
+
+	// the mutations of the block and of the tx are not written yet
+	setSequence(ctx, addrs[1], 2)
+	setSequence(ctx, addrs[2], 30)
+	mapper.RemoveAccount(ctx, mapper.GetAccount(ctx, addrs[0]))
+	txCtx := ctx.WithAccountCache(accountCache.Cache())
+	setSequence(txCtx, addrs[3], 4)
+	setSequence(txCtx, addrs[1], 20)
+
+	sequences, order := iterate(txCtx)
+	require.Equal(t, map[string]int64{"addr2": 20, "addr3": 30, "addr4": 4}, sequences)
+	require.Equal(t, []string{"addr2", "addr3", "addr4"}, order)
+	sequences, _ = iterate(ctx)
+	require.Equal(t, map[string]int64{"addr2": 2, "addr3": 30}, sequences)
+
+	// the same accounts once written
+	txCtx.AccountCache().Write()
+	accountCache.Write()
+	sequences, order = iterate(ctx)
+	require.Equal(t, map[string]int64{"addr2": 20, "addr3": 30, "addr4": 4}, sequences)
+	require.Equal(t, []string{"addr2", "addr3", "addr4"}, order)
+}
+
+func TestAccountMapperGetModuleAccount(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	// make context and mapper
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	// the module account is created on first use
+	moduleAcc := mapper.GetModuleAccount(ctx, "module", Burner)
+	require.Equal(t, "module", moduleAcc.GetName())
+	require.Equal(t, NewModuleAddress("module"), moduleAcc.GetAddress())
+	require.True(t, moduleAcc.HasPermission(Burner))
+	require.False(t, moduleAcc.HasPermission(Minter))
+	priv, _ := privAndAddr()
+	require.Error(t, moduleAcc.SetPubKey(priv.PubKey()))
+
+	// and returned with its coins and permissions afterwards
+	coins := sdk.Coins{sdk.NewCoin("foocoin", 10)}
+	require.NoError(t, moduleAcc.SetCoins(coins))
+	mapper.SetAccount(ctx, moduleAcc)
+	acc := mapper.GetModuleAccount(ctx, "module", Minter)
+	require.Equal(t, coins, acc.GetCoins())
+	require.Equal(t, moduleAcc.GetAccountNumber(), acc.GetAccountNumber())
+	require.Equal(t, []string{Burner}, acc.Permissions)
+
+	// an account created before at the module address becomes the module account
+	addr := NewModuleAddress("other")
+	baseAcc := mapper.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, baseAcc.SetCoins(coins))
+	mapper.SetAccount(ctx, baseAcc)
+	acc = mapper.GetModuleAccount(ctx, "other", Minter)
+	require.Equal(t, coins, acc.GetCoins())
+	require.Equal(t, baseAcc.GetAccountNumber(), acc.GetAccountNumber())
+	require.True(t, acc.HasPermission(Minter))
+	stored, ok := mapper.GetAccount(ctx, addr).(*ModuleAccount)
+	require.True(t, ok)
+	require.Equal(t, "other", stored.GetName())
+}
+
+func TestAccountMapperFlags(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+
+	// make context and mapper
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	_, err := mapper.GetFlags(ctx, addr)
+	require.Error(t, err)
+	require.Error(t, mapper.SetFlags(ctx, addr, FlagMemoRequired))
+
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addr))
+	flags, err := mapper.GetFlags(ctx, addr)
+	require.NoError(t, err)
+	require.Zero(t, flags)
+	require.NoError(t, mapper.SetFlags(ctx, addr, FlagTransfersDisabled|FlagMemoRequired))
+	flags, err = mapper.GetFlags(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, FlagTransfersDisabled|FlagMemoRequired, flags)
+	require.Equal(t, flags, mapper.GetAccount(ctx, addr).Clone().(FlaggedAccount).GetFlags())
+
+	// the unknown flags are rejected
+	err = mapper.SetFlags(ctx, addr, 1<<10)
+	require.Error(t, err)
+	require.Equal(t, sdk.CodeInvalidAccountFlags, err.Code())
+
+	// the accounts embedding BaseAccount have flags
+	moduleAddr := mapper.GetModuleAccount(ctx, "module").GetAddress()
+	require.NoError(t, mapper.SetFlags(ctx, moduleAddr, FlagTransfersDisabled))
+	flags, err = mapper.GetFlags(ctx, moduleAddr)
+	require.NoError(t, err)
+	require.Equal(t, FlagTransfersDisabled, flags)
+}
+
 func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
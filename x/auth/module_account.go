@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ sdk.Account = (*ModuleAccount)(nil)
+
+// NewModuleAddress returns the address of the module account name, the hash of
+// the name, so that no private key is known for it.
+func NewModuleAddress(name string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(name)))
+}
+
+// The permissions of the module accounts on the total supply.
+const (
+	Minter = "minter"
+	Burner = "burner"
+)
+
+// ModuleAccount is an account holding the coins of a module, e.g. the deposits
+// of the proposals or the delegated coins, instead of the module tracking them
+// in its own store. It has no public key and can't sign txs, and only mints
+// or burns coins if it has the permission to.
+type ModuleAccount struct {
+	*BaseAccount
+
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// NewModuleAccount returns the module account name with permissions.
+func NewModuleAccount(name string, permissions ...string) *ModuleAccount {
+	baseAcc := NewBaseAccountWithAddress(NewModuleAddress(name))
+	return &ModuleAccount{
+		BaseAccount: &baseAcc,
+		Name:        name,
+		Permissions: permissions,
+	}
+}
+
+// GetName returns the name of the module account.
+func (ma ModuleAccount) GetName() string {
+	return ma.Name
+}
+
+// HasPermission returns whether the module account has permission.
+func (ma ModuleAccount) HasPermission(permission string) bool {
+	for _, perm := range ma.Permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Implements sdk.Account, module accounts can't have a public key.
+func (ma *ModuleAccount) SetPubKey(pubKey crypto.PubKey) error {
+	return errors.New("cannot set the public key of a module account")
+}
+
+// Implements sdk.Account.
+func (ma *ModuleAccount) Clone() sdk.Account {
+	return &ModuleAccount{
+		BaseAccount: ma.BaseAccount.Clone().(*BaseAccount),
+		Name:        ma.Name,
+		Permissions: append([]string(nil), ma.Permissions...),
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// DefaultParamspace is the subspace of the auth params
+const DefaultParamspace = "auth"
+
+// The default limits of the txs, the limits before the TxLimitsParams upgrade
+const (
+	DefaultMaxTxBytes        int64 = 1024 * 1024
+	DefaultMaxMsgsPerTx      int64 = 1
+	DefaultMaxMemoCharacters int64 = maxMemoCharacters
+)
+
+// nolint - keys of the auth params
+var (
+	KeyMaxTxBytes        = []byte("MaxTxBytes")
+	KeyMaxMsgsPerTx      = []byte("MaxMsgsPerTx")
+	KeyMaxMemoCharacters = []byte("MaxMemoCharacters")
+)
+
+// ParamTypeTable for the auth module
+func ParamTypeTable() params.TypeTable {
+	return params.NewTypeTable().RegisterParamSet(&Params{})
+}
+
+// Params are the limits of the txs checked by the ante handler, they are set
+// by params change proposals. A limit of 0 is no limit.
+type Params struct {
+	MaxTxBytes        int64 `json:"max_tx_bytes"`        // of the amino encoding of the tx
+	MaxMsgsPerTx      int64 `json:"max_msgs_per_tx"`     // the txs have at least one msg
+	MaxMemoCharacters int64 `json:"max_memo_characters"` // bytes of the memo
+}
+
+// Implements params.ParamStruct
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{KeyMaxTxBytes, &p.MaxTxBytes},
+		{KeyMaxMsgsPerTx, &p.MaxMsgsPerTx},
+		{KeyMaxMemoCharacters, &p.MaxMemoCharacters},
+	}
+}
+
+// DefaultParams returns the default auth params
+func DefaultParams() Params {
+	return Params{
+		MaxTxBytes:        DefaultMaxTxBytes,
+		MaxMsgsPerTx:      DefaultMaxMsgsPerTx,
+		MaxMemoCharacters: DefaultMaxMemoCharacters,
+	}
+}
+
+// GetParams returns the auth params of paramSpace, the params which were
+// never set have their default values.
+func GetParams(ctx sdk.Context, paramSpace params.Subspace) Params {
+	p := DefaultParams()
+	paramSpace.GetParamSet(ctx, &p)
+	return p
+}
+
+// SetParams sets the auth params of paramSpace
+func SetParams(ctx sdk.Context, paramSpace params.Subspace, p Params) {
+	paramSpace.SetParamSet(ctx, &p)
+}
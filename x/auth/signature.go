@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const signatureLength = 64
+
+var (
+	// the order N of the secp256k1 group, and N/2
+	secp256k1Order, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	secp256k1HalfOrder = new(big.Int).Rsh(secp256k1Order, 1)
+	// the order L of the ed25519 group
+	ed25519Order, _ = new(big.Int).SetString("1000000000000000000000000000000014DEF9DEA2F79CD65812631A5CF5D3ED", 16)
+)
+
+// checkCanonicalSignature rejects the encodings of a signature which are not
+// the canonical one, so that a signed tx cannot be malleated into another tx
+// hash: the secp256k1 signatures are R||S with a low S, the ed25519 signatures
+// have a reduced S, and the signatures of a multisig are checked against the
+// keys of their signers, with no signature beyond its bit array.
+func checkCanonicalSignature(pubKey crypto.PubKey, sig []byte) sdk.Error {
+	switch pubKey := pubKey.(type) {
+	case secp256k1.PubKeySecp256k1:
+		if len(sig) != signatureLength {
+			return sdk.ErrUnauthorized(fmt.Sprintf("invalid signature length %d", len(sig)))
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if r.Sign() == 0 || r.Cmp(secp256k1Order) >= 0 || s.Sign() == 0 || s.Cmp(secp256k1Order) >= 0 {
+			return sdk.ErrUnauthorized("signature R or S is out of range")
+		}
+		if s.Cmp(secp256k1HalfOrder) > 0 {
+			return sdk.ErrUnauthorized("non-canonical signature with a high S")
+		}
+	case ed25519.PubKeyEd25519:
+		if len(sig) != signatureLength {
+			return sdk.ErrUnauthorized(fmt.Sprintf("invalid signature length %d", len(sig)))
+		}
+		// S is little endian
+		sBytes := make([]byte, 32)
+		for i := range sBytes {
+			sBytes[i] = sig[signatureLength-1-i]
+		}
+		if new(big.Int).SetBytes(sBytes).Cmp(ed25519Order) >= 0 {
+			return sdk.ErrUnauthorized("non-canonical signature with a non-reduced S")
+		}
+	case multisig.PubKeyMultisigThreshold:
+		var multiSig multisig.Multisignature
+		if err := codec.Cdc.UnmarshalBinaryBare(sig, &multiSig); err != nil {
+			return sdk.ErrUnauthorized("invalid multisignature")
+		}
+		if multiSig.BitArray == nil || multiSig.BitArray.Size() != len(pubKey.PubKeys) {
+			return sdk.ErrUnauthorized("invalid multisignature bit array")
+		}
+		sigIndex := 0
+		for i, subKey := range pubKey.PubKeys {
+			if !multiSig.BitArray.GetIndex(i) {
+				continue
+			}
+			if sigIndex >= len(multiSig.Sigs) {
+				return sdk.ErrUnauthorized("missing signature of the multisignature")
+			}
+			if err := checkCanonicalSignature(subKey, multiSig.Sigs[sigIndex]); err != nil {
+				return err
+			}
+			sigIndex++
+		}
+		// the signatures not set in the bit array are not verified
+		if sigIndex != len(multiSig.Sigs) {
+			return sdk.ErrUnauthorized("unexpected signatures in the multisignature")
+		}
+	}
+	return nil
+}
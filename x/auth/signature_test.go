@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCheckCanonicalSignature(t *testing.T) {
+	msg := []byte("sign bytes")
+
+	// secp256k1: S and N-S both verify with btcec, only the low S is canonical
+	secpPriv := secp256k1.GenPrivKey()
+	sig, err := secpPriv.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, checkCanonicalSignature(secpPriv.PubKey(), sig))
+	highS := make([]byte, 64)
+	copy(highS, sig[:32])
+	new(big.Int).Sub(secp256k1Order, new(big.Int).SetBytes(sig[32:])).FillBytes(highS[32:])
+	require.Error(t, checkCanonicalSignature(secpPriv.PubKey(), highS))
+	require.Error(t, checkCanonicalSignature(secpPriv.PubKey(), make([]byte, 64)))
+	require.Error(t, checkCanonicalSignature(secpPriv.PubKey(), sig[:63]))
+
+	// ed25519: S+L is the same scalar
+	edPriv := ed25519.GenPrivKey()
+	sig, err = edPriv.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, checkCanonicalSignature(edPriv.PubKey(), sig))
+	sBytes := make([]byte, 32)
+	for i := range sBytes {
+		sBytes[i] = sig[63-i]
+	}
+	s := new(big.Int).Add(new(big.Int).SetBytes(sBytes), ed25519Order).FillBytes(make([]byte, 32))
+	nonReduced := append([]byte{}, sig...)
+	for i := range s {
+		nonReduced[63-i] = s[i]
+	}
+	require.Error(t, checkCanonicalSignature(edPriv.PubKey(), nonReduced))
+
+	// multisig: the signatures beyond the bit array are ignored by the
+	// verification, so they would malleate the tx
+	pubKeys := []crypto.PubKey{secpPriv.PubKey(), edPriv.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(1, pubKeys)
+	multiSig := multisig.NewMultisig(len(pubKeys))
+	sig, err = secpPriv.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, multiSig.AddSignatureFromPubKey(sig, secpPriv.PubKey(), pubKeys))
+	require.NoError(t, checkCanonicalSignature(multisigKey, multiSig.Marshal()))
+
+	multiSig.Sigs = append(multiSig.Sigs, sig)
+	require.True(t, multisigKey.VerifyBytes(msg, multiSig.Marshal()))
+	require.Error(t, checkCanonicalSignature(multisigKey, multiSig.Marshal()))
+
+	multiSig.Sigs = [][]byte{highS}
+	require.Error(t, checkCanonicalSignature(multisigKey, multiSig.Marshal()))
+}
+
+func TestPreCheckerCanonicalSignature(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CanonicalSignature, 10)
+	defer sdk.UpgradeMgr.Reset()
+
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, capKey))
+	preChecker := NewPreChecker()
+
+	priv := ed25519.GenPrivKey()
+	pubKeys := []crypto.PubKey{priv.PubKey(), ed25519.GenPrivKey().PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(1, pubKeys)
+	addr := sdk.AccAddress(multisigKey.Address())
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	sig, err := priv.Sign(StdSignBytes(ctx.ChainID(), 0, 0, msgs, "", 0, nil))
+	require.NoError(t, err)
+	multiSig := multisig.NewMultisig(len(pubKeys))
+	require.NoError(t, multiSig.AddSignatureFromPubKey(sig, priv.PubKey(), pubKeys))
+	multiSig.Sigs = append(multiSig.Sigs, sig)
+	tx := NewStdTx(msgs, []StdSignature{{PubKey: multisigKey, Signature: multiSig.Marshal()}}, "", 0, nil)
+
+	// the malleated signature is accepted before the upgrade
+	sdk.UpgradeMgr.SetHeight(9)
+	require.True(t, preChecker(ctx, nil, tx).IsOK())
+
+	sdk.UpgradeMgr.SetHeight(10)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), preChecker(ctx, nil, tx).Code)
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
 
@@ -137,6 +138,13 @@ func DefaultTxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 		if err != nil {
 			return nil, sdk.ErrTxDecode("").TraceSDK(err.Error())
 		}
+		// a nil msg would panic the checks of the tx, some out of the recovery
+		// of the txs
+		for i, msg := range tx.Msgs {
+			if msg == nil {
+				return nil, sdk.ErrTxDecode(fmt.Sprintf("msg %d is nil", i))
+			}
+		}
 		return tx, nil
 	}
 }
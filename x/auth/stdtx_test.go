@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -48,3 +49,27 @@ func TestStdSignBytes(t *testing.T) {
 		require.Equal(t, tc.want, got, "Got unexpected result on test case i: %d", i)
 	}
 }
+
+func TestDefaultTxDecoder(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	cdc.RegisterConcrete(&sdk.TestMsg{}, "test/TestMsg", nil)
+	decoder := DefaultTxDecoder(cdc)
+
+	tx := NewStdTx([]sdk.Msg{sdk.NewTestMsg(addr)}, nil, "memo", 0, nil)
+	bz, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	require.NoError(t, err)
+	decoded, err := decoder(bz)
+	require.Nil(t, err)
+	require.Len(t, decoded.GetMsgs(), 1)
+	require.Equal(t, "memo", decoded.(StdTx).Memo)
+
+	// a tx with a nil msg is rejected by the decoder
+	bz, err = cdc.MarshalBinaryLengthPrefixed(NewStdTx([]sdk.Msg{nil}, nil, "memo", 0, nil))
+	require.NoError(t, err)
+	_, err = decoder(bz)
+	require.NotNil(t, err)
+
+	_, err = decoder(nil)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,353 @@
+package auth
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingAccount is an account whose original vesting coins are released over
+// time. The coins still vesting can't be spent, the vested ones and the coins
+// received afterwards can.
+type VestingAccount interface {
+	sdk.Account
+
+	// SpendableCoins returns the coins of the account that can be spent at
+	// blockTime.
+	SpendableCoins(blockTime time.Time) sdk.Coins
+	// GetVestedCoins returns the original vesting coins released at blockTime.
+	GetVestedCoins(blockTime time.Time) sdk.Coins
+	// GetVestingCoins returns the original vesting coins still locked at
+	// blockTime.
+	GetVestingCoins(blockTime time.Time) sdk.Coins
+
+	GetOriginalVesting() sdk.Coins
+	GetStartTime() int64
+	GetEndTime() int64
+
+	// Validate checks the vesting schedule of the account.
+	Validate() error
+}
+
+// SpendableCoins returns the coins of acc that can be spent at the block time
+// of ctx, all of them unless acc is a VestingAccount.
+func SpendableCoins(ctx sdk.Context, acc sdk.Account) sdk.Coins {
+	if vacc, ok := acc.(VestingAccount); ok {
+		return vacc.SpendableCoins(ctx.BlockHeader().Time)
+	}
+	return acc.GetCoins()
+}
+
+//-----------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount implements the common parts of the vesting accounts, the
+// times are unix timestamps in seconds.
+type BaseVestingAccount struct {
+	*BaseAccount
+
+	OriginalVesting sdk.Coins `json:"original_vesting"`
+	EndTime         int64     `json:"end_time"`
+}
+
+// GetOriginalVesting returns the coins that were vesting when the account was
+// created.
+func (bva BaseVestingAccount) GetOriginalVesting() sdk.Coins {
+	return bva.OriginalVesting
+}
+
+// GetEndTime returns the time when all the original vesting coins are vested.
+func (bva BaseVestingAccount) GetEndTime() int64 {
+	return bva.EndTime
+}
+
+// spendableCoins returns the coins of the account minus vestingCoins, the
+// coins received after the creation of the account are never locked.
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendable sdk.Coins
+	for _, coin := range bva.Coins {
+		if amount := coin.Amount - vestingCoins.AmountOf(coin.Denom); amount > 0 {
+			spendable = append(spendable, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return spendable
+}
+
+func (bva BaseVestingAccount) clone() *BaseVestingAccount {
+	return &BaseVestingAccount{
+		BaseAccount:     bva.BaseAccount.Clone().(*BaseAccount),
+		OriginalVesting: cloneCoins(bva.OriginalVesting),
+		EndTime:         bva.EndTime,
+	}
+}
+
+func (bva BaseVestingAccount) validate(startTime int64) error {
+	if startTime < 0 || bva.EndTime < startTime {
+		return errors.New("vesting start time must be positive and before the end time")
+	}
+	if !bva.OriginalVesting.IsValid() || !bva.OriginalVesting.IsPositive() {
+		return errors.New("original vesting coins must be valid and positive")
+	}
+	return nil
+}
+
+func cloneCoins(coins sdk.Coins) sdk.Coins {
+	if coins == nil {
+		return nil
+	}
+	return append(make(sdk.Coins, 0, len(coins)), coins...)
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount releases its original vesting coins linearly from
+// StartTime to EndTime.
+type ContinuousVestingAccount struct {
+	*BaseVestingAccount
+
+	StartTime int64 `json:"start_time"`
+}
+
+// NewContinuousVestingAccount returns an account vesting all the coins of acc
+// linearly from startTime to endTime.
+func NewContinuousVestingAccount(acc *BaseAccount, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: &BaseVestingAccount{
+			BaseAccount:     acc,
+			OriginalVesting: cloneCoins(acc.Coins),
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	now := blockTime.Unix()
+	if now <= cva.StartTime {
+		return nil
+	}
+	if now >= cva.EndTime {
+		return cva.OriginalVesting
+	}
+
+	elapsed, duration := big.NewInt(now-cva.StartTime), big.NewInt(cva.EndTime-cva.StartTime)
+	var vested sdk.Coins
+	for _, coin := range cva.OriginalVesting {
+		amount := new(big.Int).Mul(big.NewInt(coin.Amount), elapsed)
+		if amount = amount.Quo(amount, duration); amount.Sign() > 0 {
+			vested = append(vested, sdk.NewCoin(coin.Denom, amount.Int64()))
+		}
+	}
+	return vested
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return cva.OriginalVesting.Minus(cva.GetVestedCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (cva ContinuousVestingAccount) Validate() error {
+	return cva.validate(cva.StartTime)
+}
+
+// Implements sdk.Account.
+func (cva *ContinuousVestingAccount) Clone() sdk.Account {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: cva.BaseVestingAccount.clone(),
+		StartTime:          cva.StartTime,
+	}
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount releases all its original vesting coins at EndTime.
+type DelayedVestingAccount struct {
+	*BaseVestingAccount
+}
+
+// NewDelayedVestingAccount returns an account vesting all the coins of acc at
+// endTime.
+func NewDelayedVestingAccount(acc *BaseAccount, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: &BaseVestingAccount{
+			BaseAccount:     acc,
+			OriginalVesting: cloneCoins(acc.Coins),
+			EndTime:         endTime,
+		},
+	}
+}
+
+// Implements VestingAccount, the coins of a delayed vesting account vest at
+// once.
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if blockTime.Unix() >= dva.EndTime {
+		return dva.OriginalVesting
+	}
+	return nil
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return dva.OriginalVesting.Minus(dva.GetVestedCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (dva DelayedVestingAccount) Validate() error {
+	return dva.validate(0)
+}
+
+// Implements sdk.Account.
+func (dva *DelayedVestingAccount) Clone() sdk.Account {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: dva.BaseVestingAccount.clone(),
+	}
+}
+
+//-----------------------------------------------------------
+// PeriodicVestingAccount
+
+var _ VestingAccount = (*PeriodicVestingAccount)(nil)
+
+// Period releases Amount once Length seconds passed since the end of the
+// previous period.
+type Period struct {
+	Length int64     `json:"length"`
+	Amount sdk.Coins `json:"amount"`
+}
+
+// Periods is the vesting schedule of a PeriodicVestingAccount.
+type Periods []Period
+
+// TotalLength returns the duration of all the periods.
+func (periods Periods) TotalLength() int64 {
+	var length int64
+	for _, period := range periods {
+		length += period.Length
+	}
+	return length
+}
+
+// TotalAmount returns the coins released by all the periods.
+func (periods Periods) TotalAmount() sdk.Coins {
+	var amount sdk.Coins
+	for _, period := range periods {
+		amount = amount.Plus(period.Amount)
+	}
+	return amount
+}
+
+// PeriodicVestingAccount releases its original vesting coins at the end of each
+// of its vesting periods, starting from StartTime.
+type PeriodicVestingAccount struct {
+	*BaseVestingAccount
+
+	StartTime      int64   `json:"start_time"`
+	VestingPeriods Periods `json:"vesting_periods"`
+}
+
+// NewPeriodicVestingAccount returns an account vesting the amounts of periods
+// at the end of each period from startTime.
+func NewPeriodicVestingAccount(acc *BaseAccount, startTime int64, periods Periods) *PeriodicVestingAccount {
+	return &PeriodicVestingAccount{
+		BaseVestingAccount: &BaseVestingAccount{
+			BaseAccount:     acc,
+			OriginalVesting: periods.TotalAmount(),
+			EndTime:         startTime + periods.TotalLength(),
+		},
+		StartTime:      startTime,
+		VestingPeriods: periods,
+	}
+}
+
+// Implements VestingAccount.
+func (pva PeriodicVestingAccount) GetStartTime() int64 {
+	return pva.StartTime
+}
+
+// Implements VestingAccount.
+func (pva PeriodicVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	now := blockTime.Unix()
+	if now >= pva.EndTime {
+		return pva.OriginalVesting
+	}
+
+	var vested sdk.Coins
+	periodEnd := pva.StartTime
+	for _, period := range pva.VestingPeriods {
+		periodEnd += period.Length
+		if now < periodEnd {
+			break
+		}
+		vested = vested.Plus(period.Amount)
+	}
+	return vested
+}
+
+// Implements VestingAccount.
+func (pva PeriodicVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return pva.OriginalVesting.Minus(pva.GetVestedCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (pva PeriodicVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return pva.spendableCoins(pva.GetVestingCoins(blockTime))
+}
+
+// Implements VestingAccount.
+func (pva PeriodicVestingAccount) Validate() error {
+	for _, period := range pva.VestingPeriods {
+		if period.Length < 0 || !period.Amount.IsValid() {
+			return errors.New("vesting periods must have a positive length and valid coins")
+		}
+	}
+	if !pva.VestingPeriods.TotalAmount().IsEqual(pva.OriginalVesting) {
+		return errors.New("original vesting coins must be the total amount of the vesting periods")
+	}
+	if pva.StartTime+pva.VestingPeriods.TotalLength() != pva.EndTime {
+		return errors.New("vesting end time must be the end of the last vesting period")
+	}
+	return pva.validate(pva.StartTime)
+}
+
+// Implements sdk.Account.
+func (pva *PeriodicVestingAccount) Clone() sdk.Account {
+	periods := make(Periods, 0, len(pva.VestingPeriods))
+	for _, period := range pva.VestingPeriods {
+		periods = append(periods, Period{Length: period.Length, Amount: cloneCoins(period.Amount)})
+	}
+	return &PeriodicVestingAccount{
+		BaseVestingAccount: pva.BaseVestingAccount.clone(),
+		StartTime:          pva.StartTime,
+		VestingPeriods:     periods,
+	}
+}
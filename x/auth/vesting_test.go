@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	vestingStart = time.Unix(1000, 0)
+	vestingEnd   = time.Unix(2000, 0)
+)
+
+func newVestingBaseAccount(coins sdk.Coins) *BaseAccount {
+	_, _, addr := keyPubAddr()
+	acc := NewBaseAccountWithAddress(addr)
+	acc.Coins = coins
+	return &acc
+}
+
+func TestContinuousVestingAccount(t *testing.T) {
+	origCoins := sdk.Coins{sdk.NewCoin("fee", 1000), sdk.NewCoin("stake", 100)}
+	acc := NewContinuousVestingAccount(newVestingBaseAccount(origCoins), vestingStart.Unix(), vestingEnd.Unix())
+	require.NoError(t, acc.Validate())
+
+	require.Nil(t, acc.GetVestedCoins(vestingStart))
+	require.Equal(t, origCoins, acc.GetVestingCoins(vestingStart))
+	require.Nil(t, acc.SpendableCoins(vestingStart))
+
+	halfway := vestingStart.Add(500 * time.Second)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("fee", 500), sdk.NewCoin("stake", 50)}, acc.GetVestedCoins(halfway))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("fee", 500), sdk.NewCoin("stake", 50)}, acc.SpendableCoins(halfway))
+
+	// the coins received are spendable right away
+	acc.Coins = acc.Coins.Plus(sdk.Coins{sdk.NewCoin("fee", 10), sdk.NewCoin("other", 5)})
+	require.Equal(t, sdk.Coins{sdk.NewCoin("fee", 510), sdk.NewCoin("other", 5), sdk.NewCoin("stake", 50)}, acc.SpendableCoins(halfway))
+
+	require.Equal(t, origCoins, acc.GetVestedCoins(vestingEnd))
+	require.Nil(t, acc.GetVestingCoins(vestingEnd))
+	require.Equal(t, acc.Coins, acc.SpendableCoins(vestingEnd))
+
+	// the vesting coins can't be more than the coins of the account
+	acc.Coins = sdk.Coins{sdk.NewCoin("stake", 10)}
+	require.Nil(t, acc.SpendableCoins(halfway))
+
+	acc.EndTime = acc.StartTime - 1
+	require.Error(t, acc.Validate())
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	origCoins := sdk.Coins{sdk.NewCoin("fee", 1000)}
+	acc := NewDelayedVestingAccount(newVestingBaseAccount(origCoins), vestingEnd.Unix())
+	require.NoError(t, acc.Validate())
+
+	require.Nil(t, acc.GetVestedCoins(vestingEnd.Add(-time.Second)))
+	require.Nil(t, acc.SpendableCoins(vestingEnd.Add(-time.Second)))
+	require.Equal(t, origCoins, acc.GetVestedCoins(vestingEnd))
+	require.Equal(t, origCoins, acc.SpendableCoins(vestingEnd))
+
+	acc.OriginalVesting = nil
+	require.Error(t, acc.Validate())
+}
+
+func TestPeriodicVestingAccount(t *testing.T) {
+	periods := Periods{
+		{Length: 100, Amount: sdk.Coins{sdk.NewCoin("fee", 500)}},
+		{Length: 200, Amount: sdk.Coins{sdk.NewCoin("fee", 250), sdk.NewCoin("stake", 100)}},
+		{Length: 700, Amount: sdk.Coins{sdk.NewCoin("fee", 250)}},
+	}
+	origCoins := sdk.Coins{sdk.NewCoin("fee", 1000), sdk.NewCoin("stake", 100)}
+	acc := NewPeriodicVestingAccount(newVestingBaseAccount(origCoins), vestingStart.Unix(), periods)
+	require.NoError(t, acc.Validate())
+	require.Equal(t, origCoins, acc.GetOriginalVesting())
+	require.Equal(t, vestingEnd.Unix(), acc.GetEndTime())
+
+	require.Nil(t, acc.GetVestedCoins(vestingStart.Add(99*time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("fee", 500)}, acc.GetVestedCoins(vestingStart.Add(100*time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("fee", 750), sdk.NewCoin("stake", 100)}, acc.SpendableCoins(vestingStart.Add(300*time.Second)))
+	require.Equal(t, origCoins, acc.GetVestedCoins(vestingEnd))
+
+	acc.OriginalVesting = sdk.Coins{sdk.NewCoin("fee", 1000)}
+	require.Error(t, acc.Validate())
+}
+
+func TestVestingAccountCloneAndCodec(t *testing.T) {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	periods := Periods{{Length: 100, Amount: sdk.Coins{sdk.NewCoin("fee", 500)}}}
+	accs := []VestingAccount{
+		NewContinuousVestingAccount(newVestingBaseAccount(sdk.Coins{sdk.NewCoin("fee", 500)}), vestingStart.Unix(), vestingEnd.Unix()),
+		NewDelayedVestingAccount(newVestingBaseAccount(sdk.Coins{sdk.NewCoin("fee", 500)}), vestingEnd.Unix()),
+		NewPeriodicVestingAccount(newVestingBaseAccount(sdk.Coins{sdk.NewCoin("fee", 500)}), vestingStart.Unix(), periods),
+	}
+	for _, acc := range accs {
+		cloned := acc.Clone()
+		require.Equal(t, acc, cloned)
+		require.NoError(t, cloned.SetCoins(nil))
+		require.NotNil(t, acc.GetCoins())
+
+		bz, err := cdc.MarshalBinaryBare(acc)
+		require.NoError(t, err)
+		var decoded sdk.Account
+		require.NoError(t, cdc.UnmarshalBinaryBare(bz, &decoded))
+		require.Equal(t, acc, decoded)
+
+		require.Nil(t, SpendableCoins(sdk.Context{}.WithBlockTime(vestingStart), acc))
+	}
+}
@@ -1,30 +1,33 @@
 package cli
 
 import (
-	"io"
-	"os"
-
 	"github.com/cosmos/cosmos-sdk/client/context"
-	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/spf13/cobra"
 	amino "github.com/tendermint/go-amino"
 )
 
-// GetSignCommand returns the sign command
+// GetBroadcastCommand returns the broadcast command
 func GetBroadcastCommand(codec *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "broadcast <file>",
 		Short: "Broadcast transactions generated offline",
 		Long: `Broadcast transactions created with the --generate-only flag and signed with the sign command.
 Read a transaction from <file> and broadcast it to a node. If you supply a dash (-) argument
-in place of an input filename, the command reads from standard input.`,
+in place of an input filename, the command reads from standard input.
+
+Transactions missing the signature of one of their signers are rejected before
+they reach the node.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			cliCtx := context.NewCLIContext().WithCodec(codec)
-			stdTx, err := readAndUnmarshalStdTx(cliCtx.Codec, args[0])
+			stdTx, err := utils.ReadStdTxFromFile(cliCtx.Codec, args[0])
 			if err != nil {
 				return
 			}
+			if err = utils.CheckFullySigned(stdTx); err != nil {
+				return
+			}
 			txBytes, err := cliCtx.Codec.MarshalBinaryLengthPrefixed(stdTx)
 			if err != nil {
 				return
@@ -37,19 +40,3 @@ in place of an input filename, the command reads from standard input.`,
 
 	return cmd
 }
-
-func readAndUnmarshalStdTx(cdc *amino.Codec, filename string) (stdTx auth.StdTx, err error) {
-	var bytes []byte
-	if filename == "-" {
-		bytes, err = io.ReadAll(os.Stdin)
-	} else {
-		bytes, err = os.ReadFile(filename)
-	}
-	if err != nil {
-		return
-	}
-	if err = cdc.UnmarshalJSON(bytes, &stdTx); err != nil {
-		return
-	}
-	return
-}
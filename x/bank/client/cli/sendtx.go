@@ -40,7 +40,7 @@ func SendTxCmd(cdc *codec.Codec) *cobra.Command {
 
 			// parse coins trying to be sent
 			amount := viper.GetString(flagAmount)
-			coins, err := sdk.ParseCoins(amount)
+			coins, err := sdk.ParseUserCoins(amount)
 			if err != nil {
 				return err
 			}
@@ -73,7 +73,7 @@ func SendTxCmd(cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagTo, "", "Address to send coins")
-	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
+	cmd.Flags().String(flagAmount, "", "Amount of coins to send, e.g. \"1.5 BNB\" or \"150000000:BNB\"")
 	cmd.MarkFlagRequired(flagTo)
 	cmd.MarkFlagRequired(flagAmount)
 
@@ -13,16 +13,17 @@ const (
 	CodeInvalidOutput sdk.CodeType = 102
 )
 
-// NOTE: Don't stringer this, we'll put better messages in later.
+// The registered errors of the codes of the bank codespace.
+var (
+	ErrCodeInvalidInput  = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input coins")
+	ErrCodeInvalidOutput = sdk.Register(DefaultCodespace, CodeInvalidOutput, "invalid output coins")
+)
+
 func codeToDefaultMsg(code sdk.CodeType) string {
-	switch code {
-	case CodeInvalidInput:
-		return "invalid input coins"
-	case CodeInvalidOutput:
-		return "invalid output coins"
-	default:
-		return sdk.CodeToDefaultMsg(code)
+	if registered, ok := sdk.LookupError(DefaultCodespace, code); ok {
+		return registered.Description()
 	}
+	return sdk.CodeToDefaultMsg(code)
 }
 
 //----------------------------------------
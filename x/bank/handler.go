@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 // NewHandler returns a handler for "bank" type messages.
@@ -12,6 +13,11 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgSend:
 			return handleMsgSend(ctx, k, msg)
+		case MsgSetAccountFlags:
+			if !sdk.IsUpgrade(sdk.AccountFlags) {
+				return sdk.ErrUnknownRequest("account flags are not enabled yet").Result()
+			}
+			return handleMsgSetAccountFlags(ctx, k, msg)
 		default:
 			errMsg := "Unrecognized bank Msg type: %s" + msg.Type()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -40,6 +46,11 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 			}
 		}
 	}
+	if sdk.IsUpgrade(sdk.AccountFlags) {
+		if err := checkAccountFlags(ctx, k.GetAccountKeeper(), msg); err != nil {
+			return err.Result()
+		}
+	}
 	// NOTE: totalIn == totalOut should already have been checked
 	tags, err := k.InputOutputCoins(ctx, msg.Inputs, msg.Outputs)
 	if err != nil {
@@ -50,3 +61,39 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 		Tags: tags,
 	}
 }
+
+// checkAccountFlags rejects the sends from the accounts with their transfers
+// disabled, and the sends without a memo to the accounts requiring one.
+func checkAccountFlags(ctx sdk.Context, am auth.AccountKeeper, msg MsgSend) sdk.Error {
+	for _, in := range msg.Inputs {
+		if flags, _ := am.GetFlags(ctx, in.Address); flags&auth.FlagTransfersDisabled != 0 {
+			return sdk.ErrInvalidAccountFlags(fmt.Sprintf("the transfers of account %s are disabled", in.Address))
+		}
+	}
+	var memo string
+	if tx, ok := ctx.Tx().(auth.StdTx); ok {
+		memo = tx.GetMemo()
+	}
+	if memo != "" {
+		return nil
+	}
+	for _, out := range msg.Outputs {
+		if flags, _ := am.GetFlags(ctx, out.Address); flags&auth.FlagMemoRequired != 0 {
+			return sdk.ErrInvalidTxMemo(fmt.Sprintf("account %s requires a memo on the txs sending to it", out.Address))
+		}
+	}
+	return nil
+}
+
+// Handle MsgSetAccountFlags.
+func handleMsgSetAccountFlags(ctx sdk.Context, k Keeper, msg MsgSetAccountFlags) sdk.Result {
+	am := k.GetAccountKeeper()
+	flags, err := am.GetFlags(ctx, msg.From)
+	if err != nil {
+		return err.Result()
+	}
+	if err := am.SetFlags(ctx, msg.From, flags&^auth.OwnerFlags|msg.Flags); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
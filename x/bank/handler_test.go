@@ -116,6 +116,52 @@ func TestHandleSendMiniToken(t *testing.T) {
 	require.True(t, bankKeeper.GetCoins(ctx, addr3).IsEqual(sdk.Coins{sdk.NewCoin(MiniTokenBar, 6), sdk.NewCoin(MiniTokenFoo, 4e8)}))
 }
 
+func TestHandleAccountFlags(t *testing.T) {
+	ctx, handler, bankKeeper, accountKeeper := setup()
+	defer sdk.UpgradeMgr.Reset()
+
+	ctx = ctx.WithValue(baseapp.TxHashKey, "000")
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	accountKeeper.SetAccount(ctx, accountKeeper.NewAccountWithAddress(ctx, addr))
+	accountKeeper.SetAccount(ctx, accountKeeper.NewAccountWithAddress(ctx, addr2))
+	bankKeeper.SetCoins(ctx, addr, sdk.Coins{sdk.NewCoin("NNB-000", 100)})
+	coins := sdk.Coins{sdk.NewCoin("NNB-000", 10)}
+	send := createSendMsg(addr, addr2, coins)
+	withMemo := func(memo string) sdk.Context {
+		return ctx.WithTx(auth.NewStdTx([]sdk.Msg{send}, nil, memo, 0, nil))
+	}
+
+	// the flags are checked once the upgrade is active
+	require.NoError(t, accountKeeper.SetFlags(ctx, addr, auth.FlagTransfersDisabled))
+	setFlags := NewMsgSetAccountFlags(addr2, auth.FlagMemoRequired)
+	require.False(t, handler(ctx, setFlags).IsOK())
+	require.True(t, handler(ctx, send).IsOK())
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountFlags, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+
+	// the account with its transfers disabled cannot send
+	res := handler(withMemo("memo"), send)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidAccountFlags), res.Code)
+	require.NoError(t, accountKeeper.SetFlags(ctx, addr, 0))
+
+	// an account requiring a memo only receives txs with a memo
+	require.True(t, handler(ctx, setFlags).IsOK())
+	res = handler(withMemo(""), send)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidTxMemo), res.Code)
+	require.True(t, handler(withMemo("deposit-1"), send).IsOK())
+	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewCoin("NNB-000", 20)}))
+
+	// an account cannot lift its holds
+	require.Error(t, NewMsgSetAccountFlags(addr2, auth.FlagTransfersDisabled).ValidateBasic())
+	require.NoError(t, accountKeeper.SetFlags(ctx, addr2, auth.FlagTransfersDisabled|auth.FlagMemoRequired))
+	require.True(t, handler(ctx, NewMsgSetAccountFlags(addr2, 0)).IsOK())
+	flags, err := accountKeeper.GetFlags(ctx, addr2)
+	require.NoError(t, err)
+	require.Equal(t, auth.FlagTransfersDisabled, flags)
+}
+
 func createSendMsg(from sdk.AccAddress, to sdk.AccAddress, coins sdk.Coins) sdk.Msg {
 	input := NewInput(from, coins)
 	output := NewOutput(to, coins)
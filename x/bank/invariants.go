@@ -0,0 +1,28 @@
+package bank
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// RegisterInvariants registers the invariants of the bank module.
+func RegisterInvariants(ir sdk.InvariantRouter, am auth.AccountKeeper) {
+	ir.RegisterRoute("bank", "nonnegative-balances", NonnegativeBalanceInvariant(am))
+}
+
+// NonnegativeBalanceInvariant checks that no account has a negative balance.
+func NonnegativeBalanceInvariant(am auth.AccountKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var err error
+		am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+			if coins := acc.GetCoins(); !coins.IsNotNegative() {
+				err = fmt.Errorf("%s has a negative balance of %s", acc.GetAddress(), coins)
+				return true
+			}
+			return false
+		})
+		return err
+	}
+}
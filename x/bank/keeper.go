@@ -3,6 +3,7 @@ package bank
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
@@ -187,8 +188,15 @@ func hasCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt s
 	return getCoins(ctx, am, addr).IsGTE(amt)
 }
 
-// SubtractCoins subtracts amt from the coins at the addr.
+// SubtractCoins subtracts amt from the coins at the addr, the coins still
+// vesting can't be subtracted.
 func subtractCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
+	if vacc, ok := am.GetAccount(ctx, addr).(auth.VestingAccount); ok {
+		spendable := vacc.SpendableCoins(ctx.BlockHeader().Time)
+		if !spendable.IsGTE(amt) {
+			return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("spendable %s < %s", spendable, amt))
+		}
+	}
 	oldCoins := getCoins(ctx, am, addr)
 	newCoins := oldCoins.Minus(amt)
 	if !newCoins.IsNotNegative() {
@@ -224,6 +232,7 @@ func sendCoins(ctx sdk.Context, am auth.AccountKeeper, fromAddr sdk.AccAddress,
 		return nil, err
 	}
 
+	telemetry.IncrKeeperCounter(ctx, "bank", "send")
 	return subTags.AppendTags(addTags), nil
 }
 
@@ -248,5 +257,6 @@ func inputOutputCoins(ctx sdk.Context, am auth.AccountKeeper, inputs []Input, ou
 		allTags = allTags.AppendTags(tags)
 	}
 
+	telemetry.IncrKeeperCounter(ctx, "bank", "input_output")
 	return allTags, nil
 }
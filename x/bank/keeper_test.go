@@ -2,6 +2,7 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -214,3 +215,45 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewCoin("barcoin", 5)}))
 }
+
+func TestKeeperVestingAccount(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	accountCache := getAccountCache(cdc, ms, authKey)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountKeeper)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	baseAcc := auth.NewBaseAccountWithAddress(addr)
+	baseAcc.Coins = sdk.Coins{sdk.NewCoin("foocoin", 100)}
+	accountKeeper.SetAccount(ctx, auth.NewContinuousVestingAccount(&baseAcc, 1000, 2000))
+
+	// nothing is vested before the start time
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+	_, err := bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 1)})
+	require.Error(t, err)
+
+	// the coins received are spendable
+	bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	_, err = bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewCoin("foocoin", 10)})
+	require.NoError(t, err)
+
+	// half of the coins are vested halfway
+	ctx = ctx.WithBlockTime(time.Unix(1500, 0))
+	_, _, err = bankKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewCoin("foocoin", 51)})
+	require.Error(t, err)
+	_, err = bankKeeper.InputOutputCoins(ctx, []Input{NewInput(addr, sdk.Coins{sdk.NewCoin("foocoin", 50)})}, []Output{NewOutput(addr2, sdk.Coins{sdk.NewCoin("foocoin", 50)})})
+	require.NoError(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("foocoin", 50)}, bankKeeper.GetCoins(ctx, addr))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("foocoin", 60)}, bankKeeper.GetCoins(ctx, addr2))
+
+	// the account is still a vesting account
+	_, ok := accountKeeper.GetAccount(ctx, addr).(*auth.ContinuousVestingAccount)
+	require.True(t, ok)
+}
@@ -3,6 +3,7 @@ package bank
 import (
 	"encoding/json"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
 // MsgSend - high level transaction of the coin module
@@ -85,6 +86,60 @@ func (msg MsgSend) GetInvolvedAddresses() []sdk.AccAddress {
 	return addrs
 }
 
+//----------------------------------------
+// MsgSetAccountFlags
+
+// MsgSetAccountFlags sets the owner flags of the From account, e.g. to
+// require a memo on the deposits to it, see auth.OwnerFlags. The other flags
+// of the account are kept, an account cannot lift its own holds.
+type MsgSetAccountFlags struct {
+	From  sdk.AccAddress `json:"from"`
+	Flags uint64         `json:"flags"`
+}
+
+var _ sdk.Msg = MsgSetAccountFlags{}
+
+func NewMsgSetAccountFlags(from sdk.AccAddress, flags uint64) MsgSetAccountFlags {
+	return MsgSetAccountFlags{From: from, Flags: flags}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgSetAccountFlags) Route() string { return "bank" }
+func (msg MsgSetAccountFlags) Type() string  { return "setAccountFlags" }
+
+// Implements Msg.
+func (msg MsgSetAccountFlags) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if err := auth.ValidateFlags(msg.Flags); err != nil {
+		return err
+	}
+	if msg.Flags&^auth.OwnerFlags != 0 {
+		return sdk.ErrInvalidAccountFlags("an account can only set its owner flags")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgSetAccountFlags) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgSetAccountFlags) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgSetAccountFlags) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
 //----------------------------------------
 // Input
 
@@ -51,3 +51,22 @@ func TotalCoinsInvariant(mapper auth.AccountKeeper, totalSupplyFn func() sdk.Coi
 		return nil
 	}
 }
+
+// ModuleAccountInvariant checks that the module account name holds the coins
+// its module accounts for, and that it has no public key.
+func ModuleAccountInvariant(mapper auth.AccountKeeper, name string, expectedFn func(ctx sdk.Context) sdk.Coins) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		coins := sdk.Coins{}
+		if acc := mapper.GetAccount(ctx, auth.NewModuleAddress(name)); acc != nil {
+			if acc.GetPubKey() != nil {
+				return fmt.Errorf("module account %s has a public key", name)
+			}
+			coins = acc.GetCoins()
+		}
+		if expected := expectedFn(ctx); !coins.IsEqual(expected) {
+			return fmt.Errorf("module account %s holds %s instead of %s", name, coins, expected)
+		}
+		return nil
+	}
+}
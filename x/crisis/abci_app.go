@@ -0,0 +1,32 @@
+package crisis
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	EventTypeInvariantBroken = "invariant_broken"
+
+	TagInvariant = "invariant"
+)
+
+// EndBlocker checks the invariants every check period, all of them, and the
+// ones requested during the block. It must run after the other end blockers
+// so that the state is final.
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	routes := k.GetScheduledInvariants(ctx)
+	if k.checkPeriod != 0 && ctx.BlockHeight()%k.checkPeriod == 0 {
+		routes = k.routes
+	}
+	if len(routes) == 0 {
+		return
+	}
+
+	// the invariants iterate over the store, the accounts of the block must be
+	// written first
+	ctx.AccountCache().Write()
+	for _, invarRoute := range k.AssertInvariants(ctx, routes) {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeInvariantBroken,
+			sdk.NewAttribute(TagInvariant, invarRoute.FullRoute())))
+	}
+}
@@ -0,0 +1,26 @@
+// nolint
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// Default crisis codespace
+	DefaultCodespace sdk.CodespaceType = 32
+
+	CodeInvalidInput     sdk.CodeType = 101
+	CodeUnknownInvariant sdk.CodeType = 102
+)
+
+// The registered errors of the codes of the crisis codespace.
+var (
+	ErrCodeInvalidInput     = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeUnknownInvariant = sdk.Register(DefaultCodespace, CodeUnknownInvariant, "unknown invariant")
+)
+
+func ErrUnknownInvariant(codespace sdk.CodespaceType, route string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownInvariant, fmt.Sprintf("unknown invariant %s", route))
+}
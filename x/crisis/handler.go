@@ -0,0 +1,32 @@
+package crisis
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgVerifyInvariant:
+			return handleMsgVerifyInvariant(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in crisis module").Result()
+		}
+	}
+}
+
+// The invariant is checked at the end of the block, once all the txs are
+// delivered and their accounts written, so that it sees the whole state.
+func handleMsgVerifyInvariant(ctx sdk.Context, msg MsgVerifyInvariant, k Keeper) sdk.Result {
+	fullRoute := msg.FullInvariantRoute()
+	if _, found := k.GetRoute(fullRoute); !found {
+		return ErrUnknownInvariant(k.codespace, fullRoute).Result()
+	}
+	if ctx.IsDeliverTx() {
+		k.ScheduleInvariant(ctx, fullRoute)
+	}
+	return sdk.Result{
+		Tags: sdk.NewTags(TagInvariant, []byte(fullRoute)),
+	}
+}
@@ -0,0 +1,120 @@
+package crisis
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// the invariants requested by MsgVerifyInvariant during the block, in the
+	// transient store
+	ScheduledInvariantKeyPrefix = []byte("scheduled:")
+)
+
+// GetScheduledInvariantKey returns the transient store key of the route
+// requested during the block.
+func GetScheduledInvariantKey(route string) []byte {
+	return append(ScheduledInvariantKeyPrefix, []byte(route)...)
+}
+
+// InvarRoute is an invariant registered by a module.
+type InvarRoute struct {
+	ModuleName string
+	Route      string
+	Invar      sdk.Invariant
+}
+
+// FullRoute returns the route of the invariant prefixed by its module.
+func (i InvarRoute) FullRoute() string {
+	return i.ModuleName + "/" + i.Route
+}
+
+var _ sdk.InvariantRouter = (*Keeper)(nil)
+
+// Keeper is the registry of the invariants of the modules. They are checked
+// every checkPeriod blocks, if not zero, and at the end of the blocks
+// including a MsgVerifyInvariant. A broken invariant halts the chain if
+// haltOnBreach is set, it is only logged and reported in the block events
+// otherwise.
+type Keeper struct {
+	routes []InvarRoute
+
+	storeKey     sdk.StoreKey // transient store
+	cdc          *codec.Codec
+	checkPeriod  int64
+	haltOnBreach bool
+	codespace    sdk.CodespaceType
+}
+
+// NewKeeper returns a crisis keeper, the invariants must be registered before
+// its handler is created.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, checkPeriod int64, haltOnBreach bool, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:     key,
+		cdc:          cdc,
+		checkPeriod:  checkPeriod,
+		haltOnBreach: haltOnBreach,
+		codespace:    codespace,
+	}
+}
+
+// RegisterRoute implements sdk.InvariantRouter.
+func (k *Keeper) RegisterRoute(moduleName, route string, invar sdk.Invariant) {
+	invarRoute := InvarRoute{ModuleName: moduleName, Route: route, Invar: invar}
+	if _, found := k.GetRoute(invarRoute.FullRoute()); found {
+		panic(fmt.Sprintf("invariant %s is already registered", invarRoute.FullRoute()))
+	}
+	k.routes = append(k.routes, invarRoute)
+}
+
+// Routes returns the registered invariants.
+func (k Keeper) Routes() []InvarRoute {
+	return k.routes
+}
+
+// GetRoute returns the invariant registered at fullRoute.
+func (k Keeper) GetRoute(fullRoute string) (InvarRoute, bool) {
+	for _, invarRoute := range k.routes {
+		if invarRoute.FullRoute() == fullRoute {
+			return invarRoute, true
+		}
+	}
+	return InvarRoute{}, false
+}
+
+// ScheduleInvariant requests the check of the invariant fullRoute at the end
+// of the block.
+func (k Keeper) ScheduleInvariant(ctx sdk.Context, fullRoute string) {
+	ctx.KVStore(k.storeKey).Set(GetScheduledInvariantKey(fullRoute), []byte{1})
+}
+
+// GetScheduledInvariants returns the invariants requested during the block.
+func (k Keeper) GetScheduledInvariants(ctx sdk.Context) (routes []InvarRoute) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), ScheduledInvariantKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if invarRoute, found := k.GetRoute(string(iterator.Key()[len(ScheduledInvariantKeyPrefix):])); found {
+			routes = append(routes, invarRoute)
+		}
+	}
+	return routes
+}
+
+// AssertInvariants checks the invariants, it panics on the first broken one
+// if the keeper halts on breach and returns the broken ones otherwise.
+func (k Keeper) AssertInvariants(ctx sdk.Context, routes []InvarRoute) (broken []InvarRoute) {
+	for _, invarRoute := range routes {
+		err := invarRoute.Invar(ctx)
+		if err == nil {
+			continue
+		}
+		if k.haltOnBreach {
+			panic(fmt.Errorf("invariant broken: %s: %v", invarRoute.FullRoute(), err))
+		}
+		ctx.Logger().Error("invariant broken", "route", invarRoute.FullRoute(), "err", err.Error())
+		broken = append(broken, invarRoute)
+	}
+	return broken
+}
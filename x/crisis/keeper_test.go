@@ -0,0 +1,93 @@
+package crisis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var (
+	addr = sdk.AccAddress([]byte("addr1_______________"))
+
+	passing = func(ctx sdk.Context) error { return nil }
+	broken  = func(ctx sdk.Context) error { return errors.New("broken") }
+)
+
+func createTestInput(t *testing.T, checkPeriod int64, haltOnBreach bool) (sdk.Context, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	tkeyCrisis := sdk.NewTransientStoreKey("transient_crisis")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyCrisis, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache).WithEventManager(sdk.NewEventManager())
+
+	k := NewKeeper(cdc, tkeyCrisis, checkPeriod, haltOnBreach, DefaultCodespace)
+	k.RegisterRoute("mod", "passing", passing)
+	k.RegisterRoute("mod", "broken", broken)
+	return ctx, k
+}
+
+func TestRegisterRoute(t *testing.T) {
+	_, k := createTestInput(t, 0, false)
+	require.Len(t, k.Routes(), 2)
+	_, found := k.GetRoute("mod/broken")
+	require.True(t, found)
+	_, found = k.GetRoute("other/broken")
+	require.False(t, found)
+
+	require.Panics(t, func() { k.RegisterRoute("mod", "passing", passing) })
+}
+
+func TestHandleMsgVerifyInvariant(t *testing.T) {
+	ctx, k := createTestInput(t, 0, false)
+	handler := NewHandler(k)
+
+	res := handler(ctx, NewMsgVerifyInvariant(addr, "mod", "unknown"))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownInvariant), res.Code)
+
+	// nothing is checked without a request
+	EndBlocker(ctx, k)
+	require.Empty(t, ctx.EventManager().Events())
+
+	require.True(t, handler(ctx, NewMsgVerifyInvariant(addr, "mod", "passing")).IsOK())
+	EndBlocker(ctx, k)
+	require.Empty(t, ctx.EventManager().Events())
+
+	require.True(t, handler(ctx, NewMsgVerifyInvariant(addr, "mod", "broken")).IsOK())
+	EndBlocker(ctx, k)
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, EventTypeInvariantBroken, events[0].Type)
+	require.Equal(t, "mod/broken", string(events[0].Attributes[0].Value))
+}
+
+func TestEndBlockerCheckPeriod(t *testing.T) {
+	ctx, k := createTestInput(t, 2, false)
+
+	// all the invariants are checked every check period
+	EndBlocker(ctx, k)
+	require.Empty(t, ctx.EventManager().Events())
+	EndBlocker(ctx.WithBlockHeight(2), k)
+	require.Len(t, ctx.EventManager().Events(), 1)
+}
+
+func TestEndBlockerHaltOnBreach(t *testing.T) {
+	ctx, k := createTestInput(t, 1, true)
+	require.Panics(t, func() { EndBlocker(ctx, k) })
+}
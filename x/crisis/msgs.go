@@ -0,0 +1,68 @@
+package crisis
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const (
+	MsgRoute               = "crisis"
+	TypeMsgVerifyInvariant = "verify_invariant"
+)
+
+// verify interface at compile time
+var _ sdk.Msg = MsgVerifyInvariant{}
+
+// MsgVerifyInvariant requests the check of an invariant at the end of the
+// block, its sender pays the fee of the msg set by the fee params.
+type MsgVerifyInvariant struct {
+	Sender              sdk.AccAddress `json:"sender"`
+	InvariantModuleName string         `json:"invariant_module_name"`
+	InvariantRoute      string         `json:"invariant_route"`
+}
+
+func NewMsgVerifyInvariant(sender sdk.AccAddress, invariantModuleName, invariantRoute string) MsgVerifyInvariant {
+	return MsgVerifyInvariant{
+		Sender:              sender,
+		InvariantModuleName: invariantModuleName,
+		InvariantRoute:      invariantRoute,
+	}
+}
+
+// nolint
+func (msg MsgVerifyInvariant) Route() string { return MsgRoute }
+func (msg MsgVerifyInvariant) Type() string  { return TypeMsgVerifyInvariant }
+func (msg MsgVerifyInvariant) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgVerifyInvariant) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgVerifyInvariant) ValidateBasic() sdk.Error {
+	if len(msg.Sender) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected sender address length is %d, actual length is %d", sdk.AddrLen, len(msg.Sender)))
+	}
+	if len(msg.InvariantModuleName) == 0 || len(msg.InvariantRoute) == 0 {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "invariant module name and route are required")
+	}
+	return nil
+}
+
+func (msg MsgVerifyInvariant) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// FullInvariantRoute returns the route of the invariant prefixed by its module.
+func (msg MsgVerifyInvariant) FullInvariantRoute() string {
+	return msg.InvariantModuleName + "/" + msg.InvariantRoute
+}
@@ -10,16 +10,29 @@ import (
 // set the proposer for determining distribution during endblock
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
 
+	// the fees of the previous block are allocated to the F1 periods
+	if sdk.IsUpgradeHeight(sdk.F1Distribution) {
+		k.MigrateToF1(ctx)
+	}
+
 	if ctx.BlockHeight() > 1 {
 		previousPercentPrecommitVotes := getPreviousPercentPrecommitVotes(req)
 		previousProposer := k.GetPreviousProposerConsAddr(ctx)
 		k.AllocateTokens(ctx, previousPercentPrecommitVotes, previousProposer)
 	}
 
+	k.FundCommunityPool(ctx)
+
 	consAddr := sdk.ConsAddress(req.Header.ProposerAddress)
 	k.SetPreviousProposerConsAddr(ctx, consAddr)
 }
 
+// restake the rewards paid during the block, it must run before the staking
+// end blocker so that the validator set includes the restaked rewards
+func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
+	k.RestakePendingRewards(ctx)
+}
+
 // percent precommit votes for the previous block
 func getPreviousPercentPrecommitVotes(req abci.RequestBeginBlock) sdk.Dec {
 
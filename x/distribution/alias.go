@@ -21,12 +21,14 @@ type (
 	MsgWithdrawDelegatorRewardsAll = types.MsgWithdrawDelegatorRewardsAll
 	MsgWithdrawDelegatorReward     = types.MsgWithdrawDelegatorReward
 	MsgWithdrawValidatorRewardsAll = types.MsgWithdrawValidatorRewardsAll
+	MsgSetAutoRestake              = types.MsgSetAutoRestake
 
 	GenesisState = types.GenesisState
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper  = keeper.NewKeeper
+	NewQuerier = keeper.NewQuerier
 
 	GetValidatorDistInfoKey     = keeper.GetValidatorDistInfoKey
 	GetDelegationDistInfoKey    = keeper.GetDelegationDistInfoKey
@@ -40,6 +42,7 @@ var (
 	DefaultParamspace           = keeper.DefaultParamspace
 
 	InitialFeePool = types.InitialFeePool
+	NewDecCoins    = types.NewDecCoins
 
 	NewGenesisState              = types.NewGenesisState
 	DefaultGenesisState          = types.DefaultGenesisState
@@ -51,6 +54,7 @@ var (
 	NewMsgWithdrawDelegatorRewardsAll = types.NewMsgWithdrawDelegatorRewardsAll
 	NewMsgWithdrawDelegatorReward     = types.NewMsgWithdrawDelegatorReward
 	NewMsgWithdrawValidatorRewardsAll = types.NewMsgWithdrawValidatorRewardsAll
+	NewMsgSetAutoRestake              = types.NewMsgSetAutoRestake
 )
 
 const (
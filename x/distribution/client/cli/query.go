@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// GetCmdQueryDelegatorRewards implements the command to query the pending
+// rewards of all the delegations of a delegator, or of one of them.
+func GetCmdQueryDelegatorRewards(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewards [delegator-addr] [validator-addr]",
+		Short: "Query the pending rewards of all the delegations of a delegator, or of one of them",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 2 {
+				valAddr, err := sdk.ValAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+				return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegationRewards),
+					types.NewQueryDelegationRewardsParams(delAddr, valAddr))
+			}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorTotalRewards),
+				types.NewQueryDelegatorParams(delAddr))
+		},
+	}
+}
+
+// GetCmdQueryValidatorCommission implements the command to query the
+// commission accumulated by a validator.
+func GetCmdQueryValidatorCommission(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commission [validator-addr]",
+		Short: "Query the commission accumulated by a validator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidatorCommission),
+				types.NewQueryValidatorCommissionParams(valAddr))
+		},
+	}
+}
+
+// GetCmdQueryCommunityPool implements the command to query the community pool.
+func GetCmdQueryCommunityPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "community-pool",
+		Short: "Query the coins of the community pool",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryCommunityPool), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+func query(cdc *codec.Codec, path string, params interface{}) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(res))
+	return nil
+}
@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// RegisterRoutes registers the distribution REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
+	r.HandleFunc("/distribution/delegators/{delegatorAddr}/rewards",
+		delegatorRewardsHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/distribution/delegators/{delegatorAddr}/rewards/{validatorAddr}",
+		delegationRewardsHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/distribution/validators/{validatorAddr}/commission",
+		validatorCommissionHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/distribution/community_pool",
+		communityPoolHandlerFn(cliCtx, queryRoute)).Methods("GET")
+}
+
+// http request handler to query the pending rewards of all the delegations of a delegator
+func delegatorRewardsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		delAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegatorTotalRewards),
+			types.NewQueryDelegatorParams(delAddr))
+	}
+}
+
+// http request handler to query the pending rewards of a delegation
+func delegationRewardsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		delAddr, err := sdk.AccAddressFromBech32(vars["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		valAddr, err := sdk.ValAddressFromBech32(vars["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryDelegationRewards),
+			types.NewQueryDelegationRewardsParams(delAddr, valAddr))
+	}
+}
+
+// http request handler to query the commission accumulated by a validator
+func validatorCommissionHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		valAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryValidatorCommission),
+			types.NewQueryValidatorCommissionParams(valAddr))
+	}
+}
+
+// http request handler to query the community pool
+func communityPoolHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryCommunityPool), nil)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
+
+func query(w http.ResponseWriter, cliCtx context.CLIContext, cdc *codec.Codec, path string, params interface{}) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
@@ -1,6 +1,8 @@
 package distribution
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
@@ -11,6 +13,12 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.SetCommunityTax(ctx, data.CommunityTax)
 	keeper.SetBaseProposerReward(ctx, data.BaseProposerReward)
 	keeper.SetBonusProposerReward(ctx, data.BonusProposerReward)
+	keeper.SetCommunityPoolBlockFunding(ctx, data.CommunityPoolFunding)
+	// genesis files predating the supply cap keep the default cap
+	if data.CommunityPoolSupplyCap.GT(sdk.ZeroDec()) {
+		keeper.SetCommunityPoolSupplyCap(ctx, data.CommunityPoolSupplyCap)
+	}
+	keeper.SetMinBondedDurationForRewards(ctx, data.MinBondedDuration)
 
 	for _, vdi := range data.ValidatorDistInfos {
 		keeper.SetValidatorDistInfo(ctx, vdi)
@@ -21,18 +29,90 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	for _, dw := range data.DelegatorWithdrawInfos {
 		keeper.SetDelegatorWithdrawAddr(ctx, dw.DelegatorAddr, dw.WithdrawAddr)
 	}
+	for _, delAddr := range data.DelegatorAutoRestakes {
+		keeper.SetDelegatorAutoRestake(ctx, delAddr, true)
+	}
+	for _, record := range data.ValidatorCurrentRewards {
+		keeper.SetValidatorCurrentRewards(ctx, record.ValidatorAddr, record.Rewards)
+	}
+	for _, record := range data.ValidatorHistoricalRewards {
+		keeper.SetValidatorHistoricalRewards(ctx, record.ValidatorAddr, record.Period, record.Rewards)
+	}
+	for _, record := range data.ValidatorAccumulatedCommissions {
+		keeper.SetValidatorAccumulatedCommission(ctx, record.ValidatorAddr, record.Commission)
+	}
+	for _, record := range data.DelegatorStartingInfos {
+		keeper.SetDelegatorStartingInfo(ctx, record.DelegatorAddr, record.ValidatorAddr, record.StartingInfo)
+	}
 }
 
 // WriteGenesis returns a GenesisState for a given context and keeper. The
 // GenesisState will contain the pool, and validator/delegator distribution info's
+// or F1 rewards
 func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	feePool := keeper.GetFeePool(ctx)
 	communityTax := keeper.GetCommunityTax(ctx)
 	baseProposerRewards := keeper.GetBaseProposerReward(ctx)
 	bonusProposerRewards := keeper.GetBonusProposerReward(ctx)
+	communityPoolFunding := keeper.GetCommunityPoolBlockFunding(ctx)
+	communityPoolSupplyCap := keeper.GetCommunityPoolSupplyCap(ctx)
+	minBondedDuration := keeper.GetMinBondedDurationForRewards(ctx)
 	vdis := keeper.GetAllValidatorDistInfos(ctx)
 	ddis := keeper.GetAllDelegationDistInfos(ctx)
 	dwis := keeper.GetAllDelegatorWithdrawInfos(ctx)
-	return NewGenesisState(feePool, communityTax, baseProposerRewards,
-		bonusProposerRewards, vdis, ddis, dwis)
+	autoRestakes := keeper.GetAllDelegatorAutoRestakes(ctx)
+	data := NewGenesisState(feePool, communityTax, baseProposerRewards,
+		bonusProposerRewards, communityPoolFunding, communityPoolSupplyCap, minBondedDuration, vdis, ddis, dwis, autoRestakes)
+	data.ValidatorCurrentRewards = keeper.GetAllValidatorCurrentRewards(ctx)
+	data.ValidatorHistoricalRewards = keeper.GetAllValidatorHistoricalRewards(ctx)
+	data.ValidatorAccumulatedCommissions = keeper.GetAllValidatorAccumulatedCommissions(ctx)
+	data.DelegatorStartingInfos = keeper.GetAllDelegatorStartingInfos(ctx)
+	return data
+}
+
+// ValidateGenesis validates the provided distribution genesis state to ensure
+// the expected invariants holds. (i.e. params in correct bounds, no negative
+// pools)
+func ValidateGenesis(data types.GenesisState) error {
+	if data.CommunityTax.LT(sdk.ZeroDec()) || data.CommunityTax.GT(sdk.OneDec()) {
+		return fmt.Errorf("distribution parameter CommunityTax must be in range 0 to 1, is %v", data.CommunityTax)
+	}
+	if data.BaseProposerReward.LT(sdk.ZeroDec()) || data.BonusProposerReward.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("distribution parameters BaseProposerReward %v and BonusProposerReward %v cannot be negative",
+			data.BaseProposerReward, data.BonusProposerReward)
+	}
+	if data.BaseProposerReward.Add(data.BonusProposerReward).GT(sdk.OneDec()) {
+		return fmt.Errorf("distribution parameters BaseProposerReward %v + BonusProposerReward %v must be <= 1",
+			data.BaseProposerReward, data.BonusProposerReward)
+	}
+	if data.CommunityPoolFunding.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("distribution parameter CommunityPoolFunding cannot be negative, is %v", data.CommunityPoolFunding)
+	}
+	if data.CommunityPoolSupplyCap.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("distribution parameter CommunityPoolSupplyCap cannot be negative, is %v", data.CommunityPoolSupplyCap)
+	}
+	if data.MinBondedDuration < 0 {
+		return fmt.Errorf("distribution parameter MinBondedDuration cannot be negative, is %v", data.MinBondedDuration)
+	}
+	if err := validateDecCoins(data.FeePool.Pool); err != nil {
+		return fmt.Errorf("fee_pool.pool: %v", err)
+	}
+	if err := validateDecCoins(data.FeePool.CommunityPool); err != nil {
+		return fmt.Errorf("fee_pool.community_pool: %v", err)
+	}
+	for i, record := range data.ValidatorAccumulatedCommissions {
+		if err := validateDecCoins(record.Commission); err != nil {
+			return fmt.Errorf("validator_accumulated_commissions[%d]: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func validateDecCoins(coins types.DecCoins) error {
+	for _, coin := range coins {
+		if coin.Amount.LT(sdk.ZeroDec()) {
+			return fmt.Errorf("negative amount %v of %s", coin.Amount, coin.Denom)
+		}
+	}
+	return nil
 }
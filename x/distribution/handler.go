@@ -13,6 +13,8 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case types.MsgSetWithdrawAddress:
 			return handleMsgModifyWithdrawAddress(ctx, msg, k)
+		case types.MsgSetAutoRestake:
+			return handleMsgSetAutoRestake(ctx, msg, k)
 		case types.MsgWithdrawDelegatorRewardsAll:
 			return handleMsgWithdrawDelegatorRewardsAll(ctx, msg, k)
 		case types.MsgWithdrawDelegatorReward:
@@ -43,6 +45,19 @@ func handleMsgModifyWithdrawAddress(ctx sdk.Context, msg types.MsgSetWithdrawAdd
 	}
 }
 
+func handleMsgSetAutoRestake(ctx sdk.Context, msg types.MsgSetAutoRestake, k keeper.Keeper) sdk.Result {
+
+	k.SetDelegatorAutoRestake(ctx, msg.DelegatorAddr, msg.Enabled)
+
+	tags := sdk.NewTags(
+		tags.Action, tags.ActionSetAutoRestake,
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+	)
+	return sdk.Result{
+		Tags: tags,
+	}
+}
+
 func handleMsgWithdrawDelegatorRewardsAll(ctx sdk.Context, msg types.MsgWithdrawDelegatorRewardsAll, k keeper.Keeper) sdk.Result {
 
 	k.WithdrawDelegationRewardsAll(ctx, msg.DelegatorAddr)
@@ -2,12 +2,18 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/distribution/types"
 )
 
 // Allocate fees handles distribution of the collected fees
 func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer sdk.ConsAddress) {
 
+	if k.f1Enabled() {
+		k.allocateTokensF1(ctx, percentVotes, proposer)
+		return
+	}
+
 	// get the proposer of this block
 	proposerValidator := k.stakeKeeper.ValidatorByConsAddr(ctx, proposer)
 
@@ -44,5 +50,94 @@ func (k Keeper) AllocateTokens(ctx sdk.Context, percentVotes sdk.Dec, proposer s
 	k.SetFeePool(ctx, feePool)
 
 	// clear the now distributed fees
-	k.feeCollectionKeeper.ClearCollectedFees(ctx)
+	k.clearCollectedFees(ctx, feesCollected)
+}
+
+// Allocate the collected fees to the current period of the validators. The
+// proposer reward goes to the proposer and the rest, less the community tax,
+// is shared among the bonded validators by their power in the last block.
+func (k Keeper) allocateTokensF1(ctx sdk.Context, percentVotes sdk.Dec, proposer sdk.ConsAddress) {
+	collected := k.feeCollectionKeeper.GetCollectedFees(ctx)
+	k.clearCollectedFees(ctx, collected)
+	feesCollected := types.NewDecCoins(collected)
+	if len(feesCollected) == 0 {
+		return
+	}
+
+	feePool := k.GetFeePool(ctx)
+	totalPower := k.stakeKeeper.GetLastTotalPower(ctx)
+	if totalPower == 0 {
+		// there is no validator to reward
+		feePool.CommunityPool = feePool.CommunityPool.Plus(feesCollected)
+		k.SetFeePool(ctx, feePool)
+		return
+	}
+
+	// allocated rewards to proposer
+	remaining := feesCollected
+	proposerMultiplier := k.GetBaseProposerReward(ctx).Add(k.GetBonusProposerReward(ctx).Mul(percentVotes))
+	if proposerValidator := k.stakeKeeper.ValidatorByConsAddr(ctx, proposer); proposerValidator != nil {
+		proposerReward := feesCollected.MulDec(proposerMultiplier)
+		k.allocateTokensToValidator(ctx, proposerValidator, proposerReward)
+		remaining = remaining.Minus(proposerReward)
+	}
+
+	// allocate the rest to the validators by power
+	communityTax := k.GetCommunityTax(ctx)
+	voteMultiplier := sdk.OneDec().Sub(proposerMultiplier).Sub(communityTax)
+	totalPowerDec := sdk.NewDecFromInt(totalPower)
+	k.stakeKeeper.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+		power := k.stakeKeeper.GetLastValidatorPower(ctx, validator.GetOperator())
+		powerFraction := sdk.NewDecFromInt(power).Quo(totalPowerDec)
+		reward := feesCollected.MulDec(voteMultiplier).MulDec(powerFraction)
+		k.allocateTokensToValidator(ctx, validator, reward)
+		remaining = remaining.Minus(reward)
+		return false
+	})
+
+	// the community tax and the rounding go to the community pool, the rewards
+	// rounded up are not taken from it
+	for _, coin := range remaining {
+		if coin.Amount.GT(sdk.ZeroDec()) {
+			feePool.CommunityPool = feePool.CommunityPool.Plus(types.DecCoins{coin})
+		}
+	}
+	k.SetFeePool(ctx, feePool)
+}
+
+// Clear the collected fees once they are allocated. Once the supply is
+// tracked, the fee collector burns them, the rewards allocated from them are
+// minted when they are paid.
+func (k Keeper) clearCollectedFees(ctx sdk.Context, fees sdk.Coins) {
+	if !k.supplyTracked() || fees.IsZero() {
+		k.feeCollectionKeeper.ClearCollectedFees(ctx)
+		return
+	}
+	if err := k.supplyKeeper.BurnCoins(ctx, auth.FeeCollectorName, fees); err != nil {
+		ctx.Logger().Error("failed to burn the collected fees", "fees", fees.String(), "err", err.Error())
+		k.feeCollectionKeeper.ClearCollectedFees(ctx)
+	}
+}
+
+// Mint the fixed per block community pool funding into the community pool.
+// The minted amount is reduced so the total token supply never exceeds the
+// supply cap.
+func (k Keeper) FundCommunityPool(ctx sdk.Context) {
+	funding := k.GetCommunityPoolBlockFunding(ctx)
+	if !funding.GT(sdk.ZeroDec()) {
+		return
+	}
+
+	headroom := k.GetCommunityPoolSupplyCap(ctx).Sub(k.stakeKeeper.TotalSupply(ctx))
+	funding = sdk.MinDec(funding, headroom)
+	if !funding.GT(sdk.ZeroDec()) {
+		return
+	}
+
+	k.stakeKeeper.InflateSupply(ctx, funding)
+
+	feePool := k.GetFeePool(ctx)
+	minted := types.DecCoins{{Denom: k.stakeKeeper.BondDenom(ctx), Amount: funding}}
+	feePool.CommunityPool = feePool.CommunityPool.Plus(minted)
+	k.SetFeePool(ctx, feePool)
 }
@@ -108,3 +108,35 @@ func TestAllocateTokensWithPartialPrecommitPower(t *testing.T) {
 	require.Equal(t, 1, len(feePool.Pool))
 	require.True(sdk.DecEq(t, expRes, feePool.Pool[0].Amount))
 }
+
+func TestFundCommunityPool(t *testing.T) {
+	ctx, _, keeper, sk, _ := CreateTestInputDefault(t, false, 100)
+	denom := sk.GetParams(ctx).BondDenom
+
+	// no funding by default
+	initialSupply := sk.TotalSupply(ctx)
+	keeper.FundCommunityPool(ctx)
+	require.Empty(t, keeper.GetFeePool(ctx).CommunityPool)
+	require.True(sdk.DecEq(t, initialSupply, sk.TotalSupply(ctx)))
+
+	// the pool grows by the fixed amount every block
+	funding := sdk.NewDec(10)
+	keeper.SetCommunityPoolBlockFunding(ctx, funding)
+	for i := int64(1); i <= 3; i++ {
+		keeper.FundCommunityPool(ctx)
+		pool := keeper.GetFeePool(ctx).CommunityPool
+		require.Equal(t, 1, len(pool))
+		require.Equal(t, denom, pool[0].Denom)
+		require.True(sdk.DecEq(t, funding.MulInt(i), pool[0].Amount))
+		require.True(sdk.DecEq(t, initialSupply.Add(funding.MulInt(i)), sk.TotalSupply(ctx)))
+	}
+
+	// funding stops once the supply cap is reached
+	supplyCap := sk.TotalSupply(ctx).Add(sdk.NewDec(15))
+	keeper.SetCommunityPoolSupplyCap(ctx, supplyCap)
+	keeper.FundCommunityPool(ctx)
+	keeper.FundCommunityPool(ctx)
+	keeper.FundCommunityPool(ctx)
+	require.True(sdk.DecEq(t, supplyCap, sk.TotalSupply(ctx)))
+	require.True(sdk.DecEq(t, sdk.NewDec(45), keeper.GetFeePool(ctx).CommunityPool[0].Amount))
+}
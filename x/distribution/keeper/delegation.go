@@ -67,6 +67,98 @@ func (k Keeper) RemoveDelegatorWithdrawAddr(ctx sdk.Context, delAddr, withdrawAd
 	store.Delete(GetDelegatorWithdrawAddrKey(delAddr))
 }
 
+// check whether the delegator restakes its rewards
+func (k Keeper) GetDelegatorAutoRestake(ctx sdk.Context, delAddr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetDelegatorAutoRestakeKey(delAddr))
+}
+
+// set whether the delegator restakes its rewards
+func (k Keeper) SetDelegatorAutoRestake(ctx sdk.Context, delAddr sdk.AccAddress, enabled bool) {
+	store := ctx.KVStore(k.storeKey)
+	if enabled {
+		store.Set(GetDelegatorAutoRestakeKey(delAddr), []byte{0x01})
+	} else {
+		store.Delete(GetDelegatorAutoRestakeKey(delAddr))
+	}
+}
+
+//___________________________________________________________________________________________
+
+// pay the rewards of a delegation to the withdraw address of the delegator. The
+// rewards in the bond denom of a delegator restaking its rewards are paid to the
+// delegator instead, and delegated back to the validator at the end of the block.
+func (k Keeper) payDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, rewards sdk.Coins) {
+	if k.GetDelegatorAutoRestake(ctx, delAddr) {
+		bondDenom := k.stakeKeeper.BondDenom(ctx)
+		if amount := rewards.AmountOf(bondDenom); amount > 0 {
+			restaked := sdk.Coins{sdk.NewCoin(bondDenom, amount)}
+			k.addCoins(ctx, delAddr, restaked)
+			k.addPendingRestake(ctx, delAddr, valAddr, amount)
+
+			rewards = rewards.Minus(restaked)
+			if rewards.IsZero() {
+				return
+			}
+		}
+	}
+	k.addCoins(ctx, k.GetDelegatorWithdrawAddr(ctx, delAddr), rewards)
+}
+
+// pay coins to addr, they are minted in the total supply once it is tracked
+func (k Keeper) addCoins(ctx sdk.Context, addr sdk.AccAddress, coins sdk.Coins) {
+	if minted := nonZeroCoins(coins); k.supplyTracked() && !minted.IsZero() {
+		if err := k.supplyKeeper.Mint(ctx, minted); err != nil {
+			panic(err)
+		}
+	}
+	_, _, err := k.bankKeeper.AddCoins(ctx, addr, coins)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (k Keeper) addPendingRestake(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount int64) {
+	store := ctx.KVStore(k.storeKey)
+	key := GetPendingRestakeKey(delAddr, valAddr)
+	var pending int64
+	if b := store.Get(key); b != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &pending)
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(pending+amount))
+}
+
+// RestakePendingRewards delegates the rewards paid during the block to the
+// delegators restaking them back to their validators. The rewards of the
+// delegations that can't be restaked, e.g. to a removed validator, stay in the
+// account of the delegator.
+func (k Keeper) RestakePendingRewards(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, PendingRestakeKey)
+	var keys [][]byte
+	var amounts []int64
+	for ; iterator.Valid(); iterator.Next() {
+		var amount int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &amount)
+		keys = append(keys, iterator.Key())
+		amounts = append(amounts, amount)
+	}
+	iterator.Close()
+
+	// the delegations withdraw their rewards again, they were already paid
+	// during the block so nothing is restaked twice
+	bondDenom := k.stakeKeeper.BondDenom(ctx)
+	for i, key := range keys {
+		store.Delete(key)
+		delAddr := sdk.AccAddress(key[1 : 1+sdk.AddrLen])
+		valAddr := sdk.ValAddress(key[1+sdk.AddrLen:])
+		if err := k.stakeKeeper.Restake(ctx, delAddr, valAddr, sdk.NewCoin(bondDenom, amounts[i])); err != nil {
+			ctx.Logger().With("module", "distribution").Info("failed to restake the rewards",
+				"delegator", delAddr.String(), "validator", valAddr.String(), "err", err.Error())
+		}
+	}
+}
+
 //___________________________________________________________________________________________
 
 // Withdraw all the rewards for a single delegation
@@ -75,6 +167,16 @@ func (k Keeper) RemoveDelegatorWithdrawAddr(ctx sdk.Context, delAddr, withdrawAd
 func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccAddress,
 	valAddr sdk.ValAddress) sdk.Error {
 
+	if k.f1Enabled() {
+		return k.withdrawDelegationRewardF1(ctx, delegatorAddr, valAddr)
+	}
+	return k.withdrawDelegationRewardByHeight(ctx, delegatorAddr, valAddr)
+}
+
+// withdraw the rewards of a delegation accumulated by height
+func (k Keeper) withdrawDelegationRewardByHeight(ctx sdk.Context, delegatorAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) sdk.Error {
+
 	if !k.HasDelegationDistInfo(ctx, delegatorAddr, valAddr) {
 		return types.ErrNoDelegationDistInfo(k.codespace)
 	}
@@ -89,19 +191,16 @@ func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccA
 	validator := k.stakeKeeper.Validator(ctx, valAddr)
 	delegation := k.stakeKeeper.Delegation(ctx, delegatorAddr, valAddr)
 
+	delInfo, valInfo, shares := k.forfeitIneligibleRewards(ctx, delInfo, valInfo, validator, delegation)
 	delInfo, valInfo, feePool, withdraw := delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
-		lastValPower, validator.GetDelegatorShares(), delegation.GetShares(), validator.GetCommission())
+		lastValPower, validator.GetDelegatorShares(), shares, validator.GetCommission())
 
 	k.SetValidatorDistInfo(ctx, valInfo)
 	k.SetDelegationDistInfo(ctx, delInfo)
-	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delegatorAddr)
 	coinsToAdd, change := withdraw.TruncateDecimal()
 	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
 	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, coinsToAdd)
-	if err != nil {
-		panic(err)
-	}
+	k.payDelegationRewards(ctx, delegatorAddr, valAddr, coinsToAdd)
 	return nil
 }
 
@@ -109,6 +208,18 @@ func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccA
 
 // return all rewards for all delegations of a delegator
 func (k Keeper) WithdrawDelegationRewardsAll(ctx sdk.Context, delegatorAddr sdk.AccAddress) {
+	// the rewards are restaked to the validator they come from, the F1 rewards
+	// are withdrawn per delegation anyway
+	if k.f1Enabled() || k.GetDelegatorAutoRestake(ctx, delegatorAddr) {
+		k.stakeKeeper.IterateDelegations(ctx, delegatorAddr, func(_ int64, del sdk.Delegation) (stop bool) {
+			if err := k.WithdrawDelegationReward(ctx, delegatorAddr, del.GetValidatorAddr()); err != nil {
+				panic(err)
+			}
+			return false
+		})
+		return
+	}
+
 	height := ctx.BlockHeight()
 	withdraw := k.getDelegatorRewardsAll(ctx, delegatorAddr, height)
 	feePool := k.GetFeePool(ctx)
@@ -116,10 +227,20 @@ func (k Keeper) WithdrawDelegationRewardsAll(ctx sdk.Context, delegatorAddr sdk.
 	coinsToAdd, change := withdraw.TruncateDecimal()
 	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
 	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, coinsToAdd)
-	if err != nil {
-		panic(err)
+	k.addCoins(ctx, withdrawAddr, coinsToAdd)
+}
+
+// forfeit the rewards of the ineligible shares of a delegation, returns the
+// shares it withdraws the rewards of. The shares are not tracked before the
+// upgrade, so that the dist infos are unchanged.
+func (k Keeper) forfeitIneligibleRewards(ctx sdk.Context, delInfo types.DelegationDistInfo, valInfo types.ValidatorDistInfo,
+	validator sdk.Validator, delegation sdk.Delegation) (types.DelegationDistInfo, types.ValidatorDistInfo, sdk.Dec) {
+
+	if !sdk.IsUpgrade(sdk.RewardsMinBondedDuration) {
+		return delInfo, valInfo, delegation.GetShares()
 	}
+	return delInfo.ForfeitIneligibleRewards(valInfo, ctx.BlockHeight(), ctx.BlockHeader().Time,
+		k.enforcedMinBondedDuration(ctx), validator.GetDelegatorShares(), delegation.GetShares())
 }
 
 // return all rewards for all delegations of a delegator
@@ -139,8 +260,9 @@ func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress,
 		validator := k.stakeKeeper.Validator(ctx, valAddr)
 		delegation := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
 
+		delInfo, valInfo, shares := k.forfeitIneligibleRewards(ctx, delInfo, valInfo, validator, delegation)
 		delInfo, valInfo, feePool, diWithdraw := delInfo.WithdrawRewards(feePool, valInfo, height, lastTotalPower,
-			lastValPower, validator.GetDelegatorShares(), delegation.GetShares(), validator.GetCommission())
+			lastValPower, validator.GetDelegatorShares(), shares, validator.GetCommission())
 		withdraw = withdraw.Plus(diWithdraw)
 		k.SetFeePool(ctx, feePool)
 		k.SetValidatorDistInfo(ctx, valInfo)
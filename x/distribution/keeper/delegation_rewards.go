@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// check whether a delegation has a starting info
+func (k Keeper) HasDelegatorStartingInfo(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetDelegatorStartingInfoKey(delAddr, valAddr))
+}
+
+// get the starting info of a delegation
+func (k Keeper) GetDelegatorStartingInfo(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (info types.DelegatorStartingInfo) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegatorStartingInfoKey(delAddr, valAddr))
+	if b == nil {
+		panic("Stored delegator starting info should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &info)
+	return
+}
+
+// set the starting info of a delegation
+func (k Keeper) SetDelegatorStartingInfo(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	info types.DelegatorStartingInfo) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(info)
+	store.Set(GetDelegatorStartingInfoKey(delAddr, valAddr), b)
+}
+
+// remove the starting info of a delegation
+func (k Keeper) RemoveDelegatorStartingInfo(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegatorStartingInfoKey(delAddr, valAddr))
+}
+
+// iterate over the starting infos of all the delegations
+func (k Keeper) IterateDelegatorStartingInfos(ctx sdk.Context,
+	fn func(delAddr sdk.AccAddress, valAddr sdk.ValAddress, info types.DelegatorStartingInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegatorStartingInfoKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		var info types.DelegatorStartingInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &info)
+		delAddr := sdk.AccAddress(key[1 : 1+sdk.AddrLen])
+		valAddr := sdk.ValAddress(key[1+sdk.AddrLen:])
+		if fn(delAddr, valAddr, info) {
+			return
+		}
+	}
+}
+
+//___________________________________________________________________________________________
+
+// start the rewards of a delegation from the current period of its validator,
+// it must be called before the shares of the delegation change
+func (k Keeper) initializeDelegation(ctx sdk.Context, val sdk.Validator, delAddr sdk.AccAddress,
+	ineligible types.IneligibleShares) {
+
+	valAddr := val.GetOperator()
+	previousPeriod := k.incrementValidatorPeriod(ctx, val)
+	k.incrementReferenceCount(ctx, valAddr, previousPeriod)
+	k.SetDelegatorStartingInfo(ctx, delAddr, valAddr,
+		types.NewDelegatorStartingInfo(previousPeriod, ctx.BlockHeight(), ctx.BlockHeader().Time, ineligible))
+}
+
+// the rewards of the shares of a delegation accrued between its starting
+// period and endingPeriod
+func (k Keeper) calculateDelegationRewards(ctx sdk.Context, valAddr sdk.ValAddress, info types.DelegatorStartingInfo,
+	endingPeriod uint64, shares sdk.Dec) types.DecCoins {
+
+	starting := k.GetValidatorHistoricalRewards(ctx, valAddr, info.PreviousPeriod)
+	ending := k.GetValidatorHistoricalRewards(ctx, valAddr, endingPeriod)
+	return ending.CumulativeRewardRatio.Minus(starting.CumulativeRewardRatio).MulShares(shares)
+}
+
+// Withdraw the rewards of a delegation and restart them from the current
+// period of its validator. The rewards forfeited by the shares not yet bonded
+// for the minimum duration go back to its validator, so they are shared among
+// the delegators over the next period.
+func (k Keeper) withdrawDelegationRewardF1(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.Error {
+	if !k.HasDelegatorStartingInfo(ctx, delAddr, valAddr) {
+		return types.ErrNoDelegationDistInfo(k.codespace)
+	}
+	val := k.stakeKeeper.Validator(ctx, valAddr)
+	del := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
+	if val == nil || del == nil {
+		return types.ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	info := k.GetDelegatorStartingInfo(ctx, delAddr, valAddr)
+	endingPeriod := k.incrementValidatorPeriod(ctx, val)
+	rewards := k.calculateDelegationRewards(ctx, valAddr, info, endingPeriod, del.GetShares())
+	now, minDuration := ctx.BlockHeader().Time, k.enforcedMinBondedDuration(ctx)
+	rewards, forfeited := info.SplitIneligibleRewards(rewards, del.GetShares(), now, minDuration)
+	if len(forfeited) > 0 {
+		current := k.GetValidatorCurrentRewards(ctx, valAddr)
+		current.Rewards = current.Rewards.Plus(forfeited)
+		k.SetValidatorCurrentRewards(ctx, valAddr, current)
+	}
+
+	// restart the rewards from the period which just ended
+	k.decrementReferenceCount(ctx, valAddr, info.PreviousPeriod)
+	k.incrementReferenceCount(ctx, valAddr, endingPeriod)
+	ineligible := info.IneligibleShares.Cap(del.GetShares()).RemoveEligible(now, minDuration)
+	k.SetDelegatorStartingInfo(ctx, delAddr, valAddr,
+		types.NewDelegatorStartingInfo(endingPeriod, ctx.BlockHeight(), now, ineligible))
+
+	truncated, change := rewards.TruncateDecimal()
+	if change = nonZeroDecCoins(change); len(change) > 0 {
+		feePool := k.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Plus(change)
+		k.SetFeePool(ctx, feePool)
+	}
+	if truncated = nonZeroCoins(truncated); !truncated.IsZero() {
+		k.payDelegationRewards(ctx, delAddr, valAddr, truncated)
+	}
+	return nil
+}
+
+// remove the starting info of a removed delegation, its rewards have been
+// withdrawn before its shares were removed
+func (k Keeper) removeDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	if !k.HasDelegatorStartingInfo(ctx, delAddr, valAddr) {
+		return
+	}
+	info := k.GetDelegatorStartingInfo(ctx, delAddr, valAddr)
+	k.decrementReferenceCount(ctx, valAddr, info.PreviousPeriod)
+	k.RemoveDelegatorStartingInfo(ctx, delAddr, valAddr)
+}
+
+//___________________________________________________________________________________________
+
+// the rewards a delegation would withdraw now, including its share of the
+// current period of its validator and less the rewards it would forfeit
+func (k Keeper) GetDelegationPendingRewards(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (types.DecCoins, sdk.Error) {
+
+	if !k.f1Enabled() {
+		return nil, types.ErrF1DistributionDisabled(k.codespace)
+	}
+	if !k.HasDelegatorStartingInfo(ctx, delAddr, valAddr) {
+		return nil, types.ErrNoDelegationDistInfo(k.codespace)
+	}
+	val := k.stakeKeeper.Validator(ctx, valAddr)
+	del := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
+	if val == nil || del == nil {
+		return nil, types.ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	info := k.GetDelegatorStartingInfo(ctx, delAddr, valAddr)
+	current := k.GetValidatorCurrentRewards(ctx, valAddr)
+	endingRatio := k.GetValidatorHistoricalRewards(ctx, valAddr, current.Period-1).CumulativeRewardRatio
+	if !val.GetDelegatorShares().IsZero() {
+		endingRatio = endingRatio.Plus(types.NewRewardRatios(current.Rewards, val.GetDelegatorShares()))
+	}
+	starting := k.GetValidatorHistoricalRewards(ctx, valAddr, info.PreviousPeriod)
+	rewards := endingRatio.Minus(starting.CumulativeRewardRatio).MulShares(del.GetShares())
+	rewards, _ = info.SplitIneligibleRewards(rewards, del.GetShares(), ctx.BlockHeader().Time, k.enforcedMinBondedDuration(ctx))
+	return rewards, nil
+}
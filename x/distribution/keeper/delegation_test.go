@@ -2,8 +2,11 @@ package keeper
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
 )
@@ -252,3 +255,213 @@ func TestWithdrawDelegationRewardsAll(t *testing.T) {
 	expRes := sdk.NewDecWithoutFra(40).Add(feesInVal1).Add(feesInVal2).Add(feesInVal3).Add(feesInVal1Proposer).TruncateInt()
 	require.True(t, expRes == amt)
 }
+
+func TestWithdrawDelegationRewardMinBondedDuration(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RewardsMinBondedDuration, -1)
+	defer sdk.UpgradeMgr.Reset()
+
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	keeper.SetMinBondedDurationForRewards(ctx, 10*time.Second)
+	feeInputs := sdk.NewDecWithoutFra(100).RawInt()
+	start := time.Unix(1000, 0).UTC()
+	ctx = ctx.WithBlockTime(start)
+
+	//first make a validator
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+
+	// delegate
+	msgDelegate := stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)
+	got = stakeHandler(ctx, msgDelegate)
+	require.True(t, got.IsOK())
+	initial := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+
+	// allocate 100 denom of fees
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	// the freshly bonded delegation earns nothing before the threshold
+	ctx = ctx.WithBlockHeight(5).WithBlockTime(start.Add(5 * time.Second))
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, initial, amt)
+
+	// once the threshold has passed rewards accrue normally
+	ctx = ctx.WithBlockHeight(20).WithBlockTime(start.Add(20 * time.Second))
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	amt = accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.True(t, amt > initial)
+
+	delInfo := keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, int64(20), delInfo.WithdrawalHeight)
+	require.Equal(t, start.Add(20*time.Second), delInfo.WithdrawalTime)
+	require.Empty(t, delInfo.IneligibleShares)
+
+	// only the shares topping up the delegation must be bonded for the
+	// threshold, the shares bonded before keep earning
+	ctx = ctx.WithBlockHeight(25).WithBlockTime(start.Add(25 * time.Second))
+	got = stakeHandler(ctx, msgDelegate)
+	require.True(t, got.IsOK())
+	delInfo = keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, types.IneligibleShares{{Shares: sdk.NewDecWithoutFra(10), BondTime: start.Add(25 * time.Second)}},
+		delInfo.IneligibleShares)
+	topped := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+
+	ctx = ctx.WithBlockHeight(30).WithBlockTime(start.Add(30 * time.Second))
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	amt = accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.True(t, amt > topped)
+	require.Len(t, keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).IneligibleShares, 1)
+
+	ctx = ctx.WithBlockHeight(40).WithBlockTime(start.Add(40 * time.Second))
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	require.Empty(t, keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1).IneligibleShares)
+}
+
+func TestWithdrawDelegationRewardMinBondedDurationBeforeUpgrade(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	keeper.SetMinBondedDurationForRewards(ctx, 10*time.Second)
+	ctx = ctx.WithBlockTime(time.Unix(1000, 0))
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+
+	ctx = ctx.WithBlockHeight(1)
+	msgDelegate := stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)
+	got = stakeHandler(ctx, msgDelegate)
+	require.True(t, got.IsOK())
+	initial := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+
+	// without the upgrade the bonded shares are not tracked and not enforced
+	delInfo := keeper.GetDelegationDistInfo(ctx, delAddr1, valOpAddr1)
+	require.Empty(t, delInfo.IneligibleShares)
+	require.True(t, delInfo.WithdrawalTime.IsZero())
+
+	feeInputs := sdk.NewDecWithoutFra(100).RawInt()
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	ctx = ctx.WithBlockHeight(5)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.True(t, amt > initial)
+}
+
+func TestWithdrawDelegationRewardAutoRestake(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	//first make a validator
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	// delegate, restaking the rewards
+	msgDelegate := stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10)
+	got = stakeHandler(ctx, msgDelegate)
+	require.True(t, got.IsOK())
+	keeper.SetDelegatorAutoRestake(ctx, delAddr1, true)
+	keeper.SetDelegatorWithdrawAddr(ctx, delAddr1, delAddr2)
+	withdrawAddrCoins := accMapper.GetAccount(ctx, delAddr2).GetCoins().AmountOf(denom)
+	require.Equal(t, []sdk.AccAddress{delAddr1}, keeper.GetAllDelegatorAutoRestakes(ctx))
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewDecWithoutFra(100).RawInt()
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	// withdraw delegation, the rewards are paid to the delegator until the end
+	// of the block
+	ctx = ctx.WithBlockHeight(1)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.WithdrawDelegationRewardsAll(ctx, delAddr1)
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(140).RawInt(), amt) // 90 + 100 tokens * 10/20
+	require.Equal(t, withdrawAddrCoins, accMapper.GetAccount(ctx, delAddr2).GetCoins().AmountOf(denom))
+
+	// and delegated back to the validator
+	keeper.RestakePendingRewards(ctx)
+	amt = accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, sdk.NewDecWithoutFra(90).RawInt(), amt)
+	delegation, found := sk.GetDelegation(ctx, delAddr1, valOpAddr1)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithoutFra(60), delegation.GetShares())
+
+	// nothing is restaked twice
+	keeper.RestakePendingRewards(ctx)
+	delegation, _ = sk.GetDelegation(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, sdk.NewDecWithoutFra(60), delegation.GetShares())
+
+	// the rewards go to the withdraw address once the restaking is disabled
+	keeper.SetDelegatorAutoRestake(ctx, delAddr1, false)
+	require.Empty(t, keeper.GetAllDelegatorAutoRestakes(ctx))
+}
+
+// supply keeper recording the coins minted and burned
+type recordingSupplyKeeper struct {
+	minted sdk.Coins
+	burned map[string]sdk.Coins
+}
+
+func (sk *recordingSupplyKeeper) Mint(_ sdk.Context, coins sdk.Coins) sdk.Error {
+	sk.minted = sk.minted.Plus(coins)
+	return nil
+}
+
+func (sk *recordingSupplyKeeper) BurnCoins(_ sdk.Context, name string, coins sdk.Coins) sdk.Error {
+	sk.burned[name] = sk.burned[name].Plus(coins)
+	return nil
+}
+
+func TestWithdrawDelegationRewardSupplyTracked(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	supplyKeeper := &recordingSupplyKeeper{burned: map[string]sdk.Coins{}}
+	keeper.SetupForSupply(supplyKeeper)
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+
+	msgCreateValidator := stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+
+	// the allocated fees are burned by the fee collector
+	fees := sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())}
+	fck.SetCollectedFees(fees)
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	require.Equal(t, map[string]sdk.Coins{auth.FeeCollectorName: fees}, supplyKeeper.burned)
+
+	// and the paid rewards are minted
+	ctx = ctx.WithBlockHeight(1)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+	before := accMapper.GetAccount(ctx, delAddr1).GetCoins()
+	keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1)
+	paid := accMapper.GetAccount(ctx, delAddr1).GetCoins().Minus(before)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(50).RawInt())}, paid)
+	require.Equal(t, paid, supplyKeeper.minted)
+}
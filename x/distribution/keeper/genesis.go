@@ -36,15 +36,91 @@ func (k Keeper) GetAllDelegationDistInfos(ctx sdk.Context) (ddis []types.Delegat
 // Get the set of all delegator-withdraw addresses with no limits, used during genesis dump
 func (k Keeper) GetAllDelegatorWithdrawInfos(ctx sdk.Context) (dwis []types.DelegatorWithdrawInfo) {
 	store := ctx.KVStore(k.storeKey)
-	iterator := sdk.KVStorePrefixIterator(store, DelegationDistInfoKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegatorWithdrawInfoKey)
 	defer iterator.Close()
 
 	for ; iterator.Valid(); iterator.Next() {
 		dw := types.DelegatorWithdrawInfo{
-			DelegatorAddr: sdk.AccAddress(iterator.Key()),
+			DelegatorAddr: sdk.AccAddress(iterator.Key()[len(DelegatorWithdrawInfoKey):]),
 			WithdrawAddr:  sdk.AccAddress(iterator.Value()),
 		}
 		dwis = append(dwis, dw)
 	}
 	return dwis
 }
+
+// Get the set of all the delegators restaking their rewards with no limits, used during genesis dump
+func (k Keeper) GetAllDelegatorAutoRestakes(ctx sdk.Context) (delAddrs []sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegatorAutoRestakeKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		delAddrs = append(delAddrs, sdk.AccAddress(iterator.Key()[len(DelegatorAutoRestakeKey):]))
+	}
+	return delAddrs
+}
+
+// Get the current rewards of all the validators with no limits, used during genesis dump
+func (k Keeper) GetAllValidatorCurrentRewards(ctx sdk.Context) (records []types.ValidatorCurrentRewardsRecord) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorCurrentRewardsKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var rewards types.ValidatorCurrentRewards
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &rewards)
+		records = append(records, types.ValidatorCurrentRewardsRecord{
+			ValidatorAddr: sdk.ValAddress(iterator.Key()[len(ValidatorCurrentRewardsKey):]),
+			Rewards:       rewards,
+		})
+	}
+	return records
+}
+
+// Get the historical rewards of all the validator periods with no limits, used during genesis dump
+func (k Keeper) GetAllValidatorHistoricalRewards(ctx sdk.Context) (records []types.ValidatorHistoricalRewardsRecord) {
+	k.IterateValidatorHistoricalRewards(ctx, func(valAddr sdk.ValAddress, period uint64,
+		rewards types.ValidatorHistoricalRewards) (stop bool) {
+
+		records = append(records, types.ValidatorHistoricalRewardsRecord{
+			ValidatorAddr: valAddr,
+			Period:        period,
+			Rewards:       rewards,
+		})
+		return false
+	})
+	return records
+}
+
+// Get the accumulated commission of all the validators with no limits, used during genesis dump
+func (k Keeper) GetAllValidatorAccumulatedCommissions(ctx sdk.Context) (records []types.ValidatorAccumulatedCommissionRecord) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorAccumulatedCommissionKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var commission types.DecCoins
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &commission)
+		records = append(records, types.ValidatorAccumulatedCommissionRecord{
+			ValidatorAddr: sdk.ValAddress(iterator.Key()[len(ValidatorAccumulatedCommissionKey):]),
+			Commission:    commission,
+		})
+	}
+	return records
+}
+
+// Get the starting info of all the delegations with no limits, used during genesis dump
+func (k Keeper) GetAllDelegatorStartingInfos(ctx sdk.Context) (records []types.DelegatorStartingInfoRecord) {
+	k.IterateDelegatorStartingInfos(ctx, func(delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+		info types.DelegatorStartingInfo) (stop bool) {
+
+		records = append(records, types.DelegatorStartingInfoRecord{
+			DelegatorAddr: delAddr,
+			ValidatorAddr: valAddr,
+			StartingInfo:  info,
+		})
+		return false
+	})
+	return records
+}
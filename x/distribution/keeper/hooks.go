@@ -8,6 +8,11 @@ import (
 // Create a new validator distribution record
 func (k Keeper) onValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
 
+	if k.f1Enabled() {
+		k.initializeValidator(ctx, addr)
+		return
+	}
+
 	height := ctx.BlockHeight()
 	vdi := types.ValidatorDistInfo{
 		OperatorAddr:            addr,
@@ -19,8 +24,12 @@ func (k Keeper) onValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
 	k.SetValidatorDistInfo(ctx, vdi)
 }
 
-// Withdrawal all validator rewards
+// Withdrawal all validator rewards, the F1 periods don't depend on the power
+// or the commission of the validator
 func (k Keeper) onValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
+	if k.f1Enabled() {
+		return
+	}
 	// This doesn't need to be run at genesis
 	if ctx.BlockHeight() > 0 {
 		if err := k.WithdrawValidatorRewardsAll(ctx, addr); err != nil {
@@ -31,6 +40,10 @@ func (k Keeper) onValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
 
 // Withdrawal all validator distribution rewards and cleanup the distribution record
 func (k Keeper) onValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
+	if k.f1Enabled() {
+		k.removeValidatorRewards(ctx, addr)
+		return
+	}
 	k.RemoveValidatorDistInfo(ctx, addr)
 }
 
@@ -40,11 +53,19 @@ func (k Keeper) onValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
 func (k Keeper) onDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress,
 	valAddr sdk.ValAddress) {
 
+	if k.f1Enabled() {
+		k.initializeDelegation(ctx, k.stakeKeeper.Validator(ctx, valAddr), delAddr, nil)
+		return
+	}
+
 	ddi := types.DelegationDistInfo{
 		DelegatorAddr:    delAddr,
 		ValOperatorAddr:  valAddr,
 		WithdrawalHeight: ctx.BlockHeight(),
 	}
+	if sdk.IsUpgrade(sdk.RewardsMinBondedDuration) {
+		ddi.WithdrawalTime = ctx.BlockHeader().Time
+	}
 	k.SetDelegationDistInfo(ctx, ddi)
 }
 
@@ -57,10 +78,33 @@ func (k Keeper) onDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddre
 	}
 }
 
+// Track the shares added to a delegation, they must be bonded for the minimum
+// duration before they earn rewards while the shares bonded before keep earning
+func (k Keeper) onDelegationSharesIncreased(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress, shares sdk.Dec) {
+
+	if !sdk.IsUpgrade(sdk.RewardsMinBondedDuration) || k.GetMinBondedDurationForRewards(ctx) <= 0 {
+		return
+	}
+	if k.f1Enabled() {
+		info := k.GetDelegatorStartingInfo(ctx, delAddr, valAddr)
+		info.IneligibleShares = info.IneligibleShares.Add(shares, ctx.BlockHeader().Time)
+		k.SetDelegatorStartingInfo(ctx, delAddr, valAddr, info)
+		return
+	}
+	ddi := k.GetDelegationDistInfo(ctx, delAddr, valAddr)
+	ddi.IneligibleShares = ddi.IneligibleShares.Add(shares, ctx.BlockHeader().Time)
+	k.SetDelegationDistInfo(ctx, ddi)
+}
+
 // Withdrawal all validator distribution rewards and cleanup the distribution record
 func (k Keeper) onDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress,
 	valAddr sdk.ValAddress) {
 
+	if k.f1Enabled() {
+		k.removeDelegationRewards(ctx, delAddr, valAddr)
+		return
+	}
 	k.RemoveDelegationDistInfo(ctx, delAddr, valAddr)
 }
 
@@ -94,6 +138,9 @@ func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddres
 	h.k.onValidatorModified(ctx, valAddr)
 	h.k.onDelegationSharesModified(ctx, delAddr, valAddr)
 }
+func (h Hooks) OnDelegationSharesIncreased(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, shares sdk.Dec) {
+	h.k.onDelegationSharesIncreased(ctx, delAddr, valAddr, shares)
+}
 func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 	h.k.onDelegationRemoved(ctx, delAddr, valAddr)
 }
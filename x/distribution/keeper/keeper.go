@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/distribution/types"
@@ -18,6 +20,10 @@ type Keeper struct {
 
 	// codespace
 	codespace sdk.CodespaceType
+
+	// if you want to track the supply of the distributed fees, you need call
+	// `SetupForSupply`
+	supplyKeeper types.SupplyKeeper
 }
 
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace, ck types.BankKeeper,
@@ -35,6 +41,16 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace, c
 	return keeper
 }
 
+// SetupForSupply tracks the fees and rewards in the total supply.
+func (k *Keeper) SetupForSupply(supplyKeeper types.SupplyKeeper) {
+	k.supplyKeeper = supplyKeeper
+}
+
+// whether the fees and rewards are tracked in the total supply
+func (k Keeper) supplyTracked() bool {
+	return k.supplyKeeper != nil && sdk.IsUpgrade(sdk.SupplyTracking)
+}
+
 //______________________________________________________________________
 
 // get the global fee pool distribution info
@@ -86,6 +102,9 @@ func ParamTypeTable() params.TypeTable {
 		ParamStoreKeyCommunityTax, sdk.Dec{},
 		ParamStoreKeyBaseProposerReward, sdk.Dec{},
 		ParamStoreKeyBonusProposerReward, sdk.Dec{},
+		ParamStoreKeyCommunityPoolBlockFunding, sdk.Dec{},
+		ParamStoreKeyCommunityPoolSupplyCap, sdk.Dec{},
+		ParamStoreKeyMinBondedDuration, time.Duration(0),
 	)
 }
 
@@ -127,3 +146,54 @@ func (k Keeper) GetBonusProposerReward(ctx sdk.Context) sdk.Dec {
 func (k Keeper) SetBonusProposerReward(ctx sdk.Context, percent sdk.Dec) {
 	k.paramSpace.Set(ctx, ParamStoreKeyBonusProposerReward, &percent)
 }
+
+// Returns the fixed amount of bond denom tokens minted to the community pool
+// every block, zero if it has never been set
+// nolint: errcheck
+func (k Keeper) GetCommunityPoolBlockFunding(ctx sdk.Context) sdk.Dec {
+	amount := sdk.ZeroDec()
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyCommunityPoolBlockFunding, &amount)
+	return amount
+}
+
+// nolint: errcheck
+func (k Keeper) SetCommunityPoolBlockFunding(ctx sdk.Context, amount sdk.Dec) {
+	k.paramSpace.Set(ctx, ParamStoreKeyCommunityPoolBlockFunding, &amount)
+}
+
+// Returns the total token supply above which no community pool funding is
+// minted, defaults to the maximum total supply of a token
+// nolint: errcheck
+func (k Keeper) GetCommunityPoolSupplyCap(ctx sdk.Context) sdk.Dec {
+	supplyCap := sdk.NewDec(sdk.TokenMaxTotalSupply)
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyCommunityPoolSupplyCap, &supplyCap)
+	return supplyCap
+}
+
+// nolint: errcheck
+func (k Keeper) SetCommunityPoolSupplyCap(ctx sdk.Context, supplyCap sdk.Dec) {
+	k.paramSpace.Set(ctx, ParamStoreKeyCommunityPoolSupplyCap, &supplyCap)
+}
+
+// Returns the duration the delegated shares must have been bonded for before
+// they accrue rewards, zero if it has never been set
+// nolint: errcheck
+func (k Keeper) GetMinBondedDurationForRewards(ctx sdk.Context) time.Duration {
+	var duration time.Duration
+	k.paramSpace.GetIfExists(ctx, ParamStoreKeyMinBondedDuration, &duration)
+	return duration
+}
+
+// nolint: errcheck
+func (k Keeper) SetMinBondedDurationForRewards(ctx sdk.Context, duration time.Duration) {
+	k.paramSpace.Set(ctx, ParamStoreKeyMinBondedDuration, &duration)
+}
+
+// minimum bonded duration enforced on the rewards, none before the upgrade
+// tracking the bonded shares of the delegations
+func (k Keeper) enforcedMinBondedDuration(ctx sdk.Context) time.Duration {
+	if !sdk.IsUpgrade(sdk.RewardsMinBondedDuration) {
+		return 0
+	}
+	return k.GetMinBondedDurationForRewards(ctx)
+}
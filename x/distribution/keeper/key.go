@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,11 +13,23 @@ var (
 	DelegationDistInfoKey    = []byte{0x02} // prefix for each key to a delegation distribution
 	DelegatorWithdrawInfoKey = []byte{0x03} // prefix for each key to a delegator withdraw info
 	ProposerKey              = []byte{0x04} // key for storing the proposer operator address
+	DelegatorAutoRestakeKey  = []byte{0x05} // prefix for each key to a delegator restaking its rewards
+	PendingRestakeKey        = []byte{0x06} // prefix for each key to the rewards of a delegation restaked at the end of the block
+
+	// F1 distribution state
+	ValidatorCurrentRewardsKey        = []byte{0x07} // prefix for each key to the current rewards of a validator
+	ValidatorHistoricalRewardsKey     = []byte{0x08} // prefix for each key to the historical rewards of a validator period
+	ValidatorAccumulatedCommissionKey = []byte{0x09} // prefix for each key to the accumulated commission of a validator
+	DelegatorStartingInfoKey          = []byte{0x0A} // prefix for each key to the starting info of a delegation
 
 	// params store
 	ParamStoreKeyCommunityTax        = []byte("communitytax")
 	ParamStoreKeyBaseProposerReward  = []byte("baseproposerreward")
 	ParamStoreKeyBonusProposerReward = []byte("bonusproposerreward")
+
+	ParamStoreKeyCommunityPoolBlockFunding = []byte("communitypoolblockfunding")
+	ParamStoreKeyCommunityPoolSupplyCap    = []byte("communitypoolsupplycap")
+	ParamStoreKeyMinBondedDuration         = []byte("minbondeddurationforrewards")
 )
 
 const (
@@ -44,3 +58,45 @@ func GetDelegationDistInfosKey(delAddr sdk.AccAddress) []byte {
 func GetDelegatorWithdrawAddrKey(delAddr sdk.AccAddress) []byte {
 	return append(DelegatorWithdrawInfoKey, delAddr.Bytes()...)
 }
+
+// gets the key for a delegator restaking its rewards
+func GetDelegatorAutoRestakeKey(delAddr sdk.AccAddress) []byte {
+	return append(DelegatorAutoRestakeKey, delAddr.Bytes()...)
+}
+
+// gets the key for the rewards of a delegation restaked at the end of the block
+// VALUE: int64 amount of the bond denom
+func GetPendingRestakeKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(PendingRestakeKey, delAddr.Bytes()...), valAddr.Bytes()...)
+}
+
+// gets the key for the current rewards of a validator
+// VALUE: distribution/types.ValidatorCurrentRewards
+func GetValidatorCurrentRewardsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorCurrentRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the key for the historical rewards of a validator period
+// VALUE: distribution/types.ValidatorHistoricalRewards
+func GetValidatorHistoricalRewardsKey(valAddr sdk.ValAddress, period uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, period)
+	return append(GetValidatorHistoricalRewardsPrefix(valAddr), b...)
+}
+
+// gets the prefix for the historical rewards of all the periods of a validator
+func GetValidatorHistoricalRewardsPrefix(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorHistoricalRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the key for the accumulated commission of a validator
+// VALUE: distribution/types.DecCoins
+func GetValidatorAccumulatedCommissionKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorAccumulatedCommissionKey, valAddr.Bytes()...)
+}
+
+// gets the key for the starting info of a delegation
+// VALUE: distribution/types.DelegatorStartingInfo
+func GetDelegatorStartingInfoKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(DelegatorStartingInfoKey, delAddr.Bytes()...), valAddr.Bytes()...)
+}
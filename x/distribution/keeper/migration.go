@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// MigrateToF1 settles the rewards accumulated by height and starts the F1
+// periods of all the validators and delegations. It runs once, at the height of
+// the F1Distribution upgrade. The rewards of the delegations are withdrawn, the
+// commission of the validators is carried over to their accumulated commission
+// and the undistributed rest goes to the community pool.
+func (k Keeper) MigrateToF1(ctx sdk.Context) {
+	height := ctx.BlockHeight()
+
+	ddis := k.GetAllDelegationDistInfos(ctx)
+	for _, ddi := range ddis {
+		if k.stakeKeeper.Validator(ctx, ddi.ValOperatorAddr) == nil ||
+			k.stakeKeeper.Delegation(ctx, ddi.DelegatorAddr, ddi.ValOperatorAddr) == nil {
+			continue
+		}
+		if err := k.withdrawDelegationRewardByHeight(ctx, ddi.DelegatorAddr, ddi.ValOperatorAddr); err != nil {
+			panic(err)
+		}
+	}
+
+	lastTotalPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastTotalPower(ctx))
+	for _, vdi := range k.GetAllValidatorDistInfos(ctx) {
+		feePool := k.GetFeePool(ctx)
+		if validator := k.stakeKeeper.Validator(ctx, vdi.OperatorAddr); validator != nil {
+			lastValPower := sdk.NewDecFromInt(k.stakeKeeper.GetLastValidatorPower(ctx, vdi.OperatorAddr))
+			var commission types.DecCoins
+			vdi, feePool, commission = vdi.WithdrawCommission(feePool, height, lastTotalPower,
+				lastValPower, validator.GetCommission())
+			k.SetValidatorAccumulatedCommission(ctx, vdi.OperatorAddr, commission)
+		}
+		feePool.CommunityPool = feePool.CommunityPool.Plus(vdi.Pool).Plus(vdi.PoolCommission)
+		k.SetFeePool(ctx, feePool)
+		k.RemoveValidatorDistInfo(ctx, vdi.OperatorAddr)
+	}
+
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(feePool.Pool)
+	feePool.Pool = types.DecCoins{}
+	feePool.TotalValAccum = types.NewTotalAccum(height)
+	k.SetFeePool(ctx, feePool)
+
+	k.stakeKeeper.IterateValidators(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+		k.initializeValidator(ctx, validator.GetOperator())
+		return false
+	})
+	for _, ddi := range ddis {
+		k.RemoveDelegationDistInfo(ctx, ddi.DelegatorAddr, ddi.ValOperatorAddr)
+		validator := k.stakeKeeper.Validator(ctx, ddi.ValOperatorAddr)
+		if validator == nil || k.stakeKeeper.Delegation(ctx, ddi.DelegatorAddr, ddi.ValOperatorAddr) == nil {
+			continue
+		}
+		k.initializeDelegation(ctx, validator, ddi.DelegatorAddr, ddi.IneligibleShares)
+	}
+}
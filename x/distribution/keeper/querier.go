@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case types.QueryDelegationRewards:
+			return queryDelegationRewards(ctx, req, k)
+		case types.QueryDelegatorTotalRewards:
+			return queryDelegatorTotalRewards(ctx, req, k)
+		case types.QueryValidatorCommission:
+			return queryValidatorCommission(ctx, req, k)
+		case types.QueryCommunityPool:
+			return marshalJSON(k.cdc, k.GetFeePool(ctx).CommunityPool)
+		case types.QueryDelegatorWithdrawAddr:
+			var params types.QueryDelegatorParams
+			if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, errRequestData(err)
+			}
+			return marshalJSON(k.cdc, k.GetDelegatorWithdrawAddr(ctx, params.DelegatorAddr))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown distr query endpoint")
+		}
+	}
+}
+
+func queryDelegationRewards(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryDelegationRewardsParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, errRequestData(err)
+	}
+
+	rewards, err := k.GetDelegationPendingRewards(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(k.cdc, rewards)
+}
+
+func queryDelegatorTotalRewards(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryDelegatorParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, errRequestData(err)
+	}
+
+	res := types.QueryDelegatorTotalRewardsResponse{
+		Rewards: []types.DelegationDelegatorReward{},
+		Total:   types.DecCoins{},
+	}
+	var err sdk.Error
+	k.stakeKeeper.IterateDelegations(ctx, params.DelegatorAddr, func(_ int64, del sdk.Delegation) (stop bool) {
+		var rewards types.DecCoins
+		rewards, err = k.GetDelegationPendingRewards(ctx, params.DelegatorAddr, del.GetValidatorAddr())
+		if err != nil {
+			return true
+		}
+		res.Rewards = append(res.Rewards, types.DelegationDelegatorReward{
+			ValidatorAddr: del.GetValidatorAddr(),
+			Reward:        rewards,
+		})
+		res.Total = res.Total.Plus(rewards)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(k.cdc, res)
+}
+
+func queryValidatorCommission(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params types.QueryValidatorCommissionParams
+	if err := k.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, errRequestData(err)
+	}
+	if !k.f1Enabled() {
+		return nil, types.ErrF1DistributionDisabled(k.codespace)
+	}
+	if !k.HasValidatorCurrentRewards(ctx, params.ValidatorAddr) {
+		return nil, types.ErrNoValidatorDistInfo(k.codespace)
+	}
+	return marshalJSON(k.cdc, k.GetValidatorAccumulatedCommission(ctx, params.ValidatorAddr))
+}
+
+func errRequestData(err error) sdk.Error {
+	return sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
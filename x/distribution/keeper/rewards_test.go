@@ -0,0 +1,251 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func enableF1(ctx sdk.Context) sdk.Context {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.F1Distribution, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	return ctx.WithBlockHeight(1)
+}
+
+func TestF1WithdrawDelegationRewards(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	defer sdk.UpgradeMgr.Reset()
+	ctx = enableF1(ctx)
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	balance := func(addr sdk.AccAddress) int64 {
+		return accMapper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	// make a validator with 10% commission
+	msgCreateValidator := stake.NewTestMsgCreateValidatorWithCommission(
+		valOpAddr1, valConsPk1, sdk.NewDecWithoutFra(10).RawInt(), sdk.NewDecWithPrec(1, 1))
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	require.True(t, keeper.HasValidatorCurrentRewards(ctx, valOpAddr1))
+	require.False(t, keeper.HasValidatorDistInfo(ctx, valOpAddr1))
+
+	// delegate
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	require.True(t, keeper.HasDelegatorStartingInfo(ctx, delAddr1, valOpAddr1))
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	// allocate 100 denom of fees
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+	require.Equal(t, types.DecCoins{{denom, sdk.NewDecWithoutFra(10)}},
+		keeper.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+
+	// the delegator has half the shares of 100*90% tokens
+	ctx = ctx.WithBlockHeight(2)
+	pending, err := keeper.GetDelegationPendingRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.Equal(t, types.DecCoins{{denom, sdk.NewDecWithoutFra(45)}}, pending)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(90+45).RawInt(), balance(delAddr1))
+
+	// nothing is left to withdraw
+	pending, err = keeper.GetDelegationPendingRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.Empty(t, pending)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(90+45).RawInt(), balance(delAddr1))
+
+	// the validator withdraws its self-delegation rewards and its commission
+	require.Nil(t, keeper.WithdrawValidatorRewardsAll(ctx, valOpAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(90+45+10).RawInt(), balance(valAccAddr1))
+	require.Empty(t, keeper.GetValidatorAccumulatedCommission(ctx, valOpAddr1))
+
+	// the starting periods of both delegations are kept, the older ones are pruned
+	var periods []uint64
+	keeper.IterateValidatorHistoricalRewards(ctx, func(_ sdk.ValAddress, period uint64, _ types.ValidatorHistoricalRewards) bool {
+		periods = append(periods, period)
+		return false
+	})
+	require.Equal(t, []uint64{4, 5}, periods)
+}
+
+func TestF1SharesModified(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	defer sdk.UpgradeMgr.Reset()
+	ctx = enableF1(ctx)
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	allocate := func(amount int64) {
+		fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(amount).RawInt())})
+		keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	}
+
+	got := stakeHandler(ctx, stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	allocate(100)
+
+	// the second delegation doesn't earn the rewards allocated before it
+	ctx = ctx.WithBlockHeight(2)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr2, valOpAddr1, 20))
+	require.True(t, got.IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	allocate(80)
+
+	// topping up the first delegation withdraws its rewards: half of 100 and
+	// a quarter of 80
+	ctx = ctx.WithBlockHeight(3)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	require.Equal(t, sdk.NewDecWithoutFra(100-20+50+20).RawInt(), accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom))
+
+	pending, err := keeper.GetDelegationPendingRewards(ctx, delAddr2, valOpAddr1)
+	require.Nil(t, err)
+	require.Equal(t, types.DecCoins{{denom, sdk.NewDecWithoutFra(40)}}, pending)
+
+	// unbonding the whole second delegation removes its starting info
+	got = stakeHandler(ctx, stake.NewMsgBeginUnbonding(delAddr2, valOpAddr1, sdk.NewDecWithoutFra(20)))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	require.False(t, keeper.HasDelegatorStartingInfo(ctx, delAddr2, valOpAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(100-20+40).RawInt(), accMapper.GetAccount(ctx, delAddr2).GetCoins().AmountOf(denom))
+}
+
+func TestF1ForfeitIneligibleRewards(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RewardsMinBondedDuration, 1)
+	ctx = enableF1(ctx)
+	keeper.SetMinBondedDurationForRewards(ctx, 10*time.Second)
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	start := time.Unix(1000, 0).UTC()
+	ctx = ctx.WithBlockTime(start)
+
+	got := stakeHandler(ctx, stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+
+	// the delegation has not been bonded for 10 seconds, its rewards go back
+	// to the validator
+	ctx = ctx.WithBlockHeight(5).WithBlockTime(start.Add(5 * time.Second))
+	pending, err := keeper.GetDelegationPendingRewards(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+	require.Empty(t, pending)
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(90).RawInt(), accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom))
+	require.Equal(t, types.DecCoins{{denom, sdk.NewDecWithoutFra(50)}}, keeper.GetValidatorCurrentRewards(ctx, valOpAddr1).Rewards)
+
+	// a top up doesn't make the shares bonded before ineligible again
+	ctx = ctx.WithBlockHeight(20).WithBlockTime(start.Add(20 * time.Second))
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	info := keeper.GetDelegatorStartingInfo(ctx, delAddr1, valOpAddr1)
+	require.Equal(t, types.IneligibleShares{{Shares: sdk.NewDecWithoutFra(10), BondTime: start.Add(20 * time.Second)}},
+		info.IneligibleShares)
+	topped := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	ctx = ctx.WithBlockHeight(25).WithBlockTime(start.Add(25 * time.Second))
+	require.Nil(t, keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1))
+	require.True(t, accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom) > topped)
+}
+
+func TestMigrateToF1(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	defer sdk.UpgradeMgr.Reset()
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	balance := func(addr sdk.AccAddress) int64 {
+		return accMapper.GetAccount(ctx, addr).GetCoins().AmountOf(denom)
+	}
+
+	// the rewards accumulate by height before the upgrade
+	got := stakeHandler(ctx, stake.NewTestMsgCreateValidator(valOpAddr1, valConsPk1, 10))
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valOpAddr1, 10))
+	require.True(t, got.IsOK())
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	require.True(t, keeper.HasDelegationDistInfo(ctx, delAddr1, valOpAddr1))
+
+	// the migration withdraws the rewards accumulated so far
+	ctx = enableF1(ctx)
+	sk.SetLastTotalPower(ctx, sdk.NewDecWithoutFra(10).RawInt())
+	sk.SetLastValidatorPower(ctx, valOpAddr1, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.MigrateToF1(ctx)
+	require.Equal(t, sdk.NewDecWithoutFra(90+50).RawInt(), balance(delAddr1))
+	require.Equal(t, sdk.NewDecWithoutFra(90+50).RawInt(), balance(valAccAddr1))
+	require.Empty(t, keeper.GetAllValidatorDistInfos(ctx))
+	require.Empty(t, keeper.GetAllDelegationDistInfos(ctx))
+	require.Len(t, keeper.GetAllDelegatorStartingInfos(ctx), 2)
+	require.Empty(t, keeper.GetFeePool(ctx).Pool)
+
+	// and the next rewards are distributed with the F1 periods
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.OneDec(), valConsAddr1)
+	ctx = ctx.WithBlockHeight(2)
+	keeper.WithdrawDelegationRewardsAll(ctx, delAddr1)
+	require.Equal(t, sdk.NewDecWithoutFra(90+50+50).RawInt(), balance(delAddr1))
+}
+
+func TestQueryDelegatorTotalRewards(t *testing.T) {
+	ctx, _, keeper, sk, fck := CreateTestInputAdvanced(t, false, sdk.NewDecWithoutFra(100).RawInt(), sdk.ZeroDec())
+	defer sdk.UpgradeMgr.Reset()
+	stakeHandler := stake.NewStakeHandler(sk)
+	denom := sk.GetParams(ctx).BondDenom
+	querier := NewQuerier(keeper)
+	query := func(path string, params interface{}, res interface{}) sdk.Error {
+		bz, err := keeper.cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		out, qErr := querier(ctx, []string{path}, abci.RequestQuery{Data: bz})
+		if qErr != nil {
+			return qErr
+		}
+		require.NoError(t, keeper.cdc.UnmarshalJSON(out, res))
+		return nil
+	}
+
+	// the pending rewards are only known with the F1 periods
+	var res types.QueryDelegatorTotalRewardsResponse
+	require.NotNil(t, query(types.QueryDelegationRewards, types.NewQueryDelegationRewardsParams(delAddr1, valOpAddr1), &res))
+
+	ctx = enableF1(ctx)
+	for i, valAddr := range []sdk.ValAddress{valOpAddr1, valOpAddr2} {
+		consPk := []crypto.PubKey{valConsPk1, valConsPk2}[i]
+		got := stakeHandler(ctx, stake.NewTestMsgCreateValidator(valAddr, consPk, 10))
+		require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+		got = stakeHandler(ctx, stake.NewTestMsgDelegate(delAddr1, valAddr, 10))
+		require.True(t, got.IsOK())
+	}
+	sk.ApplyAndReturnValidatorSetUpdates(ctx)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, sdk.NewDecWithoutFra(100).RawInt())})
+	keeper.AllocateTokens(ctx, sdk.ZeroDec(), valConsAddr1)
+
+	// the proposer reward is 1% of the fees, the validators share the rest
+	require.Nil(t, query(types.QueryDelegatorTotalRewards, types.NewQueryDelegatorParams(delAddr1), &res))
+	require.Len(t, res.Rewards, 2)
+	require.Equal(t, types.DecCoins{{denom, sdk.NewDecWithoutFra(50)}}, res.Total)
+
+	var commission types.DecCoins
+	require.Nil(t, query(types.QueryValidatorCommission, types.NewQueryValidatorCommissionParams(valOpAddr1), &commission))
+	require.Empty(t, commission)
+}
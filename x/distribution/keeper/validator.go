@@ -43,6 +43,9 @@ func (k Keeper) RemoveValidatorDistInfo(ctx sdk.Context, valAddr sdk.ValAddress)
 // withdrawal all the validator rewards including the commission
 func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.ValAddress) sdk.Error {
 
+	if k.f1Enabled() {
+		return k.withdrawValidatorRewardsAllF1(ctx, operatorAddr)
+	}
 	if !k.HasValidatorDistInfo(ctx, operatorAddr) {
 		return types.ErrNoValidatorDistInfo(k.codespace)
 	}
@@ -67,14 +70,22 @@ func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.Va
 	truncated, change := withdraw.TruncateDecimal()
 	feePool.CommunityPool = feePool.CommunityPool.Plus(change)
 	k.SetFeePool(ctx, feePool)
-	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, truncated)
-	if err != nil {
-		panic(err)
-	}
+	k.addCoins(ctx, withdrawAddr, truncated)
 
 	return nil
 }
 
+// withdraw the rewards of all the delegations of the operator and the
+// accumulated commission of the validator
+func (k Keeper) withdrawValidatorRewardsAllF1(ctx sdk.Context, operatorAddr sdk.ValAddress) sdk.Error {
+	if !k.HasValidatorCurrentRewards(ctx, operatorAddr) {
+		return types.ErrNoValidatorDistInfo(k.codespace)
+	}
+	k.WithdrawDelegationRewardsAll(ctx, sdk.AccAddress(operatorAddr.Bytes()))
+	k.withdrawValidatorCommission(ctx, operatorAddr)
+	return nil
+}
+
 // iterate over all the validator distribution infos (inefficient, just used to check invariants)
 func (k Keeper) IterateValidatorDistInfos(ctx sdk.Context, fn func(index int64, distInfo types.ValidatorDistInfo) (stop bool)) {
 	store := ctx.KVStore(k.storeKey)
@@ -0,0 +1,249 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// whether the rewards are distributed with the F1 periods of the validators
+func (k Keeper) f1Enabled() bool {
+	return sdk.IsUpgrade(sdk.F1Distribution)
+}
+
+// check whether a validator has current rewards
+func (k Keeper) HasValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetValidatorCurrentRewardsKey(valAddr))
+}
+
+// get the current rewards of a validator
+func (k Keeper) GetValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) (rewards types.ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorCurrentRewardsKey(valAddr))
+	if b == nil {
+		panic("Stored validator current rewards should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rewards)
+	return
+}
+
+// set the current rewards of a validator
+func (k Keeper) SetValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress, rewards types.ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(rewards)
+	store.Set(GetValidatorCurrentRewardsKey(valAddr), b)
+}
+
+// remove the current rewards of a validator
+func (k Keeper) RemoveValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorCurrentRewardsKey(valAddr))
+}
+
+// get the historical rewards of a validator period
+func (k Keeper) GetValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress,
+	period uint64) (rewards types.ValidatorHistoricalRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorHistoricalRewardsKey(valAddr, period))
+	if b == nil {
+		panic("Stored validator historical rewards should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &rewards)
+	return
+}
+
+// set the historical rewards of a validator period
+func (k Keeper) SetValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period uint64,
+	rewards types.ValidatorHistoricalRewards) {
+
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(rewards)
+	store.Set(GetValidatorHistoricalRewardsKey(valAddr, period), b)
+}
+
+// remove the historical rewards of a validator period
+func (k Keeper) RemoveValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorHistoricalRewardsKey(valAddr, period))
+}
+
+// get the accumulated commission of a validator, empty if none
+func (k Keeper) GetValidatorAccumulatedCommission(ctx sdk.Context, valAddr sdk.ValAddress) (commission types.DecCoins) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetValidatorAccumulatedCommissionKey(valAddr))
+	if b == nil {
+		return types.DecCoins{}
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &commission)
+	return
+}
+
+// set the accumulated commission of a validator
+func (k Keeper) SetValidatorAccumulatedCommission(ctx sdk.Context, valAddr sdk.ValAddress, commission types.DecCoins) {
+	store := ctx.KVStore(k.storeKey)
+	if len(commission) == 0 {
+		store.Delete(GetValidatorAccumulatedCommissionKey(valAddr))
+		return
+	}
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(commission)
+	store.Set(GetValidatorAccumulatedCommissionKey(valAddr), b)
+}
+
+//___________________________________________________________________________________________
+
+// start the first period of a new validator
+func (k Keeper) initializeValidator(ctx sdk.Context, valAddr sdk.ValAddress) {
+	// period 0 holds the zero reward ratio the first delegations start from
+	k.SetValidatorHistoricalRewards(ctx, valAddr, 0, types.NewValidatorHistoricalRewards(types.RewardRatios{}, 1))
+	k.SetValidatorCurrentRewards(ctx, valAddr, types.NewValidatorCurrentRewards(types.DecCoins{}, 1))
+}
+
+// End the current period of a validator and return it. The rewards of the
+// period are added to the cumulative rewards per share of the validator, it
+// must be called before the delegator shares of the validator change.
+func (k Keeper) incrementValidatorPeriod(ctx sdk.Context, val sdk.Validator) uint64 {
+	valAddr := val.GetOperator()
+	rewards := k.GetValidatorCurrentRewards(ctx, valAddr)
+
+	// the rewards of a validator with no delegators go to the community pool
+	ratio := types.RewardRatios{}
+	if val.GetDelegatorShares().IsZero() {
+		if len(rewards.Rewards) > 0 {
+			feePool := k.GetFeePool(ctx)
+			feePool.CommunityPool = feePool.CommunityPool.Plus(rewards.Rewards)
+			k.SetFeePool(ctx, feePool)
+		}
+	} else {
+		ratio = types.NewRewardRatios(rewards.Rewards, val.GetDelegatorShares())
+	}
+
+	previous := k.GetValidatorHistoricalRewards(ctx, valAddr, rewards.Period-1)
+	k.decrementReferenceCount(ctx, valAddr, rewards.Period-1)
+	k.SetValidatorHistoricalRewards(ctx, valAddr, rewards.Period,
+		types.NewValidatorHistoricalRewards(previous.CumulativeRewardRatio.Plus(ratio), 1))
+	k.SetValidatorCurrentRewards(ctx, valAddr, types.NewValidatorCurrentRewards(types.DecCoins{}, rewards.Period+1))
+
+	return rewards.Period
+}
+
+// reference the historical rewards of a validator period
+func (k Keeper) incrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	historical.ReferenceCount++
+	k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+}
+
+// dereference the historical rewards of a validator period, they are pruned
+// once no delegation starts from them
+func (k Keeper) decrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period uint64) {
+	historical := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	if historical.ReferenceCount == 0 {
+		panic("cannot set negative reference count")
+	}
+	historical.ReferenceCount--
+	if historical.ReferenceCount == 0 {
+		k.RemoveValidatorHistoricalRewards(ctx, valAddr, period)
+	} else {
+		k.SetValidatorHistoricalRewards(ctx, valAddr, period, historical)
+	}
+}
+
+// allocate rewards to a validator, the commission is accumulated apart from
+// the rewards of its delegators
+func (k Keeper) allocateTokensToValidator(ctx sdk.Context, val sdk.Validator, tokens types.DecCoins) {
+	valAddr := val.GetOperator()
+	commission := tokens.MulDec(val.GetCommission())
+	if len(commission) > 0 {
+		k.SetValidatorAccumulatedCommission(ctx, valAddr, k.GetValidatorAccumulatedCommission(ctx, valAddr).Plus(commission))
+	}
+
+	current := k.GetValidatorCurrentRewards(ctx, valAddr)
+	current.Rewards = current.Rewards.Plus(tokens.Minus(commission))
+	k.SetValidatorCurrentRewards(ctx, valAddr, current)
+}
+
+// withdraw the accumulated commission of a validator to the withdraw address
+// of its operator
+func (k Keeper) withdrawValidatorCommission(ctx sdk.Context, valAddr sdk.ValAddress) {
+	commission := k.GetValidatorAccumulatedCommission(ctx, valAddr)
+	truncated, change := commission.TruncateDecimal()
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, nonZeroDecCoins(change))
+	truncated = nonZeroCoins(truncated)
+	if truncated.IsZero() {
+		return
+	}
+	accAddr := sdk.AccAddress(valAddr.Bytes())
+	k.addCoins(ctx, k.GetDelegatorWithdrawAddr(ctx, accAddr), truncated)
+}
+
+// Remove the distribution records of a removed validator. Its delegations have
+// all been removed, the remaining rewards and commission are paid to the
+// community pool.
+func (k Keeper) removeValidatorRewards(ctx sdk.Context, valAddr sdk.ValAddress) {
+	if !k.HasValidatorCurrentRewards(ctx, valAddr) {
+		return
+	}
+	k.withdrawValidatorCommission(ctx, valAddr)
+
+	current := k.GetValidatorCurrentRewards(ctx, valAddr)
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(current.Rewards).
+		Plus(k.GetValidatorAccumulatedCommission(ctx, valAddr))
+	k.SetFeePool(ctx, feePool)
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorHistoricalRewardsPrefix(valAddr))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	k.RemoveValidatorCurrentRewards(ctx, valAddr)
+	k.SetValidatorAccumulatedCommission(ctx, valAddr, types.DecCoins{})
+}
+
+// iterate over the historical rewards of all the validator periods
+func (k Keeper) IterateValidatorHistoricalRewards(ctx sdk.Context,
+	fn func(valAddr sdk.ValAddress, period uint64, rewards types.ValidatorHistoricalRewards) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorHistoricalRewardsKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		var rewards types.ValidatorHistoricalRewards
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &rewards)
+		valAddr := sdk.ValAddress(key[1 : len(key)-8])
+		if fn(valAddr, binary.BigEndian.Uint64(key[len(key)-8:]), rewards) {
+			return
+		}
+	}
+}
+
+// the coins with a positive amount
+func nonZeroCoins(coins sdk.Coins) sdk.Coins {
+	nonZero := sdk.Coins{}
+	for _, coin := range coins {
+		if coin.Amount > 0 {
+			nonZero = append(nonZero, coin)
+		}
+	}
+	return nonZero
+}
+
+// the decimal coins with a non zero amount
+func nonZeroDecCoins(coins types.DecCoins) types.DecCoins {
+	nonZero := types.DecCoins{}
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			nonZero = append(nonZero, coin)
+		}
+	}
+	return nonZero
+}
@@ -7,6 +7,7 @@ import (
 
 var (
 	ActionModifyWithdrawAddress       = []byte("modify-withdraw-address")
+	ActionSetAutoRestake              = []byte("set-auto-restake")
 	ActionWithdrawDelegatorRewardsAll = []byte("withdraw-delegator-rewards-all")
 	ActionWithdrawDelegatorReward     = []byte("withdraw-delegator-reward")
 	ActionWithdrawValidatorRewardsAll = []byte("withdraw-validator-rewards-all")
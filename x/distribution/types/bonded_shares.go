@@ -0,0 +1,77 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// shares added to a delegation, they earn rewards once they have been bonded
+// for the minimum duration
+type BondedShares struct {
+	Shares   sdk.Dec   `json:"shares"`
+	BondTime time.Time `json:"bond_time"` // block time at which the shares were added
+}
+
+// the shares of a delegation not yet eligible to rewards, from the oldest
+type IneligibleShares []BondedShares
+
+// Add the shares bonded at bondTime, the shares bonded in the same block are
+// merged.
+func (s IneligibleShares) Add(shares sdk.Dec, bondTime time.Time) IneligibleShares {
+	added := append(IneligibleShares{}, s...)
+	if n := len(added); n > 0 && added[n-1].BondTime.Equal(bondTime) {
+		added[n-1].Shares = added[n-1].Shares.Add(shares)
+		return added
+	}
+	return append(added, BondedShares{Shares: shares, BondTime: bondTime})
+}
+
+// Cap the shares to the shares left in the delegation, the latest bonded
+// shares are the first unbonded.
+func (s IneligibleShares) Cap(delegatorShares sdk.Dec) IneligibleShares {
+	var capped IneligibleShares
+	remaining := delegatorShares
+	for _, bonded := range s {
+		if !remaining.GT(sdk.ZeroDec()) {
+			break
+		}
+		if bonded.Shares.GT(remaining) {
+			bonded.Shares = remaining
+		}
+		remaining = remaining.Sub(bonded.Shares)
+		capped = append(capped, bonded)
+	}
+	return capped
+}
+
+// Remove the shares bonded for minDuration at time now.
+func (s IneligibleShares) RemoveEligible(now time.Time, minDuration time.Duration) IneligibleShares {
+	var ineligible IneligibleShares
+	for _, bonded := range s {
+		if bonded.BondTime.Add(minDuration).After(now) {
+			ineligible = append(ineligible, bonded)
+		}
+	}
+	return ineligible
+}
+
+// The shares forfeiting the rewards accrued between start and end. The bonded
+// shares are weighted by the fraction of the period before they had been
+// bonded for minDuration, the rewards are assumed to accrue evenly over time.
+func (s IneligibleShares) ForfeitedShares(start, end time.Time, minDuration time.Duration) sdk.Dec {
+	forfeited := sdk.ZeroDec()
+	period := int64(end.Sub(start) / time.Second)
+	for _, bonded := range s {
+		eligibleTime := bonded.BondTime.Add(minDuration)
+		switch {
+		case !eligibleTime.After(start):
+		case !eligibleTime.Before(end) || period <= 0:
+			forfeited = forfeited.Add(bonded.Shares)
+		default:
+			fraction := sdk.NewDecFromInt(int64(eligibleTime.Sub(start) / time.Second)).Quo(sdk.NewDecFromInt(period))
+			forfeited = forfeited.Add(bonded.Shares.Mul(fraction))
+		}
+	}
+	return forfeited
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestIneligibleShares(t *testing.T) {
+	t0, t1 := time.Unix(1000, 0), time.Unix(1010, 0)
+	shares := IneligibleShares{}.
+		Add(sdk.NewDecWithoutFra(1), t0).
+		Add(sdk.NewDecWithoutFra(2), t0).
+		Add(sdk.NewDecWithoutFra(4), t1)
+	require.Equal(t, IneligibleShares{
+		{Shares: sdk.NewDecWithoutFra(3), BondTime: t0},
+		{Shares: sdk.NewDecWithoutFra(4), BondTime: t1},
+	}, shares)
+
+	// the latest bonded shares are the first unbonded
+	require.Equal(t, IneligibleShares{
+		{Shares: sdk.NewDecWithoutFra(3), BondTime: t0},
+		{Shares: sdk.NewDecWithoutFra(2), BondTime: t1},
+	}, shares.Cap(sdk.NewDecWithoutFra(5)))
+	require.Equal(t, IneligibleShares{{Shares: sdk.NewDecWithoutFra(2), BondTime: t0}}, shares.Cap(sdk.NewDecWithoutFra(2)))
+	require.Equal(t, shares, shares.Cap(sdk.NewDecWithoutFra(10)))
+
+	require.Equal(t, shares, shares.RemoveEligible(t1, 20*time.Second))
+	require.Equal(t, shares[1:], shares.RemoveEligible(t0.Add(20*time.Second), 20*time.Second))
+	require.Empty(t, shares.RemoveEligible(t1.Add(20*time.Second), 20*time.Second))
+
+	// each bonded shares forfeit the rewards accrued before they are eligible
+	forfeited := shares.ForfeitedShares(t0, t0.Add(40*time.Second), 20*time.Second)
+	require.True(sdk.DecEq(t, sdk.NewDecWithPrec(45, 1), forfeited)) // 3 * 20/40 + 4 * 30/40
+	forfeited = shares.ForfeitedShares(t0, t0.Add(10*time.Second), 20*time.Second)
+	require.True(sdk.DecEq(t, sdk.NewDecWithoutFra(7), forfeited))
+	forfeited = shares.ForfeitedShares(t1.Add(20*time.Second), t1.Add(30*time.Second), 20*time.Second)
+	require.True(sdk.DecEq(t, sdk.ZeroDec(), forfeited))
+}
@@ -1,14 +1,18 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // distribution info for a delegation - used to determine entitled rewards
 type DelegationDistInfo struct {
-	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`
-	ValOperatorAddr  sdk.ValAddress `json:"val_operator_addr"`
-	WithdrawalHeight int64          `json:"withdrawal_height"` // last time this delegation withdrew rewards
+	DelegatorAddr    sdk.AccAddress   `json:"delegator_addr"`
+	ValOperatorAddr  sdk.ValAddress   `json:"val_operator_addr"`
+	WithdrawalHeight int64            `json:"withdrawal_height"` // last time this delegation withdrew rewards
+	WithdrawalTime   time.Time        `json:"withdrawal_time"`   // block time of the last withdrawal
+	IneligibleShares IneligibleShares `json:"ineligible_shares"` // shares not yet bonded for the minimum duration
 }
 
 func NewDelegationDistInfo(delegatorAddr sdk.AccAddress, valOperatorAddr sdk.ValAddress,
@@ -21,6 +25,29 @@ func NewDelegationDistInfo(delegatorAddr sdk.AccAddress, valOperatorAddr sdk.Val
 	}
 }
 
+// Forfeit the rewards accrued since the last withdrawal by the shares of the
+// delegation not yet bonded for minDuration. The forfeited accumulation is
+// removed from the validator's total delegator accumulation, so it is shared
+// among the eligible delegators. Returns the shares the delegation withdraws
+// the rewards of, the shares eligible by now are no longer tracked.
+func (di DelegationDistInfo) ForfeitIneligibleRewards(vi ValidatorDistInfo, height int64, now time.Time,
+	minDuration time.Duration, totalDelShares, delegatorShares sdk.Dec) (DelegationDistInfo, ValidatorDistInfo, sdk.Dec) {
+
+	ineligible := di.IneligibleShares.Cap(delegatorShares)
+	forfeited := ineligible.ForfeitedShares(di.WithdrawalTime, now, minDuration)
+	di.IneligibleShares = ineligible.RemoveEligible(now, minDuration)
+	di.WithdrawalTime = now
+	if forfeited.IsZero() || height <= di.WithdrawalHeight {
+		return di, vi, delegatorShares
+	}
+
+	vi = vi.UpdateTotalDelAccum(height, totalDelShares)
+	accum := forfeited.MulInt(height - di.WithdrawalHeight)
+	vi.DelAccum.Accum = vi.DelAccum.Accum.Sub(accum)
+
+	return di, vi, delegatorShares.Sub(forfeited)
+}
+
 // Withdraw rewards from delegator.
 // Among many things, it does:
 // * updates validator info's total del accum
@@ -35,6 +62,7 @@ func (di DelegationDistInfo) WithdrawRewards(fp FeePool, vi ValidatorDistInfo,
 	vi = vi.UpdateTotalDelAccum(height, totalDelShares)
 
 	if vi.DelAccum.Accum.IsZero() {
+		di.WithdrawalHeight = height
 		return di, vi, fp, DecCoins{}
 	}
 
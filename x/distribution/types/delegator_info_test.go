@@ -2,6 +2,7 @@ package types
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,38 @@ func TestWithdrawRewards(t *testing.T) {
 	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(4), vi.PoolCommission[0].Amount))
 	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(98), rewardRecv2[0].Amount))
 }
+
+func TestForfeitIneligibleRewards(t *testing.T) {
+	start := time.Unix(1000, 0)
+	vi := NewValidatorDistInfo(valAddr1, 0)
+	totalDelShares := sdk.NewDecWithoutFra(10)
+	di := NewDelegationDistInfo(delAddr1, valAddr1, 0)
+	di.WithdrawalTime = start
+	diShares := sdk.NewDecWithoutFra(5)
+
+	// without ineligible shares nothing is forfeited
+	di1, vi1, shares := di.ForfeitIneligibleRewards(vi, 5, start.Add(5*time.Second), 10*time.Second, totalDelShares, diShares)
+	assert.Equal(t, vi, vi1)
+	assert.True(sdk.DecEq(t, diShares, shares))
+	assert.Equal(t, start.Add(5*time.Second), di1.WithdrawalTime)
+
+	// before the threshold the whole accumulation of the added shares is forfeited
+	di.IneligibleShares = IneligibleShares{}.Add(sdk.NewDecWithoutFra(2), start)
+	di1, vi1, shares = di.ForfeitIneligibleRewards(vi, 5, start.Add(5*time.Second), 10*time.Second, totalDelShares, diShares)
+	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(3), shares))
+	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(40), vi1.DelAccum.Accum))
+	assert.Equal(t, di.IneligibleShares, di1.IneligibleShares)
+
+	// after the threshold only the accumulation before it is forfeited, and
+	// the added shares are eligible from now on
+	di1, vi1, shares = di.ForfeitIneligibleRewards(vi, 20, start.Add(20*time.Second), 10*time.Second, totalDelShares, diShares)
+	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(4), shares))
+	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(180), vi1.DelAccum.Accum))
+	assert.Empty(t, di1.IneligibleShares)
+
+	// the ineligible shares are capped to the shares of the delegation
+	di.IneligibleShares = IneligibleShares{}.Add(sdk.NewDecWithoutFra(8), start)
+	_, vi1, shares = di.ForfeitIneligibleRewards(vi, 5, start.Add(5*time.Second), 10*time.Second, totalDelShares, diShares)
+	assert.True(sdk.DecEq(t, sdk.ZeroDec(), shares))
+	assert.True(sdk.DecEq(t, sdk.NewDecWithoutFra(25), vi1.DelAccum.Accum))
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// starting info of the rewards of a delegation, the delegation earns the
+// cumulative rewards per share of its validator accrued since the end of the
+// previous period times its shares. The shares of a delegation only change
+// right after its rewards are withdrawn, so they are not recorded.
+type DelegatorStartingInfo struct {
+	PreviousPeriod   uint64           `json:"previous_period"`   // period ended when the rewards were last withdrawn
+	Height           int64            `json:"height"`            // height at which the rewards were last withdrawn
+	Time             time.Time        `json:"time"`              // block time at which the rewards were last withdrawn
+	IneligibleShares IneligibleShares `json:"ineligible_shares"` // shares not yet bonded for the minimum duration
+}
+
+func NewDelegatorStartingInfo(previousPeriod uint64, height int64, time time.Time,
+	ineligible IneligibleShares) DelegatorStartingInfo {
+
+	return DelegatorStartingInfo{
+		PreviousPeriod:   previousPeriod,
+		Height:           height,
+		Time:             time,
+		IneligibleShares: ineligible,
+	}
+}
+
+// Split the rewards accrued by the shares of the delegation since its starting
+// time into the rewards it is eligible to and the rewards forfeited by the
+// shares not yet bonded for minDuration.
+func (si DelegatorStartingInfo) SplitIneligibleRewards(rewards DecCoins, delegatorShares sdk.Dec,
+	now time.Time, minDuration time.Duration) (eligible, forfeited DecCoins) {
+
+	shares := si.IneligibleShares.Cap(delegatorShares).ForfeitedShares(si.Time, now, minDuration)
+	if shares.IsZero() {
+		return rewards, DecCoins{}
+	}
+	if shares.GTE(delegatorShares) {
+		return DecCoins{}, rewards
+	}
+
+	forfeited = rewards.MulDec(shares.Quo(delegatorShares))
+	return rewards.Minus(forfeited), forfeited
+}
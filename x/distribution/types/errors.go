@@ -11,6 +11,14 @@ const (
 	DefaultCodespace       sdk.CodespaceType = 6
 	CodeInvalidInput       CodeType          = 103
 	CodeNoDistributionInfo CodeType          = 104
+	CodeF1Disabled         CodeType          = 105
+)
+
+// The registered errors of the codes of the distribution codespace.
+var (
+	ErrCodeInvalidInput       = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeNoDistributionInfo = sdk.Register(DefaultCodespace, CodeNoDistributionInfo, "no distribution info")
+	ErrCodeF1Disabled         = sdk.Register(DefaultCodespace, CodeF1Disabled, "F1 distribution disabled")
 )
 
 func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
@@ -28,3 +36,6 @@ func ErrNoDelegationDistInfo(codespace sdk.CodespaceType) sdk.Error {
 func ErrNoValidatorDistInfo(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeNoDistributionInfo, "no validator distribution info")
 }
+func ErrF1DistributionDisabled(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeF1Disabled, "the rewards are not distributed with the F1 periods yet")
+}
@@ -1,6 +1,10 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // the address for where distributions rewards are withdrawn to by default
 // this struct is only used at genesis to feed in default withdraw addresses
@@ -15,32 +19,50 @@ type GenesisState struct {
 	CommunityTax           sdk.Dec                 `json:"community_tax"`
 	BaseProposerReward     sdk.Dec                 `json:"base_proposer_reward"`
 	BonusProposerReward    sdk.Dec                 `json:"bonus_proposer_reward"`
+	CommunityPoolFunding   sdk.Dec                 `json:"community_pool_block_funding"`
+	CommunityPoolSupplyCap sdk.Dec                 `json:"community_pool_supply_cap"`
+	MinBondedDuration      time.Duration           `json:"min_bonded_duration_for_rewards"`
 	ValidatorDistInfos     []ValidatorDistInfo     `json:"validator_dist_infos"`
 	DelegationDistInfos    []DelegationDistInfo    `json:"delegator_dist_infos"`
 	DelegatorWithdrawInfos []DelegatorWithdrawInfo `json:"delegator_withdraw_infos"`
+	DelegatorAutoRestakes  []sdk.AccAddress        `json:"delegator_auto_restakes"`
+
+	// F1 distribution state, empty before the F1Distribution upgrade
+	ValidatorCurrentRewards         []ValidatorCurrentRewardsRecord        `json:"validator_current_rewards"`
+	ValidatorHistoricalRewards      []ValidatorHistoricalRewardsRecord     `json:"validator_historical_rewards"`
+	ValidatorAccumulatedCommissions []ValidatorAccumulatedCommissionRecord `json:"validator_accumulated_commissions"`
+	DelegatorStartingInfos          []DelegatorStartingInfoRecord          `json:"delegator_starting_infos"`
 }
 
-func NewGenesisState(feePool FeePool, communityTax, baseProposerReward, bonusProposerReward sdk.Dec,
-	vdis []ValidatorDistInfo, ddis []DelegationDistInfo, dwis []DelegatorWithdrawInfo) GenesisState {
+func NewGenesisState(feePool FeePool, communityTax, baseProposerReward, bonusProposerReward,
+	communityPoolFunding, communityPoolSupplyCap sdk.Dec, minBondedDuration time.Duration,
+	vdis []ValidatorDistInfo, ddis []DelegationDistInfo, dwis []DelegatorWithdrawInfo,
+	autoRestakes []sdk.AccAddress) GenesisState {
 
 	return GenesisState{
 		FeePool:                feePool,
 		CommunityTax:           communityTax,
 		BaseProposerReward:     baseProposerReward,
 		BonusProposerReward:    bonusProposerReward,
+		CommunityPoolFunding:   communityPoolFunding,
+		CommunityPoolSupplyCap: communityPoolSupplyCap,
+		MinBondedDuration:      minBondedDuration,
 		ValidatorDistInfos:     vdis,
 		DelegationDistInfos:    ddis,
 		DelegatorWithdrawInfos: dwis,
+		DelegatorAutoRestakes:  autoRestakes,
 	}
 }
 
 // get raw genesis raw message for testing
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		FeePool:             InitialFeePool(),
-		CommunityTax:        sdk.NewDecWithPrec(2, 2), // 2%
-		BaseProposerReward:  sdk.NewDecWithPrec(1, 2), // 1%
-		BonusProposerReward: sdk.NewDecWithPrec(4, 2), // 4%
+		FeePool:                InitialFeePool(),
+		CommunityTax:           sdk.NewDecWithPrec(2, 2), // 2%
+		BaseProposerReward:     sdk.NewDecWithPrec(1, 2), // 1%
+		BonusProposerReward:    sdk.NewDecWithPrec(4, 2), // 4%
+		CommunityPoolFunding:   sdk.ZeroDec(),
+		CommunityPoolSupplyCap: sdk.NewDec(sdk.TokenMaxTotalSupply),
 	}
 }
 
@@ -57,11 +79,13 @@ func DefaultGenesisWithValidators(valAddrs []sdk.ValAddress) GenesisState {
 	}
 
 	return GenesisState{
-		FeePool:             InitialFeePool(),
-		CommunityTax:        sdk.NewDecWithPrec(2, 2), // 2%
-		BaseProposerReward:  sdk.NewDecWithPrec(1, 2), // 1%
-		BonusProposerReward: sdk.NewDecWithPrec(4, 2), // 4%
-		ValidatorDistInfos:  vdis,
-		DelegationDistInfos: ddis,
+		FeePool:                InitialFeePool(),
+		CommunityTax:           sdk.NewDecWithPrec(2, 2), // 2%
+		BaseProposerReward:     sdk.NewDecWithPrec(1, 2), // 1%
+		BonusProposerReward:    sdk.NewDecWithPrec(4, 2), // 4%
+		CommunityPoolFunding:   sdk.ZeroDec(),
+		CommunityPoolSupplyCap: sdk.NewDec(sdk.TokenMaxTotalSupply),
+		ValidatorDistInfos:     vdis,
+		DelegationDistInfos:    ddis,
 	}
 }
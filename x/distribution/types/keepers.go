@@ -8,10 +8,16 @@ type StakeKeeper interface {
 		fn func(index int64, delegation sdk.Delegation) (stop bool))
 	Delegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) sdk.Delegation
 	Validator(ctx sdk.Context, valAddr sdk.ValAddress) sdk.Validator
+	IterateValidators(ctx sdk.Context, fn func(index int64, validator sdk.Validator) (stop bool))
+	IterateValidatorsBonded(ctx sdk.Context, fn func(index int64, validator sdk.Validator) (stop bool))
 	ValidatorByConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) sdk.Validator
 	TotalPower(ctx sdk.Context) sdk.Dec
 	GetLastTotalPower(ctx sdk.Context) int64
 	GetLastValidatorPower(ctx sdk.Context, valAddr sdk.ValAddress) int64
+	TotalSupply(ctx sdk.Context) sdk.Dec
+	InflateSupply(ctx sdk.Context, newTokens sdk.Dec)
+	BondDenom(ctx sdk.Context) string
+	Restake(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin) sdk.Error
 }
 
 // expected coin keeper
@@ -24,3 +30,10 @@ type FeeCollectionKeeper interface {
 	GetCollectedFees(ctx sdk.Context) sdk.Coins
 	ClearCollectedFees(ctx sdk.Context)
 }
+
+// expected supply keeper, the collected fees leave the total supply when they
+// are allocated and the rewards join it when they are paid
+type SupplyKeeper interface {
+	Mint(ctx sdk.Context, coins sdk.Coins) sdk.Error
+	BurnCoins(ctx sdk.Context, name string, coins sdk.Coins) sdk.Error
+}
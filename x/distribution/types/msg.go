@@ -11,13 +11,14 @@ const MsgRoute = "distr"
 // Verify interface at compile time
 var _, _ sdk.Msg = &MsgSetWithdrawAddress{}, &MsgWithdrawDelegatorRewardsAll{}
 var _, _ sdk.Msg = &MsgWithdrawDelegatorReward{}, &MsgWithdrawValidatorRewardsAll{}
+var _ sdk.Msg = &MsgSetAutoRestake{}
 
 //______________________________________________________________________
 
 // msg struct for changing the withdraw address for a delegator (or validator self-delegation)
 type MsgSetWithdrawAddress struct {
 	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
-	WithdrawAddr  sdk.AccAddress `json:"delegator_addr"`
+	WithdrawAddr  sdk.AccAddress `json:"withdraw_addr"`
 }
 
 func NewMsgSetWithdrawAddress(delAddr, withdrawAddr sdk.AccAddress) MsgSetWithdrawAddress {
@@ -189,3 +190,47 @@ func (msg MsgWithdrawValidatorRewardsAll) ValidateBasic() sdk.Error {
 func (msg MsgWithdrawValidatorRewardsAll) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//______________________________________________________________________
+
+// msg struct for a delegator to restake its rewards, or to stop restaking them
+type MsgSetAutoRestake struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	Enabled       bool           `json:"enabled"`
+}
+
+func NewMsgSetAutoRestake(delAddr sdk.AccAddress, enabled bool) MsgSetAutoRestake {
+	return MsgSetAutoRestake{
+		DelegatorAddr: delAddr,
+		Enabled:       enabled,
+	}
+}
+
+func (msg MsgSetAutoRestake) Route() string { return MsgRoute }
+func (msg MsgSetAutoRestake) Type() string  { return "set_auto_restake" }
+
+// Return address that must sign over msg.GetSignBytes()
+func (msg MsgSetAutoRestake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetAutoRestake) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSetAutoRestake) ValidateBasic() sdk.Error {
+	if msg.DelegatorAddr == nil {
+		return ErrNilDelegatorAddr(DefaultCodespace)
+	}
+	return nil
+}
+
+func (msg MsgSetAutoRestake) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
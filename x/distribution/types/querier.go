@@ -0,0 +1,61 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the distribution Querier
+const (
+	QueryDelegationRewards     = "delegation_rewards"
+	QueryDelegatorTotalRewards = "delegator_total_rewards"
+	QueryValidatorCommission   = "validator_commission"
+	QueryCommunityPool         = "community_pool"
+	QueryDelegatorWithdrawAddr = "withdraw_addr"
+)
+
+// params for query 'custom/distr/delegation_rewards'
+type QueryDelegationRewardsParams struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+}
+
+func NewQueryDelegationRewardsParams(delAddr sdk.AccAddress, valAddr sdk.ValAddress) QueryDelegationRewardsParams {
+	return QueryDelegationRewardsParams{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+	}
+}
+
+// params for queries 'custom/distr/delegator_total_rewards' and 'custom/distr/withdraw_addr'
+type QueryDelegatorParams struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+}
+
+func NewQueryDelegatorParams(delAddr sdk.AccAddress) QueryDelegatorParams {
+	return QueryDelegatorParams{
+		DelegatorAddr: delAddr,
+	}
+}
+
+// params for query 'custom/distr/validator_commission'
+type QueryValidatorCommissionParams struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+}
+
+func NewQueryValidatorCommissionParams(valAddr sdk.ValAddress) QueryValidatorCommissionParams {
+	return QueryValidatorCommissionParams{
+		ValidatorAddr: valAddr,
+	}
+}
+
+// pending rewards of a delegation
+type DelegationDelegatorReward struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Reward        DecCoins       `json:"reward"`
+}
+
+// pending rewards of all the delegations of a delegator
+type QueryDelegatorTotalRewardsResponse struct {
+	Rewards []DelegationDelegatorReward `json:"rewards"`
+	Total   DecCoins                    `json:"total"`
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// fixed point precision of the reward ratios. The rewards per share of a
+// validator with a large stake are far below the 8 decimals of sdk.Dec, so the
+// ratios are kept as integers scaled by this precision.
+var RewardRatioPrecision = sdk.NewIntWithDecimal(1, 18)
+
+// rewards of a denom per delegator share, scaled by RewardRatioPrecision
+type RewardRatio struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Int `json:"amount"`
+}
+
+// reward ratios sorted by denom
+type RewardRatios []RewardRatio
+
+// the rewards per share of the rewards shared by totalShares, truncated
+func NewRewardRatios(rewards DecCoins, totalShares sdk.Dec) RewardRatios {
+	shares := big.NewInt(totalShares.RawInt())
+	ratios := RewardRatios{}
+	for _, coin := range rewards {
+		amount := new(big.Int).Mul(big.NewInt(coin.Amount.RawInt()), RewardRatioPrecision.BigInt())
+		amount.Quo(amount, shares)
+		if amount.Sign() == 0 {
+			continue
+		}
+		ratios = append(ratios, RewardRatio{coin.Denom, sdk.NewIntFromBigInt(amount)})
+	}
+	return ratios
+}
+
+// Plus combines two sets of reward ratios
+// CONTRACT: Plus will never return a RewardRatio with a 0 amount.
+func (ratios RewardRatios) Plus(ratiosB RewardRatios) RewardRatios {
+	sum := RewardRatios{}
+	indexA, indexB := 0, 0
+	lenA, lenB := len(ratios), len(ratiosB)
+	for {
+		if indexA == lenA {
+			return append(sum, ratiosB[indexB:]...)
+		} else if indexB == lenB {
+			return append(sum, ratios[indexA:]...)
+		}
+		ratioA, ratioB := ratios[indexA], ratiosB[indexB]
+		switch strings.Compare(ratioA.Denom, ratioB.Denom) {
+		case -1:
+			sum = append(sum, ratioA)
+			indexA++
+		case 0:
+			if amount := ratioA.Amount.Add(ratioB.Amount); !amount.IsZero() {
+				sum = append(sum, RewardRatio{ratioA.Denom, amount})
+			}
+			indexA++
+			indexB++
+		case 1:
+			sum = append(sum, ratioB)
+			indexB++
+		}
+	}
+}
+
+// Minus subtracts a set of reward ratios from another
+func (ratios RewardRatios) Minus(ratiosB RewardRatios) RewardRatios {
+	negative := make(RewardRatios, len(ratiosB))
+	for i, ratio := range ratiosB {
+		negative[i] = RewardRatio{ratio.Denom, ratio.Amount.Neg()}
+	}
+	return ratios.Plus(negative)
+}
+
+// the rewards of shares at these ratios, truncated
+func (ratios RewardRatios) MulShares(shares sdk.Dec) DecCoins {
+	rewards := DecCoins{}
+	for _, ratio := range ratios {
+		amount := new(big.Int).Mul(ratio.Amount.BigInt(), big.NewInt(shares.RawInt()))
+		amount.Quo(amount, RewardRatioPrecision.BigInt())
+		if amount.Sign() <= 0 {
+			continue
+		}
+		if !amount.IsInt64() {
+			panic("reward overflow")
+		}
+		rewards = append(rewards, DecCoin{ratio.Denom, sdk.NewDecFromInt(amount.Int64())})
+	}
+	return rewards
+}
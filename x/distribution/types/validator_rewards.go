@@ -0,0 +1,59 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// rewards of the delegators of a validator accumulated during its current period,
+// the commission has already been charged
+type ValidatorCurrentRewards struct {
+	Rewards DecCoins `json:"rewards"`
+	Period  uint64   `json:"period"`
+}
+
+func NewValidatorCurrentRewards(rewards DecCoins, period uint64) ValidatorCurrentRewards {
+	return ValidatorCurrentRewards{
+		Rewards: rewards,
+		Period:  period,
+	}
+}
+
+// cumulative rewards per delegator share of a validator at the end of a period.
+// The record is kept as long as it is referenced by the starting info of a
+// delegation or it is the last ended period of the validator.
+type ValidatorHistoricalRewards struct {
+	CumulativeRewardRatio RewardRatios `json:"cumulative_reward_ratio"`
+	ReferenceCount        int64        `json:"reference_count"`
+}
+
+func NewValidatorHistoricalRewards(cumulativeRewardRatio RewardRatios, referenceCount int64) ValidatorHistoricalRewards {
+	return ValidatorHistoricalRewards{
+		CumulativeRewardRatio: cumulativeRewardRatio,
+		ReferenceCount:        referenceCount,
+	}
+}
+
+// records of the F1 distribution state used during genesis import and export
+type (
+	ValidatorCurrentRewardsRecord struct {
+		ValidatorAddr sdk.ValAddress          `json:"validator_addr"`
+		Rewards       ValidatorCurrentRewards `json:"rewards"`
+	}
+
+	ValidatorHistoricalRewardsRecord struct {
+		ValidatorAddr sdk.ValAddress             `json:"validator_addr"`
+		Period        uint64                     `json:"period"`
+		Rewards       ValidatorHistoricalRewards `json:"rewards"`
+	}
+
+	ValidatorAccumulatedCommissionRecord struct {
+		ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+		Commission    DecCoins       `json:"commission"`
+	}
+
+	DelegatorStartingInfoRecord struct {
+		DelegatorAddr sdk.AccAddress        `json:"delegator_addr"`
+		ValidatorAddr sdk.ValAddress        `json:"validator_addr"`
+		StartingInfo  DelegatorStartingInfo `json:"starting_info"`
+	}
+)
@@ -0,0 +1,73 @@
+package gov
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// AccountFlagsChange is the description of a ProposalTypeAccountFlags
+// proposal, the flags replace all the flags of the account, e.g. to put a
+// compliance hold on it with auth.FlagTransfersDisabled or to lift one.
+type AccountFlagsChange struct {
+	Address sdk.AccAddress `json:"address"`
+	Flags   uint64         `json:"flags"`
+}
+
+func getAccountFlagsChange(proposal Proposal) (AccountFlagsChange, error) {
+	var change AccountFlagsChange
+	if err := json.Unmarshal([]byte(proposal.GetDescription()), &change); err != nil {
+		return change, err
+	}
+	if len(change.Address) != sdk.AddrLen {
+		return change, errors.Errorf("invalid address %s", change.Address)
+	}
+	if err := auth.ValidateFlags(change.Flags); err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+var _ GovHooks = AccountFlagsHooks{}
+
+// AccountFlagsHooks rejects the account flags proposals that could not be
+// applied at submission time.
+type AccountFlagsHooks struct {
+	am auth.AccountKeeper
+}
+
+func NewAccountFlagsHooks(am auth.AccountKeeper) AccountFlagsHooks {
+	return AccountFlagsHooks{am}
+}
+
+// Implements GovHooks, the flags are set on a discarded cache to check the
+// account.
+func (hooks AccountFlagsHooks) OnProposalSubmitted(ctx sdk.Context, proposal Proposal) error {
+	change, err := getAccountFlagsChange(proposal)
+	if err != nil {
+		return err
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	if err := hooks.am.SetFlags(cacheCtx, change.Address, change.Flags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewAccountFlagsProposalHandler returns the handler setting the flags of the
+// passed account flags proposals.
+func NewAccountFlagsProposalHandler(am auth.AccountKeeper) ProposalHandler {
+	return func(ctx sdk.Context, proposal Proposal) error {
+		change, err := getAccountFlagsChange(proposal)
+		if err != nil {
+			return err
+		}
+		if err := am.SetFlags(ctx, change.Address, change.Flags); err != nil {
+			return err
+		}
+		return nil
+	}
+}
@@ -41,6 +41,8 @@ const (
 	flagInitPrice         = "init-price"
 	flagExpireTime        = "expire-time"
 	flagSideChainId       = "side-chain-id"
+	flagExpedited         = "expedited"
+	flagBreakdown         = "breakdown"
 )
 
 type proposal struct {
@@ -50,6 +52,7 @@ type proposal struct {
 	Type         string `json:"type"`
 	Deposit      string `json:"deposit"`
 	SideChainId  string `json:"side_chain_id, omitempty"`
+	Expedited    bool   `json:"expedited,omitempty"`
 }
 
 var proposalFlags = []string{
@@ -77,7 +80,8 @@ where proposal.json contains:
   "description": "My awesome proposal",
   "voting_period": 1000,
   "type": "Text",
-  "deposit": "1000:test"
+  "deposit": "1000:test",
+  "expedited": false
 }
 
 is equivalent to
@@ -126,7 +130,7 @@ $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome prop
 				return err
 			}
 
-			amount, err := sdk.ParseCoins(proposal.Deposit)
+			amount, err := sdk.ParseUserCoins(proposal.Deposit)
 			if err != nil {
 				return err
 			}
@@ -137,7 +141,11 @@ $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome prop
 			}
 			var msg sdk.Msg
 			if sideChainId == gov.NativeChainID {
-				msg = gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod)
+				submitMsg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod)
+				submitMsg.Expedited = proposal.Expedited
+				msg = submitMsg
+			} else if proposal.Expedited {
+				return errors.New("the proposals of the side chains can not be expedited")
 			} else {
 				msg = gov.NewMsgSideChainSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod, sideChainId)
 			}
@@ -164,6 +172,7 @@ $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome prop
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposal, "", "proposal file path (if this path is given, other proposal flags are ignored)")
 	cmd.Flags().String(flagSideChainId, gov.NativeChainID, "the id of side chain, default is native chain")
+	cmd.Flags().Bool(flagExpedited, false, "vote on the proposal on the shorter voting period and the higher quorum of the expedited proposals")
 	return cmd
 }
 
@@ -178,6 +187,7 @@ func parseSubmitProposalFlags() (*proposal, error) {
 		proposal.Type = client.NormalizeProposalType(viper.GetString(flagProposalType))
 		proposal.Deposit = viper.GetString(flagDeposit)
 		proposal.SideChainId = viper.GetString(flagSideChainId)
+		proposal.Expedited = viper.GetBool(flagExpedited)
 		return proposal, nil
 	}
 
@@ -222,7 +232,7 @@ func GetCmdDeposit(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf("side-chain-id exceed the max length %d", types.MaxSideChainIdLength)
 			}
 
-			amount, err := sdk.ParseCoins(viper.GetString(flagDeposit))
+			amount, err := sdk.ParseUserCoins(viper.GetString(flagDeposit))
 			if err != nil {
 				return err
 			}
@@ -260,6 +270,12 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "vote",
 		Short: "Vote for an active proposal, options: yes/no/no_with_veto/abstain",
+		Long: strings.TrimSpace(`
+Vote for an active proposal with one of the options yes/no/no_with_veto/abstain, or split the vote among several of them with their weights summing up to 100000000 (1 in the decimal precision). For example:
+
+$ CLI gov vote --proposal-id=1 --option=yes
+$ CLI gov vote --proposal-id=1 --option="yes=70000000,abstain=30000000"
+`),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
 			cliCtx := context.NewCLIContext().
@@ -279,12 +295,19 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 				return fmt.Errorf("side-chain-id exceed the max length %d", types.MaxSideChainIdLength)
 			}
 
-			byteVoteOption, err := gov.VoteOptionFromString(client.NormalizeVoteOption(option))
-			if err != nil {
-				return err
-			}
 			var msg sdk.Msg
-			if sideChainId == gov.NativeChainID {
+			if strings.ContainsAny(option, ",=") {
+				if sideChainId != gov.NativeChainID {
+					return errors.New("the votes on the proposals of the side chains can not be weighted")
+				}
+				options, err := gov.WeightedVoteOptionsFromString(option, client.NormalizeVoteOption)
+				if err != nil {
+					return err
+				}
+				msg = gov.NewMsgWeightedVote(voterAddr, proposalID, options)
+			} else if byteVoteOption, err := gov.VoteOptionFromString(client.NormalizeVoteOption(option)); err != nil {
+				return err
+			} else if sideChainId == gov.NativeChainID {
 				msg = gov.NewMsgVote(voterAddr, proposalID, byteVoteOption)
 			} else {
 				msg = gov.NewMsgSideChainVote(voterAddr, proposalID, byteVoteOption, sideChainId)
@@ -314,7 +337,7 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
-	cmd.Flags().String(flagOption, "", "vote option {yes, no, no_with_veto, abstain}")
+	cmd.Flags().String(flagOption, "", "vote option {yes, no, no_with_veto, abstain}, or weighted options like yes=70000000,abstain=30000000")
 	cmd.Flags().String(flagSideChainId, gov.NativeChainID, "the id of side chain, default is native chain")
 
 	return cmd
@@ -605,6 +628,7 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			params := gov.QueryTallyParams{
 				BaseParams: gov.NewBaseParams(sideChainId),
 				ProposalID: proposalID,
+				Breakdown:  viper.GetBool(flagBreakdown),
 			}
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
@@ -622,6 +646,7 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal is being tallied")
+	cmd.Flags().Bool(flagBreakdown, false, "show the voting power and the vote of each bonded validator")
 	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
 
 	return cmd
@@ -686,7 +711,7 @@ func GetCmdSubmitListProposal(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			amount, err := sdk.ParseCoins(initialDeposit)
+			amount, err := sdk.ParseUserCoins(initialDeposit)
 			if err != nil {
 				return err
 			}
@@ -783,7 +808,7 @@ func GetCmdSubmitDelistProposal(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			amount, err := sdk.ParseCoins(initialDeposit)
+			amount, err := sdk.ParseUserCoins(initialDeposit)
 			if err != nil {
 				return err
 			}
@@ -24,6 +24,7 @@ const (
 	RestVoter          = "voter"
 	RestProposalStatus = "status"
 	RestNumLatest      = "latest"
+	RestBreakdown      = "breakdown"
 	storeName          = "gov"
 )
 
@@ -39,6 +40,7 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer), queryDepositHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), queryVotesOnProposalHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter), queryVoteHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/tally", RestProposalID), queryTallyOnProposalHandlerFn(cdc, cliCtx)).Methods("GET")
 }
 
 type postProposalReq struct {
@@ -49,6 +51,7 @@ type postProposalReq struct {
 	ProposalType   string         `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	Expedited      bool           `json:"expedited"`       // Whether the proposal is voted on the expedited params
 }
 
 type depositReq struct {
@@ -61,6 +64,8 @@ type voteReq struct {
 	BaseReq utils.BaseReq  `json:"base_req"`
 	Voter   sdk.AccAddress `json:"voter"`  //  address of the voter
 	Option  string         `json:"option"` //  option from OptionSet chosen by the voter
+	// options of a weighted vote, instead of the option
+	Options gov.WeightedVoteOptions `json:"options"`
 }
 
 func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
@@ -96,6 +101,7 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalType, req.Proposer, req.InitialDeposit, votingPeriod)
+		msg.Expedited = req.Expedited
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -172,14 +178,18 @@ func voteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 
-		voteOption, err := gov.VoteOptionFromString(client.NormalizeVoteOption(req.Option))
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
 		// create the message
-		msg := gov.NewMsgVote(req.Voter, proposalID, voteOption)
+		var msg gov.MsgVote
+		if len(req.Options) != 0 {
+			msg = gov.NewMsgWeightedVote(req.Voter, proposalID, req.Options)
+		} else {
+			voteOption, err := gov.VoteOptionFromString(client.NormalizeVoteOption(req.Option))
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg = gov.NewMsgVote(req.Voter, proposalID, voteOption)
+		}
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -432,6 +442,10 @@ func queryProposalsWithParameterFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		strNumLatest := r.URL.Query().Get(RestNumLatest)
 
 		params := gov.QueryProposalsParams{}
+		var ok bool
+		if params.Pagination, ok = utils.ParsePaginationOrReturnBadRequest(w, r); !ok {
+			return
+		}
 
 		if len(bechVoterAddr) != 0 {
 			voterAddr, err := sdk.AccAddressFromBech32(bechVoterAddr)
@@ -478,6 +492,10 @@ func queryProposalsWithParameterFn(cdc *codec.Codec, cliCtx context.CLIContext)
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		if params.IsPaginated() {
+			utils.WritePageResponse(w, cdc, res, cliCtx.Indent)
+			return
+		}
 
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
@@ -504,6 +522,7 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 
 		params := gov.QueryTallyParams{
 			ProposalID: proposalID,
+			Breakdown:  r.URL.Query().Get(RestBreakdown) == "true",
 		}
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
@@ -44,6 +44,10 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, bank.BaseKeeper, gov.K
 	sk := stake.NewKeeper(mapp.Cdc, keyStake, keyStakeReward, tkeyStake, ck, nil, pk.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace), sdk.ChainID(0), "")
 	sk.SetupForSideChain(&scKeeper, &ibcKeeper)
 	keeper := gov.NewKeeper(mapp.Cdc, keyGov, pk, pk.Subspace("testgov"), ck, sk, gov.DefaultCodespace, new(sdk.Pool))
+	keeper.AddHooks(gov.ProposalTypeParamsChange, gov.NewParamsChangeHooks(pk))
+	keeper.AddProposalHandler(gov.ProposalTypeParamsChange, gov.NewParamsChangeProposalHandler(pk))
+	keeper.AddHooks(gov.ProposalTypeAccountFlags, gov.NewAccountFlagsHooks(mapp.AccountKeeper))
+	keeper.AddProposalHandler(gov.ProposalTypeAccountFlags, gov.NewAccountFlagsProposalHandler(mapp.AccountKeeper))
 
 	mapp.Router().AddRoute("gov", gov.NewHandler(keeper))
 
@@ -63,9 +67,7 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, bank.BaseKeeper, gov.K
 func getEndBlocker(keeper gov.Keeper) sdk.EndBlocker {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 		gov.EndBlocker(ctx, keeper)
-		return abci.ResponseEndBlock{
-			Events: ctx.EventManager().ABCIEvents(),
-		}
+		return abci.ResponseEndBlock{}
 	}
 }
 
@@ -3,6 +3,7 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
@@ -10,14 +11,24 @@ import (
 
 // Vote
 type Vote struct {
-	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
-	ProposalID int64          `json:"proposal_id"` //  proposalID of the proposal
-	Option     VoteOption     `json:"option"`      //  option from OptionSet chosen by the voter
+	Voter      sdk.AccAddress      `json:"voter"`             //  address of the voter
+	ProposalID int64               `json:"proposal_id"`       //  proposalID of the proposal
+	Option     VoteOption          `json:"option,omitempty"`  //  option from OptionSet chosen by the voter, empty for the weighted votes
+	Options    WeightedVoteOptions `json:"options,omitempty"` //  options of the weighted votes, from the GovWeightedVotes upgrade
 }
 
 // Returns whether 2 votes are equal
 func (voteA Vote) Equals(voteB Vote) bool {
-	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option
+	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option &&
+		voteA.Options.Equals(voteB.Options)
+}
+
+// Returns the options of the vote weighted, a single option vote weighs one
+func (voteA Vote) WeightedOptions() WeightedVoteOptions {
+	if len(voteA.Options) == 0 {
+		return WeightedVoteOptions{{Option: voteA.Option, Weight: sdk.OneDec()}}
+	}
+	return voteA.Options
 }
 
 // Returns whether a vote is empty
@@ -83,6 +94,94 @@ func validVoteOption(option VoteOption) bool {
 	return false
 }
 
+// WeightedVoteOption splits the vote of a voter, e.g. 70% Yes and 30% Abstain
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"` //  option from OptionSet chosen by the voter
+	Weight sdk.Dec    `json:"weight"` //  share of the voting power of the voter given to the option
+}
+
+// WeightedVoteOptions are the options of a weighted vote
+type WeightedVoteOptions []WeightedVoteOption
+
+// Returns whether 2 weighted vote options are equal
+func (options WeightedVoteOptions) Equals(other WeightedVoteOptions) bool {
+	if len(options) != len(other) {
+		return false
+	}
+	for i := range options {
+		if options[i].Option != other[i].Option || !options[i].Weight.Equal(other[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the options are valid and distinct, and that their
+// positive weights sum up to one
+func (options WeightedVoteOptions) Validate() error {
+	if len(options) == 0 {
+		return errors.New("no weighted vote options")
+	}
+	totalWeight := sdk.ZeroDec()
+	seen := make(map[VoteOption]bool, len(options))
+	for _, option := range options {
+		if !validVoteOption(option.Option) {
+			return errors.Errorf("'%v' is not a valid voting option", option.Option)
+		}
+		if seen[option.Option] {
+			return errors.Errorf("duplicate voting option %s", option.Option)
+		}
+		seen[option.Option] = true
+		if !option.Weight.GT(sdk.ZeroDec()) || option.Weight.GT(sdk.OneDec()) {
+			return errors.Errorf("weight %s of voting option %s should be in range (0, 1]", option.Weight, option.Option)
+		}
+		totalWeight = totalWeight.Add(option.Weight)
+	}
+	if !totalWeight.Equal(sdk.OneDec()) {
+		return errors.Errorf("total weight of the voting options should be 1, is %s", totalWeight)
+	}
+	return nil
+}
+
+func (options WeightedVoteOptions) String() string {
+	strs := make([]string, len(options))
+	for i, option := range options {
+		strs[i] = fmt.Sprintf("%s=%s", option.Option, option.Weight)
+	}
+	return strings.Join(strs, ",")
+}
+
+// String to WeightedVoteOptions, e.g. "Yes=70000000,Abstain=30000000" with the
+// weights in the decimal precision. A single option without a weight weighs
+// one.
+func WeightedVoteOptionsFromString(str string, normalize func(string) string) (WeightedVoteOptions, error) {
+	var options WeightedVoteOptions
+	for _, optionStr := range strings.Split(str, ",") {
+		optionStr = strings.TrimSpace(optionStr)
+		weight := sdk.OneDec()
+		if idx := strings.Index(optionStr, "="); idx >= 0 {
+			var err error
+			weight, err = sdk.NewDecFromStr(strings.TrimSpace(optionStr[idx+1:]))
+			if err != nil {
+				return nil, errors.Errorf("invalid weight of voting option '%s': %v", optionStr, err)
+			}
+			optionStr = strings.TrimSpace(optionStr[:idx])
+		}
+		if normalize != nil {
+			optionStr = normalize(optionStr)
+		}
+		option, err := VoteOptionFromString(optionStr)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, WeightedVoteOption{Option: option, Weight: weight})
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
 // Marshal needed for protobuf compatibility
 func (vo VoteOption) Marshal() ([]byte, error) {
 	return []byte{byte(vo)}, nil
@@ -1,6 +1,7 @@
 package gov_test
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -11,7 +12,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mock"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -482,3 +485,201 @@ func TestTickPassedVotingPeriodAllAbstain(t *testing.T) {
 	validatorCoins := ck.GetCoins(ctx, addrs[0])
 	require.Equal(t, validatorCoins, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)})
 }
+
+func TestTickPassedParamsChangeProposal(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ParamsChangeProposal, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator0 := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator0, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+
+	// the proposals with changes that can't be applied are rejected
+	invalidChanges := `{"changes":[{"subspace":"stake","key":"Unknown","value":"21"}],"description":"test"}`
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", invalidChanges, gov.ProposalTypeParamsChange, addrs[0], deposit, votingPeriod))
+	require.False(t, res.IsOK())
+
+	changes := `{"changes":[{"subspace":"stake","key":"MaxValidators","value":"21"}],"description":"test"}`
+	res = govHandler(ctx, gov.NewMsgSubmitProposal("Test", changes, gov.ProposalTypeParamsChange, addrs[0], deposit, votingPeriod))
+	require.True(t, res.IsOK())
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionYes))
+	require.True(t, res.IsOK())
+	require.NotEqual(t, uint16(21), stakeKeeper.MaxValidators(ctx))
+
+	// pass voting period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+
+	require.Equal(t, gov.StatusPassed, keeper.GetProposal(ctx, int64(proposalID)).GetStatus())
+	require.Equal(t, uint16(21), stakeKeeper.MaxValidators(ctx))
+}
+
+func TestTickPassedAccountFlagsProposal(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.AccountFlags, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator0 := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator0, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+
+	// the proposals for unknown accounts or flags are rejected
+	unknown, _ := mock.GeneratePrivKeyAddressPairs(1)
+	for _, invalid := range []string{
+		fmt.Sprintf(`{"address":"%s","flags":1}`, unknown[0]),
+		fmt.Sprintf(`{"address":"%s","flags":1024}`, addrs[1]),
+		`{"flags":1}`,
+	} {
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", invalid, gov.ProposalTypeAccountFlags, addrs[0], deposit, votingPeriod))
+		require.False(t, res.IsOK(), invalid)
+	}
+
+	change := fmt.Sprintf(`{"address":"%s","flags":1}`, addrs[1])
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", change, gov.ProposalTypeAccountFlags, addrs[0], deposit, votingPeriod))
+	require.True(t, res.IsOK(), res.Log)
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionYes))
+	require.True(t, res.IsOK())
+	flags, err := mapp.AccountKeeper.GetFlags(ctx, addrs[1])
+	require.NoError(t, err)
+	require.Zero(t, flags)
+
+	// pass voting period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+
+	require.Equal(t, gov.StatusPassed, keeper.GetProposal(ctx, int64(proposalID)).GetStatus())
+	flags, err = mapp.AccountKeeper.GetFlags(ctx, addrs[1])
+	require.NoError(t, err)
+	require.Equal(t, auth.FlagTransfersDisabled, flags)
+}
+
+func TestTickVetoedProposalBurnsDeposits(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+
+	mapp, ck, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator0 := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	// the mock app resets the height at the beginning of the block
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovProposalPolicies, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator0, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	keeper.SetDepositPolicy(ctx, gov.DepositPolicy{OnVeto: gov.DepositActionBurn})
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], deposit, votingPeriod))
+	require.True(t, res.IsOK())
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionNoWithVeto))
+	require.True(t, res.IsOK())
+
+	// pass voting period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+	_, notRefundProposals := gov.EndBlocker(ctx, keeper)
+
+	require.Equal(t, gov.StatusRejected, keeper.GetProposal(ctx, int64(proposalID)).GetStatus())
+	require.Len(t, notRefundProposals, 1)
+	// the deposits are neither refunded nor distributed to the proposer
+	require.Empty(t, ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+	require.Empty(t, ck.GetCoins(ctx, feeAccount[0]))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 3000e8)}, ck.GetCoins(ctx, addrs[1]))
+
+	tallied := ctx.EventManager().Events()
+	require.Len(t, tallied, 1)
+	require.Equal(t, events.EventTypeProposalRejected, tallied[0].Type)
+	require.Contains(t, tallied[0].Attributes, sdk.NewAttribute(events.DepositAction, "Burn").ToKVPair())
+}
+
+func TestExpeditedProposal(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator0 := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	stakeKeeper.SetValidator(ctx, validator0)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator0)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	expedited := gov.NewMsgSubmitProposal("Expedited", "test", gov.ProposalTypeText, addrs[1], deposit, 1000*time.Second)
+	expedited.Expedited = true
+
+	// the proposals can't be expedited before the upgrade, or without the expedited params
+	res := govHandler(ctx, expedited)
+	require.False(t, res.IsOK())
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovProposalPolicies, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	res = govHandler(ctx, expedited)
+	require.False(t, res.IsOK())
+
+	keeper.SetExpeditedParams(ctx, gov.ExpeditedParams{VotingPeriod: 100 * time.Second, Quorum: sdk.NewDecWithPrec(9, 1)})
+
+	res = govHandler(ctx, gov.NewMsgSubmitProposal("Regular", "test", gov.ProposalTypeText, addrs[1], deposit, 100*time.Second))
+	require.True(t, res.IsOK())
+	regularID, _ := strconv.Atoi(string(res.Data))
+	res = govHandler(ctx, expedited)
+	require.True(t, res.IsOK())
+	expeditedID, _ := strconv.Atoi(string(res.Data))
+
+	// the voting period is capped to the expedited one
+	proposal := keeper.GetProposal(ctx, int64(expeditedID))
+	require.True(t, proposal.(*gov.TextProposal).Expedited)
+	require.Equal(t, 100*time.Second, proposal.GetVotingPeriod())
+
+	// the expedited proposal is ahead of the regular one expiring at the same time
+	require.Equal(t, int64(expeditedID), keeper.ActiveProposalQueuePop(ctx).GetProposalID())
+	require.Equal(t, int64(regularID), keeper.ActiveProposalQueuePop(ctx).GetProposalID())
+}
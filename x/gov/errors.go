@@ -27,6 +27,24 @@ const (
 	CodeInvalidSideChainId      sdk.CodeType = 14
 )
 
+// The registered errors of the codes of the gov codespace.
+var (
+	ErrCodeUnknownProposal         = sdk.Register(DefaultCodespace, CodeUnknownProposal, "unknown proposal")
+	ErrCodeInactiveProposal        = sdk.Register(DefaultCodespace, CodeInactiveProposal, "inactive proposal")
+	ErrCodeAlreadyActiveProposal   = sdk.Register(DefaultCodespace, CodeAlreadyActiveProposal, "already active proposal")
+	ErrCodeAlreadyFinishedProposal = sdk.Register(DefaultCodespace, CodeAlreadyFinishedProposal, "already finished proposal")
+	ErrCodeAddressNotStaked        = sdk.Register(DefaultCodespace, CodeAddressNotStaked, "address not staked")
+	ErrCodeInvalidTitle            = sdk.Register(DefaultCodespace, CodeInvalidTitle, "invalid title")
+	ErrCodeInvalidDescription      = sdk.Register(DefaultCodespace, CodeInvalidDescription, "invalid description")
+	ErrCodeInvalidProposalType     = sdk.Register(DefaultCodespace, CodeInvalidProposalType, "invalid proposal type")
+	ErrCodeInvalidVote             = sdk.Register(DefaultCodespace, CodeInvalidVote, "invalid vote")
+	ErrCodeInvalidGenesis          = sdk.Register(DefaultCodespace, CodeInvalidGenesis, "invalid genesis")
+	ErrCodeInvalidProposalStatus   = sdk.Register(DefaultCodespace, CodeInvalidProposalStatus, "invalid proposal status")
+	ErrCodeInvalidProposal         = sdk.Register(DefaultCodespace, CodeInvalidProposal, "invalid proposal")
+	ErrCodeInvalidVotingPeriod     = sdk.Register(DefaultCodespace, CodeInvalidVotingPeriod, "invalid voting period")
+	ErrCodeInvalidSideChainId      = sdk.Register(DefaultCodespace, CodeInvalidSideChainId, "invalid side chain id")
+)
+
 //----------------------------------------
 // Error constructors
 
@@ -70,6 +88,10 @@ func ErrInvalidVote(codespace sdk.CodespaceType, voteOption VoteOption) sdk.Erro
 	return sdk.NewError(codespace, CodeInvalidVote, fmt.Sprintf("'%v' is not a valid voting option", voteOption))
 }
 
+func ErrInvalidWeightedVote(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidVote, fmt.Sprintf("invalid weighted vote: %s", msg))
+}
+
 func ErrInvalidGenesis(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidVote, msg)
 }
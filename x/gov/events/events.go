@@ -5,7 +5,10 @@ var (
 	EventTypeProposalPassed   = "proposal-passed"
 	EventTypeProposalRejected = "proposal-rejected"
 
+	EventTypeProposalExecutionFailed = "proposal-execution-failed"
+
 	ProposalID        = "proposal-id"
 	VotingPeriodStart = "voting-period-start"
 	SideChainID       = "side-chain-id"
+	DepositAction     = "deposit-action"
 )
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -15,6 +17,13 @@ type GenesisState struct {
 	StartingProposalID int64         `json:"starting_proposalID"`
 	DepositParams      DepositParams `json:"deposit_params"`
 	TallyParams        TallyParams   `json:"tally_params"`
+	Proposals          []Proposal    `json:"proposals"`
+	Deposits           []Deposit     `json:"deposits"`
+	Votes              []Vote        `json:"votes"`
+
+	// from the GovProposalPolicies upgrade
+	ExpeditedParams ExpeditedParams `json:"expedited_params"`
+	DepositPolicy   DepositPolicy   `json:"deposit_policy"`
 }
 
 func NewGenesisState(startingProposalID int64, dp DepositParams, tp TallyParams) GenesisState {
@@ -41,7 +50,7 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-// InitGenesis - store genesis parameters
+// InitGenesis - store genesis parameters, proposals, deposits and votes
 func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	err := k.SetInitialProposalID(ctx, data.StartingProposalID)
 	if err != nil {
@@ -50,17 +59,142 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	}
 	k.SetDepositParams(ctx, data.DepositParams)
 	k.SetTallyParams(ctx, data.TallyParams)
+	if data.ExpeditedParams != (ExpeditedParams{}) {
+		k.SetExpeditedParams(ctx, data.ExpeditedParams)
+	}
+	if data.DepositPolicy != (DepositPolicy{}) {
+		k.SetDepositPolicy(ctx, data.DepositPolicy)
+	}
+
+	// the proposals are sorted by id, so the queues keep their order
+	for _, proposal := range data.Proposals {
+		k.SetProposal(ctx, proposal)
+		switch proposal.GetStatus() {
+		case StatusDepositPeriod:
+			k.InactiveProposalQueuePush(ctx, proposal)
+		case StatusVotingPeriod:
+			k.ActiveProposalQueuePush(ctx, proposal)
+		}
+	}
+	for _, deposit := range data.Deposits {
+		k.setDeposit(ctx, deposit.ProposalID, deposit.Depositer, deposit)
+	}
+	for _, vote := range data.Votes {
+		k.setVote(ctx, vote.ProposalID, vote.Voter, vote)
+	}
 }
 
-// WriteGenesis - output genesis parameters
+// WriteGenesis - output genesis parameters, proposals, deposits and votes
 func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	startingProposalID, _ := k.getNewProposalID(ctx)
+	startingProposalID, _ := k.peekCurrentProposalID(ctx)
 	depositParams := k.GetDepositParams(ctx)
 	tallyingParams := k.GetTallyParams(ctx)
 
+	store := ctx.KVStore(k.storeKey)
+	var proposals []Proposal
+	iter := sdk.KVStorePrefixIterator(store, KeyProposalsPrefix)
+	for ; iter.Valid(); iter.Next() {
+		var proposal Proposal
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	iter.Close()
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].GetProposalID() < proposals[j].GetProposalID()
+	})
+
+	var deposits []Deposit
+	k.IterateAllDeposits(ctx, func(deposit Deposit) (stop bool) {
+		deposits = append(deposits, deposit)
+		return false
+	})
+
+	var votes []Vote
+	iter = sdk.KVStorePrefixIterator(store, KeyVotesPrefix)
+	for ; iter.Valid(); iter.Next() {
+		var vote Vote
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &vote)
+		votes = append(votes, vote)
+	}
+	iter.Close()
+
 	return GenesisState{
 		StartingProposalID: startingProposalID,
 		DepositParams:      depositParams,
 		TallyParams:        tallyingParams,
+		Proposals:          proposals,
+		Deposits:           deposits,
+		Votes:              votes,
+		ExpeditedParams:    k.GetExpeditedParams(ctx),
+		DepositPolicy:      k.GetDepositPolicy(ctx),
+	}
+}
+
+// ValidateGenesis validates the provided gov genesis state to ensure the
+// expected invariants holds. (i.e. params in correct bounds, the deposits and
+// votes are on the genesis proposals)
+func ValidateGenesis(data GenesisState) error {
+	threshold := data.TallyParams.Threshold
+	if !threshold.GT(sdk.ZeroDec()) || threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance vote threshold should be positive and less or equal to one, is %s", threshold)
+	}
+	veto := data.TallyParams.Veto
+	if !veto.GT(sdk.ZeroDec()) || veto.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance vote veto threshold should be positive and less or equal to one, is %s", veto)
+	}
+	quorum := data.TallyParams.Quorum
+	if quorum.LT(sdk.ZeroDec()) || quorum.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance vote quorum should be in range 0 to 1, is %s", quorum)
+	}
+	minDeposit := data.DepositParams.MinDeposit
+	if !minDeposit.IsValid() || !minDeposit.IsNotNegative() {
+		return fmt.Errorf("governance deposit amount must be a valid sdk.Coins amount, is %s", minDeposit)
+	}
+	if data.DepositParams.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("governance max deposit period must be positive, is %v", data.DepositParams.MaxDepositPeriod)
+	}
+	expeditedQuorum := data.ExpeditedParams.Quorum
+	if expeditedQuorum.LT(sdk.ZeroDec()) || expeditedQuorum.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance expedited vote quorum should be in range 0 to 1, is %s", expeditedQuorum)
+	}
+	expeditedPeriod := data.ExpeditedParams.VotingPeriod
+	if expeditedPeriod < 0 || expeditedPeriod > MaxVotingPeriod {
+		return fmt.Errorf("governance expedited voting period should be in range 0 to %v, is %v", MaxVotingPeriod, expeditedPeriod)
+	}
+	for _, action := range []DepositAction{data.DepositPolicy.OnPass, data.DepositPolicy.OnReject, data.DepositPolicy.OnVeto} {
+		if !validDepositAction(action) {
+			return fmt.Errorf("governance deposit policy has an invalid deposit action %v", action)
+		}
+	}
+
+	proposals := make(map[int64]bool, len(data.Proposals))
+	for i, proposal := range data.Proposals {
+		id := proposal.GetProposalID()
+		if proposals[id] {
+			return fmt.Errorf("proposals[%d]: duplicate proposal %d", i, id)
+		}
+		if id >= data.StartingProposalID {
+			return fmt.Errorf("proposals[%d]: proposal %d must be less than the starting proposal id %d",
+				i, id, data.StartingProposalID)
+		}
+		proposals[id] = true
+	}
+	for i, deposit := range data.Deposits {
+		if !proposals[deposit.ProposalID] {
+			return fmt.Errorf("deposits[%d]: deposit of %v on the unknown proposal %d", i, deposit.Depositer, deposit.ProposalID)
+		}
+		if !deposit.Amount.IsValid() || !deposit.Amount.IsNotNegative() {
+			return fmt.Errorf("deposits[%d]: deposit of %v must be a valid sdk.Coins amount, is %s",
+				i, deposit.Depositer, deposit.Amount)
+		}
+	}
+	for i, vote := range data.Votes {
+		if !proposals[vote.ProposalID] {
+			return fmt.Errorf("votes[%d]: vote of %v on the unknown proposal %d", i, vote.Voter, vote.ProposalID)
+		}
+		if !validVoteOption(vote.Option) {
+			return fmt.Errorf("votes[%d]: invalid vote option %v of %v", i, vote.Option, vote.Voter)
+		}
 	}
+	return nil
 }
@@ -34,7 +34,22 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
+	votingPeriod := msg.VotingPeriod
+	if msg.Expedited {
+		expeditedParams := keeper.GetExpeditedParams(ctx)
+		if !sdk.IsUpgrade(sdk.GovProposalPolicies) || expeditedParams.VotingPeriod <= 0 {
+			return ErrInvalidProposal(keeper.codespace, "expedited proposals are not enabled").Result()
+		}
+		if votingPeriod > expeditedParams.VotingPeriod {
+			votingPeriod = expeditedParams.VotingPeriod
+		}
+	}
+
+	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, votingPeriod)
+	if msg.Expedited {
+		proposal.(*TextProposal).Expedited = true
+		keeper.SetProposal(ctx, proposal)
+	}
 
 	hooksErr := keeper.OnProposalSubmitted(ctx, proposal)
 	if hooksErr != nil {
@@ -101,7 +116,15 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 		return sdk.ErrUnauthorized("Validator is not bonded").Result()
 	}
 
-	err := keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	var err sdk.Error
+	if len(msg.Options) != 0 {
+		if !sdk.IsUpgrade(sdk.GovWeightedVotes) {
+			return ErrInvalidWeightedVote(keeper.codespace, "weighted votes are not enabled").Result()
+		}
+		err = keeper.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	} else {
+		err = keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	}
 	if err != nil {
 		return err.Result()
 	}
@@ -148,7 +171,7 @@ func EndBlocker(baseCtx sdk.Context, keeper Keeper) (refundProposals, notRefundP
 
 func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents sdk.Events, refundProposals, notRefundProposals []SimpleProposal) {
 
-	logger := ctx.Logger().With("module", "x/gov")
+	logger := ctx.Logger().With("module", "gov")
 
 	resEvents = sdk.EmptyEvents()
 	refundProposals = make([]SimpleProposal, 0)
@@ -169,7 +192,7 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 		event := sdk.NewEvent(events.EventTypeProposalDropped, sdk.NewAttribute(events.ProposalID,
 			strconv.FormatInt(inactiveProposal.GetProposalID(), 10)))
 		if chainId != NativeChainID {
-			event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+			event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
 		}
 		resEvents = resEvents.AppendEvent(event)
 
@@ -195,38 +218,73 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 
 		passes, refundDeposits, tallyResults := Tally(ctx, keeper, activeProposal)
 		var action string
+		var depositAction DepositAction
+		depositPolicy := keeper.GetDepositPolicy(ctx)
 		if passes {
 			activeProposal.SetStatus(StatusPassed)
 			action = events.EventTypeProposalPassed
-
-			// refund deposits
-			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
-			refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+			depositAction = depositPolicy.OnPass
+			if depositAction == DepositActionDefault {
+				depositAction = DepositActionRefund
+			}
 		} else {
 			activeProposal.SetStatus(StatusRejected)
 			action = events.EventTypeProposalRejected
 
-			// if votes reached quorum and not all votes are abstain, distribute deposits to validator, else refund deposits
+			// if votes reached quorum and not all votes are abstain, the deposits follow the policy, else refund deposits
 			if refundDeposits {
-				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
-				refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+				depositAction = DepositActionRefund
 			} else {
-				keeper.DistributeDeposits(ctx, activeProposal.GetProposalID())
-				notRefundProposals = append(notRefundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+				if isVetoed(keeper.GetTallyParams(ctx), tallyResults) {
+					depositAction = depositPolicy.OnVeto
+				} else {
+					depositAction = depositPolicy.OnReject
+				}
+				if depositAction == DepositActionDefault {
+					depositAction = DepositActionDistribute
+				}
 			}
 		}
 
+		switch depositAction {
+		case DepositActionRefund:
+			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
+			refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+		case DepositActionBurn:
+			keeper.BurnDeposits(ctx, activeProposal.GetProposalID())
+			notRefundProposals = append(notRefundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+		default:
+			keeper.DistributeDeposits(ctx, activeProposal.GetProposalID())
+			notRefundProposals = append(notRefundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+		}
+
 		activeProposal.SetTallyResult(tallyResults)
 		keeper.SetProposal(ctx, activeProposal)
 
-		logger.Info(fmt.Sprintf("proposal %d (%s) tallied; passed: %v",
-			activeProposal.GetProposalID(), activeProposal.GetTitle(), passes))
+		logger.Info(fmt.Sprintf("proposal %d (%s) tallied; passed: %v; deposits: %s",
+			activeProposal.GetProposalID(), activeProposal.GetTitle(), passes, depositAction))
 		event := sdk.NewEvent(action, sdk.NewAttribute(events.ProposalID,
 			strconv.FormatInt(activeProposal.GetProposalID(), 10)))
+		if sdk.IsUpgrade(sdk.GovProposalPolicies) {
+			event = event.AppendAttributes(sdk.NewAttribute(events.DepositAction, depositAction.String()))
+		}
 		if chainId != NativeChainID {
-			event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+			event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
 		}
 		resEvents = resEvents.AppendEvent(event)
+
+		if passes {
+			if err := executeProposal(ctx, keeper, activeProposal); err != nil {
+				logger.Error(fmt.Sprintf("proposal %d (%s) passed but failed to execute: %v",
+					activeProposal.GetProposalID(), activeProposal.GetTitle(), err))
+				failedEvent := sdk.NewEvent(events.EventTypeProposalExecutionFailed, sdk.NewAttribute(events.ProposalID,
+					strconv.FormatInt(activeProposal.GetProposalID(), 10)))
+				if chainId != NativeChainID {
+					failedEvent = failedEvent.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+				}
+				resEvents = resEvents.AppendEvent(failedEvent)
+			}
+		}
 	}
 
 	return
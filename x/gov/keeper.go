@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/tendermint/tendermint/crypto"
-
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
@@ -15,15 +15,21 @@ import (
 // Parameter store default namestore
 const (
 	DefaultParamSpace = "gov"
+
+	// DepositedCoinsAccName is the name of the module account of the deposits
+	DepositedCoinsAccName = "BinanceChainDepositedCoins"
 )
 
 // Parameter store key
 var (
 	ParamStoreKeyDepositParams = []byte("depositparams")
 	ParamStoreKeyTallyParams   = []byte("tallyparams")
+	// from the GovProposalPolicies upgrade
+	ParamStoreKeyExpeditedParams = []byte("expeditedparams")
+	ParamStoreKeyDepositPolicy   = []byte("depositpolicy")
 
 	// Will hold deposit of both BC chain and side chain.
-	DepositedCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainDepositedCoins")))
+	DepositedCoinsAccAddr = auth.NewModuleAddress(DepositedCoinsAccName)
 )
 
 // Type declaration for parameters
@@ -31,9 +37,16 @@ func ParamTypeTable() params.TypeTable {
 	return params.NewTypeTable(
 		ParamStoreKeyDepositParams, DepositParams{},
 		ParamStoreKeyTallyParams, TallyParams{},
+		ParamStoreKeyExpeditedParams, ExpeditedParams{},
+		ParamStoreKeyDepositPolicy, DepositPolicy{},
 	)
 }
 
+// SupplyKeeper tracks the total supply of the burned deposits
+type SupplyKeeper interface {
+	BurnCoins(ctx sdk.Context, name string, coins sdk.Coins) sdk.Error
+}
+
 type SideChainKeeper interface {
 	PrepareCtxForSideChain(ctx sdk.Context, sideChainId string) (sdk.Context, error)
 	GetAllSideChainPrefixes(ctx sdk.Context) ([]string, [][]byte)
@@ -65,6 +78,9 @@ type Keeper struct {
 	// Hooks registered
 	hooks map[ProposalKind][]GovHooks
 
+	// Handlers executing the passed proposals
+	handlers map[ProposalKind]ProposalHandler
+
 	// Reserved codespace
 	codespace sdk.CodespaceType
 
@@ -73,6 +89,10 @@ type Keeper struct {
 
 	// if you want to enable side chains, you need call `SetupForSideChain`
 	ScKeeper SideChainKeeper
+
+	// if you want to track the supply of the burned deposits, you need call
+	// `SetupForSupply`
+	supplyKeeper SupplyKeeper
 }
 
 // NewKeeper returns a governance keeper. It handles:
@@ -88,6 +108,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, p
 		ck:           ck,
 		ds:           ds,
 		hooks:        make(map[ProposalKind][]GovHooks),
+		handlers:     make(map[ProposalKind]ProposalHandler),
 		vs:           ds.GetValidatorSet(),
 		cdc:          cdc,
 		codespace:    codespace,
@@ -99,6 +120,10 @@ func (keeper *Keeper) SetupForSideChain(scKeeper SideChainKeeper) {
 	keeper.ScKeeper = scKeeper
 }
 
+func (keeper *Keeper) SetupForSupply(supplyKeeper SupplyKeeper) {
+	keeper.supplyKeeper = supplyKeeper
+}
+
 // AddHooks add hooks for gov keeper
 func (keeper Keeper) AddHooks(proposalType ProposalKind, hooks GovHooks) Keeper {
 	hs := keeper.hooks[proposalType]
@@ -132,6 +157,7 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	}
 	keeper.SetProposal(ctx, proposal)
 	keeper.InactiveProposalQueuePush(ctx, proposal)
+	telemetry.IncrKeeperCounter(ctx, "gov", "proposal")
 	return proposal
 }
 
@@ -233,6 +259,22 @@ func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddr
 	return matchingProposals
 }
 
+// GetProposalsFilteredPage returns the page of the proposals filtered as by
+// GetProposalsFiltered, with the number of them
+func (keeper Keeper) GetProposalsFilteredPage(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64, pagination sdk.Pagination) (proposals []Proposal, total int) {
+
+	proposals = []Proposal{}
+	keeper.Iterate(ctx, voterAddr, depositerAddr, status, numLatest, false, func(proposal Proposal) bool {
+		if pagination.InPage(total) {
+			proposals = append(proposals, proposal)
+		}
+		total++
+		return false
+	})
+
+	return proposals, total
+}
+
 func (keeper Keeper) SetInitialProposalID(ctx sdk.Context, proposalID int64) sdk.Error {
 	store := ctx.KVStore(keeper.storeKey)
 	bz := store.Get(KeyNextProposalID)
@@ -304,6 +346,24 @@ func (keeper Keeper) GetTallyParams(ctx sdk.Context) TallyParams {
 	return tallyParams
 }
 
+// Returns the current Expedited Params from the global param store, zero before
+// they are set
+func (keeper Keeper) GetExpeditedParams(ctx sdk.Context) ExpeditedParams {
+	var expeditedParams ExpeditedParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyExpeditedParams, &expeditedParams)
+	return expeditedParams
+}
+
+// Returns the current Deposit Policy from the global param store, the default
+// actions before the GovProposalPolicies upgrade
+func (keeper Keeper) GetDepositPolicy(ctx sdk.Context) DepositPolicy {
+	var depositPolicy DepositPolicy
+	if sdk.IsUpgrade(sdk.GovProposalPolicies) {
+		keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyDepositPolicy, &depositPolicy)
+	}
+	return depositPolicy
+}
+
 // nolint: errcheck
 func (keeper Keeper) SetDepositParams(ctx sdk.Context, depositParams DepositParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyDepositParams, &depositParams)
@@ -314,6 +374,16 @@ func (keeper Keeper) SetTallyParams(ctx sdk.Context, tallyParams TallyParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyTallyParams, &tallyParams)
 }
 
+// nolint: errcheck
+func (keeper Keeper) SetExpeditedParams(ctx sdk.Context, expeditedParams ExpeditedParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyExpeditedParams, &expeditedParams)
+}
+
+// nolint: errcheck
+func (keeper Keeper) SetDepositPolicy(ctx sdk.Context, depositPolicy DepositPolicy) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyDepositPolicy, &depositPolicy)
+}
+
 // =====================================================
 // Votes
 
@@ -337,6 +407,32 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 		Option:     option,
 	}
 	keeper.setVote(ctx, proposalID, voterAddr, vote)
+	telemetry.IncrKeeperCounter(ctx, "gov", "vote")
+
+	return nil
+}
+
+// Adds a vote splitting the voting power of the voter among the options
+func (keeper Keeper) AddWeightedVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, options WeightedVoteOptions) sdk.Error {
+	proposal := keeper.GetProposal(ctx, proposalID)
+	if proposal == nil {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetStatus() != StatusVotingPeriod {
+		return ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+
+	if err := options.Validate(); err != nil {
+		return ErrInvalidWeightedVote(keeper.codespace, err.Error())
+	}
+
+	vote := Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Options:    options,
+	}
+	keeper.setVote(ctx, proposalID, voterAddr, vote)
+	telemetry.IncrKeeperCounter(ctx, "gov", "vote")
 
 	return nil
 }
@@ -436,6 +532,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 		currDeposit.Amount = currDeposit.Amount.Plus(depositAmount)
 		keeper.setDeposit(ctx, proposalID, depositerAddr, currDeposit)
 	}
+	telemetry.IncrKeeperCounter(ctx, "gov", "deposit")
 
 	return nil, activatedVotingPeriod
 }
@@ -446,6 +543,21 @@ func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator
 	return sdk.KVStorePrefixIterator(store, KeyDepositsSubspace(proposalID))
 }
 
+// IterateAllDeposits iterates over the deposits on all the proposals, held by
+// the DepositedCoinsAccAddr account.
+func (keeper Keeper) IterateAllDeposits(ctx sdk.Context, iter func(Deposit) (stop bool)) {
+	store := ctx.KVStore(keeper.storeKey)
+	depositsIterator := sdk.KVStorePrefixIterator(store, KeyDepositsPrefix)
+	defer depositsIterator.Close()
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		var deposit Deposit
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), &deposit)
+		if iter(deposit) {
+			return
+		}
+	}
+}
+
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -495,6 +607,40 @@ func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) {
 	keeper.pool.AddAddrs([]sdk.AccAddress{sdk.AccAddress(proposerAccAddr), DepositedCoinsAccAddr})
 }
 
+// BurnDeposits burns and deletes all the deposits on a specific proposal
+func (keeper Keeper) BurnDeposits(ctx sdk.Context, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+
+	depositCoins := sdk.Coins{}
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := &Deposit{}
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), deposit)
+
+		depositCoins = depositCoins.Plus(deposit.Amount)
+		store.Delete(depositsIterator.Key())
+	}
+	depositsIterator.Close()
+
+	if !depositCoins.IsPositive() {
+		return
+	}
+	// the deposits are burned in the end blocker, a failure is logged rather
+	// than halting the chain. The module account burns them from the total
+	// supply once it is tracked.
+	var err sdk.Error
+	if keeper.supplyKeeper != nil && sdk.IsUpgrade(sdk.SupplyTracking) {
+		err = keeper.supplyKeeper.BurnCoins(ctx, DepositedCoinsAccName, depositCoins)
+	} else {
+		_, _, err = keeper.ck.SubtractCoins(ctx, DepositedCoinsAccAddr, depositCoins)
+	}
+	if err != nil {
+		ctx.Logger().Error("failed to burn the deposits", "proposal", proposalID, "err", err.Error())
+		return
+	}
+	keeper.pool.AddAddrs([]sdk.AccAddress{DepositedCoinsAccAddr})
+}
+
 // =====================================================
 // ProposalQueues
 
@@ -537,7 +683,8 @@ func (keeper Keeper) ActiveProposalQueuePop(ctx sdk.Context) Proposal {
 	return keeper.GetProposal(ctx, frontElement)
 }
 
-// Add a proposalID to the ProposalQueue sorted by expire time
+// Add a proposalID to the ProposalQueue sorted by expire time, the expedited
+// proposals ahead of the others expiring at the same time
 func (keeper Keeper) ActiveProposalQueuePush(ctx sdk.Context, proposal Proposal) {
 	proposalQueue := keeper.getActiveProposalQueue(ctx)
 	if len(proposalQueue) == 0 {
@@ -550,7 +697,8 @@ func (keeper Keeper) ActiveProposalQueuePush(ctx sdk.Context, proposal Proposal)
 		for idx, proposalId := range proposalQueue {
 			tmpProposal := keeper.GetProposal(ctx, proposalId)
 			tmpVotingExpireTime := tmpProposal.GetVotingStartTime().Add(tmpProposal.GetVotingPeriod())
-			if tmpVotingExpireTime.After(votingExpireTime) {
+			if tmpVotingExpireTime.After(votingExpireTime) ||
+				(tmpVotingExpireTime.Equal(votingExpireTime) && isExpedited(proposal) && !isExpedited(tmpProposal)) {
 				newProposalQueue = append(newProposalQueue, proposal.GetProposalID())
 				newProposalQueue = append(newProposalQueue, proposalQueue[idx:]...)
 				break
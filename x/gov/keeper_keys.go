@@ -13,11 +13,18 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+
+	// Key for getting all the deposits from the store
+	KeyDepositsPrefix = []byte("deposits:")
+
+	// Keys for getting all the proposals and votes from the store
+	KeyProposalsPrefix = []byte("proposals:")
+	KeyVotesPrefix     = []byte("votes:")
 )
 
 // Key for getting a specific proposal from the store
 func KeyProposal(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("proposals:%d", proposalID))
+	return []byte(fmt.Sprintf("%s%d", KeyProposalsPrefix, proposalID))
 }
 
 // Key for getting a specific deposit from the store
@@ -32,10 +39,10 @@ func KeyVote(proposalID int64, voterAddr sdk.AccAddress) []byte {
 
 // Key for getting all deposits on a proposal from the store
 func KeyDepositsSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
+	return []byte(fmt.Sprintf("%s%d:", KeyDepositsPrefix, proposalID))
 }
 
 // Key for getting all votes on a proposal from the store
 func KeyVotesSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("votes:%d:", proposalID))
+	return []byte(fmt.Sprintf("%s%d:", KeyVotesPrefix, proposalID))
 }
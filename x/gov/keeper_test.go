@@ -318,3 +318,35 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
 	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
 }
+
+type failingSupplyKeeper struct{}
+
+func (failingSupplyKeeper) BurnCoins(ctx sdk.Context, name string, coins sdk.Coins) sdk.Error {
+	return sdk.ErrUnauthorized("no burner permission")
+}
+
+func TestBurnDeposits(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+	mapp, ck, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)}
+
+	// the deposits are burned from the deposits account
+	proposalID := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second).GetProposalID()
+	err, _ := keeper.AddDeposit(ctx, proposalID, addrs[0], deposit)
+	require.Nil(t, err)
+	keeper.BurnDeposits(ctx, proposalID)
+	require.Empty(t, ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+
+	// once the supply is tracked, the failure of the supply keeper to burn them
+	// is logged without halting the chain
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	keeper.SetupForSupply(failingSupplyKeeper{})
+	proposalID = keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second).GetProposalID()
+	err, _ = keeper.AddDeposit(ctx, proposalID, addrs[1], deposit)
+	require.Nil(t, err)
+	require.NotPanics(t, func() { keeper.BurnDeposits(ctx, proposalID) })
+	require.Equal(t, deposit, ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+}
@@ -38,12 +38,13 @@ type DelistTradingPairParams struct {
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string         `json:"title"`           //  Title of the proposal
-	Description    string         `json:"description"`     //  Description of the proposal
-	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
-	VotingPeriod   time.Duration  `json:"voting_period"`   //  Length of the voting period (s)
+	Title          string         `json:"title"`               //  Title of the proposal
+	Description    string         `json:"description"`         //  Description of the proposal
+	ProposalType   ProposalKind   `json:"proposal_type"`       //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress `json:"proposer"`            //  Address of the proposer
+	InitialDeposit sdk.Coins      `json:"initial_deposit"`     //  Initial deposit paid by sender. Must be strictly positive.
+	VotingPeriod   time.Duration  `json:"voting_period"`       //  Length of the voting period (s)
+	Expedited      bool           `json:"expedited,omitempty"` //  Whether the proposal is voted on the expedited params, from the GovProposalPolicies upgrade
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins, votingPeriod time.Duration) MsgSubmitProposal {
@@ -188,9 +189,10 @@ func (msg MsgDeposit) GetInvolvedAddresses() []sdk.AccAddress {
 //-----------------------------------------------------------
 // MsgVote
 type MsgVote struct {
-	ProposalID int64          `json:"proposal_id"` // ID of the proposal
-	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
-	Option     VoteOption     `json:"option"`      //  option from OptionSet chosen by the voter
+	ProposalID int64               `json:"proposal_id"`       // ID of the proposal
+	Voter      sdk.AccAddress      `json:"voter"`             //  address of the voter
+	Option     VoteOption          `json:"option,omitempty"`  //  option from OptionSet chosen by the voter, empty for the weighted votes
+	Options    WeightedVoteOptions `json:"options,omitempty"` //  options of the weighted votes, from the GovWeightedVotes upgrade
 }
 
 func NewMsgVote(voter sdk.AccAddress, proposalID int64, option VoteOption) MsgVote {
@@ -201,6 +203,14 @@ func NewMsgVote(voter sdk.AccAddress, proposalID int64, option VoteOption) MsgVo
 	}
 }
 
+func NewMsgWeightedVote(voter sdk.AccAddress, proposalID int64, options WeightedVoteOptions) MsgVote {
+	return MsgVote{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
 // Implements Msg.
 // nolint
 func (msg MsgVote) Route() string { return MsgRoute }
@@ -214,6 +224,15 @@ func (msg MsgVote) ValidateBasic() sdk.Error {
 	if msg.ProposalID < 0 {
 		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
 	}
+	if len(msg.Options) != 0 {
+		if msg.Option != OptionEmpty {
+			return ErrInvalidWeightedVote(DefaultCodespace, "both the option and the weighted options are given")
+		}
+		if err := msg.Options.Validate(); err != nil {
+			return ErrInvalidWeightedVote(DefaultCodespace, err.Error())
+		}
+		return nil
+	}
 	if !validVoteOption(msg.Option) {
 		return ErrInvalidVote(DefaultCodespace, msg.Option)
 	}
@@ -221,6 +240,9 @@ func (msg MsgVote) ValidateBasic() sdk.Error {
 }
 
 func (msg MsgVote) String() string {
+	if len(msg.Options) != 0 {
+		return fmt.Sprintf("MsgVote{%v - %s}", msg.ProposalID, msg.Options)
+	}
 	return fmt.Sprintf("MsgVote{%v - %s}", msg.ProposalID, msg.Option)
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock"
@@ -115,6 +116,64 @@ func TestMsgVote(t *testing.T) {
 	}
 }
 
+func TestMsgWeightedVote(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	yes := gov.WeightedVoteOption{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(7, 1)}
+	abstain := gov.WeightedVoteOption{Option: gov.OptionAbstain, Weight: sdk.NewDecWithPrec(3, 1)}
+	tests := []struct {
+		option     gov.VoteOption
+		options    gov.WeightedVoteOptions
+		expectPass bool
+	}{
+		{gov.OptionEmpty, gov.WeightedVoteOptions{yes, abstain}, true},
+		{gov.OptionEmpty, gov.WeightedVoteOptions{{Option: gov.OptionNo, Weight: sdk.OneDec()}}, true},
+		{gov.OptionYes, gov.WeightedVoteOptions{yes, abstain}, false},
+		{gov.OptionEmpty, gov.WeightedVoteOptions{yes}, false},
+		{gov.OptionEmpty, gov.WeightedVoteOptions{yes, abstain, {Option: gov.OptionNo, Weight: sdk.ZeroDec()}}, false},
+		{gov.OptionEmpty, gov.WeightedVoteOptions{yes, yes}, false},
+		{gov.OptionEmpty, gov.WeightedVoteOptions{yes, {Option: gov.VoteOption(0x13), Weight: sdk.NewDecWithPrec(3, 1)}}, false},
+	}
+
+	for i, tc := range tests {
+		msg := gov.NewMsgWeightedVote(addrs[0], 0, tc.options)
+		msg.Option = tc.option
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	// the sign bytes of the single option votes are unchanged
+	msg := gov.NewMsgVote(addrs[0], 1, gov.OptionYes)
+	require.Equal(t, `{"option":"Yes","proposal_id":"1","voter":"`+addrs[0].String()+`"}`, string(msg.GetSignBytes()))
+
+	msg = gov.NewMsgWeightedVote(addrs[0], 1, gov.WeightedVoteOptions{yes, abstain})
+	require.Equal(t, `{"options":[{"option":"Yes","weight":"70000000"},{"option":"Abstain","weight":"30000000"}],"proposal_id":"1","voter":"`+
+		addrs[0].String()+`"}`, string(msg.GetSignBytes()))
+	cdc := codec.New()
+	gov.RegisterCodec(cdc)
+	var decoded gov.MsgVote
+	require.Nil(t, cdc.UnmarshalJSON(cdc.MustMarshalJSON(msg), &decoded))
+	require.Equal(t, msg.Voter, decoded.Voter)
+	require.True(t, msg.Options.Equals(decoded.Options))
+}
+
+func TestWeightedVoteOptionsFromString(t *testing.T) {
+	options, err := gov.WeightedVoteOptionsFromString("Yes=70000000, Abstain=30000000", nil)
+	require.Nil(t, err)
+	require.Equal(t, "Yes=70000000,Abstain=30000000", options.String())
+
+	options, err = gov.WeightedVoteOptionsFromString("NoWithVeto", nil)
+	require.Nil(t, err)
+	require.Equal(t, gov.WeightedVoteOptions{{Option: gov.OptionNoWithVeto, Weight: sdk.OneDec()}}, options)
+
+	for _, invalid := range []string{"", "Yes=70000000", "Yes=70000000,Maybe=30000000", "Yes=50000000,Yes=50000000", "Yes=0.7,Abstain=0.3"} {
+		_, err = gov.WeightedVoteOptionsFromString(invalid, nil)
+		require.NotNil(t, err, invalid)
+	}
+}
+
 func TestMsgSideChainSubmitProposal(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
 	tests := []struct {
@@ -1,8 +1,11 @@
 package gov
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/pkg/errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -18,3 +21,90 @@ type TallyParams struct {
 	Threshold sdk.Dec `json:"threshold"` //  Minimum proportion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto      sdk.Dec `json:"veto"`      //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
 }
+
+// Param around the expedited proposals, from the GovProposalPolicies upgrade
+type ExpeditedParams struct {
+	VotingPeriod time.Duration `json:"voting_period"` //  Maximum voting period of the expedited proposals, shorter than the regular ones
+	Quorum       sdk.Dec       `json:"quorum"`        //  Minimum percentage of total stake needed to vote for the result of an expedited proposal to be valid, higher than the regular one
+}
+
+// What becomes of the deposits of a tallied proposal
+type DepositAction byte
+
+// nolint
+const (
+	DepositActionDefault    DepositAction = 0x00 // refunded if the proposal passed, distributed to the block proposer otherwise
+	DepositActionRefund     DepositAction = 0x01
+	DepositActionDistribute DepositAction = 0x02
+	DepositActionBurn       DepositAction = 0x03
+)
+
+// Param around the deposits of the tallied proposals, from the
+// GovProposalPolicies upgrade. The deposits of the proposals failing for lack
+// of votes are always refunded.
+type DepositPolicy struct {
+	OnPass   DepositAction `json:"on_pass"`   //  Deposits of the passed proposals
+	OnReject DepositAction `json:"on_reject"` //  Deposits of the rejected proposals
+	OnVeto   DepositAction `json:"on_veto"`   //  Deposits of the vetoed proposals
+}
+
+// String to DepositAction byte. Returns ff if invalid.
+func DepositActionFromString(str string) (DepositAction, error) {
+	switch str {
+	case "", "Default":
+		return DepositActionDefault, nil
+	case "Refund":
+		return DepositActionRefund, nil
+	case "Distribute":
+		return DepositActionDistribute, nil
+	case "Burn":
+		return DepositActionBurn, nil
+	default:
+		return DepositAction(0xff), errors.Errorf("'%s' is not a valid deposit action", str)
+	}
+}
+
+// is defined DepositAction?
+func validDepositAction(action DepositAction) bool {
+	return action == DepositActionDefault ||
+		action == DepositActionRefund ||
+		action == DepositActionDistribute ||
+		action == DepositActionBurn
+}
+
+// Turns DepositAction byte to String
+func (action DepositAction) String() string {
+	switch action {
+	case DepositActionDefault:
+		return "Default"
+	case DepositActionRefund:
+		return "Refund"
+	case DepositActionDistribute:
+		return "Distribute"
+	case DepositActionBurn:
+		return "Burn"
+	default:
+		return ""
+	}
+}
+
+// Marshals to JSON using string
+func (action DepositAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(action.String())
+}
+
+// Unmarshals from JSON assuming string encoding
+func (action *DepositAction) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	bz2, err := DepositActionFromString(s)
+	if err != nil {
+		return err
+	}
+	*action = bz2
+	return nil
+}
@@ -0,0 +1,54 @@
+package gov
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// The description of a ProposalTypeParamsChange proposal is the JSON encoding
+// of params.ParamChanges, the changes can target any subspace registered in
+// the params keeper.
+func getParamChanges(proposal Proposal) (params.ParamChanges, error) {
+	var changes params.ParamChanges
+	if err := json.Unmarshal([]byte(proposal.GetDescription()), &changes); err != nil {
+		return changes, err
+	}
+	return changes, changes.ValidateBasic()
+}
+
+var _ GovHooks = ParamsChangeHooks{}
+
+// ParamsChangeHooks rejects the params change proposals that could not be
+// applied at submission time.
+type ParamsChangeHooks struct {
+	pk params.Keeper
+}
+
+func NewParamsChangeHooks(pk params.Keeper) ParamsChangeHooks {
+	return ParamsChangeHooks{pk}
+}
+
+// Implements GovHooks, the changes are applied to a discarded cache to check
+// the subspaces, keys and values.
+func (hooks ParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal Proposal) error {
+	changes, err := getParamChanges(proposal)
+	if err != nil {
+		return err
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	return hooks.pk.ApplyParamChanges(cacheCtx, changes.Changes)
+}
+
+// NewParamsChangeProposalHandler returns the handler applying the changes of
+// the passed params change proposals.
+func NewParamsChangeProposalHandler(pk params.Keeper) ProposalHandler {
+	return func(ctx sdk.Context, proposal Proposal) error {
+		changes, err := getParamChanges(proposal)
+		if err != nil {
+			return err
+		}
+		return pk.ApplyParamChanges(ctx, changes.Changes)
+	}
+}
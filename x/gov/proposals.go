@@ -77,11 +77,19 @@ type TextProposal struct {
 	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
 
 	VotingStartTime time.Time `json:"voting_start_time"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+
+	Expedited bool `json:"expedited,omitempty"` //  Whether the proposal is voted on the expedited params, from the GovProposalPolicies upgrade
 }
 
 // Implements Proposal Interface
 var _ Proposal = (*TextProposal)(nil)
 
+// isExpedited returns whether the proposal is an expedited one
+func isExpedited(proposal Proposal) bool {
+	textProposal, ok := proposal.(*TextProposal)
+	return ok && textProposal.Expedited
+}
+
 // nolint
 func (tp TextProposal) GetProposalID() int64                       { return tp.ProposalID }
 func (tp *TextProposal) SetProposalID(proposalID int64)            { tp.ProposalID = proposalID }
@@ -131,6 +139,8 @@ const (
 	ProposalTypeRemoveValidator      ProposalKind = 0x07
 	ProposalTypeDelistTradingPair    ProposalKind = 0x08
 	ProposalTypeManageChanPermission ProposalKind = 0x09
+	ProposalTypeParamsChange         ProposalKind = 0x0a
+	ProposalTypeAccountFlags         ProposalKind = 0x0b
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -158,6 +168,10 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeCSCParamsChange, nil
 	case "ManageChanPermission":
 		return ProposalTypeManageChanPermission, nil
+	case "ParamsChange":
+		return ProposalTypeParamsChange, nil
+	case "AccountFlags":
+		return ProposalTypeAccountFlags, nil
 	default:
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
@@ -176,6 +190,12 @@ func validProposalType(pt ProposalKind) bool {
 		pt == ProposalTypeManageChanPermission {
 		return true
 	}
+	if sdk.IsUpgrade(sdk.ParamsChangeProposal) && pt == ProposalTypeParamsChange {
+		return true
+	}
+	if sdk.IsUpgrade(sdk.AccountFlags) && pt == ProposalTypeAccountFlags {
+		return true
+	}
 	return false
 }
 
@@ -236,6 +256,10 @@ func (pt ProposalKind) String() string {
 		return "CSCParamsChange"
 	case ProposalTypeManageChanPermission:
 		return "ManageChanPermission"
+	case ProposalTypeParamsChange:
+		return "ParamsChange"
+	case ProposalTypeAccountFlags:
+		return "AccountFlags"
 	default:
 		return ""
 	}
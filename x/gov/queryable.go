@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"encoding/json"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -180,9 +182,11 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 	return bz, nil
 }
 
-// Params for query 'custom/gov/proposals'
+// Params for query 'custom/gov/proposals', the proposals are paginated if
+// Limit is set, the result is then a sdk.Page
 type QueryProposalsParams struct {
 	BaseParams
+	sdk.Pagination
 	Voter              sdk.AccAddress
 	Depositer          sdk.AccAddress
 	ProposalStatus     ProposalStatus
@@ -191,6 +195,21 @@ type QueryProposalsParams struct {
 
 // nolint: unparam
 func queryProposals(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryProposalsParams, keeper Keeper) (res []byte, err sdk.Error) {
+	if params.IsPaginated() {
+		if err2 := params.Pagination.ValidateBasic(); err2 != nil {
+			return nil, sdk.ErrUnknownRequest(err2.Error())
+		}
+		proposals, total := keeper.GetProposalsFilteredPage(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals, params.Pagination)
+		bz, err2 := keeper.cdc.MarshalJSON(proposals)
+		if err2 != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+		}
+		bz, err2 = json.MarshalIndent(sdk.NewPage(total, bz), "", "  ")
+		if err2 != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+		}
+		return bz, nil
+	}
 
 	proposals := keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals)
 
@@ -205,6 +224,8 @@ func queryProposals(ctx sdk.Context, path []string, req abci.RequestQuery, param
 type QueryTallyParams struct {
 	BaseParams
 	ProposalID int64
+	// with the shares of the validators, as a TallyBreakdown
+	Breakdown bool `json:",omitempty"`
 }
 
 // nolint: unparam
@@ -215,17 +236,22 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
 	}
 
-	var tallyResult TallyResult
+	var tally TallyBreakdown
 
 	if proposal.GetStatus() == StatusDepositPeriod {
-		tallyResult = EmptyTallyResult()
+		tally = TallyBreakdown{TallyResult: EmptyTallyResult(), Validators: []ValidatorTally{}}
 	} else if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
-		tallyResult = proposal.GetTallyResult()
+		// the votes are deleted once tallied
+		tally = TallyBreakdown{TallyResult: proposal.GetTallyResult(), Validators: []ValidatorTally{}}
 	} else {
-		_, _, tallyResult = Tally(ctx, keeper, proposal)
+		tally = TallyVotes(ctx, keeper, proposal)
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, tallyResult)
+	var result interface{} = tally.TallyResult
+	if params.Breakdown {
+		result = tally
+	}
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
 	}
@@ -0,0 +1,42 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ProposalHandler executes a passed proposal. The state changes are discarded
+// if it returns an error, the proposal stays passed.
+type ProposalHandler func(ctx sdk.Context, proposal Proposal) error
+
+// AddProposalHandler registers the handler executing the passed proposals of
+// proposalType, there is at most one handler per proposal type.
+func (keeper Keeper) AddProposalHandler(proposalType ProposalKind, handler ProposalHandler) Keeper {
+	if _, ok := keeper.handlers[proposalType]; ok {
+		panic(fmt.Sprintf("proposal handler for %s already registered", proposalType))
+	}
+	keeper.handlers[proposalType] = handler
+	return keeper
+}
+
+// GetProposalHandler returns the handler of proposalType, if any.
+func (keeper Keeper) GetProposalHandler(proposalType ProposalKind) (ProposalHandler, bool) {
+	handler, ok := keeper.handlers[proposalType]
+	return handler, ok
+}
+
+// executeProposal runs the handler of a passed proposal in a cached context,
+// the changes are written only if the handler succeeds.
+func executeProposal(ctx sdk.Context, keeper Keeper, proposal Proposal) error {
+	handler, ok := keeper.GetProposalHandler(proposal.GetProposalType())
+	if !ok {
+		return nil
+	}
+	cacheCtx, write := ctx.CacheContext()
+	if err := handler(cacheCtx, proposal); err != nil {
+		return err
+	}
+	write()
+	return nil
+}
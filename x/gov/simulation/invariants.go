@@ -2,14 +2,31 @@ package simulation
 
 import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 )
 
 // AllInvariants tests all governance invariants
-func AllInvariants() simulation.Invariant {
+func AllInvariants(k gov.Keeper, am auth.AccountKeeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp) error {
 		// TODO Add some invariants!
 		// Checking proposal queues, no passed-but-unexecuted proposals, etc.
-		return nil
+		return DepositsInvariant(k, am)(app)
 	}
 }
+
+// DepositsInvariant checks that the deposits account holds the deposits on the
+// proposals.
+func DepositsInvariant(k gov.Keeper, am auth.AccountKeeper) simulation.Invariant {
+	return banksim.ModuleAccountInvariant(am, gov.DepositedCoinsAccName, func(ctx sdk.Context) sdk.Coins {
+		deposits := sdk.Coins{}
+		k.IterateAllDeposits(ctx, func(deposit gov.Deposit) bool {
+			deposits = deposits.Plus(deposit.Amount)
+			return false
+		})
+		return deposits
+	})
+}
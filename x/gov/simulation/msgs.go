@@ -97,10 +97,6 @@ func simulateHandleMsgSubmitProposal(msg gov.MsgSubmitProposal, sk stake.Keeper,
 	result := handler(ctx, msg)
 	ok = result.IsOK()
 	if ok {
-		// Update pool to keep invariants
-		pool := sk.GetPool(ctx)
-		pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewDecFromInt(msg.InitialDeposit.AmountOf(denom)))
-		sk.SetPool(ctx, pool)
 		write()
 	}
 	event(fmt.Sprintf("gov/MsgSubmitProposal/%v", ok))
@@ -140,10 +136,6 @@ func SimulateMsgDeposit(k gov.Keeper, sk stake.Keeper) simulation.Operation {
 		ctx, write := ctx.CacheContext()
 		result := gov.NewHandler(k)(ctx, msg)
 		if result.IsOK() {
-			// Update pool to keep invariants
-			pool := sk.GetPool(ctx)
-			pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewDecFromInt(deposit.AmountOf(denom)))
-			sk.SetPool(ctx, pool)
 			write()
 		}
 		event(fmt.Sprintf("gov/MsgDeposit/%v", result.IsOK()))
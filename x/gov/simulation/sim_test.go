@@ -73,7 +73,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		}, []simulation.RandSetup{
 			setup,
 		}, []simulation.Invariant{
-			AllInvariants(),
+			AllInvariants(govKeeper, mapp.AccountKeeper),
 		}, 10, 100,
 		false,
 	)
@@ -87,7 +87,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		}, []simulation.RandSetup{
 			setup,
 		}, []simulation.Invariant{
-			AllInvariants(),
+			AllInvariants(govKeeper, mapp.AccountKeeper),
 		}, 10, 100,
 		false,
 	)
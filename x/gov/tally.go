@@ -1,26 +1,104 @@
 package gov
 
 import (
+	"bytes"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // validatorGovInfo used for tallying
 type validatorGovInfo struct {
-	Address             sdk.ValAddress // address of the validator operator
-	Power               sdk.Dec        // Power of a Validator
-	DelegatorShares     sdk.Dec        // Total outstanding delegator shares
-	DelegatorDeductions sdk.Dec        // Delegator deductions from validator's delegators voting independently
-	Vote                VoteOption     // Vote of the validator
+	Address             sdk.ValAddress      // address of the validator operator
+	Power               sdk.Dec             // Power of a Validator
+	DelegatorShares     sdk.Dec             // Total outstanding delegator shares
+	DelegatorDeductions sdk.Dec             // Delegator deductions from validator's delegators voting independently
+	Vote                WeightedVoteOptions // Vote of the validator, empty if the validator didn't vote
+}
+
+// ValidatorTally is the share of a bonded validator in the tally of a proposal
+type ValidatorTally struct {
+	Address     sdk.ValAddress      `json:"address"`      // address of the validator operator
+	Power       sdk.Dec             `json:"power"`        // power of the validator
+	VotingPower sdk.Dec             `json:"voting_power"` // power of the vote of the validator, less the delegators voting themselves
+	Vote        WeightedVoteOptions `json:"vote"`         // vote of the validator, empty if the validator didn't vote
+}
+
+// TallyBreakdown is the tally of a proposal with the shares of the validators
+type TallyBreakdown struct {
+	TallyResult TallyResult      `json:"tally_result"`
+	Validators  []ValidatorTally `json:"validators"` // sorted by address, empty once the proposal is tallied
 }
 
 func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult) {
-	results := make(map[VoteOption]sdk.Dec)
+	results, totalVotingPower, _ := tallyVotes(ctx, keeper, proposal, true)
+
+	tallyingParams := keeper.GetTallyParams(ctx)
+	totalPower := keeper.vs.TotalPower(ctx)
+	tallyResults = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
+		Total:      totalPower,
+	}
+
+	// If there is no staked coins, the proposal fails
+	if keeper.vs.TotalPower(ctx).IsZero() {
+		return false, true, tallyResults
+	}
+	// If there is not enough quorum of votes, the proposal fails
+	quorum := tallyingParams.Quorum
+	if isExpedited(proposal) {
+		// the expedited proposals need the higher quorum of the two
+		if expeditedQuorum := keeper.GetExpeditedParams(ctx).Quorum; expeditedQuorum.GT(quorum) {
+			quorum = expeditedQuorum
+		}
+	}
+	percentVoting := totalVotingPower.Quo(totalPower)
+	if percentVoting.LT(quorum) {
+		return false, true, tallyResults
+	}
+	// If no one votes, proposal fails
+	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
+		return false, true, tallyResults
+	}
+	// If more than 1/3 of voters veto, proposal fails
+	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingParams.Veto) {
+		return false, false, tallyResults
+	}
+	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
+	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingParams.Threshold) {
+		return true, true, tallyResults
+	}
+	// If more than 1/2 of non-abstaining voters vote No, proposal fails
+
+	return false, false, tallyResults
+}
+
+// isVetoed returns whether more than the veto ratio of the voters vetoed the
+// tallied proposal
+func isVetoed(tallyingParams TallyParams, tallyResults TallyResult) bool {
+	totalVotingPower := tallyResults.Yes.Add(tallyResults.Abstain).Add(tallyResults.No).Add(tallyResults.NoWithVeto)
+	if totalVotingPower.IsZero() {
+		return false
+	}
+	return tallyResults.NoWithVeto.Quo(totalVotingPower).GT(tallyingParams.Veto)
+}
+
+// tallyVotes adds up the voting power given to each option by the votes on the
+// proposal, deleting the votes if deleteVotes. The validators voting power is
+// split among the options of their votes, less the shares of the delegators
+// voting themselves.
+func tallyVotes(ctx sdk.Context, keeper Keeper, proposal Proposal, deleteVotes bool) (
+	results map[VoteOption]sdk.Dec, totalVotingPower sdk.Dec, validators []ValidatorTally) {
+	results = make(map[VoteOption]sdk.Dec)
 	results[OptionYes] = sdk.ZeroDec()
 	results[OptionAbstain] = sdk.ZeroDec()
 	results[OptionNo] = sdk.ZeroDec()
 	results[OptionNoWithVeto] = sdk.ZeroDec()
 
-	totalVotingPower := sdk.ZeroDec()
+	totalVotingPower = sdk.ZeroDec()
 	currValidators := make(map[string]validatorGovInfo)
 
 	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
@@ -29,7 +107,6 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 			Power:               validator.GetPower(),
 			DelegatorShares:     validator.GetDelegatorShares(),
 			DelegatorDeductions: sdk.ZeroDec(),
-			Vote:                OptionEmpty,
 		}
 		return false
 	})
@@ -45,7 +122,7 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 		// if delegator tally voting power
 		valAddrStr := sdk.ValAddress(vote.Voter).String()
 		if val, ok := currValidators[valAddrStr]; ok {
-			val.Vote = vote.Option
+			val.Vote = vote.WeightedOptions()
 			currValidators[valAddrStr] = val
 		} else {
 
@@ -59,7 +136,9 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 					delegatorShare := delegation.GetShares().Quo(val.DelegatorShares)
 					votingPower := val.Power.Mul(delegatorShare)
 
-					results[vote.Option] = results[vote.Option].Add(votingPower)
+					for _, option := range vote.WeightedOptions() {
+						results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+					}
 					totalVotingPower = totalVotingPower.Add(votingPower)
 				}
 
@@ -67,55 +146,52 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 			})
 		}
 
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		if deleteVotes {
+			keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		}
 	}
 
 	// iterate over the validators again to tally their voting power
+	validators = make([]ValidatorTally, 0, len(currValidators))
 	for _, val := range currValidators {
-		if val.Vote == OptionEmpty {
-			continue
+		validatorTally := ValidatorTally{
+			Address:     val.Address,
+			Power:       val.Power,
+			VotingPower: sdk.ZeroDec(),
+			Vote:        val.Vote,
 		}
-
-		sharesAfterMinus := val.DelegatorShares.Sub(val.DelegatorDeductions)
-		percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
-		votingPower := val.Power.Mul(percentAfterMinus)
-
-		results[val.Vote] = results[val.Vote].Add(votingPower)
-		totalVotingPower = totalVotingPower.Add(votingPower)
+		if len(val.Vote) != 0 {
+			sharesAfterMinus := val.DelegatorShares.Sub(val.DelegatorDeductions)
+			percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
+			votingPower := val.Power.Mul(percentAfterMinus)
+
+			for _, option := range val.Vote {
+				results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+			}
+			totalVotingPower = totalVotingPower.Add(votingPower)
+			validatorTally.VotingPower = votingPower
+		}
+		validators = append(validators, validatorTally)
 	}
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i].Address, validators[j].Address) < 0
+	})
 
-	tallyingParams := keeper.GetTallyParams(ctx)
-	totalPower := keeper.vs.TotalPower(ctx)
-	tallyResults = TallyResult{
-		Yes:        results[OptionYes],
-		Abstain:    results[OptionAbstain],
-		No:         results[OptionNo],
-		NoWithVeto: results[OptionNoWithVeto],
-		Total:      totalPower,
-	}
+	return results, totalVotingPower, validators
+}
 
-	// If there is no staked coins, the proposal fails
-	if keeper.vs.TotalPower(ctx).IsZero() {
-		return false, true, tallyResults
-	}
-	// If there is not enough quorum of votes, the proposal fails
-	percentVoting := totalVotingPower.Quo(totalPower)
-	if percentVoting.LT(tallyingParams.Quorum) {
-		return false, true, tallyResults
+// TallyVotes tallies the votes on the proposal without settling it, with the
+// shares of the bonded validators
+func TallyVotes(ctx sdk.Context, keeper Keeper, proposal Proposal) TallyBreakdown {
+	results, _, validators := tallyVotes(ctx, keeper, proposal, false)
+	return TallyBreakdown{
+		TallyResult: TallyResult{
+			Yes:        results[OptionYes],
+			Abstain:    results[OptionAbstain],
+			No:         results[OptionNo],
+			NoWithVeto: results[OptionNoWithVeto],
+			Total:      keeper.vs.TotalPower(ctx),
+		},
+		Validators: validators,
 	}
-	// If no one votes, proposal fails
-	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
-		return false, true, tallyResults
-	}
-	// If more than 1/3 of voters veto, proposal fails
-	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingParams.Veto) {
-		return false, false, tallyResults
-	}
-	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
-	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingParams.Threshold) {
-		return true, true, tallyResults
-	}
-	// If more than 1/2 of non-abstaining voters vote No, proposal fails
-
-	return false, false, tallyResults
 }
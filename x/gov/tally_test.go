@@ -539,3 +539,106 @@ func TestTallyJailedValidator(t *testing.T) {
 	require.True(t, passes)
 	require.False(t, tallyResults.Equals(gov.EmptyTallyResult()))
 }
+
+func TestTallyExpeditedUnreachedQuorum(t *testing.T) {
+	defer sdk.UpgradeMgr.Reset()
+
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	// the mock app resets the height at the beginning of the block
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovProposalPolicies, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{6, 6, 7})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetExpeditedParams(ctx, gov.ExpeditedParams{VotingPeriod: 100 * time.Second, Quorum: sdk.NewDecWithPrec(9, 1)})
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 100*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], gov.OptionYes)
+	require.Nil(t, err)
+
+	// 12 of 19 voted, enough for the regular quorum
+	passes, _, _ := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.True(t, passes)
+
+	err = keeper.AddVote(ctx, proposalID, addrs[0], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], gov.OptionYes)
+	require.Nil(t, err)
+	proposal.(*gov.TextProposal).Expedited = true
+	keeper.SetProposal(ctx, proposal)
+
+	// but not for the expedited one
+	passes, refundDeposits, _ := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.False(t, passes)
+	require.True(t, refundDeposits)
+}
+
+func TestTallyWeightedVotes(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{6, 6, 7})
+	stake.EndBlocker(ctx, sk)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddWeightedVote(ctx, proposalID, addrs[0], gov.WeightedVoteOptions{
+		{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(5, 1)},
+		{Option: gov.OptionNo, Weight: sdk.NewDecWithPrec(5, 1)},
+	})
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], gov.OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[2], gov.WeightedVoteOptions{
+		{Option: gov.OptionYes, Weight: sdk.NewDecWithPrec(5, 1)},
+	})
+	require.NotNil(t, err)
+
+	// the tally of the voting proposal keeps the votes
+	tally := gov.TallyVotes(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.Equal(t, sdk.NewDec(9), tally.TallyResult.Yes)
+	require.Equal(t, sdk.NewDec(3), tally.TallyResult.No)
+	require.Len(t, tally.Validators, 3)
+	for _, validator := range tally.Validators {
+		switch {
+		case validator.Address.Equals(valAddrs[0]):
+			require.Len(t, validator.Vote, 2)
+			require.Equal(t, sdk.NewDec(6), validator.VotingPower)
+		case validator.Address.Equals(valAddrs[2]):
+			require.Empty(t, validator.Vote)
+			require.Equal(t, sdk.ZeroDec(), validator.VotingPower)
+		}
+	}
+	_, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found)
+
+	passes, _, tallyResults := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.True(t, passes)
+	require.True(t, tallyResults.Equals(tally.TallyResult))
+	_, found = keeper.GetVote(ctx, proposalID, addrs[0])
+	require.False(t, found)
+}
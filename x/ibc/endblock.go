@@ -5,6 +5,8 @@ import (
 )
 
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	keeper.timeoutPendingPackages(ctx)
+
 	if len(keeper.packageCollector.collectedPackages) == 0 {
 		return
 	}
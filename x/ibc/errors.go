@@ -14,6 +14,14 @@ const (
 	CodeWritePackageForbidden sdk.CodeType = 104
 )
 
+// The registered errors of the codes of the ibc codespace.
+var (
+	ErrCodeDuplicatedSequence    = sdk.Register(DefaultCodespace, CodeDuplicatedSequence, "duplicated sequence")
+	ErrCodeFeeParamMismatch      = sdk.Register(DefaultCodespace, CodeFeeParamMismatch, "fee param mismatch")
+	ErrCodeInvalidChainId        = sdk.Register(DefaultCodespace, CodeInvalidChainId, "invalid chain id")
+	ErrCodeWritePackageForbidden = sdk.Register(DefaultCodespace, CodeWritePackageForbidden, "write package forbidden")
+)
+
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeDuplicatedSequence, msg)
 }
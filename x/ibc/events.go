@@ -11,6 +11,9 @@ const (
 	ibcEventType                 = "IBCPackage"
 	ibcPackageInfoAttributeKey   = "IBCPackageInfo"
 	ibcPackageInfoAttributeValue = "%d" + separator + "%d" + separator + "%d" // destChainID channelID sequence
+
+	ibcTimeoutEventType          = "IBCPackageTimeout"
+	ibcTimeoutResultAttributeKey = "IBCPackageTimeoutResult"
 )
 
 func buildIBCPackageAttributeValue(sideChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) string {
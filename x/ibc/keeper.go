@@ -94,6 +94,9 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 
 	kvStore.Set(key, append(packageHeader, packageLoad...))
 	k.sideKeeper.IncrSendSequence(ctx, destChainID, channelID)
+	if packageType == sdk.SynCrossChainPackageType {
+		k.trackPendingPackage(ctx, destChainID, channelID, sequence)
+	}
 
 	if ctx.IsDeliverTx() {
 		k.packageCollector.collectedPackages = append(k.packageCollector.collectedPackages, packageRecord{
@@ -3,6 +3,7 @@ package ibc
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -12,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 )
@@ -19,10 +21,12 @@ import (
 func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	keySideChain := sdk.NewKVStoreKey("sc")
+	keyAcc := sdk.NewKVStoreKey("acc")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -36,7 +40,8 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	cdc := createTestCodec()
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
 
-	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, mode, log.NewNopLogger())
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, mode, log.NewNopLogger()).WithAccountCache(accountCache)
 	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
 	ibcKeeper := NewKeeper(keyIBC, pk.Subspace(DefaultParamspace), DefaultCodespace, scKeeper)
 
@@ -117,6 +122,67 @@ func TestKeeper(t *testing.T) {
 
 }
 
+type mockTimeoutApp struct {
+	timedOut [][]byte
+}
+
+func (app *mockTimeoutApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, relayerFee int64) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *mockTimeoutApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *mockTimeoutApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func (app *mockTimeoutApp) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	app.timedOut = append(app.timedOut, payload)
+	return sdk.ExecuteResult{}
+}
+
+func TestPendingPackageTimeout(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "timeout"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	ctx = ctx.WithBlockTime(time.Unix(100, 0))
+	app := &mockTimeoutApp{}
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, app))
+	require.NoError(t, keeper.sideKeeper.RegisterChannelTimeout(channelID, time.Minute))
+
+	for i := 0; i < 3; i++ {
+		sequence, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+		require.True(t, keeper.HasPendingPackage(ctx, destChainID, channelID, sequence))
+	}
+
+	// the ack of the oldest pending package
+	require.False(t, keeper.AckPendingPackage(ctx, destChainID, channelID))
+	require.False(t, keeper.HasPendingPackage(ctx, destChainID, channelID, 0))
+
+	// not timed out yet
+	EndBlocker(ctx.WithBlockTime(time.Unix(159, 0)), keeper)
+	require.Empty(t, app.timedOut)
+
+	EndBlocker(ctx.WithBlockTime(time.Unix(160, 0)), keeper)
+	require.Equal(t, [][]byte{{1}, {2}}, app.timedOut)
+	require.False(t, keeper.HasPendingPackage(ctx, destChainID, channelID, 1))
+	require.False(t, keeper.HasPendingPackage(ctx, destChainID, channelID, 2))
+
+	// the acks of the timed out packages are late
+	require.True(t, keeper.AckPendingPackage(ctx, destChainID, channelID))
+	require.True(t, keeper.AckPendingPackage(ctx, destChainID, channelID))
+	// no syn package left to acknowledge
+	require.False(t, keeper.AckPendingPackage(ctx, destChainID, channelID))
+}
+
 func createTestCodec() *codec.Codec {
 	cdc := codec.New()
 	sdk.RegisterCodec(cdc)
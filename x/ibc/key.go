@@ -18,6 +18,9 @@ const (
 var (
 	PrefixForIbcPackageKey = []byte{0x00}
 	PrefixForSequenceKey   = []byte{0x01}
+
+	PrefixForPendingPackageKey = []byte{0x02} // syn packages waiting for their ack, with their deadline
+	PrefixForAckSequenceKey    = []byte{0x03} // next sequence of the syn packages to acknowledge
 )
 
 func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
@@ -41,4 +44,29 @@ func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
 
 	return key
-}
\ No newline at end of file
+}
+func buildPendingPackageKeyPrefix(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
+
+	copy(key[:prefixLength], PrefixForPendingPackageKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+
+	return key
+}
+
+func buildPendingPackageKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, sequenceLength)
+	binary.BigEndian.PutUint64(key, sequence)
+	return append(buildPendingPackageKeyPrefix(destChainID, channelID), key...)
+}
+
+func buildAckSequenceKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
+
+	copy(key[:prefixLength], PrefixForAckSequenceKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+
+	return key
+}
@@ -0,0 +1,138 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+// The syn packages sent on a channel registered with a timeout are pending
+// until their ack. The acks of a channel come in the order of its syn packages,
+// so the ack of the oldest pending package is the next one.
+
+func (k *Keeper) trackPendingPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	timeout := k.sideKeeper.GetChannelTimeout(channelID)
+	if timeout <= 0 {
+		return
+	}
+	deadline := ctx.BlockHeader().Time.Add(timeout)
+	ctx.KVStore(k.storeKey).Set(buildPendingPackageKey(destChainID, channelID, sequence), sdk.FormatTimeBytes(deadline))
+}
+
+// GetAckSequence returns the sequence of the next syn package to acknowledge,
+// the ack being executed acknowledges the syn package before it.
+func (k *Keeper) GetAckSequence(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(buildAckSequenceKey(destChainID, channelID))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k *Keeper) setAckSequence(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	bz := make([]byte, sequenceLength)
+	binary.BigEndian.PutUint64(bz, sequence)
+	ctx.KVStore(k.storeKey).Set(buildAckSequenceKey(destChainID, channelID), bz)
+}
+
+// HasPendingPackage returns whether the syn package is waiting for its ack.
+func (k *Keeper) HasPendingPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) bool {
+	return ctx.KVStore(k.storeKey).Has(buildPendingPackageKey(destChainID, channelID, sequence))
+}
+
+// AckPendingPackage is called on the receipt of an ack or a fail ack package,
+// it resolves the syn package acknowledged and returns whether its ack is late,
+// i.e. it already timed out. The acks of the channels without a timeout are
+// never late.
+func (k *Keeper) AckPendingPackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (late bool) {
+	if k.sideKeeper.GetChannelTimeout(channelID) <= 0 {
+		return false
+	}
+	sequence := k.GetAckSequence(ctx, destChainID, channelID)
+	if sequence >= k.sideKeeper.GetSendSequence(ctx, destChainID, channelID) {
+		// no syn package to acknowledge
+		return false
+	}
+	k.setAckSequence(ctx, destChainID, channelID, sequence+1)
+
+	if !k.HasPendingPackage(ctx, destChainID, channelID, sequence) {
+		return true
+	}
+	ctx.KVStore(k.storeKey).Delete(buildPendingPackageKey(destChainID, channelID, sequence))
+	return false
+}
+
+type pendingPackage struct {
+	destChainID sdk.ChainID
+	channelID   sdk.ChannelID
+	sequence    uint64
+}
+
+// timeoutPendingPackages passes the pending packages past their deadline to
+// the ExecuteTimeoutPackage of their channel.
+func (k *Keeper) timeoutPendingPackages(ctx sdk.Context) {
+	kvStore := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(kvStore, PrefixForPendingPackageKey)
+	var expired []pendingPackage
+	for ; iterator.Valid(); iterator.Next() {
+		deadline, err := sdk.ParseTimeBytes(iterator.Value())
+		if err != nil {
+			panic(err)
+		}
+		if deadline.After(ctx.BlockHeader().Time) {
+			continue
+		}
+		key := iterator.Key()[prefixLength:]
+		expired = append(expired, pendingPackage{
+			destChainID: sdk.ChainID(binary.BigEndian.Uint16(key[:destChainIDLength])),
+			channelID:   sdk.ChannelID(key[destChainIDLength]),
+			sequence:    binary.BigEndian.Uint64(key[destChainIDLength+channelIDLength:]),
+		})
+	}
+	iterator.Close()
+
+	for _, pack := range expired {
+		kvStore.Delete(buildPendingPackageKey(pack.destChainID, pack.channelID, pack.sequence))
+
+		app, ok := k.sideKeeper.GetCrossChainApp(ctx, pack.channelID).(sdk.CrossChainTimeoutApplication)
+		if !ok {
+			continue
+		}
+		payload, _ := k.GetIBCPackageById(ctx, pack.destChainID, pack.channelID, pack.sequence)
+		if len(payload) >= sTypes.PackageHeaderLength {
+			payload = payload[sTypes.PackageHeaderLength:]
+		}
+
+		cacheCtx, write := ctx.CacheContext()
+		result := executeTimeout(cacheCtx, app, payload)
+		if result.IsOk() {
+			write()
+		}
+
+		event := sdk.NewEvent(ibcTimeoutEventType,
+			sdk.NewAttribute(ibcPackageInfoAttributeKey, buildIBCPackageAttributeValue(pack.destChainID, pack.channelID, pack.sequence)),
+			sdk.NewAttribute(ibcTimeoutResultAttributeKey, strconv.FormatInt(int64(result.Code()), 10)),
+		)
+		if result.IsOk() {
+			event.Attributes = append(event.Attributes, result.Tags...)
+		}
+		ctx.EventManager().EmitEvent(event)
+	}
+}
+
+func executeTimeout(ctx sdk.Context, app sdk.CrossChainTimeoutApplication, payload []byte) (result sdk.ExecuteResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger().With("module", "ibc").Error("execute timeout package panic",
+				"err_log", fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack())))
+			result = sdk.ExecuteResult{
+				Err: sdk.ErrInternal(fmt.Sprintf("execute timeout package failed: %v", r)),
+			}
+		}
+	}()
+	return app.ExecuteTimeoutPackage(ctx, payload)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/ibctransfer"
+)
+
+// GetCmdQueryDenomTrace implements the command to query the trace of a
+// voucher denom, or the traces of all the vouchers.
+func GetCmdQueryDenomTrace(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "denom-trace [hash]",
+		Short: "Query the denom trace of a voucher denom or hash, or of all the vouchers",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			path, bz := fmt.Sprintf("custom/%s/%s", queryRoute, ibctransfer.QueryDenomTraces), []byte(nil)
+			if len(args) == 1 {
+				var err error
+				bz, err = cdc.MarshalJSON(ibctransfer.QueryDenomTraceParams{Hash: args[0]})
+				if err != nil {
+					return err
+				}
+				path = fmt.Sprintf("custom/%s/%s", queryRoute, ibctransfer.QueryDenomTrace)
+			}
+
+			res, err := cliCtx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/ibctransfer"
+)
+
+// GetCmdTransfer implements the command transferring a token to a receiver on
+// the counterparty chain. The denom is a separate arg as the voucher denoms
+// are not parsed as coins.
+func GetCmdTransfer(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ibc-transfer [receiver] [amount] [denom]",
+		Args:  cobra.ExactArgs(3),
+		Short: "transfer a token to a receiver on the counterparty chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			sender, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok || !amount.IsInt64() {
+				return sdk.ErrInvalidCoins(args[1])
+			}
+
+			msg := ibctransfer.NewMsgTransfer(sender, args[0], sdk.NewCoin(args[2], amount.Int64()))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+// nolint
+package ibctransfer
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 33
+
+	CodeInvalidInput   sdk.CodeType = 101
+	CodeInvalidPacket  sdk.CodeType = 102
+	CodeUnknownDenom   sdk.CodeType = 103
+	CodeNotPrepared    sdk.CodeType = 104
+	CodeTransferFailed sdk.CodeType = 105
+)
+
+// The registered errors of the codes of the ibc transfer codespace.
+var (
+	ErrCodeInvalidInput   = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeInvalidPacket  = sdk.Register(DefaultCodespace, CodeInvalidPacket, "invalid packet")
+	ErrCodeUnknownDenom   = sdk.Register(DefaultCodespace, CodeUnknownDenom, "unknown denom")
+	ErrCodeNotPrepared    = sdk.Register(DefaultCodespace, CodeNotPrepared, "transfer not prepared")
+	ErrCodeTransferFailed = sdk.Register(DefaultCodespace, CodeTransferFailed, "transfer failed")
+)
+
+func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, msg)
+}
+
+func ErrInvalidPacket(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPacket, msg)
+}
+
+func ErrUnknownDenom(codespace sdk.CodespaceType, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownDenom, fmt.Sprintf("no denom trace of %s", denom))
+}
+
+func ErrNotPrepared(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNotPrepared, "the keeper is not prepared for side chain")
+}
+
+func ErrTransferFailed(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeTransferFailed, msg)
+}
@@ -0,0 +1,32 @@
+package ibctransfer
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the sequence of the syn package of a transfer
+const TagSequence = "ibc_transfer_sequence"
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgTransfer:
+			return handleMsgTransfer(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in ibctransfer module").Result()
+		}
+	}
+}
+
+func handleMsgTransfer(ctx sdk.Context, msg MsgTransfer, k Keeper) sdk.Result {
+	sequence, tags, err := k.SendTransfer(ctx, msg.Sender, msg.Receiver, msg.Token)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tags.AppendTags(sdk.NewTags(TagSequence, []byte(strconv.FormatUint(sequence, 10)))),
+	}
+}
@@ -0,0 +1,310 @@
+package ibctransfer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+const (
+	ChannelName = "transfer"
+	ChannelId   = sdk.ChannelID(32)
+
+	// the identifier of the channel on this chain, the transfers are bound to
+	// a single counterparty chain
+	ChannelIdentifier = "channel-32"
+)
+
+var (
+	DenomTraceKeyPrefix = []byte{0x01}
+)
+
+// GetDenomTraceKey returns the key of the trace of a voucher denom hash.
+func GetDenomTraceKey(hash []byte) []byte {
+	return append(DenomTraceKeyPrefix, hash...)
+}
+
+// Keeper of the ICS-20 token transfers to the counterparty chain
+type Keeper struct {
+	storeKey     sdk.StoreKey
+	cdc          *codec.Codec
+	bk           bank.Keeper
+	supplyKeeper supply.Keeper
+
+	// codespace
+	codespace sdk.CodespaceType
+
+	ScKeeper      *sidechain.Keeper
+	ibcKeeper     *ibc.Keeper
+	destChainName string
+
+	// the port and channel of the channel on the counterparty chain
+	counterpartyPort    string
+	counterpartyChannel string
+}
+
+// NewKeeper creates an ibc transfer keeper
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, bk bank.Keeper, supplyKeeper supply.Keeper, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:     key,
+		cdc:          cdc,
+		bk:           bk,
+		supplyKeeper: supplyKeeper,
+		codespace:    codespace,
+	}
+}
+
+// SetupForSideChain binds the transfers to the counterparty port and channel
+// of the dest chain, the transfers not acknowledged within timeout are
+// refunded.
+func (k *Keeper) SetupForSideChain(scKeeper *sidechain.Keeper, ibcKeeper *ibc.Keeper, destChainName string,
+	counterpartyPort, counterpartyChannel string, timeout time.Duration) {
+
+	k.ScKeeper = scKeeper
+	k.ibcKeeper = ibcKeeper
+	k.destChainName = destChainName
+	k.counterpartyPort = counterpartyPort
+	k.counterpartyChannel = counterpartyChannel
+	k.initIbc(timeout)
+}
+
+func (k *Keeper) initIbc(timeout time.Duration) {
+	err := k.ScKeeper.RegisterChannel(ChannelName, ChannelId, k)
+	if err != nil {
+		panic(fmt.Sprintf("register ibc channel failed, channel=%s, err=%s", ChannelName, err.Error()))
+	}
+	err = k.ScKeeper.RegisterChannelTimeout(ChannelId, timeout)
+	if err != nil {
+		panic(fmt.Sprintf("register ibc channel timeout failed, channel=%s, err=%s", ChannelName, err.Error()))
+	}
+}
+
+// GetDenomTrace returns the trace of a voucher denom hash.
+func (k Keeper) GetDenomTrace(ctx sdk.Context, hash []byte) (DenomTrace, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetDenomTraceKey(hash))
+	if bz == nil {
+		return DenomTrace{}, false
+	}
+	var trace DenomTrace
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &trace)
+	return trace, true
+}
+
+func (k Keeper) HasDenomTrace(ctx sdk.Context, hash []byte) bool {
+	return ctx.KVStore(k.storeKey).Has(GetDenomTraceKey(hash))
+}
+
+func (k Keeper) SetDenomTrace(ctx sdk.Context, trace DenomTrace) {
+	ctx.KVStore(k.storeKey).Set(GetDenomTraceKey(trace.Hash()), k.cdc.MustMarshalBinaryLengthPrefixed(trace))
+}
+
+// GetAllDenomTraces returns the traces of the vouchers, sorted by hash.
+func (k Keeper) GetAllDenomTraces(ctx sdk.Context) (traces []DenomTrace) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), DenomTraceKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var trace DenomTrace
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &trace)
+		traces = append(traces, trace)
+	}
+	return traces
+}
+
+// the full denom path of a native denom or a voucher denom
+func (k Keeper) fullDenomPath(ctx sdk.Context, denom string) (string, sdk.Error) {
+	if !strings.HasPrefix(denom, VoucherDenomPrefix) {
+		return denom, nil
+	}
+	hash, err := hex.DecodeString(denom[len(VoucherDenomPrefix):])
+	if err != nil {
+		return "", ErrUnknownDenom(k.codespace, denom)
+	}
+	trace, found := k.GetDenomTrace(ctx, hash)
+	if !found {
+		return "", ErrUnknownDenom(k.codespace, denom)
+	}
+	return trace.GetFullDenomPath(), nil
+}
+
+// SendTransfer sends the token to the receiver on the counterparty chain. The
+// tokens received on the channel are burned as the counterparty chain is
+// their source, the other tokens are escrowed.
+func (k Keeper) SendTransfer(ctx sdk.Context, sender sdk.AccAddress, receiver string, token sdk.Coin) (uint64, sdk.Tags, sdk.Error) {
+	if k.ibcKeeper == nil {
+		return 0, nil, ErrNotPrepared(k.codespace)
+	}
+	fullDenomPath, sdkErr := k.fullDenomPath(ctx, token.Denom)
+	if sdkErr != nil {
+		return 0, nil, sdkErr
+	}
+
+	coins := sdk.Coins{token}
+	var tags sdk.Tags
+	if strings.HasPrefix(fullDenomPath, GetDenomPrefix(PortID, ChannelIdentifier)) {
+		_, tags, sdkErr = k.bk.SubtractCoins(ctx, sender, coins)
+		if sdkErr != nil {
+			return 0, nil, sdkErr
+		}
+		if sdkErr = k.supplyKeeper.Burn(ctx, coins); sdkErr != nil {
+			return 0, nil, sdkErr
+		}
+	} else {
+		tags, sdkErr = k.bk.SendCoins(ctx, sender, EscrowAddress(PortID, ChannelIdentifier), coins)
+		if sdkErr != nil {
+			return 0, nil, sdkErr
+		}
+	}
+
+	packet := NewFungibleTokenPacket(PortID, ChannelIdentifier, k.counterpartyPort, k.counterpartyChannel,
+		NewFungibleTokenPacketData(fullDenomPath, token.Amount, sender.String(), receiver))
+	sequence, sdkErr := k.ibcKeeper.CreateIBCSyncPackage(ctx, k.destChainName, ChannelName, packet.GetBytes())
+	if sdkErr != nil {
+		return 0, nil, sdkErr
+	}
+	return sequence, tags, nil
+}
+
+// onRecvPacket credits the receiver of a packet. The tokens whose denom path
+// is prefixed by the source port and channel return to this chain and are
+// unescrowed, the other tokens are minted as vouchers whose denom path is
+// prefixed by the destination port and channel.
+func (k Keeper) onRecvPacket(ctx sdk.Context, packet FungibleTokenPacket) (sdk.Tags, sdk.Error) {
+	if packet.DestinationPort != PortID || packet.DestinationChannel != ChannelIdentifier {
+		return nil, ErrInvalidPacket(k.codespace, fmt.Sprintf("packet sent to %s/%s",
+			packet.DestinationPort, packet.DestinationChannel))
+	}
+	data := packet.Data
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return nil, ErrInvalidPacket(k.codespace, fmt.Sprintf("invalid receiver %s", data.Receiver))
+	}
+
+	sourcePrefix := GetDenomPrefix(packet.SourcePort, packet.SourceChannel)
+	if strings.HasPrefix(data.Denom, sourcePrefix) {
+		coins := sdk.Coins{sdk.NewCoin(ParseDenomTrace(data.Denom[len(sourcePrefix):]).IBCDenom(), data.GetAmount())}
+		return k.bk.SendCoins(ctx, EscrowAddress(packet.DestinationPort, packet.DestinationChannel), receiver, coins)
+	}
+
+	trace := ParseDenomTrace(GetDenomPrefix(packet.DestinationPort, packet.DestinationChannel) + data.Denom)
+	if !k.HasDenomTrace(ctx, trace.Hash()) {
+		k.SetDenomTrace(ctx, trace)
+	}
+	coins := sdk.Coins{sdk.NewCoin(trace.IBCDenom(), data.GetAmount())}
+	if sdkErr := k.supplyKeeper.Mint(ctx, coins); sdkErr != nil {
+		return nil, sdkErr
+	}
+	_, tags, sdkErr := k.bk.AddCoins(ctx, receiver, coins)
+	return tags, sdkErr
+}
+
+// refundPacket returns the tokens of a packet not received to its sender, the
+// tokens burned on send are minted back and the others unescrowed.
+func (k Keeper) refundPacket(ctx sdk.Context, packet FungibleTokenPacket) (sdk.Tags, sdk.Error) {
+	data := packet.Data
+	sender, err := sdk.AccAddressFromBech32(data.Sender)
+	if err != nil {
+		return nil, ErrInvalidPacket(k.codespace, fmt.Sprintf("invalid sender %s", data.Sender))
+	}
+
+	trace := ParseDenomTrace(data.Denom)
+	coins := sdk.Coins{sdk.NewCoin(trace.IBCDenom(), data.GetAmount())}
+	if !strings.HasPrefix(data.Denom, GetDenomPrefix(packet.SourcePort, packet.SourceChannel)) {
+		return k.bk.SendCoins(ctx, EscrowAddress(packet.SourcePort, packet.SourceChannel), sender, coins)
+	}
+	if sdkErr := k.supplyKeeper.Mint(ctx, coins); sdkErr != nil {
+		return nil, sdkErr
+	}
+	_, tags, sdkErr := k.bk.AddCoins(ctx, sender, coins)
+	return tags, sdkErr
+}
+
+// the packet of the syn package acknowledged by the ack being executed
+func (k Keeper) acknowledgedPacket(ctx sdk.Context) (FungibleTokenPacket, sdk.Error) {
+	destChainID, err := k.ScKeeper.GetDestChainID(k.destChainName)
+	if err != nil {
+		return FungibleTokenPacket{}, sdk.ErrInternal(err.Error())
+	}
+	sequence := k.ibcKeeper.GetAckSequence(ctx, destChainID, ChannelId)
+	if sequence == 0 {
+		return FungibleTokenPacket{}, ErrInvalidPacket(k.codespace, "no packet acknowledged")
+	}
+	payload, _ := k.ibcKeeper.GetIBCPackageById(ctx, destChainID, ChannelId, sequence-1)
+	if len(payload) < sTypes.PackageHeaderLength {
+		return FungibleTokenPacket{}, ErrInvalidPacket(k.codespace, fmt.Sprintf("packet %d not found", sequence-1))
+	}
+	return k.parsePacket(payload[sTypes.PackageHeaderLength:])
+}
+
+func (k Keeper) parsePacket(payload []byte) (FungibleTokenPacket, sdk.Error) {
+	packet, err := ParseFungibleTokenPacket(payload)
+	if err != nil {
+		return packet, ErrInvalidPacket(k.codespace, err.Error())
+	}
+	return packet, nil
+}
+
+// ExecuteSynPackage receives a packet, the failures are returned in an
+// error ack so that the counterparty chain refunds the sender.
+func (k *Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	packet, sdkErr := k.parsePacket(payload)
+	var tags sdk.Tags
+	if sdkErr == nil {
+		tags, sdkErr = k.onRecvPacket(ctx, packet)
+	}
+	if sdkErr != nil {
+		return sdk.ExecuteResult{
+			Err:     sdkErr,
+			Payload: NewErrorAcknowledgement(sdkErr.RawError()).GetBytes(),
+		}
+	}
+	return sdk.ExecuteResult{
+		Tags:    tags,
+		Payload: NewResultAcknowledgement().GetBytes(),
+	}
+}
+
+// ExecuteAckPackage refunds the packets the counterparty chain failed to
+// receive.
+func (k *Keeper) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	ack, err := ParseAcknowledgement(payload)
+	if err != nil {
+		return sdk.ExecuteResult{Err: ErrInvalidPacket(k.codespace, err.Error())}
+	}
+	if ack.Success() {
+		return sdk.ExecuteResult{}
+	}
+	packet, sdkErr := k.acknowledgedPacket(ctx)
+	if sdkErr != nil {
+		return sdk.ExecuteResult{Err: sdkErr}
+	}
+	tags, sdkErr := k.refundPacket(ctx, packet)
+	return sdk.ExecuteResult{Err: sdkErr, Tags: tags}
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (k *Keeper) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return k.refundPayload(ctx, payload)
+}
+
+// ExecuteTimeoutPackage refunds the packets not acknowledged in time.
+func (k *Keeper) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return k.refundPayload(ctx, payload)
+}
+
+func (k *Keeper) refundPayload(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	packet, sdkErr := k.parsePacket(payload)
+	if sdkErr != nil {
+		return sdk.ExecuteResult{Err: sdkErr}
+	}
+	tags, sdkErr := k.refundPacket(ctx, packet)
+	return sdk.ExecuteResult{Err: sdkErr, Tags: tags}
+}
@@ -0,0 +1,181 @@
+package ibctransfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+var destChainID = sdk.ChainID(2)
+
+func createTestInput(t *testing.T) (sdk.Context, bank.Keeper, supply.Keeper, *ibc.Keeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySupply := sdk.NewKVStoreKey("supply")
+	keyTransfer := sdk.NewKVStoreKey("ibctransfer")
+	keyIbc := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyTransfer, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyIbc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(100, 0)}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	am := auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, am)
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+
+	scKeeper := sidechain.NewKeeper(keySideChain, paramsKeeper.Subspace(sidechain.DefaultParamspace), cdc)
+	scKeeper.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x99})
+	scKeeper.SetSrcChainID(sdk.ChainID(1))
+	require.NoError(t, scKeeper.RegisterDestChain("bsc", destChainID))
+	scKeeper.SetChannelSendPermission(ctx, destChainID, ChannelId, sdk.ChannelAllow)
+
+	ibcKeeper := ibc.NewKeeper(keyIbc, paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
+	ibcKeeper.SetParams(ctx.WithSideChainKeyPrefix([]byte{0x99}), ibc.Params{RelayerFee: ibc.DefaultRelayerFeeParam})
+
+	keeper := NewKeeper(cdc, keyTransfer, ck, supplyKeeper, DefaultCodespace)
+	keeper.SetupForSideChain(&scKeeper, &ibcKeeper, "bsc", PortID, counterpartyChannel, time.Minute)
+	return ctx, ck, supplyKeeper, &ibcKeeper, keeper
+}
+
+// the channel has another identifier on the counterparty chain
+const counterpartyChannel = "channel-7"
+
+func sentPacket(t *testing.T, ctx sdk.Context, ibcKeeper *ibc.Keeper, sequence uint64) FungibleTokenPacket {
+	payload, err := ibcKeeper.GetIBCPackageById(ctx, destChainID, ChannelId, sequence)
+	require.NoError(t, err)
+	packet, err := ParseFungibleTokenPacket(payload[sTypes.PackageHeaderLength:])
+	require.NoError(t, err)
+	return packet
+}
+
+// the payload of a packet sent by the counterparty chain
+func receivedPayload(denom string, amount int64, receiver sdk.AccAddress) []byte {
+	data := NewFungibleTokenPacketData(denom, amount, "0xsender", receiver.String())
+	return NewFungibleTokenPacket(PortID, counterpartyChannel, PortID, ChannelIdentifier, data).GetBytes()
+}
+
+func TestTransferRoundTrip(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, ck, supplyKeeper, ibcKeeper, keeper := createTestInput(t)
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	escrow := EscrowAddress(PortID, ChannelIdentifier)
+	_, _, err := ck.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("BNB", 100)})
+	require.NoError(t, err)
+
+	// the native tokens are escrowed
+	sequence, _, err := keeper.SendTransfer(ctx, addr, "0xreceiver", sdk.NewCoin("BNB", 40))
+	require.NoError(t, err)
+	require.Equal(t, NewFungibleTokenPacket(PortID, ChannelIdentifier, PortID, counterpartyChannel,
+		NewFungibleTokenPacketData("BNB", 40, addr.String(), "0xreceiver")), sentPacket(t, ctx, ibcKeeper, sequence))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 60)}, ck.GetCoins(ctx, addr))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 40)}, ck.GetCoins(ctx, escrow))
+
+	// the tokens of the counterparty chain are minted as vouchers
+	result := keeper.ExecuteSynPackage(ctx, receivedPayload("XYZ", 30, addr), 0)
+	require.True(t, result.IsOk())
+	require.Equal(t, NewResultAcknowledgement().GetBytes(), result.Payload)
+	trace := DenomTrace{Path: "transfer/channel-32", BaseDenom: "XYZ"}
+	found, ok := keeper.GetDenomTrace(ctx, trace.Hash())
+	require.True(t, ok)
+	require.Equal(t, trace, found)
+	voucher := trace.IBCDenom()
+	require.Equal(t, int64(30), ck.GetCoins(ctx, addr).AmountOf(voucher))
+	require.Equal(t, int64(30), supplyKeeper.GetTotal(ctx, voucher))
+
+	// the vouchers sent back to their source are burned
+	sequence, _, err = keeper.SendTransfer(ctx, addr, "0xreceiver", sdk.NewCoin(voucher, 10))
+	require.NoError(t, err)
+	require.Equal(t, "transfer/channel-32/XYZ", sentPacket(t, ctx, ibcKeeper, sequence).Data.Denom)
+	require.Equal(t, int64(20), ck.GetCoins(ctx, addr).AmountOf(voucher))
+	require.Equal(t, int64(20), supplyKeeper.GetTotal(ctx, voucher))
+
+	_, _, err = keeper.SendTransfer(ctx, addr, "0xreceiver", sdk.NewCoin("ibc/ABCD", 10))
+	require.Error(t, err)
+
+	// the native tokens sent back are unescrowed
+	result = keeper.ExecuteSynPackage(ctx, receivedPayload("transfer/channel-7/BNB", 15, addr), 0)
+	require.True(t, result.IsOk())
+	require.Equal(t, int64(75), ck.GetCoins(ctx, addr).AmountOf("BNB"))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 25)}, ck.GetCoins(ctx, escrow))
+
+	// the failure to receive is returned in an error ack
+	result = keeper.ExecuteSynPackage(ctx, receivedPayload("transfer/channel-7/BNB", 100, addr), 0)
+	require.False(t, result.IsOk())
+	ack, parseErr := ParseAcknowledgement(result.Payload)
+	require.NoError(t, parseErr)
+	require.False(t, ack.Success())
+
+	// the packets sent to another channel are rejected
+	data := NewFungibleTokenPacketData("XYZ", 30, "0xsender", addr.String())
+	result = keeper.ExecuteSynPackage(ctx, NewFungibleTokenPacket(PortID, counterpartyChannel, PortID, "channel-9", data).GetBytes(), 0)
+	require.False(t, result.IsOk())
+	require.Equal(t, int64(20), ck.GetCoins(ctx, addr).AmountOf(voucher))
+}
+
+func TestTransferRefund(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, ck, supplyKeeper, ibcKeeper, keeper := createTestInput(t)
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	_, _, err := ck.AddCoins(ctx, addr, sdk.Coins{sdk.NewCoin("BNB", 100)})
+	require.NoError(t, err)
+	result := keeper.ExecuteSynPackage(ctx, receivedPayload("XYZ", 30, addr), 0)
+	require.True(t, result.IsOk())
+	voucher := DenomTrace{Path: "transfer/channel-32", BaseDenom: "XYZ"}.IBCDenom()
+
+	for _, token := range []sdk.Coin{sdk.NewCoin("BNB", 10), sdk.NewCoin("BNB", 20), sdk.NewCoin(voucher, 30)} {
+		_, _, err = keeper.SendTransfer(ctx, addr, "0xreceiver", token)
+		require.NoError(t, err)
+	}
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 70)}, ck.GetCoins(ctx, addr))
+
+	// a successful ack refunds nothing
+	require.False(t, ibcKeeper.AckPendingPackage(ctx, destChainID, ChannelId))
+	require.True(t, keeper.ExecuteAckPackage(ctx, NewResultAcknowledgement().GetBytes()).IsOk())
+	require.Equal(t, int64(70), ck.GetCoins(ctx, addr).AmountOf("BNB"))
+
+	// an error ack refunds the escrowed tokens
+	require.False(t, ibcKeeper.AckPendingPackage(ctx, destChainID, ChannelId))
+	require.True(t, keeper.ExecuteAckPackage(ctx, NewErrorAcknowledgement("failed").GetBytes()).IsOk())
+	require.Equal(t, int64(90), ck.GetCoins(ctx, addr).AmountOf("BNB"))
+
+	// the burned vouchers not acknowledged in time are minted back
+	ibc.EndBlocker(ctx.WithBlockTime(time.Unix(160, 0)), *ibcKeeper)
+	require.Equal(t, int64(30), ck.GetCoins(ctx, addr).AmountOf(voucher))
+	require.Equal(t, int64(30), supplyKeeper.GetTotal(ctx, voucher))
+}
@@ -0,0 +1,68 @@
+package ibctransfer
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const (
+	MsgRoute        = "ibctransfer"
+	TypeMsgTransfer = "transfer"
+)
+
+// verify interface at compile time
+var _ sdk.Msg = MsgTransfer{}
+
+// MsgTransfer transfers the token of the sender to the receiver on the
+// counterparty chain. The native tokens are escrowed, the vouchers of the
+// tokens received from the counterparty chain are burned.
+type MsgTransfer struct {
+	Sender   sdk.AccAddress `json:"sender"`
+	Receiver string         `json:"receiver"`
+	Token    sdk.Coin       `json:"token"`
+}
+
+func NewMsgTransfer(sender sdk.AccAddress, receiver string, token sdk.Coin) MsgTransfer {
+	return MsgTransfer{
+		Sender:   sender,
+		Receiver: receiver,
+		Token:    token,
+	}
+}
+
+// nolint
+func (msg MsgTransfer) Route() string { return MsgRoute }
+func (msg MsgTransfer) Type() string  { return TypeMsgTransfer }
+func (msg MsgTransfer) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTransfer) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgTransfer) ValidateBasic() sdk.Error {
+	if len(msg.Sender) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected sender address length is %d, actual length is %d", sdk.AddrLen, len(msg.Sender)))
+	}
+	if strings.TrimSpace(msg.Receiver) == "" {
+		return ErrInvalidInput(DefaultCodespace, "receiver cannot be empty")
+	}
+	if len(msg.Token.Denom) == 0 || !msg.Token.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Token.String())
+	}
+	return nil
+}
+
+func (msg MsgTransfer) GetInvolvedAddresses() []sdk.AccAddress {
+	return append(msg.GetSigners(), EscrowAddress(PortID, ChannelIdentifier))
+}
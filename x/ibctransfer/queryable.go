@@ -0,0 +1,56 @@
+package ibctransfer
+
+import (
+	"encoding/hex"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the ibctransfer Querier
+const (
+	QueryDenomTrace  = "denom_trace"
+	QueryDenomTraces = "denom_traces"
+)
+
+// Params for query 'custom/ibctransfer/denom_trace'
+type QueryDenomTraceParams struct {
+	// the hash of the full denom path, or the voucher denom
+	Hash string
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryDenomTrace:
+			var params QueryDenomTraceParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			hash, err := hex.DecodeString(strings.TrimPrefix(params.Hash, VoucherDenomPrefix))
+			if err != nil {
+				return nil, sdk.ErrUnknownRequest("invalid denom trace hash")
+			}
+			trace, found := keeper.GetDenomTrace(ctx, hash)
+			if !found {
+				return nil, ErrUnknownDenom(keeper.codespace, params.Hash)
+			}
+			return marshalJSON(keeper.cdc, trace)
+		case QueryDenomTraces:
+			return marshalJSON(keeper.cdc, keeper.GetAllDenomTraces(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibctransfer query endpoint")
+		}
+	}
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
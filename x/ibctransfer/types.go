@@ -0,0 +1,219 @@
+package ibctransfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// the port of the ICS-20 denom paths
+	PortID = "transfer"
+
+	// the prefix of the denoms of the vouchers minted for the received coins
+	VoucherDenomPrefix = "ibc/"
+)
+
+// FungibleTokenPacket is the ICS-20 packet of a token transfer, its data and
+// the ports and channels of the sending and the receiving chains.
+type FungibleTokenPacket struct {
+	SourcePort         string                  `json:"source_port"`
+	SourceChannel      string                  `json:"source_channel"`
+	DestinationPort    string                  `json:"destination_port"`
+	DestinationChannel string                  `json:"destination_channel"`
+	Data               FungibleTokenPacketData `json:"data"`
+}
+
+func NewFungibleTokenPacket(sourcePort, sourceChannel, destPort, destChannel string,
+	data FungibleTokenPacketData) FungibleTokenPacket {
+
+	return FungibleTokenPacket{
+		SourcePort:         sourcePort,
+		SourceChannel:      sourceChannel,
+		DestinationPort:    destPort,
+		DestinationChannel: destChannel,
+		Data:               data,
+	}
+}
+
+func (p FungibleTokenPacket) ValidateBasic() error {
+	for _, id := range []string{p.SourcePort, p.SourceChannel, p.DestinationPort, p.DestinationChannel} {
+		if strings.TrimSpace(id) == "" || strings.Contains(id, "/") {
+			return fmt.Errorf("invalid port or channel %q", id)
+		}
+	}
+	return p.Data.ValidateBasic()
+}
+
+// GetBytes returns the packet as sorted JSON.
+func (p FungibleTokenPacket) GetBytes() []byte {
+	bz, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+func ParseFungibleTokenPacket(payload []byte) (FungibleTokenPacket, error) {
+	var packet FungibleTokenPacket
+	if err := json.Unmarshal(payload, &packet); err != nil {
+		return packet, fmt.Errorf("cannot unmarshal the packet: %v", err)
+	}
+	return packet, packet.ValidateBasic()
+}
+
+// FungibleTokenPacketData is the ICS-20 data of a token transfer, its denom
+// is the full denom path of the token on the sending chain.
+type FungibleTokenPacketData struct {
+	Denom    string `json:"denom"`
+	Amount   string `json:"amount"`
+	Sender   string `json:"sender"`
+	Receiver string `json:"receiver"`
+}
+
+func NewFungibleTokenPacketData(denom string, amount int64, sender, receiver string) FungibleTokenPacketData {
+	return FungibleTokenPacketData{
+		Denom:    denom,
+		Amount:   strconv.FormatInt(amount, 10),
+		Sender:   sender,
+		Receiver: receiver,
+	}
+}
+
+func (p FungibleTokenPacketData) ValidateBasic() error {
+	if amount, err := strconv.ParseInt(p.Amount, 10, 64); err != nil || amount <= 0 {
+		return fmt.Errorf("invalid amount %q", p.Amount)
+	}
+	if strings.TrimSpace(p.Sender) == "" {
+		return fmt.Errorf("sender cannot be empty")
+	}
+	if strings.TrimSpace(p.Receiver) == "" {
+		return fmt.Errorf("receiver cannot be empty")
+	}
+	return ParseDenomTrace(p.Denom).Validate()
+}
+
+// GetAmount returns the amount of a validated packet.
+func (p FungibleTokenPacketData) GetAmount() int64 {
+	amount, _ := strconv.ParseInt(p.Amount, 10, 64)
+	return amount
+}
+
+// Acknowledgement is the ICS-20 ack of a packet, either its result or the
+// error receiving it.
+type Acknowledgement struct {
+	Result []byte `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewResultAcknowledgement() Acknowledgement {
+	return Acknowledgement{Result: []byte{byte(1)}}
+}
+
+func NewErrorAcknowledgement(err string) Acknowledgement {
+	return Acknowledgement{Error: err}
+}
+
+func ParseAcknowledgement(payload []byte) (Acknowledgement, error) {
+	var ack Acknowledgement
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		return ack, fmt.Errorf("cannot unmarshal the ack: %v", err)
+	}
+	return ack, nil
+}
+
+func (a Acknowledgement) Success() bool {
+	return len(a.Error) == 0
+}
+
+func (a Acknowledgement) GetBytes() []byte {
+	bz, err := json.Marshal(a)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// DenomTrace is the path of the ports and the channels a token was
+// transferred through, from the newest, and its denom on its source chain.
+type DenomTrace struct {
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+// ParseDenomTrace parses a full denom path, e.g. transfer/channel-32/BNB.
+func ParseDenomTrace(fullDenomPath string) DenomTrace {
+	parts := strings.Split(fullDenomPath, "/")
+	if len(parts) == 1 {
+		return DenomTrace{BaseDenom: fullDenomPath}
+	}
+	// the path is made of port and channel pairs
+	pathLen := len(parts) - 1
+	if pathLen%2 != 0 {
+		pathLen--
+	}
+	return DenomTrace{
+		Path:      strings.Join(parts[:pathLen], "/"),
+		BaseDenom: strings.Join(parts[pathLen:], "/"),
+	}
+}
+
+func (dt DenomTrace) Validate() error {
+	if strings.TrimSpace(dt.BaseDenom) == "" {
+		return fmt.Errorf("base denom cannot be empty")
+	}
+	if dt.Path == "" {
+		return nil
+	}
+	for _, part := range strings.Split(dt.Path, "/") {
+		if strings.TrimSpace(part) == "" {
+			return fmt.Errorf("invalid denom path %s", dt.Path)
+		}
+	}
+	return nil
+}
+
+// Hash returns the SHA256 hash of the full denom path.
+func (dt DenomTrace) Hash() []byte {
+	hash := sha256.Sum256([]byte(dt.GetFullDenomPath()))
+	return hash[:]
+}
+
+func (dt DenomTrace) GetFullDenomPath() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return dt.Path + "/" + dt.BaseDenom
+}
+
+// IBCDenom returns the denom of the vouchers of the trace, the native tokens
+// keep their denom.
+func (dt DenomTrace) IBCDenom() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return VoucherDenomPrefix + strings.ToUpper(hex.EncodeToString(dt.Hash()))
+}
+
+func (dt DenomTrace) String() string {
+	return fmt.Sprintf("%s: %s", dt.IBCDenom(), dt.GetFullDenomPath())
+}
+
+// GetDenomPrefix returns the prefix of the denom paths of the tokens received
+// on the channel of port.
+func GetDenomPrefix(port, channel string) string {
+	return port + "/" + channel + "/"
+}
+
+// EscrowAddress returns the account escrowing the native tokens sent on the
+// channel of port.
+func EscrowAddress(port, channel string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(fmt.Sprintf("%s/%s/escrow", port, channel))))
+}
@@ -8,6 +8,7 @@ and that the desired invariants hold.
 It can additionally be used to detect what the performance benchmarks in the
 system are, by using benchmarking mode and cpu / mem profiling.
 If it detects a failure, it provides the entire log of what was ran,
+and the seed to replay the same simulation from to reproduce it.
 
 The simulator takes as input: a random seed, the set of operations to run,
 the invariants to test, and additional parameters to configure how long to run,
@@ -48,8 +48,10 @@ func initChain(r *rand.Rand, accounts []Account, setups []RandSetup, app *baseap
 	return
 }
 
+// randTimestamp returns a random genesis time, before the year 2500 so the
+// block times stay in the range of the amino timestamps
 func randTimestamp(r *rand.Rand) time.Time {
-	unixTime := r.Int63n(int64(math.Pow(2, 40)))
+	unixTime := r.Int63n(int64(math.Pow(2, 34)))
 	return time.Unix(unixTime, 0)
 }
 
@@ -64,6 +66,12 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 	stopEarly := false
 	testingMode, t, b := getTestingMode(tb)
 	fmt.Printf("Starting SimulateFromSeed with randomness created with seed %d\n", int(seed))
+	// the failures are reproduced by running the simulation from the same seed
+	defer func() {
+		if simError != nil || tb.Failed() {
+			fmt.Printf("Simulation failed, replay it from the seed %d\n", seed)
+		}
+	}()
 	r := rand.New(rand.NewSource(seed))
 	timestamp := randTimestamp(r)
 	fmt.Printf("Starting the simulation from time %v, unixtime %v\n", timestamp.UTC().Format(time.UnixDate), timestamp.Unix())
@@ -86,7 +94,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 	opCount := 0
 
 	// Setup code to catch SIGTERM's
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
 		receivedSignal := <-c
@@ -223,7 +231,7 @@ func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, event f
 			logUpdate, futureOps, err := selectOp(r)(r, app, ctx, accounts, event)
 			if err != nil {
 				displayLogs()
-				tb.Fatalf("error on operation %d within block %d, %v", header.Height, opCount, err)
+				tb.Fatalf("error on operation %d within block %d, %v", opCount, header.Height, err)
 			}
 			logWriter(logUpdate)
 
@@ -80,7 +80,7 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 }
 
 func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package) (sdk.Event, sdk.Error) {
-	logger := ctx.Logger().With("module", "x/oracle")
+	logger := ctx.Logger().With("module", "oracle")
 
 	crossChainApp := oracleKeeper.ScKeeper.GetCrossChainApp(ctx, pack.ChannelId)
 	if crossChainApp == nil {
@@ -126,8 +126,17 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		)
 	}
 
+	var crash bool
+	var result sdk.ExecuteResult
 	cacheCtx, write := ctx.CacheContext()
-	crash, result := executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
+	if packageType != sdk.SynCrossChainPackageType && oracleKeeper.IbcKeeper.AckPendingPackage(ctx, chainId, pack.ChannelId) {
+		// the syn package already timed out
+		result = sdk.ExecuteResult{
+			Err: types.ErrLateAckPackage(fmt.Sprintf("the ack of channel %d is late, its syn package timed out", pack.ChannelId)),
+		}
+	} else {
+		crash, result = executeClaim(cacheCtx, crossChainApp, pack.Payload, packageType, feeAmount)
+	}
 	if result.IsOk() {
 		write()
 	} else if ctx.IsDeliverTx() {
@@ -24,6 +24,26 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeLateAckPackage                sdk.CodeType = 1014
+)
+
+// The registered errors of the codes of the oracle codespace.
+var (
+	ErrCodeProphecyNotFound              = sdk.Register(DefaultCodespace, CodeProphecyNotFound, "prophecy not found")
+	ErrCodeMinimumConsensusNeededInvalid = sdk.Register(DefaultCodespace, CodeMinimumConsensusNeededInvalid, "invalid minimum consensus needed")
+	ErrCodeNoClaims                      = sdk.Register(DefaultCodespace, CodeNoClaims, "no claims")
+	ErrCodeInvalidIdentifier             = sdk.Register(DefaultCodespace, CodeInvalidIdentifier, "invalid identifier")
+	ErrCodeProphecyFinalized             = sdk.Register(DefaultCodespace, CodeProphecyFinalized, "prophecy finalized")
+	ErrCodeDuplicateMessage              = sdk.Register(DefaultCodespace, CodeDuplicateMessage, "duplicate message")
+	ErrCodeInvalidClaim                  = sdk.Register(DefaultCodespace, CodeInvalidClaim, "invalid claim")
+	ErrCodeInvalidValidator              = sdk.Register(DefaultCodespace, CodeInvalidValidator, "invalid validator")
+	ErrCodeInternalDB                    = sdk.Register(DefaultCodespace, CodeInternalDB, "internal db error")
+	ErrCodeInvalidSequence               = sdk.Register(DefaultCodespace, CodeInvalidSequence, "invalid sequence")
+	ErrCodeChannelNotRegistered          = sdk.Register(DefaultCodespace, CodeChannelNotRegistered, "channel not registered")
+	ErrCodeInvalidLengthOfPayload        = sdk.Register(DefaultCodespace, CodeInvalidLengthOfPayload, "invalid length of payload")
+	ErrCodeFeeOverflow                   = sdk.Register(DefaultCodespace, CodeFeeOverflow, "fee overflow")
+	ErrCodeInvalidPayload                = sdk.Register(DefaultCodespace, CodeInvalidPayload, "invalid payload")
+	ErrCodeLateAckPackage                = sdk.Register(DefaultCodespace, CodeLateAckPackage, "late ack package")
 )
 
 func ErrProphecyNotFound() sdk.Error {
@@ -85,3 +105,7 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrLateAckPackage(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeLateAckPackage, msg)
+}
@@ -65,7 +65,7 @@ func SubmitCSCParamChangeProposalCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			amount, err := sdk.ParseCoins(initialDeposit)
+			amount, err := sdk.ParseUserCoins(initialDeposit)
 			if err != nil {
 				return err
 			}
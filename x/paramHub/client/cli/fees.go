@@ -63,7 +63,7 @@ func SubmitFeeChangeProposalCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			amount, err := sdk.ParseCoins(initialDeposit)
+			amount, err := sdk.ParseUserCoins(initialDeposit)
 			if err != nil {
 				return err
 			}
@@ -82,8 +82,10 @@ func (keeper *Keeper) registerFeeParamCallBack() {
 	)
 }
 
+// updateFeeCalculator rebuilds the fee table from the fee params and swaps it
+// in at once, the txs checked concurrently never see a partial table.
 func (keeper *Keeper) updateFeeCalculator(updates []types.FeeParam) {
-	fees.UnsetAllCalculators()
+	calculators := make(map[string]fees.FeeCalculator, len(updates))
 	for _, u := range updates {
 		if u, ok := u.(types.MsgFeeParams); ok {
 			generator := fees.GetCalculatorGenerator(u.GetMsgType())
@@ -94,10 +96,11 @@ func (keeper *Keeper) updateFeeCalculator(updates []types.FeeParam) {
 				if err != nil {
 					panic(err)
 				}
-				fees.RegisterCalculator(u.GetMsgType(), generator(u))
+				calculators[u.GetMsgType()] = generator(u)
 			}
 		}
 	}
+	fees.SetCalculators(calculators)
 }
 
 func (keeper *Keeper) getLastFeeChangeParam(ctx sdk.Context) []types.FeeParam {
@@ -10,11 +10,18 @@ type CodeType = sdk.CodeType
 const (
 	DefaultCodespace sdk.CodespaceType = 12
 
-	CodeMissSideChainId    CodeType = 101
-	CodeInvalidSideChainId CodeType = 102
+	CodeMissSideChainId          CodeType = 101
+	CodeInvalidSideChainId       CodeType = 102
 	CodeInvalidCrossChainPackage CodeType = 103
 )
 
+// The registered errors of the codes of the param hub codespace.
+var (
+	ErrCodeMissSideChainId          = sdk.Register(DefaultCodespace, CodeMissSideChainId, "missing side chain id")
+	ErrCodeInvalidSideChainId       = sdk.Register(DefaultCodespace, CodeInvalidSideChainId, "invalid side chain id")
+	ErrCodeInvalidCrossChainPackage = sdk.Register(DefaultCodespace, CodeInvalidCrossChainPackage, "invalid cross chain package")
+)
+
 func ErrMissSideChainId(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeMissSideChainId, "side chain id is missing")
 }
@@ -9,7 +9,9 @@ permission to access all existing spaces.
 
 Subspace can be used by the individual keepers, who needs a private parameter store
 that the other keeper cannot modify. Keeper can be used by the Governance keeper,
-who need to modify any parameter in case of the proposal passes.
+who need to modify any parameter in case of the proposal passes. The ParamChanges
+of a gov params change proposal are applied with Keeper.ApplyParamChanges, each
+value being the JSON encoding of the parameter in its subspace.
 
 Basic Usage:
 
@@ -215,3 +215,39 @@ func TestSubspace(t *testing.T) {
 		require.Equal(t, kv.param, indirect(kv.ptr), "stored param not equal, tc #%d", i)
 	}
 }
+
+func TestApplyParamChanges(t *testing.T) {
+	cdc := createTestCodec()
+	key := sdk.NewKVStoreKey("test")
+	tkey := sdk.NewTransientStoreKey("transient_test")
+	ctx := defaultContext(key, tkey)
+	keeper := NewKeeper(cdc, key, tkey)
+	space := keeper.Subspace("test").WithTypeTable(NewTypeTable(
+		[]byte("int64"), int64(0),
+		[]byte("struct"), s{},
+	))
+
+	changes := ParamChanges{Changes: []ParamChange{
+		{Subspace: "test", Key: "int64", Value: `"10"`},
+		{Subspace: "test", Key: "struct", Value: `{"type":"test/s","value":{"I":"5"}}`},
+	}}
+	require.NoError(t, changes.ValidateBasic())
+	require.NoError(t, keeper.ApplyParamChanges(ctx, changes.Changes))
+
+	var i int64
+	space.Get(ctx, []byte("int64"), &i)
+	require.Equal(t, int64(10), i)
+	var st s
+	space.Get(ctx, []byte("struct"), &st)
+	require.Equal(t, s{5}, st)
+
+	require.Error(t, ParamChanges{}.ValidateBasic())
+	require.Error(t, ParamChanges{Changes: []ParamChange{{Subspace: "test", Key: "int64"}}}.ValidateBasic())
+
+	// unknown subspaces, keys and invalid values are rejected
+	require.Error(t, keeper.ApplyParamChanges(ctx, []ParamChange{{Subspace: "unknown", Key: "int64", Value: `"1"`}}))
+	require.Error(t, keeper.ApplyParamChanges(ctx, []ParamChange{{Subspace: "test", Key: "unknown", Value: `"1"`}}))
+	require.Error(t, keeper.ApplyParamChanges(ctx, []ParamChange{{Subspace: "test", Key: "int64", Value: `true`}}))
+	space.Get(ctx, []byte("int64"), &i)
+	require.Equal(t, int64(10), i)
+}
@@ -0,0 +1,57 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ParamChange sets the parameter Key of the subspace Subspace to Value, the
+// JSON encoding of the parameter, e.g. `"1209600000000000"` for the
+// UnbondingTime of the stake subspace.
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s/%s: %s", pc.Subspace, pc.Key, pc.Value)
+}
+
+// ParamChanges is the description of a params change proposal of gov, the
+// changes are applied in order once the proposal passes.
+type ParamChanges struct {
+	Changes     []ParamChange `json:"changes"`
+	Description string        `json:"description"`
+}
+
+// ValidateBasic checks the changes without the state.
+func (pcs ParamChanges) ValidateBasic() error {
+	if len(pcs.Changes) == 0 {
+		return errors.New("no param changes")
+	}
+	for _, pc := range pcs.Changes {
+		if len(pc.Subspace) == 0 || len(pc.Key) == 0 || len(pc.Value) == 0 {
+			return fmt.Errorf("param change %s must have a subspace, a key and a value", pc)
+		}
+	}
+	return nil
+}
+
+// ApplyParamChanges sets the parameters of the changes, it stops at the first
+// change referring to an unknown subspace or parameter, or with an invalid
+// value. The caller must discard the state on error.
+func (k Keeper) ApplyParamChanges(ctx sdk.Context, changes []ParamChange) error {
+	for _, pc := range changes {
+		space, ok := k.GetSubspace(pc.Subspace)
+		if !ok {
+			return fmt.Errorf("unknown subspace %s", pc.Subspace)
+		}
+		if err := space.Update(ctx, []byte(pc.Key), []byte(pc.Value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -142,6 +143,22 @@ func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
 
 }
 
+// Update sets the parameter from its JSON value, it returns an error if the
+// parameter is not registered or the value doesn't decode to its type.
+func (s Subspace) Update(ctx sdk.Context, key []byte, value []byte) error {
+	ty, ok := s.table.m[string(key)]
+	if !ok {
+		return fmt.Errorf("parameter %s is not registered in subspace %s", key, s.name)
+	}
+
+	ptr := reflect.New(ty).Interface()
+	if err := s.cdc.UnmarshalJSON(value, ptr); err != nil {
+		return fmt.Errorf("invalid value of parameter %s: %v", key, err)
+	}
+	s.Set(ctx, key, ptr)
+	return nil
+}
+
 // Get to ParamSet
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 	for _, pair := range ps.KeyValuePairs() {
@@ -61,7 +61,7 @@ func SubmitChannelManageProposalCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			amount, err := sdk.ParseCoins(initialDeposit)
+			amount, err := sdk.ParseUserCoins(initialDeposit)
 			if err != nil {
 				return err
 			}
@@ -1,6 +1,10 @@
 package sidechain
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 type crossChainConfig struct {
 	srcChainID sdk.ChainID
@@ -9,6 +13,8 @@ type crossChainConfig struct {
 	channelIDToName map[sdk.ChannelID]string
 	channelIDToApp  map[sdk.ChannelID]sdk.CrossChainApplication
 
+	channelIDToTimeout map[sdk.ChannelID]time.Duration
+
 	destChainNameToID map[string]sdk.ChainID
 	destChainIDToName map[sdk.ChainID]string
 }
@@ -21,6 +27,8 @@ func newCrossChainCfg() *crossChainConfig {
 		destChainNameToID: make(map[string]sdk.ChainID),
 		destChainIDToName: make(map[sdk.ChainID]string),
 		channelIDToApp:    make(map[sdk.ChannelID]sdk.CrossChainApplication),
+
+		channelIDToTimeout: make(map[sdk.ChannelID]time.Duration),
 	}
 	return config
 }
@@ -2,6 +2,7 @@ package sidechain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -34,6 +35,29 @@ func TestRegisterCrossChainChannel(t *testing.T) {
 	require.Equal(t, sdk.ChannelID(4), channeID)
 }
 
+type mockTimeoutApp struct {
+	sdk.CrossChainApplication
+}
+
+func (app mockTimeoutApp) ExecuteTimeoutPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+func TestRegisterChannelTimeout(t *testing.T) {
+	_, keeper := CreateTestInput(t, true)
+	require.NoError(t, keeper.RegisterChannel("transfer", sdk.ChannelID(1), nil))
+	require.NoError(t, keeper.RegisterChannel("timeout", sdk.ChannelID(2), mockTimeoutApp{}))
+
+	require.Error(t, keeper.RegisterChannelTimeout(sdk.ChannelID(1), time.Minute))
+	require.Error(t, keeper.RegisterChannelTimeout(sdk.ChannelID(3), time.Minute))
+	require.Error(t, keeper.RegisterChannelTimeout(sdk.ChannelID(2), 0))
+	require.NoError(t, keeper.RegisterChannelTimeout(sdk.ChannelID(2), time.Minute))
+	require.Error(t, keeper.RegisterChannelTimeout(sdk.ChannelID(2), time.Hour))
+
+	require.Equal(t, time.Duration(0), keeper.GetChannelTimeout(sdk.ChannelID(1)))
+	require.Equal(t, time.Minute, keeper.GetChannelTimeout(sdk.ChannelID(2)))
+}
+
 func TestRegisterDestChainID(t *testing.T) {
 	_, keeper := CreateTestInput(t, true)
 	require.NoError(t, keeper.RegisterDestChain("bsc", sdk.ChainID(1)))
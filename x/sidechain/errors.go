@@ -10,6 +10,11 @@ const (
 	CodeInvalidSideChainId sdk.CodeType = 101
 )
 
+// The registered errors of the codes of the sidechain codespace.
+var (
+	ErrCodeInvalidSideChainId = sdk.Register(DefaultCodespace, CodeInvalidSideChainId, "invalid side chain id")
+)
+
 func ErrInvalidSideChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChainId, msg)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -99,6 +100,33 @@ func (k *Keeper) RegisterChannel(name string, id sdk.ChannelID, app sdk.CrossCha
 	return nil
 }
 
+// RegisterChannelTimeout sets the timeout of the acks of the syn packages sent
+// on the channel, its app must be a sdk.CrossChainTimeoutApplication. The
+// timeout must be registered with the channel, before its first package.
+func (k *Keeper) RegisterChannelTimeout(id sdk.ChannelID, timeout time.Duration) error {
+	app, ok := k.cfg.channelIDToApp[id]
+	if !ok {
+		return fmt.Errorf("non-existing channel")
+	}
+	if _, ok := app.(sdk.CrossChainTimeoutApplication); !ok {
+		return fmt.Errorf("the app of channel %d does not handle the timeouts", id)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("timeout should be positive")
+	}
+	if _, ok := k.cfg.channelIDToTimeout[id]; ok {
+		return fmt.Errorf("duplicated channel timeout")
+	}
+	k.cfg.channelIDToTimeout[id] = timeout
+	return nil
+}
+
+// GetChannelTimeout returns the timeout of the channel, zero if the acks of its
+// syn packages are not tracked.
+func (k *Keeper) GetChannelTimeout(id sdk.ChannelID) time.Duration {
+	return k.cfg.channelIDToTimeout[id]
+}
+
 // internally, we use name as the id of the chain, must be unique
 func (k *Keeper) RegisterDestChain(name string, chainID sdk.ChainID) error {
 	if strings.Contains(name, separator) {
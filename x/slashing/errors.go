@@ -31,6 +31,24 @@ const (
 	CodeDuplicateMaliciousVoteClaim CodeType = 207
 )
 
+// The registered errors of the codes of the slashing codespace.
+var (
+	ErrCodeInvalidInput                 = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeInvalidValidator             = sdk.Register(DefaultCodespace, CodeInvalidValidator, "invalid validator")
+	ErrCodeValidatorJailed              = sdk.Register(DefaultCodespace, CodeValidatorJailed, "validator jailed")
+	ErrCodeValidatorNotJailed           = sdk.Register(DefaultCodespace, CodeValidatorNotJailed, "validator not jailed")
+	ErrCodeMissingSelfDelegation        = sdk.Register(DefaultCodespace, CodeMissingSelfDelegation, "missing self delegation")
+	ErrCodeSelfDelegationTooLowToUnjail = sdk.Register(DefaultCodespace, CodeSelfDelegationTooLowToUnjail, "self delegation too low to unjail")
+	ErrCodeInvalidClaim                 = sdk.Register(DefaultCodespace, CodeInvalidClaim, "invalid claim")
+	ErrCodeExpiredEvidence              = sdk.Register(DefaultCodespace, CodeExpiredEvidence, "expired evidence")
+	ErrCodeFailSlash                    = sdk.Register(DefaultCodespace, CodeFailSlash, "slash failed")
+	ErrCodeHandledEvidence              = sdk.Register(DefaultCodespace, CodeHandledEvidence, "evidence already handled")
+	ErrCodeInvalidEvidence              = sdk.Register(DefaultCodespace, CodeInvalidEvidence, "invalid evidence")
+	ErrCodeInvalidSideChain             = sdk.Register(DefaultCodespace, CodeInvalidSideChain, "invalid side chain")
+	ErrCodeDuplicateDowntimeClaim       = sdk.Register(DefaultCodespace, CodeDuplicateDowntimeClaim, "duplicate downtime claim")
+	ErrCodeDuplicateMaliciousVoteClaim  = sdk.Register(DefaultCodespace, CodeDuplicateMaliciousVoteClaim, "duplicate malicious vote claim")
+)
+
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "that address is not associated with any known validator")
 }
@@ -0,0 +1,135 @@
+package slashing
+
+import (
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EvidenceHandler slashes the validator of an evidence of infraction reported
+// by Tendermint, the evidence is already validated and penalty is the penalty
+// of its type.
+type EvidenceHandler func(ctx sdk.Context, k Keeper, evidence abci.Evidence, penalty EvidencePenalty)
+
+// EvidencePenalty is the penalty of the validators committing the infractions
+// of an evidence type. The penalties are params of the slashing subspace, the
+// evidence types without a penalty are penalized as double signs.
+type EvidencePenalty struct {
+	Type          string        `json:"type"`
+	SlashFraction sdk.Dec       `json:"slash_fraction"`
+	JailDuration  time.Duration `json:"jail_duration"`
+}
+
+func (p EvidencePenalty) Validate() error {
+	if len(p.Type) == 0 {
+		return fmt.Errorf("the evidence type of a penalty cannot be empty")
+	}
+	if p.SlashFraction.LT(sdk.ZeroDec()) || p.SlashFraction.GT(sdk.OneDec()) {
+		return fmt.Errorf("the slash_fraction of %s should be in range 0 to 1", p.Type)
+	}
+	if p.JailDuration < 0 {
+		return fmt.Errorf("the jail_duration of %s cannot be negative", p.Type)
+	}
+	return nil
+}
+
+// RegisterEvidenceHandler registers the handler of the evidence type, the
+// evidences of the types without a handler are ignored.
+func (k Keeper) RegisterEvidenceHandler(evidenceType string, handler EvidenceHandler) {
+	if _, ok := k.evidenceHandlers[evidenceType]; ok {
+		panic(fmt.Sprintf("evidence handler of %s already registered", evidenceType))
+	}
+	k.evidenceHandlers[evidenceType] = handler
+}
+
+// GetEvidencePenalties returns the penalties set for the evidence types.
+func (k Keeper) GetEvidencePenalties(ctx sdk.Context) (penalties []EvidencePenalty) {
+	k.paramspace.GetIfExists(ctx, KeyEvidencePenalties, &penalties)
+	return
+}
+
+// SetEvidencePenalties sets the penalties of the evidence types.
+func (k Keeper) SetEvidencePenalties(ctx sdk.Context, penalties []EvidencePenalty) error {
+	seen := make(map[string]bool, len(penalties))
+	for _, penalty := range penalties {
+		if err := penalty.Validate(); err != nil {
+			return err
+		}
+		if seen[penalty.Type] {
+			return fmt.Errorf("duplicate penalty of evidence type %s", penalty.Type)
+		}
+		seen[penalty.Type] = true
+	}
+	k.paramspace.Set(ctx, KeyEvidencePenalties, &penalties)
+	return nil
+}
+
+// EvidencePenalty returns the penalty of the evidence type.
+func (k Keeper) EvidencePenalty(ctx sdk.Context, evidenceType string) EvidencePenalty {
+	for _, penalty := range k.GetEvidencePenalties(ctx) {
+		if penalty.Type == evidenceType && penalty.Validate() == nil {
+			return penalty
+		}
+	}
+	return EvidencePenalty{
+		Type:          evidenceType,
+		SlashFraction: k.SlashFractionDoubleSign(ctx),
+		JailDuration:  k.DoubleSignUnbondDuration(ctx),
+	}
+}
+
+// validateEvidence checks the height and the power of the evidence, and its
+// age against both the max age of the evidence consensus params, in blocks,
+// and the MaxEvidenceAge param.
+func (k Keeper) validateEvidence(ctx sdk.Context, evidence abci.Evidence) error {
+	if evidence.Height <= 0 || evidence.Height > ctx.BlockHeight() {
+		return fmt.Errorf("invalid height %d, current height %d", evidence.Height, ctx.BlockHeight())
+	}
+	if evidence.Validator.Power <= 0 ||
+		(evidence.TotalVotingPower > 0 && evidence.Validator.Power > evidence.TotalVotingPower) {
+		return fmt.Errorf("invalid power %d of total voting power %d", evidence.Validator.Power, evidence.TotalVotingPower)
+	}
+
+	if params := ctx.ConsensusParams(); params != nil && params.Evidence != nil && params.Evidence.MaxAge > 0 {
+		if blocks := ctx.BlockHeight() - evidence.Height; blocks > params.Evidence.MaxAge {
+			return fmt.Errorf("age of %d blocks past max age of %d blocks", blocks, params.Evidence.MaxAge)
+		}
+	}
+	age := ctx.BlockHeader().Time.Sub(evidence.Time)
+	if maxEvidenceAge := k.MaxEvidenceAge(ctx); age > maxEvidenceAge {
+		return fmt.Errorf("age of %d past max age of %d", age, maxEvidenceAge)
+	}
+	return nil
+}
+
+// handleEvidence validates the evidence and slashes its validator by the
+// penalty of its type.
+func (k Keeper) handleEvidence(ctx sdk.Context, evidence abci.Evidence) {
+	logger := ctx.Logger().With("module", "slashing")
+	handler, ok := k.evidenceHandlers[evidence.Type]
+	if !ok {
+		logger.Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
+		return
+	}
+	if err := k.validateEvidence(ctx, evidence); err != nil {
+		logger.Info(fmt.Sprintf("Ignored %s evidence from %s at height %d: %s",
+			evidence.Type, sdk.ConsAddress(evidence.Validator.Address), evidence.Height, err.Error()))
+		return
+	}
+
+	handler(ctx, k, evidence, k.EvidencePenalty(ctx, evidence.Type))
+}
+
+// handleDuplicateVote slashes a validator signing two blocks at the same height.
+func handleDuplicateVote(ctx sdk.Context, k Keeper, evidence abci.Evidence, penalty EvidencePenalty) {
+	consAddr := sdk.ConsAddress(evidence.Validator.Address)
+	if _, err := k.getPubkey(ctx, evidence.Validator.Address); err != nil {
+		panic(fmt.Sprintf("Validator consensus-address %v not found", consAddr))
+	}
+
+	ctx.Logger().With("module", "slashing").Info(fmt.Sprintf("Confirmed double sign from %s at height %d", consAddr, evidence.Height))
+	k.slashInfraction(ctx, consAddr, evidence.Height, evidence.Validator.Power, penalty.SlashFraction, penalty.JailDuration)
+}
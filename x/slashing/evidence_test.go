@@ -0,0 +1,94 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestSetEvidencePenalties(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t, keeperTestParams())
+
+	// the evidence types without a penalty are penalized as double signs
+	penalty := keeper.EvidencePenalty(ctx, tmtypes.ABCIEvidenceTypeDuplicateVote)
+	require.Equal(t, keeper.SlashFractionDoubleSign(ctx), penalty.SlashFraction)
+	require.Equal(t, keeper.DoubleSignUnbondDuration(ctx), penalty.JailDuration)
+
+	duplicateVote := EvidencePenalty{tmtypes.ABCIEvidenceTypeDuplicateVote, sdk.NewDecWithPrec(5, 1), time.Hour}
+	require.Error(t, keeper.SetEvidencePenalties(ctx, []EvidencePenalty{{"", sdk.OneDec(), time.Hour}}))
+	require.Error(t, keeper.SetEvidencePenalties(ctx, []EvidencePenalty{{"light/client", sdk.NewDecWithoutFra(2), time.Hour}}))
+	require.Error(t, keeper.SetEvidencePenalties(ctx, []EvidencePenalty{duplicateVote, duplicateVote}))
+
+	require.NoError(t, keeper.SetEvidencePenalties(ctx, []EvidencePenalty{duplicateVote}))
+	require.Equal(t, duplicateVote, keeper.EvidencePenalty(ctx, tmtypes.ABCIEvidenceTypeDuplicateVote))
+
+	require.Panics(t, func() {
+		keeper.RegisterEvidenceHandler(tmtypes.ABCIEvidenceTypeDuplicateVote, handleDuplicateVote)
+	})
+}
+
+func TestHandleEvidence(t *testing.T) {
+	ctx, ck, sk, _, keeper := createTestInput(t, keeperTestParams())
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SlashingEvidenceParams, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	// validator added pre-genesis
+	ctx = ctx.WithBlockHeight(-1)
+	amtInt := sdk.NewDecWithoutFra(100).RawInt()
+	operatorAddr, val := addrs[0], pks[0]
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(operatorAddr, val, amtInt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+	require.Equal(t, ck.GetCoins(ctx, sdk.AccAddress(operatorAddr)), sdk.Coins{{sk.GetParams(ctx).BondDenom, initCoins - amtInt}})
+	keeper.handleValidatorSignature(ctx, val.Address(), amtInt, true)
+
+	require.NoError(t, keeper.SetEvidencePenalties(ctx, []EvidencePenalty{
+		{tmtypes.ABCIEvidenceTypeDuplicateVote, sdk.NewDecWithPrec(5, 1), time.Hour},
+	}))
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 20, Time: time.Unix(100, 0)}).WithBlockHeight(20).
+		WithConsensusParams(&abci.ConsensusParams{Evidence: &abci.EvidenceParams{MaxAge: 10}})
+	sdk.UpgradeMgr.SetHeight(20)
+	evidence := abci.Evidence{
+		Type:             tmtypes.ABCIEvidenceTypeDuplicateVote,
+		Validator:        abci.Validator{Address: val.Address(), Power: amtInt},
+		Height:           5,
+		Time:             time.Unix(90, 0),
+		TotalVotingPower: amtInt,
+	}
+	beginBlock := func(evidences ...abci.Evidence) {
+		BeginBlocker(ctx, abci.RequestBeginBlock{Header: ctx.BlockHeader(), ByzantineValidators: evidences}, keeper)
+	}
+
+	// evidence past the max age of the consensus params
+	beginBlock(evidence)
+	require.False(t, sk.Validator(ctx, operatorAddr).GetJailed())
+
+	// evidence of an unknown type
+	evidence.Height = 15
+	unknown := evidence
+	unknown.Type = "light/client"
+	beginBlock(unknown)
+	require.False(t, sk.Validator(ctx, operatorAddr).GetJailed())
+
+	// evidence with an invalid power
+	invalidPower := evidence
+	invalidPower.Validator.Power = 2 * amtInt
+	beginBlock(invalidPower)
+	require.False(t, sk.Validator(ctx, operatorAddr).GetJailed())
+
+	// slashed by the penalty of the duplicate votes
+	beginBlock(evidence)
+	require.True(t, sk.Validator(ctx, operatorAddr).GetJailed())
+	signInfo, found := keeper.getValidatorSigningInfo(ctx, sdk.ConsAddress(val.Address()))
+	require.True(t, found)
+	require.True(t, time.Unix(100, 0).Add(time.Hour).Equal(signInfo.JailedUntil))
+	sk.Unjail(ctx, sdk.ConsAddress(val.Address()))
+	require.Equal(t, sdk.NewDecFromInt(amtInt).Quo(sdk.NewDecWithoutFra(2)), sk.Validator(ctx, operatorAddr).GetPower())
+}
@@ -1,13 +1,42 @@
 package slashing
 
 import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
 // GenesisState - all slashing state that must be provided at genesis
 type GenesisState struct {
-	Params Params
+	Params                   Params                    `json:"params"`
+	EvidencePenalties        []EvidencePenalty         `json:"evidence_penalties"`
+	DowntimeAutoUnjailWindow time.Duration             `json:"downtime_auto_unjail_window"`
+	SigningInfos             []SigningInfo             `json:"signing_infos"`
+	MissedBlocks             []MissedBlocks            `json:"missed_blocks"`
+	SlashingPeriods          []ValidatorSlashingPeriod `json:"slashing_periods"`
+	SlashRecords             []SlashRecord             `json:"slash_records"`
+	DowntimeJailed           []sdk.ConsAddress         `json:"downtime_jailed"`
+}
+
+// SigningInfo - the signing info of a validator
+type SigningInfo struct {
+	Address sdk.ConsAddress      `json:"address"`
+	Info    ValidatorSigningInfo `json:"info"`
+}
+
+// MissedBlocks - the missed block bit array of a validator
+type MissedBlocks struct {
+	Address sdk.ConsAddress `json:"address"`
+	Blocks  []MissedBlock   `json:"blocks"`
+}
+
+// MissedBlock - an entry of the missed block bit array of a validator
+type MissedBlock struct {
+	Index  int64 `json:"index"`
+	Missed bool  `json:"missed"`
 }
 
 // HubDefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -17,12 +46,165 @@ func DefaultGenesisState() GenesisState {
 	}
 }
 
-// InitGenesis initialize default parameters
-// and the keeper's address to pubkey map
+// InitGenesis initialize default parameters, the keeper's address to pubkey
+// map and the validator records of an exported state
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState, sdata types.GenesisState) {
 	for _, validator := range sdata.Validators {
 		keeper.addPubkey(ctx, validator.GetConsPubKey())
 	}
 
 	keeper.paramspace.SetParamSet(ctx, &data.Params)
+	if len(data.EvidencePenalties) > 0 {
+		if err := keeper.SetEvidencePenalties(ctx, data.EvidencePenalties); err != nil {
+			panic(err)
+		}
+	}
+	if data.DowntimeAutoUnjailWindow > 0 {
+		keeper.SetDowntimeAutoUnjailWindow(ctx, data.DowntimeAutoUnjailWindow)
+	}
+
+	for _, info := range data.SigningInfos {
+		keeper.setValidatorSigningInfo(ctx, info.Address, info.Info)
+	}
+	for _, array := range data.MissedBlocks {
+		for _, missed := range array.Blocks {
+			keeper.setValidatorMissedBlockBitArray(ctx, array.Address, missed.Index, missed.Missed)
+		}
+	}
+	for _, slashingPeriod := range data.SlashingPeriods {
+		keeper.addOrUpdateValidatorSlashingPeriod(ctx, slashingPeriod)
+	}
+	for _, record := range data.SlashRecords {
+		keeper.setSlashRecord(ctx, record)
+	}
+	for _, consAddr := range data.DowntimeJailed {
+		keeper.setDowntimeJailed(ctx, consAddr)
+	}
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper. The
+// GenesisState will contain the params, signing infos, missed blocks, slashing
+// periods, slash records and validators jailed for downtime found in the keeper.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var params Params
+	keeper.paramspace.GetParamSet(ctx, &params)
+	data := GenesisState{
+		Params:                   params,
+		EvidencePenalties:        keeper.GetEvidencePenalties(ctx),
+		DowntimeAutoUnjailWindow: keeper.DowntimeAutoUnjailWindow(ctx),
+	}
+	store := ctx.KVStore(keeper.storeKey)
+
+	iter := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	for ; iter.Valid(); iter.Next() {
+		var info ValidatorSigningInfo
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &info)
+		address := sdk.ConsAddress(iter.Key()[len(ValidatorSigningInfoKey):])
+		data.SigningInfos = append(data.SigningInfos, SigningInfo{address, info})
+	}
+	iter.Close()
+
+	iter = sdk.KVStorePrefixIterator(store, ValidatorMissedBlockBitArrayKey)
+	for ; iter.Valid(); iter.Next() {
+		var missed bool
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &missed)
+		key := iter.Key()
+		address := sdk.ConsAddress(key[len(ValidatorMissedBlockBitArrayKey) : len(key)-8])
+		block := MissedBlock{int64(binary.LittleEndian.Uint64(key[len(key)-8:])), missed}
+		if n := len(data.MissedBlocks); n > 0 && data.MissedBlocks[n-1].Address.Equals(address) {
+			data.MissedBlocks[n-1].Blocks = append(data.MissedBlocks[n-1].Blocks, block)
+		} else {
+			data.MissedBlocks = append(data.MissedBlocks, MissedBlocks{address, []MissedBlock{block}})
+		}
+	}
+	iter.Close()
+
+	iter = sdk.KVStorePrefixIterator(store, ValidatorSlashingPeriodKey)
+	for ; iter.Valid(); iter.Next() {
+		data.SlashingPeriods = append(data.SlashingPeriods, keeper.unmarshalSlashingPeriodKeyValue(iter.Key(), iter.Value()))
+	}
+	iter.Close()
+
+	iter = sdk.KVStorePrefixIterator(store, SlashRecordKey)
+	for ; iter.Valid(); iter.Next() {
+		data.SlashRecords = append(data.SlashRecords, MustUnmarshalSlashRecord(keeper.cdc, iter.Key(), iter.Value()))
+	}
+	iter.Close()
+
+	iter = sdk.KVStorePrefixIterator(store, DowntimeJailedKey)
+	for ; iter.Valid(); iter.Next() {
+		data.DowntimeJailed = append(data.DowntimeJailed, sdk.ConsAddress(iter.Key()[len(DowntimeJailedKey):]))
+	}
+	iter.Close()
+
+	return data
+}
+
+// ValidateGenesis validates the provided slashing genesis state to ensure the
+// expected invariants holds. (i.e. params in correct bounds, no duplicate
+// signing infos)
+func ValidateGenesis(data GenesisState) error {
+	err := validateParams(data.Params)
+	if err != nil {
+		return err
+	}
+	penaltyTypes := make(map[string]bool, len(data.EvidencePenalties))
+	for i, penalty := range data.EvidencePenalties {
+		if err := penalty.Validate(); err != nil {
+			return fmt.Errorf("evidence_penalties[%d]: %v", i, err)
+		}
+		if penaltyTypes[penalty.Type] {
+			return fmt.Errorf("evidence_penalties[%d]: duplicate penalty of %s", i, penalty.Type)
+		}
+		penaltyTypes[penalty.Type] = true
+	}
+	if data.DowntimeAutoUnjailWindow < 0 {
+		return fmt.Errorf("slashing parameter DowntimeAutoUnjailWindow cannot be negative, is %v", data.DowntimeAutoUnjailWindow)
+	}
+
+	signingInfos := make(map[string]bool, len(data.SigningInfos))
+	for i, info := range data.SigningInfos {
+		if signingInfos[string(info.Address)] {
+			return fmt.Errorf("signing_infos[%d]: duplicate signing info of %v", i, info.Address)
+		}
+		if info.Info.MissedBlocksCounter < 0 || info.Info.IndexOffset < 0 {
+			return fmt.Errorf("signing_infos[%d]: the missed blocks counter and the index offset of %v cannot be negative", i, info.Address)
+		}
+		signingInfos[string(info.Address)] = true
+	}
+	for i, array := range data.MissedBlocks {
+		for j, missed := range array.Blocks {
+			if missed.Index < 0 {
+				return fmt.Errorf("missed_blocks[%d].blocks[%d]: negative index %d of %v", i, j, missed.Index, array.Address)
+			}
+		}
+	}
+	return nil
+}
+
+func validateParams(params Params) error {
+	if params.SignedBlocksWindow < 1 {
+		return fmt.Errorf("slashing parameter SignedBlocksWindow must be positive, is %d", params.SignedBlocksWindow)
+	}
+	for _, fraction := range []struct {
+		name  string
+		value sdk.Dec
+	}{
+		{"MinSignedPerWindow", params.MinSignedPerWindow},
+		{"SlashFractionDoubleSign", params.SlashFractionDoubleSign},
+		{"SlashFractionDowntime", params.SlashFractionDowntime},
+	} {
+		if fraction.value.LT(sdk.ZeroDec()) || fraction.value.GT(sdk.OneDec()) {
+			return fmt.Errorf("slashing parameter %s must be in range 0 to 1, is %v", fraction.name, fraction.value)
+		}
+	}
+	if params.MaxEvidenceAge < 0 || params.DoubleSignUnbondDuration < 0 ||
+		params.DowntimeUnbondDuration < 0 || params.TooLowDelUnbondDuration < 0 {
+		return fmt.Errorf("slashing parameters MaxEvidenceAge, DoubleSignUnbondDuration, DowntimeUnbondDuration and TooLowDelUnbondDuration cannot be negative")
+	}
+	if params.DoubleSignSlashAmount < 0 || params.DowntimeSlashAmount < 0 ||
+		params.SubmitterReward < 0 || params.DowntimeSlashFee < 0 {
+		return fmt.Errorf("slashing parameters DoubleSignSlashAmount, DowntimeSlashAmount, SubmitterReward and DowntimeSlashFee cannot be negative")
+	}
+	return nil
 }
@@ -36,6 +36,8 @@ type Keeper struct {
 	ScKeeper   *sidechain.Keeper
 
 	PbsbServer *pubsub.Server
+
+	evidenceHandlers map[string]EvidenceHandler
 }
 
 // NewKeeper creates a slashing keeper
@@ -47,7 +49,10 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, paramspa
 		paramspace:   paramspace.WithTypeTable(ParamTypeTable()),
 		Codespace:    codespace,
 		BankKeeper:   bk,
+
+		evidenceHandlers: make(map[string]EvidenceHandler),
 	}
+	keeper.RegisterEvidenceHandler(tmtypes.ABCIEvidenceTypeDuplicateVote, handleDuplicateVote)
 	return keeper
 }
 
@@ -70,7 +75,7 @@ func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 // handle a validator signing two blocks at the same height
 // power: power of the double-signing validator at the height of infraction
 func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractionHeight int64, timestamp time.Time, power int64) {
-	logger := ctx.Logger().With("module", "x/slashing")
+	logger := ctx.Logger().With("module", "slashing")
 	time := ctx.BlockHeader().Time
 	age := time.Sub(timestamp)
 	consAddr := sdk.ConsAddress(addr)
@@ -90,6 +95,15 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 
 	logger.Info(fmt.Sprintf("Confirmed double sign from %s at height %d, age of %d less than max age of %d", pubkey.Address(), infractionHeight, age, maxEvidenceAge))
 
+	k.slashInfraction(ctx, consAddr, infractionHeight, power, k.SlashFractionDoubleSign(ctx), k.DoubleSignUnbondDuration(ctx))
+}
+
+// slash the validator of an infraction by fraction, capped by the slashing
+// period, and jail it for jailDuration
+// power: power of the validator at the height of infraction
+func (k Keeper) slashInfraction(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeight int64, power int64, fraction sdk.Dec, jailDuration time.Duration) {
+	logger := ctx.Logger().With("module", "slashing")
+
 	// We need to retrieve the stake distribution which signed the block, so we subtract ValidatorUpdateDelay from the evidence height.
 	// Note that this *can* result in a negative "distributionHeight", up to -ValidatorUpdateDelay,
 	// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
@@ -98,7 +112,6 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 
 	// Cap the amount slashed to the penalty for the worst infraction
 	// within the slashing period when this infraction was committed
-	fraction := k.SlashFractionDoubleSign(ctx)
 	revisedFraction := k.capBySlashingPeriod(ctx, consAddr, fraction, distributionHeight)
 	logger.Info(fmt.Sprintf("Fraction slashed capped by slashing period from %v to %v", fraction, revisedFraction))
 
@@ -121,14 +134,17 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	if !found {
 		panic(fmt.Sprintf("Expected signing info for validator %s but not found", consAddr))
 	}
-	signInfo.JailedUntil = time.Add(k.DoubleSignUnbondDuration(ctx))
+	signInfo.JailedUntil = ctx.BlockHeader().Time.Add(jailDuration)
 	k.setValidatorSigningInfo(ctx, consAddr, signInfo)
+
+	// Validators jailed for an infraction are not unjailed automatically
+	k.deleteDowntimeJailed(ctx, consAddr)
 }
 
 // handle a validator signature, must be called once per validator per block
 // TODO refactor to take in a consensus address, additionally should maybe just take in the pubkey too
 func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, power int64, signed bool) {
-	logger := ctx.Logger().With("module", "x/slashing")
+	logger := ctx.Logger().With("module", "slashing")
 	height := ctx.BlockHeight()
 	consAddr := sdk.ConsAddress(addr)
 	pubkey, err := k.getPubkey(ctx, addr)
@@ -182,6 +198,9 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
 			k.validatorSet.Jail(ctx, consAddr)
 			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeUnbondDuration(ctx))
+			if sdk.IsUpgrade(sdk.DowntimeAutoUnjail) && k.DowntimeAutoUnjailWindow(ctx) > 0 {
+				k.setDowntimeJailed(ctx, consAddr)
+			}
 			// We need to reset the counter & array so that the validator won't be immediately slashed for downtime upon rebonding.
 			signInfo.MissedBlocksCounter = 0
 			signInfo.IndexOffset = 0
@@ -389,7 +408,7 @@ func (k *Keeper) slashingSideDowntime(ctx sdk.Context, pack *SideSlashPackage) s
 }
 
 func (k *Keeper) slashingSideMaliciousVote(ctx sdk.Context, pack *SideSlashPackage) sdk.Error {
-	logger := ctx.Logger().With("module", "x/slashing")
+	logger := ctx.Logger().With("module", "slashing")
 	sideVoteAddr := pack.SideAddr
 	sideChainName, err := k.ScKeeper.GetDestChainName(pack.SideChainId)
 	if err != nil {
@@ -14,6 +14,7 @@ var (
 	ValidatorSlashingPeriodKey      = []byte{0x03} // Prefix for slashing period
 	AddrPubkeyRelationKey           = []byte{0x04} // Prefix for address-pubkey relation
 	SlashRecordKey                  = []byte{0x05} // Prefix for slash record
+	DowntimeJailedKey               = []byte{0x06} // Prefix for the validators jailed for downtime, to unjail automatically
 )
 
 // stored by *Tendermint* address (not operator address)
@@ -46,6 +47,11 @@ func GetValidatorSlashingPeriodKey(v sdk.ConsAddress, startHeight int64) []byte
 	return append(GetValidatorSlashingPeriodPrefix(v), b...)
 }
 
+// stored by *Tendermint* address (not operator address)
+func GetDowntimeJailedKey(v sdk.ConsAddress) []byte {
+	return append(DowntimeJailedKey, v.Bytes()...)
+}
+
 func getAddrPubkeyRelationKey(address []byte) []byte {
 	return append(AddrPubkeyRelationKey, address...)
 }
@@ -27,11 +27,15 @@ var (
 	KeyDowntimeSlashAmount      = []byte("DowntimeSlashAmount")
 	KeySubmitterReward          = []byte("SubmitterReward")
 	KeyDowntimeSlashFee         = []byte("DowntimeSlashFee")
+	KeyEvidencePenalties        = []byte("EvidencePenalties")
+	KeyDowntimeAutoUnjailWindow = []byte("DowntimeAutoUnjailWindow")
 )
 
 // ParamTypeTable for slashing module
 func ParamTypeTable() params.TypeTable {
-	return params.NewTypeTable().RegisterParamSet(&Params{})
+	return params.NewTypeTable().RegisterParamSet(&Params{}).
+		RegisterType(KeyEvidencePenalties, []EvidencePenalty{}).
+		RegisterType(KeyDowntimeAutoUnjailWindow, time.Duration(0))
 }
 
 // Params - used for initializing default parameter for slashing at genesis
@@ -55,7 +59,15 @@ func (p *Params) GetParamAttribute() (string, bool) {
 }
 
 func (p *Params) UpdateCheck() error {
-	// no check for SignedBlocksWindow, MinSignedPerWindow, SlashFractionDoubleSign, SlashFractionDowntime
+	// no check for SlashFractionDoubleSign, SlashFractionDowntime
+	if sdk.IsUpgrade(sdk.DowntimeAutoUnjail) {
+		if p.SignedBlocksWindow < 1 {
+			return fmt.Errorf("the signed_blocks_window should be positive")
+		}
+		if p.MinSignedPerWindow.LT(sdk.ZeroDec()) || p.MinSignedPerWindow.GT(sdk.OneDec()) {
+			return fmt.Errorf("the min_signed_per_window should be in range 0 to 1")
+		}
+	}
 	if p.MaxEvidenceAge < 1*time.Minute || p.MaxEvidenceAge > 100*24*time.Hour {
 		return fmt.Errorf("the max_evidence_age should be in range 1 minutes to 100 day")
 	}
@@ -206,6 +218,18 @@ func (k Keeper) DowntimeSlashFee(ctx sdk.Context) (downtimeSlashFee int64) {
 	return
 }
 
+// DowntimeAutoUnjailWindow - grace window, after the end of their jail
+// duration, during which the validators jailed for downtime are unjailed
+// automatically; zero, the default, disables the automatic unjail
+func (k Keeper) DowntimeAutoUnjailWindow(ctx sdk.Context) (res time.Duration) {
+	k.paramspace.GetIfExists(ctx, KeyDowntimeAutoUnjailWindow, &res)
+	return
+}
+
+func (k Keeper) SetDowntimeAutoUnjailWindow(ctx sdk.Context, window time.Duration) {
+	k.paramspace.Set(ctx, KeyDowntimeAutoUnjailWindow, &window)
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 	k.paramspace.SetParamSet(ctx, &params)
@@ -28,13 +28,21 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 	// Slash any validators (and since-unbonded stake within the unbonding period)
 	// who contributed to valid infractions
 	for _, evidence := range req.ByzantineValidators {
+		if sdk.IsUpgrade(sdk.SlashingEvidenceParams) {
+			sk.handleEvidence(ctx, evidence)
+			continue
+		}
 		switch evidence.Type {
 		case tmtypes.ABCIEvidenceTypeDuplicateVote:
 			sk.handleDoubleSign(ctx, evidence.Validator.Address, evidence.Height, evidence.Time, evidence.Validator.Power)
 		default:
-			ctx.Logger().With("module", "x/slashing").Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
+			ctx.Logger().With("module", "slashing").Error(fmt.Sprintf("ignored unknown evidence type: %s", evidence.Type))
 		}
 	}
 
+	if sdk.IsUpgrade(sdk.DowntimeAutoUnjail) {
+		sk.autoUnjailDowntimeValidators(ctx)
+	}
+
 	return
 }
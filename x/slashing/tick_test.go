@@ -85,3 +85,57 @@ func TestBeginBlocker(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, sdk.Unbonding, validator.GetStatus())
 }
+
+func TestBeginBlockerAutoUnjail(t *testing.T) {
+	sdk.UpgradeMgr.Reset()
+	ctx, _, sk, _, keeper := createTestInput(t, DefaultParams())
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.DowntimeAutoUnjail, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	keeper.SetDowntimeAutoUnjailWindow(ctx, time.Hour)
+	addr, pk, amt := addrs[2], pks[2], sdk.NewDecWithoutFra(100).RawInt()
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	// bond the validator
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(addr, pk, amt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+
+	beginBlock := func(height int64, signed bool) {
+		ctx = ctx.WithBlockHeight(height)
+		req := abci.RequestBeginBlock{
+			Header: ctx.BlockHeader(),
+			LastCommitInfo: abci.LastCommitInfo{
+				Votes: []abci.VoteInfo{{
+					Validator:       abci.Validator{Address: pk.Address(), Power: amt},
+					SignedLastBlock: signed,
+				}},
+			},
+		}
+		BeginBlocker(ctx, req, keeper)
+	}
+
+	// sign a window then miss blocks until jailed for downtime
+	height := int64(0)
+	for ; height < keeper.SignedBlocksWindow(ctx); height++ {
+		beginBlock(height, true)
+	}
+	for ; !sk.Validator(ctx, addr).GetJailed(); height++ {
+		beginBlock(height, false)
+	}
+	stake.EndBlocker(ctx, sk)
+	info, found := keeper.getValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+
+	// still jailed before the end of the jail duration
+	ctx = ctx.WithBlockTime(info.JailedUntil.Add(-time.Second))
+	beginBlock(height, false)
+	require.True(t, sk.Validator(ctx, addr).GetJailed())
+
+	// unjailed automatically once the jail duration is over
+	ctx = ctx.WithBlockTime(info.JailedUntil)
+	beginBlock(height+1, true)
+	require.False(t, sk.Validator(ctx, addr).GetJailed())
+	require.False(t, ctx.KVStore(keeper.storeKey).Has(GetDowntimeJailedKey(consAddr)))
+}
@@ -1,6 +1,8 @@
 package slashing
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -51,3 +53,48 @@ func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) sdk.Error
 
 	return nil
 }
+
+func (k Keeper) setDowntimeJailed(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	ctx.KVStore(k.storeKey).Set(GetDowntimeJailedKey(consAddr), []byte{})
+}
+
+func (k Keeper) deleteDowntimeJailed(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	ctx.KVStore(k.storeKey).Delete(GetDowntimeJailedKey(consAddr))
+}
+
+// autoUnjailDowntimeValidators unjails the validators jailed for downtime once
+// their jail duration is over, as a MsgUnjail would. The validators which
+// cannot be unjailed within the DowntimeAutoUnjailWindow, e.g. with a too low
+// self-delegation, must send a MsgUnjail.
+func (k Keeper) autoUnjailDowntimeValidators(ctx sdk.Context) {
+	logger := ctx.Logger().With("module", "slashing")
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, DowntimeJailedKey)
+	var jailed []sdk.ConsAddress
+	for ; iter.Valid(); iter.Next() {
+		jailed = append(jailed, sdk.ConsAddress(iter.Key()[len(DowntimeJailedKey):]))
+	}
+	iter.Close()
+
+	now := ctx.BlockHeader().Time
+	window := k.DowntimeAutoUnjailWindow(ctx)
+	for _, consAddr := range jailed {
+		info, found := k.getValidatorSigningInfo(ctx, consAddr)
+		if found && now.Before(info.JailedUntil) {
+			continue
+		}
+		validator := k.validatorSet.ValidatorByConsAddr(ctx, consAddr)
+		if !found || validator == nil || !validator.GetJailed() {
+			k.deleteDowntimeJailed(ctx, consAddr)
+			continue
+		}
+
+		if err := k.Unjail(ctx, validator.GetOperator()); err == nil {
+			logger.Info(fmt.Sprintf("Unjailed validator %s automatically after downtime", consAddr))
+			k.deleteDowntimeJailed(ctx, consAddr)
+		} else if now.After(info.JailedUntil.Add(window)) {
+			logger.Info(fmt.Sprintf("Validator %s could not be unjailed automatically within %v: %s", consAddr, window, err.Error()))
+			k.deleteDowntimeJailed(ctx, consAddr)
+		}
+	}
+}
@@ -63,7 +63,7 @@ var (
 
 func init() {
 	fsPk.String(FlagPubKey, "", "Go-Amino encoded hex PubKey of the validator. For Ed25519 the go-amino prepend hex is 1624de6220")
-	fsAmount.String(FlagAmount, "", "Amount of coins to bond")
+	fsAmount.String(FlagAmount, "", "Amount of coins to bond, e.g. \"1.5 BNB\" or \"150000000:BNB\"")
 	fsShares.String(FlagSharesAmount, "", "Amount of source-shares to either unbond or redelegate as a positive integer or decimal")
 	fsShares.String(FlagSharesPercent, "", "Percent of source-shares to either unbond or redelegate as a positive integer or decimal >0 and <=1")
 	fsDescriptionCreate.String(FlagMoniker, "", "Validator name")
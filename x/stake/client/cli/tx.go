@@ -100,7 +100,7 @@ func GetCmdCreateValidator(cdc *codec.Codec) *cobra.Command {
 				if depositStr == "" {
 					return fmt.Errorf("must specify deposit amount when proposalId is zero using --deposit")
 				}
-				deposit, err := sdk.ParseCoin(depositStr)
+				deposit, err := sdk.ParseUserCoin(depositStr)
 				if err != nil {
 					return err
 				}
@@ -190,7 +190,7 @@ func GetCmdRemoveValidator(cdc *codec.Codec) *cobra.Command {
 				if depositStr == "" {
 					return fmt.Errorf("must specify deposit amount when proposalId is zero using --deposit")
 				}
-				deposit, err := sdk.ParseCoin(depositStr)
+				deposit, err := sdk.ParseUserCoin(depositStr)
 				if err != nil {
 					return err
 				}
@@ -470,7 +470,7 @@ func getAmount() (sdk.Coin, error) {
 	if amountStr == "" {
 		return sdk.Coin{}, fmt.Errorf("%s is required", FlagAmount)
 	}
-	amount, err := sdk.ParseCoin(amountStr)
+	amount, err := sdk.ParseUserCoin(amountStr)
 	if err != nil {
 		return sdk.Coin{}, err
 	}
@@ -30,7 +30,7 @@ func GetCmdCreateSideChainValidator(cdc *codec.Codec) *cobra.Command {
 		if amountStr == "" {
 			return fmt.Errorf("Must specify amount to stake using --amount")
 		}
-		amount, err := sdk.ParseCoin(amountStr)
+		amount, err := sdk.ParseUserCoin(amountStr)
 		if err != nil {
 			return err
 		}
@@ -15,6 +15,8 @@ import (
 
 const storeName = "stake"
 
+// Every query but the txs one is scoped to the side chain of the side_chain_id
+// query parameter, the main chain by default.
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
 
 	// Get all delegations from a delegator
@@ -103,19 +105,21 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 
 }
 
-// HTTP request handler to query a delegator delegations
+// HTTP request handler to query a delegator delegations, paginated with the
+// offset and limit query parameters
 func delegatorDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorDelegations")
+	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorDelegations", true)
 }
 
-// HTTP request handler to query a delegator unbonding delegations
+// HTTP request handler to query a delegator unbonding delegations, paginated
+// with the offset and limit query parameters
 func delegatorUnbondingDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorUnbondingDelegations")
+	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorUnbondingDelegations", true)
 }
 
 // HTTP request handler to query a delegator redelegations
 func delegatorRedelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorRedelegations")
+	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorRedelegations", false)
 }
 
 // HTTP request handler to query all staking txs (msgs) from a delegator
@@ -198,7 +202,7 @@ func delegationHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Handl
 
 // HTTP request handler to query all delegator bonded validators
 func delegatorValidatorsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorValidators")
+	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorValidators", false)
 }
 
 // HTTP request handler to get information from a currently bonded validator
@@ -208,51 +212,31 @@ func delegatorValidatorHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) ht
 
 // HTTP request handler to query list of validators
 func validatorsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/validators", nil)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
-	}
+	return queryChain(cliCtx, cdc, "custom/stake/validators")
 }
 
 // HTTP request handler to query the validator information from a given validator address
 func validatorHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryValidator(cliCtx, cdc, "custom/stake/validator")
+	return queryValidator(cliCtx, cdc, "custom/stake/validator", false)
 }
 
-// HTTP request handler to query all unbonding delegations from a validator
+// HTTP request handler to query all unbonding delegations from a validator,
+// paginated with the offset and limit query parameters
 func validatorUnbondingDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryValidator(cliCtx, cdc, "custom/stake/validatorUnbondingDelegations")
+	return queryValidator(cliCtx, cdc, "custom/stake/validatorUnbondingDelegations", true)
 }
 
 // HTTP request handler to query all redelegations from a source validator
 func validatorRedelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return queryValidator(cliCtx, cdc, "custom/stake/validatorRedelegations")
+	return queryValidator(cliCtx, cdc, "custom/stake/validatorRedelegations", false)
 }
 
 // HTTP request handler to query the pool information
 func poolHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/pool", nil)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
-	}
+	return queryChain(cliCtx, cdc, "custom/stake/pool")
 }
 
 // HTTP request handler to query the staking params values
 func paramsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/parameters", nil)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
-	}
+	return queryChain(cliCtx, cdc, "custom/stake/parameters")
 }
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -16,6 +17,15 @@ import (
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 )
 
+// the query parameter of the side chain of the queries, the main chain if
+// empty
+const queryArgSideChainId = "side_chain_id"
+
+// baseParams returns the params of the side chain of the request.
+func baseParams(r *http.Request) stake.BaseParams {
+	return stake.NewBaseParams(r.URL.Query().Get(queryArgSideChainId))
+}
+
 // contains checks if the a given query contains one of the tx types
 func contains(stringSlice []string, txType string) bool {
 	for _, word := range stringSlice {
@@ -63,11 +73,13 @@ func queryBonds(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) ht
 		}
 
 		params := stake.QueryBondsParams{
+			BaseParams:    baseParams(r),
 			DelegatorAddr: delegatorAddr,
 			ValidatorAddr: validatorAddr,
 		}
 
-		bz, err := cdc.MarshalJSON(params)
+		// the querier decodes the params with encoding/json
+		bz, err := json.Marshal(params)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -82,7 +94,31 @@ func queryBonds(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) ht
 	}
 }
 
-func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) http.HandlerFunc {
+// queryChain queries the endpoint on the side chain of the request, if any.
+func queryChain(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var bz []byte
+		if params := baseParams(r); params.SideChainId != "" {
+			var err error
+			if bz, err = json.Marshal(params); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(endpoint, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// queryDelegator queries the endpoint with the delegator of the path. The
+// results are paginated with the offset and limit query parameters if
+// paginated is set.
+func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string, paginated bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		bech32delegator := vars["delegatorAddr"]
@@ -94,10 +130,18 @@ func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 		}
 
 		params := stake.QueryDelegatorParams{
+			BaseParams:    baseParams(r),
 			DelegatorAddr: delegatorAddr,
 		}
+		if paginated {
+			var ok bool
+			if params.Pagination, ok = utils.ParsePaginationOrReturnBadRequest(w, r); !ok {
+				return
+			}
+		}
 
-		bz, err := cdc.MarshalJSON(params)
+		// the querier decodes the params with encoding/json
+		bz, err := json.Marshal(params)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -108,11 +152,18 @@ func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		if params.IsPaginated() {
+			utils.WritePageResponse(w, cdc, res, cliCtx.Indent)
+			return
+		}
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
 
-func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) http.HandlerFunc {
+// queryValidator queries the endpoint with the validator of the path. The
+// results are paginated with the offset and limit query parameters if
+// paginated is set.
+func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string, paginated bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		bech32validatorAddr := vars["validatorAddr"]
@@ -124,10 +175,18 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 		}
 
 		params := stake.QueryValidatorParams{
+			BaseParams:    baseParams(r),
 			ValidatorAddr: validatorAddr,
 		}
+		if paginated {
+			var ok bool
+			if params.Pagination, ok = utils.ParsePaginationOrReturnBadRequest(w, r); !ok {
+				return
+			}
+		}
 
-		bz, err := cdc.MarshalJSON(params)
+		// the querier decodes the params with encoding/json
+		bz, err := json.Marshal(params)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -138,6 +197,10 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
+		if params.IsPaginated() {
+			utils.WritePageResponse(w, cdc, res, cliCtx.Indent)
+			return
+		}
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
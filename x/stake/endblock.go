@@ -34,6 +34,7 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.Valid
 	if sdk.IsUpgrade(sdk.BEP153) {
 		events = events.AppendEvents(csEvents)
 	}
+	validatorUpdates = k.ThrottleValidatorUpdates(ctx, validatorUpdates)
 	ctx.EventManager().EmitEvents(events)
 	return
 }
@@ -92,6 +93,7 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 			k.DistributeInBreathBlock(ctx, types.ChainIDForBeaconChain)
 		}
 	}
+	validatorUpdates = k.ThrottleValidatorUpdates(ctx, validatorUpdates)
 	ctx.EventManager().EmitEvents(events)
 	return
 }
@@ -215,7 +217,7 @@ func handleMatureRedelegations(k keeper.Keeper, ctx sdk.Context) ([]types.DVVTri
 	for _, dvvTriplet := range matureRedelegations {
 		err := k.CompleteRedelegation(ctx, dvvTriplet.DelegatorAddr, dvvTriplet.ValidatorSrcAddr, dvvTriplet.ValidatorDstAddr)
 		if err != nil {
-			k.Logger(ctx).Error(fmt.Sprintf("Failed to complete redelegation: %s", err.Error()), "delegator_address", dvvTriplet.DelegatorAddr.String(), "source_validator_address", dvvTriplet.ValidatorSrcAddr.String(), "source_validator_address", dvvTriplet.ValidatorDstAddr.String())
+			k.Logger(ctx).Error(fmt.Sprintf("Failed to complete redelegation: %s", err.Error()), "delegator_address", dvvTriplet.DelegatorAddr.String(), "source_validator_address", dvvTriplet.ValidatorSrcAddr.String(), "destination_validator_address", dvvTriplet.ValidatorDstAddr.String())
 			continue
 		}
 		events = events.AppendEvent(sdk.NewEvent(
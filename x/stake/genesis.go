@@ -14,8 +14,9 @@ import (
 // InitGenesis sets the pool and parameters for the provided keeper and
 // initializes the IntraTxCounter. For each validator in data, it sets that
 // validator in the keeper along with manually setting the indexes. In
-// addition, it also sets any delegations found in data. Finally, it updates
-// the bonded validators.
+// addition, it also sets any delegations, unbonding delegations and
+// redelegations found in data. Finally, it updates the bonded validators, or
+// restores the last validator set of an exported state.
 // Returns final validator set after applying all declaration and delegations
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res []abci.ValidatorUpdate, err error) {
 
@@ -28,7 +29,10 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 	keeper.SetParams(ctx, data.Params)
 
 	for i, validator := range data.Validators {
-		validator.BondIntraTxCounter = int16(i) // set the intra-tx counter to the order the validators are presented
+		// the exported validators keep their counter
+		if !data.Exported {
+			validator.BondIntraTxCounter = int16(i) // set the intra-tx counter to the order the validators are presented
+		}
 		keeper.SetValidator(ctx, validator)
 
 		if validator.Tokens.IsZero() {
@@ -41,12 +45,46 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		// Manually set indices for the first time
 		keeper.SetValidatorByConsAddr(ctx, validator)
 		keeper.SetValidatorByPowerIndex(ctx, validator)
-		keeper.OnValidatorCreated(ctx, validator.OperatorAddr)
+		if validator.Status == sdk.Unbonding {
+			keeper.InsertValidatorQueue(ctx, validator)
+		}
+
+		// the distribution records of an exported state are imported apart
+		if !data.Exported {
+			keeper.OnValidatorCreated(ctx, validator.OperatorAddr)
+		}
 	}
 
 	for _, delegation := range data.Bonds {
 		keeper.SetDelegation(ctx, delegation)
-		keeper.OnDelegationCreated(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
+		if !data.Exported {
+			keeper.OnDelegationCreated(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
+		}
+	}
+
+	for _, ubd := range data.UnbondingDelegations {
+		keeper.SetUnbondingDelegation(ctx, ubd)
+		keeper.InsertUnbondingQueue(ctx, ubd)
+	}
+
+	for _, red := range data.Redelegations {
+		keeper.SetRedelegation(ctx, red)
+		keeper.InsertRedelegationQueue(ctx, red)
+	}
+
+	// an exported state restores its last validator set, the bonded validators
+	// are not updated again
+	if data.Exported {
+		keeper.SetLastTotalPower(ctx, data.LastTotalPower)
+		for _, lv := range data.LastValidatorPowers {
+			keeper.SetLastValidatorPower(ctx, lv.Address, lv.Power)
+			validator, found := keeper.GetValidator(ctx, lv.Address)
+			if !found {
+				return res, errors.Errorf("last validator %s is not a genesis validator", lv.Address)
+			}
+			res = append(res, validator.ABCIValidatorUpdate())
+		}
+		return
 	}
 
 	_, res = keeper.ApplyAndReturnValidatorSetUpdates(ctx)
@@ -54,19 +92,41 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 }
 
 // WriteGenesis returns a GenesisState for a given context and keeper. The
-// GenesisState will contain the pool, params, validators, and bonds found in
-// the keeper.
+// GenesisState will contain the pool, params, last validator set, validators,
+// bonds, unbonding delegations and redelegations found in the keeper.
 func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	pool := keeper.GetPool(ctx)
 	params := keeper.GetParams(ctx)
+	lastTotalPower := keeper.GetLastTotalPower(ctx)
 	validators := keeper.GetAllValidators(ctx)
 	bonds := keeper.GetAllDelegations(ctx)
 
+	var lastValidatorPowers []types.LastValidatorPower
+	keeper.IterateLastValidatorPowers(ctx, func(addr sdk.ValAddress, power int64) (stop bool) {
+		lastValidatorPowers = append(lastValidatorPowers, types.LastValidatorPower{Address: addr, Power: power})
+		return false
+	})
+	var unbondingDelegations []types.UnbondingDelegation
+	keeper.IterateUnbondingDelegations(ctx, func(_ int64, ubd types.UnbondingDelegation) (stop bool) {
+		unbondingDelegations = append(unbondingDelegations, ubd)
+		return false
+	})
+	var redelegations []types.Redelegation
+	keeper.IterateRedelegations(ctx, func(_ int64, red types.Redelegation) (stop bool) {
+		redelegations = append(redelegations, red)
+		return false
+	})
+
 	return types.GenesisState{
-		Pool:       pool,
-		Params:     params,
-		Validators: validators,
-		Bonds:      bonds,
+		Pool:                 pool,
+		Params:               params,
+		LastTotalPower:       lastTotalPower,
+		LastValidatorPowers:  lastValidatorPowers,
+		Validators:           validators,
+		Bonds:                bonds,
+		UnbondingDelegations: unbondingDelegations,
+		Redelegations:        redelegations,
+		Exported:             true,
 	}
 }
 
@@ -86,7 +146,8 @@ func WriteValidators(ctx sdk.Context, keeper Keeper) (vals []tmtypes.GenesisVali
 }
 
 // ValidateGenesis validates the provided staking genesis state to ensure the
-// expected invariants holds. (i.e. params in correct bounds, no duplicate validators)
+// expected invariants holds. (i.e. params in correct bounds, no duplicate
+// validators, the pool and the validator shares match the delegations)
 func ValidateGenesis(data types.GenesisState) error {
 	err := validateGenesisStateValidators(data.Validators)
 	if err != nil {
@@ -96,35 +157,95 @@ func ValidateGenesis(data types.GenesisState) error {
 	if err != nil {
 		return err
 	}
-
-	return nil
+	err = validateGenesisStatePool(data.Pool, data.Validators)
+	if err != nil {
+		return err
+	}
+	return validateGenesisStateBonds(data.Validators, data.Bonds)
 }
 
 func validateParams(params types.Params) error {
 	if params.BondDenom == "" {
 		return fmt.Errorf("staking parameter BondDenom can't be an empty string")
 	}
+	if params.UnbondingTime <= 0 {
+		return fmt.Errorf("staking parameter UnbondingTime must be positive, is %v", params.UnbondingTime)
+	}
+	if params.MaxValidators == 0 {
+		return fmt.Errorf("staking parameter MaxValidators must be positive")
+	}
 	return nil
 }
 
 func validateGenesisStateValidators(validators []types.Validator) (err error) {
 	addrMap := make(map[string]bool, len(validators))
+	operatorMap := make(map[string]bool, len(validators))
 	for i := 0; i < len(validators); i++ {
 		val := validators[i]
 		strKey := string(val.ConsPubKey.Bytes())
 		if _, ok := addrMap[strKey]; ok {
-			return fmt.Errorf("duplicate validator in genesis state: moniker %v, Address %v", val.Description.Moniker, val.ConsAddress())
+			return fmt.Errorf("validators[%d]: duplicate validator in genesis state: moniker %v, Address %v", i, val.Description.Moniker, val.ConsAddress())
+		}
+		if _, ok := operatorMap[string(val.OperatorAddr)]; ok {
+			return fmt.Errorf("validators[%d]: duplicate validator operator in genesis state: moniker %v, Operator %v", i, val.Description.Moniker, val.OperatorAddr)
 		}
 		if val.Jailed && val.Status == sdk.Bonded {
-			return fmt.Errorf("validator is bonded and jailed in genesis state: moniker %v, Address %v", val.Description.Moniker, val.ConsAddress())
+			return fmt.Errorf("validators[%d]: validator is bonded and jailed in genesis state: moniker %v, Address %v", i, val.Description.Moniker, val.ConsAddress())
 		}
 		if val.Tokens.IsZero() {
-			return fmt.Errorf("genesis validator cannot have zero pool shares, validator: %v", val)
+			return fmt.Errorf("validators[%d]: genesis validator cannot have zero pool shares, validator: %v", i, val)
 		}
 		if val.DelegatorShares.IsZero() {
-			return fmt.Errorf("genesis validator cannot have zero delegator shares, validator: %v", val)
+			return fmt.Errorf("validators[%d]: genesis validator cannot have zero delegator shares, validator: %v", i, val)
+		}
+		if val.Tokens.LT(sdk.ZeroDec()) || val.DelegatorShares.LT(sdk.ZeroDec()) {
+			return fmt.Errorf("validators[%d]: genesis validator cannot have negative tokens or shares, validator: %v", i, val)
 		}
 		addrMap[strKey] = true
+		operatorMap[string(val.OperatorAddr)] = true
 	}
 	return
 }
+
+// the bonded tokens of the pool are the tokens of the bonded validators
+func validateGenesisStatePool(pool types.Pool, validators []types.Validator) error {
+	if pool.LooseTokens.LT(sdk.ZeroDec()) || pool.BondedTokens.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("pool: the loose tokens %v and the bonded tokens %v cannot be negative", pool.LooseTokens, pool.BondedTokens)
+	}
+	bonded := sdk.ZeroDec()
+	for _, val := range validators {
+		if val.Status == sdk.Bonded {
+			bonded = bonded.Add(val.Tokens)
+		}
+	}
+	if !pool.BondedTokens.Equal(bonded) {
+		return fmt.Errorf("pool: bonded tokens %v != sum of the bonded validator tokens %v", pool.BondedTokens, bonded)
+	}
+	return nil
+}
+
+// the bonds are to the genesis validators, and the delegator shares of each
+// validator are the shares of its bonds
+func validateGenesisStateBonds(validators []types.Validator, bonds []types.Delegation) error {
+	shares := make(map[string]sdk.Dec, len(validators))
+	for _, val := range validators {
+		shares[string(val.OperatorAddr)] = sdk.ZeroDec()
+	}
+	for i, bond := range bonds {
+		total, ok := shares[string(bond.ValidatorAddr)]
+		if !ok {
+			return fmt.Errorf("bonds[%d]: delegation of %v to validator %v not in genesis state", i, bond.DelegatorAddr, bond.ValidatorAddr)
+		}
+		if !bond.Shares.GT(sdk.ZeroDec()) {
+			return fmt.Errorf("bonds[%d]: delegation of %v to validator %v must have positive shares, is %v", i, bond.DelegatorAddr, bond.ValidatorAddr, bond.Shares)
+		}
+		shares[string(bond.ValidatorAddr)] = total.Add(bond.Shares)
+	}
+	for i, val := range validators {
+		if total := shares[string(val.OperatorAddr)]; !val.DelegatorShares.Equal(total) {
+			return fmt.Errorf("validators[%d]: delegator shares %v != sum of the delegation shares %v, moniker %v",
+				i, val.DelegatorShares, total, val.Description.Moniker)
+		}
+	}
+	return nil
+}
@@ -114,6 +114,11 @@ func TestValidateGenesis(t *testing.T) {
 	genValidators1[0] = types.NewValidator(sdk.ValAddress(pk.Address()), pk, types.NewDescription("", "", "", ""))
 	genValidators1[0].Tokens = sdk.OneDec()
 	genValidators1[0].DelegatorShares = sdk.OneDec()
+	genBond := types.Delegation{
+		DelegatorAddr: sdk.AccAddress(pk.Address()),
+		ValidatorAddr: sdk.ValAddress(pk.Address()),
+		Shares:        sdk.OneDec(),
+	}
 
 	tests := []struct {
 		name    string
@@ -123,22 +128,46 @@ func TestValidateGenesis(t *testing.T) {
 		{"default", func(*types.GenesisState) {}, false},
 		// validate genesis validators
 		{"duplicate validator", func(data *types.GenesisState) {
-			(*data).Validators = genValidators1
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
 			(*data).Validators = append((*data).Validators, genValidators1[0])
 		}, true},
 		{"no pool shares", func(data *types.GenesisState) {
-			(*data).Validators = genValidators1
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
 			(*data).Validators[0].Tokens = sdk.ZeroDec()
 		}, true},
 		{"no delegator shares", func(data *types.GenesisState) {
-			(*data).Validators = genValidators1
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
 			(*data).Validators[0].DelegatorShares = sdk.ZeroDec()
 		}, true},
 		{"jailed and bonded validator", func(data *types.GenesisState) {
-			(*data).Validators = genValidators1
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
 			(*data).Validators[0].Jailed = true
 			(*data).Validators[0].Status = sdk.Bonded
 		}, true},
+		// validate the pool and the bonds
+		{"bonded validator with its bond", func(data *types.GenesisState) {
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
+			(*data).Validators[0].Status = sdk.Bonded
+			(*data).Bonds = []types.Delegation{genBond}
+			(*data).Pool.BondedTokens = sdk.OneDec()
+		}, false},
+		{"bonded tokens not in pool", func(data *types.GenesisState) {
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
+			(*data).Validators[0].Status = sdk.Bonded
+			(*data).Bonds = []types.Delegation{genBond}
+		}, true},
+		{"no bonds", func(data *types.GenesisState) {
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
+		}, true},
+		{"bond to unknown validator", func(data *types.GenesisState) {
+			(*data).Bonds = []types.Delegation{genBond}
+		}, true},
+		{"bond shares mismatch", func(data *types.GenesisState) {
+			(*data).Validators = append([]types.Validator{}, genValidators1...)
+			bond := genBond
+			bond.Shares = sdk.NewDecWithPrec(5, 1)
+			(*data).Bonds = []types.Delegation{bond}
+		}, true},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
@@ -332,17 +333,29 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	k.SetValidator(ctx, validator)
 
-	tags := sdk.NewTags(
+	resTags := sdk.NewTags(
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
 		tags.Moniker, []byte(description.Moniker),
 		tags.Identity, []byte(description.Identity),
 	)
+	if msg.CommissionRate != nil {
+		resTags = resTags.AppendTags(commissionTags(validator.Commission))
+	}
 
 	return sdk.Result{
-		Tags: tags,
+		Tags: resTags,
 	}
 }
 
+// commissionTags returns the tags of the result of a tx changing the
+// commission of a validator.
+func commissionTags(commission types.Commission) sdk.Tags {
+	return sdk.NewTags(
+		tags.CommissionRate, []byte(commission.Rate.String()),
+		tags.CommissionUpdateTime, []byte(commission.UpdateTime.UTC().Format(time.RFC3339)),
+	)
+}
+
 // handleMsgDelegateV1 is used before we open staking to common users
 func handleMsgDelegateV1(ctx sdk.Context, msg types.MsgDelegate, k keeper.Keeper) sdk.Result {
 	if selfDelegate, err := k.IsSelfDelegator(ctx, msg.DelegatorAddr, msg.ValidatorAddr); err != nil {
@@ -136,12 +136,16 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 	}
 
 	k.SetValidator(ctx, validator)
+	resTags := sdk.NewTags(
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
+	)
+	if msg.CommissionRate != nil {
+		resTags = resTags.AppendTags(commissionTags(validator.Commission))
+	}
 	return sdk.Result{
-		Tags: sdk.NewTags(
-			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-			tags.Moniker, []byte(validator.Description.Moniker),
-			tags.Identity, []byte(validator.Description.Identity),
-		),
+		Tags: resTags,
 	}
 }
 
@@ -286,12 +290,16 @@ func handleMsgEditSideChainValidatorWithVoteAddr(ctx sdk.Context, msg MsgEditSid
 	}
 
 	k.SetValidator(ctx, validator)
+	resTags := sdk.NewTags(
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
+	)
+	if msg.CommissionRate != nil {
+		resTags = resTags.AppendTags(commissionTags(validator.Commission))
+	}
 	return sdk.Result{
-		Tags: sdk.NewTags(
-			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-			tags.Moniker, []byte(validator.Description.Moniker),
-			tags.Identity, []byte(validator.Description.Identity),
-		),
+		Tags: resTags,
 	}
 }
 
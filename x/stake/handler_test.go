@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	keep "github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -594,6 +595,51 @@ func TestJailValidator(t *testing.T) {
 	require.True(t, got.IsOK(), "expected ok, got %v", got)
 }
 
+func TestEditValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := sdk.ValAddress(keep.Addrs[0])
+	description := Description{Moniker: "moniker"}
+
+	// create the validator with a 10% commission, at most 50% and changing by at most 5%
+	commission := NewCommissionMsg(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(5, 2))
+	msgCreateValidator := types.NewMsgCreateValidator(
+		validatorAddr, keep.PKs[0], sdk.NewCoin("steak", sdk.NewDecWithoutFra(10).RawInt()), description, commission,
+	)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// the commission cannot be changed within 24 hours
+	newRate := sdk.NewDecWithPrec(15, 2)
+	msgEditValidator := NewMsgEditValidator(validatorAddr, description, &newRate, "")
+	got = handleMsgEditValidator(ctx, msgEditValidator, keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(24 * time.Hour))
+
+	// the commission cannot change by more than the max change rate
+	tooHighRate := sdk.NewDecWithPrec(2, 1)
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, description, &tooHighRate, ""), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	got = handleMsgEditValidator(ctx, msgEditValidator, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	validator, found := keeper.GetValidator(ctx, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, newRate, validator.Commission.Rate)
+
+	// the delegators are notified of the new commission
+	require.Contains(t, got.Tags, sdk.MakeTag(tags.CommissionRate, []byte(newRate.String())))
+	require.Contains(t, got.Tags, sdk.MakeTag(tags.CommissionUpdateTime,
+		[]byte(ctx.BlockHeader().Time.UTC().Format(time.RFC3339))))
+
+	// editing the description only does not tag the commission
+	got = handleMsgEditValidator(ctx, NewMsgEditValidator(validatorAddr, Description{Moniker: "new moniker"}, nil, ""), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	for _, tag := range got.Tags {
+		require.NotEqual(t, tags.CommissionRate, string(tag.Key))
+	}
+}
+
 func TestValidatorQueue(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr, delegatorAddr := sdk.ValAddress(keep.Addrs[0]), keep.Addrs[1]
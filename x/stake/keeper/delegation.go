@@ -10,6 +10,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/bsc"
 	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/pubsub"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
@@ -392,6 +393,21 @@ func (k Keeper) HasReceivingRedelegation(ctx sdk.Context,
 	return found
 }
 
+// iterate through all of the redelegations
+func (k Keeper) IterateRedelegations(ctx sdk.Context, fn func(index int64, red types.Redelegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, RedelegationKey)
+	defer iterator.Close()
+
+	for i := int64(0); iterator.Valid(); iterator.Next() {
+		red := types.MustUnmarshalRED(k.cdc, iterator.Key(), iterator.Value())
+		if stop := fn(i, red); stop {
+			break
+		}
+		i++
+	}
+}
+
 // set a redelegation and associated index
 func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store := ctx.KVStore(k.storeKey)
@@ -527,20 +543,38 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	delegation.Shares = delegation.Shares.Add(newShares)
 	delegation.Height = ctx.BlockHeight()
 	k.SetDelegation(ctx, delegation)
+	k.OnDelegationSharesIncreased(ctx, delAddr, validator.OperatorAddr, newShares)
+	telemetry.IncrKeeperCounter(ctx, "stake", "delegate")
 	return newShares, nil
 }
 
+// Restake delegates amount from the account of the delegator to the validator,
+// e.g. for the rewards restaked by the distribution module. The jailed
+// validators only accept the delegations of their operator, like MsgDelegate.
+func (k Keeper) Restake(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin) sdk.Error {
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return types.ErrNoValidatorFound(k.Codespace())
+	}
+	if validator.Jailed && !validator.FeeAddr.Equals(delAddr) {
+		return types.ErrValidatorJailed(k.Codespace())
+	}
+	_, err := k.Delegate(ctx, delAddr, amount, validator, true)
+	return err
+}
+
 func (k Keeper) transferBondTokens(ctx sdk.Context, from, to sdk.AccAddress, bondAmt sdk.Coin) sdk.Error {
-	// we do not use k.bankKeeper.SendCoins to have a better error message
+	// the balance is checked first to have a better error message, the coins
+	// are subtracted by the bank keeper so that the locked coins of the
+	// vesting accounts can't be delegated
 	balanceCoins := k.BankKeeper.GetCoins(ctx, from)
 	if balance := balanceCoins.AmountOf(bondAmt.Denom); balance < bondAmt.Amount {
 		return sdk.ErrInsufficientCoins(fmt.Sprintf("No enough balance to delegate, token: %s, balance: %d, amount: %d", bondAmt.Denom, balance, bondAmt.Amount))
 	}
-	delegationAccBalance := k.BankKeeper.GetCoins(ctx, to)
-	if err := k.BankKeeper.SetCoins(ctx, from, balanceCoins.Minus(sdk.Coins{bondAmt})); err != nil {
+	if _, _, err := k.BankKeeper.SubtractCoins(ctx, from, sdk.Coins{bondAmt}); err != nil {
 		return err
 	}
-	if err := k.BankKeeper.SetCoins(ctx, to, delegationAccBalance.Plus(sdk.Coins{bondAmt})); err != nil {
+	if _, _, err := k.BankKeeper.AddCoins(ctx, to, sdk.Coins{bondAmt}); err != nil {
 		return err
 	}
 
@@ -637,6 +671,22 @@ func (k Keeper) getBeginInfo(ctx sdk.Context, valSrcAddr sdk.ValAddress) (
 	}
 }
 
+// get the completion time of an undelegation from the validator starting now:
+// the unbonding period from now, unless the validator is itself unbonding and
+// completes its unbonding later, in which case the undelegation completes with it
+func (k Keeper) undelegationCompletionTime(ctx sdk.Context, valAddr sdk.ValAddress) time.Time {
+	completionTime := ctx.BlockHeader().Time.Add(k.UnbondingTime(ctx))
+	if !sdk.IsUpgrade(sdk.UndelegateWithValidator) {
+		return completionTime
+	}
+
+	validator, found := k.GetValidator(ctx, valAddr)
+	if found && validator.Status == sdk.Unbonding && validator.UnbondingMinTime.After(completionTime) {
+		return validator.UnbondingMinTime
+	}
+	return completionTime
+}
+
 // begin unbonding an unbonding record
 func (k Keeper) BeginUnbonding(ctx sdk.Context,
 	delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) (types.UnbondingDelegation, sdk.Error) {
@@ -647,6 +697,9 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 		return types.UnbondingDelegation{}, types.ErrExistingUnbondingDelegation(k.Codespace())
 	}
 
+	// determined before unbonding, which may remove the validator
+	completionTime := k.undelegationCompletionTime(ctx, valAddr)
+
 	// TODO need to handle it if the DelegatorShareExRate is not 1
 	returnAmount, err := k.unbond(ctx, delAddr, valAddr, sharesAmount)
 	if err != nil {
@@ -655,7 +708,6 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 
 	balance := sdk.NewCoin(k.BondDenom(ctx), returnAmount.RawInt())
 
-	completionTime := ctx.BlockHeader().Time.Add(k.UnbondingTime(ctx))
 	ubd := types.UnbondingDelegation{
 		DelegatorAddr:  delAddr,
 		ValidatorAddr:  valAddr,
@@ -667,6 +719,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 	}
 	k.SetUnbondingDelegation(ctx, ubd)
 	k.InsertUnbondingQueue(ctx, ubd)
+	telemetry.IncrKeeperCounter(ctx, "stake", "unbond")
 
 	return ubd, nil
 }
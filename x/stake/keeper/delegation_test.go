@@ -5,12 +5,40 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// the locked coins of the vesting accounts can't be delegated
+func TestDelegateVestingCoins(t *testing.T) {
+	ctx, am, keeper := CreateTestInput(t, false, 10)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(100, 0)})
+	pool := keeper.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+
+	acc := am.GetAccount(ctx, addrDels[0]).(*auth.BaseAccount)
+	am.SetAccount(ctx, auth.NewDelayedVestingAccount(acc, 200))
+	bond := sdk.NewCoin(keeper.BondDenom(ctx), sdk.NewDecWithoutFra(5).RawInt())
+	_, err := keeper.Delegate(ctx, addrDels[0], bond, validator, true)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInsufficientCoins, err.Code())
+	_, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	require.False(t, found)
+
+	// the vested coins can
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(200, 0)})
+	_, err = keeper.Delegate(ctx, addrDels[0], bond, validator, true)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewDecWithoutFra(5).RawInt(), am.GetAccount(ctx, addrDels[0]).GetCoins().AmountOf(keeper.BondDenom(ctx)))
+}
+
 // tests GetDelegation, GetDelegatorDelegations, SetDelegation, RemoveDelegation, GetDelegatorDelegations
 func TestDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 10)
@@ -368,6 +396,84 @@ func TestUndelegateFromUnbondingValidator(t *testing.T) {
 	assert.True(t, blockTime2.Add(params.UnbondingTime).Equal(ubd.MinTime))
 }
 
+func TestUndelegateFromUnbondingValidatorCompletionTime(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.UndelegateWithValidator, sdk.UpgradeMgr.GetHeight())
+	defer sdk.UpgradeMgr.Reset()
+	pool := keeper.GetPool(ctx)
+	pool.LooseTokens = sdk.NewDecWithoutFra(40)
+
+	//create a validator with a self-delegation
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, issuedShares := validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+	keeper.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(keeper, ctx, validator)
+	pool = keeper.GetPool(ctx)
+	keeper.SetDelegation(ctx, types.Delegation{
+		DelegatorAddr: sdk.AccAddress(addrVals[0].Bytes()),
+		ValidatorAddr: addrVals[0],
+		Shares:        issuedShares,
+	})
+
+	// create three more delegations to this validator
+	delAddrs := []sdk.AccAddress{addrDels[0], addrDels[1], sdk.AccAddress(addrVals[1])}
+	for _, delAddr := range delAddrs {
+		keeper.DeleteValidatorByPowerIndex(ctx, validator)
+		validator, pool, issuedShares = validator.AddTokensFromDel(pool, sdk.NewDecWithoutFra(10).RawInt())
+		keeper.SetPool(ctx, pool)
+		validator = TestingUpdateValidator(keeper, ctx, validator)
+		pool = keeper.GetPool(ctx)
+		keeper.SetDelegation(ctx, types.Delegation{
+			DelegatorAddr: delAddr,
+			ValidatorAddr: addrVals[0],
+			Shares:        issuedShares,
+		})
+	}
+
+	// unbond the all self-delegation to put validator in unbonding state
+	blockTime := time.Unix(333, 0)
+	ctx = ctx.WithBlockHeight(10).WithBlockTime(blockTime)
+	_, err := keeper.BeginUnbonding(ctx, sdk.AccAddress(addrVals[0].Bytes()), addrVals[0], sdk.NewDecWithoutFra(10))
+	require.NoError(t, err)
+	keeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.Unbonding, validator.Status)
+	params := keeper.GetParams(ctx)
+	require.True(t, blockTime.Add(params.UnbondingTime).Equal(validator.UnbondingMinTime))
+
+	// shorten the unbonding period, the validator completes its unbonding later
+	// than a delegator undelegating now would
+	params.UnbondingTime = params.UnbondingTime / 10
+	keeper.SetParams(ctx, params)
+	blockTime2 := blockTime.Add(time.Second)
+	ctx = ctx.WithBlockHeight(11).WithBlockTime(blockTime2)
+	_, err = keeper.BeginUnbonding(ctx, delAddrs[0], addrVals[0], sdk.NewDecWithoutFra(5))
+	require.NoError(t, err)
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delAddrs[0], addrVals[0])
+	require.True(t, found)
+	require.True(t, validator.UnbondingMinTime.Equal(ubd.MinTime))
+
+	// the standard period is used once it ends after the validator's unbonding
+	blockTime3 := validator.UnbondingMinTime.Add(-time.Second)
+	ctx = ctx.WithBlockHeight(12).WithBlockTime(blockTime3)
+	_, err = keeper.BeginUnbonding(ctx, delAddrs[1], addrVals[0], sdk.NewDecWithoutFra(5))
+	require.NoError(t, err)
+	ubd, found = keeper.GetUnbondingDelegation(ctx, delAddrs[1], addrVals[0])
+	require.True(t, found)
+	require.True(t, blockTime3.Add(params.UnbondingTime).Equal(ubd.MinTime))
+
+	// before the upgrade the standard period is always used
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.UndelegateWithValidator, sdk.UpgradeMgr.GetHeight()+1)
+	ctx = ctx.WithBlockHeight(13).WithBlockTime(blockTime2)
+	_, err = keeper.BeginUnbonding(ctx, delAddrs[2], addrVals[0], sdk.NewDecWithoutFra(5))
+	require.NoError(t, err)
+	ubd, found = keeper.GetUnbondingDelegation(ctx, delAddrs[2], addrVals[0])
+	require.True(t, found)
+	require.True(t, blockTime2.Add(params.UnbondingTime).Equal(ubd.MinTime))
+}
+
 func TestUndelegateFromUnbondedValidator(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
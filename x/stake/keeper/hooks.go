@@ -65,6 +65,12 @@ func (k Keeper) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddre
 	}
 }
 
+func (k Keeper) OnDelegationSharesIncreased(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, shares sdk.Dec) {
+	if k.hooks != nil {
+		k.hooks.OnDelegationSharesIncreased(ctx, delAddr, valAddr, shares)
+	}
+}
+
 func (k Keeper) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 	if k.hooks != nil {
 		k.hooks.OnDelegationRemoved(ctx, delAddr, valAddr)
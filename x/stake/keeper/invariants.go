@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the stake module.
+func RegisterInvariants(ir sdk.InvariantRouter, k Keeper) {
+	ir.RegisterRoute("stake", "bonded-tokens", BondedTokensInvariant(k))
+	ir.RegisterRoute("stake", "delegator-shares", DelegatorSharesInvariant(k))
+}
+
+// BondedTokensInvariant checks that the bonded tokens of the pool are the
+// tokens of the bonded validators.
+func BondedTokensInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		bonded := sdk.ZeroDec()
+		k.IterateValidators(ctx, func(_ int64, validator sdk.Validator) bool {
+			if validator.GetStatus() == sdk.Bonded {
+				bonded = bonded.Add(validator.GetTokens())
+			}
+			return false
+		})
+
+		if pool := k.GetPool(ctx); !pool.BondedTokens.Equal(bonded) {
+			return fmt.Errorf("pool bonded tokens %v != sum of the bonded validator tokens %v", pool.BondedTokens, bonded)
+		}
+		return nil
+	}
+}
+
+// DelegatorSharesInvariant checks that the delegator shares of each validator
+// are the shares of its delegations.
+func DelegatorSharesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		shares := make(map[string]sdk.Dec)
+		for _, delegation := range k.GetAllDelegations(ctx) {
+			valAddr := delegation.ValidatorAddr.String()
+			if total, ok := shares[valAddr]; ok {
+				shares[valAddr] = total.Add(delegation.Shares)
+			} else {
+				shares[valAddr] = delegation.Shares
+			}
+		}
+
+		for _, validator := range k.GetAllValidators(ctx) {
+			total, ok := shares[validator.OperatorAddr.String()]
+			if !ok {
+				total = sdk.ZeroDec()
+			}
+			if !validator.DelegatorShares.Equal(total) {
+				return fmt.Errorf("validator %s delegator shares %v != sum of the delegation shares %v",
+					validator.OperatorAddr, validator.DelegatorShares, total)
+			}
+		}
+		return nil
+	}
+}
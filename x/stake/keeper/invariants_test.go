@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestInvariants(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	keeper.SetValidator(ctx, validator)
+	keeper.SetValidatorByPowerIndex(ctx, validator)
+	_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin(keeper.BondDenom(ctx), 100e8), validator, true)
+	require.Nil(t, err)
+	keeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, validator.Status)
+	require.NoError(t, BondedTokensInvariant(keeper)(ctx))
+	require.NoError(t, DelegatorSharesInvariant(keeper)(ctx))
+
+	// the pool out of sync with the validators
+	pool := keeper.GetPool(ctx)
+	pool.BondedTokens = pool.BondedTokens.Add(sdk.OneDec())
+	keeper.SetPool(ctx, pool)
+	require.Error(t, BondedTokensInvariant(keeper)(ctx))
+
+	// the delegations out of sync with the validators
+	delegation, _ := keeper.GetDelegation(ctx, addrDels[0], addrVals[0])
+	delegation.Shares = delegation.Shares.Add(sdk.OneDec())
+	keeper.SetDelegation(ctx, delegation)
+	require.Error(t, DelegatorSharesInvariant(keeper)(ctx))
+}
@@ -25,6 +25,9 @@ var (
 	LastValidatorPowerKey = []byte{0x11} // prefix for each key to a validator index, for bonded validators
 	LastTotalPowerKey     = []byte{0x12} // prefix for the total power
 
+	LastSentValidatorUpdateKey = []byte{0x13} // prefix for each key to the last validator update sent to Tendermint, by consensus address
+	ValidatorKeyRotationKey    = []byte{0x14} // prefix for each key to the consensus pubkey a validator rotated from, by the consensus address it rotated to
+
 	ValidatorsKey               = []byte{0x21} // prefix for each key to a validator
 	ValidatorsByConsAddrKey     = []byte{0x22} // prefix for each key to a validator index, by pubkey
 	ValidatorsByPowerIndexKey   = []byte{0x23} // prefix for each key to a validator index, sorted by power
@@ -62,6 +65,20 @@ func GetValidatorKey(operatorAddr sdk.ValAddress) []byte {
 	return append(ValidatorsKey, operatorAddr.Bytes()...)
 }
 
+// gets the key for the last validator update sent to Tendermint of the
+// validator with consensus address
+// VALUE: abci.ValidatorUpdate
+func GetLastSentValidatorUpdateKey(addr sdk.ConsAddress) []byte {
+	return append(LastSentValidatorUpdateKey, addr.Bytes()...)
+}
+
+// gets the key for the consensus pubkey a validator rotated from to the
+// consensus address
+// VALUE: abci.PubKey
+func GetValidatorKeyRotationKey(addr sdk.ConsAddress) []byte {
+	return append(ValidatorKeyRotationKey, addr.Bytes()...)
+}
+
 // gets the key for the validator with pubkey
 // VALUE: validator operator address ([]byte)
 func GetValidatorByConsAddrKey(addr sdk.ConsAddress) []byte {
@@ -3,9 +3,8 @@ package keeper
 import (
 	"time"
 
-	"github.com/tendermint/tendermint/crypto"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
@@ -15,15 +14,23 @@ const (
 	DefaultParamspace = "stake"
 )
 
+// Names of the module accounts of the stake module
+const (
+	FeeCollectorName       = auth.FeeCollectorName
+	DelegationAccName      = "BinanceChainStakeDelegation"
+	FeeForAllBcValsAccName = "BinanceChainStakeFeeForAllBcVals"
+)
+
 var (
-	FeeCollectorAddr       = sdk.AccAddress(crypto.AddressHash([]byte("FeeCollector")))
-	DelegationAccAddr      = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeDelegation")))
-	FeeForAllBcValsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeFeeForAllBcVals")))
+	FeeCollectorAddr       = auth.NewModuleAddress(FeeCollectorName)
+	DelegationAccAddr      = auth.NewModuleAddress(DelegationAccName)
+	FeeForAllBcValsAccAddr = auth.NewModuleAddress(FeeForAllBcValsAccName)
 )
 
 // ParamTable for stake module
 func ParamTypeTable() params.TypeTable {
-	return params.NewTypeTable().RegisterParamSet(&types.Params{})
+	return params.NewTypeTable().RegisterParamSet(&types.Params{}).
+		RegisterType(types.KeyMaxValidatorUpdatesPerBlock, uint16(0))
 }
 
 // UnbondingTime
@@ -79,6 +86,18 @@ func (k Keeper) FeeFromBscToBcRatio(ctx sdk.Context) (res sdk.Dec) {
 	return
 }
 
+// MaxValidatorUpdatesPerBlock - maximum number of validator updates sent to
+// Tendermint in a block from the ValidatorUpdatesThrottle upgrade, the others
+// are deferred to the next blocks; zero, the default, disables the throttle
+func (k Keeper) MaxValidatorUpdatesPerBlock(ctx sdk.Context) (res uint16) {
+	k.paramstore.GetIfExists(ctx, types.KeyMaxValidatorUpdatesPerBlock, &res)
+	return
+}
+
+func (k Keeper) SetMaxValidatorUpdatesPerBlock(ctx sdk.Context, max uint16) {
+	k.paramstore.Set(ctx, types.KeyMaxValidatorUpdatesPerBlock, max)
+}
+
 // Get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.UnbondingTime = k.UnbondingTime(ctx)
@@ -137,3 +137,58 @@ func (k Keeper) GetAllUnJailValidatorsCount(ctx sdk.Context) (count int64) {
 	}
 	return count
 }
+
+// return the page of the delegations of a delegator, with the number of them.
+// Only the delegations of the page are decoded.
+func (k Keeper) GetDelegatorDelegationsPage(ctx sdk.Context, delegator sdk.AccAddress,
+	pagination sdk.Pagination) (delegations []types.Delegation, total int) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsKey(delegator)) //smallest to largest
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if pagination.InPage(total) {
+			delegations = append(delegations, types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value()))
+		}
+		total++
+	}
+	return delegations, total
+}
+
+// return the page of the unbonding-delegations of a delegator, with the number
+// of them
+func (k Keeper) GetUnbondingDelegationsPage(ctx sdk.Context, delegator sdk.AccAddress,
+	pagination sdk.Pagination) (unbondingDelegations []types.UnbondingDelegation, total int) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetUBDsKey(delegator)) //smallest to largest
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if pagination.InPage(total) {
+			unbondingDelegations = append(unbondingDelegations, types.MustUnmarshalUBD(k.cdc, iterator.Key(), iterator.Value()))
+		}
+		total++
+	}
+	return unbondingDelegations, total
+}
+
+// return the page of the unbonding-delegations from a validator, with the
+// number of them
+func (k Keeper) GetUnbondingDelegationsFromValidatorPage(ctx sdk.Context, valAddr sdk.ValAddress,
+	pagination sdk.Pagination) (ubds []types.UnbondingDelegation, total int) {
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetUBDsByValIndexKey(valAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if pagination.InPage(total) {
+			key := GetUBDKeyFromValIndexKey(iterator.Key())
+			ubds = append(ubds, types.MustUnmarshalUBD(k.cdc, key, store.Get(key)))
+		}
+		total++
+	}
+	return ubds, total
+}
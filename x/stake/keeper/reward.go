@@ -65,6 +65,9 @@ func mulQuoDecWithExtraDecimal(a, b, c sdk.Dec, extraDecimalPlace int) (afterRou
 	extra := int64(math.Pow(10, float64(extraDecimalPlace)))
 	product, ok := sdk.Mul64(a.RawInt(), b.RawInt())
 	if !ok { // int64 exceed
+		if afterRoundDown, extraDecimalValue, ok = mulQuo128WithExtraDecimal(a.RawInt(), b.RawInt(), c.RawInt(), extra); ok {
+			return afterRoundDown, extraDecimalValue
+		}
 		return mulQuoBigIntWithExtraDecimal(big.NewInt(a.RawInt()), big.NewInt(b.RawInt()), big.NewInt(c.RawInt()), big.NewInt(extra))
 	} else {
 		if product, ok = sdk.Mul64(product, extra); !ok {
@@ -77,6 +80,22 @@ func mulQuoDecWithExtraDecimal(a, b, c sdk.Dec, extraDecimalPlace int) (afterRou
 	}
 }
 
+// mulQuo128WithExtraDecimal is mulQuoDecWithExtraDecimal of the non-negative
+// a, b and c with the products computed on 128 bits: with a * b = q * c + r,
+// a * b * extra / c = q * extra + r * extra / c where r * extra / c < extra.
+// ok is false for the negative values or if the result overflows an int64.
+func mulQuo128WithExtraDecimal(a, b, c, extra int64) (afterRoundDown int64, extraDecimalValue int, ok bool) {
+	if a < 0 || b < 0 || c <= 0 {
+		return 0, 0, false
+	}
+	quo, rem, ok := sdk.MulQuoRem64(uint64(a), uint64(b), uint64(c))
+	if !ok || quo > math.MaxInt64 {
+		return 0, 0, false
+	}
+	extraQuo, _, _ := sdk.MulQuoRem64(rem, uint64(extra), uint64(c))
+	return int64(quo), int(extraQuo), true
+}
+
 func mulQuoBigIntWithExtraDecimal(a, b, c, extra *big.Int) (afterRoundDown int64, extraDecimalValue int) {
 	product := sdk.MulBigInt(sdk.MulBigInt(a, b), extra)
 	result := sdk.QuoBigInt(product, c)
@@ -1,9 +1,12 @@
 package keeper
 
 import (
-	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	"math/big"
+	"math/rand"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
@@ -131,3 +134,33 @@ func TestMulDivDecWithExtraDecimal(t *testing.T) {
 	require.EqualValues(t, 114285714285, afterRoundDown)
 	require.EqualValues(t, 714, extraDecimalValue)
 }
+
+func TestMulQuoDecWithExtraDecimalMatchesBigInt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		// the shares of a delegator, the total rewards and the total shares
+		c := r.Int63()>>uint(r.Intn(40)) + 1
+		a := r.Int63n(c) + 1
+		b := r.Int63() >> uint(r.Intn(40))
+		extra := int64(10)
+		expectedRoundDown, expectedExtra := mulQuoBigIntWithExtraDecimal(big.NewInt(a), big.NewInt(b), big.NewInt(c), big.NewInt(extra))
+		afterRoundDown, extraDecimalValue := mulQuoDecWithExtraDecimal(sdk.NewDec(a), sdk.NewDec(b), sdk.NewDec(c), 1)
+		require.Equal(t, expectedRoundDown, afterRoundDown, "%d * %d / %d", a, b, c)
+		require.Equal(t, expectedExtra, extraDecimalValue, "%d * %d / %d", a, b, c)
+	}
+}
+
+// allocate the rewards of a validator among delegators of about 10^16 shares,
+// which overflow an int64 when multiplied by the rewards
+func BenchmarkAllocateReward(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	sharers := make([]types.Sharer, 1000)
+	for i := range sharers {
+		sharers[i] = types.Sharer{AccAddr: CreateTestAddr(), Shares: sdk.NewDec(r.Int63n(1e16) + 1)}
+	}
+	totalRewards := sdk.NewDec(123456789012)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		allocate(sharers, totalRewards)
+	}
+}
@@ -89,6 +89,12 @@ func (k Keeper) TotalPower(ctx sdk.Context) sdk.Dec {
 	return pool.BondedTokens
 }
 
+// total supply of the bond denom, both loose and bonded
+func (k Keeper) TotalSupply(ctx sdk.Context) sdk.Dec {
+	pool := k.GetPool(ctx)
+	return pool.TokenSupply()
+}
+
 // total power from the bond
 func (k Keeper) BondedRatio(ctx sdk.Context) sdk.Dec {
 	pool := k.GetPool(ctx)
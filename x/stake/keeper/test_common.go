@@ -72,6 +72,7 @@ func MakeTestCodec() *codec.Codec {
 	// Register AppAccount
 	cdc.RegisterInterface((*sdk.Account)(nil), nil)
 	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/stake/Account", nil)
+	cdc.RegisterConcrete(&auth.DelayedVestingAccount{}, "test/stake/DelayedVestingAccount", nil)
 	codec.RegisterCrypto(cdc)
 
 	return cdc
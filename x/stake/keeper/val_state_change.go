@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // Apply and return accumulated updates to the bonded validator set. Also,
@@ -405,9 +406,18 @@ func (k Keeper) AddPendingABCIValidatorUpdate(ctx sdk.Context, validatorUpdate [
 	if bz != nil {
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &currentValidatorUpdate)
 	}
+	finalValidatorUpdate := mergeValidatorUpdates(currentValidatorUpdate, validatorUpdate)
+	bz = k.cdc.MustMarshalBinaryLengthPrefixed(finalValidatorUpdate)
+	store.Set(PendingValidatorUpdateKey, bz)
+
+}
+
+// mergeValidatorUpdates appends the updates to the earlier ones, the updates of
+// a validator replacing its earlier update in place.
+func mergeValidatorUpdates(earlier, updates []abci.ValidatorUpdate) []abci.ValidatorUpdate {
 	// remove the duplicates
 	validatorUpdateMap := make(map[string]int)
-	combinedSlice := append(currentValidatorUpdate[:], validatorUpdate...)
+	combinedSlice := append(earlier[:], updates...)
 	var finalValidatorUpdate []abci.ValidatorUpdate
 	for _, v := range combinedSlice {
 		if index, ok := validatorUpdateMap[v.PubKey.String()]; ok {
@@ -417,9 +427,7 @@ func (k Keeper) AddPendingABCIValidatorUpdate(ctx sdk.Context, validatorUpdate [
 			finalValidatorUpdate = append(finalValidatorUpdate, v)
 		}
 	}
-	bz = k.cdc.MustMarshalBinaryLengthPrefixed(finalValidatorUpdate)
-	store.Set(PendingValidatorUpdateKey, bz)
-
+	return finalValidatorUpdate
 }
 
 func (k Keeper) PopPendingABCIValidatorUpdate(ctx sdk.Context) (validatorUpdate []abci.ValidatorUpdate) {
@@ -431,3 +439,143 @@ func (k Keeper) PopPendingABCIValidatorUpdate(ctx sdk.Context) (validatorUpdate
 	}
 	return
 }
+
+// ThrottleValidatorUpdates returns the validator updates of the block to send
+// to Tendermint from the ValidatorUpdatesThrottle upgrade, the updates as they
+// are before it. The pending updates are sent first, then the updates leaving
+// the power of their validator as it was last sent are dropped, and the updates
+// over MaxValidatorUpdatesPerBlock are deferred to the next blocks, the updates
+// removing a validator last so that the validator set is never emptied. The
+// rotations of the consensus key of a validator are never throttled, the
+// removal of the old key is always sent along with the new key so that the
+// validator never has its power twice.
+func (k Keeper) ThrottleValidatorUpdates(ctx sdk.Context, updates []abci.ValidatorUpdate) []abci.ValidatorUpdate {
+	if !sdk.IsUpgrade(sdk.ValidatorUpdatesThrottle) {
+		return updates
+	}
+	updates = mergeValidatorUpdates(k.PopPendingABCIValidatorUpdate(ctx), updates)
+
+	diff := make([]abci.ValidatorUpdate, 0, len(updates))
+	for _, update := range updates {
+		last, found := k.GetLastSentValidatorUpdate(ctx, update.PubKey)
+		if found && last.Power == update.Power {
+			continue
+		}
+		diff = append(diff, update)
+	}
+
+	rotations, diff := k.splitValidatorKeyRotations(ctx, diff)
+	if max := int(k.MaxValidatorUpdatesPerBlock(ctx)); max > 0 && len(diff) > max {
+		sort.SliceStable(diff, func(i, j int) bool {
+			return diff[i].Power != 0 && diff[j].Power == 0
+		})
+		k.AddPendingABCIValidatorUpdate(ctx, diff[max:])
+		diff = diff[:max]
+	}
+	diff = append(rotations, diff...)
+
+	for _, update := range diff {
+		k.setLastSentValidatorUpdate(ctx, update)
+	}
+	return diff
+}
+
+// splitValidatorKeyRotations returns the updates rotating the consensus key of
+// a validator, each removal of an old key followed by the update of the key
+// replacing it, and the other updates. The rotations are forgotten once their
+// new key is updated.
+func (k Keeper) splitValidatorKeyRotations(ctx sdk.Context, updates []abci.ValidatorUpdate) (rotations, others []abci.ValidatorUpdate) {
+	updated := make(map[string]abci.ValidatorUpdate, len(updates))
+	for _, update := range updates {
+		updated[update.PubKey.String()] = update
+	}
+
+	rotated := make(map[string]bool)
+	for _, update := range updates {
+		oldPubKey, found := k.popValidatorKeyRotation(ctx, update.PubKey)
+		if !found {
+			continue
+		}
+		old, ok := updated[oldPubKey.String()]
+		if !ok {
+			continue
+		}
+		// the old key may itself be the new key of a rotation of the block
+		if !rotated[oldPubKey.String()] {
+			rotated[oldPubKey.String()] = true
+			rotations = append(rotations, old)
+		}
+		rotated[update.PubKey.String()] = true
+		rotations = append(rotations, update)
+	}
+
+	for _, update := range updates {
+		if !rotated[update.PubKey.String()] {
+			others = append(others, update)
+		}
+	}
+	return rotations, others
+}
+
+func (k Keeper) setValidatorKeyRotation(ctx sdk.Context, newConsAddr sdk.ConsAddress, oldPubKey abci.PubKey) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorKeyRotationKey(newConsAddr), k.cdc.MustMarshalBinaryLengthPrefixed(oldPubKey))
+}
+
+func (k Keeper) popValidatorKeyRotation(ctx sdk.Context, newPubKey abci.PubKey) (oldPubKey abci.PubKey, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	key := GetValidatorKeyRotationKey(consAddressFromABCIPubKey(newPubKey))
+	bz := store.Get(key)
+	if bz == nil {
+		return oldPubKey, false
+	}
+	store.Delete(key)
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &oldPubKey)
+	return oldPubKey, true
+}
+
+// GetLastSentValidatorUpdate returns the last update sent to Tendermint of the
+// validator of the consensus pubkey, from the ValidatorUpdatesThrottle upgrade.
+func (k Keeper) GetLastSentValidatorUpdate(ctx sdk.Context, pubKey abci.PubKey) (update abci.ValidatorUpdate, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetLastSentValidatorUpdateKey(consAddressFromABCIPubKey(pubKey)))
+	if bz == nil {
+		return update, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &update)
+	return update, true
+}
+
+// GetLastSentValidatorSet returns the validator set as last sent to
+// Tendermint, sorted by consensus address. The set only holds the validators
+// updated from the ValidatorUpdatesThrottle upgrade.
+func (k Keeper) GetLastSentValidatorSet(ctx sdk.Context) (validators []abci.ValidatorUpdate) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, LastSentValidatorUpdateKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var update abci.ValidatorUpdate
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &update)
+		validators = append(validators, update)
+	}
+	return validators
+}
+
+// the validators removed from the set are forgotten
+func (k Keeper) setLastSentValidatorUpdate(ctx sdk.Context, update abci.ValidatorUpdate) {
+	store := ctx.KVStore(k.storeKey)
+	key := GetLastSentValidatorUpdateKey(consAddressFromABCIPubKey(update.PubKey))
+	if update.Power == 0 {
+		store.Delete(key)
+		return
+	}
+	store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(update))
+}
+
+func consAddressFromABCIPubKey(pubKey abci.PubKey) sdk.ConsAddress {
+	pk, err := tmtypes.PB2TM.PubKey(pubKey)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.ConsAddress(pk.Address())
+}
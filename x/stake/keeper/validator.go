@@ -182,6 +182,9 @@ func (k Keeper) UpdateValidatorPubKey(ctx sdk.Context, validator types.Validator
 			Power:  validator.BondedTokens().RawInt(),
 		}
 		k.AddPendingABCIValidatorUpdate(ctx, []abci.ValidatorUpdate{oldValidatorUpdate, newValidatorUpdate})
+		if sdk.IsUpgrade(sdk.ValidatorUpdatesThrottle) {
+			k.setValidatorKeyRotation(ctx, newConsAddr, oldValidatorUpdate.PubKey)
+		}
 	}
 }
 
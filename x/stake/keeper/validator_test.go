@@ -8,6 +8,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1119,3 +1120,56 @@ func TestUpdateValidatorCommission(t *testing.T) {
 		}
 	}
 }
+
+func TestThrottleValidatorUpdates(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 10)
+	update := func(i int, power int64) abci.ValidatorUpdate {
+		return abci.ValidatorUpdate{PubKey: tmtypes.TM2PB.PubKey(PKs[i]), Power: power}
+	}
+
+	// the updates are sent as they are before the upgrade
+	updates := []abci.ValidatorUpdate{update(0, 10), update(0, 10)}
+	require.Equal(t, updates, keeper.ThrottleValidatorUpdates(ctx, updates))
+	require.Empty(t, keeper.GetLastSentValidatorSet(ctx))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ValidatorUpdatesThrottle, 1)
+	updates = []abci.ValidatorUpdate{update(0, 10), update(1, 20), update(2, 30)}
+	require.Equal(t, updates, keeper.ThrottleValidatorUpdates(ctx, updates))
+	require.Len(t, keeper.GetLastSentValidatorSet(ctx), 3)
+
+	// only the changed powers are sent
+	updates = []abci.ValidatorUpdate{update(0, 10), update(1, 25), update(2, 30)}
+	require.Equal(t, []abci.ValidatorUpdate{update(1, 25)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+	last, found := keeper.GetLastSentValidatorUpdate(ctx, update(1, 0).PubKey)
+	require.True(t, found)
+	require.Equal(t, int64(25), last.Power)
+
+	// the updates over the max are deferred, the removals last
+	keeper.SetMaxValidatorUpdatesPerBlock(ctx, 2)
+	updates = []abci.ValidatorUpdate{update(0, 0), update(3, 40), update(4, 50)}
+	require.Equal(t, []abci.ValidatorUpdate{update(3, 40), update(4, 50)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+	// the later update of a deferred validator replaces it
+	updates = []abci.ValidatorUpdate{update(1, 20), update(0, 15)}
+	require.Equal(t, []abci.ValidatorUpdate{update(0, 15), update(1, 20)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+	require.Empty(t, keeper.PopPendingABCIValidatorUpdate(ctx))
+
+	updates = []abci.ValidatorUpdate{update(3, 0), update(4, 0), update(2, 35)}
+	require.Equal(t, []abci.ValidatorUpdate{update(2, 35), update(3, 0)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+	require.Equal(t, []abci.ValidatorUpdate{update(4, 0)}, keeper.ThrottleValidatorUpdates(ctx, nil))
+	require.Len(t, keeper.GetLastSentValidatorSet(ctx), 3)
+
+	// the rotations of a consensus key are sent whole, over the max
+	keeper.SetMaxValidatorUpdatesPerBlock(ctx, 1)
+	keeper.setValidatorKeyRotation(ctx, sdk.ConsAddress(PKs[5].Address()), update(0, 0).PubKey)
+	updates = []abci.ValidatorUpdate{update(0, 0), update(5, 15), update(1, 30), update(2, 40)}
+	require.Equal(t, []abci.ValidatorUpdate{update(0, 0), update(5, 15), update(1, 30)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+	require.Equal(t, []abci.ValidatorUpdate{update(2, 40)}, keeper.ThrottleValidatorUpdates(ctx, nil))
+	_, found = keeper.popValidatorKeyRotation(ctx, update(5, 0).PubKey)
+	require.False(t, found)
+
+	// a key rotated twice in a block is sent along with both rotations
+	keeper.setValidatorKeyRotation(ctx, sdk.ConsAddress(PKs[6].Address()), update(5, 0).PubKey)
+	keeper.setValidatorKeyRotation(ctx, sdk.ConsAddress(PKs[7].Address()), update(6, 0).PubKey)
+	updates = []abci.ValidatorUpdate{update(5, 0), update(6, 0), update(7, 15), update(1, 35)}
+	require.Equal(t, []abci.ValidatorUpdate{update(5, 0), update(6, 0), update(7, 15), update(1, 35)}, keeper.ThrottleValidatorUpdates(ctx, updates))
+}
@@ -208,8 +208,12 @@ func (p BaseParams) GetSideChainId() string {
 // - 'custom/stake/delegatorUnbondingDelegations'
 // - 'custom/stake/delegatorRedelegations'
 // - 'custom/stake/delegatorValidators'
+//
+// The delegations and unbonding delegations are paginated if Limit is set, the
+// result is then a sdk.Page.
 type QueryDelegatorParams struct {
 	BaseParams
+	sdk.Pagination
 	DelegatorAddr sdk.AccAddress
 }
 
@@ -217,8 +221,12 @@ type QueryDelegatorParams struct {
 // - 'custom/stake/validator'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+//
+// The unbonding delegations are paginated if Limit is set, the result is then a
+// sdk.Page.
 type QueryValidatorParams struct {
 	BaseParams
+	sdk.Pagination
 	ValidatorAddr sdk.ValAddress
 }
 
@@ -277,6 +285,13 @@ func queryValidator(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorPar
 }
 
 func queryValidatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	if params.IsPaginated() {
+		if errRes := params.Pagination.ValidateBasic(); errRes != nil {
+			return nil, sdk.ErrUnknownRequest(errRes.Error())
+		}
+		unbonds, total := k.GetUnbondingDelegationsFromValidatorPage(ctx, params.ValidatorAddr, params.Pagination)
+		return marshalPage(cdc, total, unbonds)
+	}
 
 	unbonds := k.GetUnbondingDelegationsFromValidator(ctx, params.ValidatorAddr)
 
@@ -298,6 +313,18 @@ func queryValidatorRedelegations(ctx sdk.Context, cdc *codec.Codec, params *Quer
 }
 
 func queryDelegatorDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	if params.IsPaginated() {
+		if errRes := params.Pagination.ValidateBasic(); errRes != nil {
+			return nil, sdk.ErrUnknownRequest(errRes.Error())
+		}
+		delegations, total := k.GetDelegatorDelegationsPage(ctx, params.DelegatorAddr, params.Pagination)
+		delResponses, err := delegationsToDelegationResponses(ctx, k, delegations)
+		if err != nil {
+			return res, err
+		}
+		return marshalPage(cdc, total, delResponses)
+	}
+
 	delegations := k.GetAllDelegatorDelegations(ctx, params.DelegatorAddr)
 	delResponses, err := delegationsToDelegationResponses(ctx, k, delegations)
 	if err != nil {
@@ -312,6 +339,14 @@ func queryDelegatorDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryD
 }
 
 func queryDelegatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	if params.IsPaginated() {
+		if errRes := params.Pagination.ValidateBasic(); errRes != nil {
+			return nil, sdk.ErrUnknownRequest(errRes.Error())
+		}
+		unbondingDelegations, total := k.GetUnbondingDelegationsPage(ctx, params.DelegatorAddr, params.Pagination)
+		return marshalPage(cdc, total, unbondingDelegations)
+	}
+
 	unbondingDelegations := k.GetAllUnbondingDelegations(ctx, params.DelegatorAddr)
 
 	res, errRes := codec.MarshalJSONIndent(cdc, unbondingDelegations)
@@ -518,3 +553,17 @@ func delegationsToDelegationResponses(
 
 	return resp, nil
 }
+
+// marshalPage marshals the page of the results items, with the total number of
+// results
+func marshalPage(cdc *codec.Codec, total int, items interface{}) (res []byte, err sdk.Error) {
+	bz, errRes := cdc.MarshalJSON(items)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	res, errRes = json.MarshalIndent(sdk.NewPage(total, bz), "", "  ")
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -415,3 +415,91 @@ func TestQueryRedelegations(t *testing.T) {
 
 	require.Equal(t, redelegation, redsRes[0])
 }
+
+func TestQueryDelegatorDelegationsPage(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
+
+	// delegate to 3 validators
+	for i := 0; i < 3; i++ {
+		val := types.NewValidator(sdk.ValAddress(keep.Addrs[i]), keep.PKs[i], types.Description{})
+		keeper.SetValidator(ctx, val)
+		keeper.SetValidatorByPowerIndex(ctx, val)
+		keeper.Delegate(ctx, addrAcc2, sdk.NewCoin("steak", sdk.NewDecWithoutFra(10).RawInt()), val, true)
+	}
+	delegations := keeper.GetAllDelegatorDelegations(ctx, addrAcc2)
+	require.Len(t, delegations, 3)
+
+	querier := NewQuerier(keeper, cdc)
+	queryParams := newTestDelegatorQuery(addrAcc2)
+	for _, tc := range []struct {
+		offset, limit int
+		expected      []types.Delegation
+	}{
+		{0, 2, delegations[:2]},
+		{1, 2, delegations[1:]},
+		{2, 5, delegations[2:]},
+		{3, 1, nil},
+	} {
+		queryParams.Pagination = sdk.NewPagination(tc.offset, tc.limit)
+		bz, errRes := json.Marshal(queryParams)
+		require.Nil(t, errRes)
+
+		res, err := querier(ctx, []string{"delegatorDelegations"}, abci.RequestQuery{Data: bz})
+		require.Nil(t, err)
+
+		var page sdk.Page
+		require.Nil(t, json.Unmarshal(res, &page))
+		require.Equal(t, 3, page.Total)
+		var delResponses []types.DelegationResponse
+		require.Nil(t, cdc.UnmarshalJSON(page.Items, &delResponses))
+		require.Len(t, delResponses, len(tc.expected))
+		for i, delegation := range tc.expected {
+			require.Equal(t, delegation.ValidatorAddr, delResponses[i].ValidatorAddr)
+		}
+	}
+
+	// limit out of range
+	queryParams.Pagination = sdk.NewPagination(0, sdk.MaxPageLimit+1)
+	bz, errRes := json.Marshal(queryParams)
+	require.Nil(t, errRes)
+	_, err := querier(ctx, []string{"delegatorDelegations"}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
+
+func TestQuerySideChain(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	keeper.ScKeeper.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x99})
+	sideChainCtx, errRes := keeper.ScKeeper.PrepareCtxForSideChain(ctx, "bsc")
+	require.Nil(t, errRes)
+
+	validator := types.NewValidator(addrVal1, pk1, types.Description{})
+	keeper.SetValidator(sideChainCtx, validator)
+
+	querier := NewQuerier(keeper, cdc)
+	queryValParams := newTestValidatorQuery(addrVal1)
+	bz, errRes := json.Marshal(queryValParams)
+	require.Nil(t, errRes)
+
+	// the validator of the side chain is not on the main chain
+	_, err := querier(ctx, []string{QueryValidator}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidValidator, err.Code())
+
+	queryValParams.SideChainId = "bsc"
+	bz, errRes = json.Marshal(queryValParams)
+	require.Nil(t, errRes)
+	res, err := querier(ctx, []string{QueryValidator}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var resValidator types.Validator
+	require.Nil(t, cdc.UnmarshalJSON(res, &resValidator))
+	require.Equal(t, validator.OperatorAddr, resValidator.OperatorAddr)
+
+	queryValParams.SideChainId = "unknown"
+	bz, errRes = json.Marshal(queryValParams)
+	require.Nil(t, errRes)
+	_, err = querier(ctx, []string{QueryValidator}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeInvalidSideChain, err.Code())
+}
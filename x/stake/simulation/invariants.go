@@ -40,6 +40,11 @@ func SupplyInvariants(ck bank.Keeper, k stake.Keeper, d distribution.Keeper, am
 		loose := sdk.ZeroDec()
 		bonded := sdk.ZeroDec()
 		am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+			// the delegation account holds the tokens of the validators and
+			// of the unbonding delegations, they are counted below
+			if acc.GetAddress().Equals(stake.DelegationAccAddr) {
+				return false
+			}
 			loose = loose.Add(sdk.NewDecFromInt(acc.GetCoins().AmountOf("steak")))
 			return false
 		})
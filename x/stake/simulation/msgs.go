@@ -13,6 +13,16 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
+// randomDelegationAmount returns a random amount of a balance to delegate, at
+// least the minimum delegation of one token
+func randomDelegationAmount(r *rand.Rand, balance int64) (int64, bool) {
+	minAmount := sdk.NewDecWithoutFra(1).RawInt()
+	if balance <= minAmount {
+		return 0, false
+	}
+	return minAmount + simulation.RandomAmount(r, balance-minAmount), true
+}
+
 // SimulateMsgCreateValidator
 func SimulateMsgCreateValidator(m auth.AccountKeeper, k stake.Keeper) simulation.Operation {
 	handler := stake.NewStakeHandler(k)
@@ -25,21 +35,18 @@ func SimulateMsgCreateValidator(m auth.AccountKeeper, k stake.Keeper) simulation
 			Moniker: simulation.RandStringOfLength(r, 10),
 		}
 
-		maxCommission := int64(10)
+		// the rate and its max change are bounded by the max rate
+		maxCommission := simulation.RandomAmount(r, 10) + 1
 		commission := stake.NewCommissionMsg(
 			sdk.NewDecWithPrec(simulation.RandomAmount(r, maxCommission), 1),
-			sdk.NewDecWithPrec(simulation.RandomAmount(r, maxCommission), 1),
+			sdk.NewDecWithPrec(maxCommission, 1),
 			sdk.NewDecWithPrec(simulation.RandomAmount(r, maxCommission), 1),
 		)
 
 		acc := simulation.RandomAcc(r, accs)
 		address := sdk.ValAddress(acc.Address)
-		amount := m.GetAccount(ctx, acc.Address).GetCoins().AmountOf(denom)
-		if amount > 0 {
-			amount = simulation.RandomAmount(r, amount)
-		}
-
-		if amount == 0 {
+		amount, ok := randomDelegationAmount(r, m.GetAccount(ctx, acc.Address).GetCoins().AmountOf(denom))
+		if !ok {
 			return "no-operation", nil, nil
 		}
 
@@ -122,11 +129,8 @@ func SimulateMsgDelegate(m auth.AccountKeeper, k stake.Keeper) simulation.Operat
 		validatorAddress := sdk.ValAddress(validatorAcc.Address)
 		delegatorAcc := simulation.RandomAcc(r, accs)
 		delegatorAddress := delegatorAcc.Address
-		amount := m.GetAccount(ctx, delegatorAddress).GetCoins().AmountOf(denom)
-		if amount > 0 {
-			amount = simulation.RandomAmount(r, amount)
-		}
-		if amount == 0 {
+		amount, ok := randomDelegationAmount(r, m.GetAccount(ctx, delegatorAddress).GetCoins().AmountOf(denom))
+		if !ok {
 			return "no-operation", nil, nil
 		}
 		msg := stake.MsgDelegate{
@@ -198,6 +202,9 @@ func SimulateMsgBeginRedelegate(m auth.AccountKeeper, k stake.Keeper) simulation
 		sourceValidatorAddress := sdk.ValAddress(sourceValidatorAcc.Address)
 		destValidatorAcc := simulation.RandomAcc(r, accs)
 		destValidatorAddress := sdk.ValAddress(destValidatorAcc.Address)
+		if sourceValidatorAddress.Equals(destValidatorAddress) {
+			return "no-operation", nil, nil
+		}
 		delegatorAcc := simulation.RandomAcc(r, accs)
 		delegatorAddress := delegatorAcc.Address
 		// TODO
@@ -36,7 +36,7 @@ func TestStakeWithRandomMessages(t *testing.T) {
 	keySideChain := sdk.NewKVStoreKey("sc")
 	distrKey := sdk.NewKVStoreKey("distr")
 
-	feeCollectionKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, feeKey)
+	feeCollectionKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, feeKey, mapp.AccountKeeper)
 	paramstore := params.NewKeeper(mapp.Cdc, paramsKey, paramsTKey)
 	scKeeper := sidechain.NewKeeper(keySideChain, paramstore.Subspace(sidechain.DefaultParamspace), mapp.Cdc)
 	ibcKeeper := ibc.NewKeeper(ibcKey, paramstore.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
@@ -27,6 +27,7 @@ type (
 	MsgRedelegate              = types.MsgRedelegate
 	MsgUndelegate              = types.MsgUndelegate
 	GenesisState               = types.GenesisState
+	LastValidatorPower         = types.LastValidatorPower
 	QueryDelegatorParams       = querier.QueryDelegatorParams
 	QueryValidatorParams       = querier.QueryValidatorParams
 	QueryBondsParams           = querier.QueryBondsParams
@@ -138,11 +139,19 @@ var (
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
 
+	RegisterInvariants       = keeper.RegisterInvariants
+	BondedTokensInvariant    = keeper.BondedTokensInvariant
+	DelegatorSharesInvariant = keeper.DelegatorSharesInvariant
+
 	FeeCollectorAddr  = keeper.FeeCollectorAddr
 	DelegationAccAddr = keeper.DelegationAccAddr
 	FeeForAllAccAddr  = keeper.FeeForAllBcValsAccAddr
 )
 
+const (
+	DelegationAccName = keeper.DelegationAccName
+)
+
 const (
 	QueryValidators                    = querier.QueryValidators
 	QueryValidator                     = querier.QueryValidator
@@ -13,4 +13,9 @@ var (
 	Moniker      = "moniker"
 	Identity     = "identity"
 	EndTime      = "end-time"
+
+	// the new commission rate and the time it was changed, set when a
+	// validator changes its commission so that the delegators can follow it
+	CommissionRate       = "commission-rate"
+	CommissionUpdateTime = "commission-update-time"
 )
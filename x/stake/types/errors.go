@@ -31,6 +31,26 @@ const (
 	CodeUnknownRequest               CodeType = sdk.CodeUnknownRequest
 )
 
+// The registered errors of the codes of the stake codespace.
+var (
+	ErrCodeInvalidValidator             = sdk.Register(DefaultCodespace, CodeInvalidValidator, "invalid validator")
+	ErrCodeInvalidDelegation            = sdk.Register(DefaultCodespace, CodeInvalidDelegation, "invalid delegation")
+	ErrCodeInvalidInput                 = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeValidatorJailed              = sdk.Register(DefaultCodespace, CodeValidatorJailed, "validator jailed")
+	ErrCodeInvalidProposal              = sdk.Register(DefaultCodespace, CodeInvalidProposal, "invalid proposal")
+	ErrCodeInvalidSideChain             = sdk.Register(DefaultCodespace, CodeInvalidSideChain, "invalid side chain")
+	ErrCodeInvalidCrossChainPackage     = sdk.Register(DefaultCodespace, CodeInvalidCrossChainPackage, "invalid cross chain package")
+	ErrCodeDeserializePackageFailed     = sdk.Register(DefaultCodespace, CodeDeserializePackageFailed, "deserialize package failed")
+	ErrCodeExpiredCrossStakeSyncPackage = sdk.Register(DefaultCodespace, CodeExpiredCrossStakeSyncPackage, "expired cross stake sync package")
+	ErrCodeCrossStakingNoBalance        = sdk.Register(DefaultCodespace, CodeCrossStakingNoBalance, "cross staking no balance")
+	ErrCodeCrossStakingNotEnoughBalance = sdk.Register(DefaultCodespace, CodeCrossStakingNotEnoughBalance, "cross staking not enough balance")
+	ErrCodeInvalidConsAddrUpdateTime    = sdk.Register(DefaultCodespace, CodeInvalidConsAddrUpdateTime, "invalid consensus address update time")
+	ErrCodeInvalidAddress               = sdk.Register(DefaultCodespace, CodeInvalidAddress, "invalid address")
+	ErrCodeUnauthorized                 = sdk.Register(DefaultCodespace, CodeUnauthorized, "unauthorized")
+	ErrCodeInternal                     = sdk.Register(DefaultCodespace, CodeInternal, "internal error")
+	ErrCodeUnknownRequest               = sdk.Register(DefaultCodespace, CodeUnknownRequest, "unknown request")
+)
+
 // validator
 func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "validator address is nil")
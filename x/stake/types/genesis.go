@@ -1,11 +1,26 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 // GenesisState - all staking state that must be provided at genesis
 type GenesisState struct {
-	Pool       Pool         `json:"pool"`
-	Params     Params       `json:"params"`
-	Validators []Validator  `json:"validators"`
-	Bonds      []Delegation `json:"bonds"`
+	Pool                 Pool                  `json:"pool"`
+	Params               Params                `json:"params"`
+	LastTotalPower       int64                 `json:"last_total_power"`
+	LastValidatorPowers  []LastValidatorPower  `json:"last_validator_powers"`
+	Validators           []Validator           `json:"validators"`
+	Bonds                []Delegation          `json:"bonds"`
+	UnbondingDelegations []UnbondingDelegation `json:"unbonding_delegations"`
+	Redelegations        []Redelegation        `json:"redelegations"`
+	Exported             bool                  `json:"exported"` // whether the state was exported from a running chain
+}
+
+// Last validator power, needed for validator set update logic
+type LastValidatorPower struct {
+	Address sdk.ValAddress `json:"address"`
+	Power   int64          `json:"power"`
 }
 
 func NewGenesisState(pool Pool, params Params, validators []Validator, bonds []Delegation) GenesisState {
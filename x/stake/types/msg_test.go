@@ -168,6 +168,7 @@ func TestMsgBeginRedelegate(t *testing.T) {
 		{"empty delegator", sdk.AccAddress(emptyAddr), addr1, addr3, sdk.NewDecWithPrec(1, 1), false},
 		{"empty source validator", sdk.AccAddress(addr1), emptyAddr, addr3, sdk.NewDecWithPrec(1, 1), false},
 		{"empty destination validator", sdk.AccAddress(addr1), addr2, emptyAddr, sdk.NewDecWithPrec(1, 1), false},
+		{"same validators", sdk.AccAddress(addr1), addr2, addr2, sdk.NewDecWithPrec(1, 1), false},
 	}
 
 	for _, tc := range tests {
@@ -46,6 +46,7 @@ var (
 	KeyBaseProposerRewardRatio     = []byte("BaseProposerRewardRatio")
 	KeyBonusProposerRewardRatio    = []byte("BonusProposerRewardRatio")
 	KeyFeeFromBscToBcRatio         = []byte("FeeFromBscToBcRatio")
+	KeyMaxValidatorUpdatesPerBlock = []byte("MaxValidatorUpdatesPerBlock")
 )
 
 var _ params.ParamSet = (*Params)(nil)
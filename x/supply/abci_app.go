@@ -0,0 +1,13 @@
+package supply
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker seeds the total supply at the height of the SupplyTracking
+// upgrade, the minted and burned coins are tracked from then on.
+func BeginBlocker(ctx sdk.Context, keeper Keeper) {
+	if sdk.IsUpgradeHeight(sdk.SupplyTracking) {
+		keeper.SeedTotalSupply(ctx)
+	}
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+// GetCmdQuerySupply implements the command to query the total and circulating
+// supply of a denom, or of all the denoms.
+func GetCmdQuerySupply(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "supply [denom]",
+		Short: "Query the total and circulating supply of a denom, or of all the denoms",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			path, bz := fmt.Sprintf("custom/%s/%s", queryRoute, supply.QuerySupplies), []byte(nil)
+			if len(args) == 1 {
+				var err error
+				bz, err = cdc.MarshalJSON(supply.QuerySupplyParams{Denom: args[0]})
+				if err != nil {
+					return err
+				}
+				path = fmt.Sprintf("custom/%s/%s", queryRoute, supply.QuerySupply)
+			}
+
+			res, err := cliCtx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,13 @@
+package supply
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// InitGenesis sets the total supply of the chain, i.e. the coins of all the
+// genesis accounts.
+func InitGenesis(ctx sdk.Context, keeper Keeper, total sdk.Coins) {
+	for _, coin := range total {
+		keeper.SetTotal(ctx, coin.Denom, coin.Amount)
+	}
+}
@@ -0,0 +1,36 @@
+package supply
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the supply module.
+func RegisterInvariants(ir sdk.InvariantRouter, k Keeper) {
+	ir.RegisterRoute("supply", "total-supply", TotalSupplyInvariant(k))
+}
+
+// TotalSupplyInvariant checks that the coins of all the accounts are the
+// total supply, i.e. that the coins are only created and destroyed by Mint
+// and Burn. The collected fees, the delegations, the deposits and the escrows
+// are held by module accounts, and the distribution rewards are only coins
+// once paid, so all the coins are in the accounts once the supply is tracked.
+func TotalSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		if !sdk.IsUpgrade(sdk.SupplyTracking) {
+			return nil
+		}
+
+		var coins sdk.Coins
+		k.am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+			coins = coins.Plus(acc.GetCoins())
+			return false
+		})
+
+		if total := k.GetTotalSupply(ctx); !coins.IsEqual(total) {
+			return fmt.Errorf("coins of the accounts %s != total supply %s", coins, total)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,218 @@
+package supply
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var (
+	// the total supply of each denom is stored under SupplyKeyPrefix + denom
+	SupplyKeyPrefix = []byte("supply:")
+)
+
+// GetSupplyKey returns the key of the total supply of denom.
+func GetSupplyKey(denom string) []byte {
+	return append(SupplyKeyPrefix, []byte(denom)...)
+}
+
+// Supply is the total and the circulating supply of a denom. The coins locked
+// in the peg account for the cross chain transfers are not circulating.
+type Supply struct {
+	Denom       string `json:"denom"`
+	Total       int64  `json:"total"`
+	Circulating int64  `json:"circulating"`
+}
+
+func (s Supply) String() string {
+	return fmt.Sprintf("%s: total %d, circulating %d", s.Denom, s.Total, s.Circulating)
+}
+
+// Keeper tracks the total supply of each denom, the modules minting or burning
+// coins must report them to it.
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	am       auth.AccountKeeper
+}
+
+// NewKeeper returns a supply keeper.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, am auth.AccountKeeper) Keeper {
+	return Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		am:       am,
+	}
+}
+
+// GetTotal returns the total supply of denom.
+func (k Keeper) GetTotal(ctx sdk.Context, denom string) int64 {
+	bz := ctx.KVStore(k.storeKey).Get(GetSupplyKey(denom))
+	if bz == nil {
+		return 0
+	}
+	var total int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &total)
+	return total
+}
+
+// SetTotal sets the total supply of denom, a zero supply is removed.
+func (k Keeper) SetTotal(ctx sdk.Context, denom string, total int64) {
+	store := ctx.KVStore(k.storeKey)
+	if total == 0 {
+		store.Delete(GetSupplyKey(denom))
+		return
+	}
+	store.Set(GetSupplyKey(denom), k.cdc.MustMarshalBinaryLengthPrefixed(total))
+}
+
+// GetTotalSupply returns the total supply of all the denoms.
+func (k Keeper) GetTotalSupply(ctx sdk.Context) sdk.Coins {
+	var coins sdk.Coins
+	k.IterateTotalSupply(ctx, func(coin sdk.Coin) bool {
+		coins = append(coins, coin)
+		return false
+	})
+	return coins
+}
+
+// IterateTotalSupply iterates over the total supply of each denom, sorted by
+// denom, until iter returns true.
+func (k Keeper) IterateTotalSupply(ctx sdk.Context, iter func(coin sdk.Coin) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), SupplyKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var total int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &total)
+		denom := string(iterator.Key()[len(SupplyKeyPrefix):])
+		if iter(sdk.NewCoin(denom, total)) {
+			return
+		}
+	}
+}
+
+// SeedTotalSupply sets the total supply to the coins of all the accounts. It
+// runs at the height of the SupplyTracking upgrade, the coins minted or burned
+// before it were not reported.
+func (k Keeper) SeedTotalSupply(ctx sdk.Context) {
+	for _, coin := range k.GetTotalSupply(ctx) {
+		k.SetTotal(ctx, coin.Denom, 0)
+	}
+
+	var coins sdk.Coins
+	k.am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+		coins = coins.Plus(acc.GetCoins())
+		return false
+	})
+	for _, coin := range coins {
+		k.SetTotal(ctx, coin.Denom, coin.Amount)
+	}
+}
+
+// Mint adds the newly created coins to the total supply. The supply is not
+// tracked before the SupplyTracking upgrade.
+func (k Keeper) Mint(ctx sdk.Context, coins sdk.Coins) sdk.Error {
+	if !coins.IsValid() {
+		return sdk.ErrInvalidCoins(coins.String())
+	}
+	if !sdk.IsUpgrade(sdk.SupplyTracking) {
+		return nil
+	}
+	for _, coin := range coins {
+		total := k.GetTotal(ctx, coin.Denom)
+		if total+coin.Amount < total {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("total supply of %s overflows", coin.Denom))
+		}
+	}
+	for _, coin := range coins {
+		k.SetTotal(ctx, coin.Denom, k.GetTotal(ctx, coin.Denom)+coin.Amount)
+	}
+	return nil
+}
+
+// Burn removes the destroyed coins from the total supply. The supply is not
+// tracked before the SupplyTracking upgrade.
+func (k Keeper) Burn(ctx sdk.Context, coins sdk.Coins) sdk.Error {
+	if !coins.IsValid() {
+		return sdk.ErrInvalidCoins(coins.String())
+	}
+	if !sdk.IsUpgrade(sdk.SupplyTracking) {
+		return nil
+	}
+	for _, coin := range coins {
+		if total := k.GetTotal(ctx, coin.Denom); total < coin.Amount {
+			return sdk.ErrInsufficientCoins(fmt.Sprintf("total supply of %s is %d < %d", coin.Denom, total, coin.Amount))
+		}
+	}
+	for _, coin := range coins {
+		k.SetTotal(ctx, coin.Denom, k.GetTotal(ctx, coin.Denom)-coin.Amount)
+	}
+	return nil
+}
+
+// BurnCoins burns coins of the module account name, which must have the
+// burner permission.
+func (k Keeper) BurnCoins(ctx sdk.Context, name string, coins sdk.Coins) sdk.Error {
+	acc, ok := k.am.GetAccount(ctx, auth.NewModuleAddress(name)).(*auth.ModuleAccount)
+	if !ok || !acc.HasPermission(auth.Burner) {
+		return sdk.ErrUnauthorized(fmt.Sprintf("module account %s can't burn coins", name))
+	}
+	newCoins := acc.GetCoins().Minus(coins)
+	if !newCoins.IsNotNegative() {
+		return sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), coins))
+	}
+	if err := k.Burn(ctx, coins); err != nil {
+		return err
+	}
+	if err := acc.SetCoins(newCoins); err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
+	k.am.SetAccount(ctx, acc)
+	return nil
+}
+
+// GetLocked returns the coins locked in the peg account, i.e. transferred to
+// the other chains.
+func (k Keeper) GetLocked(ctx sdk.Context) sdk.Coins {
+	acc := k.am.GetAccount(ctx, sdk.PegAccount)
+	if acc == nil {
+		return nil
+	}
+	return acc.GetCoins()
+}
+
+// GetSupply returns the total and circulating supply of denom. The cross chain
+// transfers move the coins in and out of the peg account, so the circulating
+// supply follows them without being stored.
+func (k Keeper) GetSupply(ctx sdk.Context, denom string) Supply {
+	total := k.GetTotal(ctx, denom)
+	return Supply{
+		Denom:       denom,
+		Total:       total,
+		Circulating: circulating(total, k.GetLocked(ctx).AmountOf(denom)),
+	}
+}
+
+// GetSupplies returns the supply of all the denoms.
+func (k Keeper) GetSupplies(ctx sdk.Context) []Supply {
+	locked := k.GetLocked(ctx)
+	supplies := make([]Supply, 0)
+	k.IterateTotalSupply(ctx, func(coin sdk.Coin) bool {
+		supplies = append(supplies, Supply{
+			Denom:       coin.Denom,
+			Total:       coin.Amount,
+			Circulating: circulating(coin.Amount, locked.AmountOf(coin.Denom)),
+		})
+		return false
+	})
+	return supplies
+}
+
+func circulating(total, locked int64) int64 {
+	if locked > total {
+		return 0
+	}
+	return total - locked
+}
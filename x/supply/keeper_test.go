@@ -0,0 +1,109 @@
+package supply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySupply := sdk.NewKVStoreKey("supply")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	am := auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount)
+	return ctx, am, NewKeeper(cdc, keySupply, am)
+}
+
+func TestKeeperMintBurn(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, _, keeper := createTestInput(t)
+
+	InitGenesis(ctx, keeper, sdk.Coins{sdk.NewCoin("BNB", 100)})
+	require.NoError(t, keeper.Mint(ctx, sdk.Coins{sdk.NewCoin("BNB", 50), sdk.NewCoin("XYZ", 10)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 150), sdk.NewCoin("XYZ", 10)}, keeper.GetTotalSupply(ctx))
+
+	// burning more than the supply fails without burning anything
+	require.Error(t, keeper.Burn(ctx, sdk.Coins{sdk.NewCoin("BNB", 10), sdk.NewCoin("XYZ", 11)}))
+	require.Equal(t, int64(150), keeper.GetTotal(ctx, "BNB"))
+	require.NoError(t, keeper.Burn(ctx, sdk.Coins{sdk.NewCoin("BNB", 10), sdk.NewCoin("XYZ", 10)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 140)}, keeper.GetTotalSupply(ctx))
+
+	require.Error(t, keeper.Mint(ctx, sdk.Coins{sdk.NewCoin("BNB", -1)}))
+}
+
+func TestKeeperCirculatingSupply(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, am, keeper := createTestInput(t)
+	require.NoError(t, keeper.Mint(ctx, sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("XYZ", 10)}))
+	require.Equal(t, Supply{Denom: "BNB", Total: 100, Circulating: 100}, keeper.GetSupply(ctx, "BNB"))
+
+	// the coins transferred to the other chains are locked in the peg account
+	peg := am.NewAccountWithAddress(ctx, sdk.PegAccount)
+	require.NoError(t, peg.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 30)}))
+	am.SetAccount(ctx, peg)
+	require.Equal(t, Supply{Denom: "BNB", Total: 100, Circulating: 70}, keeper.GetSupply(ctx, "BNB"))
+	require.Equal(t, []Supply{
+		{Denom: "BNB", Total: 100, Circulating: 70},
+		{Denom: "XYZ", Total: 10, Circulating: 10},
+	}, keeper.GetSupplies(ctx))
+
+	querier := NewQuerier(keeper)
+	bz, err := querier(ctx, []string{QuerySupply}, abci.RequestQuery{Data: []byte(`{"Denom":"BNB"}`)})
+	require.Nil(t, err)
+	var supply Supply
+	require.NoError(t, keeper.cdc.UnmarshalJSON(bz, &supply))
+	require.Equal(t, int64(70), supply.Circulating)
+	_, err = querier(ctx, []string{QuerySupply}, abci.RequestQuery{Data: []byte(`{}`)})
+	require.NotNil(t, err)
+}
+
+func TestKeeperSupplyTracking(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 10)
+	sdk.UpgradeMgr.SetHeight(9)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, am, keeper := createTestInput(t)
+
+	// the coins minted or burned before the upgrade are not tracked
+	InitGenesis(ctx, keeper, sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("OLD", 5)})
+	require.NoError(t, keeper.Mint(ctx, sdk.Coins{sdk.NewCoin("BNB", 50)}))
+	require.NoError(t, keeper.Burn(ctx, sdk.Coins{sdk.NewCoin("BNB", 500)}))
+	require.Equal(t, int64(100), keeper.GetTotal(ctx, "BNB"))
+
+	acc := am.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("addr1")))
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 150), sdk.NewCoin("XYZ", 10)}))
+	am.SetAccount(ctx, acc)
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, int64(100), keeper.GetTotal(ctx, "BNB"))
+
+	// the total supply is seeded from the accounts at the upgrade height
+	sdk.UpgradeMgr.SetHeight(10)
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 150), sdk.NewCoin("XYZ", 10)}, keeper.GetTotalSupply(ctx))
+	require.NoError(t, TotalSupplyInvariant(keeper)(ctx))
+
+	require.NoError(t, keeper.Mint(ctx, sdk.Coins{sdk.NewCoin("BNB", 50)}))
+	require.Equal(t, int64(200), keeper.GetTotal(ctx, "BNB"))
+}
@@ -0,0 +1,47 @@
+package supply
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the supply Querier
+const (
+	QuerySupply   = "supply"
+	QuerySupplies = "supplies"
+)
+
+// Params for query 'custom/supply/supply'
+type QuerySupplyParams struct {
+	Denom string
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QuerySupply:
+			var params QuerySupplyParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			if len(params.Denom) == 0 {
+				return nil, sdk.ErrUnknownRequest("denom is missing")
+			}
+			return marshalJSON(keeper.cdc, keeper.GetSupply(ctx, params.Denom))
+		case QuerySupplies:
+			return marshalJSON(keeper.cdc, keeper.GetSupplies(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown supply query endpoint")
+		}
+	}
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,37 @@
+package swap
+
+import (
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	EventTypeSwapExpired = "swap_expired"
+
+	TagSwapID           = "swap_id"
+	TagRandomNumberHash = "random_number_hash"
+)
+
+// BeginBlocker refunds the open swaps expired by the height of the block, up
+// to the max refunds per block of the keeper, before the txs of the block may
+// claim them. The swaps left for the next blocks may be refunded by a msg.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	var expired [][]byte
+	k.IterateExpiredSwaps(ctx, ctx.BlockHeight(), func(swapID []byte, _ AtomicSwap) bool {
+		expired = append(expired, swapID)
+		return len(expired) >= k.maxRefundsPerBlock
+	})
+	for _, swapID := range expired {
+		// the module account holds the coins of all the open swaps
+		swap, err := k.RefundSwap(ctx, swapID)
+		if err != nil {
+			panic(fmt.Sprintf("failed to refund swap %X: %s", swapID, err))
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeSwapExpired,
+			sdk.NewAttribute(TagSwapID, cmn.HexBytes(swapID).String()),
+			sdk.NewAttribute(TagRandomNumberHash, swap.RandomNumberHash.String())))
+	}
+}
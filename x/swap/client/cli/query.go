@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+)
+
+const (
+	flagOffset = "offset"
+	flagLimit  = "limit"
+)
+
+// GetCmdQuerySwap implements the command to query an atomic swap by id.
+func GetCmdQuerySwap(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swap [swap-id]",
+		Short: "Query an atomic swap",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			swapID, err := parseHex(args[0], "swap id")
+			if err != nil {
+				return err
+			}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, swap.QuerySwapByID), swap.QuerySwapByIDParams{SwapID: swapID})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQuerySwapsByCreator implements the command to query the ids of the
+// atomic swaps created by an account.
+func GetCmdQuerySwapsByCreator(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return swapsByAddressCmd(queryRoute, swap.QuerySwapByCreator, "swaps-by-creator", "created by", cdc)
+}
+
+// GetCmdQuerySwapsByRecipient implements the command to query the ids of the
+// atomic swaps to an account.
+func GetCmdQuerySwapsByRecipient(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return swapsByAddressCmd(queryRoute, swap.QuerySwapByRecipient, "swaps-by-recipient", "to", cdc)
+}
+
+func swapsByAddressCmd(queryRoute, endpoint, use, relation string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [address]",
+		Short: fmt.Sprintf("Query the ids of the atomic swaps %s an account", relation),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			params := swap.QuerySwapByAddressParams{Address: addr, Offset: viper.GetInt(flagOffset), Limit: viper.GetInt(flagLimit)}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), params)
+		},
+	}
+
+	cmd.Flags().Int(flagOffset, 0, "number of swap ids to skip")
+	cmd.Flags().Int(flagLimit, swap.DefaultLimit, "max number of swap ids")
+	return cmd
+}
+
+// GetCmdQuerySwapsByRandomNumberHash implements the command to query the
+// atomic swaps locked by a random number hash.
+func GetCmdQuerySwapsByRandomNumberHash(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swaps-by-random-number-hash [random-number-hash]",
+		Short: "Query the atomic swaps locked by a random number hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			randomNumberHash, err := parseHex(args[0], "random number hash")
+			if err != nil {
+				return err
+			}
+			params := swap.QuerySwapByRandomNumberHashParams{RandomNumberHash: randomNumberHash}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, swap.QuerySwapByRandomNumberHash), params)
+		},
+	}
+
+	return cmd
+}
+
+func query(cdc *codec.Codec, path string, params interface{}) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+)
+
+const (
+	flagRecipient           = "recipient"
+	flagRecipientOtherChain = "recipient-other-chain"
+	flagSenderOtherChain    = "sender-other-chain"
+	flagRandomNumberHash    = "random-number-hash"
+	flagTimestamp           = "timestamp"
+	flagAmount              = "amount"
+	flagExpectedIncome      = "expected-income"
+	flagHeightSpan          = "height-span"
+	flagCrossChain          = "cross-chain"
+)
+
+// GetCmdHTLT implements the command creating an atomic swap. Without a random
+// number hash, a random number is generated and printed, it must be kept
+// secret until the swap is claimed.
+func GetCmdHTLT(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "HTLT",
+		Args:  cobra.NoArgs,
+		Short: "create a hash timer locked transfer for an atomic swap",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			to, err := sdk.AccAddressFromBech32(viper.GetString(flagRecipient))
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseUserCoins(viper.GetString(flagAmount))
+			if err != nil {
+				return err
+			}
+			timestamp := viper.GetInt64(flagTimestamp)
+			if timestamp == 0 {
+				timestamp = time.Now().Unix()
+			}
+
+			var randomNumberHash []byte
+			if hashStr := viper.GetString(flagRandomNumberHash); hashStr != "" {
+				if randomNumberHash, err = hex.DecodeString(hashStr); err != nil {
+					return errors.Wrap(err, "random number hash must be hex encoded")
+				}
+			} else {
+				randomNumber := make([]byte, swap.RandomNumberLength)
+				if _, err := rand.Read(randomNumber); err != nil {
+					return err
+				}
+				randomNumberHash = swap.CalculateRandomHash(randomNumber, timestamp)
+				fmt.Printf("Random number: %X\nTimestamp: %d\nRandom number hash: %X\n", randomNumber, timestamp, randomNumberHash)
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return swap.NewMsgHTLT(from, to, viper.GetString(flagRecipientOtherChain), viper.GetString(flagSenderOtherChain),
+					randomNumberHash, timestamp, amount, viper.GetString(flagExpectedIncome), viper.GetInt64(flagHeightSpan),
+					viper.GetBool(flagCrossChain))
+			})
+		},
+	}
+
+	cmd.Flags().String(flagRecipient, "", "recipient of the swapped coins")
+	cmd.Flags().String(flagRecipientOtherChain, "", "address of the sender on the other chain, receiving the coins of the matching swap")
+	cmd.Flags().String(flagSenderOtherChain, "", "address of the recipient on the other chain, sending the coins of the matching swap")
+	cmd.Flags().String(flagRandomNumberHash, "", "hex encoded SHA256 of the random number and the timestamp, generated if empty")
+	cmd.Flags().Int64(flagTimestamp, 0, "unix timestamp hashed with the random number, now if zero")
+	cmd.Flags().String(flagAmount, "", "coins locked in the swap")
+	cmd.Flags().String(flagExpectedIncome, "", "coins expected in exchange, deposited by the recipient of a single chain swap")
+	cmd.Flags().Int64(flagHeightSpan, swap.MinimumHeightSpan, "number of blocks before the swap expires")
+	cmd.Flags().Bool(flagCrossChain, false, "whether the swap is matched by a swap on the other chain")
+	return cmd
+}
+
+// GetCmdDepositHTLT implements the command depositing the expected income of
+// a single chain swap.
+func GetCmdDepositHTLT(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit [swap-id] [amount]",
+		Args:  cobra.ExactArgs(2),
+		Short: "deposit the expected income of a single chain swap",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			swapID, err := parseHex(args[0], "swap id")
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseUserCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return swap.NewMsgDepositHTLT(from, swapID, amount)
+			})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdClaimHTLT implements the command claiming a swap with its random
+// number.
+func GetCmdClaimHTLT(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim [swap-id] [random-number]",
+		Args:  cobra.ExactArgs(2),
+		Short: "claim an atomic swap with its random number",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			swapID, err := parseHex(args[0], "swap id")
+			if err != nil {
+				return err
+			}
+			randomNumber, err := parseHex(args[1], "random number")
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return swap.NewMsgClaimHTLT(from, swapID, randomNumber)
+			})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdRefundHTLT implements the command refunding an expired swap.
+func GetCmdRefundHTLT(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refund [swap-id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "refund an expired atomic swap",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			swapID, err := parseHex(args[0], "swap id")
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return swap.NewMsgRefundHTLT(from, swapID)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func parseHex(s, name string) ([]byte, error) {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s must be hex encoded", name)
+	}
+	return bz, nil
+}
+
+func completeAndBroadcast(cdc *codec.Codec, newMsg func(from sdk.AccAddress) sdk.Msg) error {
+	txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{newMsg(from)}
+	if cliCtx.GenerateOnly {
+		return utils.PrintUnsignedStdTx(txBldr, cliCtx, msgs)
+	}
+	return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, msgs)
+}
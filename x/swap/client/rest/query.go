@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/swap"
+)
+
+// RegisterRoutes registers the atomic swap REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
+	r.HandleFunc("/atomic_swap/swaps/id/{swapID}", swapHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/atomic_swap/swaps/creator/{address}", swapsByAddressHandlerFn(cliCtx, cdc, queryRoute, swap.QuerySwapByCreator)).Methods("GET")
+	r.HandleFunc("/atomic_swap/swaps/recipient/{address}", swapsByAddressHandlerFn(cliCtx, cdc, queryRoute, swap.QuerySwapByRecipient)).Methods("GET")
+	r.HandleFunc("/atomic_swap/swaps/random_number_hash/{hash}", swapsByHashHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+}
+
+// http request handler to query an atomic swap
+func swapHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		swapID, err := hex.DecodeString(mux.Vars(r)["swapID"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "swap id must be hex encoded")
+			return
+		}
+
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, swap.QuerySwapByID), swap.QuerySwapByIDParams{SwapID: swapID})
+	}
+}
+
+// http request handler to query the ids of the atomic swaps created by or to
+// an account, with the offset and limit query parameters
+func swapsByAddressHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute, endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr, err := sdk.AccAddressFromBech32(mux.Vars(r)["address"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params := swap.QuerySwapByAddressParams{Address: addr}
+		if offsetStr := r.FormValue("offset"); offsetStr != "" {
+			if params.Offset, err = strconv.Atoi(offsetStr); err != nil || params.Offset < 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "offset parameter is not a valid non negative integer")
+				return
+			}
+		}
+		if limitStr := r.FormValue("limit"); limitStr != "" {
+			if params.Limit, err = strconv.Atoi(limitStr); err != nil || params.Limit <= 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "limit parameter is not a valid positive integer")
+				return
+			}
+		}
+
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), params)
+	}
+}
+
+// http request handler to query the atomic swaps locked by a random number
+// hash
+func swapsByHashHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		randomNumberHash, err := hex.DecodeString(mux.Vars(r)["hash"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "random number hash must be hex encoded")
+			return
+		}
+
+		params := swap.QuerySwapByRandomNumberHashParams{RandomNumberHash: randomNumberHash}
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, swap.QuerySwapByRandomNumberHash), params)
+	}
+}
+
+func query(w http.ResponseWriter, cliCtx context.CLIContext, cdc *codec.Codec, path string, params interface{}) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
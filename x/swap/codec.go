@@ -0,0 +1,23 @@
+package swap
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Register concrete types on codec codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgHTLT{}, "cosmos-sdk/MsgHTLT", nil)
+	cdc.RegisterConcrete(MsgDepositHTLT{}, "cosmos-sdk/MsgDepositHTLT", nil)
+	cdc.RegisterConcrete(MsgClaimHTLT{}, "cosmos-sdk/MsgClaimHTLT", nil)
+	cdc.RegisterConcrete(MsgRefundHTLT{}, "cosmos-sdk/MsgRefundHTLT", nil)
+}
+
+// generic sealed codec to be used throughout sdk
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	MsgCdc = cdc.Seal()
+}
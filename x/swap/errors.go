@@ -0,0 +1,72 @@
+// nolint
+package swap
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// Default swap codespace
+	DefaultCodespace sdk.CodespaceType = 35
+
+	CodeInvalidInput        sdk.CodeType = 101
+	CodeInvalidTimestamp    sdk.CodeType = 102
+	CodeDuplicatedSwapID    sdk.CodeType = 103
+	CodeUnknownSwap         sdk.CodeType = 104
+	CodeSwapNotOpen         sdk.CodeType = 105
+	CodeSwapExpired         sdk.CodeType = 106
+	CodeSwapNotExpired      sdk.CodeType = 107
+	CodeInvalidRandomNumber sdk.CodeType = 108
+	CodeInvalidDeposit      sdk.CodeType = 109
+)
+
+// The registered errors of the codes of the swap codespace.
+var (
+	ErrCodeInvalidInput        = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeInvalidTimestamp    = sdk.Register(DefaultCodespace, CodeInvalidTimestamp, "invalid timestamp")
+	ErrCodeDuplicatedSwapID    = sdk.Register(DefaultCodespace, CodeDuplicatedSwapID, "duplicated swap id")
+	ErrCodeUnknownSwap         = sdk.Register(DefaultCodespace, CodeUnknownSwap, "unknown swap")
+	ErrCodeSwapNotOpen         = sdk.Register(DefaultCodespace, CodeSwapNotOpen, "swap not open")
+	ErrCodeSwapExpired         = sdk.Register(DefaultCodespace, CodeSwapExpired, "swap expired")
+	ErrCodeSwapNotExpired      = sdk.Register(DefaultCodespace, CodeSwapNotExpired, "swap not expired")
+	ErrCodeInvalidRandomNumber = sdk.Register(DefaultCodespace, CodeInvalidRandomNumber, "invalid random number")
+	ErrCodeInvalidDeposit      = sdk.Register(DefaultCodespace, CodeInvalidDeposit, "invalid deposit")
+)
+
+func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, msg)
+}
+
+func ErrInvalidTimestamp(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidTimestamp, msg)
+}
+
+func ErrDuplicatedSwapID(codespace sdk.CodespaceType, swapID []byte) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicatedSwapID, fmt.Sprintf("swap %X already exists", swapID))
+}
+
+func ErrUnknownSwap(codespace sdk.CodespaceType, swapID []byte) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownSwap, fmt.Sprintf("unknown swap %X", swapID))
+}
+
+func ErrSwapNotOpen(codespace sdk.CodespaceType, status SwapStatus) sdk.Error {
+	return sdk.NewError(codespace, CodeSwapNotOpen, fmt.Sprintf("swap is %s, not open", status))
+}
+
+func ErrSwapExpired(codespace sdk.CodespaceType, expireHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeSwapExpired, fmt.Sprintf("swap expired at height %d", expireHeight))
+}
+
+func ErrSwapNotExpired(codespace sdk.CodespaceType, expireHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeSwapNotExpired, fmt.Sprintf("swap cannot be refunded before height %d", expireHeight))
+}
+
+func ErrInvalidRandomNumber(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidRandomNumber, "the hash of the random number and the timestamp is not the random number hash of the swap")
+}
+
+func ErrInvalidDeposit(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDeposit, msg)
+}
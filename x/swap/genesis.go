@@ -0,0 +1,72 @@
+package swap
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState is the state of the swap module at genesis. The coins of the
+// open swaps are held by the AtomicSwapCoinsAccAddr account of the genesis
+// accounts.
+type GenesisState struct {
+	Swaps []SwapRecord `json:"swaps"`
+}
+
+// SwapRecord is the swap of id SwapID.
+type SwapRecord struct {
+	SwapID cmn.HexBytes `json:"swap_id"`
+	Swap   AtomicSwap   `json:"swap"`
+}
+
+// InitGenesis sets the swaps, indexes them and queues the open ones by
+// expire height.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, record := range data.Swaps {
+		keeper.setSwap(ctx, record.SwapID, record.Swap)
+	}
+}
+
+// WriteGenesis returns the swaps, sorted by id.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var data GenesisState
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), SwapKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var swap AtomicSwap
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &swap)
+		swapID := append([]byte{}, iterator.Key()[len(SwapKeyPrefix):]...)
+		data.Swaps = append(data.Swaps, SwapRecord{swapID, swap})
+	}
+	return data
+}
+
+// ValidateGenesis checks that the swaps are under the ids calculated from their
+// random number hashes and senders, and the open ones hold valid coins.
+func ValidateGenesis(data GenesisState) error {
+	ids := make(map[string]bool, len(data.Swaps))
+	for i, record := range data.Swaps {
+		swap := record.Swap
+		if ids[string(record.SwapID)] {
+			return fmt.Errorf("swaps[%d]: duplicate swap %s", i, record.SwapID)
+		}
+		if len(swap.RandomNumberHash) != RandomNumberHashLength {
+			return fmt.Errorf("swaps[%d]: random number hash of swap %s must be %d bytes", i, record.SwapID, RandomNumberHashLength)
+		}
+		if !bytes.Equal(record.SwapID, CalculateSwapID(swap.RandomNumberHash, swap.From, swap.SenderOtherChain)) {
+			return fmt.Errorf("swaps[%d]: swap id %s does not match its random number hash and sender", i, record.SwapID)
+		}
+		if swap.Status == Open && (swap.OutAmount.IsZero() || !swap.OutAmount.IsValid() || !swap.OutAmount.IsNotNegative() ||
+			!swap.InAmount.IsValid() || !swap.InAmount.IsNotNegative()) {
+			return fmt.Errorf("swaps[%d]: coins of the open swap %s must be valid and positive", i, record.SwapID)
+		}
+		if swap.Status != Open && swap.Status != Completed && swap.Status != Expired {
+			return fmt.Errorf("swaps[%d]: invalid status %v of swap %s", i, swap.Status, record.SwapID)
+		}
+		ids[string(record.SwapID)] = true
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package swap
+
+import (
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgHTLT:
+			return handleMsgHTLT(ctx, msg, k)
+		case MsgDepositHTLT:
+			return handleMsgDepositHTLT(ctx, msg, k)
+		case MsgClaimHTLT:
+			return handleMsgClaimHTLT(ctx, msg, k)
+		case MsgRefundHTLT:
+			return handleMsgRefundHTLT(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in atomic swap module").Result()
+		}
+	}
+}
+
+func handleMsgHTLT(ctx sdk.Context, msg MsgHTLT, k Keeper) sdk.Result {
+	swapID, err := k.CreateSwap(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	// the id of the swap is returned to its sender for the next msgs
+	return sdk.Result{
+		Data: swapID,
+		Tags: swapTags(swapID, msg.RandomNumberHash),
+	}
+}
+
+func handleMsgDepositHTLT(ctx sdk.Context, msg MsgDepositHTLT, k Keeper) sdk.Result {
+	swap, err := k.DepositSwap(ctx, msg.From, msg.SwapID, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: swapTags(msg.SwapID, swap.RandomNumberHash),
+	}
+}
+
+func handleMsgClaimHTLT(ctx sdk.Context, msg MsgClaimHTLT, k Keeper) sdk.Result {
+	swap, err := k.ClaimSwap(ctx, msg.SwapID, msg.RandomNumber)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: swapTags(msg.SwapID, swap.RandomNumberHash),
+	}
+}
+
+func handleMsgRefundHTLT(ctx sdk.Context, msg MsgRefundHTLT, k Keeper) sdk.Result {
+	swap, err := k.RefundSwap(ctx, msg.SwapID)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: swapTags(msg.SwapID, swap.RandomNumberHash),
+	}
+}
+
+// the swaps are tagged with their random number hash, so that the swap
+// matching one on the other chain is found in the tx search
+func swapTags(swapID, randomNumberHash []byte) sdk.Tags {
+	return sdk.NewTags(
+		TagSwapID, []byte(cmn.HexBytes(swapID).String()),
+		TagRandomNumberHash, []byte(cmn.HexBytes(randomNumberHash).String()),
+	)
+}
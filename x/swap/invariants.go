@@ -0,0 +1,45 @@
+package swap
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the swap module.
+func RegisterInvariants(ir sdk.InvariantRouter, k Keeper) {
+	ir.RegisterRoute("atomicSwap", "swap-coins", SwapCoinsInvariant(k))
+}
+
+// SwapCoinsInvariant checks that the module account holds the coins of all
+// the open swaps, and that all of them are in the expire queue.
+func SwapCoinsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var locked sdk.Coins
+		var open int
+		iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), SwapKeyPrefix)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var swap AtomicSwap
+			k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &swap)
+			if swap.Status == Open {
+				locked = locked.Plus(swap.OutAmount).Plus(swap.InAmount)
+				open++
+			}
+		}
+
+		if coins := k.ck.GetCoins(ctx, AtomicSwapCoinsAccAddr); !coins.IsEqual(locked) {
+			return fmt.Errorf("coins of the atomic swap account %s != coins of the open swaps %s", coins, locked)
+		}
+		var queued int
+		queue := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), ExpireQueueKeyPrefix)
+		defer queue.Close()
+		for ; queue.Valid(); queue.Next() {
+			queued++
+		}
+		if queued != open {
+			return fmt.Errorf("%d swaps in the expire queue != %d open swaps", queued, open)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,296 @@
+package swap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	// AtomicSwapCoinsAccName is the module account holding the coins of the
+	// open swaps.
+	AtomicSwapCoinsAccName = "atomicswap"
+
+	// DefaultMaxRefundsPerBlock is the default number of expired swaps the
+	// begin blocker refunds per block, the others wait for the next blocks or
+	// are refunded by a msg.
+	DefaultMaxRefundsPerBlock = 100
+)
+
+var (
+	AtomicSwapCoinsAccAddr = auth.NewModuleAddress(AtomicSwapCoinsAccName)
+
+	SwapKeyPrefix           = []byte("swap:")      // swap id -> AtomicSwap
+	CreatorIndexKeyPrefix   = []byte("creator:")   // creator, swap id -> nil
+	RecipientIndexKeyPrefix = []byte("recipient:") // recipient, swap id -> nil
+	HashIndexKeyPrefix      = []byte("hash:")      // random number hash, swap id -> nil
+	ExpireQueueKeyPrefix    = []byte("expire:")    // expire height, swap id -> nil, for the open swaps
+)
+
+// GetSwapKey returns the key of the swap swapID.
+func GetSwapKey(swapID []byte) []byte {
+	return append(append([]byte{}, SwapKeyPrefix...), swapID...)
+}
+
+// GetCreatorIndexKey returns the prefix of the swaps created by addr.
+func GetCreatorIndexKey(addr sdk.AccAddress) []byte {
+	return append(append([]byte{}, CreatorIndexKeyPrefix...), addr...)
+}
+
+// GetRecipientIndexKey returns the prefix of the swaps to addr.
+func GetRecipientIndexKey(addr sdk.AccAddress) []byte {
+	return append(append([]byte{}, RecipientIndexKeyPrefix...), addr...)
+}
+
+// GetHashIndexKey returns the prefix of the swaps locked by randomNumberHash.
+func GetHashIndexKey(randomNumberHash []byte) []byte {
+	return append(append([]byte{}, HashIndexKeyPrefix...), randomNumberHash...)
+}
+
+// GetExpireQueueKey returns the prefix of the open swaps expiring at height.
+func GetExpireQueueKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(append([]byte{}, ExpireQueueKeyPrefix...), bz...)
+}
+
+// Keeper of the atomic swaps, the coins of the open swaps are held by the
+// AtomicSwapCoinsAccAddr module account.
+type Keeper struct {
+	storeKey           sdk.StoreKey
+	cdc                *codec.Codec
+	ck                 bank.Keeper
+	maxRefundsPerBlock int
+	codespace          sdk.CodespaceType
+}
+
+// NewKeeper returns a swap keeper refunding up to maxRefundsPerBlock expired
+// swaps per block, DefaultMaxRefundsPerBlock if it is not positive.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ck bank.Keeper, maxRefundsPerBlock int, codespace sdk.CodespaceType) Keeper {
+	if maxRefundsPerBlock <= 0 {
+		maxRefundsPerBlock = DefaultMaxRefundsPerBlock
+	}
+	return Keeper{
+		storeKey:           key,
+		cdc:                cdc,
+		ck:                 ck,
+		maxRefundsPerBlock: maxRefundsPerBlock,
+		codespace:          codespace,
+	}
+}
+
+// GetSwap returns the swap swapID.
+func (k Keeper) GetSwap(ctx sdk.Context, swapID []byte) (swap AtomicSwap, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetSwapKey(swapID))
+	if bz == nil {
+		return swap, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &swap)
+	return swap, true
+}
+
+// setSwap stores the swap and indexes a new one.
+func (k Keeper) setSwap(ctx sdk.Context, swapID []byte, swap AtomicSwap) {
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(GetSwapKey(swapID)) {
+		store.Set(append(GetCreatorIndexKey(swap.From), swapID...), []byte{})
+		store.Set(append(GetRecipientIndexKey(swap.To), swapID...), []byte{})
+		store.Set(append(GetHashIndexKey(swap.RandomNumberHash), swapID...), []byte{})
+	}
+	if swap.Status == Open {
+		store.Set(append(GetExpireQueueKey(swap.ExpireHeight), swapID...), []byte{})
+	} else {
+		store.Delete(append(GetExpireQueueKey(swap.ExpireHeight), swapID...))
+	}
+	store.Set(GetSwapKey(swapID), k.cdc.MustMarshalBinaryLengthPrefixed(swap))
+}
+
+// GetSwapIDsByCreator returns the ids of the swaps created by addr, skipping
+// the offset first ones, at most limit of them.
+func (k Keeper) GetSwapIDsByCreator(ctx sdk.Context, addr sdk.AccAddress, offset, limit int) [][]byte {
+	return k.getIndexedSwapIDs(ctx, GetCreatorIndexKey(addr), offset, limit)
+}
+
+// GetSwapIDsByRecipient returns the ids of the swaps to addr, skipping the
+// offset first ones, at most limit of them.
+func (k Keeper) GetSwapIDsByRecipient(ctx sdk.Context, addr sdk.AccAddress, offset, limit int) [][]byte {
+	return k.getIndexedSwapIDs(ctx, GetRecipientIndexKey(addr), offset, limit)
+}
+
+// GetSwapIDsByRandomNumberHash returns the ids of the swaps locked by
+// randomNumberHash, skipping the offset first ones, at most limit of them.
+func (k Keeper) GetSwapIDsByRandomNumberHash(ctx sdk.Context, randomNumberHash []byte, offset, limit int) [][]byte {
+	return k.getIndexedSwapIDs(ctx, GetHashIndexKey(randomNumberHash), offset, limit)
+}
+
+func (k Keeper) getIndexedSwapIDs(ctx sdk.Context, prefix []byte, offset, limit int) [][]byte {
+	swapIDs := make([][]byte, 0)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for i := 0; iterator.Valid() && len(swapIDs) < limit; iterator.Next() {
+		if i++; i <= offset {
+			continue
+		}
+		swapIDs = append(swapIDs, iterator.Key()[len(prefix):])
+	}
+	return swapIDs
+}
+
+// IterateExpiredSwaps iterates over the open swaps expired by height, sorted
+// by expire height, until iter returns true.
+func (k Keeper) IterateExpiredSwaps(ctx sdk.Context, height int64, iter func(swapID []byte, swap AtomicSwap) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(ExpireQueueKeyPrefix, GetExpireQueueKey(height+1))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		swapID := iterator.Key()[len(ExpireQueueKeyPrefix)+8:]
+		swap, found := k.GetSwap(ctx, swapID)
+		if !found {
+			panic(fmt.Sprintf("the expire queue has the unknown swap %X", swapID))
+		}
+		if iter(swapID, swap) {
+			return
+		}
+	}
+}
+
+// CreateSwap locks the coins of msg.From in a new open swap and returns its
+// id.
+func (k Keeper) CreateSwap(ctx sdk.Context, msg MsgHTLT) ([]byte, sdk.Error) {
+	blockTime := ctx.BlockHeader().Time.Unix()
+	if msg.Timestamp < blockTime-MaxTimestampPast || msg.Timestamp > blockTime+MaxTimestampFuture {
+		return nil, ErrInvalidTimestamp(k.codespace, fmt.Sprintf("timestamp must be within %d seconds before and %d seconds after the block time", MaxTimestampPast, MaxTimestampFuture))
+	}
+	swapID := CalculateSwapID(msg.RandomNumberHash, msg.From, msg.SenderOtherChain)
+	if _, found := k.GetSwap(ctx, swapID); found {
+		return nil, ErrDuplicatedSwapID(k.codespace, swapID)
+	}
+	if _, err := k.ck.SendCoins(ctx, msg.From, AtomicSwapCoinsAccAddr, msg.Amount); err != nil {
+		return nil, err
+	}
+
+	k.setSwap(ctx, swapID, AtomicSwap{
+		From:                msg.From,
+		To:                  msg.To,
+		OutAmount:           msg.Amount,
+		ExpectedIncome:      msg.ExpectedIncome,
+		RecipientOtherChain: msg.RecipientOtherChain,
+		SenderOtherChain:    msg.SenderOtherChain,
+		RandomNumberHash:    msg.RandomNumberHash,
+		Timestamp:           msg.Timestamp,
+		CrossChain:          msg.CrossChain,
+		ExpireHeight:        ctx.BlockHeight() + msg.HeightSpan,
+		Status:              Open,
+	})
+	return swapID, nil
+}
+
+// getOpenSwap returns the swap swapID if it is open and not expired.
+func (k Keeper) getOpenSwap(ctx sdk.Context, swapID []byte) (AtomicSwap, sdk.Error) {
+	swap, found := k.GetSwap(ctx, swapID)
+	if !found {
+		return swap, ErrUnknownSwap(k.codespace, swapID)
+	}
+	if swap.Status != Open {
+		return swap, ErrSwapNotOpen(k.codespace, swap.Status)
+	}
+	if ctx.BlockHeight() >= swap.ExpireHeight {
+		return swap, ErrSwapExpired(k.codespace, swap.ExpireHeight)
+	}
+	return swap, nil
+}
+
+// DepositSwap locks the expected income of the single chain swap swapID from
+// its recipient.
+func (k Keeper) DepositSwap(ctx sdk.Context, from sdk.AccAddress, swapID []byte, amount sdk.Coins) (AtomicSwap, sdk.Error) {
+	swap, err := k.getOpenSwap(ctx, swapID)
+	if err != nil {
+		return swap, err
+	}
+	if swap.CrossChain {
+		return swap, ErrInvalidDeposit(k.codespace, "a cross chain swap takes no deposit")
+	}
+	if !from.Equals(swap.To) {
+		return swap, ErrInvalidDeposit(k.codespace, "only the recipient of the swap may deposit")
+	}
+	if len(swap.InAmount) != 0 {
+		return swap, ErrInvalidDeposit(k.codespace, "the expected income is already deposited")
+	}
+	expected, err := parseExpectedIncome(swap.ExpectedIncome)
+	if err != nil {
+		return swap, err
+	}
+	if !amount.IsEqual(expected) {
+		return swap, ErrInvalidDeposit(k.codespace, fmt.Sprintf("the deposit must be the expected income %s", expected))
+	}
+	if _, err := k.ck.SendCoins(ctx, from, AtomicSwapCoinsAccAddr, amount); err != nil {
+		return swap, err
+	}
+
+	swap.InAmount = amount
+	k.setSwap(ctx, swapID, swap)
+	return swap, nil
+}
+
+// ClaimSwap completes the swap swapID with its random number: the coins of
+// its sender go to its recipient, and the deposit of a single chain swap to
+// its sender.
+func (k Keeper) ClaimSwap(ctx sdk.Context, swapID, randomNumber []byte) (AtomicSwap, sdk.Error) {
+	swap, err := k.getOpenSwap(ctx, swapID)
+	if err != nil {
+		return swap, err
+	}
+	if !bytes.Equal(CalculateRandomHash(randomNumber, swap.Timestamp), swap.RandomNumberHash) {
+		return swap, ErrInvalidRandomNumber(k.codespace)
+	}
+	if !swap.CrossChain && len(swap.InAmount) == 0 {
+		return swap, ErrInvalidDeposit(k.codespace, "the expected income is not deposited yet")
+	}
+	if _, err := k.ck.SendCoins(ctx, AtomicSwapCoinsAccAddr, swap.To, swap.OutAmount); err != nil {
+		return swap, err
+	}
+	if len(swap.InAmount) != 0 {
+		if _, err := k.ck.SendCoins(ctx, AtomicSwapCoinsAccAddr, swap.From, swap.InAmount); err != nil {
+			return swap, err
+		}
+	}
+
+	swap.RandomNumber = randomNumber
+	swap.Status = Completed
+	swap.ClosedHeight = ctx.BlockHeight()
+	k.setSwap(ctx, swapID, swap)
+	return swap, nil
+}
+
+// RefundSwap refunds the expired swap swapID to its sender, and its deposit
+// to its recipient.
+func (k Keeper) RefundSwap(ctx sdk.Context, swapID []byte) (AtomicSwap, sdk.Error) {
+	swap, found := k.GetSwap(ctx, swapID)
+	if !found {
+		return swap, ErrUnknownSwap(k.codespace, swapID)
+	}
+	if swap.Status != Open {
+		return swap, ErrSwapNotOpen(k.codespace, swap.Status)
+	}
+	if ctx.BlockHeight() < swap.ExpireHeight {
+		return swap, ErrSwapNotExpired(k.codespace, swap.ExpireHeight)
+	}
+	if _, err := k.ck.SendCoins(ctx, AtomicSwapCoinsAccAddr, swap.From, swap.OutAmount); err != nil {
+		return swap, err
+	}
+	if len(swap.InAmount) != 0 {
+		if _, err := k.ck.SendCoins(ctx, AtomicSwapCoinsAccAddr, swap.To, swap.InAmount); err != nil {
+			return swap, err
+		}
+	}
+
+	swap.Status = Expired
+	swap.ClosedHeight = ctx.BlockHeight()
+	k.setSwap(ctx, swapID, swap)
+	return swap, nil
+}
@@ -0,0 +1,281 @@
+package swap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+var (
+	addr1 = sdk.AccAddress([]byte("addr1_______________"))
+	addr2 = sdk.AccAddress([]byte("addr2_______________"))
+
+	initCoins = sdk.Coins{sdk.NewCoin("BNB", 1000), sdk.NewCoin("BTC", 1000)}
+
+	blockTime = time.Unix(100000, 0)
+)
+
+func createTestInput(t *testing.T, maxRefundsPerBlock int) (sdk.Context, Keeper, bank.Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySwap := sdk.NewKVStoreKey("atomic_swap")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySwap, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	header := abci.Header{Height: 10, Time: blockTime}
+	ctx := sdk.NewContext(ms, header, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache).WithEventManager(sdk.NewEventManager())
+
+	ck := bank.NewBaseKeeper(auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount))
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		_, _, err := ck.AddCoins(ctx, addr, initCoins)
+		require.NoError(t, err)
+	}
+	return ctx, NewKeeper(cdc, keySwap, ck, maxRefundsPerBlock, DefaultCodespace), ck
+}
+
+func bnb(amount int64) sdk.Coins {
+	return sdk.Coins{sdk.NewCoin("BNB", amount)}
+}
+
+func randomNumber(b byte) []byte {
+	rn := make([]byte, RandomNumberLength)
+	rn[0] = b
+	return rn
+}
+
+func newCrossChainHTLT(from sdk.AccAddress, rn []byte, amount int64) MsgHTLT {
+	timestamp := blockTime.Unix()
+	return NewMsgHTLT(from, addr2, "0xrecipient", "0xsender", CalculateRandomHash(rn, timestamp), timestamp,
+		bnb(amount), "100:ETH", MinimumHeightSpan, true)
+}
+
+func TestValidateHTLT(t *testing.T) {
+	rn := randomNumber(1)
+	require.NoError(t, newCrossChainHTLT(addr1, rn, 100).ValidateBasic())
+
+	single := NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(100), "50:BTC", MinimumHeightSpan, false)
+	require.NoError(t, single.ValidateBasic())
+
+	for _, msg := range []MsgHTLT{
+		NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(100), "100:ETH", MinimumHeightSpan, true),
+		NewMsgHTLT(addr1, addr2, "0xrecipient", "", CalculateRandomHash(rn, 0), 0, bnb(100), "50:BTC", MinimumHeightSpan, false),
+		NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(100), "none", MinimumHeightSpan, false),
+		NewMsgHTLT(addr1, addr2, "", "", rn[:10], 0, bnb(100), "50:BTC", MinimumHeightSpan, false),
+		NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(0), "50:BTC", MinimumHeightSpan, false),
+		NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(100), "50:BTC", MinimumHeightSpan-1, false),
+		NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, 0), 0, bnb(100), "50:BTC", MaximumHeightSpan+1, false),
+	} {
+		require.Error(t, msg.ValidateBasic())
+	}
+}
+
+func TestCrossChainSwap(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+	rn := randomNumber(1)
+
+	// the timestamp must be close to the block time
+	msg := newCrossChainHTLT(addr1, rn, 100)
+	msg.Timestamp = blockTime.Unix() - MaxTimestampPast - 1
+	res := handler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidTimestamp), res.Code)
+
+	msg = newCrossChainHTLT(addr1, rn, 100)
+	res = handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	swapID := CalculateSwapID(msg.RandomNumberHash, addr1, msg.SenderOtherChain)
+	require.Equal(t, swapID, res.Data)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeDuplicatedSwapID), handler(ctx, msg).Code)
+	require.Equal(t, bnb(100), ck.GetCoins(ctx, AtomicSwapCoinsAccAddr))
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+
+	swap, found := keeper.GetSwap(ctx, swapID)
+	require.True(t, found)
+	require.Equal(t, Open, swap.Status)
+	require.Equal(t, ctx.BlockHeight()+MinimumHeightSpan, swap.ExpireHeight)
+
+	// a cross chain swap takes no deposit
+	res = handler(ctx, NewMsgDepositHTLT(addr2, swapID, sdk.Coins{sdk.NewCoin("BTC", 100)}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidDeposit), res.Code)
+
+	// the claim needs the random number, from any account
+	res = handler(ctx, NewMsgClaimHTLT(addr2, swapID, randomNumber(2)))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidRandomNumber), res.Code)
+	res = handler(ctx, NewMsgClaimHTLT(addr1, swapID, rn))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(900), ck.GetCoins(ctx, addr1).AmountOf("BNB"))
+	require.Equal(t, int64(1100), ck.GetCoins(ctx, addr2).AmountOf("BNB"))
+	require.True(t, ck.GetCoins(ctx, AtomicSwapCoinsAccAddr).IsZero())
+
+	swap, _ = keeper.GetSwap(ctx, swapID)
+	require.Equal(t, Completed, swap.Status)
+	require.Equal(t, rn, []byte(swap.RandomNumber))
+	require.Equal(t, ctx.BlockHeight(), swap.ClosedHeight)
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+
+	// a closed swap is neither claimed nor refunded again
+	res = handler(ctx, NewMsgClaimHTLT(addr1, swapID, rn))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSwapNotOpen), res.Code)
+	res = handler(ctx.WithBlockHeight(swap.ExpireHeight), NewMsgRefundHTLT(addr1, swapID))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSwapNotOpen), res.Code)
+}
+
+func TestSingleChainSwap(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+	rn := randomNumber(1)
+	timestamp := blockTime.Unix()
+	btc := sdk.Coins{sdk.NewCoin("BTC", 50)}
+
+	msg := NewMsgHTLT(addr1, addr2, "", "", CalculateRandomHash(rn, timestamp), timestamp, bnb(100), "50:BTC", MinimumHeightSpan, false)
+	res := handler(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	swapID := res.Data
+
+	// the swap is not claimed before the deposit of the expected income
+	res = handler(ctx, NewMsgClaimHTLT(addr1, swapID, rn))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidDeposit), res.Code)
+
+	// only the recipient deposits, exactly the expected income, once
+	res = handler(ctx, NewMsgDepositHTLT(addr1, swapID, btc))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidDeposit), res.Code)
+	res = handler(ctx, NewMsgDepositHTLT(addr2, swapID, sdk.Coins{sdk.NewCoin("BTC", 40)}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidDeposit), res.Code)
+	res = handler(ctx, NewMsgDepositHTLT(addr2, swapID, btc))
+	require.True(t, res.IsOK(), res.Log)
+	res = handler(ctx, NewMsgDepositHTLT(addr2, swapID, btc))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidDeposit), res.Code)
+	require.Equal(t, btc.Plus(bnb(100)), ck.GetCoins(ctx, AtomicSwapCoinsAccAddr))
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+
+	res = handler(ctx, NewMsgClaimHTLT(addr2, swapID, rn))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 900), sdk.NewCoin("BTC", 1050)}, ck.GetCoins(ctx, addr1))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 1100), sdk.NewCoin("BTC", 950)}, ck.GetCoins(ctx, addr2))
+	require.True(t, ck.GetCoins(ctx, AtomicSwapCoinsAccAddr).IsZero())
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+}
+
+func TestRefundSwap(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+	rn := randomNumber(1)
+
+	res := handler(ctx, newCrossChainHTLT(addr1, rn, 100))
+	require.True(t, res.IsOK(), res.Log)
+	swapID := res.Data
+	swap, _ := keeper.GetSwap(ctx, swapID)
+
+	res = handler(ctx.WithBlockHeight(swap.ExpireHeight-1), NewMsgRefundHTLT(addr2, swapID))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSwapNotExpired), res.Code)
+
+	// an expired swap is not claimed, it is refunded by any account
+	expiredCtx := ctx.WithBlockHeight(swap.ExpireHeight)
+	res = handler(expiredCtx, NewMsgClaimHTLT(addr2, swapID, rn))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSwapExpired), res.Code)
+	res = handler(expiredCtx, NewMsgRefundHTLT(addr2, swapID))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, initCoins, ck.GetCoins(ctx, addr1))
+	require.True(t, ck.GetCoins(ctx, AtomicSwapCoinsAccAddr).IsZero())
+
+	swap, _ = keeper.GetSwap(ctx, swapID)
+	require.Equal(t, Expired, swap.Status)
+	require.Equal(t, expiredCtx.BlockHeight(), swap.ClosedHeight)
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+
+	res = handler(ctx, NewMsgRefundHTLT(addr2, randomNumber(3)))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownSwap), res.Code)
+}
+
+func TestBeginBlockerRefunds(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 2)
+	handler := NewHandler(keeper)
+
+	var swapIDs [][]byte
+	for i := byte(1); i <= 3; i++ {
+		res := handler(ctx, newCrossChainHTLT(addr1, randomNumber(i), 100))
+		require.True(t, res.IsOK(), res.Log)
+		swapIDs = append(swapIDs, res.Data)
+	}
+	claimed := handler(ctx, NewMsgClaimHTLT(addr2, swapIDs[2], randomNumber(3)))
+	require.True(t, claimed.IsOK(), claimed.Log)
+	res := handler(ctx.WithBlockHeight(ctx.BlockHeight()+1), newCrossChainHTLT(addr2, randomNumber(4), 100))
+	require.True(t, res.IsOK(), res.Log)
+	swapIDs = append(swapIDs, res.Data)
+
+	// nothing expires before the expire height
+	expireHeight := ctx.BlockHeight() + MinimumHeightSpan
+	BeginBlocker(ctx.WithBlockHeight(expireHeight-1), keeper)
+	require.Equal(t, bnb(300), ck.GetCoins(ctx, AtomicSwapCoinsAccAddr))
+
+	// the open swaps are refunded up to the max refunds per block, the ones
+	// left are refunded by the next blocks
+	expiredCtx := ctx.WithBlockHeight(expireHeight + 1).WithEventManager(sdk.NewEventManager())
+	BeginBlocker(expiredCtx, keeper)
+	require.Len(t, expiredCtx.EventManager().Events(), 2)
+	for _, swapID := range swapIDs[:2] {
+		swap, _ := keeper.GetSwap(ctx, swapID)
+		require.Equal(t, Expired, swap.Status)
+	}
+	swap, _ := keeper.GetSwap(ctx, swapIDs[3])
+	require.Equal(t, Open, swap.Status)
+	require.Equal(t, int64(900), ck.GetCoins(ctx, addr1).AmountOf("BNB"))
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+
+	BeginBlocker(ctx.WithBlockHeight(expireHeight+2), keeper)
+	swap, _ = keeper.GetSwap(ctx, swapIDs[3])
+	require.Equal(t, Expired, swap.Status)
+	require.True(t, ck.GetCoins(ctx, AtomicSwapCoinsAccAddr).IsZero())
+	require.NoError(t, SwapCoinsInvariant(keeper)(ctx))
+}
+
+func TestSwapIndexes(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+
+	msg1 := newCrossChainHTLT(addr1, randomNumber(1), 100)
+	msg2 := newCrossChainHTLT(addr1, randomNumber(2), 100)
+	// the same random number hash locks the swaps of both parties
+	msg3 := newCrossChainHTLT(addr2, randomNumber(1), 100)
+	msg3.To = addr1
+	var swapIDs [][]byte
+	for _, msg := range []MsgHTLT{msg1, msg2, msg3} {
+		res := handler(ctx, msg)
+		require.True(t, res.IsOK(), res.Log)
+		swapIDs = append(swapIDs, res.Data)
+	}
+
+	require.Len(t, keeper.GetSwapIDsByCreator(ctx, addr1, 0, 10), 2)
+	require.Len(t, keeper.GetSwapIDsByCreator(ctx, addr1, 1, 10), 1)
+	require.Len(t, keeper.GetSwapIDsByCreator(ctx, addr1, 0, 1), 1)
+	require.Equal(t, [][]byte{swapIDs[2]}, keeper.GetSwapIDsByCreator(ctx, addr2, 0, 10))
+	require.Equal(t, [][]byte{swapIDs[2]}, keeper.GetSwapIDsByRecipient(ctx, addr1, 0, 10))
+	require.Len(t, keeper.GetSwapIDsByRecipient(ctx, addr2, 0, 10), 2)
+	require.ElementsMatch(t, [][]byte{swapIDs[0], swapIDs[2]}, keeper.GetSwapIDsByRandomNumberHash(ctx, msg1.RandomNumberHash, 0, 10))
+
+	querier := NewQuerier(keeper)
+	bz, err := keeper.cdc.MarshalJSON(QuerySwapByRandomNumberHashParams{RandomNumberHash: msg1.RandomNumberHash})
+	require.NoError(t, err)
+	res, err := querier(ctx, []string{QuerySwapByRandomNumberHash}, abci.RequestQuery{Data: bz})
+	require.NoError(t, err)
+	var swaps []AtomicSwap
+	require.NoError(t, keeper.cdc.UnmarshalJSON(res, &swaps))
+	require.Len(t, swaps, 2)
+}
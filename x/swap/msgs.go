@@ -0,0 +1,264 @@
+package swap
+
+import (
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const (
+	MsgRoute           = "atomicSwap"
+	TypeMsgHTLT        = "HTLT"
+	TypeMsgDepositHTLT = "depositHTLT"
+	TypeMsgClaimHTLT   = "claimHTLT"
+	TypeMsgRefundHTLT  = "refundHTLT"
+)
+
+// verify interface at compile time
+var (
+	_ sdk.Msg = MsgHTLT{}
+	_ sdk.Msg = MsgDepositHTLT{}
+	_ sdk.Msg = MsgClaimHTLT{}
+	_ sdk.Msg = MsgRefundHTLT{}
+)
+
+// MsgHTLT creates a swap locking Amount of From for To until HeightSpan
+// blocks after it. For a single chain swap, CrossChain is false and To must
+// deposit the ExpectedIncome coins before the swap is claimed.
+type MsgHTLT struct {
+	From                sdk.AccAddress `json:"from"`
+	To                  sdk.AccAddress `json:"to"`
+	RecipientOtherChain string         `json:"recipient_other_chain"`
+	SenderOtherChain    string         `json:"sender_other_chain"`
+	RandomNumberHash    cmn.HexBytes   `json:"random_number_hash"`
+	Timestamp           int64          `json:"timestamp"`
+	Amount              sdk.Coins      `json:"amount"`
+	ExpectedIncome      string         `json:"expected_income"`
+	HeightSpan          int64          `json:"height_span"`
+	CrossChain          bool           `json:"cross_chain"`
+}
+
+func NewMsgHTLT(from, to sdk.AccAddress, recipientOtherChain, senderOtherChain string, randomNumberHash []byte, timestamp int64,
+	amount sdk.Coins, expectedIncome string, heightSpan int64, crossChain bool) MsgHTLT {
+	return MsgHTLT{
+		From:                from,
+		To:                  to,
+		RecipientOtherChain: recipientOtherChain,
+		SenderOtherChain:    senderOtherChain,
+		RandomNumberHash:    randomNumberHash,
+		Timestamp:           timestamp,
+		Amount:              amount,
+		ExpectedIncome:      expectedIncome,
+		HeightSpan:          heightSpan,
+		CrossChain:          crossChain,
+	}
+}
+
+// nolint
+func (msg MsgHTLT) Route() string { return MsgRoute }
+func (msg MsgHTLT) Type() string  { return TypeMsgHTLT }
+func (msg MsgHTLT) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgHTLT) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check, the timestamp is checked against the block time by
+// the handler
+func (msg MsgHTLT) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.To) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.To)))
+	}
+	if msg.CrossChain {
+		if len(msg.RecipientOtherChain) == 0 {
+			return ErrInvalidInput(DefaultCodespace, "recipient on the other chain is required for a cross chain swap")
+		}
+		if len(msg.RecipientOtherChain) > MaxOtherChainAddrLength || len(msg.SenderOtherChain) > MaxOtherChainAddrLength {
+			return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("the addresses on the other chain must be at most %d characters", MaxOtherChainAddrLength))
+		}
+	} else {
+		if len(msg.RecipientOtherChain) != 0 || len(msg.SenderOtherChain) != 0 {
+			return ErrInvalidInput(DefaultCodespace, "a single chain swap has no addresses on the other chain")
+		}
+		if _, err := parseExpectedIncome(msg.ExpectedIncome); err != nil {
+			return err
+		}
+	}
+	if len(msg.ExpectedIncome) > MaxExpectedIncomeLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("expected income must be at most %d characters", MaxExpectedIncomeLength))
+	}
+	if len(msg.RandomNumberHash) != RandomNumberHashLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("random number hash must be %d bytes", RandomNumberHashLength))
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	if msg.HeightSpan < MinimumHeightSpan || msg.HeightSpan > MaximumHeightSpan {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("height span must be in [%d, %d]", MinimumHeightSpan, MaximumHeightSpan))
+	}
+	return nil
+}
+
+func (msg MsgHTLT) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, AtomicSwapCoinsAccAddr}
+}
+
+// parseExpectedIncome parses the coins a single chain swap expects from its
+// recipient.
+func parseExpectedIncome(expectedIncome string) (sdk.Coins, sdk.Error) {
+	coins, err := sdk.ParseCoins(expectedIncome)
+	if err != nil {
+		return nil, ErrInvalidInput(DefaultCodespace, fmt.Sprintf("invalid expected income %q: %s", expectedIncome, err))
+	}
+	if !coins.IsValid() || !coins.IsPositive() {
+		return nil, ErrInvalidInput(DefaultCodespace, fmt.Sprintf("invalid expected income %q", expectedIncome))
+	}
+	return coins, nil
+}
+
+// MsgDepositHTLT deposits the expected income of a single chain swap, From
+// must be its recipient.
+type MsgDepositHTLT struct {
+	From   sdk.AccAddress `json:"from"`
+	Amount sdk.Coins      `json:"amount"`
+	SwapID cmn.HexBytes   `json:"swap_id"`
+}
+
+func NewMsgDepositHTLT(from sdk.AccAddress, swapID []byte, amount sdk.Coins) MsgDepositHTLT {
+	return MsgDepositHTLT{From: from, Amount: amount, SwapID: swapID}
+}
+
+// nolint
+func (msg MsgDepositHTLT) Route() string { return MsgRoute }
+func (msg MsgDepositHTLT) Type() string  { return TypeMsgDepositHTLT }
+func (msg MsgDepositHTLT) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgDepositHTLT) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgDepositHTLT) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.SwapID) != SwapIDLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("swap id must be %d bytes", SwapIDLength))
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	return nil
+}
+
+func (msg MsgDepositHTLT) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, AtomicSwapCoinsAccAddr}
+}
+
+// MsgClaimHTLT claims an open swap with its random number, anyone knowing it
+// may claim the swap for its recipient.
+type MsgClaimHTLT struct {
+	From         sdk.AccAddress `json:"from"`
+	SwapID       cmn.HexBytes   `json:"swap_id"`
+	RandomNumber cmn.HexBytes   `json:"random_number"`
+}
+
+func NewMsgClaimHTLT(from sdk.AccAddress, swapID, randomNumber []byte) MsgClaimHTLT {
+	return MsgClaimHTLT{From: from, SwapID: swapID, RandomNumber: randomNumber}
+}
+
+// nolint
+func (msg MsgClaimHTLT) Route() string { return MsgRoute }
+func (msg MsgClaimHTLT) Type() string  { return TypeMsgClaimHTLT }
+func (msg MsgClaimHTLT) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgClaimHTLT) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgClaimHTLT) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.SwapID) != SwapIDLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("swap id must be %d bytes", SwapIDLength))
+	}
+	if len(msg.RandomNumber) != RandomNumberLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("random number must be %d bytes", RandomNumberLength))
+	}
+	return nil
+}
+
+func (msg MsgClaimHTLT) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, AtomicSwapCoinsAccAddr}
+}
+
+// MsgRefundHTLT refunds an expired swap to its sender, and the deposit of a
+// single chain swap to its recipient. Anyone may refund an expired swap.
+type MsgRefundHTLT struct {
+	From   sdk.AccAddress `json:"from"`
+	SwapID cmn.HexBytes   `json:"swap_id"`
+}
+
+func NewMsgRefundHTLT(from sdk.AccAddress, swapID []byte) MsgRefundHTLT {
+	return MsgRefundHTLT{From: from, SwapID: swapID}
+}
+
+// nolint
+func (msg MsgRefundHTLT) Route() string { return MsgRoute }
+func (msg MsgRefundHTLT) Type() string  { return TypeMsgRefundHTLT }
+func (msg MsgRefundHTLT) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRefundHTLT) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgRefundHTLT) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.SwapID) != SwapIDLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("swap id must be %d bytes", SwapIDLength))
+	}
+	return nil
+}
+
+func (msg MsgRefundHTLT) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, AtomicSwapCoinsAccAddr}
+}
@@ -0,0 +1,113 @@
+package swap
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the swap Querier
+const (
+	QuerySwapByID               = "swapid"
+	QuerySwapByCreator          = "swapcreator"
+	QuerySwapByRecipient        = "swaprecipient"
+	QuerySwapByRandomNumberHash = "swaprandomnumberhash"
+
+	// DefaultLimit and MaxLimit are the default and the maximum numbers of
+	// swap ids per page of the index queries.
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Params for query 'custom/atomicSwap/swapid'
+type QuerySwapByIDParams struct {
+	SwapID cmn.HexBytes
+}
+
+// Params for query 'custom/atomicSwap/swapcreator' and
+// 'custom/atomicSwap/swaprecipient'
+type QuerySwapByAddressParams struct {
+	Address sdk.AccAddress
+	Offset  int
+	Limit   int
+}
+
+// Params for query 'custom/atomicSwap/swaprandomnumberhash'
+type QuerySwapByRandomNumberHashParams struct {
+	RandomNumberHash cmn.HexBytes
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QuerySwapByID:
+			var params QuerySwapByIDParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			swap, found := keeper.GetSwap(ctx, params.SwapID)
+			if !found {
+				return nil, ErrUnknownSwap(keeper.codespace, params.SwapID)
+			}
+			return marshalJSON(keeper.cdc, swap)
+		case QuerySwapByCreator, QuerySwapByRecipient:
+			var params QuerySwapByAddressParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			if len(params.Address) == 0 {
+				return nil, sdk.ErrUnknownRequest("address is missing")
+			}
+			if params.Offset < 0 {
+				return nil, sdk.ErrUnknownRequest("offset cannot be negative")
+			}
+			if params.Limit <= 0 {
+				params.Limit = DefaultLimit
+			}
+			if params.Limit > MaxLimit {
+				params.Limit = MaxLimit
+			}
+			var swapIDs [][]byte
+			if path[0] == QuerySwapByCreator {
+				swapIDs = keeper.GetSwapIDsByCreator(ctx, params.Address, params.Offset, params.Limit)
+			} else {
+				swapIDs = keeper.GetSwapIDsByRecipient(ctx, params.Address, params.Offset, params.Limit)
+			}
+			return marshalJSON(keeper.cdc, hexIDs(swapIDs))
+		case QuerySwapByRandomNumberHash:
+			var params QuerySwapByRandomNumberHashParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			if len(params.RandomNumberHash) != RandomNumberHashLength {
+				return nil, sdk.ErrUnknownRequest("invalid random number hash")
+			}
+			swaps := make([]AtomicSwap, 0)
+			for _, swapID := range keeper.GetSwapIDsByRandomNumberHash(ctx, params.RandomNumberHash, 0, MaxLimit) {
+				swap, _ := keeper.GetSwap(ctx, swapID)
+				swaps = append(swaps, swap)
+			}
+			return marshalJSON(keeper.cdc, swaps)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown atomic swap query endpoint")
+		}
+	}
+}
+
+func hexIDs(swapIDs [][]byte) []cmn.HexBytes {
+	ids := make([]cmn.HexBytes, len(swapIDs))
+	for i, swapID := range swapIDs {
+		ids[i] = swapID
+	}
+	return ids
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,147 @@
+package swap
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	RandomNumberHashLength = 32
+	RandomNumberLength     = 32
+	SwapIDLength           = 32
+
+	MaxOtherChainAddrLength = 64
+	MaxExpectedIncomeLength = 64
+
+	// the expire height of a swap is between MinimumHeightSpan and
+	// MaximumHeightSpan blocks after its creation
+	MinimumHeightSpan = 360
+	MaximumHeightSpan = 518400
+
+	// the timestamp of a swap, hashed with its random number, is at most
+	// MaxTimestampPast seconds before and MaxTimestampFuture seconds after the
+	// block time
+	MaxTimestampPast   = 30 * 60
+	MaxTimestampFuture = 15 * 60
+)
+
+// SwapStatus is the status of an atomic swap.
+type SwapStatus byte
+
+const (
+	NULL      SwapStatus = 0x00
+	Open      SwapStatus = 0x01
+	Completed SwapStatus = 0x02
+	Expired   SwapStatus = 0x03
+)
+
+func NewSwapStatusFromString(str string) SwapStatus {
+	switch str {
+	case "Open", "open":
+		return Open
+	case "Completed", "completed":
+		return Completed
+	case "Expired", "expired":
+		return Expired
+	default:
+		return NULL
+	}
+}
+
+func (status SwapStatus) String() string {
+	switch status {
+	case Open:
+		return "Open"
+	case Completed:
+		return "Completed"
+	case Expired:
+		return "Expired"
+	default:
+		return "NULL"
+	}
+}
+
+func (status SwapStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(status.String())
+}
+
+func (status *SwapStatus) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*status = NewSwapStatusFromString(s)
+	return nil
+}
+
+// AtomicSwap is a hash timer locked transfer of OutAmount from From to To,
+// claimed with the random number of RandomNumberHash before ExpireHeight and
+// refunded after. A cross chain swap is matched by a swap on the other chain
+// locked with the same random number hash, a single chain swap by the
+// deposit of the expected income by To.
+type AtomicSwap struct {
+	From      sdk.AccAddress `json:"from"`
+	To        sdk.AccAddress `json:"to"`
+	OutAmount sdk.Coins      `json:"out_amount"`
+	// the coins deposited by To in a single chain swap
+	InAmount sdk.Coins `json:"in_amount"`
+
+	ExpectedIncome      string `json:"expected_income"`
+	RecipientOtherChain string `json:"recipient_other_chain"`
+	SenderOtherChain    string `json:"sender_other_chain"`
+
+	RandomNumberHash cmn.HexBytes `json:"random_number_hash"`
+	RandomNumber     cmn.HexBytes `json:"random_number"`
+	Timestamp        int64        `json:"timestamp"`
+	CrossChain       bool         `json:"cross_chain"`
+
+	ExpireHeight int64      `json:"expire_height"`
+	ClosedHeight int64      `json:"closed_height"`
+	Status       SwapStatus `json:"status"`
+}
+
+func (swap AtomicSwap) String() string {
+	return fmt.Sprintf(`Atomic Swap
+  From:                  %s
+  To:                    %s
+  Out Amount:            %s
+  In Amount:             %s
+  Expected Income:       %s
+  Recipient Other Chain: %s
+  Sender Other Chain:    %s
+  Random Number Hash:    %s
+  Random Number:         %s
+  Timestamp:             %d
+  Cross Chain:           %t
+  Expire Height:         %d
+  Closed Height:         %d
+  Status:                %s`, swap.From, swap.To, swap.OutAmount, swap.InAmount, swap.ExpectedIncome,
+		swap.RecipientOtherChain, swap.SenderOtherChain, swap.RandomNumberHash, swap.RandomNumber,
+		swap.Timestamp, swap.CrossChain, swap.ExpireHeight, swap.ClosedHeight, swap.Status)
+}
+
+// CalculateRandomHash returns the hash locking a swap, the SHA256 of the
+// random number and the big endian timestamp.
+func CalculateRandomHash(randomNumber []byte, timestamp int64) []byte {
+	data := make([]byte, RandomNumberLength+8)
+	copy(data[:RandomNumberLength], randomNumber)
+	binary.BigEndian.PutUint64(data[RandomNumberLength:], uint64(timestamp))
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
+// CalculateSwapID returns the id of the swap of sender, the SHA256 of its
+// random number hash, sender and sender on the other chain. The swap matching
+// it on the other chain has another id.
+func CalculateSwapID(randomNumberHash []byte, sender sdk.AccAddress, senderOtherChain string) []byte {
+	data := append(append([]byte{}, randomNumberHash...), sender...)
+	data = append(data, []byte(senderOtherChain)...)
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
@@ -0,0 +1,35 @@
+package timelock
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	EventTypeTimeUnlock = "time_unlock"
+
+	TagOwner = "owner"
+	TagID    = "id"
+)
+
+// EndBlocker releases the coins of the time locks due at the block time, up
+// to the max unlocks per block of the keeper. The owners of the time locks
+// left for the next blocks may claim them before.
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	var due []TimeLock
+	k.IterateDueTimeLocks(ctx, ctx.BlockHeader().Time, func(lock TimeLock) bool {
+		due = append(due, lock)
+		return len(due) >= k.maxUnlocksPerBlock
+	})
+	for _, lock := range due {
+		// the module account holds the coins of all the time locks
+		if err := k.release(ctx, lock); err != nil {
+			panic(fmt.Sprintf("failed to release time lock %d of %s: %s", lock.ID, lock.Owner, err))
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeTimeUnlock,
+			sdk.NewAttribute(TagOwner, lock.Owner.String()),
+			sdk.NewAttribute(TagID, strconv.FormatInt(lock.ID, 10))))
+	}
+}
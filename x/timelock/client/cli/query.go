@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+)
+
+// GetCmdQueryTimeLocks implements the command to query the time locks of an
+// account, or one of them.
+func GetCmdQueryTimeLocks(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time-locks [owner] [id]",
+		Short: "Query the time locks of an account, or one of them",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			owner, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			var path string
+			var params interface{} = timelock.QueryTimeLocksParams{Owner: owner}
+			if len(args) == 2 {
+				id, err := strconv.ParseInt(args[1], 10, 64)
+				if err != nil {
+					return err
+				}
+				params = timelock.QueryTimeLockParams{Owner: owner, ID: id}
+				path = fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryTimeLock)
+			} else {
+				path = fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryTimeLocks)
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(path, bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryDueTimeLocks implements the command to query the pending unlocks
+// of the time locks due at the last block time.
+func GetCmdQueryDueTimeLocks(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "due-time-locks",
+		Short: "Query the time locks due but not released yet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryDue), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
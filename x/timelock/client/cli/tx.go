@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+)
+
+const (
+	flagDescription = "description"
+	flagAmount      = "amount"
+	flagUnlockTime  = "unlock-time"
+)
+
+// GetCmdTimeLock implements the command locking coins until a time.
+func GetCmdTimeLock(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time-lock [description] [amount] [unlock-time]",
+		Args:  cobra.ExactArgs(3),
+		Short: "lock coins until the unlock time, in RFC3339 format, e.g. 2027-01-02T15:04:05Z",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := sdk.ParseUserCoins(args[1])
+			if err != nil {
+				return err
+			}
+			unlockTime, err := parseUnlockTime(args[2])
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return timelock.NewMsgTimeLock(from, args[0], amount, unlockTime)
+			})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdTimeRelock implements the command updating a time lock.
+func GetCmdTimeRelock(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time-relock [id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "raise the amount of a time lock, extend its unlock time or change its description",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			var amount sdk.Coins
+			if amountStr := viper.GetString(flagAmount); amountStr != "" {
+				if amount, err = sdk.ParseUserCoins(amountStr); err != nil {
+					return err
+				}
+			}
+			var unlockTime time.Time
+			if unlockTimeStr := viper.GetString(flagUnlockTime); unlockTimeStr != "" {
+				if unlockTime, err = parseUnlockTime(unlockTimeStr); err != nil {
+					return err
+				}
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return timelock.NewMsgTimeRelock(from, id, viper.GetString(flagDescription), amount, unlockTime)
+			})
+		},
+	}
+
+	cmd.Flags().String(flagDescription, "", "new description of the time lock")
+	cmd.Flags().String(flagAmount, "", "new amount of the time lock, not lower than the locked one")
+	cmd.Flags().String(flagUnlockTime, "", "new unlock time of the time lock in RFC3339 format, not earlier than the current one")
+	return cmd
+}
+
+// GetCmdTimeUnlock implements the command claiming the coins of a due time
+// lock.
+func GetCmdTimeUnlock(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "time-unlock [id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "claim the coins of a time lock once its unlock time is passed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return timelock.NewMsgTimeUnlock(from, id)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func parseUnlockTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, errors.Wrap(err, "unlock time must be in RFC3339 format")
+	}
+	return t.UTC(), nil
+}
+
+func completeAndBroadcast(cdc *codec.Codec, newMsg func(from sdk.AccAddress) sdk.Msg) error {
+	txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{newMsg(from)}
+	if cliCtx.GenerateOnly {
+		return utils.PrintUnsignedStdTx(txBldr, cliCtx, msgs)
+	}
+	return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, msgs)
+}
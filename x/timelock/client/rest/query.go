@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/timelock"
+)
+
+// RegisterRoutes registers the timelock REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
+	r.HandleFunc("/timelock/due", dueHandlerFn(cliCtx, queryRoute)).Methods("GET")
+	r.HandleFunc("/timelock/{owner}", timeLocksHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/timelock/{owner}/{id}", timeLockHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+}
+
+// http request handler to query the time locks due but not released yet
+func dueHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query(w, cliCtx, fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryDue), nil)
+	}
+}
+
+// http request handler to query the time locks of an account
+func timeLocksHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		owner, err := sdk.AccAddressFromBech32(mux.Vars(r)["owner"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		bz, err := cdc.MarshalJSON(timelock.QueryTimeLocksParams{Owner: owner})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query(w, cliCtx, fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryTimeLocks), bz)
+	}
+}
+
+// http request handler to query a time lock of an account
+func timeLockHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		owner, err := sdk.AccAddressFromBech32(vars["owner"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id, err := strconv.ParseInt(vars["id"], 10, 64)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, "id must be an integer")
+			return
+		}
+		bz, err := cdc.MarshalJSON(timelock.QueryTimeLockParams{Owner: owner, ID: id})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query(w, cliCtx, fmt.Sprintf("custom/%s/%s", queryRoute, timelock.QueryTimeLock), bz)
+	}
+}
+
+func query(w http.ResponseWriter, cliCtx context.CLIContext, path string, data []byte) {
+	res, err := cliCtx.QueryWithData(path, data)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
@@ -0,0 +1,22 @@
+package timelock
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Register concrete types on codec codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgTimeLock{}, "cosmos-sdk/MsgTimeLock", nil)
+	cdc.RegisterConcrete(MsgTimeRelock{}, "cosmos-sdk/MsgTimeRelock", nil)
+	cdc.RegisterConcrete(MsgTimeUnlock{}, "cosmos-sdk/MsgTimeUnlock", nil)
+}
+
+// generic sealed codec to be used throughout sdk
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	MsgCdc = cdc.Seal()
+}
@@ -0,0 +1,54 @@
+// nolint
+package timelock
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// Default timelock codespace
+	DefaultCodespace sdk.CodespaceType = 34
+
+	CodeInvalidInput      sdk.CodeType = 101
+	CodeInvalidUnlockTime sdk.CodeType = 102
+	CodeUnknownTimeLock   sdk.CodeType = 103
+	CodeTimeLockNotDue    sdk.CodeType = 104
+	CodeInvalidRelock     sdk.CodeType = 105
+	CodeTooManyTimeLocks  sdk.CodeType = 106
+)
+
+// The registered errors of the codes of the timelock codespace.
+var (
+	ErrCodeInvalidInput      = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeInvalidUnlockTime = sdk.Register(DefaultCodespace, CodeInvalidUnlockTime, "invalid unlock time")
+	ErrCodeUnknownTimeLock   = sdk.Register(DefaultCodespace, CodeUnknownTimeLock, "unknown time lock")
+	ErrCodeTimeLockNotDue    = sdk.Register(DefaultCodespace, CodeTimeLockNotDue, "time lock not due")
+	ErrCodeInvalidRelock     = sdk.Register(DefaultCodespace, CodeInvalidRelock, "invalid relock")
+	ErrCodeTooManyTimeLocks  = sdk.Register(DefaultCodespace, CodeTooManyTimeLocks, "too many time locks")
+)
+
+func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, msg)
+}
+
+func ErrInvalidUnlockTime(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidUnlockTime, msg)
+}
+
+func ErrUnknownTimeLock(codespace sdk.CodespaceType, owner sdk.AccAddress, id int64) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownTimeLock, fmt.Sprintf("account %s has no time lock %d", owner, id))
+}
+
+func ErrTimeLockNotDue(codespace sdk.CodespaceType, id int64) sdk.Error {
+	return sdk.NewError(codespace, CodeTimeLockNotDue, fmt.Sprintf("time lock %d cannot be claimed before its unlock time", id))
+}
+
+func ErrInvalidRelock(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidRelock, msg)
+}
+
+func ErrTooManyTimeLocks(codespace sdk.CodespaceType, max int) sdk.Error {
+	return sdk.NewError(codespace, CodeTooManyTimeLocks, fmt.Sprintf("an account cannot have more than %d time locks", max))
+}
@@ -0,0 +1,90 @@
+package timelock
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState is the state of the timelock module at genesis. The locked
+// coins are held by the LockedCoinsAccAddr account of the genesis accounts.
+type GenesisState struct {
+	TimeLocks []TimeLock     `json:"time_locks"`
+	LastIDs   []LastIDRecord `json:"last_ids"`
+}
+
+// LastIDRecord is the id of the last time lock of Owner, the ids of the
+// released time locks are not reused.
+type LastIDRecord struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	LastID int64          `json:"last_id"`
+}
+
+// InitGenesis sets the time locks, queued by unlock time, and the last ids of
+// their owners.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	store := ctx.KVStore(keeper.storeKey)
+	for _, lock := range data.TimeLocks {
+		keeper.setTimeLock(ctx, lock)
+	}
+	for _, record := range data.LastIDs {
+		store.Set(GetLastIDKey(record.Owner), keeper.cdc.MustMarshalBinaryLengthPrefixed(record.LastID))
+	}
+}
+
+// WriteGenesis returns the time locks, sorted by owner and id, and the last
+// ids of their owners.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var data GenesisState
+	store := ctx.KVStore(keeper.storeKey)
+
+	iterator := sdk.KVStorePrefixIterator(store, TimeLockKeyPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var lock TimeLock
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &lock)
+		data.TimeLocks = append(data.TimeLocks, lock)
+	}
+	iterator.Close()
+
+	iterator = sdk.KVStorePrefixIterator(store, LastIDKeyPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var id int64
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &id)
+		data.LastIDs = append(data.LastIDs, LastIDRecord{
+			Owner:  sdk.AccAddress(iterator.Key()[len(LastIDKeyPrefix):]),
+			LastID: id,
+		})
+	}
+	iterator.Close()
+	return data
+}
+
+// ValidateGenesis checks that the time locks have unique ids not above the
+// last id of their owners, and lock positive coins until after their lock time.
+func ValidateGenesis(data GenesisState) error {
+	lastIDs := make(map[string]int64, len(data.LastIDs))
+	for i, record := range data.LastIDs {
+		if _, ok := lastIDs[string(record.Owner)]; ok {
+			return fmt.Errorf("last_ids[%d]: duplicate last id of %s", i, record.Owner)
+		}
+		lastIDs[string(record.Owner)] = record.LastID
+	}
+	ids := make(map[string]bool, len(data.TimeLocks))
+	for i, lock := range data.TimeLocks {
+		key := string(GetTimeLockKey(lock.Owner, lock.ID))
+		if ids[key] {
+			return fmt.Errorf("time_locks[%d]: duplicate time lock %d of %s", i, lock.ID, lock.Owner)
+		}
+		if lastID, ok := lastIDs[string(lock.Owner)]; !ok || lock.ID < 1 || lock.ID > lastID {
+			return fmt.Errorf("time_locks[%d]: time lock %d of %s must be in range 1 to the last id of its owner", i, lock.ID, lock.Owner)
+		}
+		if lock.Amount.IsZero() || !lock.Amount.IsValid() || !lock.Amount.IsNotNegative() {
+			return fmt.Errorf("time_locks[%d]: locked coins %s of %s must be valid and positive", i, lock.Amount, lock.Owner)
+		}
+		if !lock.UnlockTime.After(lock.LockTime) {
+			return fmt.Errorf("time_locks[%d]: unlock time %v of %s must be after its lock time %v", i, lock.UnlockTime, lock.Owner, lock.LockTime)
+		}
+		ids[key] = true
+	}
+	return nil
+}
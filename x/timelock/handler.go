@@ -0,0 +1,62 @@
+package timelock
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgTimeLock:
+			return handleMsgTimeLock(ctx, msg, k)
+		case MsgTimeRelock:
+			return handleMsgTimeRelock(ctx, msg, k)
+		case MsgTimeUnlock:
+			return handleMsgTimeUnlock(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in timelock module").Result()
+		}
+	}
+}
+
+func handleMsgTimeLock(ctx sdk.Context, msg MsgTimeLock, k Keeper) sdk.Result {
+	lock, err := k.TimeLock(ctx, msg.From, msg.Description, msg.Amount, msg.UnlockTime)
+	if err != nil {
+		return err.Result()
+	}
+	// the id of the time lock is returned to the owner for the next msgs
+	return sdk.Result{
+		Data: []byte(strconv.FormatInt(lock.ID, 10)),
+		Tags: timeLockTags(lock),
+	}
+}
+
+func handleMsgTimeRelock(ctx sdk.Context, msg MsgTimeRelock, k Keeper) sdk.Result {
+	lock, err := k.TimeRelock(ctx, msg.From, msg.ID, msg.Description, msg.Amount, msg.UnlockTime)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: timeLockTags(lock),
+	}
+}
+
+func handleMsgTimeUnlock(ctx sdk.Context, msg MsgTimeUnlock, k Keeper) sdk.Result {
+	lock, err := k.TimeUnlock(ctx, msg.From, msg.ID)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: timeLockTags(lock),
+	}
+}
+
+func timeLockTags(lock TimeLock) sdk.Tags {
+	return sdk.NewTags(
+		TagOwner, []byte(lock.Owner.String()),
+		TagID, []byte(strconv.FormatInt(lock.ID, 10)),
+	)
+}
@@ -0,0 +1,43 @@
+package timelock
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the timelock module.
+func RegisterInvariants(ir sdk.InvariantRouter, k Keeper) {
+	ir.RegisterRoute("timelock", "locked-coins", LockedCoinsInvariant(k))
+}
+
+// LockedCoinsInvariant checks that the module account holds the coins of all
+// the time locks, and that all of them are in the unlock queue.
+func LockedCoinsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var locked sdk.Coins
+		var count int
+		iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), TimeLockKeyPrefix)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var lock TimeLock
+			k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &lock)
+			locked = locked.Plus(lock.Amount)
+			count++
+		}
+
+		if coins := k.ck.GetCoins(ctx, LockedCoinsAccAddr); !coins.IsEqual(locked) {
+			return fmt.Errorf("coins of the timelock account %s != locked coins %s", coins, locked)
+		}
+		var queued int
+		queue := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), UnlockQueueKeyPrefix)
+		defer queue.Close()
+		for ; queue.Valid(); queue.Next() {
+			queued++
+		}
+		if queued != count {
+			return fmt.Errorf("%d time locks in the unlock queue != %d time locks", queued, count)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,284 @@
+package timelock
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+const (
+	// LockedCoinsAccName is the module account holding the locked coins.
+	LockedCoinsAccName = "timelock"
+
+	// MaxLockPeriod bounds the unlock time of the time locks from the block
+	// time.
+	MaxLockPeriod = 10 * 365 * 24 * time.Hour
+	// MaxTimeLocksPerAccount bounds the time locks of an account, they are
+	// listed by the queries.
+	MaxTimeLocksPerAccount = 100
+	// DefaultMaxUnlocksPerBlock is the default number of due time locks the
+	// end blocker releases per block, the others wait for the next blocks or
+	// are claimed by their owners.
+	DefaultMaxUnlocksPerBlock = 100
+)
+
+var (
+	LockedCoinsAccAddr = auth.NewModuleAddress(LockedCoinsAccName)
+
+	TimeLockKeyPrefix    = []byte("lock:")  // owner, id -> TimeLock
+	UnlockQueueKeyPrefix = []byte("queue:") // unlock time, owner, id -> nil
+	LastIDKeyPrefix      = []byte("id:")    // owner -> the id of the last time lock
+)
+
+// GetTimeLockKey returns the key of the time lock id of owner, the time locks
+// of an account are sorted by id.
+func GetTimeLockKey(owner sdk.AccAddress, id int64) []byte {
+	return append(GetTimeLocksKey(owner), idBytes(id)...)
+}
+
+// GetTimeLocksKey returns the prefix of the time locks of owner.
+func GetTimeLocksKey(owner sdk.AccAddress) []byte {
+	return append(append([]byte{}, TimeLockKeyPrefix...), owner...)
+}
+
+// GetUnlockQueueKey returns the key of the time lock id of owner in the queue
+// of the unlocks, sorted by unlock time.
+func GetUnlockQueueKey(unlockTime time.Time, owner sdk.AccAddress, id int64) []byte {
+	key := append(GetUnlockQueueTimeKey(unlockTime), owner...)
+	return append(key, idBytes(id)...)
+}
+
+// GetUnlockQueueTimeKey returns the prefix of the unlocks due at unlockTime.
+func GetUnlockQueueTimeKey(unlockTime time.Time) []byte {
+	return append(append([]byte{}, UnlockQueueKeyPrefix...), sdk.FormatTimeBytes(unlockTime)...)
+}
+
+// GetLastIDKey returns the key of the id of the last time lock of owner.
+func GetLastIDKey(owner sdk.AccAddress) []byte {
+	return append(append([]byte{}, LastIDKeyPrefix...), owner...)
+}
+
+func idBytes(id int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id))
+	return bz
+}
+
+// TimeLock is an amount of coins of Owner locked until UnlockTime.
+type TimeLock struct {
+	Owner       sdk.AccAddress `json:"owner"`
+	ID          int64          `json:"id"`
+	Description string         `json:"description"`
+	Amount      sdk.Coins      `json:"amount"`
+	LockTime    time.Time      `json:"lock_time"`
+	UnlockTime  time.Time      `json:"unlock_time"`
+}
+
+func (l TimeLock) String() string {
+	return fmt.Sprintf(`Time Lock %d
+  Owner:       %s
+  Description: %s
+  Amount:      %s
+  Lock Time:   %s
+  Unlock Time: %s`, l.ID, l.Owner, l.Description, l.Amount,
+		l.LockTime.UTC().Format(time.RFC3339), l.UnlockTime.UTC().Format(time.RFC3339))
+}
+
+// IsDue returns whether the coins of the time lock can be released at t.
+func (l TimeLock) IsDue(t time.Time) bool {
+	return !l.UnlockTime.After(t)
+}
+
+// Keeper of the time locks, the locked coins are held by the
+// LockedCoinsAccAddr module account until they are released to their owner.
+type Keeper struct {
+	storeKey           sdk.StoreKey
+	cdc                *codec.Codec
+	ck                 bank.Keeper
+	maxUnlocksPerBlock int
+	codespace          sdk.CodespaceType
+}
+
+// NewKeeper returns a timelock keeper releasing up to maxUnlocksPerBlock due
+// time locks per block, DefaultMaxUnlocksPerBlock if it is not positive.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ck bank.Keeper, maxUnlocksPerBlock int, codespace sdk.CodespaceType) Keeper {
+	if maxUnlocksPerBlock <= 0 {
+		maxUnlocksPerBlock = DefaultMaxUnlocksPerBlock
+	}
+	return Keeper{
+		storeKey:           key,
+		cdc:                cdc,
+		ck:                 ck,
+		maxUnlocksPerBlock: maxUnlocksPerBlock,
+		codespace:          codespace,
+	}
+}
+
+// GetTimeLock returns the time lock id of owner.
+func (k Keeper) GetTimeLock(ctx sdk.Context, owner sdk.AccAddress, id int64) (lock TimeLock, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetTimeLockKey(owner, id))
+	if bz == nil {
+		return lock, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &lock)
+	return lock, true
+}
+
+// GetTimeLocks returns the time locks of owner, sorted by id.
+func (k Keeper) GetTimeLocks(ctx sdk.Context, owner sdk.AccAddress) []TimeLock {
+	locks := make([]TimeLock, 0)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetTimeLocksKey(owner))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var lock TimeLock
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &lock)
+		locks = append(locks, lock)
+	}
+	return locks
+}
+
+// IterateDueTimeLocks iterates over the time locks due at t, sorted by unlock
+// time, until iter returns true.
+func (k Keeper) IterateDueTimeLocks(ctx sdk.Context, t time.Time, iter func(lock TimeLock) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(UnlockQueueKeyPrefix, sdk.PrefixEndBytes(GetUnlockQueueTimeKey(t)))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		owner := sdk.AccAddress(key[len(GetUnlockQueueTimeKey(t)) : len(key)-8])
+		id := int64(binary.BigEndian.Uint64(key[len(key)-8:]))
+		lock, found := k.GetTimeLock(ctx, owner, id)
+		if !found {
+			panic(fmt.Sprintf("the unlock queue has the unknown time lock %d of %s", id, owner))
+		}
+		if iter(lock) {
+			return
+		}
+	}
+}
+
+func (k Keeper) setTimeLock(ctx sdk.Context, lock TimeLock) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetTimeLockKey(lock.Owner, lock.ID), k.cdc.MustMarshalBinaryLengthPrefixed(lock))
+	store.Set(GetUnlockQueueKey(lock.UnlockTime, lock.Owner, lock.ID), []byte{})
+}
+
+func (k Keeper) deleteTimeLock(ctx sdk.Context, lock TimeLock) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetTimeLockKey(lock.Owner, lock.ID))
+	store.Delete(GetUnlockQueueKey(lock.UnlockTime, lock.Owner, lock.ID))
+}
+
+func (k Keeper) nextID(ctx sdk.Context, owner sdk.AccAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+	var id int64
+	if bz := store.Get(GetLastIDKey(owner)); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &id)
+	}
+	id++
+	store.Set(GetLastIDKey(owner), k.cdc.MustMarshalBinaryLengthPrefixed(id))
+	return id
+}
+
+func (k Keeper) validateUnlockTime(ctx sdk.Context, unlockTime time.Time) sdk.Error {
+	blockTime := ctx.BlockHeader().Time
+	if !unlockTime.After(blockTime) {
+		return ErrInvalidUnlockTime(k.codespace, "unlock time must be after the block time")
+	}
+	if unlockTime.Sub(blockTime) > MaxLockPeriod {
+		return ErrInvalidUnlockTime(k.codespace, fmt.Sprintf("unlock time must be within %s of the block time", MaxLockPeriod))
+	}
+	return nil
+}
+
+// TimeLock locks amount of the coins of owner until unlockTime and returns
+// the time lock.
+func (k Keeper) TimeLock(ctx sdk.Context, owner sdk.AccAddress, description string, amount sdk.Coins, unlockTime time.Time) (TimeLock, sdk.Error) {
+	if err := k.validateUnlockTime(ctx, unlockTime); err != nil {
+		return TimeLock{}, err
+	}
+	if len(k.GetTimeLocks(ctx, owner)) >= MaxTimeLocksPerAccount {
+		return TimeLock{}, ErrTooManyTimeLocks(k.codespace, MaxTimeLocksPerAccount)
+	}
+	if _, err := k.ck.SendCoins(ctx, owner, LockedCoinsAccAddr, amount); err != nil {
+		return TimeLock{}, err
+	}
+
+	lock := TimeLock{
+		Owner:       owner,
+		ID:          k.nextID(ctx, owner),
+		Description: description,
+		Amount:      amount,
+		LockTime:    ctx.BlockHeader().Time,
+		UnlockTime:  unlockTime,
+	}
+	k.setTimeLock(ctx, lock)
+	return lock, nil
+}
+
+// TimeRelock updates the time lock id of owner, the empty description,
+// amount and unlock time are kept. The amount may only be raised and the
+// unlock time only extended, so that a relock never releases coins early.
+func (k Keeper) TimeRelock(ctx sdk.Context, owner sdk.AccAddress, id int64, description string, amount sdk.Coins, unlockTime time.Time) (TimeLock, sdk.Error) {
+	lock, found := k.GetTimeLock(ctx, owner, id)
+	if !found {
+		return TimeLock{}, ErrUnknownTimeLock(k.codespace, owner, id)
+	}
+	relocked := lock
+	if description != "" {
+		relocked.Description = description
+	}
+	if !unlockTime.IsZero() {
+		if unlockTime.Before(lock.UnlockTime) {
+			return TimeLock{}, ErrInvalidRelock(k.codespace, "unlock time cannot be earlier than the current one")
+		}
+		if err := k.validateUnlockTime(ctx, unlockTime); err != nil {
+			return TimeLock{}, err
+		}
+		relocked.UnlockTime = unlockTime
+	}
+	if len(amount) != 0 {
+		if !amount.IsGTE(lock.Amount) {
+			return TimeLock{}, ErrInvalidRelock(k.codespace, fmt.Sprintf("amount cannot be lower than the locked %s", lock.Amount))
+		}
+		if extra := amount.Minus(lock.Amount); !extra.IsZero() {
+			if _, err := k.ck.SendCoins(ctx, owner, LockedCoinsAccAddr, extra); err != nil {
+				return TimeLock{}, err
+			}
+		}
+		relocked.Amount = amount
+	}
+
+	k.deleteTimeLock(ctx, lock)
+	k.setTimeLock(ctx, relocked)
+	return relocked, nil
+}
+
+// TimeUnlock releases the coins of the time lock id of owner, its unlock time
+// must be passed.
+func (k Keeper) TimeUnlock(ctx sdk.Context, owner sdk.AccAddress, id int64) (TimeLock, sdk.Error) {
+	lock, found := k.GetTimeLock(ctx, owner, id)
+	if !found {
+		return TimeLock{}, ErrUnknownTimeLock(k.codespace, owner, id)
+	}
+	if !lock.IsDue(ctx.BlockHeader().Time) {
+		return TimeLock{}, ErrTimeLockNotDue(k.codespace, id)
+	}
+	if err := k.release(ctx, lock); err != nil {
+		return TimeLock{}, err
+	}
+	return lock, nil
+}
+
+func (k Keeper) release(ctx sdk.Context, lock TimeLock) sdk.Error {
+	if _, err := k.ck.SendCoins(ctx, LockedCoinsAccAddr, lock.Owner, lock.Amount); err != nil {
+		return err
+	}
+	k.deleteTimeLock(ctx, lock)
+	return nil
+}
@@ -0,0 +1,158 @@
+package timelock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+var (
+	addr1 = sdk.AccAddress([]byte("addr1_______________"))
+	addr2 = sdk.AccAddress([]byte("addr2_______________"))
+
+	initCoins = sdk.Coins{sdk.NewCoin("BNB", 1000)}
+)
+
+func createTestInput(t *testing.T, maxUnlocksPerBlock int) (sdk.Context, Keeper, bank.Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyTimeLock := sdk.NewKVStoreKey("timelock")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyTimeLock, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	header := abci.Header{Height: 10, Time: time.Unix(1000, 0)}
+	ctx := sdk.NewContext(ms, header, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache).WithEventManager(sdk.NewEventManager())
+
+	ck := bank.NewBaseKeeper(auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount))
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		_, _, err := ck.AddCoins(ctx, addr, initCoins)
+		require.NoError(t, err)
+	}
+	return ctx, NewKeeper(cdc, keyTimeLock, ck, maxUnlocksPerBlock, DefaultCodespace), ck
+}
+
+func coins(amount int64) sdk.Coins {
+	return sdk.Coins{sdk.NewCoin("BNB", amount)}
+}
+
+func TestTimeLock(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+
+	// the unlock time must be in the future and within the max lock period
+	res := handler(ctx, NewMsgTimeLock(addr1, "team", coins(100), time.Unix(1000, 0)))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidUnlockTime), res.Code)
+	res = handler(ctx, NewMsgTimeLock(addr1, "team", coins(100), time.Unix(1000, 0).Add(MaxLockPeriod+time.Second)))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidUnlockTime), res.Code)
+	require.False(t, handler(ctx, NewMsgTimeLock(addr1, "team", coins(2000), time.Unix(2000, 0))).IsOK())
+
+	res = handler(ctx, NewMsgTimeLock(addr1, "team", coins(100), time.Unix(2000, 0)))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "1", string(res.Data))
+	res = handler(ctx, NewMsgTimeLock(addr1, "burn", coins(200), time.Unix(3000, 0)))
+	require.Equal(t, "2", string(res.Data))
+	require.Equal(t, coins(700), ck.GetCoins(ctx, addr1))
+	require.Equal(t, coins(300), ck.GetCoins(ctx, LockedCoinsAccAddr))
+
+	locks := keeper.GetTimeLocks(ctx, addr1)
+	require.Len(t, locks, 2)
+	require.Equal(t, TimeLock{
+		Owner:       addr1,
+		ID:          1,
+		Description: "team",
+		Amount:      coins(100),
+		LockTime:    time.Unix(1000, 0).UTC(),
+		UnlockTime:  time.Unix(2000, 0).UTC(),
+	}, locks[0])
+	require.Empty(t, keeper.GetTimeLocks(ctx, addr2))
+	require.NoError(t, LockedCoinsInvariant(keeper)(ctx))
+}
+
+func TestTimeRelock(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 0)
+	handler := NewHandler(keeper)
+	require.True(t, handler(ctx, NewMsgTimeLock(addr1, "team", coins(100), time.Unix(2000, 0))).IsOK())
+
+	res := handler(ctx, NewMsgTimeRelock(addr2, 1, "other", nil, time.Time{}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownTimeLock), res.Code)
+	// a relock never releases coins early
+	res = handler(ctx, NewMsgTimeRelock(addr1, 1, "", coins(50), time.Time{}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidRelock), res.Code)
+	res = handler(ctx, NewMsgTimeRelock(addr1, 1, "", nil, time.Unix(1500, 0)))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidRelock), res.Code)
+
+	res = handler(ctx, NewMsgTimeRelock(addr1, 1, "team lockup", coins(150), time.Unix(5000, 0)))
+	require.True(t, res.IsOK(), res.Log)
+	lock, found := keeper.GetTimeLock(ctx, addr1, 1)
+	require.True(t, found)
+	require.Equal(t, "team lockup", lock.Description)
+	require.Equal(t, coins(150), lock.Amount)
+	require.Equal(t, time.Unix(5000, 0).UTC(), lock.UnlockTime)
+	require.Equal(t, coins(850), ck.GetCoins(ctx, addr1))
+	require.NoError(t, LockedCoinsInvariant(keeper)(ctx))
+
+	// the lock is not due at its former unlock time
+	ctx = ctx.WithBlockTime(time.Unix(2000, 0))
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetTimeLock(ctx, addr1, 1)
+	require.True(t, found)
+}
+
+func TestTimeUnlock(t *testing.T) {
+	ctx, keeper, ck := createTestInput(t, 2)
+	handler := NewHandler(keeper)
+	for i := int64(1); i <= 4; i++ {
+		require.True(t, handler(ctx, NewMsgTimeLock(addr1, "lock", coins(100), time.Unix(1000+i*100, 0))).IsOK())
+	}
+	require.True(t, handler(ctx, NewMsgTimeLock(addr2, "lock", coins(100), time.Unix(1100, 0))).IsOK())
+
+	// the locks cannot be claimed before their unlock time
+	res := handler(ctx, NewMsgTimeUnlock(addr1, 1))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeTimeLockNotDue), res.Code)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, coins(600), ck.GetCoins(ctx, addr1))
+
+	// the end blocker releases the due locks up to its limit per block
+	ctx = ctx.WithBlockTime(time.Unix(1300, 0))
+	EndBlocker(ctx, keeper)
+	require.Len(t, ctx.EventManager().Events(), 2)
+	require.Equal(t, coins(700), ck.GetCoins(ctx, addr1))
+	require.Equal(t, coins(1000), ck.GetCoins(ctx, addr2))
+	require.Len(t, keeper.GetTimeLocks(ctx, addr1), 3)
+
+	// the owner claims a due lock before the end blocker
+	res = handler(ctx, NewMsgTimeUnlock(addr1, 3))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, coins(800), ck.GetCoins(ctx, addr1))
+	res = handler(ctx, NewMsgTimeUnlock(addr1, 3))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownTimeLock), res.Code)
+
+	EndBlocker(ctx, keeper)
+	require.Equal(t, coins(900), ck.GetCoins(ctx, addr1))
+	locks := keeper.GetTimeLocks(ctx, addr1)
+	require.Len(t, locks, 1)
+	require.Equal(t, int64(4), locks[0].ID)
+	require.Equal(t, coins(100), ck.GetCoins(ctx, LockedCoinsAccAddr))
+	require.NoError(t, LockedCoinsInvariant(keeper)(ctx))
+
+	// the ids are not reused
+	res = handler(ctx, NewMsgTimeLock(addr1, "lock", coins(100), time.Unix(2000, 0)))
+	require.Equal(t, "5", string(res.Data))
+}
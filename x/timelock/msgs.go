@@ -0,0 +1,197 @@
+package timelock
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const (
+	MsgRoute          = "timelock"
+	TypeMsgTimeLock   = "timeLock"
+	TypeMsgTimeRelock = "timeRelock"
+	TypeMsgTimeUnlock = "timeUnlock"
+
+	// MaxDescriptionLength bounds the description of a time lock.
+	MaxDescriptionLength = 128
+)
+
+// verify interface at compile time
+var (
+	_ sdk.Msg = MsgTimeLock{}
+	_ sdk.Msg = MsgTimeRelock{}
+	_ sdk.Msg = MsgTimeUnlock{}
+)
+
+// MsgTimeLock locks Amount of the coins of From until UnlockTime, e.g. for a
+// team lockup or a commitment not to sell.
+type MsgTimeLock struct {
+	From        sdk.AccAddress `json:"from"`
+	Description string         `json:"description"`
+	Amount      sdk.Coins      `json:"amount"`
+	UnlockTime  time.Time      `json:"unlock_time"`
+}
+
+func NewMsgTimeLock(from sdk.AccAddress, description string, amount sdk.Coins, unlockTime time.Time) MsgTimeLock {
+	return MsgTimeLock{
+		From:        from,
+		Description: description,
+		Amount:      amount,
+		UnlockTime:  unlockTime,
+	}
+}
+
+// nolint
+func (msg MsgTimeLock) Route() string { return MsgRoute }
+func (msg MsgTimeLock) Type() string  { return TypeMsgTimeLock }
+func (msg MsgTimeLock) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTimeLock) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check, the unlock time is checked against the block time by
+// the handler
+func (msg MsgTimeLock) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if err := validateDescription(msg.Description); err != nil {
+		return err
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	if msg.UnlockTime.IsZero() {
+		return ErrInvalidUnlockTime(DefaultCodespace, "unlock time is required")
+	}
+	return nil
+}
+
+func (msg MsgTimeLock) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// MsgTimeRelock updates the time lock ID of From, the empty fields are kept.
+// The amount may only be raised, the extra coins are locked from From, and
+// the unlock time may only be extended.
+type MsgTimeRelock struct {
+	From        sdk.AccAddress `json:"from"`
+	ID          int64          `json:"id"`
+	Description string         `json:"description"`
+	Amount      sdk.Coins      `json:"amount"`
+	UnlockTime  time.Time      `json:"unlock_time"`
+}
+
+func NewMsgTimeRelock(from sdk.AccAddress, id int64, description string, amount sdk.Coins, unlockTime time.Time) MsgTimeRelock {
+	return MsgTimeRelock{
+		From:        from,
+		ID:          id,
+		Description: description,
+		Amount:      amount,
+		UnlockTime:  unlockTime,
+	}
+}
+
+// nolint
+func (msg MsgTimeRelock) Route() string { return MsgRoute }
+func (msg MsgTimeRelock) Type() string  { return TypeMsgTimeRelock }
+func (msg MsgTimeRelock) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTimeRelock) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgTimeRelock) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if msg.ID <= 0 {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("invalid time lock id %d", msg.ID))
+	}
+	if msg.Description == "" && len(msg.Amount) == 0 && msg.UnlockTime.IsZero() {
+		return ErrInvalidRelock(DefaultCodespace, "nothing to update")
+	}
+	if msg.Description != "" {
+		if err := validateDescription(msg.Description); err != nil {
+			return err
+		}
+	}
+	if len(msg.Amount) != 0 && (!msg.Amount.IsValid() || !msg.Amount.IsPositive()) {
+		return sdk.ErrInvalidCoins(msg.Amount.String())
+	}
+	return nil
+}
+
+func (msg MsgTimeRelock) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// MsgTimeUnlock claims the coins of the time lock ID of From once its unlock
+// time is passed, before the end blocker releases them.
+type MsgTimeUnlock struct {
+	From sdk.AccAddress `json:"from"`
+	ID   int64          `json:"id"`
+}
+
+func NewMsgTimeUnlock(from sdk.AccAddress, id int64) MsgTimeUnlock {
+	return MsgTimeUnlock{From: from, ID: id}
+}
+
+// nolint
+func (msg MsgTimeUnlock) Route() string { return MsgRoute }
+func (msg MsgTimeUnlock) Type() string  { return TypeMsgTimeUnlock }
+func (msg MsgTimeUnlock) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTimeUnlock) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgTimeUnlock) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if msg.ID <= 0 {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("invalid time lock id %d", msg.ID))
+	}
+	return nil
+}
+
+func (msg MsgTimeUnlock) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func validateDescription(description string) sdk.Error {
+	if len(description) == 0 {
+		return ErrInvalidInput(DefaultCodespace, "description is required")
+	}
+	if len(description) > MaxDescriptionLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("description is longer than %d characters", MaxDescriptionLength))
+	}
+	return nil
+}
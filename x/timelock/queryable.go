@@ -0,0 +1,84 @@
+package timelock
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the timelock Querier
+const (
+	QueryTimeLock  = "timelock"
+	QueryTimeLocks = "timelocks"
+	QueryDue       = "due"
+)
+
+// Params for query 'custom/timelock/timelock'
+type QueryTimeLockParams struct {
+	Owner sdk.AccAddress
+	ID    int64
+}
+
+// Params for query 'custom/timelock/timelocks'
+type QueryTimeLocksParams struct {
+	Owner sdk.AccAddress
+}
+
+// Params for query 'custom/timelock/due', the pending unlocks of the time
+// locks due at the block time, at most Limit of them.
+type QueryDueParams struct {
+	Limit int
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryTimeLock:
+			var params QueryTimeLockParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			lock, found := keeper.GetTimeLock(ctx, params.Owner, params.ID)
+			if !found {
+				return nil, ErrUnknownTimeLock(keeper.codespace, params.Owner, params.ID)
+			}
+			return marshalJSON(keeper.cdc, lock)
+		case QueryTimeLocks:
+			var params QueryTimeLocksParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			if len(params.Owner) == 0 {
+				return nil, sdk.ErrUnknownRequest("owner is missing")
+			}
+			return marshalJSON(keeper.cdc, keeper.GetTimeLocks(ctx, params.Owner))
+		case QueryDue:
+			params := QueryDueParams{Limit: DefaultMaxUnlocksPerBlock}
+			if len(req.Data) != 0 {
+				if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+				}
+			}
+			if params.Limit <= 0 || params.Limit > DefaultMaxUnlocksPerBlock {
+				params.Limit = DefaultMaxUnlocksPerBlock
+			}
+			due := make([]TimeLock, 0)
+			keeper.IterateDueTimeLocks(ctx, ctx.BlockHeader().Time, func(lock TimeLock) bool {
+				due = append(due, lock)
+				return len(due) >= params.Limit
+			})
+			return marshalJSON(keeper.cdc, due)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown timelock query endpoint")
+		}
+	}
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
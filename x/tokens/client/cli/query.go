@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+const (
+	flagOffset = "offset"
+	flagLimit  = "limit"
+)
+
+// GetCmdQueryToken implements the command to query an issued token.
+func GetCmdQueryToken(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token [symbol]",
+		Short: "Query an issued token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryToken), tokens.QueryTokenParams{Symbol: args[0]})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryTokens implements the command to list the issued tokens.
+func GetCmdQueryTokens(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "List the issued tokens, sorted by symbol",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := tokens.QueryTokensParams{Offset: viper.GetInt(flagOffset), Limit: viper.GetInt(flagLimit)}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryTokens), params)
+		},
+	}
+
+	cmd.Flags().Int(flagOffset, 0, "number of tokens to skip")
+	cmd.Flags().Int(flagLimit, tokens.DefaultLimit, "max number of tokens")
+	return cmd
+}
+
+// GetCmdQueryFrozen implements the command to query the frozen coins of an
+// account.
+func GetCmdQueryFrozen(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "frozen [address]",
+		Short: "Query the frozen coins of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			return query(cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryFrozen), tokens.QueryFrozenParams{Address: addr})
+		},
+	}
+
+	return cmd
+}
+
+func query(cdc *codec.Codec, path string, params interface{}) error {
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		return err
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(res))
+	return nil
+}
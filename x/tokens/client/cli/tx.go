@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+const (
+	flagName        = "name"
+	flagSymbol      = "symbol"
+	flagTotalSupply = "total-supply"
+	flagMaxSupply   = "max-supply"
+	flagMintable    = "mintable"
+)
+
+// GetCmdIssue implements the command issuing a token. The symbol of the token
+// is the given symbol with a suffix, it is returned in the data of the tx.
+func GetCmdIssue(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Args:  cobra.NoArgs,
+		Short: "issue a token owned by the sender, crediting its total supply to the sender",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			totalSupply := viper.GetInt64(flagTotalSupply)
+			maxSupply := viper.GetInt64(flagMaxSupply)
+			mintable := viper.GetBool(flagMintable)
+			if maxSupply == 0 && !mintable {
+				maxSupply = totalSupply
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return tokens.NewMsgIssue(from, viper.GetString(flagName), viper.GetString(flagSymbol), totalSupply, maxSupply, mintable)
+			})
+		},
+	}
+
+	cmd.Flags().String(flagName, "", "name of the token")
+	cmd.Flags().String(flagSymbol, "", "symbol of the token, 2 to 8 upper case letters or digits")
+	cmd.Flags().Int64(flagTotalSupply, 0, "total supply of the token")
+	cmd.Flags().Int64(flagMaxSupply, 0, "max supply of a mintable token, the total supply of a token which is not")
+	cmd.Flags().Bool(flagMintable, false, "whether the owner may mint the token up to its max supply")
+	return cmd
+}
+
+// GetCmdMint implements the command minting a token to its owner.
+func GetCmdMint(cdc *codec.Codec) *cobra.Command {
+	return amountCmd(cdc, "mint", "mint a token owned by the sender, up to its max supply",
+		func(from sdk.AccAddress, symbol string, amount int64) sdk.Msg {
+			return tokens.NewMsgMint(from, symbol, amount)
+		})
+}
+
+// GetCmdBurn implements the command burning coins of a token of its owner.
+func GetCmdBurn(cdc *codec.Codec) *cobra.Command {
+	return amountCmd(cdc, "burn", "burn coins of a token owned by the sender",
+		func(from sdk.AccAddress, symbol string, amount int64) sdk.Msg {
+			return tokens.NewMsgBurn(from, symbol, amount)
+		})
+}
+
+// GetCmdFreeze implements the command freezing coins of the sender.
+func GetCmdFreeze(cdc *codec.Codec) *cobra.Command {
+	return amountCmd(cdc, "freeze", "freeze coins of the sender, they are not transferable until unfrozen",
+		func(from sdk.AccAddress, symbol string, amount int64) sdk.Msg {
+			return tokens.NewMsgFreeze(from, symbol, amount)
+		})
+}
+
+// GetCmdUnfreeze implements the command unfreezing the frozen coins of the
+// sender.
+func GetCmdUnfreeze(cdc *codec.Codec) *cobra.Command {
+	return amountCmd(cdc, "unfreeze", "unfreeze frozen coins of the sender",
+		func(from sdk.AccAddress, symbol string, amount int64) sdk.Msg {
+			return tokens.NewMsgUnfreeze(from, symbol, amount)
+		})
+}
+
+func amountCmd(cdc *codec.Codec, use, short string, newMsg func(from sdk.AccAddress, symbol string, amount int64) sdk.Msg) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [symbol] [amount]",
+		Args:  cobra.ExactArgs(2),
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "amount must be an integer")
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return newMsg(from, args[0], amount)
+			})
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdTransferOwnership implements the command transferring the ownership
+// of a token.
+func GetCmdTransferOwnership(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer-ownership [symbol] [new-owner]",
+		Args:  cobra.ExactArgs(2),
+		Short: "transfer the ownership of a token owned by the sender",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newOwner, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			return completeAndBroadcast(cdc, func(from sdk.AccAddress) sdk.Msg {
+				return tokens.NewMsgTransferOwnership(from, args[0], newOwner)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func completeAndBroadcast(cdc *codec.Codec, newMsg func(from sdk.AccAddress) sdk.Msg) error {
+	txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{newMsg(from)}
+	if cliCtx.GenerateOnly {
+		return utils.PrintUnsignedStdTx(txBldr, cliCtx, msgs)
+	}
+	return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, msgs)
+}
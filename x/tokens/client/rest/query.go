@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/tokens"
+)
+
+// RegisterRoutes registers the tokens REST handlers to a router
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
+	r.HandleFunc("/tokens", tokensHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/tokens/{symbol}", tokenHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+	r.HandleFunc("/tokens/frozen/{address}", frozenHandlerFn(cliCtx, cdc, queryRoute)).Methods("GET")
+}
+
+// http request handler to list the issued tokens, with the offset and limit
+// query parameters
+func tokensHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params tokens.QueryTokensParams
+		var err error
+		if offsetStr := r.FormValue("offset"); offsetStr != "" {
+			if params.Offset, err = strconv.Atoi(offsetStr); err != nil || params.Offset < 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "offset parameter is not a valid non negative integer")
+				return
+			}
+		}
+		if limitStr := r.FormValue("limit"); limitStr != "" {
+			if params.Limit, err = strconv.Atoi(limitStr); err != nil || params.Limit <= 0 {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, "limit parameter is not a valid positive integer")
+				return
+			}
+		}
+
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryTokens), params)
+	}
+}
+
+// http request handler to query an issued token
+func tokenHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := tokens.QueryTokenParams{Symbol: mux.Vars(r)["symbol"]}
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryToken), params)
+	}
+}
+
+// http request handler to query the frozen coins of an account
+func frozenHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		addr, err := sdk.AccAddressFromBech32(mux.Vars(r)["address"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query(w, cliCtx, cdc, fmt.Sprintf("custom/%s/%s", queryRoute, tokens.QueryFrozen), tokens.QueryFrozenParams{Address: addr})
+	}
+}
+
+func query(w http.ResponseWriter, cliCtx context.CLIContext, cdc *codec.Codec, path string, params interface{}) {
+	bz, err := cdc.MarshalJSON(params)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	res, err := cliCtx.QueryWithData(path, bz)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(res)
+}
@@ -0,0 +1,25 @@
+package tokens
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Register concrete types on codec codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgIssue{}, "cosmos-sdk/MsgIssue", nil)
+	cdc.RegisterConcrete(MsgMint{}, "cosmos-sdk/MsgMint", nil)
+	cdc.RegisterConcrete(MsgBurn{}, "cosmos-sdk/MsgBurn", nil)
+	cdc.RegisterConcrete(MsgFreeze{}, "cosmos-sdk/MsgFreeze", nil)
+	cdc.RegisterConcrete(MsgUnfreeze{}, "cosmos-sdk/MsgUnfreeze", nil)
+	cdc.RegisterConcrete(MsgTransferOwnership{}, "cosmos-sdk/MsgTransferOwnership", nil)
+}
+
+// generic sealed codec to be used throughout sdk
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	MsgCdc = cdc.Seal()
+}
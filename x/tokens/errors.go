@@ -0,0 +1,66 @@
+// nolint
+package tokens
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// Default tokens codespace
+	DefaultCodespace sdk.CodespaceType = 36
+
+	CodeInvalidInput       sdk.CodeType = 101
+	CodeInvalidSymbol      sdk.CodeType = 102
+	CodeDuplicatedSymbol   sdk.CodeType = 103
+	CodeUnknownToken       sdk.CodeType = 104
+	CodeNotTokenOwner      sdk.CodeType = 105
+	CodeTokenNotMintable   sdk.CodeType = 106
+	CodeExceedsMaxSupply   sdk.CodeType = 107
+	CodeInsufficientFrozen sdk.CodeType = 108
+)
+
+// The registered errors of the codes of the tokens codespace.
+var (
+	ErrCodeInvalidInput       = sdk.Register(DefaultCodespace, CodeInvalidInput, "invalid input")
+	ErrCodeInvalidSymbol      = sdk.Register(DefaultCodespace, CodeInvalidSymbol, "invalid symbol")
+	ErrCodeDuplicatedSymbol   = sdk.Register(DefaultCodespace, CodeDuplicatedSymbol, "duplicated symbol")
+	ErrCodeUnknownToken       = sdk.Register(DefaultCodespace, CodeUnknownToken, "unknown token")
+	ErrCodeNotTokenOwner      = sdk.Register(DefaultCodespace, CodeNotTokenOwner, "not the token owner")
+	ErrCodeTokenNotMintable   = sdk.Register(DefaultCodespace, CodeTokenNotMintable, "token not mintable")
+	ErrCodeExceedsMaxSupply   = sdk.Register(DefaultCodespace, CodeExceedsMaxSupply, "exceeds the max supply")
+	ErrCodeInsufficientFrozen = sdk.Register(DefaultCodespace, CodeInsufficientFrozen, "insufficient frozen tokens")
+)
+
+func ErrInvalidInput(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, msg)
+}
+
+func ErrInvalidSymbol(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidSymbol, msg)
+}
+
+func ErrDuplicatedSymbol(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicatedSymbol, fmt.Sprintf("token %s is already issued", symbol))
+}
+
+func ErrUnknownToken(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownToken, fmt.Sprintf("token %s is not issued", symbol))
+}
+
+func ErrNotTokenOwner(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeNotTokenOwner, fmt.Sprintf("only the owner of token %s may do this", symbol))
+}
+
+func ErrTokenNotMintable(codespace sdk.CodespaceType, symbol string) sdk.Error {
+	return sdk.NewError(codespace, CodeTokenNotMintable, fmt.Sprintf("token %s is not mintable", symbol))
+}
+
+func ErrExceedsMaxSupply(codespace sdk.CodespaceType, symbol string, maxSupply int64) sdk.Error {
+	return sdk.NewError(codespace, CodeExceedsMaxSupply, fmt.Sprintf("total supply of %s cannot exceed %d", symbol, maxSupply))
+}
+
+func ErrInsufficientFrozen(codespace sdk.CodespaceType, symbol string, frozen int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientFrozen, fmt.Sprintf("only %d of %s are frozen", frozen, symbol))
+}
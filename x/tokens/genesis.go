@@ -0,0 +1,86 @@
+package tokens
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState is the state of the tokens module at genesis. The frozen
+// coins are held by the FrozenCoinsAccAddr account of the genesis accounts.
+type GenesisState struct {
+	Tokens []Token        `json:"tokens"`
+	Frozen []FrozenRecord `json:"frozen"`
+}
+
+// FrozenRecord is the frozen amount of the coins Symbol of Address.
+type FrozenRecord struct {
+	Address sdk.AccAddress `json:"address"`
+	Symbol  string         `json:"symbol"`
+	Amount  int64          `json:"amount"`
+}
+
+// InitGenesis sets the issued tokens and the frozen coins.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, token := range data.Tokens {
+		keeper.setToken(ctx, token)
+	}
+	for _, record := range data.Frozen {
+		keeper.setFrozen(ctx, record.Address, record.Symbol, record.Amount)
+	}
+}
+
+// WriteGenesis returns the issued tokens, sorted by symbol, and the frozen
+// coins, sorted by address.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var data GenesisState
+	keeper.IterateTokens(ctx, func(token Token) bool {
+		data.Tokens = append(data.Tokens, token)
+		return false
+	})
+
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), FrozenKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(FrozenKeyPrefix):]
+		var frozen int64
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &frozen)
+		data.Frozen = append(data.Frozen, FrozenRecord{
+			Address: sdk.AccAddress(key[:sdk.AddrLen]),
+			Symbol:  string(key[sdk.AddrLen:]),
+			Amount:  frozen,
+		})
+	}
+	return data
+}
+
+// ValidateGenesis checks that the symbols of the tokens are unique, their
+// supplies are within their max supplies and the frozen amounts are positive.
+func ValidateGenesis(data GenesisState) error {
+	symbols := make(map[string]bool, len(data.Tokens))
+	for i, token := range data.Tokens {
+		if token.Symbol == "" {
+			return fmt.Errorf("tokens[%d]: the symbol of a token cannot be empty", i)
+		}
+		if symbols[token.Symbol] {
+			return fmt.Errorf("tokens[%d]: duplicate token %s", i, token.Symbol)
+		}
+		if token.TotalSupply < 0 || token.TotalSupply > token.MaxSupply {
+			return fmt.Errorf("tokens[%d]: total supply %d of token %s must be in range 0 to the max supply %d",
+				i, token.TotalSupply, token.Symbol, token.MaxSupply)
+		}
+		symbols[token.Symbol] = true
+	}
+	frozen := make(map[string]bool, len(data.Frozen))
+	for i, record := range data.Frozen {
+		if record.Amount <= 0 {
+			return fmt.Errorf("frozen[%d]: frozen amount %d of %s of %s must be positive", i, record.Amount, record.Symbol, record.Address)
+		}
+		key := string(GetFrozenKey(record.Address, record.Symbol))
+		if frozen[key] {
+			return fmt.Errorf("frozen[%d]: duplicate frozen coins %s of %s", i, record.Symbol, record.Address)
+		}
+		frozen[key] = true
+	}
+	return nil
+}
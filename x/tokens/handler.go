@@ -0,0 +1,107 @@
+package tokens
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	TagSymbol  = "symbol"
+	TagOwner   = "owner"
+	TagAccount = "account"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		// NOTE msg already has validate basic run
+		switch msg := msg.(type) {
+		case MsgIssue:
+			return handleMsgIssue(ctx, msg, k)
+		case MsgMint:
+			return handleMsgMint(ctx, msg, k)
+		case MsgBurn:
+			return handleMsgBurn(ctx, msg, k)
+		case MsgFreeze:
+			return handleMsgFreeze(ctx, msg, k)
+		case MsgUnfreeze:
+			return handleMsgUnfreeze(ctx, msg, k)
+		case MsgTransferOwnership:
+			return handleMsgTransferOwnership(ctx, msg, k)
+		default:
+			return sdk.ErrTxDecode("invalid message parse in tokens module").Result()
+		}
+	}
+}
+
+func handleMsgIssue(ctx sdk.Context, msg MsgIssue, k Keeper) sdk.Result {
+	token, err := k.IssueToken(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+	// the symbol of the token is returned to the owner for the next msgs
+	return sdk.Result{
+		Data: []byte(token.Symbol),
+		Tags: tokenTags(token),
+	}
+}
+
+func handleMsgMint(ctx sdk.Context, msg MsgMint, k Keeper) sdk.Result {
+	token, err := k.MintToken(ctx, msg.From, msg.Symbol, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tokenTags(token),
+	}
+}
+
+func handleMsgBurn(ctx sdk.Context, msg MsgBurn, k Keeper) sdk.Result {
+	token, err := k.BurnToken(ctx, msg.From, msg.Symbol, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tokenTags(token),
+	}
+}
+
+func handleMsgFreeze(ctx sdk.Context, msg MsgFreeze, k Keeper) sdk.Result {
+	if err := k.Freeze(ctx, msg.From, msg.Symbol, msg.Amount); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: frozenTags(msg.From, msg.Symbol),
+	}
+}
+
+func handleMsgUnfreeze(ctx sdk.Context, msg MsgUnfreeze, k Keeper) sdk.Result {
+	if err := k.Unfreeze(ctx, msg.From, msg.Symbol, msg.Amount); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: frozenTags(msg.From, msg.Symbol),
+	}
+}
+
+func handleMsgTransferOwnership(ctx sdk.Context, msg MsgTransferOwnership, k Keeper) sdk.Result {
+	token, err := k.TransferOwnership(ctx, msg.From, msg.Symbol, msg.NewOwner)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: tokenTags(token),
+	}
+}
+
+func tokenTags(token Token) sdk.Tags {
+	return sdk.NewTags(
+		TagSymbol, []byte(token.Symbol),
+		TagOwner, []byte(token.Owner.String()),
+	)
+}
+
+func frozenTags(addr sdk.AccAddress, symbol string) sdk.Tags {
+	return sdk.NewTags(
+		TagSymbol, []byte(symbol),
+		TagAccount, []byte(addr.String()),
+	)
+}
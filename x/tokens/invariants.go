@@ -0,0 +1,53 @@
+package tokens
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RegisterInvariants registers the invariants of the tokens module.
+func RegisterInvariants(ir sdk.InvariantRouter, k Keeper) {
+	ir.RegisterRoute("tokens", "total-supply", TotalSupplyInvariant(k))
+	ir.RegisterRoute("tokens", "frozen-coins", FrozenCoinsInvariant(k))
+}
+
+// TotalSupplyInvariant checks that the total supply of each token is at most
+// its max supply, and the one tracked by the supply keeper once the supply is
+// tracked.
+func TotalSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		tracked := sdk.IsUpgrade(sdk.SupplyTracking)
+		var err error
+		k.IterateTokens(ctx, func(token Token) bool {
+			if total := k.supplyKeeper.GetTotal(ctx, token.Symbol); tracked && total != token.TotalSupply {
+				err = fmt.Errorf("total supply of token %s %d != supply %d", token.Symbol, token.TotalSupply, total)
+			} else if token.TotalSupply > token.MaxSupply {
+				err = fmt.Errorf("total supply of token %s %d > max supply %d", token.Symbol, token.TotalSupply, token.MaxSupply)
+			}
+			return err != nil
+		})
+		return err
+	}
+}
+
+// FrozenCoinsInvariant checks that the module account holds the frozen coins
+// of all the accounts.
+func FrozenCoinsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) error {
+		var frozen sdk.Coins
+		iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), FrozenKeyPrefix)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var amount int64
+			k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &amount)
+			symbol := string(iterator.Key()[len(FrozenKeyPrefix)+sdk.AddrLen:])
+			frozen = frozen.Plus(sdk.Coins{sdk.NewCoin(symbol, amount)})
+		}
+
+		if coins := k.ck.GetCoins(ctx, FrozenCoinsAccAddr); !coins.IsEqual(frozen) {
+			return fmt.Errorf("coins of the frozen account %s != frozen coins %s", coins, frozen)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,267 @@
+package tokens
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+const (
+	// FrozenCoinsAccName is the module account holding the frozen coins.
+	FrozenCoinsAccName = "frozen"
+)
+
+var (
+	FrozenCoinsAccAddr = auth.NewModuleAddress(FrozenCoinsAccName)
+
+	TokenKeyPrefix  = []byte("token:")  // symbol -> Token
+	FrozenKeyPrefix = []byte("frozen:") // address, symbol -> the frozen amount
+)
+
+// GetTokenKey returns the key of the token symbol, the tokens are sorted by
+// symbol.
+func GetTokenKey(symbol string) []byte {
+	return append(append([]byte{}, TokenKeyPrefix...), symbol...)
+}
+
+// GetFrozenKey returns the key of the frozen coins symbol of addr.
+func GetFrozenKey(addr sdk.AccAddress, symbol string) []byte {
+	return append(GetFrozenCoinsKey(addr), symbol...)
+}
+
+// GetFrozenCoinsKey returns the prefix of the frozen coins of addr.
+func GetFrozenCoinsKey(addr sdk.AccAddress) []byte {
+	return append(append([]byte{}, FrozenKeyPrefix...), addr...)
+}
+
+// Keeper of the issued tokens, it reports the minted and burned coins to the
+// supply keeper. The frozen coins are held by the FrozenCoinsAccAddr module
+// account until they are unfrozen.
+type Keeper struct {
+	storeKey     sdk.StoreKey
+	cdc          *codec.Codec
+	ck           bank.Keeper
+	supplyKeeper supply.Keeper
+	codespace    sdk.CodespaceType
+}
+
+// NewKeeper returns a tokens keeper.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ck bank.Keeper, supplyKeeper supply.Keeper, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:     key,
+		cdc:          cdc,
+		ck:           ck,
+		supplyKeeper: supplyKeeper,
+		codespace:    codespace,
+	}
+}
+
+// GetToken returns the token symbol.
+func (k Keeper) GetToken(ctx sdk.Context, symbol string) (token Token, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetTokenKey(symbol))
+	if bz == nil {
+		return token, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
+	return token, true
+}
+
+func (k Keeper) setToken(ctx sdk.Context, token Token) {
+	ctx.KVStore(k.storeKey).Set(GetTokenKey(token.Symbol), k.cdc.MustMarshalBinaryLengthPrefixed(token))
+}
+
+// GetTokens returns up to limit tokens from offset, sorted by symbol.
+func (k Keeper) GetTokens(ctx sdk.Context, offset, limit int) []Token {
+	tokens := make([]Token, 0)
+	k.IterateTokens(ctx, func(token Token) bool {
+		if offset > 0 {
+			offset--
+			return false
+		}
+		tokens = append(tokens, token)
+		return len(tokens) >= limit
+	})
+	return tokens
+}
+
+// IterateTokens iterates over the tokens, sorted by symbol, until iter
+// returns true.
+func (k Keeper) IterateTokens(ctx sdk.Context, iter func(token Token) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), TokenKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var token Token
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &token)
+		if iter(token) {
+			return
+		}
+	}
+}
+
+// getOwnedToken returns the token symbol if it is owned by owner.
+func (k Keeper) getOwnedToken(ctx sdk.Context, owner sdk.AccAddress, symbol string) (Token, sdk.Error) {
+	token, found := k.GetToken(ctx, symbol)
+	if !found {
+		return token, ErrUnknownToken(k.codespace, symbol)
+	}
+	if !token.Owner.Equals(owner) {
+		return token, ErrNotTokenOwner(k.codespace, symbol)
+	}
+	return token, nil
+}
+
+// IssueToken registers the token of msg under the symbol returned by
+// TokenSymbol and credits its total supply to its owner.
+func (k Keeper) IssueToken(ctx sdk.Context, msg MsgIssue) (Token, sdk.Error) {
+	token := Token{
+		Name:        msg.Name,
+		Symbol:      TokenSymbol(msg.Symbol, msg.From, ctx.BlockHash()),
+		OrigSymbol:  msg.Symbol,
+		TotalSupply: msg.TotalSupply,
+		MaxSupply:   msg.MaxSupply,
+		Owner:       msg.From,
+		Mintable:    msg.Mintable,
+	}
+	if _, found := k.GetToken(ctx, token.Symbol); found {
+		return token, ErrDuplicatedSymbol(k.codespace, token.Symbol)
+	}
+	if err := k.mint(ctx, msg.From, sdk.NewCoin(token.Symbol, token.TotalSupply)); err != nil {
+		return token, err
+	}
+
+	k.setToken(ctx, token)
+	return token, nil
+}
+
+// MintToken mints amount of the mintable token symbol to its owner, up to its
+// max supply.
+func (k Keeper) MintToken(ctx sdk.Context, owner sdk.AccAddress, symbol string, amount int64) (Token, sdk.Error) {
+	token, err := k.getOwnedToken(ctx, owner, symbol)
+	if err != nil {
+		return token, err
+	}
+	if !token.Mintable {
+		return token, ErrTokenNotMintable(k.codespace, symbol)
+	}
+	if amount > token.MaxSupply-token.TotalSupply {
+		return token, ErrExceedsMaxSupply(k.codespace, symbol, token.MaxSupply)
+	}
+	if err := k.mint(ctx, owner, sdk.NewCoin(symbol, amount)); err != nil {
+		return token, err
+	}
+
+	token.TotalSupply += amount
+	k.setToken(ctx, token)
+	return token, nil
+}
+
+func (k Keeper) mint(ctx sdk.Context, to sdk.AccAddress, coin sdk.Coin) sdk.Error {
+	coins := sdk.Coins{coin}
+	if err := k.supplyKeeper.Mint(ctx, coins); err != nil {
+		return err
+	}
+	_, _, err := k.ck.AddCoins(ctx, to, coins)
+	return err
+}
+
+// BurnToken burns amount of the coins of the token symbol of its owner.
+func (k Keeper) BurnToken(ctx sdk.Context, owner sdk.AccAddress, symbol string, amount int64) (Token, sdk.Error) {
+	token, err := k.getOwnedToken(ctx, owner, symbol)
+	if err != nil {
+		return token, err
+	}
+	coins := sdk.Coins{sdk.NewCoin(symbol, amount)}
+	if _, _, err := k.ck.SubtractCoins(ctx, owner, coins); err != nil {
+		return token, err
+	}
+	if err := k.supplyKeeper.Burn(ctx, coins); err != nil {
+		return token, err
+	}
+
+	token.TotalSupply -= amount
+	k.setToken(ctx, token)
+	return token, nil
+}
+
+// TransferOwnership transfers the ownership of the token symbol from owner to
+// newOwner.
+func (k Keeper) TransferOwnership(ctx sdk.Context, owner sdk.AccAddress, symbol string, newOwner sdk.AccAddress) (Token, sdk.Error) {
+	token, err := k.getOwnedToken(ctx, owner, symbol)
+	if err != nil {
+		return token, err
+	}
+
+	token.Owner = newOwner
+	k.setToken(ctx, token)
+	return token, nil
+}
+
+// GetFrozen returns the frozen amount of the coins symbol of addr.
+func (k Keeper) GetFrozen(ctx sdk.Context, addr sdk.AccAddress, symbol string) int64 {
+	bz := ctx.KVStore(k.storeKey).Get(GetFrozenKey(addr, symbol))
+	if bz == nil {
+		return 0
+	}
+	var frozen int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &frozen)
+	return frozen
+}
+
+func (k Keeper) setFrozen(ctx sdk.Context, addr sdk.AccAddress, symbol string, frozen int64) {
+	store := ctx.KVStore(k.storeKey)
+	if frozen == 0 {
+		store.Delete(GetFrozenKey(addr, symbol))
+		return
+	}
+	store.Set(GetFrozenKey(addr, symbol), k.cdc.MustMarshalBinaryLengthPrefixed(frozen))
+}
+
+// GetFrozenCoins returns the frozen coins of addr.
+func (k Keeper) GetFrozenCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	prefix := GetFrozenCoinsKey(addr)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	var coins sdk.Coins
+	for ; iterator.Valid(); iterator.Next() {
+		var frozen int64
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &frozen)
+		coins = append(coins, sdk.NewCoin(string(iterator.Key()[len(prefix):]), frozen))
+	}
+	return coins
+}
+
+// Freeze freezes amount of the coins symbol of addr, symbol must be an issued
+// token or the native token.
+func (k Keeper) Freeze(ctx sdk.Context, addr sdk.AccAddress, symbol string, amount int64) sdk.Error {
+	if _, found := k.GetToken(ctx, symbol); !found && symbol != sdk.NativeTokenSymbol {
+		return ErrUnknownToken(k.codespace, symbol)
+	}
+	frozen := k.GetFrozen(ctx, addr, symbol)
+	if frozen+amount < frozen {
+		return ErrInvalidInput(k.codespace, fmt.Sprintf("frozen amount of %s overflows", symbol))
+	}
+	if _, err := k.ck.SendCoins(ctx, addr, FrozenCoinsAccAddr, sdk.Coins{sdk.NewCoin(symbol, amount)}); err != nil {
+		return err
+	}
+
+	k.setFrozen(ctx, addr, symbol, frozen+amount)
+	return nil
+}
+
+// Unfreeze unfreezes amount of the frozen coins symbol of addr.
+func (k Keeper) Unfreeze(ctx sdk.Context, addr sdk.AccAddress, symbol string, amount int64) sdk.Error {
+	frozen := k.GetFrozen(ctx, addr, symbol)
+	if frozen < amount {
+		return ErrInsufficientFrozen(k.codespace, symbol, frozen)
+	}
+	if _, err := k.ck.SendCoins(ctx, FrozenCoinsAccAddr, addr, sdk.Coins{sdk.NewCoin(symbol, amount)}); err != nil {
+		return err
+	}
+
+	k.setFrozen(ctx, addr, symbol, frozen-amount)
+	return nil
+}
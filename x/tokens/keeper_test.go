@@ -0,0 +1,178 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+)
+
+var (
+	addr1 = sdk.AccAddress([]byte("addr1_______________"))
+	addr2 = sdk.AccAddress([]byte("addr2_______________"))
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, bank.Keeper, supply.Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySupply := sdk.NewKVStoreKey("supply")
+	keyTokens := sdk.NewKVStoreKey("tokens")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyTokens, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	auth.RegisterBaseAccount(cdc)
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	header := abci.Header{Height: 10, Time: time.Unix(1000, 0)}
+	ctx := sdk.NewContext(ms, header, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(accountCache).WithEventManager(sdk.NewEventManager()).WithBlockHash([]byte("block hash"))
+
+	am := auth.NewAccountKeeper(cdc, keyAcc, auth.ProtoBaseAccount)
+	ck := bank.NewBaseKeeper(am)
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, am)
+	return ctx, NewKeeper(cdc, keyTokens, ck, supplyKeeper, DefaultCodespace), ck, supplyKeeper
+}
+
+func requireInvariants(t *testing.T, ctx sdk.Context, keeper Keeper) {
+	require.NoError(t, TotalSupplyInvariant(keeper)(ctx))
+	require.NoError(t, FrozenCoinsInvariant(keeper)(ctx))
+}
+
+func TestValidateIssue(t *testing.T) {
+	require.NoError(t, NewMsgIssue(addr1, "Token", "ABC", 100, 100, false).ValidateBasic())
+	require.NoError(t, NewMsgIssue(addr1, "Token", "ABC123", 100, 1000, true).ValidateBasic())
+
+	for _, msg := range []MsgIssue{
+		NewMsgIssue(addr1, "", "ABC", 100, 100, false),
+		NewMsgIssue(addr1, "Token", "abc", 100, 100, false),
+		NewMsgIssue(addr1, "Token", "A", 100, 100, false),
+		NewMsgIssue(addr1, "Token", "ABC-123", 100, 100, false),
+		NewMsgIssue(addr1, "Token", sdk.NativeTokenSymbol, 100, 100, false),
+		NewMsgIssue(addr1, "Token", "ABC", 0, 100, true),
+		NewMsgIssue(addr1, "Token", "ABC", 200, 100, true),
+		NewMsgIssue(addr1, "Token", "ABC", 100, sdk.TokenMaxTotalSupply+1, true),
+		NewMsgIssue(addr1, "Token", "ABC", 100, 1000, false),
+	} {
+		require.Error(t, msg.ValidateBasic())
+	}
+}
+
+func TestIssueMintBurn(t *testing.T) {
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SupplyTracking, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer sdk.UpgradeMgr.Reset()
+	ctx, keeper, ck, supplyKeeper := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	res := handler(ctx, NewMsgIssue(addr1, "Token", "ABC", 1000, 1500, true))
+	require.True(t, res.IsOK(), res.Log)
+	symbol := string(res.Data)
+	require.Equal(t, TokenSymbol("ABC", addr1, ctx.BlockHash()), symbol)
+	require.Len(t, symbol, len("ABC-")+SymbolSuffixLength)
+	require.Equal(t, int64(1000), ck.GetCoins(ctx, addr1).AmountOf(symbol))
+	require.Equal(t, int64(1000), supplyKeeper.GetTotal(ctx, symbol))
+	requireInvariants(t, ctx, keeper)
+
+	// the symbol of the same issuance in the same block is taken, another
+	// block gives another symbol
+	res = handler(ctx, NewMsgIssue(addr1, "Token", "ABC", 1000, 1500, true))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeDuplicatedSymbol), res.Code)
+	res = handler(ctx.WithBlockHash([]byte("next block hash")), NewMsgIssue(addr1, "Token", "ABC", 1000, 1000, false))
+	require.True(t, res.IsOK(), res.Log)
+	fixed := string(res.Data)
+	require.NotEqual(t, symbol, fixed)
+	require.Len(t, keeper.GetTokens(ctx, 0, 10), 2)
+	require.Len(t, keeper.GetTokens(ctx, 1, 10), 1)
+
+	// only the owner mints a mintable token, up to its max supply
+	res = handler(ctx, NewMsgMint(addr2, symbol, 100))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotTokenOwner), res.Code)
+	res = handler(ctx, NewMsgMint(addr1, fixed, 100))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeTokenNotMintable), res.Code)
+	res = handler(ctx, NewMsgMint(addr1, symbol, 501))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeExceedsMaxSupply), res.Code)
+	res = handler(ctx, NewMsgMint(addr1, symbol, 500))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, int64(1500), ck.GetCoins(ctx, addr1).AmountOf(symbol))
+	res = handler(ctx, NewMsgMint(addr1, "XYZ-000", 1))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownToken), res.Code)
+
+	// the owner burns its own coins, the burned coins may be minted again
+	require.False(t, handler(ctx, NewMsgBurn(addr1, symbol, 1501)).IsOK())
+	res = handler(ctx, NewMsgBurn(addr1, symbol, 300))
+	require.True(t, res.IsOK(), res.Log)
+	token, found := keeper.GetToken(ctx, symbol)
+	require.True(t, found)
+	require.Equal(t, int64(1200), token.TotalSupply)
+	require.Equal(t, int64(1200), supplyKeeper.GetTotal(ctx, symbol))
+	require.True(t, handler(ctx, NewMsgMint(addr1, symbol, 300)).IsOK())
+	requireInvariants(t, ctx, keeper)
+}
+
+func TestTransferOwnership(t *testing.T) {
+	ctx, keeper, _, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	res := handler(ctx, NewMsgIssue(addr1, "Token", "ABC", 1000, 1500, true))
+	require.True(t, res.IsOK(), res.Log)
+	symbol := string(res.Data)
+
+	res = handler(ctx, NewMsgTransferOwnership(addr2, symbol, addr2))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotTokenOwner), res.Code)
+	res = handler(ctx, NewMsgTransferOwnership(addr1, symbol, addr2))
+	require.True(t, res.IsOK(), res.Log)
+	token, _ := keeper.GetToken(ctx, symbol)
+	require.Equal(t, addr2, token.Owner)
+
+	// the new owner mints to itself, the previous one may not
+	res = handler(ctx, NewMsgMint(addr1, symbol, 100))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotTokenOwner), res.Code)
+	res = handler(ctx, NewMsgMint(addr2, symbol, 100))
+	require.True(t, res.IsOK(), res.Log)
+	requireInvariants(t, ctx, keeper)
+}
+
+func TestFreeze(t *testing.T) {
+	ctx, keeper, ck, _ := createTestInput(t)
+	handler := NewHandler(keeper)
+
+	res := handler(ctx, NewMsgIssue(addr1, "Token", "ABC", 1000, 1000, false))
+	require.True(t, res.IsOK(), res.Log)
+	symbol := string(res.Data)
+
+	res = handler(ctx, NewMsgFreeze(addr1, "XYZ-000", 100))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeUnknownToken), res.Code)
+	require.False(t, handler(ctx, NewMsgFreeze(addr1, symbol, 1001)).IsOK())
+	require.True(t, handler(ctx, NewMsgFreeze(addr1, symbol, 300)).IsOK())
+	require.True(t, handler(ctx, NewMsgFreeze(addr1, symbol, 200)).IsOK())
+	require.Equal(t, int64(500), keeper.GetFrozen(ctx, addr1, symbol))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(symbol, 500)}, keeper.GetFrozenCoins(ctx, addr1))
+	requireInvariants(t, ctx, keeper)
+
+	// the frozen coins are not transferable
+	_, err := ck.SendCoins(ctx, addr1, addr2, sdk.Coins{sdk.NewCoin(symbol, 501)})
+	require.Error(t, err)
+
+	res = handler(ctx, NewMsgUnfreeze(addr1, symbol, 501))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInsufficientFrozen), res.Code)
+	res = handler(ctx, NewMsgUnfreeze(addr2, symbol, 1))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInsufficientFrozen), res.Code)
+	require.True(t, handler(ctx, NewMsgUnfreeze(addr1, symbol, 500)).IsOK())
+	require.Equal(t, int64(1000), ck.GetCoins(ctx, addr1).AmountOf(symbol))
+	require.Empty(t, keeper.GetFrozenCoins(ctx, addr1))
+	requireInvariants(t, ctx, keeper)
+}
@@ -0,0 +1,300 @@
+package tokens
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const (
+	MsgRoute                 = "tokens"
+	TypeMsgIssue             = "issueMsg"
+	TypeMsgMint              = "mintMsg"
+	TypeMsgBurn              = "tokensBurn"
+	TypeMsgFreeze            = "tokensFreeze"
+	TypeMsgTransferOwnership = "transferOwnership"
+
+	// the unfreeze shares the fee of the freeze
+	TypeMsgUnfreeze = TypeMsgFreeze
+)
+
+// verify interface at compile time
+var (
+	_ sdk.Msg = MsgIssue{}
+	_ sdk.Msg = MsgMint{}
+	_ sdk.Msg = MsgBurn{}
+	_ sdk.Msg = MsgFreeze{}
+	_ sdk.Msg = MsgUnfreeze{}
+	_ sdk.Msg = MsgTransferOwnership{}
+)
+
+// MsgIssue issues the token Symbol owned by From, its TotalSupply is credited
+// to From. A mintable token may be minted up to MaxSupply, the max supply of
+// a token which is not is its total supply.
+type MsgIssue struct {
+	From        sdk.AccAddress `json:"from"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	TotalSupply int64          `json:"total_supply"`
+	MaxSupply   int64          `json:"max_supply"`
+	Mintable    bool           `json:"mintable"`
+}
+
+func NewMsgIssue(from sdk.AccAddress, name, symbol string, totalSupply, maxSupply int64, mintable bool) MsgIssue {
+	return MsgIssue{
+		From:        from,
+		Name:        name,
+		Symbol:      symbol,
+		TotalSupply: totalSupply,
+		MaxSupply:   maxSupply,
+		Mintable:    mintable,
+	}
+}
+
+// nolint
+func (msg MsgIssue) Route() string { return MsgRoute }
+func (msg MsgIssue) Type() string  { return TypeMsgIssue }
+func (msg MsgIssue) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgIssue) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgIssue) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.Name) == 0 || len(msg.Name) > MaxTokenNameLength {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("token name must be 1 to %d characters", MaxTokenNameLength))
+	}
+	if err := ValidateOrigSymbol(msg.Symbol); err != nil {
+		return err
+	}
+	if msg.TotalSupply <= 0 || msg.MaxSupply > sdk.TokenMaxTotalSupply || msg.TotalSupply > msg.MaxSupply {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("total supply must be positive and at most the max supply, which is at most %d", sdk.TokenMaxTotalSupply))
+	}
+	if !msg.Mintable && msg.MaxSupply != msg.TotalSupply {
+		return ErrInvalidInput(DefaultCodespace, "the max supply of a token which is not mintable must be its total supply")
+	}
+	return nil
+}
+
+func (msg MsgIssue) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// MsgMint mints Amount of the mintable token Symbol to its owner From.
+type MsgMint struct {
+	From   sdk.AccAddress `json:"from"`
+	Symbol string         `json:"symbol"`
+	Amount int64          `json:"amount"`
+}
+
+func NewMsgMint(from sdk.AccAddress, symbol string, amount int64) MsgMint {
+	return MsgMint{From: from, Symbol: symbol, Amount: amount}
+}
+
+// nolint
+func (msg MsgMint) Route() string { return MsgRoute }
+func (msg MsgMint) Type() string  { return TypeMsgMint }
+func (msg MsgMint) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgMint) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgMint) ValidateBasic() sdk.Error {
+	return validateAmountMsg(msg.From, msg.Symbol, msg.Amount)
+}
+
+func (msg MsgMint) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// MsgBurn burns Amount of the coins of the token Symbol of its owner From.
+type MsgBurn struct {
+	From   sdk.AccAddress `json:"from"`
+	Symbol string         `json:"symbol"`
+	Amount int64          `json:"amount"`
+}
+
+func NewMsgBurn(from sdk.AccAddress, symbol string, amount int64) MsgBurn {
+	return MsgBurn{From: from, Symbol: symbol, Amount: amount}
+}
+
+// nolint
+func (msg MsgBurn) Route() string { return MsgRoute }
+func (msg MsgBurn) Type() string  { return TypeMsgBurn }
+func (msg MsgBurn) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgBurn) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgBurn) ValidateBasic() sdk.Error {
+	return validateAmountMsg(msg.From, msg.Symbol, msg.Amount)
+}
+
+func (msg MsgBurn) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+// MsgFreeze freezes Amount of the coins Symbol of From, the frozen coins are
+// not transferable until they are unfrozen.
+type MsgFreeze struct {
+	From   sdk.AccAddress `json:"from"`
+	Symbol string         `json:"symbol"`
+	Amount int64          `json:"amount"`
+}
+
+func NewMsgFreeze(from sdk.AccAddress, symbol string, amount int64) MsgFreeze {
+	return MsgFreeze{From: from, Symbol: symbol, Amount: amount}
+}
+
+// nolint
+func (msg MsgFreeze) Route() string { return MsgRoute }
+func (msg MsgFreeze) Type() string  { return TypeMsgFreeze }
+func (msg MsgFreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgFreeze) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgFreeze) ValidateBasic() sdk.Error {
+	return validateAmountMsg(msg.From, msg.Symbol, msg.Amount)
+}
+
+func (msg MsgFreeze) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, FrozenCoinsAccAddr}
+}
+
+// MsgUnfreeze unfreezes Amount of the frozen coins Symbol of From.
+type MsgUnfreeze struct {
+	From   sdk.AccAddress `json:"from"`
+	Symbol string         `json:"symbol"`
+	Amount int64          `json:"amount"`
+}
+
+func NewMsgUnfreeze(from sdk.AccAddress, symbol string, amount int64) MsgUnfreeze {
+	return MsgUnfreeze{From: from, Symbol: symbol, Amount: amount}
+}
+
+// nolint
+func (msg MsgUnfreeze) Route() string { return MsgRoute }
+func (msg MsgUnfreeze) Type() string  { return TypeMsgUnfreeze }
+func (msg MsgUnfreeze) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgUnfreeze) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgUnfreeze) ValidateBasic() sdk.Error {
+	return validateAmountMsg(msg.From, msg.Symbol, msg.Amount)
+}
+
+func (msg MsgUnfreeze) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, FrozenCoinsAccAddr}
+}
+
+// MsgTransferOwnership transfers the ownership of the token Symbol from its
+// owner From to NewOwner.
+type MsgTransferOwnership struct {
+	From     sdk.AccAddress `json:"from"`
+	Symbol   string         `json:"symbol"`
+	NewOwner sdk.AccAddress `json:"new_owner"`
+}
+
+func NewMsgTransferOwnership(from sdk.AccAddress, symbol string, newOwner sdk.AccAddress) MsgTransferOwnership {
+	return MsgTransferOwnership{From: from, Symbol: symbol, NewOwner: newOwner}
+}
+
+// nolint
+func (msg MsgTransferOwnership) Route() string { return MsgRoute }
+func (msg MsgTransferOwnership) Type() string  { return TypeMsgTransferOwnership }
+func (msg MsgTransferOwnership) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgTransferOwnership) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgTransferOwnership) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.NewOwner) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.NewOwner)))
+	}
+	if msg.From.Equals(msg.NewOwner) {
+		return ErrInvalidInput(DefaultCodespace, "the new owner must not be the owner")
+	}
+	if len(msg.Symbol) == 0 {
+		return ErrInvalidSymbol(DefaultCodespace, "symbol is required")
+	}
+	return nil
+}
+
+func (msg MsgTransferOwnership) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, msg.NewOwner}
+}
+
+func validateAmountMsg(from sdk.AccAddress, symbol string, amount int64) sdk.Error {
+	if len(from) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(from)))
+	}
+	if len(symbol) == 0 {
+		return ErrInvalidSymbol(DefaultCodespace, "symbol is required")
+	}
+	if amount <= 0 {
+		return ErrInvalidInput(DefaultCodespace, "amount must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package tokens
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the tokens Querier
+const (
+	QueryToken  = "token"
+	QueryTokens = "tokens"
+	QueryFrozen = "frozen"
+
+	// DefaultLimit and MaxLimit bound the tokens listed by a query.
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Params for query 'custom/tokens/token'
+type QueryTokenParams struct {
+	Symbol string
+}
+
+// Params for query 'custom/tokens/tokens', up to Limit tokens from Offset,
+// sorted by symbol.
+type QueryTokensParams struct {
+	Offset int
+	Limit  int
+}
+
+// Params for query 'custom/tokens/frozen'
+type QueryFrozenParams struct {
+	Address sdk.AccAddress
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryToken:
+			var params QueryTokenParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			token, found := keeper.GetToken(ctx, params.Symbol)
+			if !found {
+				return nil, ErrUnknownToken(keeper.codespace, params.Symbol)
+			}
+			return marshalJSON(keeper.cdc, token)
+		case QueryTokens:
+			var params QueryTokensParams
+			if len(req.Data) != 0 {
+				if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+				}
+			}
+			if params.Offset < 0 {
+				return nil, sdk.ErrUnknownRequest("offset must not be negative")
+			}
+			if params.Limit <= 0 {
+				params.Limit = DefaultLimit
+			}
+			if params.Limit > MaxLimit {
+				params.Limit = MaxLimit
+			}
+			return marshalJSON(keeper.cdc, keeper.GetTokens(ctx, params.Offset, params.Limit))
+		case QueryFrozen:
+			var params QueryFrozenParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			frozen := keeper.GetFrozenCoins(ctx, params.Address)
+			if frozen == nil {
+				frozen = sdk.Coins{}
+			}
+			return marshalJSON(keeper.cdc, frozen)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown tokens query endpoint")
+		}
+	}
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,67 @@
+package tokens
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// MaxTokenNameLength bounds the name of a token.
+	MaxTokenNameLength = 32
+	// SymbolSuffixLength is the number of hex characters of the suffix
+	// appended to the symbol of an issued token, see TokenSymbol.
+	SymbolSuffixLength = 3
+)
+
+// the symbols given at issuance, the issued symbols add a suffix to them
+var origSymbolRegexp = regexp.MustCompile(`^[A-Z0-9]{2,8}$`)
+
+// Token is an asset issued by Owner. Its total supply changes by mint up to
+// MaxSupply and by burn.
+type Token struct {
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	OrigSymbol  string         `json:"original_symbol"`
+	TotalSupply int64          `json:"total_supply"`
+	MaxSupply   int64          `json:"max_supply"`
+	Owner       sdk.AccAddress `json:"owner"`
+	Mintable    bool           `json:"mintable"`
+}
+
+func (token Token) String() string {
+	return fmt.Sprintf(`Token %s:
+  Name:         %s
+  Owner:        %s
+  Total Supply: %d
+  Max Supply:   %d
+  Mintable:     %t`, token.Symbol, token.Name, token.Owner, token.TotalSupply, token.MaxSupply, token.Mintable)
+}
+
+// ValidateOrigSymbol checks the symbol of a token to issue: 2 to 8 upper case
+// letters or digits, other than the native token.
+func ValidateOrigSymbol(symbol string) sdk.Error {
+	if !origSymbolRegexp.MatchString(symbol) {
+		return ErrInvalidSymbol(DefaultCodespace, fmt.Sprintf("symbol %q must be 2 to 8 upper case letters or digits", symbol))
+	}
+	if symbol == sdk.NativeTokenSymbol {
+		return ErrInvalidSymbol(DefaultCodespace, fmt.Sprintf("symbol %s is the native token", symbol))
+	}
+	return nil
+}
+
+// TokenSymbol returns the symbol of the token origSymbol issued by owner in
+// the block of hash blockHash: origSymbol and a suffix of SymbolSuffixLength
+// hex characters, so that the same symbol may be issued many times.
+func TokenSymbol(origSymbol string, owner sdk.AccAddress, blockHash []byte) string {
+	hash := sha256.New()
+	hash.Write(blockHash)
+	hash.Write(owner)
+	hash.Write([]byte(origSymbol))
+	suffix := strings.ToUpper(hex.EncodeToString(hash.Sum(nil))[:SymbolSuffixLength])
+	return origSymbol + "-" + suffix
+}
@@ -0,0 +1,44 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	EventTypeUpgradeApplied = "upgrade_applied"
+
+	TagName = "name"
+)
+
+// BeginBlocker halts the chain once the scheduled plan is due, unless the
+// binary has the handler of the plan: the handler then runs and the plan is
+// cleared. A binary having the handler of a plan that is not due yet halts
+// too, it was started too early.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+
+	_, hasHandler := k.handlers[plan.Name]
+	if !plan.ShouldExecute(ctx) {
+		if hasHandler {
+			msg := fmt.Sprintf("BINARY UPDATED BEFORE TRIGGER! UPGRADE %q scheduled at %s", plan.Name, plan.DueAt())
+			ctx.Logger().Error(msg)
+			panic(msg)
+		}
+		return
+	}
+
+	if !hasHandler {
+		msg := fmt.Sprintf("UPGRADE %q NEEDED at %s: %s", plan.Name, plan.DueAt(), plan.Info)
+		ctx.Logger().Error(msg)
+		panic(msg)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("applying upgrade %q at height %d", plan.Name, ctx.BlockHeight()))
+	k.ApplyUpgrade(ctx, plan)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(EventTypeUpgradeApplied, sdk.NewAttribute(TagName, plan.Name)))
+}
@@ -0,0 +1,118 @@
+package upgrade
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	PlanKey       = []byte{0x00} // key of the scheduled upgrade plan
+	DonePrefixKey = []byte{0x01} // prefix of the heights of the applied upgrades
+)
+
+// GetDoneKey returns the key of the height at which the upgrade name was
+// applied.
+func GetDoneKey(name string) []byte {
+	return append(DonePrefixKey, []byte(name)...)
+}
+
+// Handler migrates the state of the chain for an upgrade, it is registered by
+// the binary implementing the upgrade and runs once the plan is due.
+type Handler func(ctx sdk.Context, plan Plan)
+
+// Keeper stores the scheduled upgrade plan, at most one at a time, the
+// applied upgrades and the consensus versions of the module stores.
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+
+	handlers   map[string]Handler
+	versions   map[string]uint64
+	migrations map[string]map[uint64]MigrationHandler
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey) Keeper {
+	return Keeper{
+		storeKey:   key,
+		cdc:        cdc,
+		handlers:   make(map[string]Handler),
+		versions:   make(map[string]uint64),
+		migrations: make(map[string]map[uint64]MigrationHandler),
+	}
+}
+
+// SetUpgradeHandler registers the handler of the upgrade name. A binary
+// registering the handler of a plan is able to run the chain past the plan.
+func (k Keeper) SetUpgradeHandler(name string, handler Handler) {
+	k.handlers[name] = handler
+}
+
+// ScheduleUpgrade replaces the scheduled plan, if any, by plan. The plan must
+// be due in the future and not already applied.
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if plan.ShouldExecute(ctx) {
+		return fmt.Errorf("upgrade plan %s is due in the past, current height: %d", plan.DueAt(), ctx.BlockHeight())
+	}
+	if height := k.GetDoneHeight(ctx, plan.Name); height != 0 {
+		return fmt.Errorf("upgrade %s was already applied at height %d", plan.Name, height)
+	}
+
+	ctx.KVStore(k.storeKey).Set(PlanKey, k.cdc.MustMarshalBinaryLengthPrefixed(plan))
+	return nil
+}
+
+// GetUpgradePlan returns the scheduled plan, if any.
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(PlanKey)
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan removes the scheduled plan, if any.
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	ctx.KVStore(k.storeKey).Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which the upgrade name was applied, zero
+// if it was not.
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) int64 {
+	bz := ctx.KVStore(k.storeKey).Get(GetDoneKey(name))
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+func (k Keeper) setDone(ctx sdk.Context, name string) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(ctx.BlockHeight()))
+	ctx.KVStore(k.storeKey).Set(GetDoneKey(name), bz)
+}
+
+// ApplyUpgrade runs the handler of plan and the migrations of the module
+// stores, records the upgrade as applied and clears the plan. A failed
+// migration halts the chain.
+func (k Keeper) ApplyUpgrade(ctx sdk.Context, plan Plan) {
+	handler, ok := k.handlers[plan.Name]
+	if !ok {
+		panic(fmt.Sprintf("no handler for upgrade %s", plan.Name))
+	}
+	handler(ctx, plan)
+	if _, err := k.RunMigrations(ctx); err != nil {
+		msg := fmt.Sprintf("UPGRADE %q FAILED: %v", plan.Name, err)
+		ctx.Logger().Error(msg)
+		panic(msg)
+	}
+
+	k.ClearUpgradePlan(ctx)
+	k.setDone(ctx, plan.Name)
+}
@@ -0,0 +1,169 @@
+package upgrade
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
+	keyAcc := sdk.NewKVStoreKey("acc")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyUpgrade, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	accountCache := auth.NewAccountCache(auth.NewAccountStoreCache(cdc, ms.GetKVStore(keyAcc), 10))
+	header := abci.Header{Height: 10, Time: time.Unix(1000, 0)}
+	ctx := sdk.NewContext(ms, header, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	return ctx, NewKeeper(cdc, keyUpgrade)
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	require.Error(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test"}))
+	require.Error(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 20, Time: time.Unix(2000, 0)}))
+	// the plans due in the past are rejected
+	require.Error(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 10}))
+	require.Error(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Time: time.Unix(1000, 0)}))
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	require.NoError(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 20}))
+	// a new plan replaces the scheduled one
+	plan := Plan{Name: "test2", Time: time.Unix(2000, 0).UTC(), Info: "v2"}
+	require.NoError(t, keeper.ScheduleUpgrade(ctx, plan))
+	scheduled, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, scheduled)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlocker(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	plan := Plan{Name: "test", Height: 20, Info: "v2"}
+	require.NoError(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	// nothing happens until the plan is due
+	require.NotPanics(t, func() { BeginBlocker(ctx.WithBlockHeight(19), keeper) })
+	// the old binary halts the chain at the plan height
+	require.Panics(t, func() { BeginBlocker(ctx.WithBlockHeight(20), keeper) })
+
+	// the new binary halts before the plan height
+	var applied int64
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) { applied = ctx.BlockHeight() })
+	require.Panics(t, func() { BeginBlocker(ctx.WithBlockHeight(19), keeper) })
+	require.Equal(t, int64(0), applied)
+
+	// and applies the plan at the plan height
+	ctx = ctx.WithBlockHeight(20)
+	require.NotPanics(t, func() { BeginBlocker(ctx, keeper) })
+	require.Equal(t, int64(20), applied)
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	require.Equal(t, int64(20), keeper.GetDoneHeight(ctx, "test"))
+
+	// an applied upgrade can't be scheduled again
+	require.Error(t, keeper.ScheduleUpgrade(ctx.WithBlockHeight(21), Plan{Name: "test", Height: 30}))
+	require.NotPanics(t, func() { BeginBlocker(ctx.WithBlockHeight(21), keeper) })
+}
+
+func TestRunMigrations(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	var ran []MigrationStep
+	migration := func(module string, from uint64) MigrationHandler {
+		return func(ctx sdk.Context) error {
+			ran = append(ran, MigrationStep{module, from})
+			return nil
+		}
+	}
+	keeper.SetModuleVersion("stake", 1)
+	keeper.SetModuleVersion("gov", 2)
+	keeper.RegisterMigration("gov", 1, migration("gov", 1))
+	require.Panics(t, func() { keeper.RegisterMigration("gov", 1, migration("gov", 1)) })
+
+	// the stores without a version are at version 1
+	require.Equal(t, uint64(1), keeper.GetStoredVersion(ctx, "gov"))
+	expected := []MigrationStep{{"gov", 1}}
+	pending, err := keeper.PendingMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, pending)
+
+	// the dry run leaves the state as is
+	steps, err := keeper.DryRunMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, steps)
+	require.Equal(t, expected, ran)
+	require.Equal(t, uint64(1), keeper.GetStoredVersion(ctx, "gov"))
+
+	ran = nil
+	steps, err = keeper.RunMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, steps)
+	require.Equal(t, expected, ran)
+	require.Equal(t, uint64(2), keeper.GetStoredVersion(ctx, "gov"))
+	pending, err = keeper.PendingMigrations(ctx)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	// the steps run in order of module and version, a missing step aborts
+	ran = nil
+	keeper.SetModuleVersion("stake", 3)
+	keeper.SetModuleVersion("gov", 3)
+	keeper.RegisterMigration("stake", 1, migration("stake", 1))
+	keeper.RegisterMigration("gov", 2, migration("gov", 2))
+	_, err = keeper.RunMigrations(ctx)
+	require.Error(t, err)
+	require.Empty(t, ran)
+	keeper.RegisterMigration("stake", 2, migration("stake", 2))
+	steps, err = keeper.RunMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationStep{{"gov", 2}, {"stake", 1}, {"stake", 2}}, steps)
+	require.Equal(t, steps, ran)
+
+	// a binary older than the stores can't run them
+	keeper.SetModuleVersion("gov", 2)
+	_, err = keeper.PendingMigrations(ctx)
+	require.Error(t, err)
+}
+
+func TestApplyUpgradeMigrations(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	keeper.InitModuleVersions(ctx)
+	require.NoError(t, keeper.ScheduleUpgrade(ctx, Plan{Name: "test", Height: 20}))
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) {})
+
+	// the upgraded binary migrates the stores in the apply step
+	keeper.SetModuleVersion("gov", 2)
+	keeper.RegisterMigration("gov", 1, func(ctx sdk.Context) error {
+		return errors.New("invalid store")
+	})
+	cacheCtx, _ := ctx.CacheContext()
+	require.Panics(t, func() { BeginBlocker(cacheCtx.WithBlockHeight(20), keeper) })
+
+	keeper = NewKeeper(codec.New(), keeper.storeKey)
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) {})
+	keeper.SetModuleVersion("gov", 2)
+	keeper.RegisterMigration("gov", 1, func(ctx sdk.Context) error { return nil })
+	require.NotPanics(t, func() { BeginBlocker(ctx.WithBlockHeight(20), keeper) })
+	require.Equal(t, uint64(2), keeper.GetStoredVersion(ctx, "gov"))
+	require.Equal(t, int64(20), keeper.GetDoneHeight(ctx, "test"))
+}
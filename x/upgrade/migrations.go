@@ -0,0 +1,132 @@
+package upgrade
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VersionPrefixKey is the prefix of the consensus versions of the module
+// stores.
+var VersionPrefixKey = []byte{0x02}
+
+// GetVersionKey returns the key of the consensus version of the store of
+// module.
+func GetVersionKey(module string) []byte {
+	return append(VersionPrefixKey, []byte(module)...)
+}
+
+// MigrationHandler migrates the store of a module from a consensus version to
+// the next one.
+type MigrationHandler func(ctx sdk.Context) error
+
+// MigrationStep is the migration of the store of Module from FromVersion to
+// the next version.
+type MigrationStep struct {
+	Module      string `json:"module"`
+	FromVersion uint64 `json:"from_version"`
+}
+
+func (s MigrationStep) String() string {
+	return fmt.Sprintf("%s: version %d to %d", s.Module, s.FromVersion, s.FromVersion+1)
+}
+
+// SetModuleVersion declares the consensus version of the store of module
+// implemented by the binary. The stores without a version are at version 1.
+func (k Keeper) SetModuleVersion(module string, version uint64) {
+	if version == 0 {
+		panic(fmt.Sprintf("consensus version of %s must be positive", module))
+	}
+	k.versions[module] = version
+}
+
+// RegisterMigration registers the handler migrating the store of module from
+// fromVersion to fromVersion+1.
+func (k Keeper) RegisterMigration(module string, fromVersion uint64, handler MigrationHandler) {
+	if k.migrations[module] == nil {
+		k.migrations[module] = make(map[uint64]MigrationHandler)
+	}
+	if _, ok := k.migrations[module][fromVersion]; ok {
+		panic(fmt.Sprintf("migration of %s from version %d already registered", module, fromVersion))
+	}
+	k.migrations[module][fromVersion] = handler
+}
+
+// GetStoredVersion returns the consensus version of the store of module, 1 if
+// it was not recorded.
+func (k Keeper) GetStoredVersion(ctx sdk.Context, module string) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(GetVersionKey(module))
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k Keeper) setStoredVersion(ctx sdk.Context, module string, version uint64) {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, version)
+	ctx.KVStore(k.storeKey).Set(GetVersionKey(module), bz)
+}
+
+// InitModuleVersions records the consensus versions of the binary at genesis,
+// the stores initialized by the binary need no migration.
+func (k Keeper) InitModuleVersions(ctx sdk.Context) {
+	for module, version := range k.versions {
+		k.setStoredVersion(ctx, module, version)
+	}
+}
+
+// PendingMigrations returns the migrations from the stored versions to the
+// versions of the binary, ordered by module and version. A store ahead of the
+// binary or a missing migration step is an error.
+func (k Keeper) PendingMigrations(ctx sdk.Context) ([]MigrationStep, error) {
+	modules := make([]string, 0, len(k.versions))
+	for module := range k.versions {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	var steps []MigrationStep
+	for _, module := range modules {
+		stored, version := k.GetStoredVersion(ctx, module), k.versions[module]
+		if stored > version {
+			return nil, fmt.Errorf("store of %s is at version %d, ahead of the version %d of the binary", module, stored, version)
+		}
+		for from := stored; from < version; from++ {
+			if _, ok := k.migrations[module][from]; !ok {
+				return nil, fmt.Errorf("no migration of %s from version %d", module, from)
+			}
+			steps = append(steps, MigrationStep{module, from})
+		}
+	}
+	return steps, nil
+}
+
+// RunMigrations migrates the stores to the versions of the binary, the stored
+// version of a module is updated once all its steps ran. The state of ctx is
+// partially migrated if an error is returned.
+func (k Keeper) RunMigrations(ctx sdk.Context) ([]MigrationStep, error) {
+	steps, err := k.PendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range steps {
+		ctx.Logger().Info(fmt.Sprintf("migrating the store of %s", step))
+		if err := k.migrations[step.Module][step.FromVersion](ctx); err != nil {
+			return steps[:i], fmt.Errorf("migration of %s failed: %v", step, err)
+		}
+		if i == len(steps)-1 || steps[i+1].Module != step.Module {
+			k.setStoredVersion(ctx, step.Module, step.FromVersion+1)
+		}
+	}
+	return steps, nil
+}
+
+// DryRunMigrations runs the pending migrations on a cache of the state of
+// ctx, which is never written, and returns the steps which ran.
+func (k Keeper) DryRunMigrations(ctx sdk.Context) ([]MigrationStep, error) {
+	cacheCtx, _ := ctx.CacheContext()
+	return k.RunMigrations(cacheCtx)
+}
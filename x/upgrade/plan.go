@@ -0,0 +1,60 @@
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan schedules the upgrade Name at Height, or at Time if Height is zero.
+// Info is shown to the operators when the chain halts, e.g. the release of
+// the new binary.
+type Plan struct {
+	Name   string    `json:"name"`
+	Time   time.Time `json:"time"`
+	Height int64     `json:"height"`
+	Info   string    `json:"info"`
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf(`Upgrade Plan
+  Name:   %s
+  %s
+  Info:   %s`, p.Name, p.DueAt(), p.Info)
+}
+
+// ValidateBasic checks the plan without the state, exactly one of the height
+// and the time must be set.
+func (p Plan) ValidateBasic() error {
+	if len(p.Name) == 0 {
+		return errors.New("upgrade plan name cannot be empty")
+	}
+	if p.Height < 0 {
+		return errors.New("upgrade plan height cannot be negative")
+	}
+	if p.Height == 0 && p.Time.IsZero() {
+		return errors.New("upgrade plan must set a height or a time")
+	}
+	if p.Height != 0 && !p.Time.IsZero() {
+		return errors.New("upgrade plan cannot set both a height and a time")
+	}
+	return nil
+}
+
+// ShouldExecute returns whether the plan is due at the block of ctx.
+func (p Plan) ShouldExecute(ctx sdk.Context) bool {
+	if p.Height > 0 {
+		return p.Height <= ctx.BlockHeight()
+	}
+	return !p.Time.After(ctx.BlockHeader().Time)
+}
+
+// DueAt returns the height or the time of the plan, for the messages.
+func (p Plan) DueAt() string {
+	if p.Height > 0 {
+		return fmt.Sprintf("height: %d", p.Height)
+	}
+	return fmt.Sprintf("time: %s", p.Time.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,59 @@
+package upgrade
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// Since the ScheduledUpgrade upgrade, the description of a
+// ProposalTypeSoftwareUpgrade proposal is the JSON encoding of the Plan it
+// schedules once passed. It was a free text before.
+func getPlan(proposal gov.Proposal) (Plan, error) {
+	var plan Plan
+	if err := json.Unmarshal([]byte(proposal.GetDescription()), &plan); err != nil {
+		return plan, err
+	}
+	return plan, plan.ValidateBasic()
+}
+
+var _ gov.GovHooks = SoftwareUpgradeHooks{}
+
+// SoftwareUpgradeHooks rejects the software upgrade proposals whose plan
+// could not be scheduled at submission time.
+type SoftwareUpgradeHooks struct {
+	k Keeper
+}
+
+func NewSoftwareUpgradeHooks(k Keeper) SoftwareUpgradeHooks {
+	return SoftwareUpgradeHooks{k}
+}
+
+// Implements gov.GovHooks.
+func (hooks SoftwareUpgradeHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if !sdk.IsUpgrade(sdk.ScheduledUpgrade) {
+		return nil
+	}
+	plan, err := getPlan(proposal)
+	if err != nil {
+		return err
+	}
+	cacheCtx, _ := ctx.CacheContext()
+	return hooks.k.ScheduleUpgrade(cacheCtx, plan)
+}
+
+// NewSoftwareUpgradeProposalHandler returns the gov handler scheduling the
+// plans of the passed software upgrade proposals, replacing the scheduled one.
+func NewSoftwareUpgradeProposalHandler(k Keeper) gov.ProposalHandler {
+	return func(ctx sdk.Context, proposal gov.Proposal) error {
+		if !sdk.IsUpgrade(sdk.ScheduledUpgrade) {
+			return nil
+		}
+		plan, err := getPlan(proposal)
+		if err != nil {
+			return err
+		}
+		return k.ScheduleUpgrade(ctx, plan)
+	}
+}
@@ -0,0 +1,48 @@
+package upgrade
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// query endpoints supported by the upgrade Querier
+const (
+	QueryPlan    = "plan"
+	QueryApplied = "applied"
+)
+
+// Params for query 'custom/upgrade/applied'
+type QueryAppliedParams struct {
+	Name string
+}
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryPlan:
+			plan, found := keeper.GetUpgradePlan(ctx)
+			if !found {
+				return nil, nil
+			}
+			return marshalJSON(keeper.cdc, plan)
+		case QueryApplied:
+			var params QueryAppliedParams
+			if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return marshalJSON(keeper.cdc, keeper.GetDoneHeight(ctx, params.Name))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown upgrade query endpoint")
+		}
+	}
+}
+
+func marshalJSON(cdc *codec.Codec, o interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, o)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}